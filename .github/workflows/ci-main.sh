@@ -84,6 +84,14 @@ for file in `find . -name go.mod`; do
         fi
     fi
 
+    # package http3 needs golang >= v1.22
+    if [ "http3" = $(basename $dirpath) ]; then
+        if ! go version|grep -qE "go1.2[2-9]|go1.[3-9][0-9]"; then
+          echo "ignore http3 as go version: $(go version)"
+          continue 1
+        fi
+    fi
+
     cd $dirpath
     go mod tidy
     go build ./...
@@ -81,6 +81,7 @@ func GetCommand(ctx context.Context) (*Command, error) {
 		cmd.Fix,
 		cmd.Run,
 		cmd.Gen,
+		cmd.Migrate,
 		cmd.Tpl,
 		cmd.Init,
 		cmd.Pack,
@@ -0,0 +1,205 @@
+// Copyright GoFrame gf Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gtag"
+
+	"github.com/gogf/gf/cmd/gf/v2/internal/utility/mlog"
+)
+
+var (
+	Migrate = cMigrate{}
+)
+
+type cMigrate struct {
+	g.Meta `name:"migrate" brief:"{cMigrateBrief}" dc:"{cMigrateDc}"`
+}
+
+const (
+	cMigrateBrief = `versioned database schema migration commands`
+	cMigrateDc    = `
+The "migrate" command applies or reverts the versioned SQL migrations in folder,
+which are named like "20240102150405_create_user.up.sql" and "20240102150405_create_user.down.sql".
+The applied versions are stored in table "schema_migrations" of the database.
+`
+	cMigrateUpBrief     = `apply the pending migrations`
+	cMigrateDownBrief   = `revert the applied migrations`
+	cMigrateStatusBrief = `show the status of migrations`
+	cMigrateEg          = `
+gf migrate up
+gf migrate up -p ./manifest/migrations -l "mysql:root:12345678@tcp(127.0.0.1:3306)/test"
+gf migrate up --dryRun
+gf migrate down -n 2
+gf migrate status
+`
+	cMigrateBriefPath   = `folder of the migration SQL files, default is:manifest/migrations`
+	cMigrateBriefLink   = `database configuration, the same as the ORM configuration of GoFrame`
+	cMigrateBriefGroup  = `database configuration group name, which is used if link is empty`
+	cMigrateBriefTable  = `table of the applied versions, default is:schema_migrations`
+	cMigrateBriefDryRun = `print the migrations and their SQL without applying them`
+)
+
+type (
+	cMigrateUpInput struct {
+		g.Meta `name:"up" config:"gfcli.migrate" brief:"{cMigrateUpBrief}" eg:"{cMigrateEg}"`
+		Path   string `name:"path"   short:"p" brief:"{cMigrateBriefPath}"   d:"manifest/migrations"`
+		Link   string `name:"link"   short:"l" brief:"{cMigrateBriefLink}"`
+		Group  string `name:"group"  short:"g" brief:"{cMigrateBriefGroup}"  d:"default"`
+		Table  string `name:"table"  short:"t" brief:"{cMigrateBriefTable}"`
+		DryRun bool   `name:"dryRun" short:"r" brief:"{cMigrateBriefDryRun}" orphan:"true"`
+		Steps  int    `name:"steps"  short:"n" brief:"max number of migrations to apply, all if 0" d:"0"`
+	}
+	cMigrateUpOutput  struct{}
+	cMigrateDownInput struct {
+		g.Meta `name:"down" config:"gfcli.migrate" brief:"{cMigrateDownBrief}" eg:"{cMigrateEg}"`
+		Path   string `name:"path"   short:"p" brief:"{cMigrateBriefPath}"   d:"manifest/migrations"`
+		Link   string `name:"link"   short:"l" brief:"{cMigrateBriefLink}"`
+		Group  string `name:"group"  short:"g" brief:"{cMigrateBriefGroup}"  d:"default"`
+		Table  string `name:"table"  short:"t" brief:"{cMigrateBriefTable}"`
+		DryRun bool   `name:"dryRun" short:"r" brief:"{cMigrateBriefDryRun}" orphan:"true"`
+		Steps  int    `name:"steps"  short:"n" brief:"number of migrations to revert, all if less than 0" d:"1"`
+	}
+	cMigrateDownOutput  struct{}
+	cMigrateStatusInput struct {
+		g.Meta `name:"status" config:"gfcli.migrate" brief:"{cMigrateStatusBrief}" eg:"{cMigrateEg}"`
+		Path   string `name:"path"   short:"p" brief:"{cMigrateBriefPath}"   d:"manifest/migrations"`
+		Link   string `name:"link"   short:"l" brief:"{cMigrateBriefLink}"`
+		Group  string `name:"group"  short:"g" brief:"{cMigrateBriefGroup}"  d:"default"`
+		Table  string `name:"table"  short:"t" brief:"{cMigrateBriefTable}"`
+		DryRun bool   `name:"dryRun" short:"r" brief:"{cMigrateBriefDryRun}" orphan:"true"`
+	}
+	cMigrateStatusOutput struct{}
+)
+
+func init() {
+	gtag.Sets(g.MapStrStr{
+		`cMigrateBrief`:       cMigrateBrief,
+		`cMigrateDc`:          cMigrateDc,
+		`cMigrateUpBrief`:     cMigrateUpBrief,
+		`cMigrateDownBrief`:   cMigrateDownBrief,
+		`cMigrateStatusBrief`: cMigrateStatusBrief,
+		`cMigrateEg`:          cMigrateEg,
+		`cMigrateBriefPath`:   cMigrateBriefPath,
+		`cMigrateBriefLink`:   cMigrateBriefLink,
+		`cMigrateBriefGroup`:  cMigrateBriefGroup,
+		`cMigrateBriefTable`:  cMigrateBriefTable,
+		`cMigrateBriefDryRun`: cMigrateBriefDryRun,
+	})
+}
+
+func (c cMigrate) Up(ctx context.Context, in cMigrateUpInput) (out *cMigrateUpOutput, err error) {
+	migrator, err := c.newMigrator(in.Path, in.Link, in.Group, in.Table, in.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	migrated, err := migrator.Up(ctx, in.Steps)
+	if err != nil {
+		return nil, err
+	}
+	c.printMigrated("up", migrated, in.DryRun)
+	return
+}
+
+func (c cMigrate) Down(ctx context.Context, in cMigrateDownInput) (out *cMigrateDownOutput, err error) {
+	migrator, err := c.newMigrator(in.Path, in.Link, in.Group, in.Table, in.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	migrated, err := migrator.Down(ctx, in.Steps)
+	if err != nil {
+		return nil, err
+	}
+	c.printMigrated("down", migrated, in.DryRun)
+	return
+}
+
+func (c cMigrate) Status(ctx context.Context, in cMigrateStatusInput) (out *cMigrateStatusOutput, err error) {
+	migrator, err := c.newMigrator(in.Path, in.Link, in.Group, in.Table, in.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		buffer = bytes.NewBuffer(nil)
+		array  = make([][]string, 0, len(statuses))
+	)
+	for _, status := range statuses {
+		applied := "pending"
+		if status.Applied {
+			applied = "applied"
+		}
+		array = append(array, []string{gconv.String(status.Version), status.Name, applied, status.AppliedAt})
+	}
+	tw := tablewriter.NewWriter(buffer)
+	tw.SetHeader([]string{"VERSION", "NAME", "STATUS", "APPLIED AT"})
+	tw.AppendBulk(array)
+	tw.Render()
+	mlog.Print(buffer.String())
+	return
+}
+
+// newMigrator creates and returns the migrator of SQL files in `path`.
+func (c cMigrate) newMigrator(path, link, group, table string, dryRun bool) (*gdb.Migrator, error) {
+	if !gfile.IsDir(path) {
+		return nil, gerror.Newf(`migration path "%s" does not exist`, path)
+	}
+	var (
+		db  gdb.DB
+		err error
+	)
+	// It uses user passed database configuration.
+	if link != "" {
+		var tempGroup = gtime.TimestampNanoStr()
+		gdb.AddConfigNode(tempGroup, gdb.ConfigNode{
+			Link: link,
+		})
+		if db, err = gdb.Instance(tempGroup); err != nil {
+			return nil, gerror.Wrap(err, `database initialization failed`)
+		}
+	} else {
+		db = g.DB(group)
+	}
+	migrator := gdb.NewMigrator(db, gdb.MigratorOption{
+		Table:  table,
+		DryRun: dryRun,
+	})
+	if err = migrator.AddPath(path); err != nil {
+		return nil, err
+	}
+	return migrator, nil
+}
+
+// printMigrated prints the applied or reverted migrations.
+func (c cMigrate) printMigrated(direction string, migrated []gdb.Migration, dryRun bool) {
+	if len(migrated) == 0 {
+		mlog.Print("no migration to " + direction)
+		return
+	}
+	for _, migration := range migrated {
+		if dryRun {
+			mlog.Printf("[dry-run] migrate %s: %d_%s", direction, migration.Version, migration.Name)
+		} else {
+			mlog.Printf("migrate %s: %d_%s", direction, migration.Version, migration.Name)
+		}
+	}
+	mlog.Print("done!")
+}
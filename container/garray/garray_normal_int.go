@@ -856,3 +856,13 @@ func (a *IntArray) DeepCopy() interface{} {
 	copy(newSlice, a.array)
 	return NewIntArrayFrom(newSlice, a.mu.IsSafe())
 }
+
+// TArray returns a generic array of type TArray[int], which is a copy of current array.
+// It is used for migrating to generic array without interface{} boxing.
+func (a *IntArray) TArray() *TArray[int] {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	newSlice := make([]int, len(a.array))
+	copy(newSlice, a.array)
+	return NewTArrayFrom(newSlice, a.mu.IsSafe())
+}
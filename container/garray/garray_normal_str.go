@@ -855,3 +855,13 @@ func (a *StrArray) DeepCopy() interface{} {
 	copy(newSlice, a.array)
 	return NewStrArrayFrom(newSlice, a.mu.IsSafe())
 }
+
+// TArray returns a generic array of type TArray[string], which is a copy of current array.
+// It is used for migrating to generic array without interface{} boxing.
+func (a *StrArray) TArray() *TArray[string] {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	newSlice := make([]string, len(a.array))
+	copy(newSlice, a.array)
+	return NewTArrayFrom(newSlice, a.mu.IsSafe())
+}
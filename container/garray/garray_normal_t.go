@@ -0,0 +1,540 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package garray
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/internal/rwmutex"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// TArray is a golang generic array with rich features, which stores its elements
+// in type `T` and needs no boxing and type assertion for element accessing.
+// It contains a concurrent-safe/unsafe switch, which should be set
+// when its initialization and cannot be changed then.
+type TArray[T comparable] struct {
+	mu       rwmutex.RWMutex
+	array    []T
+	snapshot []T // Shared readonly copy of array for Snapshot, which is reset when array changes.
+}
+
+// NewTArray creates and returns an empty array.
+// The parameter `safe` is used to specify whether using array in concurrent-safety,
+// which is false in default.
+func NewTArray[T comparable](safe ...bool) *TArray[T] {
+	return NewTArraySize[T](0, 0, safe...)
+}
+
+// NewTArraySize create and returns an array with given size and cap.
+// The parameter `safe` is used to specify whether using array in concurrent-safety,
+// which is false in default.
+func NewTArraySize[T comparable](size int, cap int, safe ...bool) *TArray[T] {
+	return &TArray[T]{
+		mu:    rwmutex.Create(safe...),
+		array: make([]T, size, cap),
+	}
+}
+
+// NewTArrayFrom creates and returns an array with given slice `array`.
+// The parameter `safe` is used to specify whether using array in concurrent-safety,
+// which is false in default.
+func NewTArrayFrom[T comparable](array []T, safe ...bool) *TArray[T] {
+	return &TArray[T]{
+		mu:    rwmutex.Create(safe...),
+		array: array,
+	}
+}
+
+// NewTArrayFromCopy creates and returns an array from a copy of given slice `array`.
+// The parameter `safe` is used to specify whether using array in concurrent-safety,
+// which is false in default.
+func NewTArrayFromCopy[T comparable](array []T, safe ...bool) *TArray[T] {
+	newArray := make([]T, len(array))
+	copy(newArray, array)
+	return &TArray[T]{
+		mu:    rwmutex.Create(safe...),
+		array: newArray,
+	}
+}
+
+// At returns the value by the specified index.
+// If the given `index` is out of range of the array, it returns the zero value of `T`.
+func (a *TArray[T]) At(index int) (value T) {
+	value, _ = a.Get(index)
+	return
+}
+
+// Get returns the value by the specified index.
+// If the given `index` is out of range of the array, the `found` is false.
+func (a *TArray[T]) Get(index int) (value T, found bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if index < 0 || index >= len(a.array) {
+		return
+	}
+	return a.array[index], true
+}
+
+// Set sets value to specified index.
+func (a *TArray[T]) Set(index int, value T) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	if index < 0 || index >= len(a.array) {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, "index %d out of array range %d", index, len(a.array))
+	}
+	a.array[index] = value
+	return nil
+}
+
+// SetArray sets the underlying slice array with the given `array`.
+func (a *TArray[T]) SetArray(array []T) *TArray[T] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	a.array = array
+	return a
+}
+
+// SortFunc sorts the array by custom function `less`.
+func (a *TArray[T]) SortFunc(less func(v1, v2 T) bool) *TArray[T] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	sort.Slice(a.array, func(i, j int) bool {
+		return less(a.array[i], a.array[j])
+	})
+	return a
+}
+
+// InsertBefore inserts the `values` to the front of `index`.
+func (a *TArray[T]) InsertBefore(index int, values ...T) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	if index < 0 || index >= len(a.array) {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, "index %d out of array range %d", index, len(a.array))
+	}
+	rear := append([]T{}, a.array[index:]...)
+	a.array = append(a.array[0:index], values...)
+	a.array = append(a.array, rear...)
+	return nil
+}
+
+// InsertAfter inserts the `values` to the back of `index`.
+func (a *TArray[T]) InsertAfter(index int, values ...T) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	if index < 0 || index >= len(a.array) {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, "index %d out of array range %d", index, len(a.array))
+	}
+	rear := append([]T{}, a.array[index+1:]...)
+	a.array = append(a.array[0:index+1], values...)
+	a.array = append(a.array, rear...)
+	return nil
+}
+
+// Remove removes an item by index.
+// If the given `index` is out of range of the array, the `found` is false.
+func (a *TArray[T]) Remove(index int) (value T, found bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	return a.doRemoveWithoutLock(index)
+}
+
+// doRemoveWithoutLock removes an item by index without lock.
+func (a *TArray[T]) doRemoveWithoutLock(index int) (value T, found bool) {
+	if index < 0 || index >= len(a.array) {
+		return
+	}
+	value = a.array[index]
+	a.array = append(a.array[:index], a.array[index+1:]...)
+	return value, true
+}
+
+// RemoveValue removes an item by value.
+// It returns true if value is found in the array, or else false if not found.
+func (a *TArray[T]) RemoveValue(value T) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	if i := a.doSearchWithoutLock(value); i != -1 {
+		_, found := a.doRemoveWithoutLock(i)
+		return found
+	}
+	return false
+}
+
+// RemoveValues removes multiple items by `values`.
+func (a *TArray[T]) RemoveValues(values ...T) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	for _, value := range values {
+		if i := a.doSearchWithoutLock(value); i != -1 {
+			a.doRemoveWithoutLock(i)
+		}
+	}
+}
+
+// PushLeft pushes one or multiple items to the beginning of array.
+func (a *TArray[T]) PushLeft(value ...T) *TArray[T] {
+	a.mu.Lock()
+	a.snapshot = nil
+	a.array = append(value, a.array...)
+	a.mu.Unlock()
+	return a
+}
+
+// PushRight pushes one or multiple items to the end of array.
+// It equals to Append.
+func (a *TArray[T]) PushRight(value ...T) *TArray[T] {
+	a.mu.Lock()
+	a.snapshot = nil
+	a.array = append(a.array, value...)
+	a.mu.Unlock()
+	return a
+}
+
+// PopLeft pops and returns an item from the beginning of array.
+// Note that if the array is empty, the `found` is false.
+func (a *TArray[T]) PopLeft() (value T, found bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	if len(a.array) == 0 {
+		return
+	}
+	value = a.array[0]
+	a.array = a.array[1:]
+	return value, true
+}
+
+// PopRight pops and returns an item from the end of array.
+// Note that if the array is empty, the `found` is false.
+func (a *TArray[T]) PopRight() (value T, found bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	index := len(a.array) - 1
+	if index < 0 {
+		return
+	}
+	value = a.array[index]
+	a.array = a.array[:index]
+	return value, true
+}
+
+// PopRand randomly pops and return an item out of array.
+// Note that if the array is empty, the `found` is false.
+func (a *TArray[T]) PopRand() (value T, found bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	if len(a.array) == 0 {
+		return
+	}
+	return a.doRemoveWithoutLock(grand.Intn(len(a.array)))
+}
+
+// Append is alias of PushRight, please See PushRight.
+func (a *TArray[T]) Append(value ...T) *TArray[T] {
+	return a.PushRight(value...)
+}
+
+// Len returns the length of array.
+func (a *TArray[T]) Len() int {
+	a.mu.RLock()
+	length := len(a.array)
+	a.mu.RUnlock()
+	return length
+}
+
+// Slice returns the underlying data of array.
+// Note that, if it's in concurrent-safe usage, it returns a copy of underlying data,
+// or else a pointer to the underlying data.
+func (a *TArray[T]) Slice() []T {
+	if a.mu.IsSafe() {
+		a.mu.RLock()
+		defer a.mu.RUnlock()
+		array := make([]T, len(a.array))
+		copy(array, a.array)
+		return array
+	}
+	return a.array
+}
+
+// Snapshot returns a readonly copy of current array, with which the caller can iterate
+// the array for a long time without holding the lock, so that writers are not stalled.
+//
+// In concurrent-safety usage, the copy is made only once and shared by all the callers
+// until the array changes, so the returned slice must not be modified.
+func (a *TArray[T]) Snapshot() []T {
+	if !a.mu.IsSafe() {
+		return a.Slice()
+	}
+	a.mu.RLock()
+	snapshot := a.snapshot
+	a.mu.RUnlock()
+	if snapshot != nil {
+		return snapshot
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.snapshot == nil {
+		a.snapshot = make([]T, len(a.array))
+		copy(a.snapshot, a.array)
+	}
+	return a.snapshot
+}
+
+// Interfaces returns current array as []interface{}.
+func (a *TArray[T]) Interfaces() []interface{} {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	array := make([]interface{}, len(a.array))
+	for k, v := range a.array {
+		array[k] = v
+	}
+	return array
+}
+
+// Clone returns a new array, which is a copy of current array.
+func (a *TArray[T]) Clone() (newArray *TArray[T]) {
+	a.mu.RLock()
+	array := make([]T, len(a.array))
+	copy(array, a.array)
+	a.mu.RUnlock()
+	return NewTArrayFrom(array, a.mu.IsSafe())
+}
+
+// Clear deletes all items of current array.
+func (a *TArray[T]) Clear() *TArray[T] {
+	a.mu.Lock()
+	a.snapshot = nil
+	if len(a.array) > 0 {
+		a.array = make([]T, 0)
+	}
+	a.mu.Unlock()
+	return a
+}
+
+// Contains checks whether a value exists in the array.
+func (a *TArray[T]) Contains(value T) bool {
+	return a.Search(value) != -1
+}
+
+// Search searches array by `value`, returns the index of `value`,
+// or returns -1 if not exists.
+func (a *TArray[T]) Search(value T) int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.doSearchWithoutLock(value)
+}
+
+func (a *TArray[T]) doSearchWithoutLock(value T) int {
+	for index, v := range a.array {
+		if v == value {
+			return index
+		}
+	}
+	return -1
+}
+
+// Unique uniques the array, clear repeated items.
+// Example: [1,1,2,3,2] -> [1,2,3]
+func (a *TArray[T]) Unique() *TArray[T] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	if len(a.array) == 0 {
+		return a
+	}
+	var (
+		uniqueSet   = make(map[T]struct{})
+		uniqueArray = make([]T, 0, len(a.array))
+	)
+	for _, v := range a.array {
+		if _, ok := uniqueSet[v]; ok {
+			continue
+		}
+		uniqueSet[v] = struct{}{}
+		uniqueArray = append(uniqueArray, v)
+	}
+	a.array = uniqueArray
+	return a
+}
+
+// LockFunc locks writing by callback function `f`.
+func (a *TArray[T]) LockFunc(f func(array []T)) *TArray[T] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	f(a.array)
+	return a
+}
+
+// RLockFunc locks reading by callback function `f`.
+func (a *TArray[T]) RLockFunc(f func(array []T)) *TArray[T] {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	f(a.array)
+	return a
+}
+
+// Reverse makes array with elements in reverse order.
+func (a *TArray[T]) Reverse() *TArray[T] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	for i, j := 0, len(a.array)-1; i < j; i, j = i+1, j-1 {
+		a.array[i], a.array[j] = a.array[j], a.array[i]
+	}
+	return a
+}
+
+// CountValues counts the number of occurrences of all values in the array.
+func (a *TArray[T]) CountValues() map[T]int {
+	m := make(map[T]int)
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for _, v := range a.array {
+		m[v]++
+	}
+	return m
+}
+
+// Iterator is alias of IteratorAsc.
+func (a *TArray[T]) Iterator(f func(k int, v T) bool) {
+	a.IteratorAsc(f)
+}
+
+// IteratorAsc iterates the array readonly in ascending order with given callback function `f`.
+// If `f` returns true, then it continues iterating; or false to stop.
+func (a *TArray[T]) IteratorAsc(f func(k int, v T) bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for k, v := range a.array {
+		if !f(k, v) {
+			break
+		}
+	}
+}
+
+// IteratorDesc iterates the array readonly in descending order with given callback function `f`.
+// If `f` returns true, then it continues iterating; or false to stop.
+func (a *TArray[T]) IteratorDesc(f func(k int, v T) bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for i := len(a.array) - 1; i >= 0; i-- {
+		if !f(i, a.array[i]) {
+			break
+		}
+	}
+}
+
+// Filter iterates array and filters elements using custom callback function.
+// It removes the element from array if callback function `filter` returns true,
+// it or else does nothing and continues iterating.
+func (a *TArray[T]) Filter(filter func(index int, value T) bool) *TArray[T] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	for i := 0; i < len(a.array); {
+		if filter(i, a.array[i]) {
+			a.array = append(a.array[:i], a.array[i+1:]...)
+		} else {
+			i++
+		}
+	}
+	return a
+}
+
+// Walk applies a user supplied function `f` to every item of array.
+func (a *TArray[T]) Walk(f func(value T) T) *TArray[T] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	for i, v := range a.array {
+		a.array[i] = f(v)
+	}
+	return a
+}
+
+// IsEmpty checks whether the array is empty.
+func (a *TArray[T]) IsEmpty() bool {
+	return a.Len() == 0
+}
+
+// String returns current array as a string, which implements like json.Marshal does.
+func (a *TArray[T]) String() string {
+	if a == nil {
+		return ""
+	}
+	array := a.Snapshot()
+	b, err := json.Marshal(array)
+	if err != nil {
+		return fmt.Sprint(array)
+	}
+	return string(b)
+}
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal.
+func (a *TArray[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.Snapshot())
+}
+
+// UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal.
+func (a *TArray[T]) UnmarshalJSON(b []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	if a.array == nil {
+		a.array = make([]T, 0)
+	}
+	return json.UnmarshalUseNumber(b, &a.array)
+}
+
+// DeepCopy implements interface for deep copy of current type.
+func (a *TArray[T]) DeepCopy() interface{} {
+	if a == nil {
+		return nil
+	}
+	return a.Clone()
+}
+
+// StrArray converts and returns current array as a StrArray, which is a copy of current array.
+// It is used for compatibility with the APIs using StrArray.
+func (a *TArray[T]) StrArray() *StrArray {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	newSlice := make([]string, len(a.array))
+	for i, v := range a.array {
+		newSlice[i] = gconv.String(v)
+	}
+	return NewStrArrayFrom(newSlice, a.mu.IsSafe())
+}
+
+// IntArray converts and returns current array as an IntArray, which is a copy of current array.
+// It is used for compatibility with the APIs using IntArray.
+func (a *TArray[T]) IntArray() *IntArray {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	newSlice := make([]int, len(a.array))
+	for i, v := range a.array {
+		newSlice[i] = gconv.Int(v)
+	}
+	return NewIntArrayFrom(newSlice, a.mu.IsSafe())
+}
@@ -0,0 +1,456 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package garray
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/internal/rwmutex"
+)
+
+// SortedTArray is a golang generic sorted array with rich features.
+// It is using increasing order in default, which can be changed by
+// setting it a custom comparator.
+// It contains a concurrent-safe/unsafe switch, which should be set
+// when its initialization and cannot be changed then.
+type SortedTArray[T any] struct {
+	mu         rwmutex.RWMutex
+	array      []T
+	unique     bool             // Whether enable unique feature(false)
+	comparator func(a, b T) int // Comparison function(it returns -1: a < b; 0: a == b; 1: a > b)
+	snapshot   []T              // Shared readonly copy of array for Snapshot, which is reset when array changes.
+}
+
+// NewSortedTArray creates and returns an empty sorted array.
+// The parameter `safe` is used to specify whether using array in concurrent-safety, which is false in default.
+// The parameter `comparator` used to compare values to sort in array,
+// if it returns value < 0, means `a` < `b`; the `a` will be inserted before `b`;
+// if it returns value = 0, means `a` = `b`; the `a` will be replaced by `b`;
+// if it returns value > 0, means `a` > `b`; the `a` will be inserted after `b`;
+func NewSortedTArray[T any](comparator func(a, b T) int, safe ...bool) *SortedTArray[T] {
+	return NewSortedTArraySize(0, comparator, safe...)
+}
+
+// NewSortedTArraySize create and returns an sorted array with given size and cap.
+// The parameter `safe` is used to specify whether using array in concurrent-safety,
+// which is false in default.
+func NewSortedTArraySize[T any](cap int, comparator func(a, b T) int, safe ...bool) *SortedTArray[T] {
+	return &SortedTArray[T]{
+		mu:         rwmutex.Create(safe...),
+		array:      make([]T, 0, cap),
+		comparator: comparator,
+	}
+}
+
+// NewSortedTArrayFrom creates and returns an sorted array with given slice `array`.
+// The parameter `safe` is used to specify whether using array in concurrent-safety,
+// which is false in default.
+func NewSortedTArrayFrom[T any](array []T, comparator func(a, b T) int, safe ...bool) *SortedTArray[T] {
+	a := NewSortedTArraySize(0, comparator, safe...)
+	a.array = array
+	sort.Slice(a.array, func(i, j int) bool {
+		return a.comparator(a.array[i], a.array[j]) < 0
+	})
+	return a
+}
+
+// NewSortedTArrayFromCopy creates and returns an sorted array from a copy of given slice `array`.
+// The parameter `safe` is used to specify whether using array in concurrent-safety,
+// which is false in default.
+func NewSortedTArrayFromCopy[T any](array []T, comparator func(a, b T) int, safe ...bool) *SortedTArray[T] {
+	newArray := make([]T, len(array))
+	copy(newArray, array)
+	return NewSortedTArrayFrom(newArray, comparator, safe...)
+}
+
+// At returns the value by the specified index.
+// If the given `index` is out of range of the array, it returns the zero value of `T`.
+func (a *SortedTArray[T]) At(index int) (value T) {
+	value, _ = a.Get(index)
+	return
+}
+
+// Get returns the value by the specified index.
+// If the given `index` is out of range of the array, the `found` is false.
+func (a *SortedTArray[T]) Get(index int) (value T, found bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if index < 0 || index >= len(a.array) {
+		return
+	}
+	return a.array[index], true
+}
+
+// SetArray sets the underlying slice array with the given `array`.
+func (a *SortedTArray[T]) SetArray(array []T) *SortedTArray[T] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	a.array = array
+	sort.Slice(a.array, func(i, j int) bool {
+		return a.comparator(a.array[i], a.array[j]) < 0
+	})
+	return a
+}
+
+// Add adds one or multiple values to sorted array, the array always keeps sorted.
+// It's alias of function Append, see Append.
+func (a *SortedTArray[T]) Add(values ...T) *SortedTArray[T] {
+	return a.Append(values...)
+}
+
+// Append adds one or multiple values to sorted array, the array always keeps sorted.
+func (a *SortedTArray[T]) Append(values ...T) *SortedTArray[T] {
+	if len(values) == 0 {
+		return a
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	for _, value := range values {
+		index, cmp := a.binSearch(value, false)
+		if a.unique && cmp == 0 {
+			continue
+		}
+		if index < 0 {
+			a.array = append(a.array, value)
+			continue
+		}
+		if cmp > 0 {
+			index++
+		}
+		a.array = append(a.array[:index], append([]T{value}, a.array[index:]...)...)
+	}
+	return a
+}
+
+// Remove removes an item by index.
+// If the given `index` is out of range of the array, the `found` is false.
+func (a *SortedTArray[T]) Remove(index int) (value T, found bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	return a.doRemoveWithoutLock(index)
+}
+
+// doRemoveWithoutLock removes an item by index without lock.
+func (a *SortedTArray[T]) doRemoveWithoutLock(index int) (value T, found bool) {
+	if index < 0 || index >= len(a.array) {
+		return
+	}
+	value = a.array[index]
+	a.array = append(a.array[:index], a.array[index+1:]...)
+	return value, true
+}
+
+// RemoveValue removes an item by value.
+// It returns true if value is found in the array, or else false if not found.
+func (a *SortedTArray[T]) RemoveValue(value T) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	if i, r := a.binSearch(value, false); r == 0 {
+		_, found := a.doRemoveWithoutLock(i)
+		return found
+	}
+	return false
+}
+
+// PopLeft pops and returns an item from the beginning of array.
+// Note that if the array is empty, the `found` is false.
+func (a *SortedTArray[T]) PopLeft() (value T, found bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	if len(a.array) == 0 {
+		return
+	}
+	value = a.array[0]
+	a.array = a.array[1:]
+	return value, true
+}
+
+// PopRight pops and returns an item from the end of array.
+// Note that if the array is empty, the `found` is false.
+func (a *SortedTArray[T]) PopRight() (value T, found bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	index := len(a.array) - 1
+	if index < 0 {
+		return
+	}
+	value = a.array[index]
+	a.array = a.array[:index]
+	return value, true
+}
+
+// Len returns the length of array.
+func (a *SortedTArray[T]) Len() int {
+	a.mu.RLock()
+	length := len(a.array)
+	a.mu.RUnlock()
+	return length
+}
+
+// Slice returns the underlying data of array.
+// Note that, if it's in concurrent-safe usage, it returns a copy of underlying data,
+// or else a pointer to the underlying data.
+func (a *SortedTArray[T]) Slice() []T {
+	if a.mu.IsSafe() {
+		a.mu.RLock()
+		defer a.mu.RUnlock()
+		array := make([]T, len(a.array))
+		copy(array, a.array)
+		return array
+	}
+	return a.array
+}
+
+// Snapshot returns a readonly copy of current array, with which the caller can iterate
+// the array for a long time without holding the lock, so that writers are not stalled.
+//
+// In concurrent-safety usage, the copy is made only once and shared by all the callers
+// until the array changes, so the returned slice must not be modified.
+func (a *SortedTArray[T]) Snapshot() []T {
+	if !a.mu.IsSafe() {
+		return a.Slice()
+	}
+	a.mu.RLock()
+	snapshot := a.snapshot
+	a.mu.RUnlock()
+	if snapshot != nil {
+		return snapshot
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.snapshot == nil {
+		a.snapshot = make([]T, len(a.array))
+		copy(a.snapshot, a.array)
+	}
+	return a.snapshot
+}
+
+// Interfaces returns current array as []interface{}.
+func (a *SortedTArray[T]) Interfaces() []interface{} {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	array := make([]interface{}, len(a.array))
+	for k, v := range a.array {
+		array[k] = v
+	}
+	return array
+}
+
+// Contains checks whether a value exists in the array.
+func (a *SortedTArray[T]) Contains(value T) bool {
+	return a.Search(value) != -1
+}
+
+// Search searches array by `value`, returns the index of `value`,
+// or returns -1 if not exists.
+func (a *SortedTArray[T]) Search(value T) (index int) {
+	if i, r := a.binSearch(value, true); r == 0 {
+		return i
+	}
+	return -1
+}
+
+// Binary search.
+// It returns the last compared index and the result.
+// If `result` equals to 0, it means the value at `index` is equals to `value`.
+// If `result` lesser than 0, it means the value at `index` is lesser than `value`.
+// If `result` greater than 0, it means the value at `index` is greater than `value`.
+func (a *SortedTArray[T]) binSearch(value T, lock bool) (index int, result int) {
+	if lock {
+		a.mu.RLock()
+		defer a.mu.RUnlock()
+	}
+	if len(a.array) == 0 {
+		return -1, -2
+	}
+	min := 0
+	max := len(a.array) - 1
+	mid := 0
+	cmp := -2
+	for min <= max {
+		mid = min + (max-min)/2
+		cmp = a.comparator(value, a.array[mid])
+		switch {
+		case cmp < 0:
+			max = mid - 1
+		case cmp > 0:
+			min = mid + 1
+		default:
+			return mid, cmp
+		}
+	}
+	return mid, cmp
+}
+
+// SetUnique sets unique mark to the array,
+// which means it does not contain any repeated items.
+// It also does unique check, remove all repeated items.
+func (a *SortedTArray[T]) SetUnique(unique bool) *SortedTArray[T] {
+	oldUnique := a.unique
+	a.unique = unique
+	if unique && oldUnique != unique {
+		a.Unique()
+	}
+	return a
+}
+
+// Unique uniques the array, clear repeated items.
+func (a *SortedTArray[T]) Unique() *SortedTArray[T] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	if len(a.array) == 0 {
+		return a
+	}
+	i := 0
+	for {
+		if i == len(a.array)-1 {
+			break
+		}
+		if a.comparator(a.array[i], a.array[i+1]) == 0 {
+			a.array = append(a.array[:i+1], a.array[i+1+1:]...)
+		} else {
+			i++
+		}
+	}
+	return a
+}
+
+// Clone returns a new array, which is a copy of current array.
+func (a *SortedTArray[T]) Clone() (newArray *SortedTArray[T]) {
+	a.mu.RLock()
+	array := make([]T, len(a.array))
+	copy(array, a.array)
+	a.mu.RUnlock()
+	newArray = NewSortedTArrayFrom(array, a.comparator, a.mu.IsSafe())
+	newArray.unique = a.unique
+	return
+}
+
+// Clear deletes all items of current array.
+func (a *SortedTArray[T]) Clear() *SortedTArray[T] {
+	a.mu.Lock()
+	a.snapshot = nil
+	if len(a.array) > 0 {
+		a.array = make([]T, 0)
+	}
+	a.mu.Unlock()
+	return a
+}
+
+// RLockFunc locks reading by callback function `f`.
+func (a *SortedTArray[T]) RLockFunc(f func(array []T)) *SortedTArray[T] {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	f(a.array)
+	return a
+}
+
+// Iterator is alias of IteratorAsc.
+func (a *SortedTArray[T]) Iterator(f func(k int, v T) bool) {
+	a.IteratorAsc(f)
+}
+
+// IteratorAsc iterates the array readonly in ascending order with given callback function `f`.
+// If `f` returns true, then it continues iterating; or false to stop.
+func (a *SortedTArray[T]) IteratorAsc(f func(k int, v T) bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for k, v := range a.array {
+		if !f(k, v) {
+			break
+		}
+	}
+}
+
+// IteratorDesc iterates the array readonly in descending order with given callback function `f`.
+// If `f` returns true, then it continues iterating; or false to stop.
+func (a *SortedTArray[T]) IteratorDesc(f func(k int, v T) bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for i := len(a.array) - 1; i >= 0; i-- {
+		if !f(i, a.array[i]) {
+			break
+		}
+	}
+}
+
+// Filter iterates array and filters elements using custom callback function.
+// It removes the element from array if callback function `filter` returns true,
+// it or else does nothing and continues iterating.
+func (a *SortedTArray[T]) Filter(filter func(index int, value T) bool) *SortedTArray[T] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	for i := 0; i < len(a.array); {
+		if filter(i, a.array[i]) {
+			a.array = append(a.array[:i], a.array[i+1:]...)
+		} else {
+			i++
+		}
+	}
+	return a
+}
+
+// IsEmpty checks whether the array is empty.
+func (a *SortedTArray[T]) IsEmpty() bool {
+	return a.Len() == 0
+}
+
+// String returns current array as a string, which implements like json.Marshal does.
+func (a *SortedTArray[T]) String() string {
+	if a == nil {
+		return ""
+	}
+	array := a.Snapshot()
+	b, err := json.Marshal(array)
+	if err != nil {
+		return fmt.Sprint(array)
+	}
+	return string(b)
+}
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal.
+func (a *SortedTArray[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.Snapshot())
+}
+
+// UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal.
+// Note that the comparator should be set before unmarshalling.
+func (a *SortedTArray[T]) UnmarshalJSON(b []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snapshot = nil
+	if a.array == nil {
+		a.array = make([]T, 0)
+	}
+	if err := json.UnmarshalUseNumber(b, &a.array); err != nil {
+		return err
+	}
+	if a.comparator != nil {
+		sort.Slice(a.array, func(i, j int) bool {
+			return a.comparator(a.array[i], a.array[j]) < 0
+		})
+	}
+	return nil
+}
+
+// DeepCopy implements interface for deep copy of current type.
+func (a *SortedTArray[T]) DeepCopy() interface{} {
+	if a == nil {
+		return nil
+	}
+	return a.Clone()
+}
@@ -0,0 +1,146 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// go test *.go
+
+package garray_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_TArray_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		array := garray.NewTArrayFrom([]int{0, 1, 2, 3}, true)
+		t.Assert(array.Slice(), []int{0, 1, 2, 3})
+		t.Assert(array.At(0), 0)
+		t.Assert(array.At(9), 0)
+		t.Assert(array.Len(), 4)
+		t.Assert(array.Search(2), 2)
+		t.Assert(array.Search(9), -1)
+		t.Assert(array.Contains(3), true)
+		t.AssertNil(array.Set(0, 100))
+		t.AssertNE(array.Set(9, 100), nil)
+		t.Assert(array.At(0), 100)
+
+		v, found := array.Remove(0)
+		t.Assert(v, 100)
+		t.Assert(found, true)
+		_, found = array.Remove(9)
+		t.Assert(found, false)
+		t.Assert(array.Slice(), []int{1, 2, 3})
+
+		t.AssertNil(array.InsertBefore(0, 0))
+		t.AssertNil(array.InsertAfter(3, 4))
+		t.Assert(array.Slice(), []int{0, 1, 2, 3, 4})
+
+		array.PushLeft(-1).PushRight(5).Append(5)
+		t.Assert(array.Slice(), []int{-1, 0, 1, 2, 3, 4, 5, 5})
+		v, _ = array.PopLeft()
+		t.Assert(v, -1)
+		v, _ = array.PopRight()
+		t.Assert(v, 5)
+		t.Assert(array.RemoveValue(5), true)
+		t.Assert(array.RemoveValue(5), false)
+		array.RemoveValues(0, 1)
+		t.Assert(array.Slice(), []int{2, 3, 4})
+
+		array.Append(2, 3).Unique()
+		t.Assert(array.Slice(), []int{2, 3, 4})
+		t.Assert(array.Reverse().Slice(), []int{4, 3, 2})
+		t.Assert(array.SortFunc(func(v1, v2 int) bool { return v1 < v2 }).Slice(), []int{2, 3, 4})
+		t.Assert(array.Clone().Slice(), array.Slice())
+		t.Assert(array.Clear().Len(), 0)
+		t.Assert(array.IsEmpty(), true)
+	})
+}
+
+func Test_TArray_Struct(t *testing.T) {
+	type User struct {
+		Id   int
+		Name string
+	}
+	gtest.C(t, func(t *gtest.T) {
+		array := garray.NewTArray[User]()
+		array.Append(User{1, "john"}, User{2, "smith"})
+		t.Assert(array.Contains(User{1, "john"}), true)
+		t.Assert(array.Search(User{2, "smith"}), 1)
+		t.Assert(array.String(), `[{"Id":1,"Name":"john"},{"Id":2,"Name":"smith"}]`)
+
+		var names []string
+		array.Iterator(func(k int, v User) bool {
+			names = append(names, v.Name)
+			return true
+		})
+		t.Assert(names, []string{"john", "smith"})
+		array.Filter(func(index int, value User) bool {
+			return value.Id == 1
+		})
+		t.Assert(array.Len(), 1)
+	})
+}
+
+func Test_TArray_Json(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		array := garray.NewTArrayFrom([]string{"a", "b"})
+		b, err := json.Marshal(array)
+		t.AssertNil(err)
+		t.Assert(b, `["a","b"]`)
+
+		array2 := garray.NewTArray[string]()
+		t.AssertNil(json.Unmarshal(b, array2))
+		t.Assert(array2.Slice(), []string{"a", "b"})
+	})
+}
+
+func Test_TArray_Compatibility(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		strArray := garray.NewStrArrayFrom([]string{"1", "2"})
+		t.Assert(strArray.TArray().Slice(), []string{"1", "2"})
+		t.Assert(strArray.TArray().IntArray().Slice(), []int{1, 2})
+
+		intArray := garray.NewIntArrayFrom([]int{1, 2})
+		t.Assert(intArray.TArray().Slice(), []int{1, 2})
+		t.Assert(intArray.TArray().StrArray().Slice(), []string{"1", "2"})
+	})
+}
+
+func Test_TArray_Snapshot(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		a := garray.NewTArrayFrom([]int{1, 2, 3}, true)
+		s1 := a.Snapshot()
+		s2 := a.Snapshot()
+		t.Assert(s1, []int{1, 2, 3})
+		t.Assert(&s1[0] == &s2[0], true)
+
+		// Writing does not hold the snapshot iteration, and resets the snapshot.
+		for _, v := range s1 {
+			a.Append(v * 10)
+		}
+		t.Assert(s1, []int{1, 2, 3})
+		t.Assert(a.Snapshot(), []int{1, 2, 3, 10, 20, 30})
+		t.Assert(a.String(), `[1,2,3,10,20,30]`)
+
+		// In-place changes of the array do not leak into the taken snapshot.
+		s3 := a.Snapshot()
+		t.AssertNil(a.Set(0, 100))
+		a.Reverse()
+		t.Assert(s3, []int{1, 2, 3, 10, 20, 30})
+		t.Assert(a.Snapshot(), []int{30, 20, 10, 3, 2, 100})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		a := garray.NewSortedTArrayFrom([]int{3, 1, 2}, func(a, b int) int { return a - b }, true)
+		s := a.Snapshot()
+		t.Assert(s, []int{1, 2, 3})
+		a.Add(0)
+		t.Assert(s, []int{1, 2, 3})
+		t.Assert(a.Snapshot(), []int{0, 1, 2, 3})
+	})
+}
@@ -0,0 +1,81 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// go test *.go
+
+package garray_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_SortedTArray_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		array := garray.NewSortedTArrayFrom([]string{"c", "a", "b"}, strings.Compare, true)
+		t.Assert(array.Slice(), []string{"a", "b", "c"})
+		array.Add("d", "a")
+		t.Assert(array.Slice(), []string{"a", "a", "b", "c", "d"})
+		array.SetUnique(true)
+		t.Assert(array.Slice(), []string{"a", "b", "c", "d"})
+		array.Add("b")
+		t.Assert(array.Slice(), []string{"a", "b", "c", "d"})
+
+		t.Assert(array.At(1), "b")
+		t.Assert(array.Search("c"), 2)
+		t.Assert(array.Search("z"), -1)
+		t.Assert(array.Contains("d"), true)
+		t.Assert(array.RemoveValue("b"), true)
+		t.Assert(array.RemoveValue("b"), false)
+
+		v, found := array.PopLeft()
+		t.Assert(v, "a")
+		t.Assert(found, true)
+		v, found = array.PopRight()
+		t.Assert(v, "d")
+		t.Assert(found, true)
+		t.Assert(array.Slice(), []string{"c"})
+		t.Assert(array.Clone().Slice(), []string{"c"})
+		t.Assert(array.Clear().IsEmpty(), true)
+	})
+}
+
+func Test_SortedTArray_Comparator(t *testing.T) {
+	type User struct {
+		Id   int
+		Name string
+	}
+	gtest.C(t, func(t *gtest.T) {
+		array := garray.NewSortedTArray(func(a, b User) int {
+			return b.Id - a.Id
+		})
+		array.Add(User{1, "john"}, User{3, "smith"}, User{2, "jack"})
+		var ids []int
+		array.Iterator(func(k int, v User) bool {
+			ids = append(ids, v.Id)
+			return true
+		})
+		t.Assert(ids, []int{3, 2, 1})
+		t.Assert(array.Search(User{Id: 2}), 1)
+	})
+}
+
+func Test_SortedTArray_Json(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		array := garray.NewSortedTArray(func(a, b int) int {
+			return a - b
+		})
+		t.AssertNil(json.Unmarshal([]byte(`[3,1,2]`), array))
+		t.Assert(array.Slice(), []int{1, 2, 3})
+		b, err := json.Marshal(array)
+		t.AssertNil(err)
+		t.Assert(b, `[1,2,3]`)
+	})
+}
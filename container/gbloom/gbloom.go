@@ -0,0 +1,233 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gbloom provides concurrent-safe/unsafe probabilistic containers,
+// which are Bloom filter for membership testing and count-min sketch for frequency estimation.
+//
+// Both of them can be serialized to bytes using MarshalBinary, which is useful for persistence
+// or sharing in storage like redis, and be restored using UnmarshalBinary.
+package gbloom
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/rwmutex"
+)
+
+// Filter is a Bloom filter, which tests whether an item is a member of a set.
+// False positive matches are possible with configured rate, but false negatives are not.
+type Filter struct {
+	mu   rwmutex.RWMutex
+	bits []uint64 // Underlying bit array.
+	m    uint64   // Bit count of the filter.
+	k    uint64   // Hash function count.
+}
+
+const (
+	filterBinaryMagic = "GBF1" // Magic header of binary format of Filter.
+	defaultFalseRate  = 0.01   // Default false positive rate.
+)
+
+// New creates and returns a Bloom filter, which is sized for `expectedItems` items
+// with false positive rate `falsePositiveRate` in range (0, 1), which is 0.01 in default if invalid.
+// The optional parameter `safe` specifies whether using filter in concurrent-safety,
+// which is false in default.
+func New(expectedItems uint64, falsePositiveRate float64, safe ...bool) *Filter {
+	if expectedItems == 0 {
+		expectedItems = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = defaultFalseRate
+	}
+	var (
+		n = float64(expectedItems)
+		m = math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+		k = math.Max(1, math.Round(m/n*math.Ln2))
+	)
+	return NewWithSize(uint64(m), uint64(k), safe...)
+}
+
+// NewWithSize creates and returns a Bloom filter with `m` bits and `k` hash functions.
+// The optional parameter `safe` specifies whether using filter in concurrent-safety,
+// which is false in default.
+func NewWithSize(m, k uint64, safe ...bool) *Filter {
+	if m == 0 {
+		m = 1
+	}
+	if k == 0 {
+		k = 1
+	}
+	return &Filter{
+		mu:   rwmutex.Create(safe...),
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+	}
+}
+
+// hashPair returns two independent hash values of `item`, which are used to
+// simulate `k` hash functions using double hashing: h1 + i*h2.
+func hashPair(item []byte) (h1, h2 uint64) {
+	h := fnv.New128a()
+	_, _ = h.Write(item)
+	sum := h.Sum(nil)
+	h1 = binary.BigEndian.Uint64(sum[0:8])
+	h2 = binary.BigEndian.Uint64(sum[8:16]) | 1
+	return
+}
+
+// Add adds `item` to the filter.
+func (f *Filter) Add(item []byte) {
+	h1, h2 := hashPair(item)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := uint64(0); i < f.k; i++ {
+		position := (h1 + i*h2) % f.m
+		f.bits[position/64] |= 1 << (position % 64)
+	}
+}
+
+// AddString adds string `item` to the filter.
+func (f *Filter) AddString(item string) {
+	f.Add([]byte(item))
+}
+
+// AddIfNotContains adds `item` to the filter if it is not contained in the filter, and returns true.
+// It returns false if `item` is probably contained in the filter, which is useful for deduplication.
+func (f *Filter) AddIfNotContains(item []byte) bool {
+	h1, h2 := hashPair(item)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	added := false
+	for i := uint64(0); i < f.k; i++ {
+		var (
+			position = (h1 + i*h2) % f.m
+			mask     = uint64(1) << (position % 64)
+		)
+		if f.bits[position/64]&mask == 0 {
+			f.bits[position/64] |= mask
+			added = true
+		}
+	}
+	return added
+}
+
+// Contains checks whether `item` is probably contained in the filter.
+// It returns false if `item` is definitely not contained in the filter.
+func (f *Filter) Contains(item []byte) bool {
+	h1, h2 := hashPair(item)
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for i := uint64(0); i < f.k; i++ {
+		position := (h1 + i*h2) % f.m
+		if f.bits[position/64]&(1<<(position%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsString checks whether string `item` is probably contained in the filter.
+func (f *Filter) ContainsString(item string) bool {
+	return f.Contains([]byte(item))
+}
+
+// Cap returns the bit count of the filter.
+func (f *Filter) Cap() uint64 {
+	return f.m
+}
+
+// K returns the hash function count of the filter.
+func (f *Filter) K() uint64 {
+	return f.k
+}
+
+// Clear removes all items of the filter.
+func (f *Filter) Clear() {
+	f.mu.Lock()
+	for i := range f.bits {
+		f.bits[i] = 0
+	}
+	f.mu.Unlock()
+}
+
+// Merge merges `other` filter into current filter, which makes current filter contain
+// the items of both filters. The two filters should have the same size.
+func (f *Filter) Merge(other *Filter) error {
+	if f == other {
+		return nil
+	}
+	// It copies the bits of `other` and releases its lock before locking current filter,
+	// as locking both filters deadlocks if they are merged into each other concurrently.
+	other.mu.RLock()
+	var (
+		m    = other.m
+		k    = other.k
+		bits = make([]uint64, len(other.bits))
+	)
+	copy(bits, other.bits)
+	other.mu.RUnlock()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.m != m || f.k != k {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`cannot merge filters of different size: m=%d,k=%d and m=%d,k=%d`,
+			f.m, f.k, m, k,
+		)
+	}
+	for i, v := range bits {
+		f.bits[i] |= v
+	}
+	return nil
+}
+
+// MarshalBinary implements the interface encoding.BinaryMarshaler,
+// which serializes the filter to bytes for persistence.
+func (f *Filter) MarshalBinary() ([]byte, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	var (
+		headerSize = len(filterBinaryMagic) + 16
+		b          = make([]byte, headerSize+len(f.bits)*8)
+	)
+	copy(b, filterBinaryMagic)
+	binary.BigEndian.PutUint64(b[len(filterBinaryMagic):], f.m)
+	binary.BigEndian.PutUint64(b[len(filterBinaryMagic)+8:], f.k)
+	for i, v := range f.bits {
+		binary.BigEndian.PutUint64(b[headerSize+i*8:], v)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements the interface encoding.BinaryUnmarshaler,
+// which restores the filter from bytes produced by MarshalBinary.
+func (f *Filter) UnmarshalBinary(data []byte) error {
+	headerSize := len(filterBinaryMagic) + 16
+	if len(data) < headerSize || string(data[:len(filterBinaryMagic)]) != filterBinaryMagic {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `invalid binary data of bloom filter`)
+	}
+	var (
+		m = binary.BigEndian.Uint64(data[len(filterBinaryMagic):])
+		k = binary.BigEndian.Uint64(data[len(filterBinaryMagic)+8:])
+	)
+	if m == 0 || k == 0 || uint64(len(data)-headerSize) != (m+63)/64*8 {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `invalid binary data of bloom filter`)
+	}
+	bits := make([]uint64, (m+63)/64)
+	for i := range bits {
+		bits[i] = binary.BigEndian.Uint64(data[headerSize+i*8:])
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.m, f.k, f.bits = m, k, bits
+	return nil
+}
@@ -0,0 +1,215 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gbloom
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/rwmutex"
+)
+
+// CountMinSketch is a count-min sketch, which estimates the frequency of items in a stream
+// using sub-linear memory, eg: detecting hot keys.
+// The estimated count is never less than the real count, and it exceeds the real count
+// by at most epsilon*Total() with probability 1-delta.
+type CountMinSketch struct {
+	mu     rwmutex.RWMutex
+	width  uint64   // Counter count of each row.
+	depth  uint64   // Row count, which is also the hash function count.
+	counts []uint64 // Underlying counters of all rows, in size of width*depth.
+	total  uint64   // Total count of all added items.
+}
+
+const (
+	sketchBinaryMagic = "GCM1" // Magic header of binary format of CountMinSketch.
+)
+
+// NewCountMinSketch creates and returns a count-min sketch with error factor `epsilon`
+// and error probability `delta`, which are both in range (0, 1).
+// The optional parameter `safe` specifies whether using sketch in concurrent-safety,
+// which is false in default.
+func NewCountMinSketch(epsilon, delta float64, safe ...bool) *CountMinSketch {
+	if epsilon <= 0 || epsilon >= 1 {
+		epsilon = 0.001
+	}
+	if delta <= 0 || delta >= 1 {
+		delta = 0.01
+	}
+	var (
+		width = uint64(math.Ceil(math.E / epsilon))
+		depth = uint64(math.Ceil(math.Log(1 / delta)))
+	)
+	return NewCountMinSketchWithSize(width, depth, safe...)
+}
+
+// NewCountMinSketchWithSize creates and returns a count-min sketch with `depth` rows
+// of `width` counters.
+// The optional parameter `safe` specifies whether using sketch in concurrent-safety,
+// which is false in default.
+func NewCountMinSketchWithSize(width, depth uint64, safe ...bool) *CountMinSketch {
+	if width == 0 {
+		width = 1
+	}
+	if depth == 0 {
+		depth = 1
+	}
+	return &CountMinSketch{
+		mu:     rwmutex.Create(safe...),
+		width:  width,
+		depth:  depth,
+		counts: make([]uint64, width*depth),
+	}
+}
+
+// Add adds `count` to the frequency of `item`, and returns the new estimated count of `item`.
+func (s *CountMinSketch) Add(item []byte, count uint64) uint64 {
+	h1, h2 := hashPair(item)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.total += count
+	var estimate uint64 = math.MaxUint64
+	for i := uint64(0); i < s.depth; i++ {
+		index := i*s.width + (h1+i*h2)%s.width
+		s.counts[index] += count
+		if s.counts[index] < estimate {
+			estimate = s.counts[index]
+		}
+	}
+	return estimate
+}
+
+// AddString adds `count` to the frequency of string `item`, and returns the new estimated count of `item`.
+func (s *CountMinSketch) AddString(item string, count uint64) uint64 {
+	return s.Add([]byte(item), count)
+}
+
+// Count returns the estimated count of `item`.
+func (s *CountMinSketch) Count(item []byte) uint64 {
+	h1, h2 := hashPair(item)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var estimate uint64 = math.MaxUint64
+	for i := uint64(0); i < s.depth; i++ {
+		if v := s.counts[i*s.width+(h1+i*h2)%s.width]; v < estimate {
+			estimate = v
+		}
+	}
+	return estimate
+}
+
+// CountString returns the estimated count of string `item`.
+func (s *CountMinSketch) CountString(item string) uint64 {
+	return s.Count([]byte(item))
+}
+
+// Total returns the total count of all added items.
+func (s *CountMinSketch) Total() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.total
+}
+
+// Width returns the counter count of each row.
+func (s *CountMinSketch) Width() uint64 {
+	return s.width
+}
+
+// Depth returns the row count of the sketch.
+func (s *CountMinSketch) Depth() uint64 {
+	return s.depth
+}
+
+// Clear resets all counters of the sketch.
+func (s *CountMinSketch) Clear() {
+	s.mu.Lock()
+	for i := range s.counts {
+		s.counts[i] = 0
+	}
+	s.total = 0
+	s.mu.Unlock()
+}
+
+// Merge merges the counters of `other` sketch into current sketch.
+// The two sketches should have the same size.
+func (s *CountMinSketch) Merge(other *CountMinSketch) error {
+	if s == other {
+		return nil
+	}
+	// It copies the counters of `other` and releases its lock before locking current sketch,
+	// as locking both sketches deadlocks if they are merged into each other concurrently.
+	other.mu.RLock()
+	var (
+		width  = other.width
+		depth  = other.depth
+		total  = other.total
+		counts = make([]uint64, len(other.counts))
+	)
+	copy(counts, other.counts)
+	other.mu.RUnlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.width != width || s.depth != depth {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`cannot merge sketches of different size: width=%d,depth=%d and width=%d,depth=%d`,
+			s.width, s.depth, width, depth,
+		)
+	}
+	for i, v := range counts {
+		s.counts[i] += v
+	}
+	s.total += total
+	return nil
+}
+
+// MarshalBinary implements the interface encoding.BinaryMarshaler,
+// which serializes the sketch to bytes for persistence.
+func (s *CountMinSketch) MarshalBinary() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var (
+		headerSize = len(sketchBinaryMagic) + 24
+		b          = make([]byte, headerSize+len(s.counts)*8)
+	)
+	copy(b, sketchBinaryMagic)
+	binary.BigEndian.PutUint64(b[len(sketchBinaryMagic):], s.width)
+	binary.BigEndian.PutUint64(b[len(sketchBinaryMagic)+8:], s.depth)
+	binary.BigEndian.PutUint64(b[len(sketchBinaryMagic)+16:], s.total)
+	for i, v := range s.counts {
+		binary.BigEndian.PutUint64(b[headerSize+i*8:], v)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements the interface encoding.BinaryUnmarshaler,
+// which restores the sketch from bytes produced by MarshalBinary.
+func (s *CountMinSketch) UnmarshalBinary(data []byte) error {
+	headerSize := len(sketchBinaryMagic) + 24
+	if len(data) < headerSize || string(data[:len(sketchBinaryMagic)]) != sketchBinaryMagic {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `invalid binary data of count-min sketch`)
+	}
+	var (
+		width = binary.BigEndian.Uint64(data[len(sketchBinaryMagic):])
+		depth = binary.BigEndian.Uint64(data[len(sketchBinaryMagic)+8:])
+		total = binary.BigEndian.Uint64(data[len(sketchBinaryMagic)+16:])
+	)
+	if width == 0 || depth == 0 || uint64(len(data)-headerSize) != width*depth*8 {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `invalid binary data of count-min sketch`)
+	}
+	counts := make([]uint64, width*depth)
+	for i := range counts {
+		counts[i] = binary.BigEndian.Uint64(data[headerSize+i*8:])
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.width, s.depth, s.total, s.counts = width, depth, total, counts
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gbloom_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/gbloom"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_CountMinSketch_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := gbloom.NewCountMinSketch(0.001, 0.01, true)
+		t.Assert(s.Width(), 2719)
+		t.Assert(s.Depth(), 5)
+
+		t.Assert(s.AddString("a", 1), 1)
+		t.Assert(s.AddString("a", 2), 3)
+		s.Add([]byte("b"), 10)
+		t.Assert(s.CountString("a"), 3)
+		t.Assert(s.Count([]byte("b")), 10)
+		t.Assert(s.CountString("c"), 0)
+		t.Assert(s.Total(), 13)
+
+		s.Clear()
+		t.Assert(s.CountString("a"), 0)
+		t.Assert(s.Total(), 0)
+	})
+}
+
+func Test_CountMinSketch_HotKey(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := gbloom.NewCountMinSketch(0.001, 0.01)
+		for i := 0; i < 10000; i++ {
+			s.AddString(fmt.Sprintf("key-%d", i), 1)
+		}
+		s.AddString("hot", 1000)
+		t.AssertGE(s.CountString("hot"), 1000)
+		t.AssertLE(s.CountString("hot"), 1000+uint64(0.001*float64(s.Total())))
+		t.AssertGE(s.CountString("key-1"), 1)
+	})
+}
+
+func Test_CountMinSketch_Binary(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := gbloom.NewCountMinSketchWithSize(100, 4)
+		s.AddString("john", 3)
+		b, err := s.MarshalBinary()
+		t.AssertNil(err)
+
+		var s2 gbloom.CountMinSketch
+		t.AssertNil(s2.UnmarshalBinary(b))
+		t.Assert(s2.Width(), 100)
+		t.Assert(s2.Depth(), 4)
+		t.Assert(s2.Total(), 3)
+		t.Assert(s2.CountString("john"), 3)
+
+		t.AssertNE(s2.UnmarshalBinary(b[:10]), nil)
+	})
+}
+
+func Test_CountMinSketch_Merge(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s1 := gbloom.NewCountMinSketchWithSize(100, 4)
+		s2 := gbloom.NewCountMinSketchWithSize(100, 4)
+		s1.AddString("a", 1)
+		s2.AddString("a", 2)
+		t.AssertNil(s1.Merge(s2))
+		t.Assert(s1.CountString("a"), 3)
+		t.Assert(s1.Total(), 3)
+		t.AssertNE(s1.Merge(gbloom.NewCountMinSketchWithSize(10, 4)), nil)
+	})
+}
+
+func Test_CountMinSketch_Merge_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg sync.WaitGroup
+			s1 = gbloom.NewCountMinSketchWithSize(10000, 4, true)
+			s2 = gbloom.NewCountMinSketchWithSize(10000, 4, true)
+		)
+		// Merging the sketches into each other concurrently does not deadlock.
+		for i := 0; i < 1000; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				t.AssertNil(s1.Merge(s2))
+			}()
+			go func() {
+				defer wg.Done()
+				t.AssertNil(s2.Merge(s1))
+			}()
+		}
+		wg.Wait()
+	})
+}
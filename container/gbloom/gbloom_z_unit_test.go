@@ -0,0 +1,117 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gbloom_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/gbloom"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Filter_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		f := gbloom.New(1000, 0.01, true)
+		t.Assert(f.Cap(), 9586)
+		t.Assert(f.K(), 7)
+
+		f.AddString("a")
+		f.Add([]byte("b"))
+		t.Assert(f.ContainsString("a"), true)
+		t.Assert(f.Contains([]byte("b")), true)
+		t.Assert(f.ContainsString("c"), false)
+
+		t.Assert(f.AddIfNotContains([]byte("c")), true)
+		t.Assert(f.AddIfNotContains([]byte("c")), false)
+
+		f.Clear()
+		t.Assert(f.ContainsString("a"), false)
+	})
+}
+
+func Test_Filter_FalsePositiveRate(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			n = 10000
+			f = gbloom.New(uint64(n), 0.01)
+		)
+		for i := 0; i < n; i++ {
+			f.AddString(fmt.Sprintf("item-%d", i))
+		}
+		for i := 0; i < n; i++ {
+			t.Assert(f.ContainsString(fmt.Sprintf("item-%d", i)), true)
+		}
+		falsePositives := 0
+		for i := 0; i < n; i++ {
+			if f.ContainsString(fmt.Sprintf("other-%d", i)) {
+				falsePositives++
+			}
+		}
+		t.AssertLT(float64(falsePositives)/float64(n), 0.02)
+	})
+}
+
+func Test_Filter_Binary(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		f := gbloom.New(100, 0.001)
+		f.AddString("john")
+		b, err := f.MarshalBinary()
+		t.AssertNil(err)
+
+		var f2 gbloom.Filter
+		t.AssertNil(f2.UnmarshalBinary(b))
+		t.Assert(f2.Cap(), f.Cap())
+		t.Assert(f2.K(), f.K())
+		t.Assert(f2.ContainsString("john"), true)
+		t.Assert(f2.ContainsString("smith"), false)
+
+		t.AssertNE(f2.UnmarshalBinary([]byte("invalid")), nil)
+		t.AssertNE(f2.UnmarshalBinary(b[:len(b)-1]), nil)
+	})
+}
+
+func Test_Filter_Merge(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		f1 := gbloom.New(100, 0.01)
+		f2 := gbloom.New(100, 0.01)
+		f1.AddString("a")
+		f2.AddString("b")
+		t.AssertNil(f1.Merge(f2))
+		t.Assert(f1.ContainsString("a"), true)
+		t.Assert(f1.ContainsString("b"), true)
+		t.AssertNE(f1.Merge(gbloom.New(1000, 0.01)), nil)
+	})
+}
+
+func Test_Filter_Merge_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg sync.WaitGroup
+			f1 = gbloom.New(100000, 0.01, true)
+			f2 = gbloom.New(100000, 0.01, true)
+		)
+		f1.AddString("a")
+		f2.AddString("b")
+		// Merging the filters into each other concurrently does not deadlock.
+		for i := 0; i < 1000; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				t.AssertNil(f1.Merge(f2))
+			}()
+			go func() {
+				defer wg.Done()
+				t.AssertNil(f2.Merge(f1))
+			}()
+		}
+		wg.Wait()
+		t.Assert(f1.ContainsString("b"), true)
+		t.Assert(f2.ContainsString("a"), true)
+	})
+}
@@ -0,0 +1,132 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gheap provides a concurrent-safe/unsafe generic priority queue based on heap structure.
+//
+// The heap is a min-max binary heap, which supports retrieving both of the minimum and the maximum
+// priority item in O(1), and removing any of them in O(log n).
+package gheap
+
+import (
+	"github.com/gogf/gf/v2/internal/rwmutex"
+)
+
+// Heap is a generic priority queue, of which each value has a `priority` associated with it.
+// It contains a concurrent-safe/unsafe switch, which should be set when its initialization
+// and cannot be changed then.
+//
+// The values with the same priority are served in FIFO order by PopMin.
+type Heap[T any] struct {
+	mu       rwmutex.RWMutex
+	items    []heapItem[T] // The underlying array implementing the min-max heap structure.
+	sequence uint64        // The sequence counter of pushing, which keeps the order of values with the same priority.
+}
+
+// heapItem stores the value which has a `priority` attribute to sort itself in heap.
+type heapItem[T any] struct {
+	value    T
+	priority int64
+	sequence uint64
+}
+
+// New creates and returns an empty heap.
+// The parameter `safe` is used to specify whether using heap in concurrent-safety,
+// which is false in default.
+func New[T any](safe ...bool) *Heap[T] {
+	return &Heap[T]{
+		mu: rwmutex.Create(safe...),
+	}
+}
+
+// PushWithPriority pushes `value` with given `priority` to the heap.
+func (h *Heap[T]) PushWithPriority(value T, priority int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sequence++
+	h.items = append(h.items, heapItem[T]{
+		value:    value,
+		priority: priority,
+		sequence: h.sequence,
+	})
+	h.bubbleUp(len(h.items) - 1)
+}
+
+// Peek retrieves and returns the value with the minimum priority without removing it.
+// The `found` is false if the heap is empty.
+func (h *Heap[T]) Peek() (value T, priority int64, found bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if len(h.items) == 0 {
+		return
+	}
+	return h.items[0].value, h.items[0].priority, true
+}
+
+// PeekMax retrieves and returns the value with the maximum priority without removing it.
+// The `found` is false if the heap is empty.
+func (h *Heap[T]) PeekMax() (value T, priority int64, found bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if len(h.items) == 0 {
+		return
+	}
+	item := h.items[h.maxIndex()]
+	return item.value, item.priority, true
+}
+
+// PopMin retrieves, removes and returns the value with the minimum priority.
+// The `found` is false if the heap is empty.
+func (h *Heap[T]) PopMin() (value T, priority int64, found bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.items) == 0 {
+		return
+	}
+	item := h.removeAt(0)
+	return item.value, item.priority, true
+}
+
+// PopMax retrieves, removes and returns the value with the maximum priority.
+// The `found` is false if the heap is empty.
+func (h *Heap[T]) PopMax() (value T, priority int64, found bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.items) == 0 {
+		return
+	}
+	item := h.removeAt(h.maxIndex())
+	return item.value, item.priority, true
+}
+
+// Size returns the count of values in the heap.
+func (h *Heap[T]) Size() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.items)
+}
+
+// IsEmpty checks whether the heap is empty.
+func (h *Heap[T]) IsEmpty() bool {
+	return h.Size() == 0
+}
+
+// Clear removes all values of the heap.
+func (h *Heap[T]) Clear() {
+	h.mu.Lock()
+	h.items = nil
+	h.mu.Unlock()
+}
+
+// Values returns a copy of all values of the heap, which are in no particular order.
+func (h *Heap[T]) Values() []T {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	values := make([]T, len(h.items))
+	for i, item := range h.items {
+		values[i] = item.value
+	}
+	return values
+}
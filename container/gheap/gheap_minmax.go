@@ -0,0 +1,141 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gheap
+
+import "math/bits"
+
+// The min-max heap places the items of even levels(min levels) less than their descendants,
+// and the items of odd levels(max levels) greater than their descendants.
+// So the root is the minimum item, and the maximum item is one of the children of the root.
+
+// less checks whether the item at index `i` is less than the item at index `j`.
+// The items with the same priority are compared by their pushing sequence.
+func (h *Heap[T]) less(i, j int) bool {
+	if h.items[i].priority != h.items[j].priority {
+		return h.items[i].priority < h.items[j].priority
+	}
+	return h.items[i].sequence < h.items[j].sequence
+}
+
+// swap swaps the items at index `i` and `j`.
+func (h *Heap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+// isMinLevel checks whether the index `i` is on a min level of the heap.
+func isMinLevel(i int) bool {
+	return (bits.Len(uint(i+1))-1)%2 == 0
+}
+
+// maxIndex returns the index of the maximum item, the heap should not be empty.
+func (h *Heap[T]) maxIndex() int {
+	switch len(h.items) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	default:
+		if h.less(1, 2) {
+			return 2
+		}
+		return 1
+	}
+}
+
+// removeAt removes and returns the item at index `i`, which should be the root or a child of the root.
+func (h *Heap[T]) removeAt(i int) heapItem[T] {
+	var (
+		last = len(h.items) - 1
+		item = h.items[i]
+	)
+	h.items[i] = h.items[last]
+	h.items[last] = heapItem[T]{} // avoid memory leaks
+	h.items = h.items[:last]
+	if i < last {
+		h.trickleDown(i)
+	}
+	return item
+}
+
+// bubbleUp moves the item at index `i` up to its proper position.
+func (h *Heap[T]) bubbleUp(i int) {
+	if i == 0 {
+		return
+	}
+	parent := (i - 1) / 2
+	if isMinLevel(i) {
+		if h.less(parent, i) {
+			h.swap(i, parent)
+			h.bubbleUpWith(parent, false)
+		} else {
+			h.bubbleUpWith(i, true)
+		}
+	} else {
+		if h.less(i, parent) {
+			h.swap(i, parent)
+			h.bubbleUpWith(parent, true)
+		} else {
+			h.bubbleUpWith(i, false)
+		}
+	}
+}
+
+// bubbleUpWith moves the item at index `i` up by its grandparents,
+// which are on min levels if `min` is true, or else on max levels.
+func (h *Heap[T]) bubbleUpWith(i int, min bool) {
+	for i > 2 {
+		grandparent := ((i-1)/2 - 1) / 2
+		if min && !h.less(i, grandparent) || !min && !h.less(grandparent, i) {
+			return
+		}
+		h.swap(i, grandparent)
+		i = grandparent
+	}
+}
+
+// trickleDown moves the item at index `i` down to its proper position.
+func (h *Heap[T]) trickleDown(i int) {
+	var (
+		min    = isMinLevel(i)
+		length = len(h.items)
+	)
+	for {
+		// Searching the least(or the greatest) one among children and grandchildren.
+		var (
+			m     = -1
+			child = 2*i + 1
+		)
+		for _, j := range [6]int{child, child + 1, 2*child + 1, 2*child + 2, 2*child + 3, 2*child + 4} {
+			if j >= length {
+				break
+			}
+			if m == -1 || min && h.less(j, m) || !min && h.less(m, j) {
+				m = j
+			}
+		}
+		if m == -1 {
+			return
+		}
+		if m <= child+1 {
+			// It is a child.
+			if min && h.less(m, i) || !min && h.less(i, m) {
+				h.swap(m, i)
+			}
+			return
+		}
+		// It is a grandchild.
+		if min && !h.less(m, i) || !min && !h.less(i, m) {
+			return
+		}
+		h.swap(m, i)
+		parent := (m - 1) / 2
+		if min && h.less(parent, m) || !min && h.less(m, parent) {
+			h.swap(m, parent)
+		}
+		i = m
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gheap_test
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/gheap"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Heap_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		h := gheap.New[string]()
+		t.Assert(h.IsEmpty(), true)
+		_, _, found := h.Peek()
+		t.Assert(found, false)
+		_, _, found = h.PopMax()
+		t.Assert(found, false)
+
+		h.PushWithPriority("c", 3)
+		h.PushWithPriority("a", 1)
+		h.PushWithPriority("e", 5)
+		h.PushWithPriority("b", 2)
+		h.PushWithPriority("d", 4)
+		t.Assert(h.Size(), 5)
+		t.Assert(len(h.Values()), 5)
+
+		value, priority, found := h.Peek()
+		t.Assert(value, "a")
+		t.Assert(priority, 1)
+		t.Assert(found, true)
+		value, priority, _ = h.PeekMax()
+		t.Assert(value, "e")
+		t.Assert(priority, 5)
+
+		value, _, _ = h.PopMin()
+		t.Assert(value, "a")
+		value, _, _ = h.PopMax()
+		t.Assert(value, "e")
+		value, _, _ = h.PopMax()
+		t.Assert(value, "d")
+		value, _, _ = h.PopMin()
+		t.Assert(value, "b")
+		value, _, _ = h.PopMax()
+		t.Assert(value, "c")
+		t.Assert(h.Size(), 0)
+
+		h.PushWithPriority("a", 1)
+		h.Clear()
+		t.Assert(h.IsEmpty(), true)
+	})
+}
+
+func Test_Heap_SamePriority(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		h := gheap.New[int]()
+		for i := 0; i < 10; i++ {
+			h.PushWithPriority(i, 1)
+		}
+		for i := 0; i < 10; i++ {
+			value, _, _ := h.PopMin()
+			t.Assert(value, i)
+		}
+	})
+}
+
+func Test_Heap_Random(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			h          = gheap.New[int64]()
+			priorities = make([]int64, 0)
+		)
+		for round := 0; round < 2000; round++ {
+			switch rand.Intn(4) {
+			case 0, 1:
+				p := rand.Int63n(100)
+				h.PushWithPriority(p, p)
+				priorities = append(priorities, p)
+				sort.Slice(priorities, func(i, j int) bool { return priorities[i] < priorities[j] })
+			case 2:
+				value, priority, found := h.PopMin()
+				t.Assert(found, len(priorities) > 0)
+				if found {
+					t.Assert(value, priorities[0])
+					t.Assert(priority, priorities[0])
+					priorities = priorities[1:]
+				}
+			case 3:
+				value, _, found := h.PopMax()
+				t.Assert(found, len(priorities) > 0)
+				if found {
+					t.Assert(value, priorities[len(priorities)-1])
+					priorities = priorities[:len(priorities)-1]
+				}
+			}
+			t.Assert(h.Size(), len(priorities))
+		}
+	})
+}
+
+func Test_Heap_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg    sync.WaitGroup
+			h     = gheap.New[int](true)
+			total = 1000
+		)
+		for i := 0; i < total; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				h.PushWithPriority(i, int64(i))
+			}(i)
+		}
+		wg.Wait()
+		for i := 0; i < total; i++ {
+			value, _, _ := h.PopMin()
+			t.Assert(value, i)
+		}
+	})
+}
@@ -0,0 +1,398 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with l file,
+// You can obtain one at https://github.com/gogf/gf.
+//
+
+package glist
+
+import (
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/internal/rwmutex"
+)
+
+type (
+	// TList is a generic doubly linked list containing a concurrent-safe/unsafe switch.
+	// The switch should be set when its initialization and cannot be changed then.
+	//
+	// The Push/Insert functions return element handles, with which the callers can
+	// Remove/Move the elements in O(1), eg: building LRU structures on top of it.
+	TList[T any] struct {
+		mu   rwmutex.RWMutex
+		root TElement[T] // Sentinel list element, only &root, root.prev, and root.next are used.
+		len  int         // Current list length excluding sentinel element.
+	}
+
+	// TElement is the element type of TList.
+	TElement[T any] struct {
+		next, prev *TElement[T]
+		list       *TList[T] // The list to which this element belongs.
+		Value      T         // The value stored with this element.
+	}
+)
+
+// Next returns the next list element or nil.
+func (e *TElement[T]) Next() *TElement[T] {
+	if p := e.next; e.list != nil && p != &e.list.root {
+		return p
+	}
+	return nil
+}
+
+// Prev returns the previous list element or nil.
+func (e *TElement[T]) Prev() *TElement[T] {
+	if p := e.prev; e.list != nil && p != &e.list.root {
+		return p
+	}
+	return nil
+}
+
+// NewT creates and returns a new empty generic doubly linked list.
+// The parameter `safe` is used to specify whether using list in concurrent-safety,
+// which is false in default.
+func NewT[T any](safe ...bool) *TList[T] {
+	l := &TList[T]{
+		mu: rwmutex.Create(safe...),
+	}
+	l.init()
+	return l
+}
+
+// NewTFrom creates and returns a generic list from a copy of given slice `array`.
+// The parameter `safe` is used to specify whether using list in concurrent-safety,
+// which is false in default.
+func NewTFrom[T any](array []T, safe ...bool) *TList[T] {
+	l := NewT[T](safe...)
+	for _, v := range array {
+		l.insertValue(v, l.root.prev)
+	}
+	return l
+}
+
+// init initializes or clears the list.
+func (l *TList[T]) init() {
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	l.len = 0
+}
+
+// lazyInit lazily initializes a zero TList value.
+func (l *TList[T]) lazyInit() {
+	if l.root.next == nil {
+		l.init()
+	}
+}
+
+// insert inserts `e` after `at`, increments l.len, and returns `e`.
+func (l *TList[T]) insert(e, at *TElement[T]) *TElement[T] {
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+	e.list = l
+	l.len++
+	return e
+}
+
+// insertValue is a convenience wrapper for insert(&TElement{Value: v}, at).
+func (l *TList[T]) insertValue(v T, at *TElement[T]) *TElement[T] {
+	return l.insert(&TElement[T]{Value: v}, at)
+}
+
+// remove removes `e` from its list, decrements l.len.
+func (l *TList[T]) remove(e *TElement[T]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.next = nil // avoid memory leaks
+	e.prev = nil // avoid memory leaks
+	e.list = nil
+	l.len--
+}
+
+// move moves `e` to next to `at`.
+func (l *TList[T]) move(e, at *TElement[T]) {
+	if e == at {
+		return
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+}
+
+// PushFront inserts a new element `e` with value `v` at the front of list `l` and returns `e`.
+func (l *TList[T]) PushFront(v T) (e *TElement[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lazyInit()
+	return l.insertValue(v, &l.root)
+}
+
+// PushBack inserts a new element `e` with value `v` at the back of list `l` and returns `e`.
+func (l *TList[T]) PushBack(v T) (e *TElement[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lazyInit()
+	return l.insertValue(v, l.root.prev)
+}
+
+// PushFronts inserts multiple new elements with values `values` at the front of list `l`.
+func (l *TList[T]) PushFronts(values []T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lazyInit()
+	for _, v := range values {
+		l.insertValue(v, &l.root)
+	}
+}
+
+// PushBacks inserts multiple new elements with values `values` at the back of list `l`.
+func (l *TList[T]) PushBacks(values []T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lazyInit()
+	for _, v := range values {
+		l.insertValue(v, l.root.prev)
+	}
+}
+
+// PopBack removes the element from back of `l` and returns the value of the element.
+// The `found` is false if the list is empty.
+func (l *TList[T]) PopBack() (value T, found bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.len == 0 {
+		return
+	}
+	e := l.root.prev
+	l.remove(e)
+	return e.Value, true
+}
+
+// PopFront removes the element from front of `l` and returns the value of the element.
+// The `found` is false if the list is empty.
+func (l *TList[T]) PopFront() (value T, found bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.len == 0 {
+		return
+	}
+	e := l.root.next
+	l.remove(e)
+	return e.Value, true
+}
+
+// FrontAll copies and returns values of all elements from front of `l` as slice.
+func (l *TList[T]) FrontAll() (values []T) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	values = make([]T, 0, l.len)
+	for i, e := 0, l.root.next; i < l.len; i, e = i+1, e.next {
+		values = append(values, e.Value)
+	}
+	return
+}
+
+// BackAll copies and returns values of all elements from back of `l` as slice.
+func (l *TList[T]) BackAll() (values []T) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	values = make([]T, 0, l.len)
+	for i, e := 0, l.root.prev; i < l.len; i, e = i+1, e.prev {
+		values = append(values, e.Value)
+	}
+	return
+}
+
+// Front returns the first element of list `l` or nil if the list is empty.
+func (l *TList[T]) Front() (e *TElement[T]) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back returns the last element of list `l` or nil if the list is empty.
+func (l *TList[T]) Back() (e *TElement[T]) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// Len returns the number of elements of list `l`.
+// The complexity is O(1).
+func (l *TList[T]) Len() (length int) {
+	l.mu.RLock()
+	length = l.len
+	l.mu.RUnlock()
+	return
+}
+
+// Size is alias of Len.
+func (l *TList[T]) Size() int {
+	return l.Len()
+}
+
+// MoveBefore moves element `e` to its new position before `p`.
+// If `e` or `p` is not an element of `l`, or `e` == `p`, the list is not modified.
+// The element and `p` must not be nil.
+func (l *TList[T]) MoveBefore(e, p *TElement[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e.list != l || e == p || p.list != l {
+		return
+	}
+	l.move(e, p.prev)
+}
+
+// MoveAfter moves element `e` to its new position after `p`.
+// If `e` or `p` is not an element of `l`, or `e` == `p`, the list is not modified.
+// The element and `p` must not be nil.
+func (l *TList[T]) MoveAfter(e, p *TElement[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e.list != l || e == p || p.list != l {
+		return
+	}
+	l.move(e, p)
+}
+
+// MoveToFront moves element `e` to the front of list `l`.
+// If `e` is not an element of `l`, the list is not modified.
+// The element must not be nil.
+func (l *TList[T]) MoveToFront(e *TElement[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e.list != l || l.root.next == e {
+		return
+	}
+	l.move(e, &l.root)
+}
+
+// MoveToBack moves element `e` to the back of list `l`.
+// If `e` is not an element of `l`, the list is not modified.
+// The element must not be nil.
+func (l *TList[T]) MoveToBack(e *TElement[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e.list != l || l.root.prev == e {
+		return
+	}
+	l.move(e, l.root.prev)
+}
+
+// InsertAfter inserts a new element `e` with value `v` immediately after `p` and returns `e`.
+// If `p` is not an element of `l`, the list is not modified and it returns nil.
+// The `p` must not be nil.
+func (l *TList[T]) InsertAfter(p *TElement[T], v T) (e *TElement[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if p.list != l {
+		return nil
+	}
+	return l.insertValue(v, p)
+}
+
+// InsertBefore inserts a new element `e` with value `v` immediately before `p` and returns `e`.
+// If `p` is not an element of `l`, the list is not modified and it returns nil.
+// The `p` must not be nil.
+func (l *TList[T]) InsertBefore(p *TElement[T], v T) (e *TElement[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if p.list != l {
+		return nil
+	}
+	return l.insertValue(v, p.prev)
+}
+
+// Remove removes `e` from `l` if `e` is an element of list `l`.
+// It returns the element value e.Value.
+// The element must not be nil.
+func (l *TList[T]) Remove(e *TElement[T]) (value T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e.list == l {
+		l.remove(e)
+	}
+	return e.Value
+}
+
+// Removes removes multiple elements `es` from `l` if `es` are elements of list `l`.
+func (l *TList[T]) Removes(es []*TElement[T]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, e := range es {
+		if e.list == l {
+			l.remove(e)
+		}
+	}
+}
+
+// Clear removes all the elements from list `l`.
+func (l *TList[T]) Clear() {
+	l.mu.Lock()
+	l.init()
+	l.mu.Unlock()
+}
+
+// Iterator is alias of IteratorAsc.
+func (l *TList[T]) Iterator(f func(e *TElement[T]) bool) {
+	l.IteratorAsc(f)
+}
+
+// IteratorAsc iterates the list readonly in ascending order with given callback function `f`.
+// If `f` returns true, then it continues iterating; or false to stop.
+func (l *TList[T]) IteratorAsc(f func(e *TElement[T]) bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for i, e := 0, l.root.next; i < l.len; i, e = i+1, e.next {
+		if !f(e) {
+			break
+		}
+	}
+}
+
+// IteratorDesc iterates the list readonly in descending order with given callback function `f`.
+// If `f` returns true, then it continues iterating; or false to stop.
+func (l *TList[T]) IteratorDesc(f func(e *TElement[T]) bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for i, e := 0, l.root.prev; i < l.len; i, e = i+1, e.prev {
+		if !f(e) {
+			break
+		}
+	}
+}
+
+// String returns current list as a string.
+func (l *TList[T]) String() string {
+	if l == nil {
+		return ""
+	}
+	b, _ := l.MarshalJSON()
+	return string(b)
+}
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal.
+func (l *TList[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.FrontAll())
+}
+
+// UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal.
+func (l *TList[T]) UnmarshalJSON(b []byte) error {
+	var array []T
+	if err := json.UnmarshalUseNumber(b, &array); err != nil {
+		return err
+	}
+	l.PushBacks(array)
+	return nil
+}
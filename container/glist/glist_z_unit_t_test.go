@@ -0,0 +1,157 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glist_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/container/glist"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func TestTList_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		l := glist.NewT[int]()
+		e1 := l.PushBack(1)
+		e2 := l.PushBack(2)
+		e0 := l.PushFront(0)
+		t.Assert(l.Len(), 3)
+		t.Assert(l.FrontAll(), []int{0, 1, 2})
+		t.Assert(l.BackAll(), []int{2, 1, 0})
+		t.Assert(l.Front(), e0)
+		t.Assert(l.Back(), e2)
+		t.Assert(e0.Next(), e1)
+		t.Assert(e1.Prev(), e0)
+		t.Assert(e2.Next() == nil, true)
+
+		l.MoveToBack(e0)
+		t.Assert(l.FrontAll(), []int{1, 2, 0})
+		l.MoveToFront(e0)
+		t.Assert(l.FrontAll(), []int{0, 1, 2})
+		l.MoveAfter(e0, e2)
+		t.Assert(l.FrontAll(), []int{1, 2, 0})
+		l.MoveBefore(e0, e1)
+		t.Assert(l.FrontAll(), []int{0, 1, 2})
+
+		e3 := l.InsertAfter(e2, 3)
+		l.InsertBefore(e0, -1)
+		t.Assert(l.FrontAll(), []int{-1, 0, 1, 2, 3})
+
+		t.Assert(l.Remove(e3), 3)
+		t.Assert(l.Remove(e3), 3)
+		l.Removes([]*glist.TElement[int]{e0, e1})
+		t.Assert(l.FrontAll(), []int{-1, 2})
+
+		v, ok := l.PopFront()
+		t.Assert(v, -1)
+		t.Assert(ok, true)
+		v, ok = l.PopBack()
+		t.Assert(v, 2)
+		t.Assert(ok, true)
+		v, ok = l.PopBack()
+		t.Assert(v, 0)
+		t.Assert(ok, false)
+		t.Assert(l.Front() == nil, true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var l glist.TList[string]
+		l.PushBacks([]string{"b", "c"})
+		l.PushFronts([]string{"a"})
+		t.Assert(l.Size(), 3)
+		t.Assert(l.FrontAll(), []string{"a", "b", "c"})
+		l.Clear()
+		t.Assert(l.Size(), 0)
+	})
+}
+
+func TestTList_ForeignElement(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		l1 := glist.NewTFrom([]int{1, 2})
+		l2 := glist.NewTFrom([]int{3, 4})
+		e := l2.Front()
+		l1.MoveToFront(e)
+		l1.Remove(e)
+		t.Assert(l1.InsertAfter(e, 5) == nil, true)
+		t.Assert(l1.FrontAll(), []int{1, 2})
+		t.Assert(l2.FrontAll(), []int{3, 4})
+	})
+}
+
+func TestTList_Iterator(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			l     = glist.NewTFrom([]int{1, 2, 3}, true)
+			array []int
+		)
+		l.IteratorAsc(func(e *glist.TElement[int]) bool {
+			array = append(array, e.Value)
+			return e.Value < 2
+		})
+		t.Assert(array, []int{1, 2})
+		array = array[:0]
+		l.IteratorDesc(func(e *glist.TElement[int]) bool {
+			array = append(array, e.Value)
+			return true
+		})
+		t.Assert(array, []int{3, 2, 1})
+	})
+}
+
+// TestTList_LRU uses TList as the eviction queue of an LRU cache,
+// which relies on the O(1) MoveToFront and Remove by element handle.
+func TestTList_LRU(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			capacity = 2
+			queue    = glist.NewT[string]()
+			index    = make(map[string]*glist.TElement[string])
+			access   = func(key string) {
+				if e, ok := index[key]; ok {
+					queue.MoveToFront(e)
+					return
+				}
+				index[key] = queue.PushFront(key)
+				if queue.Len() > capacity {
+					delete(index, queue.Remove(queue.Back()))
+				}
+			}
+		)
+		access("a")
+		access("b")
+		access("a")
+		access("c")
+		t.Assert(queue.FrontAll(), []string{"c", "a"})
+		t.Assert(len(index), 2)
+		_, ok := index["b"]
+		t.Assert(ok, false)
+	})
+}
+
+func TestTList_Json(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		l := glist.NewTFrom([]int{1, 2, 3})
+		b, err := json.Marshal(l)
+		t.AssertNil(err)
+		t.Assert(string(b), `[1,2,3]`)
+		t.Assert(l.String(), `[1,2,3]`)
+
+		var l2 glist.TList[int]
+		t.AssertNil(json.Unmarshal(b, &l2))
+		t.Assert(l2.FrontAll(), []int{1, 2, 3})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		type T struct {
+			Name string
+			List *glist.TList[string]
+		}
+		var v *T
+		err := json.Unmarshal([]byte(`{"Name":"john","List":["a","b"]}`), &v)
+		t.AssertNil(err)
+		t.Assert(v.List.FrontAll(), []string{"a", "b"})
+	})
+}
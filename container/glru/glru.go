@@ -0,0 +1,230 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package glru provides concurrent-safe, size-bounded generic cache maps with LRU or LFU eviction.
+//
+// Different from package gcache, it has no adapter or context plumbing,
+// which is suitable for libraries that just need a tiny bounded map.
+package glru
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/glist"
+)
+
+// Cache is a concurrent-safe, size-bounded generic map, which evicts the least recently used item
+// when it is full. Items can have optional TTL, and the expired items are removed lazily on accessing.
+type Cache[K comparable, V any] struct {
+	mu        sync.Mutex
+	capacity  int                                     // Max item count of the cache.
+	ttl       time.Duration                           // Default TTL for items, which is no expiration if it is 0.
+	list      *glist.TList[*cacheItem[K, V]]          // Items ordered by recently usage, the front one is the most recently used.
+	data      map[K]*glist.TElement[*cacheItem[K, V]] // Item elements by keys.
+	evictFunc func(key K, value V)                    // Callback function on item eviction.
+}
+
+// cacheItem is the item of Cache.
+type cacheItem[K comparable, V any] struct {
+	key      K
+	value    V
+	expireAt int64 // Expiration timestamp in nanoseconds, which is no expiration if it is 0.
+}
+
+// New creates and returns a LRU cache with max item count `capacity`.
+// The optional parameter `ttl` specifies the default TTL for items, which is no expiration in default.
+func New[K comparable, V any](capacity int, ttl ...time.Duration) *Cache[K, V] {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	c := &Cache[K, V]{
+		capacity: capacity,
+		list:     glist.NewT[*cacheItem[K, V]](),
+		data:     make(map[K]*glist.TElement[*cacheItem[K, V]]),
+	}
+	if len(ttl) > 0 {
+		c.ttl = ttl[0]
+	}
+	return c
+}
+
+// SetEvictFunc sets the callback function `f`, which is called when an item is evicted
+// because of the cache being full or the item being expired.
+// Note that it is not called for Remove and Clear.
+func (c *Cache[K, V]) SetEvictFunc(f func(key K, value V)) {
+	c.mu.Lock()
+	c.evictFunc = f
+	c.mu.Unlock()
+}
+
+// Set sets `key`-`value` to the cache with the default TTL.
+// It evicts the least recently used item if the cache is full.
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL sets `key`-`value` to the cache with given `ttl`, which is no expiration if it is 0.
+// It evicts the least recently used item if the cache is full.
+func (c *Cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	var evicted []*cacheItem[K, V]
+	c.mu.Lock()
+	if e, ok := c.data[key]; ok {
+		e.Value.value = value
+		e.Value.expireAt = expireAt(ttl)
+		c.list.MoveToFront(e)
+	} else {
+		c.data[key] = c.list.PushFront(&cacheItem[K, V]{
+			key:      key,
+			value:    value,
+			expireAt: expireAt(ttl),
+		})
+		for c.list.Len() > c.capacity {
+			evicted = append(evicted, c.removeElement(c.list.Back()))
+		}
+	}
+	evictFunc := c.evictFunc
+	c.mu.Unlock()
+	doEvictCallback(evictFunc, evicted)
+}
+
+// Get returns the value of `key`, and marks it as the most recently used.
+// The `found` is false if `key` does not exist or is expired.
+func (c *Cache[K, V]) Get(key K) (value V, found bool) {
+	var evicted []*cacheItem[K, V]
+	c.mu.Lock()
+	if e, ok := c.data[key]; ok {
+		if e.Value.isExpired(time.Now().UnixNano()) {
+			evicted = append(evicted, c.removeElement(e))
+		} else {
+			c.list.MoveToFront(e)
+			value, found = e.Value.value, true
+		}
+	}
+	evictFunc := c.evictFunc
+	c.mu.Unlock()
+	doEvictCallback(evictFunc, evicted)
+	return
+}
+
+// Peek returns the value of `key` without marking it as the most recently used.
+// The `found` is false if `key` does not exist or is expired.
+func (c *Cache[K, V]) Peek(key K) (value V, found bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.data[key]; ok && !e.Value.isExpired(time.Now().UnixNano()) {
+		return e.Value.value, true
+	}
+	return
+}
+
+// Contains checks whether `key` exists and is not expired in the cache.
+func (c *Cache[K, V]) Contains(key K) bool {
+	_, found := c.Peek(key)
+	return found
+}
+
+// Remove deletes `key` from the cache, and returns its value.
+// The `found` is false if `key` does not exist.
+func (c *Cache[K, V]) Remove(key K) (value V, found bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.data[key]; ok {
+		item := c.removeElement(e)
+		return item.value, true
+	}
+	return
+}
+
+// Keys returns the keys of not expired items, from the most recently used to the least.
+func (c *Cache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var (
+		now  = time.Now().UnixNano()
+		keys = make([]K, 0, len(c.data))
+	)
+	c.list.Iterator(func(e *glist.TElement[*cacheItem[K, V]]) bool {
+		if !e.Value.isExpired(now) {
+			keys = append(keys, e.Value.key)
+		}
+		return true
+	})
+	return keys
+}
+
+// Size returns the item count of the cache, which might include expired items not removed yet.
+func (c *Cache[K, V]) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.data)
+}
+
+// Cap returns the max item count of the cache.
+func (c *Cache[K, V]) Cap() int {
+	return c.capacity
+}
+
+// ClearExpired removes all the expired items of the cache,
+// and calls the eviction callback function for them.
+func (c *Cache[K, V]) ClearExpired() {
+	var (
+		now     = time.Now().UnixNano()
+		evicted []*cacheItem[K, V]
+		removes []*glist.TElement[*cacheItem[K, V]]
+	)
+	c.mu.Lock()
+	c.list.Iterator(func(e *glist.TElement[*cacheItem[K, V]]) bool {
+		if e.Value.isExpired(now) {
+			removes = append(removes, e)
+		}
+		return true
+	})
+	for _, e := range removes {
+		evicted = append(evicted, c.removeElement(e))
+	}
+	evictFunc := c.evictFunc
+	c.mu.Unlock()
+	doEvictCallback(evictFunc, evicted)
+}
+
+// Clear removes all the items of the cache.
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	c.list.Clear()
+	c.data = make(map[K]*glist.TElement[*cacheItem[K, V]])
+	c.mu.Unlock()
+}
+
+// removeElement removes element `e` from the cache and returns its item.
+func (c *Cache[K, V]) removeElement(e *glist.TElement[*cacheItem[K, V]]) *cacheItem[K, V] {
+	item := c.list.Remove(e)
+	delete(c.data, item.key)
+	return item
+}
+
+// isExpired checks whether the item is expired at timestamp `now` in nanoseconds.
+func (item *cacheItem[K, V]) isExpired(now int64) bool {
+	return item.expireAt > 0 && item.expireAt <= now
+}
+
+// expireAt calculates and returns the expiration timestamp in nanoseconds for `ttl`.
+func expireAt(ttl time.Duration) int64 {
+	if ttl <= 0 {
+		return 0
+	}
+	return time.Now().Add(ttl).UnixNano()
+}
+
+// doEvictCallback calls `evictFunc` for `evicted` items, which should be called without lock.
+func doEvictCallback[K comparable, V any](evictFunc func(key K, value V), evicted []*cacheItem[K, V]) {
+	if evictFunc == nil {
+		return
+	}
+	for _, item := range evicted {
+		evictFunc(item.key, item.value)
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glru
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/glist"
+)
+
+// LFUCache is a concurrent-safe, size-bounded generic map, which evicts the least frequently used item
+// when it is full, and the least recently used one among the items with the same frequency.
+// Items can have optional TTL, and the expired items are removed lazily on accessing.
+type LFUCache[K comparable, V any] struct {
+	mu          sync.Mutex
+	capacity    int                                   // Max item count of the cache.
+	ttl         time.Duration                         // Default TTL for items, which is no expiration if it is 0.
+	minFreq     int                                   // The minimum frequency of all items.
+	frequencies map[int]*glist.TList[*lfuItem[K, V]]  // Items lists by frequencies, the front one is the most recently used.
+	data        map[K]*glist.TElement[*lfuItem[K, V]] // Item elements by keys.
+	evictFunc   func(key K, value V)                  // Callback function on item eviction.
+}
+
+// lfuItem is the item of LFUCache.
+type lfuItem[K comparable, V any] struct {
+	cacheItem[K, V]
+	frequency int // Accessing frequency of the item.
+}
+
+// NewLFU creates and returns a LFU cache with max item count `capacity`.
+// The optional parameter `ttl` specifies the default TTL for items, which is no expiration in default.
+func NewLFU[K comparable, V any](capacity int, ttl ...time.Duration) *LFUCache[K, V] {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	c := &LFUCache[K, V]{
+		capacity:    capacity,
+		frequencies: make(map[int]*glist.TList[*lfuItem[K, V]]),
+		data:        make(map[K]*glist.TElement[*lfuItem[K, V]]),
+	}
+	if len(ttl) > 0 {
+		c.ttl = ttl[0]
+	}
+	return c
+}
+
+// SetEvictFunc sets the callback function `f`, which is called when an item is evicted
+// because of the cache being full or the item being expired.
+// Note that it is not called for Remove and Clear.
+func (c *LFUCache[K, V]) SetEvictFunc(f func(key K, value V)) {
+	c.mu.Lock()
+	c.evictFunc = f
+	c.mu.Unlock()
+}
+
+// Set sets `key`-`value` to the cache with the default TTL.
+// It evicts the least frequently used item if the cache is full.
+func (c *LFUCache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL sets `key`-`value` to the cache with given `ttl`, which is no expiration if it is 0.
+// It evicts the least frequently used item if the cache is full.
+func (c *LFUCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	var evicted []*cacheItem[K, V]
+	c.mu.Lock()
+	if e, ok := c.data[key]; ok {
+		e.Value.value = value
+		e.Value.expireAt = expireAt(ttl)
+		c.increaseFrequency(e)
+	} else {
+		if len(c.data) >= c.capacity {
+			if list := c.minFrequencyList(); list != nil {
+				evicted = append(evicted, c.removeElement(list.Back()))
+			}
+		}
+		item := &lfuItem[K, V]{
+			cacheItem: cacheItem[K, V]{
+				key:      key,
+				value:    value,
+				expireAt: expireAt(ttl),
+			},
+			frequency: 1,
+		}
+		c.data[key] = c.frequencyList(1).PushFront(item)
+		c.minFreq = 1
+	}
+	evictFunc := c.evictFunc
+	c.mu.Unlock()
+	doEvictCallback(evictFunc, evicted)
+}
+
+// Get returns the value of `key`, and increases its accessing frequency.
+// The `found` is false if `key` does not exist or is expired.
+func (c *LFUCache[K, V]) Get(key K) (value V, found bool) {
+	var evicted []*cacheItem[K, V]
+	c.mu.Lock()
+	if e, ok := c.data[key]; ok {
+		if e.Value.isExpired(time.Now().UnixNano()) {
+			evicted = append(evicted, c.removeElement(e))
+		} else {
+			value, found = e.Value.value, true
+			c.increaseFrequency(e)
+		}
+	}
+	evictFunc := c.evictFunc
+	c.mu.Unlock()
+	doEvictCallback(evictFunc, evicted)
+	return
+}
+
+// Peek returns the value of `key` without increasing its accessing frequency.
+// The `found` is false if `key` does not exist or is expired.
+func (c *LFUCache[K, V]) Peek(key K) (value V, found bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.data[key]; ok && !e.Value.isExpired(time.Now().UnixNano()) {
+		return e.Value.value, true
+	}
+	return
+}
+
+// Contains checks whether `key` exists and is not expired in the cache.
+func (c *LFUCache[K, V]) Contains(key K) bool {
+	_, found := c.Peek(key)
+	return found
+}
+
+// Frequency returns the accessing frequency of `key`, which is 0 if `key` does not exist.
+func (c *LFUCache[K, V]) Frequency(key K) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.data[key]; ok {
+		return e.Value.frequency
+	}
+	return 0
+}
+
+// Remove deletes `key` from the cache, and returns its value.
+// The `found` is false if `key` does not exist.
+func (c *LFUCache[K, V]) Remove(key K) (value V, found bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.data[key]; ok {
+		item := c.removeElement(e)
+		return item.value, true
+	}
+	return
+}
+
+// Keys returns the keys of not expired items, which are in no particular order.
+func (c *LFUCache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var (
+		now  = time.Now().UnixNano()
+		keys = make([]K, 0, len(c.data))
+	)
+	for key, e := range c.data {
+		if !e.Value.isExpired(now) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Size returns the item count of the cache, which might include expired items not removed yet.
+func (c *LFUCache[K, V]) Size() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.data)
+}
+
+// Cap returns the max item count of the cache.
+func (c *LFUCache[K, V]) Cap() int {
+	return c.capacity
+}
+
+// ClearExpired removes all the expired items of the cache,
+// and calls the eviction callback function for them.
+func (c *LFUCache[K, V]) ClearExpired() {
+	var (
+		now     = time.Now().UnixNano()
+		evicted []*cacheItem[K, V]
+	)
+	c.mu.Lock()
+	for _, e := range c.data {
+		if e.Value.isExpired(now) {
+			evicted = append(evicted, c.removeElement(e))
+		}
+	}
+	evictFunc := c.evictFunc
+	c.mu.Unlock()
+	doEvictCallback(evictFunc, evicted)
+}
+
+// Clear removes all the items of the cache.
+func (c *LFUCache[K, V]) Clear() {
+	c.mu.Lock()
+	c.minFreq = 0
+	c.frequencies = make(map[int]*glist.TList[*lfuItem[K, V]])
+	c.data = make(map[K]*glist.TElement[*lfuItem[K, V]])
+	c.mu.Unlock()
+}
+
+// frequencyList returns the items list of `frequency`, it creates one if it does not exist.
+func (c *LFUCache[K, V]) frequencyList(frequency int) *glist.TList[*lfuItem[K, V]] {
+	list, ok := c.frequencies[frequency]
+	if !ok {
+		list = glist.NewT[*lfuItem[K, V]]()
+		c.frequencies[frequency] = list
+	}
+	return list
+}
+
+// minFrequencyList returns the items list of the minimum frequency, or nil if the cache is empty.
+// The minimum frequency is recalculated if it is out of date because of item removing.
+func (c *LFUCache[K, V]) minFrequencyList() *glist.TList[*lfuItem[K, V]] {
+	if list, ok := c.frequencies[c.minFreq]; ok {
+		return list
+	}
+	var list *glist.TList[*lfuItem[K, V]]
+	for frequency, l := range c.frequencies {
+		if list == nil || frequency < c.minFreq {
+			c.minFreq, list = frequency, l
+		}
+	}
+	return list
+}
+
+// increaseFrequency moves the item of element `e` to the list of the next frequency,
+// and updates the element of the item.
+func (c *LFUCache[K, V]) increaseFrequency(e *glist.TElement[*lfuItem[K, V]]) {
+	item := e.Value
+	c.removeFromFrequencyList(e)
+	item.frequency++
+	c.data[item.key] = c.frequencyList(item.frequency).PushFront(item)
+}
+
+// removeFromFrequencyList removes element `e` from its frequency list,
+// and updates the minimum frequency if necessary.
+func (c *LFUCache[K, V]) removeFromFrequencyList(e *glist.TElement[*lfuItem[K, V]]) {
+	frequency := e.Value.frequency
+	list := c.frequencies[frequency]
+	list.Remove(e)
+	if list.Len() == 0 {
+		delete(c.frequencies, frequency)
+		if c.minFreq == frequency {
+			c.minFreq++
+		}
+	}
+}
+
+// removeElement removes element `e` from the cache and returns its item.
+func (c *LFUCache[K, V]) removeElement(e *glist.TElement[*lfuItem[K, V]]) *cacheItem[K, V] {
+	item := e.Value
+	c.removeFromFrequencyList(e)
+	delete(c.data, item.key)
+	return &item.cacheItem
+}
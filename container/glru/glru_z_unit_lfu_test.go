@@ -0,0 +1,82 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glru_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/glru"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func sortedKeys(keys []string) []string {
+	sort.Strings(keys)
+	return keys
+}
+
+func Test_LFUCache_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			c       = glru.NewLFU[string, int](2)
+			evicted []string
+		)
+		c.SetEvictFunc(func(key string, value int) {
+			evicted = append(evicted, key)
+		})
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Get("a")
+		c.Get("a")
+		t.Assert(c.Frequency("a"), 3)
+		t.Assert(c.Frequency("b"), 1)
+
+		c.Set("c", 3)
+		t.Assert(evicted, []string{"b"})
+		t.Assert(sortedKeys(c.Keys()), []string{"a", "c"})
+
+		// The least recently used one among the same frequency is evicted.
+		c.Get("c")
+		c.Get("c")
+		c.Set("d", 4)
+		t.Assert(evicted, []string{"b", "a"})
+
+		v, found := c.Peek("c")
+		t.Assert(v, 3)
+		t.Assert(found, true)
+		t.Assert(c.Frequency("c"), 3)
+
+		// Removing the item of minimum frequency.
+		_, found = c.Remove("d")
+		t.Assert(found, true)
+		c.Set("e", 5)
+		c.Set("f", 6)
+		t.Assert(evicted, []string{"b", "a", "e"})
+		t.Assert(c.Size(), 2)
+
+		c.Clear()
+		t.Assert(c.Size(), 0)
+		t.Assert(c.Frequency("c"), 0)
+	})
+}
+
+func Test_LFUCache_TTL(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c := glru.NewLFU[int, int](10, 20*time.Millisecond)
+		c.Set(1, 1)
+		c.SetWithTTL(2, 2, time.Hour)
+		time.Sleep(50 * time.Millisecond)
+		_, found := c.Get(1)
+		t.Assert(found, false)
+		t.Assert(c.Keys(), []int{2})
+		c.SetWithTTL(3, 3, time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+		c.ClearExpired()
+		t.Assert(c.Size(), 1)
+	})
+}
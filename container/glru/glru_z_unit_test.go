@@ -0,0 +1,112 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glru_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/glru"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Cache_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			c       = glru.New[string, int](2)
+			evicted = make(map[string]int)
+		)
+		c.SetEvictFunc(func(key string, value int) {
+			evicted[key] = value
+		})
+		t.Assert(c.Cap(), 2)
+		c.Set("a", 1)
+		c.Set("b", 2)
+		v, found := c.Get("a")
+		t.Assert(v, 1)
+		t.Assert(found, true)
+		c.Set("c", 3)
+		t.Assert(c.Size(), 2)
+		t.Assert(c.Keys(), []string{"c", "a"})
+		t.Assert(evicted, map[string]int{"b": 2})
+		t.Assert(c.Contains("b"), false)
+
+		// Peek does not change the order.
+		v, found = c.Peek("a")
+		t.Assert(v, 1)
+		t.Assert(found, true)
+		c.Set("d", 4)
+		t.Assert(c.Keys(), []string{"d", "c"})
+
+		// Updating moves the item to the front.
+		c.Set("c", 30)
+		t.Assert(c.Keys(), []string{"c", "d"})
+
+		v, found = c.Remove("c")
+		t.Assert(v, 30)
+		t.Assert(found, true)
+		_, found = c.Remove("c")
+		t.Assert(found, false)
+		t.Assert(len(evicted), 2)
+
+		c.Clear()
+		t.Assert(c.Size(), 0)
+	})
+}
+
+func Test_Cache_TTL(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			c       = glru.New[int, int](10, 50*time.Millisecond)
+			evicted []int
+		)
+		c.SetEvictFunc(func(key int, value int) {
+			evicted = append(evicted, key)
+		})
+		c.Set(1, 1)
+		c.SetWithTTL(2, 2, 0)
+		c.SetWithTTL(3, 3, time.Hour)
+		t.Assert(c.Contains(1), true)
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(c.Contains(1), false)
+		t.Assert(c.Keys(), []int{3, 2})
+		t.Assert(c.Size(), 3)
+
+		_, found := c.Get(1)
+		t.Assert(found, false)
+		t.Assert(evicted, []int{1})
+		t.Assert(c.Size(), 2)
+
+		c.SetWithTTL(4, 4, time.Millisecond)
+		time.Sleep(10 * time.Millisecond)
+		c.ClearExpired()
+		t.Assert(evicted, []int{1, 4})
+		t.Assert(c.Size(), 2)
+	})
+}
+
+func Test_Cache_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg sync.WaitGroup
+			c  = glru.New[int, int](100)
+		)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 1000; j++ {
+					c.Set(i*1000+j, j)
+					c.Get(j)
+				}
+			}(i)
+		}
+		wg.Wait()
+		t.Assert(c.Size(), 100)
+	})
+}
@@ -8,8 +8,9 @@
 package gmap
 
 type (
-	Map     = AnyAnyMap // Map is alias of AnyAnyMap.
-	HashMap = AnyAnyMap // HashMap is alias of AnyAnyMap.
+	Map        = AnyAnyMap // Map is alias of AnyAnyMap.
+	HashMap    = AnyAnyMap // HashMap is alias of AnyAnyMap.
+	OrderedMap = ListMap   // OrderedMap is alias of ListMap, which preserves insertion-order.
 )
 
 // New creates and returns an empty hash map.
@@ -43,3 +44,10 @@ func NewHashMap(safe ...bool) *Map {
 func NewHashMapFrom(data map[interface{}]interface{}, safe ...bool) *Map {
 	return NewAnyAnyMapFrom(data, safe...)
 }
+
+// NewOrderedMap creates and returns an empty map that preserves insertion-order.
+// The parameter `safe` is used to specify whether using map in concurrent-safety,
+// which is false in default.
+func NewOrderedMap(safe ...bool) *OrderedMap {
+	return NewListMap(safe...)
+}
@@ -0,0 +1,387 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with gm file,
+// You can obtain one at https://github.com/gogf/gf.
+//
+
+package gmap
+
+import (
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/internal/rwmutex"
+)
+
+// KVMap implements generic map[K]V with RWMutex that has switch,
+// which needs no boxing and type assertion for its keys and values.
+type KVMap[K comparable, V any] struct {
+	mu       rwmutex.RWMutex
+	data     map[K]V
+	snapshot map[K]V // Shared readonly copy of data for Snapshot, which is reset when data changes.
+}
+
+// NewKVMap returns an empty KVMap object.
+// The parameter `safe` is used to specify whether using map in concurrent-safety,
+// which is false in default.
+func NewKVMap[K comparable, V any](safe ...bool) *KVMap[K, V] {
+	return &KVMap[K, V]{
+		mu:   rwmutex.Create(safe...),
+		data: make(map[K]V),
+	}
+}
+
+// NewKVMapFrom creates and returns a hash map from given map `data`.
+// Note that, the param `data` map will be set as the underlying data map(no deep copy),
+// there might be some concurrent-safe issues when changing the map outside.
+func NewKVMapFrom[K comparable, V any](data map[K]V, safe ...bool) *KVMap[K, V] {
+	return &KVMap[K, V]{
+		mu:   rwmutex.Create(safe...),
+		data: data,
+	}
+}
+
+// Iterator iterates the hash map readonly with custom callback function `f`.
+// If `f` returns true, then it continues iterating; or false to stop.
+func (m *KVMap[K, V]) Iterator(f func(k K, v V) bool) {
+	for k, v := range m.Snapshot() {
+		if !f(k, v) {
+			break
+		}
+	}
+}
+
+// Clone returns a new hash map with copy of current map data.
+func (m *KVMap[K, V]) Clone() *KVMap[K, V] {
+	return NewKVMapFrom(m.MapCopy(), m.mu.IsSafe())
+}
+
+// Map returns the underlying data map.
+// Note that, if it's in concurrent-safe usage, it returns a copy of underlying data,
+// or else a pointer to the underlying data.
+func (m *KVMap[K, V]) Map() map[K]V {
+	if !m.mu.IsSafe() {
+		return m.data
+	}
+	return m.MapCopy()
+}
+
+// Snapshot returns a readonly copy of the underlying data of the hash map, with which the caller can
+// iterate the map for a long time without holding the lock, so that writers are not stalled.
+//
+// In concurrent-safety usage, the copy is made only once and shared by all the callers
+// until the map changes, so the returned map must not be modified.
+func (m *KVMap[K, V]) Snapshot() map[K]V {
+	if !m.mu.IsSafe() {
+		return m.data
+	}
+	m.mu.RLock()
+	snapshot := m.snapshot
+	m.mu.RUnlock()
+	if snapshot != nil {
+		return snapshot
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.snapshot == nil {
+		m.snapshot = make(map[K]V, len(m.data))
+		for k, v := range m.data {
+			m.snapshot[k] = v
+		}
+	}
+	return m.snapshot
+}
+
+// MapCopy returns a copy of the underlying data of the hash map.
+func (m *KVMap[K, V]) MapCopy() map[K]V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	data := make(map[K]V, len(m.data))
+	for k, v := range m.data {
+		data[k] = v
+	}
+	return data
+}
+
+// Set sets key-value to the hash map.
+func (m *KVMap[K, V]) Set(key K, value V) {
+	m.mu.Lock()
+	m.snapshot = nil
+	if m.data == nil {
+		m.data = make(map[K]V)
+	}
+	m.data[key] = value
+	m.mu.Unlock()
+}
+
+// Sets batch sets key-values to the hash map.
+func (m *KVMap[K, V]) Sets(data map[K]V) {
+	m.mu.Lock()
+	m.snapshot = nil
+	if m.data == nil {
+		m.data = data
+	} else {
+		for k, v := range data {
+			m.data[k] = v
+		}
+	}
+	m.mu.Unlock()
+}
+
+// Search searches the map with given `key`.
+// Second return parameter `found` is true if key was found, otherwise false.
+func (m *KVMap[K, V]) Search(key K) (value V, found bool) {
+	m.mu.RLock()
+	if m.data != nil {
+		value, found = m.data[key]
+	}
+	m.mu.RUnlock()
+	return
+}
+
+// Get returns the value by given `key`.
+// It returns the zero value of `V` if `key` does not exist.
+func (m *KVMap[K, V]) Get(key K) (value V) {
+	value, _ = m.Search(key)
+	return
+}
+
+// GetOrSet returns the value by key,
+// or sets value with given `value` if it does not exist and then returns this value.
+func (m *KVMap[K, V]) GetOrSet(key K, value V) V {
+	if v, ok := m.Search(key); ok {
+		return v
+	}
+	return m.doSetWithLockCheck(key, func() V { return value })
+}
+
+// GetOrSetFunc returns the value by key,
+// or sets value with returned value of callback function `f` if it does not exist
+// and then returns this value.
+func (m *KVMap[K, V]) GetOrSetFunc(key K, f func() V) V {
+	if v, ok := m.Search(key); ok {
+		return v
+	}
+	value := f()
+	return m.doSetWithLockCheck(key, func() V { return value })
+}
+
+// GetOrSetFuncLock returns the value by key,
+// or sets value with returned value of callback function `f` if it does not exist
+// and then returns this value.
+//
+// GetOrSetFuncLock differs with GetOrSetFunc function is that it executes function `f`
+// with mutex.Lock of the hash map, which guarantees `f` is called at most once for `key`.
+func (m *KVMap[K, V]) GetOrSetFuncLock(key K, f func() V) V {
+	if v, ok := m.Search(key); ok {
+		return v
+	}
+	return m.doSetWithLockCheck(key, f)
+}
+
+// doSetWithLockCheck checks whether value of the key exists with mutex.Lock,
+// if not exists, set value to the map with the returned value of `f`.
+// It returns the existing value or the value returned by `f`.
+func (m *KVMap[K, V]) doSetWithLockCheck(key K, f func() V) V {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.data == nil {
+		m.data = make(map[K]V)
+	}
+	if v, ok := m.data[key]; ok {
+		return v
+	}
+	value := f()
+	m.snapshot = nil
+	m.data[key] = value
+	return value
+}
+
+// SetIfNotExist sets `value` to the map if the `key` does not exist, and then returns true.
+// It returns false if `key` exists, and `value` would be ignored.
+func (m *KVMap[K, V]) SetIfNotExist(key K, value V) bool {
+	var set bool
+	m.doSetWithLockCheck(key, func() V {
+		set = true
+		return value
+	})
+	return set
+}
+
+// SetIfNotExistFunc sets value with return value of callback function `f`, and then returns true.
+// It returns false if `key` exists, and `value` would be ignored.
+func (m *KVMap[K, V]) SetIfNotExistFunc(key K, f func() V) bool {
+	if m.Contains(key) {
+		return false
+	}
+	return m.SetIfNotExist(key, f())
+}
+
+// SetIfNotExistFuncLock sets value with return value of callback function `f`, and then returns true.
+// It returns false if `key` exists, and `value` would be ignored.
+//
+// SetIfNotExistFuncLock differs with SetIfNotExistFunc function is that
+// it executes function `f` with mutex.Lock of the hash map.
+func (m *KVMap[K, V]) SetIfNotExistFuncLock(key K, f func() V) bool {
+	var set bool
+	m.doSetWithLockCheck(key, func() V {
+		set = true
+		return f()
+	})
+	return set
+}
+
+// Compute atomically computes a new value for `key` with callback function `f`
+// within mutex.Lock of the hash map.
+// The callback function `f` receives the current value and whether it exists,
+// and returns the new value and whether keeping it in the map.
+// If `keep` is false, the `key` is deleted from the map.
+// It returns the new value and whether it is kept in the map.
+func (m *KVMap[K, V]) Compute(key K, f func(value V, exist bool) (newValue V, keep bool)) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.snapshot = nil
+	if m.data == nil {
+		m.data = make(map[K]V)
+	}
+	oldValue, exist := m.data[key]
+	newValue, keep := f(oldValue, exist)
+	if keep {
+		m.data[key] = newValue
+	} else if exist {
+		delete(m.data, key)
+	}
+	return newValue, keep
+}
+
+// Removes batch deletes values of the map by keys.
+func (m *KVMap[K, V]) Removes(keys []K) {
+	m.mu.Lock()
+	m.snapshot = nil
+	if m.data != nil {
+		for _, key := range keys {
+			delete(m.data, key)
+		}
+	}
+	m.mu.Unlock()
+}
+
+// Remove deletes value from map by given `key`, and return this deleted value.
+func (m *KVMap[K, V]) Remove(key K) (value V) {
+	m.mu.Lock()
+	m.snapshot = nil
+	if m.data != nil {
+		var ok bool
+		if value, ok = m.data[key]; ok {
+			delete(m.data, key)
+		}
+	}
+	m.mu.Unlock()
+	return
+}
+
+// Keys returns all keys of the map as a slice.
+func (m *KVMap[K, V]) Keys() []K {
+	m.mu.RLock()
+	var (
+		keys  = make([]K, len(m.data))
+		index = 0
+	)
+	for key := range m.data {
+		keys[index] = key
+		index++
+	}
+	m.mu.RUnlock()
+	return keys
+}
+
+// Values returns all values of the map as a slice.
+func (m *KVMap[K, V]) Values() []V {
+	m.mu.RLock()
+	var (
+		values = make([]V, len(m.data))
+		index  = 0
+	)
+	for _, value := range m.data {
+		values[index] = value
+		index++
+	}
+	m.mu.RUnlock()
+	return values
+}
+
+// Contains checks whether a key exists.
+// It returns true if the `key` exists, or else false.
+func (m *KVMap[K, V]) Contains(key K) bool {
+	_, ok := m.Search(key)
+	return ok
+}
+
+// Size returns the size of the map.
+func (m *KVMap[K, V]) Size() int {
+	m.mu.RLock()
+	length := len(m.data)
+	m.mu.RUnlock()
+	return length
+}
+
+// IsEmpty checks whether the map is empty.
+// It returns true if map is empty, or else false.
+func (m *KVMap[K, V]) IsEmpty() bool {
+	return m.Size() == 0
+}
+
+// Clear deletes all data of the map, it will remake a new underlying data map.
+func (m *KVMap[K, V]) Clear() {
+	m.mu.Lock()
+	m.snapshot = nil
+	m.data = make(map[K]V)
+	m.mu.Unlock()
+}
+
+// Replace the data of the map with given `data`.
+func (m *KVMap[K, V]) Replace(data map[K]V) {
+	m.mu.Lock()
+	m.snapshot = nil
+	m.data = data
+	m.mu.Unlock()
+}
+
+// LockFunc locks writing with given callback function `f` within RWMutex.Lock.
+func (m *KVMap[K, V]) LockFunc(f func(m map[K]V)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.snapshot = nil
+	f(m.data)
+}
+
+// RLockFunc locks reading with given callback function `f` within RWMutex.RLock.
+func (m *KVMap[K, V]) RLockFunc(f func(m map[K]V)) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	f(m.data)
+}
+
+// String returns the map as a string.
+func (m *KVMap[K, V]) String() string {
+	if m == nil {
+		return ""
+	}
+	b, _ := m.MarshalJSON()
+	return string(b)
+}
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal.
+func (m *KVMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Snapshot())
+}
+
+// UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal.
+func (m *KVMap[K, V]) UnmarshalJSON(b []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.snapshot = nil
+	if m.data == nil {
+		m.data = make(map[K]V)
+	}
+	return json.UnmarshalUseNumber(b, &m.data)
+}
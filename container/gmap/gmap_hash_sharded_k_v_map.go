@@ -0,0 +1,257 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with gm file,
+// You can obtain one at https://github.com/gogf/gf.
+//
+
+package gmap
+
+import (
+	"fmt"
+
+	"github.com/gogf/gf/v2/encoding/ghash"
+)
+
+const (
+	// defaultShardCount is the default shard count of ShardedKVMap.
+	defaultShardCount = 32
+)
+
+// ShardedKVMap implements concurrent-safe generic map[K]V, which splits its data into
+// multiple shards that are locked separately, reducing lock contention for high-contention workloads.
+type ShardedKVMap[K comparable, V any] struct {
+	shards []*KVMap[K, V]
+	hasher func(key K) uint64
+}
+
+// NewShardedKVMap returns an empty concurrent-safe ShardedKVMap object.
+// The parameter `shardCount` specifies the count of shards, which is 32 in default if it is not greater than 0.
+// The optional parameter `hasher` specifies the hash function for locating the shard of a key,
+// which supports string and integer keys efficiently in default.
+func NewShardedKVMap[K comparable, V any](shardCount int, hasher ...func(key K) uint64) *ShardedKVMap[K, V] {
+	if shardCount <= 0 {
+		shardCount = defaultShardCount
+	}
+	m := &ShardedKVMap[K, V]{
+		shards: make([]*KVMap[K, V], shardCount),
+		hasher: defaultShardHasher[K],
+	}
+	if len(hasher) > 0 && hasher[0] != nil {
+		m.hasher = hasher[0]
+	}
+	for i := 0; i < shardCount; i++ {
+		m.shards[i] = NewKVMap[K, V](true)
+	}
+	return m
+}
+
+// defaultShardHasher is the default hash function for locating the shard of a key.
+func defaultShardHasher[K comparable](key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return ghash.BKDR64([]byte(k))
+	case int:
+		return uint64(k)
+	case int8:
+		return uint64(k)
+	case int16:
+		return uint64(k)
+	case int32:
+		return uint64(k)
+	case int64:
+		return uint64(k)
+	case uint:
+		return uint64(k)
+	case uint8:
+		return uint64(k)
+	case uint16:
+		return uint64(k)
+	case uint32:
+		return uint64(k)
+	case uint64:
+		return k
+	case uintptr:
+		return uint64(k)
+	default:
+		return ghash.BKDR64([]byte(fmt.Sprint(k)))
+	}
+}
+
+// getShard returns the shard of given `key`.
+func (m *ShardedKVMap[K, V]) getShard(key K) *KVMap[K, V] {
+	return m.shards[m.hasher(key)%uint64(len(m.shards))]
+}
+
+// ShardCount returns the count of shards.
+func (m *ShardedKVMap[K, V]) ShardCount() int {
+	return len(m.shards)
+}
+
+// Iterator iterates the map readonly with custom callback function `f`.
+// If `f` returns true, then it continues iterating; or false to stop.
+// It iterates the snapshots of shards, so that the writers are not stalled.
+func (m *ShardedKVMap[K, V]) Iterator(f func(k K, v V) bool) {
+	for _, shard := range m.shards {
+		for k, v := range shard.Snapshot() {
+			if !f(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Map returns a copy of all data of the map.
+func (m *ShardedKVMap[K, V]) Map() map[K]V {
+	data := make(map[K]V)
+	for _, shard := range m.shards {
+		shard.RLockFunc(func(shardData map[K]V) {
+			for k, v := range shardData {
+				data[k] = v
+			}
+		})
+	}
+	return data
+}
+
+// Snapshot returns a copy of current map data, which is merged from the snapshots of shards,
+// with which the caller can iterate the map for a long time without holding the locks.
+// Note that the shards are snapshotted one by one, the returned data is not an atomic view of all shards.
+func (m *ShardedKVMap[K, V]) Snapshot() map[K]V {
+	data := make(map[K]V)
+	for _, shard := range m.shards {
+		for k, v := range shard.Snapshot() {
+			data[k] = v
+		}
+	}
+	return data
+}
+
+// Set sets key-value to the map.
+func (m *ShardedKVMap[K, V]) Set(key K, value V) {
+	m.getShard(key).Set(key, value)
+}
+
+// Sets batch sets key-values to the map.
+func (m *ShardedKVMap[K, V]) Sets(data map[K]V) {
+	for k, v := range data {
+		m.getShard(k).Set(k, v)
+	}
+}
+
+// Search searches the map with given `key`.
+// Second return parameter `found` is true if key was found, otherwise false.
+func (m *ShardedKVMap[K, V]) Search(key K) (value V, found bool) {
+	return m.getShard(key).Search(key)
+}
+
+// Get returns the value by given `key`.
+// It returns the zero value of `V` if `key` does not exist.
+func (m *ShardedKVMap[K, V]) Get(key K) (value V) {
+	return m.getShard(key).Get(key)
+}
+
+// GetOrSet returns the value by key,
+// or sets value with given `value` if it does not exist and then returns this value.
+func (m *ShardedKVMap[K, V]) GetOrSet(key K, value V) V {
+	return m.getShard(key).GetOrSet(key, value)
+}
+
+// GetOrSetFunc returns the value by key,
+// or sets value with returned value of callback function `f` if it does not exist
+// and then returns this value.
+func (m *ShardedKVMap[K, V]) GetOrSetFunc(key K, f func() V) V {
+	return m.getShard(key).GetOrSetFunc(key, f)
+}
+
+// GetOrSetFuncLock returns the value by key,
+// or sets value with returned value of callback function `f` if it does not exist
+// and then returns this value.
+// It executes function `f` with mutex.Lock of the shard the `key` belongs to.
+func (m *ShardedKVMap[K, V]) GetOrSetFuncLock(key K, f func() V) V {
+	return m.getShard(key).GetOrSetFuncLock(key, f)
+}
+
+// SetIfNotExist sets `value` to the map if the `key` does not exist, and then returns true.
+// It returns false if `key` exists, and `value` would be ignored.
+func (m *ShardedKVMap[K, V]) SetIfNotExist(key K, value V) bool {
+	return m.getShard(key).SetIfNotExist(key, value)
+}
+
+// Compute atomically computes a new value for `key` with callback function `f`
+// within mutex.Lock of the shard the `key` belongs to, see KVMap.Compute.
+func (m *ShardedKVMap[K, V]) Compute(key K, f func(value V, exist bool) (newValue V, keep bool)) (V, bool) {
+	return m.getShard(key).Compute(key, f)
+}
+
+// Remove deletes value from map by given `key`, and return this deleted value.
+func (m *ShardedKVMap[K, V]) Remove(key K) (value V) {
+	return m.getShard(key).Remove(key)
+}
+
+// Removes batch deletes values of the map by keys.
+func (m *ShardedKVMap[K, V]) Removes(keys []K) {
+	for _, key := range keys {
+		m.getShard(key).Remove(key)
+	}
+}
+
+// Keys returns all keys of the map as a slice.
+func (m *ShardedKVMap[K, V]) Keys() []K {
+	keys := make([]K, 0)
+	for _, shard := range m.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+// Values returns all values of the map as a slice.
+func (m *ShardedKVMap[K, V]) Values() []V {
+	values := make([]V, 0)
+	for _, shard := range m.shards {
+		values = append(values, shard.Values()...)
+	}
+	return values
+}
+
+// Contains checks whether a key exists.
+// It returns true if the `key` exists, or else false.
+func (m *ShardedKVMap[K, V]) Contains(key K) bool {
+	return m.getShard(key).Contains(key)
+}
+
+// Size returns the size of the map.
+func (m *ShardedKVMap[K, V]) Size() int {
+	size := 0
+	for _, shard := range m.shards {
+		size += shard.Size()
+	}
+	return size
+}
+
+// IsEmpty checks whether the map is empty.
+// It returns true if map is empty, or else false.
+func (m *ShardedKVMap[K, V]) IsEmpty() bool {
+	return m.Size() == 0
+}
+
+// Clear deletes all data of the map.
+func (m *ShardedKVMap[K, V]) Clear() {
+	for _, shard := range m.shards {
+		shard.Clear()
+	}
+}
+
+// String returns the map as a string.
+func (m *ShardedKVMap[K, V]) String() string {
+	if m == nil {
+		return ""
+	}
+	b, _ := m.MarshalJSON()
+	return string(b)
+}
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal.
+func (m *ShardedKVMap[K, V]) MarshalJSON() ([]byte, error) {
+	return NewKVMapFrom(m.Map()).MarshalJSON()
+}
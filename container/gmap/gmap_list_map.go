@@ -8,10 +8,11 @@ package gmap
 
 import (
 	"bytes"
-	"fmt"
 
 	"github.com/gogf/gf/v2/container/glist"
 	"github.com/gogf/gf/v2/container/gvar"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/internal/deepcopy"
 	"github.com/gogf/gf/v2/internal/empty"
 	"github.com/gogf/gf/v2/internal/json"
@@ -27,9 +28,10 @@ import (
 //
 // Reference: http://en.wikipedia.org/wiki/Associative_array
 type ListMap struct {
-	mu   rwmutex.RWMutex
-	data map[interface{}]*glist.Element
-	list *glist.List
+	mu            rwmutex.RWMutex
+	data          map[interface{}]*glist.Element
+	list          *glist.List
+	orderedNested bool // Whether unmarshalling nested JSON objects as *ListMap, see SetOrderedNested.
 }
 
 type gListMapNode struct {
@@ -545,14 +547,29 @@ func (m ListMap) MarshalJSON() (jsonBytes []byte, err error) {
 		if buffer.Len() > 1 {
 			buffer.WriteByte(',')
 		}
-		buffer.WriteString(fmt.Sprintf(`"%v":%s`, key, valueBytes))
+		keyBytes, _ := json.Marshal(gconv.String(key))
+		buffer.Write(keyBytes)
+		buffer.WriteByte(':')
+		buffer.Write(valueBytes)
 		return true
 	})
 	buffer.WriteByte('}')
-	return buffer.Bytes(), nil
+	return buffer.Bytes(), err
+}
+
+// SetOrderedNested enables/disables unmarshalling the nested JSON objects as *ListMap in UnmarshalJSON,
+// which keeps the key order of nested JSON objects. The nested JSON objects are unmarshalled as
+// map[string]interface{} in default.
+func (m *ListMap) SetOrderedNested(enabled bool) *ListMap {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.orderedNested = enabled
+	return m
 }
 
 // UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal.
+// It preserves the key order of the JSON object. The nested JSON objects are unmarshalled as
+// map[string]interface{}, or *ListMap keeping their key order if SetOrderedNested is enabled.
 func (m *ListMap) UnmarshalJSON(b []byte) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -560,20 +577,97 @@ func (m *ListMap) UnmarshalJSON(b []byte) error {
 		m.data = make(map[interface{}]*glist.Element)
 		m.list = glist.New()
 	}
-	var data map[string]interface{}
-	if err := json.UnmarshalUseNumber(b, &data); err != nil {
-		return err
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	token, err := decoder.Token()
+	if err != nil {
+		return gerror.Wrap(err, `json.Decoder.Token failed`)
 	}
-	for key, value := range data {
+	switch token {
+	case nil:
+		return nil
+	case json.Delim('{'):
+		return m.doUnmarshalJSONObject(decoder)
+	default:
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid JSON object: %s`, b)
+	}
+}
+
+// doUnmarshalJSONObject reads the key-value pairs of a JSON object from `decoder` in order,
+// which expects the beginning delimiter of the object has been read.
+func (m *ListMap) doUnmarshalJSONObject(decoder *json.Decoder) error {
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return gerror.Wrap(err, `json.Decoder.Token failed`)
+		}
+		key := token.(string)
+		value, err := listMapUnmarshalJSONValue(decoder, m.orderedNested)
+		if err != nil {
+			return err
+		}
 		if e, ok := m.data[key]; !ok {
 			m.data[key] = m.list.PushBack(&gListMapNode{key, value})
 		} else {
 			e.Value = &gListMapNode{key, value}
 		}
 	}
+	// The ending delimiter of the object.
+	if _, err := decoder.Token(); err != nil {
+		return gerror.Wrap(err, `json.Decoder.Token failed`)
+	}
 	return nil
 }
 
+// listMapUnmarshalJSONValue reads and returns the next JSON value from `decoder`, in which the
+// JSON objects are unmarshalled as *ListMap if `ordered` is true, or else map[string]interface{}.
+func listMapUnmarshalJSONValue(decoder *json.Decoder, ordered bool) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, gerror.Wrap(err, `json.Decoder.Token failed`)
+	}
+	switch token {
+	case json.Delim('{'):
+		if ordered {
+			m := NewListMap().SetOrderedNested(true)
+			if err = m.doUnmarshalJSONObject(decoder); err != nil {
+				return nil, err
+			}
+			return m, nil
+		}
+		object := make(map[string]interface{})
+		for decoder.More() {
+			if token, err = decoder.Token(); err != nil {
+				return nil, gerror.Wrap(err, `json.Decoder.Token failed`)
+			}
+			if object[token.(string)], err = listMapUnmarshalJSONValue(decoder, ordered); err != nil {
+				return nil, err
+			}
+		}
+		// The ending delimiter of the object.
+		if _, err = decoder.Token(); err != nil {
+			return nil, gerror.Wrap(err, `json.Decoder.Token failed`)
+		}
+		return object, nil
+	case json.Delim('['):
+		array := make([]interface{}, 0)
+		for decoder.More() {
+			value, err := listMapUnmarshalJSONValue(decoder, ordered)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		// The ending delimiter of the array.
+		if _, err = decoder.Token(); err != nil {
+			return nil, gerror.Wrap(err, `json.Decoder.Token failed`)
+		}
+		return array, nil
+	default:
+		return token, nil
+	}
+}
+
 // UnmarshalValue is an interface implement which sets any type of value for map.
 func (m *ListMap) UnmarshalValue(value interface{}) (err error) {
 	m.mu.Lock()
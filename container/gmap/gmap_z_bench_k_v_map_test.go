@@ -0,0 +1,102 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with gm file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// go test *.go -bench=".*" -benchmem
+
+package gmap_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/gmap"
+)
+
+var (
+	kvMap        = gmap.NewKVMap[int, int](true)
+	shardedKVMap = gmap.NewShardedKVMap[int, int](32)
+	kvSyncMap    = sync.Map{}
+)
+
+func Benchmark_KVMap_Set(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			kvMap.Set(i, i)
+			i++
+		}
+	})
+}
+
+func Benchmark_ShardedKVMap_Set(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			shardedKVMap.Set(i, i)
+			i++
+		}
+	})
+}
+
+func Benchmark_SyncMap_Set(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			kvSyncMap.Store(i, i)
+			i++
+		}
+	})
+}
+
+func Benchmark_KVMap_Get(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			kvMap.Get(i)
+			i++
+		}
+	})
+}
+
+func Benchmark_ShardedKVMap_Get(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			shardedKVMap.Get(i)
+			i++
+		}
+	})
+}
+
+func Benchmark_SyncMap_Get(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			kvSyncMap.Load(i)
+			i++
+		}
+	})
+}
+
+func Benchmark_ShardedKVMap_GetOrSetFunc(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			shardedKVMap.GetOrSetFunc(i%1000, func() int { return i })
+			i++
+		}
+	})
+}
+
+func Benchmark_SyncMap_LoadOrStore(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			kvSyncMap.LoadOrStore(i%1000, i)
+			i++
+		}
+	})
+}
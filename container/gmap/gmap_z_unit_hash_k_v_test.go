@@ -0,0 +1,196 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with gm file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmap_test
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_KVMap_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := gmap.NewKVMap[string, int](true)
+		m.Set("a", 1)
+		t.Assert(m.Get("a"), 1)
+		t.Assert(m.Get("b"), 0)
+		t.Assert(m.Size(), 1)
+		t.Assert(m.IsEmpty(), false)
+
+		v, found := m.Search("a")
+		t.Assert(v, 1)
+		t.Assert(found, true)
+		_, found = m.Search("b")
+		t.Assert(found, false)
+
+		t.Assert(m.GetOrSet("b", 2), 2)
+		t.Assert(m.GetOrSet("b", 3), 2)
+		t.Assert(m.GetOrSetFunc("c", func() int { return 3 }), 3)
+		t.Assert(m.GetOrSetFuncLock("c", func() int { return 4 }), 3)
+		t.Assert(m.SetIfNotExist("c", 4), false)
+		t.Assert(m.SetIfNotExist("d", 4), true)
+		t.Assert(m.SetIfNotExistFunc("e", func() int { return 5 }), true)
+		t.Assert(m.SetIfNotExistFuncLock("e", func() int { return 6 }), false)
+		t.Assert(m.Map(), map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5})
+
+		keys := m.Keys()
+		sort.Strings(keys)
+		t.Assert(keys, []string{"a", "b", "c", "d", "e"})
+		values := m.Values()
+		sort.Ints(values)
+		t.Assert(values, []int{1, 2, 3, 4, 5})
+
+		t.Assert(m.Remove("a"), 1)
+		m.Removes([]string{"b", "c"})
+		t.Assert(m.Contains("a"), false)
+		t.Assert(m.Clone().Map(), map[string]int{"d": 4, "e": 5})
+		m.Clear()
+		t.Assert(m.Size(), 0)
+	})
+}
+
+func Test_KVMap_Compute(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := gmap.NewKVMap[string, int](true)
+		incr := func(value int, exist bool) (int, bool) {
+			return value + 1, true
+		}
+		v, kept := m.Compute("a", incr)
+		t.Assert(v, 1)
+		t.Assert(kept, true)
+		v, _ = m.Compute("a", incr)
+		t.Assert(v, 2)
+
+		_, kept = m.Compute("a", func(value int, exist bool) (int, bool) {
+			return 0, false
+		})
+		t.Assert(kept, false)
+		t.Assert(m.Contains("a"), false)
+	})
+}
+
+func Test_KVMap_Json(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := gmap.NewKVMapFrom(map[string]int{"a": 1})
+		b, err := json.Marshal(m)
+		t.AssertNil(err)
+		t.Assert(b, `{"a":1}`)
+		t.Assert(m.String(), `{"a":1}`)
+
+		m2 := gmap.NewKVMap[string, int]()
+		t.AssertNil(json.Unmarshal(b, m2))
+		t.Assert(m2.Get("a"), 1)
+	})
+}
+
+func Test_ShardedKVMap_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := gmap.NewShardedKVMap[int, string](8)
+		t.Assert(m.ShardCount(), 8)
+		for i := 0; i < 100; i++ {
+			m.Set(i, "v")
+		}
+		t.Assert(m.Size(), 100)
+		t.Assert(len(m.Keys()), 100)
+		t.Assert(len(m.Values()), 100)
+		t.Assert(len(m.Map()), 100)
+		t.Assert(m.Get(10), "v")
+		t.Assert(m.Contains(99), true)
+		t.Assert(m.Contains(100), false)
+		t.Assert(m.GetOrSet(100, "x"), "x")
+		t.Assert(m.SetIfNotExist(100, "y"), false)
+		t.Assert(m.Remove(100), "x")
+		m.Removes([]int{0, 1})
+		t.Assert(m.Size(), 98)
+
+		count := 0
+		m.Iterator(func(k int, v string) bool {
+			count++
+			return count < 10
+		})
+		t.Assert(count, 10)
+		m.Clear()
+		t.Assert(m.IsEmpty(), true)
+	})
+	// Default shard count and custom hasher.
+	gtest.C(t, func(t *gtest.T) {
+		m := gmap.NewShardedKVMap[string, int](0, func(key string) uint64 {
+			return uint64(len(key))
+		})
+		t.Assert(m.ShardCount(), 32)
+		m.Sets(map[string]int{"a": 1, "bb": 2})
+		t.Assert(m.String(), `{"a":1,"bb":2}`)
+	})
+}
+
+func Test_ShardedKVMap_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg sync.WaitGroup
+			m  = gmap.NewShardedKVMap[string, int](16)
+		)
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				m.Compute("counter", func(value int, exist bool) (int, bool) {
+					return value + 1, true
+				})
+			}()
+		}
+		wg.Wait()
+		t.Assert(m.Get("counter"), 100)
+	})
+}
+
+func Test_ShardedKVMap_Snapshot(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := gmap.NewShardedKVMap[string, int](4)
+		m.Sets(map[string]int{"a": 1, "b": 2})
+		s := m.Snapshot()
+		t.Assert(s, map[string]int{"a": 1, "b": 2})
+
+		// The snapshot is isolated from later changes of the map, and vice versa.
+		m.Set("a", 10)
+		m.Remove("b")
+		m.Set("c", 3)
+		t.Assert(s, map[string]int{"a": 1, "b": 2})
+		s["d"] = 4
+		t.Assert(m.Contains("d"), false)
+		t.Assert(m.Snapshot(), map[string]int{"a": 10, "c": 3})
+
+		// Writing within iteration does not deadlock.
+		m.Iterator(func(k string, v int) bool {
+			m.Set(k, v*10)
+			return true
+		})
+		t.Assert(m.Snapshot(), map[string]int{"a": 100, "c": 30})
+	})
+}
+
+func Test_KVMap_Snapshot(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		m := gmap.NewKVMapFrom(map[string]int{"a": 1, "b": 2}, true)
+		s := m.Snapshot()
+		t.Assert(s, map[string]int{"a": 1, "b": 2})
+
+		// Writing within iteration does not deadlock, and resets the snapshot.
+		m.Iterator(func(k string, v int) bool {
+			m.Set(k+k, v*10)
+			return true
+		})
+		t.Assert(s, map[string]int{"a": 1, "b": 2})
+		t.Assert(m.Snapshot(), map[string]int{"a": 1, "b": 2, "aa": 10, "bb": 20})
+		m.GetOrSet("a", 100)
+		t.Assert(m.Get("a"), 1)
+		t.Assert(m.Size(), 4)
+	})
+}
@@ -377,6 +377,38 @@ func TestListMap_MarshalJSON(t *testing.T) {
 	})
 }
 
+func TestListMap_UnmarshalJSON_Order(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			content = `{"z":1,"a":{"y":true,"b":null},"m":[{"d":"1","c":2}],"k\"":"v"}`
+			m       = gmap.NewOrderedMap().SetOrderedNested(true)
+		)
+		err := json.Unmarshal([]byte(content), m)
+		t.AssertNil(err)
+		t.Assert(m.Keys(), g.Slice{"z", "a", "m", `k"`})
+		t.Assert(m.Get("a").(*gmap.ListMap).Keys(), g.Slice{"y", "b"})
+		t.Assert(m.Get("m").([]interface{})[0].(*gmap.ListMap).Keys(), g.Slice{"d", "c"})
+		b, err := json.Marshal(m)
+		t.AssertNil(err)
+		t.Assert(b, content)
+	})
+	// The nested JSON objects are unmarshalled as map[string]interface{} in default.
+	gtest.C(t, func(t *gtest.T) {
+		m := gmap.NewOrderedMap()
+		err := json.Unmarshal([]byte(`{"z":1,"a":{"y":true,"b":null},"m":[{"d":"1"}]}`), m)
+		t.AssertNil(err)
+		t.Assert(m.Keys(), g.Slice{"z", "a", "m"})
+		t.Assert(m.Get("a").(map[string]interface{}), g.Map{"y": true, "b": nil})
+		t.Assert(m.Get("m").([]interface{})[0].(map[string]interface{}), g.Map{"d": "1"})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		m := gmap.NewOrderedMap()
+		t.AssertNil(m.UnmarshalJSON([]byte(`null`)))
+		t.AssertNE(m.UnmarshalJSON([]byte(`[1]`)), nil)
+		t.AssertNE(m.UnmarshalJSON([]byte(`{"a":`)), nil)
+	})
+}
+
 func TestListMap_DeepCopy(t *testing.T) {
 	gtest.C(t, func(t *gtest.T) {
 		m := gmap.NewListMap()
@@ -0,0 +1,210 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gqueue
+
+import (
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+)
+
+// RingQueue is a lock-free, fixed-capacity and concurrent-safe ring buffer(circular queue)
+// for multiple producers and multiple consumers, which is designed for high-throughput pipelines.
+//
+// Different from Queue, it uses no channel or list, each slot of the ring has a sequence number
+// to coordinate the producers and consumers with atomic operations.
+type RingQueue[T any] struct {
+	head   uint64             // Next position for reading, it should be the first attribute for 64-bit alignment.
+	_      [56]byte           // Padding to avoid false sharing between head and tail.
+	tail   uint64             // Next position for writing.
+	_      [56]byte           // Padding to avoid false sharing between tail and others.
+	mask   uint64             // Mask for locating the slot of a position, which is capacity-1.
+	slots  []ringQueueSlot[T] // Underlying slots of the ring.
+	closed *gtype.Bool        // Whether queue is closed.
+}
+
+// ringQueueSlot is the slot of RingQueue.
+type ringQueueSlot[T any] struct {
+	sequence uint64 // Sequence of the slot, which tells whether the slot is readable or writable for a position.
+	value    T
+}
+
+const (
+	ringQueueSpinTimes     = 64                   // Spin times before sleeping for blocking operations.
+	ringQueueMaxSleepDelay = 1 * time.Millisecond // Max sleeping delay for blocking operations.
+	ringQueueMinSleepDelay = 1 * time.Microsecond // Min sleeping delay for blocking operations.
+)
+
+// NewRingQueue creates and returns a ring queue of given `capacity`.
+// The `capacity` is rounded up to the power of 2, and it is 2 at least.
+func NewRingQueue[T any](capacity int) *RingQueue[T] {
+	size := uint64(2)
+	for size < uint64(capacity) {
+		size <<= 1
+	}
+	q := &RingQueue[T]{
+		mask:   size - 1,
+		slots:  make([]ringQueueSlot[T], size),
+		closed: gtype.NewBool(),
+	}
+	for i := range q.slots {
+		q.slots[i].sequence = uint64(i)
+	}
+	return q
+}
+
+// TryPush pushes `value` into the queue without blocking.
+// It returns false if the queue is full or closed.
+func (q *RingQueue[T]) TryPush(value T) bool {
+	if q.closed.Val() {
+		return false
+	}
+	position := atomic.LoadUint64(&q.tail)
+	for {
+		var (
+			slot     = &q.slots[position&q.mask]
+			sequence = atomic.LoadUint64(&slot.sequence)
+			diff     = int64(sequence - position)
+		)
+		switch {
+		case diff == 0:
+			if atomic.CompareAndSwapUint64(&q.tail, position, position+1) {
+				slot.value = value
+				atomic.StoreUint64(&slot.sequence, position+1)
+				return true
+			}
+		case diff < 0:
+			// The slot is not consumed yet, the queue is full.
+			return false
+		}
+		position = atomic.LoadUint64(&q.tail)
+	}
+}
+
+// TryPop pops an item from the queue in FIFO way without blocking.
+// The `found` is false if the queue is empty.
+func (q *RingQueue[T]) TryPop() (value T, found bool) {
+	position := atomic.LoadUint64(&q.head)
+	for {
+		var (
+			slot     = &q.slots[position&q.mask]
+			sequence = atomic.LoadUint64(&slot.sequence)
+			diff     = int64(sequence - (position + 1))
+		)
+		switch {
+		case diff == 0:
+			if atomic.CompareAndSwapUint64(&q.head, position, position+1) {
+				var empty T
+				value = slot.value
+				slot.value = empty // avoid memory leaks
+				atomic.StoreUint64(&slot.sequence, position+q.mask+1)
+				return value, true
+			}
+		case diff < 0:
+			// The slot is not produced yet, the queue is empty.
+			return
+		}
+		position = atomic.LoadUint64(&q.head)
+	}
+}
+
+// Push pushes `value` into the queue, it blocks if the queue is full.
+// It returns false if the queue is closed.
+func (q *RingQueue[T]) Push(value T) bool {
+	var backoff ringQueueBackoff
+	for !q.TryPush(value) {
+		if q.closed.Val() {
+			return false
+		}
+		backoff.Wait()
+	}
+	return true
+}
+
+// Pop pops an item from the queue in FIFO way, it blocks if the queue is empty.
+// The `found` is false if the queue is closed and there's no item left in the queue.
+func (q *RingQueue[T]) Pop() (value T, found bool) {
+	var backoff ringQueueBackoff
+	for {
+		if value, found = q.TryPop(); found {
+			return
+		}
+		if q.closed.Val() {
+			// Double check for the items pushed before closing.
+			return q.TryPop()
+		}
+		backoff.Wait()
+	}
+}
+
+// Pops pops at most `size` items from the queue in FIFO way without blocking.
+// It returns an empty slice if the queue is empty.
+func (q *RingQueue[T]) Pops(size int) []T {
+	values := make([]T, 0, size)
+	for len(values) < size {
+		value, found := q.TryPop()
+		if !found {
+			break
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// Close closes the queue.
+// The pushing operations fail after the queue is closed, and the blocking Pop returns
+// immediately once all the remaining items are consumed.
+func (q *RingQueue[T]) Close() {
+	q.closed.Set(true)
+}
+
+// IsClosed checks and returns whether the queue is closed.
+func (q *RingQueue[T]) IsClosed() bool {
+	return q.closed.Val()
+}
+
+// Len returns the length of the queue.
+// Note that the result might not be accurate if there're concurrent operations on the queue.
+func (q *RingQueue[T]) Len() int {
+	var (
+		head = atomic.LoadUint64(&q.head)
+		tail = atomic.LoadUint64(&q.tail)
+	)
+	if tail <= head {
+		return 0
+	}
+	return int(tail - head)
+}
+
+// Cap returns the capacity of the queue.
+func (q *RingQueue[T]) Cap() int {
+	return len(q.slots)
+}
+
+// ringQueueBackoff implements the waiting strategy for blocking operations,
+// which spins at first and then sleeps with exponential increasing delay.
+type ringQueueBackoff struct {
+	times int
+	delay time.Duration
+}
+
+// Wait waits for a while according to current waiting times.
+func (b *ringQueueBackoff) Wait() {
+	if b.times < ringQueueSpinTimes {
+		b.times++
+		runtime.Gosched()
+		return
+	}
+	if b.delay == 0 {
+		b.delay = ringQueueMinSleepDelay
+	} else if b.delay < ringQueueMaxSleepDelay {
+		b.delay *= 2
+	}
+	time.Sleep(b.delay)
+}
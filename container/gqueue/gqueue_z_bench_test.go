@@ -53,3 +53,13 @@ func Benchmark_Channel_PushAndPop(b *testing.B) {
 		<-cany
 	}
 }
+
+var qring = gqueue.NewRingQueue[interface{}](length)
+
+func Benchmark_RingQueue_PushAndPop(b *testing.B) {
+	b.N = bn
+	for i := 0; i < b.N; i++ {
+		qring.Push(i)
+		qring.Pop()
+	}
+}
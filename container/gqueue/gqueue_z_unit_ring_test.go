@@ -0,0 +1,114 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gqueue_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gqueue"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func TestRingQueue_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		q := gqueue.NewRingQueue[int](3)
+		t.Assert(q.Cap(), 4)
+		t.Assert(q.Len(), 0)
+		for i := 0; i < 4; i++ {
+			t.Assert(q.TryPush(i), true)
+		}
+		t.Assert(q.TryPush(4), false)
+		t.Assert(q.Len(), 4)
+
+		v, found := q.TryPop()
+		t.Assert(v, 0)
+		t.Assert(found, true)
+		t.Assert(q.TryPush(4), true)
+		t.Assert(q.Pops(2), []int{1, 2})
+		t.Assert(q.Pops(10), []int{3, 4})
+		t.Assert(q.Pops(10), []int{})
+
+		_, found = q.TryPop()
+		t.Assert(found, false)
+	})
+}
+
+func TestRingQueue_Close(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		q := gqueue.NewRingQueue[int](2)
+		t.Assert(q.Push(1), true)
+		q.Close()
+		t.Assert(q.IsClosed(), true)
+		t.Assert(q.Push(2), false)
+		v, found := q.Pop()
+		t.Assert(v, 1)
+		t.Assert(found, true)
+		_, found = q.Pop()
+		t.Assert(found, false)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			q    = gqueue.NewRingQueue[int](2)
+			done = make(chan bool)
+		)
+		go func() {
+			_, found := q.Pop()
+			done <- found
+		}()
+		time.Sleep(10 * time.Millisecond)
+		q.Close()
+		t.Assert(<-done, false)
+	})
+}
+
+func TestRingQueue_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			q         = gqueue.NewRingQueue[int](16)
+			producers = 4
+			consumers = 4
+			count     = 10000
+			wg        sync.WaitGroup
+			mu        sync.Mutex
+			sum       int
+			total     int
+		)
+		for i := 0; i < consumers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					v, found := q.Pop()
+					if !found {
+						return
+					}
+					mu.Lock()
+					sum += v
+					total++
+					mu.Unlock()
+				}
+			}()
+		}
+		var producerWg sync.WaitGroup
+		for i := 0; i < producers; i++ {
+			producerWg.Add(1)
+			go func() {
+				defer producerWg.Done()
+				for j := 1; j <= count; j++ {
+					q.Push(j)
+				}
+			}()
+		}
+		producerWg.Wait()
+		q.Close()
+		wg.Wait()
+		t.Assert(total, producers*count)
+		t.Assert(sum, producers*count*(count+1)/2)
+	})
+}
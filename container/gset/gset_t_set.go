@@ -0,0 +1,317 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+//
+
+package gset
+
+import (
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/internal/rwmutex"
+)
+
+// TSet is a generic set consisted of items in type `T`,
+// which needs no boxing and type assertion for its items.
+type TSet[T comparable] struct {
+	mu       rwmutex.RWMutex
+	data     map[T]struct{}
+	snapshot []T // Shared readonly copy of items for Snapshot, which is reset when items change.
+}
+
+// NewTSet create and returns a new set, which contains un-repeated items.
+// The parameter `safe` is used to specify whether using set in concurrent-safety,
+// which is false in default.
+func NewTSet[T comparable](safe ...bool) *TSet[T] {
+	return &TSet[T]{
+		mu:   rwmutex.Create(safe...),
+		data: make(map[T]struct{}),
+	}
+}
+
+// NewTSetFrom returns a new set from `items`.
+func NewTSetFrom[T comparable](items []T, safe ...bool) *TSet[T] {
+	m := make(map[T]struct{}, len(items))
+	for _, v := range items {
+		m[v] = struct{}{}
+	}
+	return &TSet[T]{
+		mu:   rwmutex.Create(safe...),
+		data: m,
+	}
+}
+
+// Iterator iterates the set readonly with given callback function `f`,
+// if `f` returns true then continue iterating; or false to stop.
+func (set *TSet[T]) Iterator(f func(v T) bool) {
+	for _, k := range set.Snapshot() {
+		if !f(k) {
+			break
+		}
+	}
+}
+
+// Add adds one or multiple items to the set.
+func (set *TSet[T]) Add(items ...T) {
+	set.mu.Lock()
+	set.snapshot = nil
+	if set.data == nil {
+		set.data = make(map[T]struct{})
+	}
+	for _, v := range items {
+		set.data[v] = struct{}{}
+	}
+	set.mu.Unlock()
+}
+
+// AddIfNotExist checks whether item exists in the set,
+// it adds the item to set and returns true if it does not exist in the set,
+// or else it does nothing and returns false.
+func (set *TSet[T]) AddIfNotExist(item T) bool {
+	set.mu.Lock()
+	defer set.mu.Unlock()
+	set.snapshot = nil
+	if set.data == nil {
+		set.data = make(map[T]struct{})
+	}
+	if _, ok := set.data[item]; !ok {
+		set.data[item] = struct{}{}
+		return true
+	}
+	return false
+}
+
+// Contains checks whether the set contains `item`.
+func (set *TSet[T]) Contains(item T) bool {
+	var ok bool
+	set.mu.RLock()
+	if set.data != nil {
+		_, ok = set.data[item]
+	}
+	set.mu.RUnlock()
+	return ok
+}
+
+// Remove deletes `item` from set.
+func (set *TSet[T]) Remove(item T) {
+	set.mu.Lock()
+	set.snapshot = nil
+	if set.data != nil {
+		delete(set.data, item)
+	}
+	set.mu.Unlock()
+}
+
+// Size returns the size of the set.
+func (set *TSet[T]) Size() int {
+	set.mu.RLock()
+	l := len(set.data)
+	set.mu.RUnlock()
+	return l
+}
+
+// Clear deletes all items of the set.
+func (set *TSet[T]) Clear() {
+	set.mu.Lock()
+	set.snapshot = nil
+	set.data = make(map[T]struct{})
+	set.mu.Unlock()
+}
+
+// Slice returns the items of the set as slice.
+func (set *TSet[T]) Slice() []T {
+	set.mu.RLock()
+	var (
+		i   = 0
+		ret = make([]T, len(set.data))
+	)
+	for item := range set.data {
+		ret[i] = item
+		i++
+	}
+	set.mu.RUnlock()
+	return ret
+}
+
+// Snapshot returns a readonly copy of the items of the set as slice, with which the caller can
+// iterate the set for a long time without holding the lock, so that writers are not stalled.
+//
+// In concurrent-safety usage, the copy is made only once and shared by all the callers
+// until the set changes, so the returned slice must not be modified.
+func (set *TSet[T]) Snapshot() []T {
+	if !set.mu.IsSafe() {
+		return set.Slice()
+	}
+	set.mu.RLock()
+	snapshot := set.snapshot
+	set.mu.RUnlock()
+	if snapshot != nil {
+		return snapshot
+	}
+	set.mu.Lock()
+	defer set.mu.Unlock()
+	if set.snapshot == nil {
+		set.snapshot = make([]T, 0, len(set.data))
+		for item := range set.data {
+			set.snapshot = append(set.snapshot, item)
+		}
+	}
+	return set.snapshot
+}
+
+// String returns items as a string, which implements like json.Marshal does.
+func (set *TSet[T]) String() string {
+	if set == nil {
+		return ""
+	}
+	b, _ := set.MarshalJSON()
+	return string(b)
+}
+
+// LockFunc locks writing with callback function `f`.
+func (set *TSet[T]) LockFunc(f func(m map[T]struct{})) {
+	set.mu.Lock()
+	defer set.mu.Unlock()
+	set.snapshot = nil
+	f(set.data)
+}
+
+// RLockFunc locks reading with callback function `f`.
+func (set *TSet[T]) RLockFunc(f func(m map[T]struct{})) {
+	set.mu.RLock()
+	defer set.mu.RUnlock()
+	f(set.data)
+}
+
+// Equal checks whether the two sets equal.
+func (set *TSet[T]) Equal(other *TSet[T]) bool {
+	if set == other {
+		return true
+	}
+	set.mu.RLock()
+	defer set.mu.RUnlock()
+	other.mu.RLock()
+	defer other.mu.RUnlock()
+	if len(set.data) != len(other.data) {
+		return false
+	}
+	for key := range set.data {
+		if _, ok := other.data[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubsetOf checks whether the current set is a sub-set of `other`.
+func (set *TSet[T]) IsSubsetOf(other *TSet[T]) bool {
+	if set == other {
+		return true
+	}
+	set.mu.RLock()
+	defer set.mu.RUnlock()
+	other.mu.RLock()
+	defer other.mu.RUnlock()
+	for key := range set.data {
+		if _, ok := other.data[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns a new set which is the union of `set` and `others`.
+// Which means, all the items in `newSet` are in `set` or in any of `others`.
+func (set *TSet[T]) Union(others ...*TSet[T]) (newSet *TSet[T]) {
+	view := set.View()
+	for _, other := range others {
+		view = view.Union(other.View())
+	}
+	return view.Set()
+}
+
+// Intersect returns a new set which is the intersection from `set` to `others`.
+// Which means, all the items in `newSet` are in `set` and also in all of `others`.
+func (set *TSet[T]) Intersect(others ...*TSet[T]) (newSet *TSet[T]) {
+	view := set.View()
+	for _, other := range others {
+		view = view.Intersect(other.View())
+	}
+	return view.Set()
+}
+
+// Diff returns a new set which is the difference set from `set` to `others`.
+// Which means, all the items in `newSet` are in `set` but not in any of `others`.
+func (set *TSet[T]) Diff(others ...*TSet[T]) (newSet *TSet[T]) {
+	view := set.View()
+	for _, other := range others {
+		view = view.Diff(other.View())
+	}
+	return view.Set()
+}
+
+// SymmetricDiff returns a new set which is the symmetric difference of `set` and `other`.
+// Which means, all the items in `newSet` are in either `set` or `other` but not in both.
+func (set *TSet[T]) SymmetricDiff(other *TSet[T]) (newSet *TSet[T]) {
+	return set.View().SymmetricDiff(other.View()).Set()
+}
+
+// Merge adds items from `others` sets into `set`.
+func (set *TSet[T]) Merge(others ...*TSet[T]) *TSet[T] {
+	for _, other := range others {
+		if set != other {
+			set.Add(other.Slice()...)
+		}
+	}
+	return set
+}
+
+// Pop randomly pops an item from set.
+// The `found` is false if the set is empty.
+func (set *TSet[T]) Pop() (item T, found bool) {
+	set.mu.Lock()
+	defer set.mu.Unlock()
+	set.snapshot = nil
+	for k := range set.data {
+		delete(set.data, k)
+		return k, true
+	}
+	return
+}
+
+// Walk applies a user supplied function `f` to every item of set.
+func (set *TSet[T]) Walk(f func(item T) T) *TSet[T] {
+	set.mu.Lock()
+	defer set.mu.Unlock()
+	set.snapshot = nil
+	m := make(map[T]struct{}, len(set.data))
+	for k, v := range set.data {
+		m[f(k)] = v
+	}
+	set.data = m
+	return set
+}
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal.
+func (set *TSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.Snapshot())
+}
+
+// UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal.
+func (set *TSet[T]) UnmarshalJSON(b []byte) error {
+	var array []T
+	if err := json.UnmarshalUseNumber(b, &array); err != nil {
+		return err
+	}
+	set.mu.Lock()
+	defer set.mu.Unlock()
+	set.snapshot = nil
+	if set.data == nil {
+		set.data = make(map[T]struct{}, len(array))
+	}
+	for _, v := range array {
+		set.data[v] = struct{}{}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+//
+
+package gset
+
+// TSetView is a readonly and lazily evaluated view of set, which is usually the result of set algebra.
+// The set algebra operations of TSetView build no intermediate sets, the items are computed
+// on demand when the view is iterated, which avoids huge memory usage for large sets.
+//
+// Note that the view reflects the underlying sets in real time. Each underlying set is iterated
+// with its read lock held, so the underlying sets should not be changed in the callbacks, and a set
+// should not be combined with itself in the view, which acquires its read lock recursively.
+type TSetView[T comparable] struct {
+	iterator func(f func(v T) bool) bool // iterator iterates the items, it returns false if iterating is stopped.
+	contains func(v T) bool              // contains checks whether the view contains the item.
+}
+
+// View returns a lazily evaluated view of current set.
+func (set *TSet[T]) View() *TSetView[T] {
+	return &TSetView[T]{
+		iterator: func(f func(v T) bool) bool {
+			set.mu.RLock()
+			defer set.mu.RUnlock()
+			for k := range set.data {
+				if !f(k) {
+					return false
+				}
+			}
+			return true
+		},
+		contains: set.Contains,
+	}
+}
+
+// Iterator iterates the view readonly with given callback function `f`,
+// if `f` returns true then continue iterating; or false to stop.
+func (view *TSetView[T]) Iterator(f func(v T) bool) {
+	view.iterator(f)
+}
+
+// Contains checks whether the view contains `item`.
+func (view *TSetView[T]) Contains(item T) bool {
+	return view.contains(item)
+}
+
+// Size computes and returns the item count of the view.
+// Note that it iterates all the items of the view.
+func (view *TSetView[T]) Size() int {
+	size := 0
+	view.iterator(func(v T) bool {
+		size++
+		return true
+	})
+	return size
+}
+
+// Slice computes and returns the items of the view as slice.
+func (view *TSetView[T]) Slice() []T {
+	array := make([]T, 0)
+	view.iterator(func(v T) bool {
+		array = append(array, v)
+		return true
+	})
+	return array
+}
+
+// Set computes and returns the items of the view as a new set.
+func (view *TSetView[T]) Set(safe ...bool) *TSet[T] {
+	newSet := NewTSet[T](safe...)
+	view.iterator(func(v T) bool {
+		newSet.data[v] = struct{}{}
+		return true
+	})
+	return newSet
+}
+
+// Union returns a lazy view which is the union of `view` and `other`.
+// Which means, all the items of the returned view are in `view` or in `other`.
+func (view *TSetView[T]) Union(other *TSetView[T]) *TSetView[T] {
+	return &TSetView[T]{
+		iterator: func(f func(v T) bool) bool {
+			if !view.iterator(f) {
+				return false
+			}
+			return other.iterator(func(v T) bool {
+				if view.contains(v) {
+					return true
+				}
+				return f(v)
+			})
+		},
+		contains: func(v T) bool {
+			return view.contains(v) || other.contains(v)
+		},
+	}
+}
+
+// Intersect returns a lazy view which is the intersection from `view` to `other`.
+// Which means, all the items of the returned view are in `view` and also in `other`.
+func (view *TSetView[T]) Intersect(other *TSetView[T]) *TSetView[T] {
+	return &TSetView[T]{
+		iterator: func(f func(v T) bool) bool {
+			return view.iterator(func(v T) bool {
+				if !other.contains(v) {
+					return true
+				}
+				return f(v)
+			})
+		},
+		contains: func(v T) bool {
+			return view.contains(v) && other.contains(v)
+		},
+	}
+}
+
+// Diff returns a lazy view which is the difference set from `view` to `other`.
+// Which means, all the items of the returned view are in `view` but not in `other`.
+func (view *TSetView[T]) Diff(other *TSetView[T]) *TSetView[T] {
+	return &TSetView[T]{
+		iterator: func(f func(v T) bool) bool {
+			return view.iterator(func(v T) bool {
+				if other.contains(v) {
+					return true
+				}
+				return f(v)
+			})
+		},
+		contains: func(v T) bool {
+			return view.contains(v) && !other.contains(v)
+		},
+	}
+}
+
+// SymmetricDiff returns a lazy view which is the symmetric difference of `view` and `other`.
+// Which means, all the items of the returned view are in either `view` or `other` but not in both.
+func (view *TSetView[T]) SymmetricDiff(other *TSetView[T]) *TSetView[T] {
+	return view.Diff(other).Union(other.Diff(view))
+}
@@ -0,0 +1,164 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// go test *.go
+
+package gset_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// sortedInts returns a sorted copy of `array`, which does not change the readonly snapshots.
+func sortedInts(array []int) []int {
+	sorted := append([]int(nil), array...)
+	sort.Ints(sorted)
+	return sorted
+}
+
+func TestTSet_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := gset.NewTSet[int](true)
+		s.Add(1, 1, 2)
+		t.Assert(s.Size(), 2)
+		t.Assert(s.Contains(1), true)
+		t.Assert(s.Contains(3), false)
+		t.Assert(s.AddIfNotExist(1), false)
+		t.Assert(s.AddIfNotExist(3), true)
+		t.Assert(sortedInts(s.Slice()), []int{1, 2, 3})
+		s.Remove(3)
+		t.Assert(s.Contains(3), false)
+
+		item, found := s.Pop()
+		t.Assert(found, true)
+		t.Assert(s.Contains(item), false)
+		s.Clear()
+		_, found = s.Pop()
+		t.Assert(found, false)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		s1 := gset.NewTSetFrom([]string{"a", "b"})
+		s2 := gset.NewTSetFrom([]string{"a", "b", "c"})
+		t.Assert(s1.IsSubsetOf(s2), true)
+		t.Assert(s2.IsSubsetOf(s1), false)
+		t.Assert(s1.Equal(s2), false)
+		s1.Merge(s2)
+		t.Assert(s1.Equal(s2), true)
+		s1.Walk(func(item string) string {
+			return "x" + item
+		})
+		t.Assert(s1.Contains("xa"), true)
+	})
+}
+
+func TestTSet_Algebra(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s1 := gset.NewTSetFrom([]int{1, 2, 3})
+		s2 := gset.NewTSetFrom([]int{2, 3, 4})
+		s3 := gset.NewTSetFrom([]int{3, 5})
+		t.Assert(sortedInts(s1.Union(s2, s3).Slice()), []int{1, 2, 3, 4, 5})
+		t.Assert(sortedInts(s1.Intersect(s2, s3).Slice()), []int{3})
+		t.Assert(sortedInts(s1.Diff(s2).Slice()), []int{1})
+		t.Assert(sortedInts(s1.Diff(s3).Slice()), []int{1, 2})
+		t.Assert(sortedInts(s1.SymmetricDiff(s2).Slice()), []int{1, 4})
+	})
+}
+
+func TestTSet_View(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s1 := gset.NewTSetFrom([]int{1, 2, 3}, true)
+		s2 := gset.NewTSetFrom([]int{2, 3, 4}, true)
+		s3 := gset.NewTSetFrom([]int{3, 5}, true)
+
+		view := s1.View().Union(s2.View()).Diff(s3.View())
+		t.Assert(view.Size(), 3)
+		t.Assert(view.Contains(1), true)
+		t.Assert(view.Contains(3), false)
+		t.Assert(sortedInts(view.Slice()), []int{1, 2, 4})
+
+		// The view reflects changes of the underlying sets.
+		s2.Add(6)
+		t.Assert(sortedInts(view.Set().Slice()), []int{1, 2, 4, 6})
+
+		count := 0
+		view.Iterator(func(v int) bool {
+			count++
+			return false
+		})
+		t.Assert(count, 1)
+
+		symmetric := s1.View().SymmetricDiff(s3.View())
+		t.Assert(sortedInts(symmetric.Slice()), []int{1, 2, 5})
+		t.Assert(symmetric.Contains(3), false)
+		t.Assert(symmetric.Contains(5), true)
+	})
+}
+
+func TestTSet_View_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			s1   = gset.NewTSetFrom([]int{1, 2, 3}, true)
+			s2   = gset.NewTSetFrom([]int{2, 3, 4}, true)
+			done = make(chan struct{})
+		)
+		// The view iterates the sets while there are writers waiting.
+		go func() {
+			defer close(done)
+			for i := 0; i < 1000; i++ {
+				s1.Add(i%3 + 1)
+				s2.Remove(5)
+			}
+		}()
+		for i := 0; i < 100; i++ {
+			t.Assert(sortedInts(s1.View().Intersect(s2.View()).Slice()), []int{2, 3})
+		}
+		<-done
+	})
+}
+
+func TestTSet_Json(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := gset.NewTSetFrom([]string{"a"})
+		b, err := json.Marshal(s)
+		t.AssertNil(err)
+		t.Assert(b, `["a"]`)
+		t.Assert(s.String(), `["a"]`)
+
+		s2 := gset.NewTSet[string]()
+		t.AssertNil(json.Unmarshal([]byte(`["a","b","a"]`), s2))
+		t.Assert(s2.Size(), 2)
+	})
+}
+
+func Test_TSet_Snapshot(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := gset.NewTSetFrom([]int{1, 2, 3}, true)
+		s1 := s.Snapshot()
+		s2 := s.Snapshot()
+		t.Assert(sortedInts(s1), []int{1, 2, 3})
+		t.Assert(&s1[0] == &s2[0], true)
+
+		// Writing within iteration does not deadlock, and resets the snapshot.
+		s.Iterator(func(v int) bool {
+			s.Add(v * 10)
+			return true
+		})
+		t.Assert(sortedInts(s1), []int{1, 2, 3})
+		t.Assert(sortedInts(s.Snapshot()), []int{1, 2, 3, 10, 20, 30})
+
+		// Removing items does not change the taken snapshot.
+		s3 := s.Snapshot()
+		s.Remove(1)
+		s.Clear()
+		t.Assert(sortedInts(s3), []int{1, 2, 3, 10, 20, 30})
+		t.Assert(len(s.Snapshot()), 0)
+	})
+}
@@ -0,0 +1,151 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtype
+
+import (
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/gogf/gf/v2/internal/deepcopy"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Value is a generic struct for concurrent-safe operation for type `T`,
+// which can be used for any type instead of the per-type atomic wrappers.
+// The zero value of Value is ready to use, and its Load returns the zero value of `T`.
+type Value[T any] struct {
+	pointer unsafe.Pointer // The pointer to value in type *T, which is atomically replaced on each writing.
+}
+
+// NewValue creates and returns a concurrent-safe object for type `T`,
+// with given initial value `value`.
+func NewValue[T any](value ...T) *Value[T] {
+	t := &Value[T]{}
+	if len(value) > 0 {
+		t.Store(value[0])
+	}
+	return t
+}
+
+// Clone clones and returns a new concurrent-safe object for type `T`.
+func (v *Value[T]) Clone() *Value[T] {
+	return NewValue[T](v.Load())
+}
+
+// Load atomically loads and returns t.value.
+func (v *Value[T]) Load() (value T) {
+	if p := atomic.LoadPointer(&v.pointer); p != nil {
+		return *(*T)(p)
+	}
+	return
+}
+
+// Store atomically stores `value` into t.value.
+func (v *Value[T]) Store(value T) {
+	atomic.StorePointer(&v.pointer, unsafe.Pointer(&value))
+}
+
+// Swap atomically stores `new` into t.value and returns the previous value of t.value.
+func (v *Value[T]) Swap(new T) (old T) {
+	if p := atomic.SwapPointer(&v.pointer, unsafe.Pointer(&new)); p != nil {
+		return *(*T)(p)
+	}
+	return
+}
+
+// CompareAndSwap executes the compare-and-swap operation for value.
+// Note that it panics if type `T` is not comparable, just like atomic.Value does.
+func (v *Value[T]) CompareAndSwap(old, new T) (swapped bool) {
+	for {
+		var (
+			p       = atomic.LoadPointer(&v.pointer)
+			current T
+		)
+		if p != nil {
+			current = *(*T)(p)
+		}
+		if any(current) != any(old) {
+			return false
+		}
+		if atomic.CompareAndSwapPointer(&v.pointer, p, unsafe.Pointer(&new)) {
+			return true
+		}
+	}
+}
+
+// Update atomically updates t.value with the returned value of callback function `f`,
+// which receives the current value, and returns the new value.
+// Note that `f` might be called multiple times if there's concurrent writing,
+// so it should be a pure function without side effects.
+func (v *Value[T]) Update(f func(value T) T) (new T) {
+	for {
+		var (
+			p       = atomic.LoadPointer(&v.pointer)
+			current T
+		)
+		if p != nil {
+			current = *(*T)(p)
+		}
+		new = f(current)
+		if atomic.CompareAndSwapPointer(&v.pointer, p, unsafe.Pointer(&new)) {
+			return
+		}
+	}
+}
+
+// Set is alias of Swap, which is consistent with the other types of package gtype.
+func (v *Value[T]) Set(value T) (old T) {
+	return v.Swap(value)
+}
+
+// Val is alias of Load, which is consistent with the other types of package gtype.
+func (v *Value[T]) Val() T {
+	return v.Load()
+}
+
+// String implements String interface for string printing.
+func (v *Value[T]) String() string {
+	return gconv.String(v.Load())
+}
+
+// MarshalJSON implements the interface MarshalJSON for json.Marshal.
+func (v *Value[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Load())
+}
+
+// UnmarshalJSON implements the interface UnmarshalJSON for json.Unmarshal.
+func (v *Value[T]) UnmarshalJSON(b []byte) error {
+	var value T
+	if err := json.UnmarshalUseNumber(b, &value); err != nil {
+		return err
+	}
+	v.Store(value)
+	return nil
+}
+
+// UnmarshalValue is an interface implement which sets any type of value for `v`.
+func (v *Value[T]) UnmarshalValue(value interface{}) error {
+	if t, ok := value.(T); ok {
+		v.Store(t)
+		return nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return v.UnmarshalJSON(b)
+}
+
+// DeepCopy implements interface for deep copy of current type.
+func (v *Value[T]) DeepCopy() interface{} {
+	if v == nil {
+		return nil
+	}
+	value, _ := deepcopy.Copy(v.Load()).(T)
+	return NewValue[T](value)
+}
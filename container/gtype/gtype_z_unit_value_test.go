@@ -0,0 +1,100 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtype_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+func Test_Value(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var v gtype.Value[int]
+		t.Assert(v.Load(), 0)
+		v.Store(1)
+		t.Assert(v.Load(), 1)
+		t.Assert(v.Swap(2), 1)
+		t.Assert(v.Set(3), 2)
+		t.Assert(v.Val(), 3)
+		t.Assert(v.CompareAndSwap(2, 4), false)
+		t.Assert(v.CompareAndSwap(3, 4), true)
+		t.Assert(v.Load(), 4)
+		t.Assert(v.String(), "4")
+		t.Assert(v.Clone().Load(), 4)
+
+		var empty gtype.Value[string]
+		t.Assert(empty.CompareAndSwap("", "a"), true)
+		t.Assert(empty.Load(), "a")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg       sync.WaitGroup
+			addTimes = 1000
+			v        = gtype.NewValue[int]()
+		)
+		for i := 0; i < addTimes; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				v.Update(func(value int) int {
+					return value + 1
+				})
+			}()
+		}
+		wg.Wait()
+		t.Assert(v.Load(), addTimes)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		type Config struct {
+			Name  string
+			Hosts []string
+		}
+		v := gtype.NewValue(&Config{Name: "a"})
+		t.Assert(v.Load().Name, "a")
+		newValue := v.Update(func(value *Config) *Config {
+			return &Config{Name: value.Name + "b", Hosts: []string{"127.0.0.1"}}
+		})
+		t.Assert(newValue.Name, "ab")
+		t.Assert(v.Load().Hosts, []string{"127.0.0.1"})
+
+		copied := v.DeepCopy().(*gtype.Value[*Config])
+		copied.Load().Hosts[0] = "localhost"
+		t.Assert(v.Load().Hosts, []string{"127.0.0.1"})
+	})
+}
+
+func Test_Value_JSON(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		v := gtype.NewValue([]int{1, 2})
+		b, err := json.Marshal(v)
+		t.AssertNil(err)
+		t.Assert(string(b), `[1,2]`)
+
+		v2 := gtype.NewValue[[]int]()
+		t.AssertNil(json.UnmarshalUseNumber(b, v2))
+		t.Assert(v2.Load(), []int{1, 2})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		type V struct {
+			Name string
+			Var  *gtype.Value[int]
+		}
+		var v *V
+		err := gconv.Struct(map[string]interface{}{
+			"name": "john",
+			"var":  123,
+		}, &v)
+		t.AssertNil(err)
+		t.Assert(v.Name, "john")
+		t.Assert(v.Var.Load(), 123)
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gvar
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+var (
+	timeReflectType  = reflect.TypeOf(time.Time{})
+	gtimeReflectType = reflect.TypeOf(gtime.Time{})
+)
+
+// As converts `v` to type `T` and returns the converted value,
+// which gives compile-time type to the result instead of chains of .Int()/.Map() conversions.
+// It returns the zero value of `T` if `v` is nil.
+//
+// Eg:
+// id, err := gvar.As[int](v)
+// user, err := gvar.As[*User](v)
+// names, err := gvar.As[[]string](v)
+func As[T any](v *Var) (value T, err error) {
+	if v == nil {
+		return
+	}
+	err = v.ScanTo(&value)
+	return
+}
+
+// ScanTo converts `v` to the value that `pointer` points to.
+// Different from Scan, it supports `pointer` of any type besides struct and map types,
+// eg: *int, *string, *[]int, *time.Time, *map[string]int, *struct, **struct, *[]struct.
+// It does nothing if `v` is nil.
+func (v *Var) ScanTo(pointer interface{}) error {
+	var reflectValue = reflect.ValueOf(pointer)
+	if reflectValue.Kind() != reflect.Ptr || reflectValue.IsNil() {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`destination pointer should be type of non-nil pointer, but got type: %T`,
+			pointer,
+		)
+	}
+	if v == nil || v.Val() == nil {
+		return nil
+	}
+	var elemValue = reflectValue.Elem()
+	if scanTypeNeedsScan(elemValue.Type()) {
+		return v.Scan(pointer)
+	}
+	converted := gconv.ConvertWithRefer(v.Val(), reflect.New(elemValue.Type()).Elem())
+	convertedValue := reflect.ValueOf(converted)
+	if !convertedValue.IsValid() || !convertedValue.Type().AssignableTo(elemValue.Type()) {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`cannot convert value of type %T to type %s`,
+			v.Val(), elemValue.Type(),
+		)
+	}
+	elemValue.Set(convertedValue)
+	return nil
+}
+
+// scanTypeNeedsScan checks and returns whether values of type `t` should be converted using Scan,
+// which are struct and map types, or slices of struct and map types.
+func scanTypeNeedsScan(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return t != timeReflectType && t != gtimeReflectType
+	case reflect.Map:
+		return true
+	case reflect.Slice, reflect.Array:
+		elemType := t.Elem()
+		for elemType.Kind() == reflect.Ptr {
+			elemType = elemType.Elem()
+		}
+		return elemType.Kind() == reflect.Map || (elemType.Kind() == reflect.Struct && scanTypeNeedsScan(elemType))
+	default:
+		return false
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gvar_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gvar"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func TestVar_As(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		i, err := gvar.As[int](gvar.New("100"))
+		t.AssertNil(err)
+		t.Assert(i, 100)
+
+		s, err := gvar.As[string](gvar.New(1.5))
+		t.AssertNil(err)
+		t.Assert(s, "1.5")
+
+		p, err := gvar.As[*int64](gvar.New("9"))
+		t.AssertNil(err)
+		t.Assert(*p, 9)
+
+		ints, err := gvar.As[[]int](gvar.New(g.Slice{"1", 2, 3.0}))
+		t.AssertNil(err)
+		t.Assert(ints, []int{1, 2, 3})
+
+		m, err := gvar.As[map[string]int](gvar.New(g.Map{"a": "1", "b": 2}))
+		t.AssertNil(err)
+		t.Assert(m, map[string]int{"a": 1, "b": 2})
+
+		tm, err := gvar.As[time.Time](gvar.New("2023-01-02 03:04:05"))
+		t.AssertNil(err)
+		t.Assert(tm.Year(), 2023)
+
+		gtm, err := gvar.As[*gtime.Time](gvar.New("2023-01-02 03:04:05"))
+		t.AssertNil(err)
+		t.Assert(gtm.Month(), 1)
+
+		d, err := gvar.As[time.Duration](gvar.New("1s"))
+		t.AssertNil(err)
+		t.Assert(d, time.Second)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		type User struct {
+			Id   int
+			Name string
+		}
+		user, err := gvar.As[*User](gvar.New(g.Map{"id": 1, "name": "john"}))
+		t.AssertNil(err)
+		t.Assert(user.Id, 1)
+		t.Assert(user.Name, "john")
+
+		users, err := gvar.As[[]User](gvar.New(g.Slice{g.Map{"id": 1}, g.Map{"id": 2}}))
+		t.AssertNil(err)
+		t.Assert(len(users), 2)
+		t.Assert(users[1].Id, 2)
+
+		maps, err := gvar.As[[]map[string]int](gvar.New(`[{"a":1},{"b":2}]`))
+		t.AssertNil(err)
+		t.Assert(maps, []map[string]int{{"a": 1}, {"b": 2}})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		i, err := gvar.As[int](nil)
+		t.AssertNil(err)
+		t.Assert(i, 0)
+
+		i, err = gvar.As[int](gvar.New(nil))
+		t.AssertNil(err)
+		t.Assert(i, 0)
+	})
+}
+
+func TestVar_ScanTo(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			v  = gvar.New("8")
+			i  uint8
+			s  string
+			ss []string
+		)
+		t.AssertNil(v.ScanTo(&i))
+		t.Assert(i, 8)
+		t.AssertNil(v.ScanTo(&s))
+		t.Assert(s, "8")
+		t.AssertNil(gvar.New(g.Slice{1, 2}).ScanTo(&ss))
+		t.Assert(ss, []string{"1", "2"})
+
+		t.AssertNE(v.ScanTo(i), nil)
+		t.AssertNE(v.ScanTo(nil), nil)
+	})
+}
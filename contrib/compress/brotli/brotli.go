@@ -0,0 +1,104 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package brotli implements the brotli adapter of gcompress using github.com/andybalholm/brotli.
+//
+// It registers itself to gcompress in package initialization, so that it only needs importing:
+//
+//	import _ "github.com/gogf/gf/contrib/compress/brotli/v2"
+package brotli
+
+import (
+	"io"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/gogf/gf/v2/encoding/gcompress"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Adapter implements gcompress.BrotliAdapter using github.com/andybalholm/brotli.
+type Adapter struct{}
+
+// Reader is the decompressing reader which reports truncated stream as io.ErrUnexpectedEOF,
+// as brotli.Reader returns io.EOF once the underlying reader ends, even if the stream is not complete.
+type Reader struct {
+	source *probeReader
+	reader *brotli.Reader
+	err    error
+}
+
+// probeReader is the underlying reader of brotli.Reader,
+// which returns an extra byte after the end of data on probing.
+type probeReader struct {
+	reader  io.Reader
+	probing bool
+}
+
+// errExcessiveInput is the error message of brotli.Reader for input after the end of stream.
+const errExcessiveInput = "brotli: excessive input"
+
+func init() {
+	gcompress.SetBrotliAdapter(New())
+}
+
+// New creates and returns a brotli adapter.
+func New() *Adapter {
+	return &Adapter{}
+}
+
+// NewWriter creates and returns a writer compressing data written to it into `writer`.
+func (a *Adapter) NewWriter(writer io.Writer, option gcompress.BrotliOption) (gcompress.WriteFlushCloser, error) {
+	return brotli.NewWriterOptions(writer, brotli.WriterOptions{
+		Quality: option.Quality,
+		LGWin:   option.Window,
+	}), nil
+}
+
+// NewReader creates and returns a reader decompressing data read from `reader`.
+func (a *Adapter) NewReader(reader io.Reader) (io.ReadCloser, error) {
+	source := &probeReader{reader: reader}
+	return &Reader{
+		source: source,
+		reader: brotli.NewReader(source),
+	}, nil
+}
+
+// Read reads decompressed data into `p`.
+func (r *Reader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.reader.Read(p)
+	if err == io.EOF {
+		// The stream is complete only if the extra byte is reported as excessive input.
+		r.source.probing = true
+		if _, err = r.reader.Read(make([]byte, 1)); err != nil && err.Error() == errExcessiveInput {
+			err = io.EOF
+		} else {
+			err = gerror.Wrap(io.ErrUnexpectedEOF, `brotli stream is truncated`)
+		}
+	}
+	if err != nil {
+		r.err = err
+	}
+	return n, err
+}
+
+// Close closes the reader, which does not close the underlying reader.
+func (r *Reader) Close() error {
+	return nil
+}
+
+// Read reads data from the underlying reader, or returns the extra byte on probing.
+func (r *probeReader) Read(p []byte) (int, error) {
+	if r.probing && len(p) > 0 {
+		r.probing = false
+		p[0] = 0
+		return 1, nil
+	}
+	return r.reader.Read(p)
+}
@@ -0,0 +1,234 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package brotli_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/gogf/gf/contrib/compress/brotli/v2"
+
+	"github.com/gogf/gf/v2/encoding/gcompress"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gres"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+var text = strings.Repeat(
+	"GoFrame is a modular, powerful, high-performance and enterprise-class "+
+		"application development framework of Golang. ", 3,
+)
+
+func Test_Brotli_UnBrotli(t *testing.T) {
+	// Compressed by the brotli command line tool.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			src    = "Hello World!!"
+			brotli = []byte{
+				0x0b, 0x06, 0x80, 0x48, 0x65, 0x6c, 0x6c, 0x6f, 0x20, 0x57, 0x6f, 0x72,
+				0x6c, 0x64, 0x21, 0x21, 0x03,
+			}
+		)
+		data, err := gcompress.UnBrotli(brotli)
+		t.AssertNil(err)
+		t.Assert(data, src)
+
+		data, err = gcompress.Brotli([]byte(src))
+		t.AssertNil(err)
+		data, err = gcompress.UnBrotli(data)
+		t.AssertNil(err)
+		t.Assert(data, src)
+
+		_, err = gcompress.UnBrotli(brotli[:len(brotli)-1])
+		t.AssertNE(err, nil)
+	})
+	// With static dictionary references and context modeling.
+	gtest.C(t, func(t *gtest.T) {
+		brotli := []byte{
+			0x1b, 0x58, 0x01, 0xe0, 0x1d, 0x09, 0x36, 0x4e, 0xf1, 0x8d, 0x9e, 0x69,
+			0x35, 0x4b, 0xcb, 0x51, 0x98, 0x78, 0x4b, 0x90, 0x16, 0x39, 0xd0, 0xc1,
+			0x01, 0xfb, 0xe5, 0x70, 0xab, 0x38, 0xe2, 0x61, 0xa2, 0x9e, 0xc7, 0xd8,
+			0x9b, 0x58, 0x32, 0x14, 0xdd, 0x31, 0xfb, 0xd9, 0x3a, 0xc0, 0x3e, 0x87,
+			0x24, 0x04, 0x72, 0xad, 0x09, 0x42, 0x42, 0xb2, 0x30, 0x22, 0x30, 0x59,
+			0xb8, 0xa2, 0x50, 0x8d, 0xad, 0x3f, 0x88, 0x07,
+		}
+		data, err := gcompress.UnBrotli(brotli)
+		t.AssertNil(err)
+		t.Assert(string(data), text)
+		_, err = gcompress.UnBrotli(brotli[:40])
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Brotli_Qualities(t *testing.T) {
+	var (
+		repeated = []byte(strings.Repeat(text, 1000))
+		random   = make([]byte, 200*1024)
+		r        = rand.New(rand.NewSource(1))
+	)
+	r.Read(random)
+	gtest.C(t, func(t *gtest.T) {
+		for _, src := range [][]byte{nil, {'a'}, bytes.Repeat([]byte{'a'}, 1000), repeated, random} {
+			for _, quality := range []int{gcompress.BrotliQualityFastest, 4, gcompress.BrotliQualityDefault, gcompress.BrotliQualityBest} {
+				data, err := gcompress.Brotli(src, quality)
+				t.AssertNil(err)
+				t.Assert(len(data) <= len(src)+len(src)/1000+32, true)
+
+				result, err := gcompress.UnBrotli(data)
+				t.AssertNil(err)
+				t.Assert(bytes.Equal(result, src), true)
+			}
+		}
+		data, err := gcompress.Brotli(repeated)
+		t.AssertNil(err)
+		t.Assert(len(data) < len(repeated)/20, true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		for _, window := range []int{10, 16, 24} {
+			data, err := gcompress.BrotliWithOption(repeated, gcompress.BrotliOption{Window: window})
+			t.AssertNil(err)
+			result, err := gcompress.UnBrotli(data)
+			t.AssertNil(err)
+			t.Assert(bytes.Equal(result, repeated), true)
+		}
+	})
+}
+
+func Test_Brotli_Stream(t *testing.T) {
+	src := []byte(strings.Repeat("GoFrame brotli streaming compression. ", 20000))
+	gtest.C(t, func(t *gtest.T) {
+		var buffer bytes.Buffer
+		writer, err := gcompress.NewBrotliWriter(&buffer, gcompress.BrotliOption{Quality: gcompress.BrotliQualityFastest})
+		t.AssertNil(err)
+		for i := 0; i < len(src); i += 1000 {
+			_, err = writer.Write(src[i : i+1000])
+			t.AssertNil(err)
+		}
+		t.AssertNil(writer.Close())
+		_, err = writer.Write(src)
+		t.AssertNE(err, nil)
+
+		reader, err := gcompress.NewBrotliReader(&buffer)
+		t.AssertNil(err)
+		result, err := io.ReadAll(reader)
+		t.AssertNil(err)
+		t.Assert(bytes.Equal(result, src), true)
+		t.AssertNil(reader.Close())
+	})
+	// Flushed data can be decompressed before the stream ends.
+	gtest.C(t, func(t *gtest.T) {
+		var buffer bytes.Buffer
+		writer, err := gcompress.NewBrotliWriter(&buffer)
+		t.AssertNil(err)
+		_, err = writer.Write([]byte("Hello "))
+		t.AssertNil(err)
+		t.AssertNil(writer.Flush())
+
+		reader, err := gcompress.NewBrotliReader(bytes.NewReader(buffer.Bytes()))
+		t.AssertNil(err)
+		result := make([]byte, 6)
+		_, err = io.ReadFull(reader, result)
+		t.AssertNil(err)
+		t.Assert(result, "Hello ")
+
+		_, err = writer.Write([]byte("World!"))
+		t.AssertNil(err)
+		t.AssertNil(writer.Close())
+		result, err = gcompress.UnBrotli(buffer.Bytes())
+		t.AssertNil(err)
+		t.Assert(result, "Hello World!")
+	})
+}
+
+func Test_Brotli_UnBrotli_File(t *testing.T) {
+	var (
+		srcPath  = gfile.Temp(gtime.TimestampNanoStr(), "file.txt")
+		dstPath1 = gfile.Temp(gtime.TimestampNanoStr(), "file.br")
+		dstPath2 = gfile.Temp(gtime.TimestampNanoStr(), "file.txt")
+	)
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(gfile.PutContents(srcPath, text))
+		defer gfile.Remove(srcPath)
+
+		err := gcompress.BrotliFile(srcPath, dstPath1, gcompress.BrotliQualityBest)
+		t.AssertNil(err)
+		defer gfile.Remove(dstPath1)
+		t.Assert(gfile.Exists(dstPath1), true)
+
+		err = gcompress.UnBrotliFile(dstPath1, dstPath2)
+		t.AssertNil(err)
+		defer gfile.Remove(dstPath2)
+		t.Assert(gfile.GetContents(dstPath2), text)
+	})
+}
+
+func Test_Gres_PackWithBrotli(t *testing.T) {
+	srcPath := gfile.Temp(gtime.TimestampNanoStr(), "files")
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(gfile.PutContents(gfile.Join(srcPath, "index.html"), text))
+		defer gfile.Remove(srcPath)
+
+		data, err := gres.PackWithOption(srcPath, gres.Option{Brotli: true})
+		t.AssertNil(err)
+
+		files, err := gres.UnpackContent(string(data))
+		t.AssertNil(err)
+		t.AssertGT(len(files), 0)
+
+		r := gres.New()
+		t.AssertNil(r.Add(string(data)))
+		t.Assert(r.GetContent("files/index.html"), text)
+	})
+}
+
+func Test_Ghttp_MiddlewareCompression(t *testing.T) {
+	var (
+		s       = g.Server(guid.S())
+		content = strings.Repeat("goframe compression ", 100)
+	)
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCompression())
+		group.GET("/text", func(r *ghttp.Request) {
+			r.Response.Write(content)
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	get := func(t *gtest.T, acceptEncoding string) (encoding string, body []byte) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		client.SetHeader("Accept-Encoding", acceptEncoding)
+		resp, err := client.Get(context.Background(), "/text")
+		t.AssertNil(err)
+		defer resp.Close()
+		return resp.Header.Get("Content-Encoding"), resp.ReadAll()
+	}
+
+	gtest.C(t, func(t *gtest.T) {
+		encoding, body := get(t, "gzip, deflate, br, zstd")
+		t.Assert(encoding, "br")
+		data, err := gcompress.UnBrotli(body)
+		t.AssertNil(err)
+		t.Assert(data, content)
+
+		encoding, _ = get(t, "*")
+		t.Assert(encoding, "br")
+	})
+}
@@ -0,0 +1,78 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package zstd implements the zstd adapter of gcompress using github.com/klauspost/compress/zstd.
+//
+// It registers itself to gcompress in package initialization, so that it only needs importing:
+//
+//	import _ "github.com/gogf/gf/contrib/compress/zstd/v2"
+package zstd
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/gogf/gf/v2/encoding/gcompress"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Adapter implements gcompress.ZstdAdapter using github.com/klauspost/compress/zstd.
+type Adapter struct{}
+
+// dictMagic is the magic number 0xEC30A437 of zstd dictionary format in little endian.
+var dictMagic = []byte{0x37, 0xA4, 0x30, 0xEC}
+
+func init() {
+	gcompress.SetZstdAdapter(New())
+}
+
+// New creates and returns a zstd adapter.
+func New() *Adapter {
+	return &Adapter{}
+}
+
+// NewWriter creates and returns a writer compressing data written to it into `writer`.
+func (a *Adapter) NewWriter(writer io.Writer, option gcompress.ZstdOption) (gcompress.WriteFlushCloser, error) {
+	options := []zstd.EOption{
+		zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(option.Level)),
+	}
+	if len(option.Dict) > 0 {
+		if isDictFormat(option.Dict) {
+			options = append(options, zstd.WithEncoderDict(option.Dict))
+		} else {
+			options = append(options, zstd.WithEncoderDictRaw(0, option.Dict))
+		}
+	}
+	encoder, err := zstd.NewWriter(writer, options...)
+	if err != nil {
+		return nil, gerror.Wrap(err, `zstd.NewWriter failed`)
+	}
+	return encoder, nil
+}
+
+// NewReader creates and returns a reader decompressing data read from `reader`.
+func (a *Adapter) NewReader(reader io.Reader, option gcompress.ZstdOption) (io.ReadCloser, error) {
+	var options []zstd.DOption
+	if len(option.Dict) > 0 {
+		if isDictFormat(option.Dict) {
+			options = append(options, zstd.WithDecoderDicts(option.Dict))
+		} else {
+			options = append(options, zstd.WithDecoderDictRaw(0, option.Dict))
+		}
+	}
+	decoder, err := zstd.NewReader(reader, options...)
+	if err != nil {
+		return nil, gerror.Wrap(err, `zstd.NewReader failed`)
+	}
+	return decoder.IOReadCloser(), nil
+}
+
+// isDictFormat checks whether `dict` is in zstd dictionary format, or else it is raw content.
+func isDictFormat(dict []byte) bool {
+	return len(dict) > 8 && bytes.Equal(dict[:4], dictMagic)
+}
@@ -0,0 +1,262 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package zstd_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/gogf/gf/contrib/compress/zstd/v2"
+
+	"github.com/gogf/gf/v2/encoding/gcompress"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gres"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+var text = strings.Repeat(
+	"GoFrame is a modular, powerful, high-performance and enterprise-class "+
+		"application development framework of Golang. ", 3,
+)
+
+func Test_Zstd_UnZstd(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		src := []byte("Hello World!!")
+		data, err := gcompress.Zstd(src)
+		t.AssertNil(err)
+		t.Assert(data[:4], []byte{0x28, 0xB5, 0x2F, 0xFD})
+
+		result, err := gcompress.UnZstd(data)
+		t.AssertNil(err)
+		t.Assert(result, src)
+
+		_, err = gcompress.UnZstd(data[1:])
+		t.AssertNE(err, nil)
+		_, err = gcompress.UnZstd(data[:len(data)-1])
+		t.AssertNE(err, nil)
+	})
+	// Compressed by the zstd command line tool, with Huffman compressed literals and sequences.
+	gtest.C(t, func(t *gtest.T) {
+		zstd := []byte{
+			0x28, 0xb5, 0x2f, 0xfd, 0x60, 0x59, 0x00, 0x05, 0x03, 0x00, 0xf2, 0x06,
+			0x15, 0x16, 0xa0, 0x35, 0x6d, 0xdf, 0x6c, 0x7d, 0xe5, 0x92, 0x5f, 0xdb,
+			0x2c, 0x49, 0x44, 0x3f, 0xdd, 0x2a, 0xde, 0xf3, 0xea, 0xbf, 0xa8, 0x01,
+			0x06, 0x14, 0x58, 0x97, 0x84, 0x4f, 0x5a, 0xb0, 0x99, 0x1c, 0x1f, 0x78,
+			0xa4, 0x79, 0x1f, 0xdc, 0x18, 0xd3, 0x8e, 0x2a, 0x76, 0xaa, 0xe5, 0x94,
+			0xea, 0xca, 0xf0, 0x64, 0xab, 0xfb, 0x03, 0x6f, 0x83, 0xe5, 0x17, 0x2c,
+			0xb4, 0x79, 0xf6, 0x3a, 0x18, 0x85, 0x19, 0x16, 0x0e, 0x39, 0xfb, 0x24,
+			0xd5, 0xc2, 0xd6, 0x21, 0x26, 0x91, 0xcb, 0xc9, 0x7c, 0x54, 0x1b, 0x48,
+			0x22, 0x02, 0x00, 0xc6, 0x76, 0xd0, 0x7e, 0x9f, 0x15, 0xcc,
+		}
+		data, err := gcompress.UnZstd(zstd)
+		t.AssertNil(err)
+		t.Assert(string(data), text)
+	})
+}
+
+func Test_Zstd_Levels(t *testing.T) {
+	var (
+		repeated = []byte(strings.Repeat(text, 1000))
+		random   = make([]byte, 200*1024)
+		r        = rand.New(rand.NewSource(1))
+	)
+	r.Read(random)
+	gtest.C(t, func(t *gtest.T) {
+		for _, src := range [][]byte{nil, {'a'}, bytes.Repeat([]byte{'a'}, 1000), repeated, random} {
+			for _, level := range []int{gcompress.ZstdLevelFastest, gcompress.ZstdLevelDefault, 9, gcompress.ZstdLevelBest, 22} {
+				data, err := gcompress.Zstd(src, level)
+				t.AssertNil(err)
+				t.Assert(len(data) <= len(src)+len(src)/1000+32, true)
+
+				result, err := gcompress.UnZstd(data)
+				t.AssertNil(err)
+				t.Assert(bytes.Equal(result, src), true)
+			}
+		}
+		data, err := gcompress.Zstd(repeated)
+		t.AssertNil(err)
+		t.Assert(len(data) < len(repeated)/20, true)
+	})
+}
+
+func Test_Zstd_Stream(t *testing.T) {
+	src := []byte(strings.Repeat("GoFrame zstd streaming compression. ", 20000))
+	gtest.C(t, func(t *gtest.T) {
+		var buffer bytes.Buffer
+		writer, err := gcompress.NewZstdWriter(&buffer, gcompress.ZstdOption{Level: gcompress.ZstdLevelFastest})
+		t.AssertNil(err)
+		for i := 0; i < len(src); i += 1000 {
+			_, err = writer.Write(src[i : i+1000])
+			t.AssertNil(err)
+		}
+		t.AssertNil(writer.Close())
+		_, err = writer.Write(src)
+		t.AssertNE(err, nil)
+
+		reader, err := gcompress.NewZstdReader(&buffer)
+		t.AssertNil(err)
+		result, err := io.ReadAll(reader)
+		t.AssertNil(err)
+		t.Assert(bytes.Equal(result, src), true)
+		t.AssertNil(reader.Close())
+	})
+	// Flushed data can be decompressed before the frame ends.
+	gtest.C(t, func(t *gtest.T) {
+		var buffer bytes.Buffer
+		writer, err := gcompress.NewZstdWriter(&buffer)
+		t.AssertNil(err)
+		_, err = writer.Write([]byte("Hello "))
+		t.AssertNil(err)
+		t.AssertNil(writer.Flush())
+
+		reader, err := gcompress.NewZstdReader(bytes.NewReader(buffer.Bytes()))
+		t.AssertNil(err)
+		defer reader.Close()
+		result := make([]byte, 6)
+		_, err = io.ReadFull(reader, result)
+		t.AssertNil(err)
+		t.Assert(result, "Hello ")
+
+		_, err = writer.Write([]byte("World!"))
+		t.AssertNil(err)
+		t.AssertNil(writer.Close())
+		result, err = gcompress.UnZstd(buffer.Bytes())
+		t.AssertNil(err)
+		t.Assert(result, "Hello World!")
+	})
+	// Concatenated frames are decompressed as a whole.
+	gtest.C(t, func(t *gtest.T) {
+		data1, err := gcompress.Zstd([]byte("Hello "))
+		t.AssertNil(err)
+		data2, err := gcompress.Zstd([]byte("World!"), gcompress.ZstdLevelBest)
+		t.AssertNil(err)
+		result, err := gcompress.UnZstd(append(data1, data2...))
+		t.AssertNil(err)
+		t.Assert(result, "Hello World!")
+	})
+}
+
+func Test_Zstd_Dict(t *testing.T) {
+	var (
+		dict = []byte(strings.Repeat("GoFrame is a modular, powerful, high-performance and enterprise-class framework. ", 10))
+		src  = []byte("GoFrame is a modular, powerful framework of Golang.")
+	)
+	gtest.C(t, func(t *gtest.T) {
+		option := gcompress.ZstdOption{Dict: dict}
+		withDict, err := gcompress.ZstdWithOption(src, option)
+		t.AssertNil(err)
+		withoutDict, err := gcompress.Zstd(src)
+		t.AssertNil(err)
+		t.Assert(len(withDict) < len(withoutDict), true)
+
+		result, err := gcompress.UnZstdWithOption(withDict, option)
+		t.AssertNil(err)
+		t.Assert(result, src)
+
+		result, err = gcompress.UnZstd(withDict)
+		t.AssertNE(err, nil)
+		t.AssertNE(result, src)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var buffer bytes.Buffer
+		writer, err := gcompress.NewZstdWriter(&buffer, gcompress.ZstdOption{Dict: dict})
+		t.AssertNil(err)
+		_, err = writer.Write(src)
+		t.AssertNil(err)
+		t.AssertNil(writer.Close())
+
+		reader, err := gcompress.NewZstdReader(&buffer, gcompress.ZstdOption{Dict: dict})
+		t.AssertNil(err)
+		defer reader.Close()
+		result, err := io.ReadAll(reader)
+		t.AssertNil(err)
+		t.Assert(result, src)
+	})
+}
+
+func Test_Zstd_UnZstd_File(t *testing.T) {
+	var (
+		srcPath  = gfile.Temp(gtime.TimestampNanoStr(), "file.txt")
+		dstPath1 = gfile.Temp(gtime.TimestampNanoStr(), "file.zst")
+		dstPath2 = gfile.Temp(gtime.TimestampNanoStr(), "file.txt")
+	)
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(gfile.PutContents(srcPath, text))
+		defer gfile.Remove(srcPath)
+
+		err := gcompress.ZstdFile(srcPath, dstPath1, gcompress.ZstdLevelBest)
+		t.AssertNil(err)
+		defer gfile.Remove(dstPath1)
+		t.Assert(gfile.Exists(dstPath1), true)
+
+		err = gcompress.UnZstdFile(dstPath1, dstPath2)
+		t.AssertNil(err)
+		defer gfile.Remove(dstPath2)
+		t.Assert(gfile.GetContents(dstPath2), text)
+	})
+}
+
+func Test_Gres_PackWithZstd(t *testing.T) {
+	srcPath := gfile.Temp(gtime.TimestampNanoStr(), "files")
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(gfile.PutContents(gfile.Join(srcPath, "index.html"), text))
+		defer gfile.Remove(srcPath)
+
+		data, err := gres.PackWithOption(srcPath, gres.Option{Zstd: true})
+		t.AssertNil(err)
+		t.Assert(data[:4], []byte{0x28, 0xB5, 0x2F, 0xFD})
+
+		files, err := gres.UnpackContent(string(data))
+		t.AssertNil(err)
+		t.AssertGT(len(files), 0)
+
+		r := gres.New()
+		t.AssertNil(r.Add(string(data)))
+		t.Assert(r.GetContent("files/index.html"), text)
+	})
+}
+
+func Test_Ghttp_MiddlewareCompression(t *testing.T) {
+	var (
+		s       = g.Server(guid.S())
+		content = strings.Repeat("goframe compression ", 100)
+	)
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCompression())
+		group.GET("/text", func(r *ghttp.Request) {
+			r.Response.Write(content)
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		client.SetHeader("Accept-Encoding", "gzip;q=0.8, zstd")
+		resp, err := client.Get(context.Background(), "/text")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.Header.Get("Content-Encoding"), "zstd")
+
+		data, err := gcompress.UnZstd(resp.ReadAll())
+		t.AssertNil(err)
+		t.Assert(data, content)
+	})
+}
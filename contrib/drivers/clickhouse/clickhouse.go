@@ -26,6 +26,7 @@ var (
 	errUnsupportedReplace      = errors.New("unsupported method:Replace")
 	errUnsupportedBegin        = errors.New("unsupported method:Begin")
 	errUnsupportedTransaction  = errors.New("unsupported method:Transaction")
+	errUnsupportedOnDuplicate  = errors.New("unsupported method:OnDuplicate")
 )
 
 const (
@@ -16,9 +16,20 @@ import (
 )
 
 // DoInsert inserts or updates data for given table.
+// All the records are sent to server in one block, as ClickHouse prefers large batch inserting.
+//
+// ClickHouse does not support updating on conflict, so Save and Replace insert the records
+// which are deduplicated by the sorting key in background merges of ReplacingMergeTree engine.
+// It returns error if the updating columns are specified by OnDuplicate.
 func (d *Driver) DoInsert(
 	ctx context.Context, link gdb.Link, table string, list gdb.List, option gdb.DoInsertOption,
 ) (result sql.Result, err error) {
+	switch option.InsertOption {
+	case gdb.InsertOptionSave, gdb.InsertOptionReplace:
+		if option.OnDuplicateStr != "" || len(option.OnDuplicateMap) > 0 {
+			return nil, errUnsupportedOnDuplicate
+		}
+	}
 	var (
 		keys        []string // Field names.
 		valueHolder = make([]string, 0)
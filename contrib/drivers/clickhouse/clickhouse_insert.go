@@ -9,6 +9,8 @@ package clickhouse
 import (
 	"context"
 	"database/sql"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
 )
 
 // InsertIgnore Other queries for modifying data parts are not supported: REPLACE, MERGE, UPSERT, INSERT UPDATE.
@@ -25,3 +27,21 @@ func (d *Driver) InsertAndGetId(ctx context.Context, table string, data interfac
 func (d *Driver) Replace(ctx context.Context, table string, data interface{}, batch ...int) (sql.Result, error) {
 	return nil, errUnsupportedReplace
 }
+
+// AsyncInsert returns a context that enables asynchronous insert of ClickHouse server for the inserting
+// using it, in which the server buffers the data of small inserts and writes them to table in batches.
+// The parameter `wait` specifies whether the inserting waits for the data written to table.
+//
+// Note that it overwrites the query options of clickhouse.Context in `ctx`.
+// The asynchronous insert can also be enabled for all inserting by configuration ConfigNode.Extra
+// like "async_insert=1&wait_for_async_insert=0".
+func AsyncInsert(ctx context.Context, wait bool) context.Context {
+	var waitSetting int
+	if wait {
+		waitSetting = 1
+	}
+	return clickhouse.Context(ctx, clickhouse.WithSettings(clickhouse.Settings{
+		"async_insert":          1,
+		"wait_for_async_insert": waitSetting,
+	}))
+}
@@ -0,0 +1,155 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package clickhouse
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtimer"
+)
+
+const (
+	defaultInsertBufferSize     = 1000
+	defaultInsertBufferInterval = time.Second
+)
+
+// InsertBuffer buffers the records in memory, and inserts them to the table in batches,
+// as ClickHouse performs badly for frequent small inserts.
+// The records are inserted if the buffered number reaches InsertBufferOption.Size,
+// or in every InsertBufferOption.Interval in background.
+//
+// Note that the records are inserted at most once, that the records failed inserting are removed
+// from the buffer and passed to InsertBufferOption.ErrorHandler, which can retry or save them.
+type InsertBuffer struct {
+	mu      sync.Mutex
+	db      gdb.DB
+	table   string
+	option  InsertBufferOption
+	records []interface{}
+	timer   *gtimer.Entry
+	closed  bool
+}
+
+// InsertBufferOption is the option for InsertBuffer.
+type InsertBufferOption struct {
+	Size     int           // Max number of buffered records, which is 1000 in default.
+	Interval time.Duration // Interval inserting the buffered records in background, which is 1s in default.
+	Async    bool          // Whether using asynchronous insert of ClickHouse server, see AsyncInsert.
+	// ErrorHandler handles the error of inserting with the `records` failed inserting.
+	// The error of background inserting is logged by the logger of DB if it is nil,
+	// and the failed records are dropped.
+	ErrorHandler func(ctx context.Context, err error, records []interface{})
+}
+
+// NewInsertBuffer creates and returns an InsertBuffer for `table` of `db`.
+// It should be closed by InsertBuffer.Close after use, which inserts the remaining records.
+func NewInsertBuffer(db gdb.DB, table string, option ...InsertBufferOption) *InsertBuffer {
+	b := &InsertBuffer{
+		db:    db,
+		table: table,
+	}
+	if len(option) > 0 {
+		b.option = option[0]
+	}
+	if b.option.Size <= 0 {
+		b.option.Size = defaultInsertBufferSize
+	}
+	if b.option.Interval <= 0 {
+		b.option.Interval = defaultInsertBufferInterval
+	}
+	b.records = make([]interface{}, 0, b.option.Size)
+	b.timer = gtimer.AddSingleton(db.GetCtx(), b.option.Interval, func(ctx context.Context) {
+		if err := b.Flush(ctx); err != nil && b.option.ErrorHandler == nil {
+			b.db.GetLogger().Errorf(ctx, `%+v`, err)
+		}
+	})
+	return b
+}
+
+// Add adds `records` to the buffer, which can be type of map/struct/*struct.
+// It inserts the buffered records if the buffered number reaches InsertBufferOption.Size.
+func (b *InsertBuffer) Add(ctx context.Context, records ...interface{}) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return gerror.NewCode(gcode.CodeInvalidOperation, `insert buffer is closed`)
+	}
+	b.records = append(b.records, records...)
+	if len(b.records) < b.option.Size {
+		b.mu.Unlock()
+		return nil
+	}
+	list := b.takeRecords()
+	b.mu.Unlock()
+	return b.insert(ctx, list)
+}
+
+// Len returns the number of buffered records.
+func (b *InsertBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.records)
+}
+
+// Flush inserts all the buffered records.
+// The records failed inserting are passed to InsertBufferOption.ErrorHandler but not put back to the buffer.
+func (b *InsertBuffer) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	list := b.takeRecords()
+	b.mu.Unlock()
+	return b.insert(ctx, list)
+}
+
+// Close stops the background inserting, and inserts the remaining records.
+func (b *InsertBuffer) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.timer.Close()
+	list := b.takeRecords()
+	b.mu.Unlock()
+	return b.insert(ctx, list)
+}
+
+// takeRecords takes and returns the buffered records, and resets the buffer.
+// Note that the caller should hold the lock.
+func (b *InsertBuffer) takeRecords() []interface{} {
+	if len(b.records) == 0 {
+		return nil
+	}
+	list := b.records
+	b.records = make([]interface{}, 0, b.option.Size)
+	return list
+}
+
+// insert inserts `list` to the table in one block.
+// It passes `list` to InsertBufferOption.ErrorHandler if it fails inserting.
+func (b *InsertBuffer) insert(ctx context.Context, list []interface{}) error {
+	if len(list) == 0 {
+		return nil
+	}
+	insertCtx := ctx
+	if b.option.Async {
+		insertCtx = AsyncInsert(ctx, false)
+	}
+	_, err := b.db.Model(b.table).Ctx(insertCtx).Data(list).Insert()
+	if err != nil {
+		err = gerror.Wrapf(err, `insert %d buffered records to table "%s" failed`, len(list), b.table)
+		if b.option.ErrorHandler != nil {
+			b.option.ErrorHandler(ctx, err, list)
+		}
+	}
+	return err
+}
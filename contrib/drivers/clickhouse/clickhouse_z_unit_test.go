@@ -418,6 +418,83 @@ func TestDriverClickhouse_DoFilter(t *testing.T) {
 	gtest.AssertEQ(replaceSQL, "ALTER TABLE visit DELETE WHERE url = '0'")
 }
 
+func TestDriverClickhouse_Save_OnDuplicate(t *testing.T) {
+	connect := clickhouseConfigDB()
+	gtest.AssertEQ(createClickhouseTableVisits(connect), nil)
+	defer dropClickhouseTableVisits(connect)
+	_, err := connect.Model("visits").Data(g.Map{
+		"id":       1,
+		"duration": 1,
+		"url":      "https://goframe.org",
+		"created":  time.Now(),
+	}).OnDuplicate("url").Save()
+	gtest.AssertEQ(err, errUnsupportedOnDuplicate)
+}
+
+func TestDriverClickhouse_InsertBuffer(t *testing.T) {
+	connect := clickhouseConfigDB()
+	gtest.AssertEQ(createClickhouseTableVisits(connect), nil)
+	defer dropClickhouseTableVisits(connect)
+	buffer := NewInsertBuffer(connect, "visits", InsertBufferOption{
+		Size:     10,
+		Interval: time.Hour,
+	})
+	for i := 1; i <= 15; i++ {
+		gtest.AssertNil(buffer.Add(context.Background(), g.Map{
+			"id":       i,
+			"duration": i,
+			"url":      "https://goframe.org",
+			"created":  time.Now(),
+		}))
+	}
+	gtest.AssertEQ(buffer.Len(), 5)
+	total, err := connect.Model("visits").Count()
+	gtest.AssertNil(err)
+	gtest.AssertEQ(total, 10)
+
+	gtest.AssertNil(buffer.Close(context.Background()))
+	gtest.AssertEQ(buffer.Len(), 0)
+	total, err = connect.Model("visits").Count()
+	gtest.AssertNil(err)
+	gtest.AssertEQ(total, 15)
+	gtest.AssertNE(buffer.Add(context.Background(), g.Map{"id": 16}), nil)
+}
+
+func TestDriverClickhouse_InsertBuffer_ErrorHandler(t *testing.T) {
+	connect := clickhouseConfigDB()
+	var failedRecords []interface{}
+	buffer := NewInsertBuffer(connect, "visits_not_exist", InsertBufferOption{
+		Size:     10,
+		Interval: time.Hour,
+		ErrorHandler: func(ctx context.Context, err error, records []interface{}) {
+			failedRecords = append(failedRecords, records...)
+		},
+	})
+	for i := 1; i <= 5; i++ {
+		gtest.AssertNil(buffer.Add(context.Background(), g.Map{"id": i}))
+	}
+	gtest.AssertNE(buffer.Flush(context.Background()), nil)
+	gtest.AssertEQ(buffer.Len(), 0)
+	gtest.AssertEQ(len(failedRecords), 5)
+	gtest.AssertNil(buffer.Close(context.Background()))
+}
+
+func TestDriverClickhouse_AsyncInsert(t *testing.T) {
+	connect := clickhouseConfigDB()
+	gtest.AssertEQ(createClickhouseTableVisits(connect), nil)
+	defer dropClickhouseTableVisits(connect)
+	_, err := connect.Model("visits").Ctx(AsyncInsert(context.Background(), true)).Data(g.Map{
+		"id":       1,
+		"duration": 1,
+		"url":      "https://goframe.org",
+		"created":  time.Now(),
+	}).Insert()
+	gtest.AssertNil(err)
+	total, err := connect.Model("visits").Count()
+	gtest.AssertNil(err)
+	gtest.AssertEQ(total, 1)
+}
+
 func TestDriverClickhouse_Select(t *testing.T) {
 	connect := clickhouseConfigDB()
 	gtest.AssertNil(createClickhouseTableVisits(connect))
@@ -17,6 +17,7 @@ import (
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
 )
 
 // DoInsert inserts or updates data for given table.
@@ -37,7 +38,7 @@ func (d *Driver) DoInsert(
 	return d.Core.DoInsert(ctx, link, table, list, option)
 }
 
-// doSave support upsert for dm
+// doSave support upsert for dm, which merges the records in batches of option.BatchCount.
 func (d *Driver) doSave(ctx context.Context,
 	link gdb.Link, table string, list gdb.List, option gdb.DoInsertOption,
 ) (result sql.Result, err error) {
@@ -49,87 +50,129 @@ func (d *Driver) doSave(ctx context.Context,
 
 	if len(list) == 0 {
 		return nil, gerror.NewCode(
-			gcode.CodeInvalidRequest, `Save operation list is empty by oracle driver`,
+			gcode.CodeInvalidRequest, `Save operation list is empty by dm driver`,
 		)
 	}
 
 	var (
 		one          = list[0]
-		oneLen       = len(one)
 		charL, charR = d.GetChars()
+		batchCount   = option.BatchCount
 
 		conflictKeys   = option.OnConflict
 		conflictKeySet = gset.New(false)
 
-		// queryHolders:	Handle data with Holder that need to be upsert
-		// queryValues:		Handle data that need to be upsert
+		// keys:			Keys of the records in fixed order
+		// rowHolders:		Handle holders of a record that need to be upsert
 		// insertKeys:		Handle valid keys that need to be inserted
 		// insertValues:	Handle values that need to be inserted
 		// updateValues:	Handle values that need to be updated
-		queryHolders = make([]string, oneLen)
-		queryValues  = make([]interface{}, oneLen)
-		insertKeys   = make([]string, oneLen)
-		insertValues = make([]string, oneLen)
+		keys         = make([]string, 0, len(one))
+		rowHolders   = make([]string, 0, len(one))
+		insertKeys   = make([]string, 0, len(one))
+		insertValues = make([]string, 0, len(one))
 		updateValues []string
 	)
+	if batchCount <= 0 {
+		batchCount = len(list)
+	}
 
 	// conflictKeys slice type conv to set type
 	for _, conflictKey := range conflictKeys {
 		conflictKeySet.Add(gstr.ToUpper(conflictKey))
 	}
 
-	index := 0
-	for key, value := range one {
+	for key := range one {
 		keyWithChar := charL + key + charR
-		queryHolders[index] = fmt.Sprintf("? AS %s", keyWithChar)
-		queryValues[index] = value
-		insertKeys[index] = keyWithChar
-		insertValues[index] = fmt.Sprintf("T2.%s", keyWithChar)
+		keys = append(keys, key)
+		rowHolders = append(rowHolders, fmt.Sprintf("? AS %s", keyWithChar))
+		insertKeys = append(insertKeys, keyWithChar)
+		insertValues = append(insertValues, fmt.Sprintf("T2.%s", keyWithChar))
 
 		// filter conflict keys in updateValues.
 		// And the key is not a soft created field.
-		if !(conflictKeySet.Contains(key) || d.Core.IsSoftCreatedFieldName(key)) {
+		if !(conflictKeySet.Contains(gstr.ToUpper(key)) || d.Core.IsSoftCreatedFieldName(key)) {
 			updateValues = append(
 				updateValues,
 				fmt.Sprintf(`T1.%s = T2.%s`, keyWithChar, keyWithChar),
 			)
 		}
-		index++
 	}
 
-	batchResult := new(gdb.SqlResult)
-	sqlStr := parseSqlForUpsert(table, queryHolders, insertKeys, insertValues, updateValues, conflictKeys)
-	r, err := d.DoExec(ctx, link, sqlStr, queryValues...)
-	if err != nil {
-		return r, err
+	// The updated columns specified by OnDuplicate/DoUpdate.
+	if option.OnDuplicateStr != "" {
+		updateValues = []string{option.OnDuplicateStr}
+	} else if len(option.OnDuplicateMap) > 0 {
+		updateValues = updateValues[:0]
+		for k, v := range option.OnDuplicateMap {
+			switch v.(type) {
+			case gdb.Raw, *gdb.Raw:
+				updateValues = append(
+					updateValues,
+					fmt.Sprintf(`T1.%s = %s`, charL+k+charR, gconv.String(v)),
+				)
+			default:
+				updateValues = append(
+					updateValues,
+					fmt.Sprintf(`T1.%s = T2.%s`, charL+k+charR, charL+gconv.String(v)+charR),
+				)
+			}
+		}
 	}
-	if n, err := r.RowsAffected(); err != nil {
-		return r, err
-	} else {
-		batchResult.Result = r
-		batchResult.Affected += n
+
+	var (
+		rowHolder   = "SELECT " + strings.Join(rowHolders, ",") + " FROM DUAL"
+		batchResult = new(gdb.SqlResult)
+	)
+	for i := 0; i < len(list); i += batchCount {
+		var (
+			batch        = list[i:]
+			queryHolders []string
+			queryValues  []interface{}
+		)
+		if len(batch) > batchCount {
+			batch = batch[:batchCount]
+		}
+		for _, record := range batch {
+			queryHolders = append(queryHolders, rowHolder)
+			for _, key := range keys {
+				queryValues = append(queryValues, record[key])
+			}
+		}
+		sqlStr := parseSqlForUpsert(table, queryHolders, insertKeys, insertValues, updateValues, conflictKeys)
+		r, err := d.DoExec(ctx, link, sqlStr, queryValues...)
+		if err != nil {
+			return r, err
+		}
+		if n, err := r.RowsAffected(); err != nil {
+			return r, err
+		} else {
+			batchResult.Result = r
+			batchResult.Affected += n
+		}
 	}
 	return batchResult, nil
 }
 
 // parseSqlForUpsert
 // MERGE INTO {{table}} T1
-// USING ( SELECT {{queryHolders}} FROM DUAL T2
+// USING ( SELECT {{queryHolders}} FROM DUAL UNION ALL ... ) T2
 // ON (T1.{{duplicateKey}} = T2.{{duplicateKey}} AND ...)
 // WHEN NOT MATCHED THEN
 // INSERT {{insertKeys}} VALUES {{insertValues}}
 // WHEN MATCHED THEN
 // UPDATE SET {{updateValues}}
+//
+// The WHEN MATCHED clause is omitted if there's no updateValues.
 func parseSqlForUpsert(table string,
 	queryHolders, insertKeys, insertValues, updateValues, duplicateKey []string,
 ) (sqlStr string) {
 	var (
-		queryHolderStr  = strings.Join(queryHolders, ",")
+		queryHolderStr  = strings.Join(queryHolders, " UNION ALL ")
 		insertKeyStr    = strings.Join(insertKeys, ",")
 		insertValueStr  = strings.Join(insertValues, ",")
-		updateValueStr  = strings.Join(updateValues, ",")
 		duplicateKeyStr string
-		pattern         = gstr.Trim(`MERGE INTO %s T1 USING (SELECT %s FROM DUAL) T2 ON (%s) WHEN NOT MATCHED THEN INSERT(%s) VALUES (%s) WHEN MATCHED THEN UPDATE SET %s;`)
+		pattern         = gstr.Trim(`MERGE INTO %s T1 USING (%s) T2 ON (%s) WHEN NOT MATCHED THEN INSERT(%s) VALUES (%s)`)
 	)
 
 	for index, keys := range duplicateKey {
@@ -140,12 +183,15 @@ func parseSqlForUpsert(table string,
 		duplicateKeyStr += duplicateTmp
 	}
 
-	return fmt.Sprintf(pattern,
+	sqlStr = fmt.Sprintf(pattern,
 		table,
 		queryHolderStr,
 		duplicateKeyStr,
 		insertKeyStr,
 		insertValueStr,
-		updateValueStr,
 	)
+	if len(updateValues) > 0 {
+		sqlStr += fmt.Sprintf(` WHEN MATCHED THEN UPDATE SET %s`, strings.Join(updateValues, ","))
+	}
+	return sqlStr + ";"
 }
@@ -0,0 +1,49 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package mssql
+
+import (
+	"strings"
+
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// FormatReturning returns the statement with OUTPUT clause for SQL Server, which has no RETURNING clause.
+// For example:
+// INSERT INTO user(name) OUTPUT INSERTED.id VALUES(?)
+// UPDATE user SET name=? OUTPUT INSERTED.id WHERE id=?
+// DELETE FROM user OUTPUT DELETED.id WHERE id=?
+func (d *Driver) FormatReturning(sql string, columns []string) string {
+	var (
+		keyword = strings.ToUpper(gstr.StrTillEx(gstr.Trim(sql), " "))
+		prefix  = "INSERTED."
+		outputs = make([]string, len(columns))
+	)
+	if keyword == "DELETE" {
+		prefix = "DELETED."
+	}
+	for i, column := range columns {
+		outputs[i] = prefix + column
+	}
+	output := " OUTPUT " + strings.Join(outputs, ",")
+	switch keyword {
+	case "INSERT":
+		if pos := strings.Index(sql, ") VALUES"); pos != -1 {
+			return sql[:pos+1] + output + sql[pos+1:]
+		}
+
+	case "UPDATE", "DELETE":
+		if pos := strings.Index(sql, " WHERE "); pos != -1 {
+			return sql[:pos] + output + sql[pos:]
+		}
+		return sql + output
+
+	case "MERGE":
+		return strings.TrimSuffix(gstr.Trim(sql), ";") + output + ";"
+	}
+	return ""
+}
@@ -7,21 +7,31 @@
 // Package oracle implements gdb.Driver, which supports operations for database Oracle.
 //
 // Note:
-// 1. It does not support Save/Replace features.
-// 2. It does not support LastInsertId.
+// 1. It does not support Replace feature.
+// 2. It supports LastInsertId only for the table configured by SetSequence.
+// 3. It paginates by ROWNUM in default, or by FETCH NEXT if ConfigNode.Extra contains "pagination=fetch".
 package oracle
 
 import (
+	"strings"
+
 	"github.com/gogf/gf/v2/database/gdb"
 )
 
 // Driver is the driver for oracle database.
 type Driver struct {
 	*gdb.Core
+	pagination string // Pagination syntax for LIMIT statement, see paginationRowNum and paginationFetch.
 }
 
 const (
 	quoteChar = `"`
+
+	// extraKeyPagination is the key of ConfigNode.Extra specifying the pagination syntax,
+	// eg: "pagination=fetch", which is not passed to the underlying driver.
+	extraKeyPagination = "pagination"
+	paginationRowNum   = "rownum" // Pagination by ROWNUM, which is supported by all versions.
+	paginationFetch    = "fetch"  // Pagination by OFFSET...FETCH NEXT...ROWS ONLY, which is supported by 12c and above.
 )
 
 func init() {
@@ -39,10 +49,26 @@ func New() gdb.Driver {
 // It implements the interface of gdb.Driver for extra database driver installation.
 func (d *Driver) New(core *gdb.Core, node *gdb.ConfigNode) (gdb.DB, error) {
 	return &Driver{
-		Core: core,
+		Core:       core,
+		pagination: parseExtra(node.Extra)[extraKeyPagination],
 	}, nil
 }
 
+// parseExtra parses ConfigNode.Extra like "key1=value1&key2=value2" into map.
+func parseExtra(extra string) map[string]string {
+	var options = make(map[string]string)
+	if extra == "" {
+		return options
+	}
+	for _, v := range strings.Split(extra, "&") {
+		kv := strings.Split(v, "=")
+		if len(kv) == 2 {
+			options[kv[0]] = kv[1]
+		}
+	}
+	return options
+}
+
 // GetChars returns the security char for this type of database.
 func (d *Driver) GetChars() (charLeft string, charRight string) {
 	return quoteChar, quoteChar
@@ -7,6 +7,7 @@
 package oracle
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strconv"
@@ -24,6 +25,7 @@ SELECT * FROM (
 ) 
 	WHERE ROWNUM_ > %d
 `
+	newSqlFetchTmp = `%s %s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY`
 )
 
 func init() {
@@ -36,16 +38,9 @@ func init() {
 
 // DoFilter deals with the sql string before commits it to underlying sql driver.
 func (d *Driver) DoFilter(ctx context.Context, link gdb.Link, sql string, args []interface{}) (newSql string, newArgs []interface{}, err error) {
-	var index int
 	newArgs = args
 	// Convert placeholder char '?' to string ":vx".
-	newSql, err = gregex.ReplaceStringFunc("\\?", sql, func(s string) string {
-		index++
-		return fmt.Sprintf(":v%d", index)
-	})
-	if err != nil {
-		return
-	}
+	newSql = convertPlaceholder(sql)
 	newSql, err = gregex.ReplaceString("\"", "", newSql)
 	if err != nil {
 		return
@@ -75,6 +70,29 @@ func (d *Driver) parseSql(toBeCommittedSql string) (string, error) {
 	return toBeCommittedSql, nil
 }
 
+// convertPlaceholder converts the placeholder char '?' that is not in string literal to ":vx".
+func convertPlaceholder(sql string) string {
+	var (
+		index    int
+		inString bool
+		buffer   = bytes.NewBuffer(nil)
+	)
+	for _, char := range sql {
+		switch {
+		case char == '\'':
+			// The escaped quote "''" in string literal toggles it twice.
+			inString = !inString
+
+		case char == '?' && !inString:
+			index++
+			buffer.WriteString(fmt.Sprintf(":v%d", index))
+			continue
+		}
+		buffer.WriteRune(char)
+	}
+	return buffer.String()
+}
+
 func (d *Driver) handleSelectSqlReplacement(toBeCommittedSql string) (newSql string, err error) {
 	var (
 		match  [][]string
@@ -104,14 +122,14 @@ func (d *Driver) handleSelectSqlReplacement(toBeCommittedSql string) (newSql str
 		strings.EqualFold(queryExpr[3], "LIMIT") == false {
 		return toBeCommittedSql, nil
 	}
-	page, limit := 0, 0
+	offset, limit := 0, 0
 	for i := 1; i < len(match[index]); i++ {
 		if len(strings.TrimSpace(match[index][i])) == 0 {
 			continue
 		}
 		if strings.HasPrefix(match[index][i], "LIMIT") {
 			if match[index][i+2] != "" {
-				page, err = strconv.Atoi(match[index][i+1])
+				offset, err = strconv.Atoi(match[index][i+1])
 				if err != nil {
 					return "", err
 				}
@@ -119,14 +137,6 @@ func (d *Driver) handleSelectSqlReplacement(toBeCommittedSql string) (newSql str
 				if err != nil {
 					return "", err
 				}
-				if page <= 0 {
-					page = 1
-				}
-				limit = (page/limit + 1) * limit
-				page, err = strconv.Atoi(match[index][i+1])
-				if err != nil {
-					return "", err
-				}
 			} else {
 				limit, err = strconv.Atoi(match[index][i+1])
 				if err != nil {
@@ -136,9 +146,15 @@ func (d *Driver) handleSelectSqlReplacement(toBeCommittedSql string) (newSql str
 			break
 		}
 	}
+	if d.pagination == paginationFetch {
+		return fmt.Sprintf(
+			newSqlFetchTmp,
+			queryExpr[1], queryExpr[2], offset, limit,
+		), nil
+	}
 	var newReplacedSql = fmt.Sprintf(
 		newSqlReplacementTmp,
-		queryExpr[1], queryExpr[2], limit, page,
+		queryExpr[1], queryExpr[2], offset+limit, offset,
 	)
 	return newReplacedSql, nil
 }
@@ -8,7 +8,6 @@ package oracle
 
 import (
 	"database/sql"
-	"strings"
 
 	gora "github.com/sijms/go-ora/v2"
 
@@ -45,14 +44,10 @@ func (d *Driver) Open(config *gdb.ConfigNode) (db *sql.DB, err error) {
 			source, _ = gregex.ReplaceString(`@(.+?)/([\w\.\-]+)+`, "@$1/"+config.Name, source)
 		}
 	} else {
-		if config.Extra != "" {
-			// fix #3226
-			list := strings.Split(config.Extra, "&")
-			for _, v := range list {
-				kv := strings.Split(v, "=")
-				if len(kv) == 2 {
-					options[kv[0]] = kv[1]
-				}
+		// fix #3226
+		for k, v := range parseExtra(config.Extra) {
+			if k != extraKeyPagination {
+				options[k] = v
 			}
 		}
 		source = gora.BuildUrl(
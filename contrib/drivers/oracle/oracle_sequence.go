@@ -0,0 +1,123 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+// sequence is the sequence generating the auto-increment value of a table column.
+type sequence struct {
+	column string // Column of the auto-increment value.
+	name   string // Name of the sequence.
+}
+
+// sqlResult is the inserting result, whose LastInsertId is the sequence value of the last record.
+type sqlResult struct {
+	*gdb.SqlResult
+	lastInsertId int64
+}
+
+var (
+	// sequences is the configured sequences, "group@SCHEMA.TABLE" or "group@TABLE" => *sequence.
+	sequences = gmap.NewStrAnyMap(true)
+)
+
+// SetSequence sets the sequence `name` generating the auto-increment value of `column` for `table`
+// of configuration `group`, eg:
+// SetSequence("default", "user", "id", "seq_user_id").
+// The `table` can have schema prefix like "hr.user", or else it applies to the table in any schema of `group`.
+// The value of `column` is fetched from the sequence if it's absent in the inserting record,
+// and the value of the last record is returned as LastInsertId of the inserting result.
+func SetSequence(group, table, column, name string) {
+	sequences.Set(getSequenceKey(group, table), &sequence{
+		column: column,
+		name:   name,
+	})
+}
+
+// LastInsertId returns the sequence value of the last inserted record.
+func (r *sqlResult) LastInsertId() (int64, error) {
+	return r.lastInsertId, nil
+}
+
+// getSequenceKey returns the key of `sequences` for `table` of `group`.
+func getSequenceKey(group, table string) string {
+	return fmt.Sprintf(`%s@%s`, group, strings.ToUpper(table))
+}
+
+// getSequence returns the configured sequence of `table`, which can be quoted and has schema prefix.
+// The sequence configured with schema is prior to the one without schema.
+func (d *Driver) getSequence(table string) *sequence {
+	var (
+		schema = d.GetSchema()
+		group  = d.GetGroup()
+	)
+	table = gstr.Replace(table, quoteChar, "")
+	if pos := strings.LastIndex(table, "."); pos >= 0 {
+		schema, table = table[:pos], table[pos+1:]
+	}
+	for _, key := range []string{
+		getSequenceKey(group, schema+"."+table),
+		getSequenceKey(group, table),
+	} {
+		if v := sequences.Get(key); v != nil {
+			return v.(*sequence)
+		}
+	}
+	return nil
+}
+
+// fillSequenceValues fills the sequence values to the records of `list` that have no auto-increment value,
+// and returns the sequence value of the last record.
+// It returns false if no sequence is configured for `table` or no value is filled.
+func (d *Driver) fillSequenceValues(
+	ctx context.Context, link gdb.Link, table string, list gdb.List,
+) (lastInsertId int64, ok bool, err error) {
+	seq := d.getSequence(table)
+	if seq == nil {
+		return 0, false, nil
+	}
+	var (
+		column  = strings.ToUpper(seq.column)
+		indexes = make([]int, 0, len(list))
+	)
+	for i, record := range list {
+		if key, _ := gutil.MapPossibleItemByKey(record, column); key == "" {
+			indexes = append(indexes, i)
+		}
+	}
+	if len(indexes) == 0 {
+		return 0, false, nil
+	}
+	result, err := d.DoQuery(ctx, link, fmt.Sprintf(
+		`SELECT %s.NEXTVAL AS ID FROM DUAL CONNECT BY LEVEL <= %d`, seq.name, len(indexes),
+	))
+	if err != nil {
+		return 0, false, err
+	}
+	if len(result) != len(indexes) {
+		return 0, false, gerror.NewCodef(
+			gcode.CodeDbOperationError,
+			`expect %d values from sequence "%s", but got %d`, len(indexes), seq.name, len(result),
+		)
+	}
+	for i, index := range indexes {
+		lastInsertId = result[i]["ID"].Int64()
+		list[index][column] = lastInsertId
+	}
+	return lastInsertId, true, nil
+}
@@ -11,7 +11,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/gogf/gf/contrib/drivers/oracle/v2"
 	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/text/gstr"
 	"github.com/gogf/gf/v2/util/gconv"
 
 	"github.com/gogf/gf/v2/frame/g"
@@ -712,3 +714,59 @@ func Test_Extra(t *testing.T) {
 		}
 	})
 }
+
+func Test_DoFilter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		sql, args, err := db.DoFilter(ctx, nil, "SELECT * FROM t WHERE id=? AND name='a?b' AND nick=?", g.Slice{1, 2})
+		t.AssertNil(err)
+		t.Assert(sql, "SELECT * FROM t WHERE id=:v1 AND name='a?b' AND nick=:v2")
+		t.Assert(args, g.Slice{1, 2})
+
+		sql, _, err = db.DoFilter(ctx, nil, "SELECT * FROM t ORDER BY id LIMIT 5,10", nil)
+		t.AssertNil(err)
+		t.Assert(gstr.Contains(sql, "ROWNUM <= 15"), true)
+		t.Assert(gstr.Contains(sql, "ROWNUM_ > 5"), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		node := gdb.ConfigNode{
+			Type:  TestDbType,
+			Extra: "pagination=fetch",
+		}
+		fetchDb, err := gdb.New(node)
+		t.AssertNil(err)
+		sql, _, err := fetchDb.DoFilter(ctx, nil, "SELECT * FROM t ORDER BY id LIMIT 5,10", nil)
+		t.AssertNil(err)
+		t.Assert(gstr.Contains(sql, "OFFSET 5 ROWS FETCH NEXT 10 ROWS ONLY"), true)
+	})
+}
+
+func Test_DB_InsertAndGetId_Sequence(t *testing.T) {
+	table := createTable()
+	defer dropTable(table)
+	sequence := "SEQ_" + strings.ToUpper(table)
+	gtest.C(t, func(t *gtest.T) {
+		_, err := db.Exec(ctx, fmt.Sprintf("CREATE SEQUENCE %s START WITH 100", sequence))
+		t.AssertNil(err)
+		defer db.Exec(ctx, fmt.Sprintf("DROP SEQUENCE %s", sequence))
+		oracle.SetSequence(db.GetGroup(), table, "ID", sequence)
+
+		id, err := db.Model(table).Data(g.Map{
+			"PASSPORT": "t1",
+			"NICKNAME": "name_1",
+		}).InsertAndGetId()
+		t.AssertNil(err)
+		t.Assert(id, 100)
+
+		result, err := db.Model(table).Data(g.List{
+			{"PASSPORT": "t2", "NICKNAME": "name_2"},
+			{"PASSPORT": "t3", "NICKNAME": "name_3"},
+		}).Insert()
+		t.AssertNil(err)
+		id, err = result.LastInsertId()
+		t.AssertNil(err)
+		t.Assert(id, 102)
+		value, err := db.Model(table).Where("PASSPORT", "t3").Value("ID")
+		t.AssertNil(err)
+		t.Assert(value.Int(), 102)
+	})
+}
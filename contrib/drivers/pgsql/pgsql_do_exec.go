@@ -53,6 +53,11 @@ func (d *Driver) DoExec(ctx context.Context, link gdb.Link, sql string, args ...
 		isUseCoreDoExec = true
 	}
 
+	// The RETURNING clause of Model.Returning is handled by default DoExec.
+	if len(gdb.ReturningColumnsFromCtx(ctx)) > 0 {
+		isUseCoreDoExec = true
+	}
+
 	// check if it is an insert operation.
 	if !isUseCoreDoExec && pkField.Name != "" && strings.Contains(sql, "INSERT INTO") {
 		primaryKey = pkField.Name
@@ -0,0 +1,45 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package pgsql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// pathItemReplacer escapes the item of array literal of JSON path.
+var pathItemReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// FormatJSON returns SQL expression of JSON operation for PostgreSQL, in which the JSON path is bound as argument.
+// For example: "extra" #>> CAST(? AS text[]) and ("extra" #> CAST(? AS text[])) @> CAST(? AS jsonb),
+// with path argument '{"address","city"}'.
+// Note that the JSON column should be type of jsonb for JSONOperationContains.
+func (d *Driver) FormatJSON(operation gdb.JSONOperation, column string, path []string) (expr string, args []interface{}) {
+	if len(path) > 0 {
+		items := make([]string, len(path))
+		for i, item := range path {
+			items[i] = fmt.Sprintf(`"%s"`, pathItemReplacer.Replace(item))
+		}
+		args = []interface{}{fmt.Sprintf(`{%s}`, gstr.Join(items, ","))}
+	}
+	switch operation {
+	case gdb.JSONOperationContains:
+		if len(args) == 0 {
+			return fmt.Sprintf(`%s @> CAST(? AS jsonb)`, column), nil
+		}
+		return fmt.Sprintf(`(%s #> CAST(? AS text[])) @> CAST(? AS jsonb)`, column), args
+
+	default:
+		if len(args) == 0 {
+			return column, nil
+		}
+		return fmt.Sprintf(`%s #>> CAST(? AS text[])`, column), args
+	}
+}
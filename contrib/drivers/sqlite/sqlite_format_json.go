@@ -0,0 +1,33 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package sqlite
+
+import (
+	"fmt"
+
+	"github.com/gogf/gf/v2/database/gdb"
+)
+
+// FormatJSON returns SQL expression of JSON operation for SQLite, in which the JSON path is bound as argument.
+// For example: json_extract("extra", ?) with argument '$.address.city'.
+// Note that JSONOperationContains checks whether the array or object at the path contains
+// the scalar value of placeholder, as SQLite has no function for JSON document containing.
+func (d *Driver) FormatJSON(operation gdb.JSONOperation, column string, path []string) (expr string, args []interface{}) {
+	args = []interface{}{gdb.FormatJSONPath(path)}
+	switch operation {
+	case gdb.JSONOperationContains:
+		return fmt.Sprintf(
+			`EXISTS (SELECT 1 FROM json_each(%s, ?) WHERE json_each.value = json_extract(?, '$'))`, column,
+		), args
+
+	default:
+		if len(path) == 0 {
+			return column, nil
+		}
+		return fmt.Sprintf(`json_extract(%s, ?)`, column), args
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package sqlite
+
+import (
+	"strings"
+)
+
+// FormatReturning returns the statement with RETURNING clause for SQLite, which is supported since 3.35.0.
+// For example: INSERT INTO "user"("name") VALUES(?) RETURNING "id","created_at".
+func (d *Driver) FormatReturning(sql string, columns []string) string {
+	return strings.TrimSpace(sql) + " RETURNING " + strings.Join(columns, ",")
+}
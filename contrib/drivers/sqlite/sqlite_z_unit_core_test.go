@@ -17,10 +17,12 @@ import (
 	"github.com/gogf/gf/v2/database/gdb"
 	"github.com/gogf/gf/v2/encoding/gjson"
 	"github.com/gogf/gf/v2/encoding/gxml"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
 )
 
 func Test_New(t *testing.T) {
@@ -1610,3 +1612,175 @@ func Test_TableNameIsKeyword(t *testing.T) {
 		t.Assert(t_users[0].CreateTime, resultIntMap[id]["create_time"])
 	})
 }
+
+func Test_DB_StmtCache(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		node := configNode
+		node.StmtCacheSize = 2
+		cacheDb, err := gdb.New(node)
+		t.AssertNil(err)
+		defer cacheDb.Close(ctx)
+		table := createInitTableWithDb(cacheDb)
+		defer dropTableWithDb(cacheDb, table)
+
+		before := cacheDb.Stats(ctx)[0].StmtCacheStats()
+		for i := 1; i <= 3; i++ {
+			one, err := cacheDb.Model(table).WherePri(i).One()
+			t.AssertNil(err)
+			t.Assert(one["passport"], fmt.Sprintf(`user_%d`, i))
+		}
+		stats := cacheDb.Stats(ctx)[0].StmtCacheStats()
+		t.Assert(stats.Size, 2)
+		t.Assert(stats.Hits-before.Hits, 2)
+		t.Assert(stats.Misses-before.Misses, 1)
+		t.Assert(stats.Evictions-before.Evictions, 1)
+
+		// The statement of a new query evicts the least recently used one.
+		_, err = cacheDb.Model(table).Data(g.Map{"nickname": "name"}).WherePri(1).Update()
+		t.AssertNil(err)
+		stats = cacheDb.Stats(ctx)[0].StmtCacheStats()
+		t.Assert(stats.Size, 2)
+		t.Assert(stats.Evictions-before.Evictions, 2)
+		count, err := cacheDb.Model(table).Where("nickname", "name").Count()
+		t.AssertNil(err)
+		t.Assert(count, 1)
+	})
+}
+
+func Test_DB_SlowQuery(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			group = fmt.Sprintf(`slow_%d`, gtime.TimestampNano())
+			node  = configNode
+		)
+		node.SlowThreshold = time.Millisecond
+		gdb.SetConfigGroup(group, gdb.ConfigGroup{node})
+		slowDb, err := gdb.NewByGroup(group)
+		t.AssertNil(err)
+		defer slowDb.Close(ctx)
+
+		var queries = make([]*gdb.SlowQuery, 0)
+		gdb.SetSlowQueryHandler(group, func(ctx context.Context, query *gdb.SlowQuery) {
+			queries = append(queries, query)
+		})
+		defer gdb.SetSlowQueryHandler(group, nil)
+
+		value, err := slowDb.GetValue(ctx, `
+WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM c WHERE x < ?)
+SELECT COUNT(*) FROM c`, 1000000)
+		t.AssertNil(err)
+		t.Assert(value, 1000000)
+		t.Assert(len(queries), 1)
+		t.Assert(queries[0].Group, group)
+		t.Assert(queries[0].Args, g.Slice{1000000})
+		t.Assert(queries[0].RowsAffected, 1)
+		t.AssertGE(queries[0].Duration, time.Millisecond)
+		t.Assert(gstr.Contains(queries[0].Stack, "sqlite_z_unit_core_test.go"), true)
+		t.Assert(gstr.Contains(queries[0].Stack, "/database/gdb/"), false)
+	})
+}
+
+func Test_DB_Transaction_Nested_Policy(t *testing.T) {
+	// Savepoint policy in default.
+	gtest.C(t, func(t *gtest.T) {
+		table := createTable()
+		defer dropTable(table)
+
+		err := db.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+			_, err := tx.Model(table).Data(g.Map{"id": 1, "passport": "user_1"}).Insert()
+			t.AssertNil(err)
+			err = db.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+				_, err := db.Model(table).Ctx(ctx).Data(g.Map{"id": 2, "passport": "user_2"}).Insert()
+				t.AssertNil(err)
+				return gerror.New("inner error")
+			})
+			t.AssertNE(err, nil)
+			return nil
+		})
+		t.AssertNil(err)
+		count, err := db.Model(table).Count()
+		t.AssertNil(err)
+		t.Assert(count, 1)
+	})
+	// Join policy.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			group = fmt.Sprintf(`tx_join_%d`, gtime.TimestampNano())
+			node  = configNode
+		)
+		node.TxNestedPolicy = gdb.TxNestedPolicyJoin
+		gdb.SetConfigGroup(group, gdb.ConfigGroup{node})
+		joinDb, err := gdb.NewByGroup(group)
+		t.AssertNil(err)
+		defer joinDb.Close(ctx)
+
+		table := createTableWithDb(joinDb)
+		defer dropTableWithDb(joinDb, table)
+
+		err = joinDb.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+			_, err := tx.Model(table).Data(g.Map{"id": 1, "passport": "user_1"}).Insert()
+			t.AssertNil(err)
+			err = joinDb.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+				_, err := joinDb.Model(table).Ctx(ctx).Data(g.Map{"id": 2, "passport": "user_2"}).Insert()
+				t.AssertNil(err)
+				return gerror.New("inner error")
+			})
+			t.AssertNE(err, nil)
+			return nil
+		})
+		t.AssertNil(err)
+		// The inserting of inner transaction is not rolled back, as there's no savepoint.
+		count, err := joinDb.Model(table).Count()
+		t.AssertNil(err)
+		t.Assert(count, 2)
+	})
+}
+
+func Test_DB_PoolExhausted(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			group = fmt.Sprintf(`pool_%d`, gtime.TimestampNano())
+			node  = configNode
+		)
+		node.MaxOpenConnCount = 1
+		gdb.SetConfigGroup(group, gdb.ConfigGroup{node})
+		poolDb, err := gdb.NewByGroup(group)
+		t.AssertNil(err)
+		defer poolDb.Close(ctx)
+
+		var events = make(chan *gdb.PoolExhaustedEvent, 10)
+		gdb.SetPoolExhaustedHandler(group, func(ctx context.Context, event *gdb.PoolExhaustedEvent) {
+			events <- event
+		})
+		defer gdb.SetPoolExhaustedHandler(group, nil)
+
+		_, err = poolDb.GetValue(ctx, "SELECT 1")
+		t.AssertNil(err)
+		t.Assert(len(events), 0)
+
+		// The transaction holds the only connection.
+		tx, err := poolDb.Begin(ctx)
+		t.AssertNil(err)
+		var done = make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = poolDb.GetValue(ctx, "SELECT 1")
+		}()
+		time.Sleep(100 * time.Millisecond)
+		t.AssertNil(tx.Commit())
+		<-done
+
+		t.Assert(len(events), 1)
+		event := <-events
+		t.Assert(event.Group, group)
+		t.Assert(event.Node.Type, "sqlite")
+		t.Assert(event.Stats.MaxOpenConnections, 1)
+		t.Assert(event.Stats.InUse, 1)
+
+		// The pool has free connection again.
+		_, err = poolDb.GetValue(ctx, "SELECT 1")
+		t.AssertNil(err)
+		t.Assert(len(events), 0)
+		t.AssertGE(poolDb.GetCore().Stats(ctx)[0].Stats().WaitCount, 1)
+	})
+}
@@ -0,0 +1,205 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package sqlite_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// newTestMigrator creates a migrator with a SQL migration creating table and a Go migration inserting data,
+// which returns the migrator, the table created by the migrations and the lock table of migrator.
+func newTestMigrator(t *gtest.T, option gdb.MigratorOption) (*gdb.Migrator, string, string) {
+	var (
+		suffix = guid.S()
+		table  = "migration_user_" + suffix
+		path   = gfile.Temp(suffix)
+	)
+	option.Table = "migrations_" + suffix
+	option.LockTable = "migrations_lock_" + suffix
+	t.AssertNil(gfile.PutContents(
+		gfile.Join(path, "20240101000000_create_user.up.sql"),
+		fmt.Sprintf("-- Create table.\nCREATE TABLE %s (\n  id INTEGER PRIMARY KEY,\n  name VARCHAR(45)\n);\n", table),
+	))
+	t.AssertNil(gfile.PutContents(
+		gfile.Join(path, "20240101000000_create_user.down.sql"),
+		fmt.Sprintf("DROP TABLE %s;", table),
+	))
+	migrator := gdb.NewMigrator(db, option)
+	t.AssertNil(migrator.AddPath(path))
+	t.AssertNil(gfile.Remove(path))
+	t.AssertNil(migrator.Add(gdb.Migration{
+		Version: 20240102000000,
+		Name:    "insert_user",
+		Up: func(ctx context.Context, tx gdb.TX) error {
+			_, err := tx.Insert(table, g.Map{"id": 1, "name": "john"})
+			return err
+		},
+		Down: func(ctx context.Context, tx gdb.TX) error {
+			_, err := tx.Delete(table, "id", 1)
+			return err
+		},
+	}))
+	return migrator, table, option.LockTable
+}
+
+func Test_Migrator_Up_Down(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		migrator, table, _ := newTestMigrator(t, gdb.MigratorOption{})
+		defer dropTable(table)
+
+		migrated, err := migrator.Up(ctx)
+		t.AssertNil(err)
+		t.Assert(len(migrated), 2)
+		t.Assert(migrated[0].Version, 20240101000000)
+		t.Assert(migrated[1].Name, "insert_user")
+		count, err := db.Model(table).Count()
+		t.AssertNil(err)
+		t.Assert(count, 1)
+
+		// The applied migrations are not applied again.
+		migrated, err = migrator.Up(ctx)
+		t.AssertNil(err)
+		t.Assert(len(migrated), 0)
+
+		statuses, err := migrator.Status(ctx)
+		t.AssertNil(err)
+		t.Assert(len(statuses), 2)
+		t.Assert(statuses[0].Applied, true)
+		t.Assert(statuses[1].Applied, true)
+		t.AssertNE(statuses[1].AppliedAt, "")
+
+		// Only the last one is reverted in default.
+		migrated, err = migrator.Down(ctx)
+		t.AssertNil(err)
+		t.Assert(len(migrated), 1)
+		t.Assert(migrated[0].Version, 20240102000000)
+		count, err = db.Model(table).Count()
+		t.AssertNil(err)
+		t.Assert(count, 0)
+
+		statuses, err = migrator.Status(ctx)
+		t.AssertNil(err)
+		t.Assert(statuses[0].Applied, true)
+		t.Assert(statuses[1].Applied, false)
+
+		migrated, err = migrator.Down(ctx, -1)
+		t.AssertNil(err)
+		t.Assert(len(migrated), 1)
+		tables, err := db.Tables(ctx)
+		t.AssertNil(err)
+		t.AssertNI(table, tables)
+
+		// Applying with steps.
+		migrated, err = migrator.Up(ctx, 1)
+		t.AssertNil(err)
+		t.Assert(len(migrated), 1)
+		t.Assert(migrated[0].Version, 20240101000000)
+	})
+}
+
+func Test_Migrator_DryRun(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		migrator, table, _ := newTestMigrator(t, gdb.MigratorOption{DryRun: true})
+		migrated, err := migrator.Up(ctx)
+		t.AssertNil(err)
+		t.Assert(len(migrated), 2)
+
+		tables, err := db.Tables(ctx)
+		t.AssertNil(err)
+		t.AssertNI(table, tables)
+		statuses, err := migrator.Status(ctx)
+		t.AssertNil(err)
+		t.Assert(statuses[0].Applied, false)
+		t.Assert(statuses[1].Applied, false)
+	})
+}
+
+func Test_Migrator_Lock(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		migrator, table, lockTable := newTestMigrator(t, gdb.MigratorOption{
+			LockTimeout: 300 * time.Millisecond,
+			LockTTL:     time.Minute,
+		})
+		defer dropTable(table)
+
+		// The tables of migrator are created by the first running.
+		_, err := migrator.Up(ctx, 1)
+		t.AssertNil(err)
+
+		// The lock held by others.
+		_, err = db.Insert(ctx, lockTable, g.Map{"id": 1, "owner": "other", "locked_at": time.Now().Unix()})
+		t.AssertNil(err)
+		_, err = migrator.Up(ctx)
+		t.AssertNE(err, nil)
+		statuses, err := migrator.Status(ctx)
+		t.AssertNil(err)
+		t.Assert(statuses[1].Applied, false)
+
+		// The stale lock is released.
+		_, err = db.Update(ctx, lockTable, g.Map{"locked_at": time.Now().Add(-time.Hour).Unix()}, "id", 1)
+		t.AssertNil(err)
+		migrated, err := migrator.Up(ctx)
+		t.AssertNil(err)
+		t.Assert(len(migrated), 1)
+		count, err := db.Model(lockTable).Count()
+		t.AssertNil(err)
+		t.Assert(count, 0)
+	})
+}
+
+func Test_Migrator_Lock_Renew(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		migrator, table, lockTable := newTestMigrator(t, gdb.MigratorOption{
+			LockTTL: 300 * time.Millisecond,
+		})
+		defer dropTable(table)
+
+		_, err := migrator.Up(ctx, 1)
+		t.AssertNil(err)
+		t.AssertNil(migrator.Add(gdb.Migration{
+			Version: 20240103000000,
+			Name:    "slow",
+			Up: func(ctx context.Context, tx gdb.TX) error {
+				time.Sleep(2500 * time.Millisecond)
+				return nil
+			},
+		}))
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := migrator.Up(ctx)
+			done <- err
+		}()
+		time.Sleep(100 * time.Millisecond)
+
+		// The lock is renewed while migrating, which is not released as stale by others.
+		other := gdb.NewMigrator(db, gdb.MigratorOption{
+			Table:       strings.Replace(lockTable, "migrations_lock_", "migrations_", 1),
+			LockTable:   lockTable,
+			LockTimeout: 2 * time.Second,
+			LockTTL:     300 * time.Millisecond,
+		})
+		_, err = other.Up(ctx)
+		t.AssertNE(err, nil)
+		t.AssertNil(<-done)
+
+		statuses, err := migrator.Status(ctx)
+		t.AssertNil(err)
+		t.Assert(len(statuses), 3)
+		t.Assert(statuses[2].Applied, true)
+	})
+}
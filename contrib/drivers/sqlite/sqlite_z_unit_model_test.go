@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -4299,3 +4300,761 @@ func TestResult_Structs1(t *testing.T) {
 		t.Assert(array[1].Name, "smith")
 	})
 }
+
+func Test_Model_Cursor(t *testing.T) {
+	table := createInitTable()
+	defer dropTable(table)
+
+	gtest.C(t, func(t *gtest.T) {
+		cursor, err := db.Model(table).Where("id>?", 3).Order("id").Cursor(ctx)
+		t.AssertNil(err)
+		defer cursor.Close()
+		ids := make([]int, 0)
+		for cursor.Next() {
+			ids = append(ids, cursor.Record()["id"].Int())
+		}
+		t.AssertNil(cursor.Err())
+		t.Assert(ids, []int{4, 5, 6, 7, 8, 9, 10})
+		t.Assert(cursor.Next(), false)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		cursor, err := db.Model(table).Order("id").Cursor(ctx)
+		t.AssertNil(err)
+		defer cursor.Close()
+		var sizes []int
+		for {
+			result, err := cursor.Batch(4)
+			t.AssertNil(err)
+			if result.IsEmpty() {
+				break
+			}
+			sizes = append(sizes, result.Len())
+		}
+		t.Assert(sizes, []int{4, 4, 2})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := db.Model(table).Where("no_such_field", 1).Cursor(ctx)
+		t.AssertNE(err, nil)
+	})
+	// The query timeout does not apply to the iterating of rows.
+	gtest.C(t, func(t *gtest.T) {
+		node := configNode
+		node.QueryTimeout = 100 * time.Millisecond
+		timeoutDb, err := gdb.New(node)
+		t.AssertNil(err)
+		defer timeoutDb.Close(ctx)
+
+		cursor, err := timeoutDb.Model(table).Order("id").Cursor(ctx)
+		t.AssertNil(err)
+		defer cursor.Close()
+		var count int
+		for cursor.Next() {
+			if count++; count == 1 {
+				time.Sleep(200 * time.Millisecond)
+			}
+		}
+		t.AssertNil(cursor.Err())
+		t.Assert(count, TableSize)
+	})
+}
+
+func Test_Model_ScanIterator(t *testing.T) {
+	table := createInitTable()
+	defer dropTable(table)
+
+	gtest.C(t, func(t *gtest.T) {
+		type User struct {
+			Id       int
+			Passport string
+		}
+		var (
+			user      *User
+			passports = make([]string, 0)
+		)
+		err := db.Model(table).Order("id").ScanIterator(ctx, &user, func() error {
+			passports = append(passports, user.Passport)
+			return nil
+		})
+		t.AssertNil(err)
+		t.Assert(len(passports), TableSize)
+		t.Assert(passports[0], "user_1")
+		t.Assert(passports[TableSize-1], "user_10")
+
+		// It stops by error of handler.
+		var count int
+		err = db.Model(table).ScanIterator(ctx, &user, func() error {
+			if count++; count == 3 {
+				return gerror.New("stop")
+			}
+			return nil
+		})
+		t.Assert(err, "stop")
+		t.Assert(count, 3)
+	})
+}
+
+func Test_Model_Save_DoUpdate(t *testing.T) {
+	table := createInitTable()
+	defer dropTable(table)
+
+	gtest.C(t, func(t *gtest.T) {
+		list := g.List{}
+		for i := 1; i <= 25; i++ {
+			list = append(list, g.Map{
+				"id":       i,
+				"passport": fmt.Sprintf(`new_user_%d`, i),
+				"nickname": fmt.Sprintf(`new_name_%d`, i),
+			})
+		}
+		sqlArray, err := gdb.CatchSQL(ctx, func(ctx context.Context) error {
+			_, err := db.Model(table).Ctx(ctx).Data(list).OnConflict("id").DoUpdate("nickname").Batch(10).Save()
+			return err
+		})
+		t.AssertNil(err)
+		t.Assert(len(sqlArray), 3)
+
+		// The existing records are updated in nickname only.
+		one, err := db.Model(table).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "user_1")
+		t.Assert(one["nickname"], "new_name_1")
+		one, err = db.Model(table).WherePri(25).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "new_user_25")
+		count, err := db.Model(table).Count()
+		t.AssertNil(err)
+		t.Assert(count, 25)
+	})
+}
+
+func Test_Model_Update_Version(t *testing.T) {
+	table := fmt.Sprintf(`version_%d`, gtime.TimestampNano())
+	if _, err := db.Exec(ctx, fmt.Sprintf(`
+CREATE TABLE %s (
+	id       INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	nickname VARCHAR(45),
+	version  INTEGER NOT NULL DEFAULT 0
+);`, table)); err != nil {
+		gtest.Fatal(err)
+	}
+	defer dropTable(table)
+
+	type User struct {
+		Id       int    `orm:"id"`
+		Nickname string `orm:"nickname"`
+		Version  int    `orm:"version" gdb:"version"`
+	}
+	gtest.C(t, func(t *gtest.T) {
+		_, err := db.Model(table).Data(g.Map{"id": 1, "nickname": "name_1", "version": 1}).Insert()
+		t.AssertNil(err)
+
+		var user, stale *User
+		t.AssertNil(db.Model(table).WherePri(1).Scan(&user))
+		t.AssertNil(db.Model(table).WherePri(1).Scan(&stale))
+
+		user.Nickname = "name_2"
+		_, err = db.Model(table).Data(user).WherePri(1).Update()
+		t.AssertNil(err)
+		t.Assert(user.Version, 2)
+		one, err := db.Model(table).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["nickname"], "name_2")
+		t.Assert(one["version"], 2)
+
+		// The stale record conflicts with the updated one.
+		stale.Nickname = "name_3"
+		_, err = db.Model(table).Data(stale).WherePri(1).Update()
+		var conflictErr *gdb.VersionConflictError
+		t.Assert(errors.As(err, &conflictErr), true)
+		t.Assert(conflictErr.Version, 1)
+		t.Assert(gerror.Code(err), gcode.CodeOperationFailed)
+		t.Assert(stale.Version, 1)
+		one, err = db.Model(table).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["nickname"], "name_2")
+	})
+	// The record is specified by the primary key in data if there's no WHERE condition.
+	gtest.C(t, func(t *gtest.T) {
+		_, err := db.Model(table).Data(g.Map{"id": 2, "nickname": "name_1", "version": 1}).Insert()
+		t.AssertNil(err)
+
+		user := &User{Id: 2, Nickname: "name_2", Version: 1}
+		_, err = db.Model(table).Data(user).Update()
+		t.AssertNil(err)
+		t.Assert(user.Version, 2)
+		one, err := db.Model(table).WherePri(2).One()
+		t.AssertNil(err)
+		t.Assert(one["nickname"], "name_2")
+		t.Assert(one["version"], 2)
+		// Other records are not updated.
+		one, err = db.Model(table).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["nickname"], "name_2")
+		t.Assert(one["version"], 2)
+
+		// It requires WHERE condition or primary key in data.
+		_, err = db.Model(table).Data(&User{Nickname: "name_3", Version: 2}).Update()
+		t.AssertNE(err, nil)
+		t.Assert(gerror.Code(err), gcode.CodeMissingParameter)
+		count, err := db.Model(table).Where("nickname", "name_3").Count()
+		t.AssertNil(err)
+		t.Assert(count, 0)
+	})
+}
+
+func Test_Model_JSON(t *testing.T) {
+	table := fmt.Sprintf(`json_%d`, gtime.TimestampNano())
+	if _, err := db.Exec(ctx, fmt.Sprintf(`
+CREATE TABLE %s (
+	id    INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,
+	extra TEXT
+);`, table)); err != nil {
+		gtest.Fatal(err)
+	}
+	defer dropTable(table)
+
+	type Address struct {
+		City string `json:"city"`
+	}
+	type Extra struct {
+		Address Address  `json:"address"`
+		Tags    []string `json:"tags"`
+	}
+	type User struct {
+		Id    int
+		Extra *Extra
+	}
+	gtest.C(t, func(t *gtest.T) {
+		for i := 1; i <= 3; i++ {
+			_, err := db.Model(table).Data(User{
+				Id: i,
+				Extra: &Extra{
+					Address: Address{City: fmt.Sprintf(`city_%d`, i)},
+					Tags:    []string{"tag", fmt.Sprintf(`tag_%d`, i)},
+				},
+			}).Insert()
+			t.AssertNil(err)
+		}
+
+		var user *User
+		err := db.Model(table).WhereJSONExtract("extra.address.city", "=", "city_2").Scan(&user)
+		t.AssertNil(err)
+		t.Assert(user.Id, 2)
+		t.Assert(user.Extra.Address.City, "city_2")
+		t.Assert(user.Extra.Tags, g.Slice{"tag", "tag_2"})
+
+		array, err := db.Model(table).WhereJSONExtract("extra.tags[1]", "!=", "tag_1").OrderAsc("id").Array("id")
+		t.AssertNil(err)
+		t.Assert(array, g.Slice{2, 3})
+
+		array, err = db.Model(table).WhereJSONContains("extra.tags", "tag_3").Array("id")
+		t.AssertNil(err)
+		t.Assert(array, g.Slice{3})
+
+		count, err := db.Model(table).WhereJSONContains("extra.tags", "tag").Count()
+		t.AssertNil(err)
+		t.Assert(count, 3)
+
+		one, err := db.Model(table).FieldJSON("extra.address.city", "city").WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["city"], "city_1")
+	})
+	// The path and operator cannot inject SQL.
+	gtest.C(t, func(t *gtest.T) {
+		count, err := db.Model(table).WhereJSONExtract(`extra.a\') OR 1=1 --`, "=", "city_1").Count()
+		t.AssertNil(err)
+		t.Assert(count, 0)
+
+		count, err = db.Model(table).WhereJSONContains(`extra.a') OR 1=1 --`, "tag").Count()
+		t.AssertNil(err)
+		t.Assert(count, 0)
+
+		count, err = db.Model(table).WhereJSONExtract("extra.address.city", "like", "city%").Count()
+		t.AssertNil(err)
+		t.Assert(count, 3)
+
+		t.AssertNE(gutil.Try(ctx, func(ctx context.Context) {
+			db.Model(table).WhereJSONExtract("extra.address.city", "= 'x' OR 1=1 OR 'y' =", "city_1")
+		}), nil)
+		t.AssertNE(gutil.Try(ctx, func(ctx context.Context) {
+			db.Model(table).FieldJSON(`extra.a') AS city FROM sqlite_master --`)
+		}), nil)
+	})
+}
+
+func Test_Model_Audit(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			group = fmt.Sprintf(`audit_%d`, gtime.TimestampNano())
+			node  = configNode
+		)
+		gdb.SetConfigGroup(group, gdb.ConfigGroup{node})
+		auditDb, err := gdb.NewByGroup(group)
+		t.AssertNil(err)
+		defer auditDb.Close(ctx)
+
+		var (
+			table      = createInitTableWithDb(auditDb)
+			auditTable = fmt.Sprintf(`audit_%d`, gtime.TimestampNano())
+			entries    = make([]*gdb.AuditEntry, 0)
+		)
+		defer dropTableWithDb(auditDb, table)
+		_, err = auditDb.Exec(ctx, fmt.Sprintf(`
+CREATE TABLE %s (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	table_name  VARCHAR(45),
+	operation   VARCHAR(16),
+	actor       VARCHAR(45),
+	before_data TEXT,
+	after_data  TEXT,
+	created_at  DATETIME
+)`, auditTable))
+		t.AssertNil(err)
+		defer dropTableWithDb(auditDb, auditTable)
+
+		gdb.SetAuditOption(group, &gdb.AuditOption{
+			Table: auditTable,
+			Handler: func(ctx context.Context, entry *gdb.AuditEntry) error {
+				entries = append(entries, entry)
+				return nil
+			},
+		})
+		defer gdb.SetAuditOption(group, nil)
+
+		auditCtx := gdb.WithAuditActor(ctx, "john")
+		_, err = auditDb.Model(table).Ctx(auditCtx).Data(g.Map{
+			"passport": "user_100",
+			"nickname": "name_100",
+		}).Insert()
+		t.AssertNil(err)
+		_, err = auditDb.Model(table).Ctx(auditCtx).Data("nickname", "updated").Where("id", 1).Update()
+		t.AssertNil(err)
+		_, err = auditDb.Model(table).Ctx(auditCtx).Where("id", 2).Delete()
+		t.AssertNil(err)
+
+		t.Assert(len(entries), 3)
+		t.Assert(entries[0].Table, table)
+		t.Assert(entries[0].Operation, gdb.AuditOperationInsert)
+		t.Assert(entries[0].Actor, "john")
+		t.Assert(entries[0].Before, nil)
+		t.Assert(entries[0].After[0]["id"], TableSize+1)
+		t.Assert(entries[0].After[0]["passport"], "user_100")
+
+		t.Assert(entries[1].Operation, gdb.AuditOperationUpdate)
+		t.Assert(entries[1].Before[0]["nickname"], "name_1")
+		t.Assert(entries[1].After[0]["nickname"], "updated")
+		t.AssertNE(entries[1].Time, nil)
+
+		t.Assert(entries[2].Operation, gdb.AuditOperationDelete)
+		t.Assert(entries[2].Before[0]["id"], 2)
+		t.Assert(entries[2].After, nil)
+
+		all, err := auditDb.Model(auditTable).OrderAsc("id").All()
+		t.AssertNil(err)
+		t.Assert(len(all), 3)
+		t.Assert(all[0]["table_name"], table)
+		t.Assert(all[0]["operation"], "insert")
+		t.Assert(all[0]["actor"], "john")
+		t.Assert(all[0]["before_data"].IsNil(), true)
+		t.Assert(all[1]["operation"], "update")
+		t.Assert(gjson.New(all[1]["before_data"]).Get("0.nickname"), "name_1")
+		t.Assert(gjson.New(all[1]["after_data"]).Get("0.nickname"), "updated")
+		t.Assert(all[2]["operation"], "delete")
+		t.Assert(all[2]["after_data"].IsNil(), true)
+
+		// Tables filter.
+		gdb.SetAuditOption(group, &gdb.AuditOption{
+			Handler: func(ctx context.Context, entry *gdb.AuditEntry) error {
+				entries = append(entries, entry)
+				return nil
+			},
+			Tables: []string{"none"},
+		})
+		_, err = auditDb.Model(table).Ctx(auditCtx).Where("id", 3).Delete()
+		t.AssertNil(err)
+		t.Assert(len(entries), 3)
+
+		// The operation is rolled back if the audit entry fails.
+		gdb.SetAuditOption(group, &gdb.AuditOption{
+			Table: auditTable + "_none",
+		})
+		_, err = auditDb.Model(table).Ctx(auditCtx).Where("id", 4).Delete()
+		t.AssertNE(err, nil)
+		count, err := auditDb.Model(table).Where("id", 4).Count()
+		t.AssertNil(err)
+		t.Assert(count, 1)
+	})
+	// Composite primary keys.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			group = fmt.Sprintf(`audit_%d`, gtime.TimestampNano())
+			node  = configNode
+		)
+		gdb.SetConfigGroup(group, gdb.ConfigGroup{node})
+		auditDb, err := gdb.NewByGroup(group)
+		t.AssertNil(err)
+		defer auditDb.Close(ctx)
+
+		var (
+			table   = fmt.Sprintf(`audit_member_%d`, gtime.TimestampNano())
+			entries = make([]*gdb.AuditEntry, 0)
+		)
+		_, err = auditDb.Exec(ctx, fmt.Sprintf(`
+CREATE TABLE %s (
+	uid  INTEGER NOT NULL,
+	gid  INTEGER NOT NULL,
+	role VARCHAR(45),
+	PRIMARY KEY (uid, gid)
+)`, table))
+		t.AssertNil(err)
+		defer dropTableWithDb(auditDb, table)
+
+		_, err = auditDb.Model(table).Data(g.List{
+			{"uid": 1, "gid": 1, "role": "owner"},
+			{"uid": 1, "gid": 2, "role": "member"},
+			{"uid": 2, "gid": 1, "role": "member"},
+		}).Insert()
+		t.AssertNil(err)
+
+		gdb.SetAuditOption(group, &gdb.AuditOption{
+			Handler: func(ctx context.Context, entry *gdb.AuditEntry) error {
+				entries = append(entries, entry)
+				return nil
+			},
+		})
+		defer gdb.SetAuditOption(group, nil)
+
+		_, err = auditDb.Model(table).Data("role", "admin").Where("uid", 1).Where("role", "member").Update()
+		t.AssertNil(err)
+		t.Assert(len(entries), 1)
+		t.Assert(len(entries[0].Before), 1)
+		t.Assert(entries[0].Before[0]["gid"], 2)
+		t.Assert(len(entries[0].After), 1)
+		t.Assert(entries[0].After[0]["gid"], 2)
+		t.Assert(entries[0].After[0]["role"], "admin")
+	})
+}
+
+func Test_Model_Cache_Tags(t *testing.T) {
+	table := createInitTable()
+	defer dropTable(table)
+
+	// Invalidated by writing on the table.
+	gtest.C(t, func(t *gtest.T) {
+		option := gdb.CacheOption{Duration: time.Hour, TagTables: true}
+		one, err := db.Model(table).Cache(option).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "user_1")
+
+		_, err = db.Exec(ctx, fmt.Sprintf(`UPDATE %s SET passport='user_exec' WHERE id=1`, table))
+		t.AssertNil(err)
+		one, err = db.Model(table).Cache(option).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "user_1")
+
+		_, err = db.Model(table).Data("passport", "user_model").WherePri(1).Update()
+		t.AssertNil(err)
+		one, err = db.Model(table).Cache(option).WherePri(1).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "user_model")
+	})
+	// Invalidated by custom tags.
+	gtest.C(t, func(t *gtest.T) {
+		option := gdb.CacheOption{Duration: time.Hour, Tags: []string{"user_tag"}}
+		count, err := db.Model(table).Cache(option).Count()
+		t.AssertNil(err)
+		t.Assert(count, TableSize)
+
+		_, err = db.Exec(ctx, fmt.Sprintf(`DELETE FROM %s WHERE id=1`, table))
+		t.AssertNil(err)
+		count, err = db.Model(table).Cache(option).Count()
+		t.AssertNil(err)
+		t.Assert(count, TableSize)
+
+		t.AssertNil(db.GetCore().ClearCacheByTags(ctx, "user_tag"))
+		count, err = db.Model(table).Cache(option).Count()
+		t.AssertNil(err)
+		t.Assert(count, TableSize-1)
+	})
+	// Invalidated after the transaction is committed.
+	gtest.C(t, func(t *gtest.T) {
+		option := gdb.CacheOption{Duration: time.Hour, TagTables: true}
+		one, err := db.Model(table).Cache(option).WherePri(2).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "user_2")
+
+		err = db.Transaction(ctx, func(ctx context.Context, tx gdb.TX) error {
+			_, err := tx.Model(table).Data("passport", "user_tx").WherePri(2).Update()
+			t.AssertNil(err)
+			// The result before committing is cached again.
+			one, err := db.Model(table).Cache(gdb.CacheOption{Duration: -1}).WherePri(2).One()
+			t.AssertNil(err)
+			t.Assert(one["passport"], "user_2")
+			one, err = db.Model(table).Cache(option).WherePri(2).One()
+			t.AssertNil(err)
+			t.Assert(one["passport"], "user_2")
+			return nil
+		})
+		t.AssertNil(err)
+		one, err = db.Model(table).Cache(option).WherePri(2).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "user_tx")
+	})
+}
+
+func Test_Model_SoftDelete_Option(t *testing.T) {
+	table := fmt.Sprintf(`soft_%d`, gtime.TimestampNano())
+	_, err := db.Exec(ctx, fmt.Sprintf(`
+CREATE TABLE %s (
+	id         INTEGER PRIMARY KEY,
+	name       VARCHAR(45),
+	is_deleted INTEGER NOT NULL DEFAULT 0,
+	removed_at INTEGER
+)`, table))
+	gtest.AssertNil(err)
+	defer dropTable(table)
+
+	// Flag value with custom field name.
+	gtest.C(t, func(t *gtest.T) {
+		_, err := db.Model(table).Data(g.List{
+			{"id": 1, "name": "a"},
+			{"id": 2, "name": "b"},
+			{"id": 3, "name": "c"},
+		}).Insert()
+		t.AssertNil(err)
+		defer db.Model(table).Unscoped().Where("1=1").Delete()
+
+		model := db.Model(table).Safe().SoftTime(gdb.SoftTimeOption{
+			SoftTimeType: gdb.SoftTimeTypeFlag,
+			DeletedField: "is_deleted",
+		})
+		_, err = model.Delete("id", 1)
+		t.AssertNil(err)
+		value, err := db.Model(table).Where("id", 1).Value("is_deleted")
+		t.AssertNil(err)
+		t.Assert(value, 1)
+
+		count, err := model.Count()
+		t.AssertNil(err)
+		t.Assert(count, 2)
+		count, err = model.WithTrashed().Count()
+		t.AssertNil(err)
+		t.Assert(count, 3)
+		array, err := model.OnlyTrashed().Array("id")
+		t.AssertNil(err)
+		t.Assert(array, g.Slice{1})
+
+		// Soft deleting is still available with trashed records.
+		_, err = model.WithTrashed().Delete("id", 2)
+		t.AssertNil(err)
+		count, err = model.Count()
+		t.AssertNil(err)
+		t.Assert(count, 1)
+
+		_, err = model.Restore("id", g.Slice{1, 2})
+		t.AssertNil(err)
+		count, err = model.Count()
+		t.AssertNil(err)
+		t.Assert(count, 3)
+		count, err = model.OnlyTrashed().Count()
+		t.AssertNil(err)
+		t.Assert(count, 0)
+	})
+	// Timestamp value, NULL means alive.
+	gtest.C(t, func(t *gtest.T) {
+		model := db.Model(table).Safe().SoftTime(gdb.SoftTimeOption{
+			SoftTimeType:    gdb.SoftTimeTypeTimestamp,
+			DeletedField:    "removed_at",
+			DeletedNullable: true,
+		})
+		_, err := model.Data(g.List{
+			{"id": 1, "name": "a"},
+			{"id": 2, "name": "b"},
+		}).Insert()
+		t.AssertNil(err)
+		defer db.Model(table).Unscoped().Where("1=1").Delete()
+
+		value, err := db.Model(table).Where("id", 1).Value("removed_at")
+		t.AssertNil(err)
+		t.Assert(value.IsNil(), true)
+
+		_, err = model.Delete("id", 1)
+		t.AssertNil(err)
+		value, err = db.Model(table).Where("id", 1).Value("removed_at")
+		t.AssertNil(err)
+		t.AssertGT(value.Int64(), 0)
+
+		array, err := model.Array("id")
+		t.AssertNil(err)
+		t.Assert(array, g.Slice{2})
+		array, err = model.OnlyTrashed().Array("id")
+		t.AssertNil(err)
+		t.Assert(array, g.Slice{1})
+
+		_, err = model.Restore("id", 1)
+		t.AssertNil(err)
+		value, err = db.Model(table).Where("id", 1).Value("removed_at")
+		t.AssertNil(err)
+		t.Assert(value.IsNil(), true)
+	})
+	// Restoring table without soft deleting field.
+	gtest.C(t, func(t *gtest.T) {
+		_, err := db.Model(table).SoftTime(gdb.SoftTimeOption{
+			DeletedField: "none",
+		}).Restore("id", 1)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Model_CTE(t *testing.T) {
+	table := createInitTable()
+	defer dropTable(table)
+
+	gtest.C(t, func(t *gtest.T) {
+		model := db.Model("top_users").WithCTE(
+			"top_users", db.Model(table).Where("id>?", 5),
+		).Where("id<?", 9)
+		array, err := model.Safe().OrderAsc("id").Array("id")
+		t.AssertNil(err)
+		t.Assert(array, g.Slice{6, 7, 8})
+		count, err := model.Count()
+		t.AssertNil(err)
+		t.Assert(count, 3)
+	})
+	// Multiple CTEs, in which the latter references the former.
+	gtest.C(t, func(t *gtest.T) {
+		array, err := db.Model("b").
+			WithCTE("a", fmt.Sprintf(`SELECT * FROM %s WHERE id<=?`, table), 4).
+			WithCTE("b", "SELECT * FROM a WHERE id>=?", 3).
+			OrderAsc("id").
+			Array("id")
+		t.AssertNil(err)
+		t.Assert(array, g.Slice{3, 4})
+	})
+	// Recursive CTE.
+	gtest.C(t, func(t *gtest.T) {
+		array, err := db.Model("nums").WithRecursiveCTE(
+			"nums(n)", "SELECT ? UNION ALL SELECT n+1 FROM nums WHERE n<?", 1, 5,
+		).Array("n")
+		t.AssertNil(err)
+		t.Assert(array, g.Slice{1, 2, 3, 4, 5})
+	})
+}
+
+func Test_Model_FieldOver(t *testing.T) {
+	table := createInitTable()
+	defer dropTable(table)
+
+	gtest.C(t, func(t *gtest.T) {
+		all, err := db.Model(table).
+			Fields("id").
+			FieldRowNumber(gdb.Window{OrderBy: "id DESC"}, "rn").
+			FieldOver("SUM(id)", gdb.Window{
+				PartitionBy: []string{"id%2"},
+				OrderBy:     "id",
+				Frame:       "ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW",
+			}, "total").
+			OrderAsc("id").
+			All()
+		t.AssertNil(err)
+		t.Assert(len(all), TableSize)
+		t.Assert(all[0]["rn"], TableSize)
+		t.Assert(all[0]["total"], 1)
+		t.Assert(all[2]["total"], 4)
+		t.Assert(all[TableSize-1]["rn"], 1)
+	})
+	// Ranking query with CTE.
+	gtest.C(t, func(t *gtest.T) {
+		array, err := db.Model("ranked").WithCTE(
+			"ranked", db.Model(table).Fields("id").FieldRank(gdb.Window{OrderBy: "id DESC"}, "r"),
+		).Where("r<=?", 3).OrderAsc("r").Array("id")
+		t.AssertNil(err)
+		t.Assert(array, g.Slice{TableSize, TableSize - 1, TableSize - 2})
+	})
+}
+
+func Test_Model_Encryption(t *testing.T) {
+	type User struct {
+		Id       int
+		Passport string `gdb:"encrypted,deterministic"`
+		Password string `gdb:"encrypted"`
+		Nickname string
+	}
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			group = fmt.Sprintf(`encryption_%d`, gtime.TimestampNano())
+			node  = configNode
+			key   = []byte("0123456789abcdef0123456789abcdef")
+		)
+		gdb.SetConfigGroup(group, gdb.ConfigGroup{node})
+		encDb, err := gdb.NewByGroup(group)
+		t.AssertNil(err)
+		defer encDb.Close(ctx)
+
+		table := createTableWithDb(encDb)
+		defer dropTableWithDb(encDb, table)
+
+		// It fails writing encrypted attributes without key provider.
+		_, err = encDb.Model(table).Data(User{Id: 1, Passport: "user_1", Password: "pass_1"}).Insert()
+		t.Assert(gerror.Code(err), gcode.CodeMissingConfiguration)
+
+		gdb.SetEncryptionKeyProvider(group, gdb.NewLocalKeyProvider("k1", key))
+		defer gdb.SetEncryptionKeyProvider(group, nil)
+
+		_, err = encDb.Model(table).Data(g.Slice{
+			User{Id: 1, Passport: "user_1", Password: "pass", Nickname: "name_1"},
+			User{Id: 2, Passport: "user_2", Password: "pass", Nickname: "name_2"},
+		}).Insert()
+		t.AssertNil(err)
+
+		// The values are encrypted in table.
+		all, err := encDb.Model(table).OrderAsc("id").All()
+		t.AssertNil(err)
+		t.Assert(gstr.HasPrefix(all[0]["passport"].String(), "$enc$k1$"), true)
+		t.Assert(gstr.HasPrefix(all[0]["password"].String(), "$enc$k1$"), true)
+		t.AssertNE(all[0]["password"].String(), all[1]["password"].String())
+		t.Assert(all[0]["nickname"].String(), "name_1")
+
+		// Equality query by deterministic encryption.
+		passport, err := encDb.GetCore().Encrypt(ctx, "user_2", true)
+		t.AssertNil(err)
+		t.Assert(passport, all[1]["passport"].String())
+
+		var user *User
+		err = encDb.Model(table).Where("passport", passport).Scan(&user)
+		t.AssertNil(err)
+		t.Assert(user.Id, 2)
+		t.Assert(user.Passport, "user_2")
+		t.Assert(user.Password, "pass")
+
+		// Updating by struct.
+		user.Password = "new_pass"
+		_, err = encDb.Model(table).Data(user).WherePri(user.Id).Update()
+		t.AssertNil(err)
+		value, err := encDb.Model(table).WherePri(2).Value("password")
+		t.AssertNil(err)
+		t.AssertNE(value.String(), "new_pass")
+
+		// Decrypting with rotated keys.
+		gdb.SetEncryptionKeyProvider(
+			group,
+			gdb.NewLocalKeyProvider("k2", []byte("abcdef0123456789")).AddKey("k1", key),
+		)
+		_, err = encDb.Model(table).Data(User{Id: 3, Passport: "user_3", Password: "pass_3"}).Insert()
+		t.AssertNil(err)
+
+		var users []User
+		err = encDb.Model(table).OrderAsc("id").Scan(&users)
+		t.AssertNil(err)
+		t.Assert(len(users), 3)
+		t.Assert(users[0].Passport, "user_1")
+		t.Assert(users[0].Password, "pass")
+		t.Assert(users[1].Password, "new_pass")
+		t.Assert(users[2].Passport, "user_3")
+		t.Assert(users[2].Password, "pass_3")
+
+		value, err = encDb.Model(table).WherePri(3).Value("passport")
+		t.AssertNil(err)
+		t.Assert(gstr.HasPrefix(value.String(), "$enc$k2$"), true)
+	})
+}
@@ -0,0 +1,156 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package sqlite_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/contrib/drivers/sqlite/v2"
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// brokenConnDriver is a database/sql driver whose connections are always broken.
+type brokenConnDriver struct{}
+
+// failoverDriver is the sqlite driver whose slave nodes have broken connections,
+// which tests the failover of slave nodes.
+type failoverDriver struct {
+	*sqlite.Driver
+}
+
+func init() {
+	sql.Register(`broken_conn`, brokenConnDriver{})
+	if err := gdb.Register(`sqlite_failover`, &failoverDriver{}); err != nil {
+		panic(err)
+	}
+}
+
+func (brokenConnDriver) Open(name string) (driver.Conn, error) {
+	return nil, driver.ErrBadConn
+}
+
+func (d *failoverDriver) New(core *gdb.Core, node *gdb.ConfigNode) (gdb.DB, error) {
+	db, err := sqlite.New().New(core, node)
+	if err != nil {
+		return nil, err
+	}
+	return &failoverDriver{Driver: db.(*sqlite.Driver)}, nil
+}
+
+func (d *failoverDriver) Open(node *gdb.ConfigNode) (*sql.DB, error) {
+	if node.Role == "slave" {
+		return sql.Open(`broken_conn`, node.Link)
+	}
+	return d.Driver.Open(node)
+}
+
+// newTestReplicaDB creates a database of group with master node and slave node of `slaveLink`.
+func newTestReplicaDB(t *gtest.T, slaveLink string, master, slave gdb.ConfigNode) gdb.DB {
+	group := fmt.Sprintf(`replica_%d`, gtime.TimestampNano())
+	master.Type, master.Charset = configNode.Type, configNode.Charset
+	master.Link = fmt.Sprintf(`sqlite::@file(%s)`, gfile.Join(dbDir, group+"_master.db"))
+	slave.Type, slave.Charset = configNode.Type, configNode.Charset
+	slave.Link = slaveLink
+	slave.Role = "slave"
+	gdb.SetConfigGroup(group, gdb.ConfigGroup{master, slave})
+	replicaDb, err := gdb.Instance(group)
+	t.AssertNil(err)
+	return replicaDb
+}
+
+func Test_Replica_PrimaryAfterWrite(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			slaveLink = fmt.Sprintf(`sqlite::@file(%s)`, gfile.Join(dbDir, fmt.Sprintf(`slave_%d.db`, gtime.TimestampNano())))
+			replicaDb = newTestReplicaDB(t, slaveLink, gdb.ConfigNode{PrimaryAfterWrite: 500 * time.Millisecond}, gdb.ConfigNode{})
+			table     = createTableWithDb(replicaDb)
+		)
+		defer dropTableWithDb(replicaDb, table)
+		// The slave has the same table but no data.
+		slaveDb, err := gdb.New(gdb.ConfigNode{Type: configNode.Type, Charset: configNode.Charset, Link: slaveLink})
+		t.AssertNil(err)
+		createTableWithDb(slaveDb, table)
+		defer dropTableWithDb(slaveDb, table)
+
+		sessionCtx := gdb.WithReplicaSession(ctx)
+		_, err = replicaDb.Model(table).Ctx(sessionCtx).Data(g.Map{"id": 1, "passport": "john"}).Insert()
+		t.AssertNil(err)
+		count, err := replicaDb.Model(table).Ctx(sessionCtx).Count()
+		t.AssertNil(err)
+		t.Assert(count, 1)
+
+		// The reading of other sessions is not affected by the writing.
+		count, err = replicaDb.Model(table).Ctx(gdb.WithReplicaSession(ctx)).Count()
+		t.AssertNil(err)
+		t.Assert(count, 0)
+		count, err = replicaDb.Model(table).Ctx(ctx).Count()
+		t.AssertNil(err)
+		t.Assert(count, 0)
+
+		time.Sleep(600 * time.Millisecond)
+		count, err = replicaDb.Model(table).Ctx(sessionCtx).Count()
+		t.AssertNil(err)
+		t.Assert(count, 0)
+	})
+}
+
+func Test_Replica_HealthCheck(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			slaveLink = fmt.Sprintf(`sqlite::@file(%s)`, gfile.Join(dbDir, "not_exist", "slave.db"))
+			replicaDb = newTestReplicaDB(t, slaveLink, gdb.ConfigNode{}, gdb.ConfigNode{
+				HealthCheckInterval: time.Minute,
+			})
+			table = createTableWithDb(replicaDb)
+		)
+		defer dropTableWithDb(replicaDb, table)
+
+		// The first reading triggers the health checking and fails on the dead slave.
+		_, err := replicaDb.Model(table).Count()
+		t.AssertNE(err, nil)
+		time.Sleep(100 * time.Millisecond)
+		count, err := replicaDb.Model(table).Count()
+		t.AssertNil(err)
+		t.Assert(count, 0)
+	})
+}
+
+func Test_Replica_Failover(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			group  = fmt.Sprintf(`failover_%d`, gtime.TimestampNano())
+			master = gdb.ConfigNode{
+				Type:    "sqlite_failover",
+				Charset: configNode.Charset,
+				Link:    fmt.Sprintf(`sqlite_failover::@file(%s)`, gfile.Join(dbDir, group+"_master.db")),
+			}
+			slave = master
+		)
+		slave.Role = "slave"
+		gdb.SetConfigGroup(group, gdb.ConfigGroup{master, slave})
+		failoverDb, err := gdb.Instance(group)
+		t.AssertNil(err)
+		table := createInitTableWithDb(failoverDb)
+		defer dropTableWithDb(failoverDb, table)
+
+		// The reading fails on the broken slave, and then fails over to the master.
+		count, err := failoverDb.Model(table).Count()
+		t.AssertNil(err)
+		t.Assert(count, TableSize)
+		count, err = failoverDb.Model(table).Count()
+		t.AssertNil(err)
+		t.Assert(count, TableSize)
+	})
+}
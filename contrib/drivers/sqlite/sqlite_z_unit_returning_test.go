@@ -0,0 +1,121 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package sqlite_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gogf/gf/contrib/drivers/sqlite/v2"
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// noReturningDriver is the sqlite driver without RETURNING clause support,
+// which tests the fallback of Model.Returning like MySQL.
+type noReturningDriver struct {
+	*sqlite.Driver
+}
+
+func init() {
+	if err := gdb.Register(`sqlite_no_returning`, &noReturningDriver{}); err != nil {
+		panic(err)
+	}
+}
+
+func (d *noReturningDriver) New(core *gdb.Core, node *gdb.ConfigNode) (gdb.DB, error) {
+	db, err := sqlite.New().New(core, node)
+	if err != nil {
+		return nil, err
+	}
+	return &noReturningDriver{Driver: db.(*sqlite.Driver)}, nil
+}
+
+func (d *noReturningDriver) FormatReturning(sql string, columns []string) string {
+	return ""
+}
+
+// testModelReturning tests Model.Returning of Insert/Update/Delete operations with `returningDb`.
+func testModelReturning(t *gtest.T, returningDb gdb.DB) {
+	table := createTableWithDb(returningDb)
+	defer dropTableWithDb(returningDb, table)
+
+	// Inserting single record.
+	r, err := returningDb.Model(table).Returning("id", "passport").Data(g.Map{
+		"passport": "user_1",
+		"password": "pass_1",
+	}).Insert()
+	t.AssertNil(err)
+	records := r.(*gdb.SqlResult).Records
+	t.Assert(len(records), 1)
+	t.Assert(records[0]["id"].Int(), 1)
+	t.Assert(records[0]["passport"], "user_1")
+	t.Assert(records[0]["password"], nil)
+	n, err := r.RowsAffected()
+	t.AssertNil(err)
+	t.Assert(n, 1)
+
+	_, err = returningDb.Model(table).Data(g.Slice{
+		g.Map{"id": 2, "passport": "user_2"},
+		g.Map{"id": 3, "passport": "user_3"},
+	}).Insert()
+	t.AssertNil(err)
+
+	// Updating with condition column updated.
+	r, err = returningDb.Model(table).Returning("id", "nickname").
+		Data(g.Map{"passport": "updated", "nickname": "name"}).
+		WhereIn("passport", g.Slice{"user_1", "user_2"}).
+		Update()
+	t.AssertNil(err)
+	records = r.(*gdb.SqlResult).Records
+	t.Assert(len(records), 2)
+	t.Assert(records.Array("id"), g.Slice{1, 2})
+	t.Assert(records[1]["nickname"], "name")
+
+	// Deleting.
+	r, err = returningDb.Model(table).Returning("*").Where("id", 3).Delete()
+	t.AssertNil(err)
+	records = r.(*gdb.SqlResult).Records
+	t.Assert(len(records), 1)
+	t.Assert(records[0]["id"].Int(), 3)
+	t.Assert(records[0]["passport"], "user_3")
+
+	count, err := returningDb.Model(table).Count()
+	t.AssertNil(err)
+	t.Assert(count, 2)
+
+	// No returning.
+	r, err = returningDb.Model(table).Where("id", 2).Delete()
+	t.AssertNil(err)
+	_, ok := r.(*gdb.SqlResult)
+	t.Assert(ok && len(r.(*gdb.SqlResult).Records) > 0, false)
+}
+
+func Test_Model_Returning(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		testModelReturning(t, db)
+	})
+
+	// Fallback for the database not supporting RETURNING clause.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			group = fmt.Sprintf(`returning_%d`, gtime.TimestampNano())
+			node  = configNode
+		)
+		node.Type = "sqlite_no_returning"
+		node.Link = gstr.Replace(node.Link, "sqlite:", "sqlite_no_returning:", 1)
+		gdb.SetConfigGroup(group, gdb.ConfigGroup{node})
+		noReturningDb, err := gdb.NewByGroup(group)
+		t.AssertNil(err)
+		defer noReturningDb.Close(ctx)
+
+		testModelReturning(t, noReturningDb)
+	})
+}
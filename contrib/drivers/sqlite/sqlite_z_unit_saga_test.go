@@ -0,0 +1,179 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gsaga"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func createSagaTable() string {
+	table := fmt.Sprintf(`saga_%d`, gtime.TimestampNano())
+	if _, err := db.Exec(ctx, fmt.Sprintf(`
+	CREATE TABLE %s (
+		id         VARCHAR(64)  PRIMARY KEY NOT NULL,
+		name       VARCHAR(128) NOT NULL,
+		payload    TEXT,
+		status     VARCHAR(32)  NOT NULL,
+		step       INTEGER      NOT NULL,
+		reason     TEXT,
+		last_error TEXT,
+		trace      TEXT,
+		created_at DATETIME     NOT NULL,
+		updated_at DATETIME     NOT NULL
+	);
+	`, table)); err != nil {
+		gtest.Fatal(err)
+	}
+	return table
+}
+
+func Test_Saga_StorageDb(t *testing.T) {
+	table := createSagaTable()
+	defer dropTable(table)
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			storage     = gsaga.NewStorageDb(db, table)
+			broken      = gtype.NewBool()
+			debited     = gtype.NewInt()
+			coordinator = gsaga.NewCoordinator(storage, gsaga.Option{
+				MaxRetries: -1,
+				Backoff:    time.Millisecond,
+			})
+			payload = g.Map{"from": 1, "to": 2, "amount": 100}
+		)
+		t.AssertNil(coordinator.Register("transfer",
+			gsaga.Step{
+				Name: "debit",
+				Action: func(ctx context.Context, sc *gsaga.StepContext) error {
+					debited.Add(1)
+					return nil
+				},
+				Compensate: func(ctx context.Context, sc *gsaga.StepContext) error {
+					debited.Add(-1)
+					return nil
+				},
+			},
+			gsaga.Step{
+				Name: "credit",
+				Action: func(ctx context.Context, sc *gsaga.StepContext) error {
+					if broken.Val() {
+						return errors.New("account frozen")
+					}
+					return nil
+				},
+			},
+		))
+		// Succeeded saga.
+		id, err := coordinator.Start(ctx, "transfer", payload, gsaga.StartOption{Id: "transfer-1"})
+		t.AssertNil(err)
+		t.Assert(id, "transfer-1")
+		t.Assert(debited.Val(), 1)
+
+		state, err := storage.Get(ctx, id)
+		t.AssertNil(err)
+		t.Assert(state.Name, "transfer")
+		t.Assert(state.Status, gsaga.StatusSucceeded)
+		var data struct {
+			From   int
+			To     int
+			Amount int
+		}
+		t.AssertNil(state.Scan(&data))
+		t.Assert(data.Amount, 100)
+
+		// Idempotent starting.
+		_, err = coordinator.Start(ctx, "transfer", payload, gsaga.StartOption{Id: "transfer-1"})
+		t.AssertNil(err)
+		t.Assert(debited.Val(), 1)
+
+		// Aborted saga.
+		broken.Set(true)
+		id, err = coordinator.Start(ctx, "transfer", payload)
+		t.AssertNE(err, nil)
+		t.Assert(debited.Val(), 1)
+
+		state, err = storage.Get(ctx, id)
+		t.AssertNil(err)
+		t.Assert(state.Status, gsaga.StatusAborted)
+		t.Assert(state.Reason, "account frozen")
+
+		states, err := storage.ListUnfinished(ctx)
+		t.AssertNil(err)
+		t.Assert(len(states), 0)
+
+		state, err = storage.Get(ctx, "none")
+		t.AssertNil(err)
+		t.AssertNil(state)
+	})
+
+	// Unfinished saga is recovered.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			storage = gsaga.NewStorageDb(db, table)
+			now     = time.Now()
+			state   = &gsaga.State{
+				Id:        "transfer-2",
+				Name:      "transfer",
+				Status:    gsaga.StatusRunning,
+				Step:      1,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			credited    = gtype.NewInt()
+			coordinator = gsaga.NewCoordinator(storage)
+		)
+		t.AssertNil(storage.Create(ctx, state))
+		t.AssertNE(storage.Create(ctx, state), nil)
+
+		// The state is updated only if it is not changed by others.
+		ok, err := storage.Update(ctx, state, gsaga.StatusRunning, 0)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+		ok, err = storage.Update(ctx, state, gsaga.StatusRunning, 1)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+
+		states, err := storage.ListUnfinished(ctx)
+		t.AssertNil(err)
+		t.Assert(len(states), 1)
+		t.Assert(states[0].Id, "transfer-2")
+		t.Assert(states[0].Step, 1)
+
+		t.AssertNil(coordinator.Register("transfer",
+			gsaga.Step{
+				Name: "debit",
+				Action: func(ctx context.Context, sc *gsaga.StepContext) error {
+					return errors.New("debit should not be executed again")
+				},
+			},
+			gsaga.Step{
+				Name: "credit",
+				Action: func(ctx context.Context, sc *gsaga.StepContext) error {
+					credited.Add(1)
+					return nil
+				},
+			},
+		))
+		t.AssertNil(coordinator.Recover(ctx))
+		t.Assert(credited.Val(), 1)
+
+		state, err = storage.Get(ctx, "transfer-2")
+		t.AssertNil(err)
+		t.Assert(state.Status, gsaga.StatusSucceeded)
+	})
+}
@@ -0,0 +1,145 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package sqlite_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Model_Sharding_Table(t *testing.T) {
+	var (
+		table = fmt.Sprintf(`%s_%d`, TableName, gtime.TimestampNano())
+		rule  = gdb.ShardingRule{Key: "id", Tables: 4}
+	)
+	for i := 0; i < rule.Tables; i++ {
+		createTable(fmt.Sprintf(`%s_%d`, table, i))
+		defer dropTable(fmt.Sprintf(`%s_%d`, table, i))
+	}
+	gtest.C(t, func(t *gtest.T) {
+		list := g.List{}
+		for i := 1; i <= TableSize; i++ {
+			list = append(list, g.Map{"id": i, "passport": fmt.Sprintf(`user_%d`, i)})
+		}
+		result, err := db.Model(table).Sharding(rule).Data(list).Insert()
+		t.AssertNil(err)
+		n, _ := result.RowsAffected()
+		t.Assert(n, TableSize)
+
+		// Records are routed by hash of id.
+		count, err := db.Model(fmt.Sprintf(`%s_%d`, table, 1)).Count()
+		t.AssertNil(err)
+		t.Assert(count, 3)
+		count, err = db.Model(fmt.Sprintf(`%s_%d`, table, 3)).Count()
+		t.AssertNil(err)
+		t.Assert(count, 2)
+
+		// Single shard.
+		one, err := db.Model(table).Sharding(rule).Where("id", 5).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], "user_5")
+		value, err := db.Model(table).Sharding(rule).Where(g.Map{"id": 6}).Value("passport")
+		t.AssertNil(err)
+		t.Assert(value, "user_6")
+		array, err := db.Model(table).Sharding(rule).ShardingValue(7).Order("id").Array("passport")
+		t.AssertNil(err)
+		t.Assert(array, g.Slice{"user_3", "user_7"})
+
+		// Fan-out.
+		all, err := db.Model(table).Sharding(rule).Where("id>?", 2).All()
+		t.AssertNil(err)
+		t.Assert(len(all), TableSize-2)
+		all, err = db.Model(table).Sharding(rule).Limit(3).All()
+		t.AssertNil(err)
+		t.Assert(len(all), 3)
+		count, err = db.Model(table).Sharding(rule).Count()
+		t.AssertNil(err)
+		t.Assert(count, TableSize)
+		_, err = db.Model(table).Sharding(rule).Value("passport")
+		t.AssertNE(err, nil)
+		_, err = db.Model(table).Sharding(rule).Page(2, 3).All()
+		t.AssertNE(err, nil)
+
+		// Update and delete.
+		result, err = db.Model(table).Sharding(rule).Data("nickname", "john").Where("id", 1).Update()
+		t.AssertNil(err)
+		n, _ = result.RowsAffected()
+		t.Assert(n, 1)
+		result, err = db.Model(table).Sharding(rule).Data("password", "123").Where("id<?", 4).Update()
+		t.AssertNil(err)
+		n, _ = result.RowsAffected()
+		t.Assert(n, 3)
+		result, err = db.Model(table).Sharding(rule).Where("id", []int{1, 2, 3}).Delete()
+		t.AssertNil(err)
+		n, _ = result.RowsAffected()
+		t.Assert(n, 3)
+		count, err = db.Model(table).Sharding(rule).Count()
+		t.AssertNil(err)
+		t.Assert(count, TableSize-3)
+	})
+}
+
+func Test_Model_Sharding_Database(t *testing.T) {
+	var (
+		table = fmt.Sprintf(`%s_%d`, TableName, gtime.TimestampNano())
+		group = fmt.Sprintf(`sharding_%d`, gtime.TimestampNano())
+		node  = configNode
+	)
+	node.Link = fmt.Sprintf(`sqlite::@file(%s)`, gfile.Join(dbDir, group+".db"))
+	gdb.AddConfigNode(group, node)
+	db2, err := gdb.Instance(group)
+	gtest.AssertNil(err)
+
+	var rule = gdb.ShardingRule{
+		Key:    "passport",
+		Tables: 4,
+		Groups: []string{gdb.DefaultGroupName, group},
+		Func:   gdb.ShardingRange(100, 200, 300),
+	}
+	for i := 0; i < rule.Tables; i++ {
+		name := fmt.Sprintf(`%s_%d`, table, i)
+		if i < 2 {
+			createTableWithDb(db, name)
+			defer dropTableWithDb(db, name)
+		} else {
+			createTableWithDb(db2, name)
+			defer dropTableWithDb(db2, name)
+		}
+	}
+	gtest.C(t, func(t *gtest.T) {
+		_, err := db.Model(table).Sharding(rule).Data(g.List{
+			{"passport": 10},
+			{"passport": 150},
+			{"passport": 250},
+			{"passport": 350},
+		}).Insert()
+		t.AssertNil(err)
+
+		count, err := db.Model(table + "_1").Count()
+		t.AssertNil(err)
+		t.Assert(count, 1)
+		count, err = db2.Model(table + "_3").Count()
+		t.AssertNil(err)
+		t.Assert(count, 1)
+
+		one, err := db.Model(table).Sharding(rule).Where("passport", 250).One()
+		t.AssertNil(err)
+		t.Assert(one["passport"], 250)
+		count, err = db.Model(table).Sharding(rule).Count()
+		t.AssertNil(err)
+		t.Assert(count, 4)
+
+		_, err = db.Model(table).Sharding(rule).Data(g.Map{"nickname": "john"}).Insert()
+		t.AssertNE(err, nil)
+	})
+}
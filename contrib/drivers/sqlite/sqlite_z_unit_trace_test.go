@@ -0,0 +1,59 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package sqlite_test
+
+import (
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.18.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Trace_Statement_Sanitized(t *testing.T) {
+	var (
+		table       = createInitTable()
+		recorder    = tracetest.NewSpanRecorder()
+		oldProvider = otel.GetTracerProvider()
+	)
+	defer dropTable(table)
+	otel.SetTracerProvider(sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(oldProvider)
+
+	gtest.C(t, func(t *gtest.T) {
+		_, err := db.Model(table).Data(g.Map{"password": "secret_password"}).Where("passport", "user_1").Update()
+		t.AssertNil(err)
+		_, err = db.Model(table).Where("passport", "user_2").One()
+		t.AssertNil(err)
+
+		var statements []string
+		for _, span := range recorder.Ended() {
+			t.Assert(span.SpanKind(), trace.SpanKindClient)
+			for _, attr := range span.Attributes() {
+				if attr.Key == semconv.DBStatementKey {
+					statements = append(statements, attr.Value.AsString())
+				}
+			}
+			for _, event := range span.Events() {
+				for _, attr := range event.Attributes {
+					t.Assert(strings.Contains(attr.Value.Emit(), "secret_password"), false)
+				}
+			}
+		}
+		t.AssertGE(len(statements), 2)
+		for _, statement := range statements {
+			t.Assert(strings.Contains(statement, "secret_password"), false)
+			t.Assert(strings.Contains(statement, "'user_"), false)
+		}
+	})
+}
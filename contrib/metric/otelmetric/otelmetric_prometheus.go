@@ -15,6 +15,11 @@ import (
 	"github.com/gogf/gf/v2/net/ghttp"
 )
 
+func init() {
+	// It serves the prometheus metrics at server MetricPath.
+	ghttp.RegisterMetricHandler(PrometheusHandler)
+}
+
 // PrometheusHandler returns the http handler for prometheus metrics exporting.
 func PrometheusHandler(r *ghttp.Request) {
 	// Remove all builtin metrics that are produced by prometheus client.
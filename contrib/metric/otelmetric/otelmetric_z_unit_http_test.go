@@ -7,6 +7,7 @@
 package otelmetric_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -16,10 +17,13 @@ import (
 
 	"github.com/gogf/gf/contrib/metric/otelmetric/v2"
 	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/container/gtype"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gcache"
 	"github.com/gogf/gf/v2/os/gctx"
 	"github.com/gogf/gf/v2/os/gmetric"
+	"github.com/gogf/gf/v2/os/grpool"
 	"github.com/gogf/gf/v2/test/gtest"
 	"github.com/gogf/gf/v2/text/gregex"
 	"github.com/gogf/gf/v2/text/gstr"
@@ -35,7 +39,11 @@ func Test_HTTP_Server(t *testing.T) {
 		s.BindHandler("/order/:id", func(r *ghttp.Request) {
 			r.Response.Write("order")
 		})
+		s.BindHandler("/healthz", func(r *ghttp.Request) {
+			r.Response.Write("ok")
+		})
 		s.BindHandler("/metrics", ghttp.WrapH(promhttp.Handler()))
+		s.SetMetricExcludeRoutes("/healthz")
 		s.SetDumpRouterMap(false)
 		s.Start()
 		defer s.Shutdown()
@@ -70,6 +78,25 @@ func Test_HTTP_Server(t *testing.T) {
 		c.PutContent(ctx, "/order/1", "1234")
 		c.PostContent(ctx, "/order/2", "1234")
 		c.DeleteContent(ctx, "/order/3")
+		c.GetContent(ctx, "/healthz")
+
+		// Framework components instrumented by gmetric.
+		cache := gcache.New()
+		t.AssertNil(cache.Set(ctx, "k", "v", 0))
+		_, err = cache.Get(ctx, "k")
+		t.AssertNil(err)
+		_, err = cache.Get(ctx, "none")
+		t.AssertNil(err)
+
+		var (
+			pool = grpool.New(1)
+			done = make(chan struct{})
+		)
+		t.AssertNil(pool.Add(ctx, func(ctx context.Context) {
+			close(done)
+		}))
+		<-done
+		time.Sleep(100 * time.Millisecond)
 
 		var (
 			metricsContent = c.GetContent(ctx, "/metrics")
@@ -90,5 +117,46 @@ func Test_HTTP_Server(t *testing.T) {
 			//fmt.Println(line)
 			t.Assert(gstr.Contains(metricsContent, line), true)
 		}
+		t.Assert(gstr.Contains(metricsContent, `http_route="/healthz"`), false)
+		t.Assert(gstr.Contains(metricsContent, `cache_hit_total{cache_adapter="memory"`), true)
+		t.Assert(gstr.Contains(metricsContent, `cache_miss_total{cache_adapter="memory"`), true)
+		t.Assert(gstr.Contains(metricsContent, `grpool_job_total{`), true)
+		t.Assert(gstr.Contains(metricsContent, `grpool_job_duration_bucket{`), true)
+		t.Assert(gstr.Contains(metricsContent, `http_server_request_size_bucket{`), true)
+		t.Assert(gstr.Contains(metricsContent, `http_server_response_size_bucket{`), true)
+	})
+}
+
+func Test_HTTP_Server_MetricPath(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx   = gctx.New()
+			hooks = gtype.NewInt()
+			s     = g.Server(guid.S())
+		)
+		s.BindHandler("/product/:id", func(r *ghttp.Request) {
+			r.Response.Write("product")
+		})
+		s.SetMetricPath("/metrics")
+		s.AddMetricHook(func(r *ghttp.Request, attrMap gmetric.AttributeMap) {
+			if attrMap["http.route"] != "/product/:id" || attrMap["http.response.status_code"] != 200 {
+				hooks.Add(100)
+			}
+			hooks.Add(1)
+		})
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+
+		time.Sleep(100 * time.Millisecond)
+
+		c := g.Client()
+		c.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(c.PostContent(ctx, "/product/1", "12345"), "product")
+		t.Assert(c.GetContent(ctx, "/product/2"), "product")
+		t.Assert(gstr.Contains(c.GetContent(ctx, "/metrics"), "# TYPE "), true)
+		time.Sleep(100 * time.Millisecond)
+		// The requests to metric path are not recorded.
+		t.Assert(hooks.Val(), 2)
 	})
 }
@@ -0,0 +1,118 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package kafka implements gmq.Broker using kafka.
+package kafka
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gmq"
+	"github.com/gogf/gf/v2/os/glog"
+)
+
+var (
+	_ gmq.Broker = &Broker{}
+)
+
+// Broker implements gmq.Broker using kafka.
+type Broker struct {
+	brokers []string
+	option  Option
+	writer  *kafka.Writer
+	mu      sync.Mutex
+	closed  bool
+	closing chan struct{}   // Closed when the broker is closed.
+	readers []*kafka.Reader // Readers of all subscriptions.
+	running sync.WaitGroup  // Running consuming goroutines.
+}
+
+// Option is the option for kafka broker.
+type Option struct {
+	Logger glog.ILogger
+	// Dialer is the dialer for consumers, which is used for TLS or SASL authentication.
+	Dialer *kafka.Dialer
+	// Transport is the transport for producer, which is used for TLS or SASL authentication.
+	Transport kafka.RoundTripper
+	// RetryInterval is the interval for redelivering the failed message, which is 1 second in default.
+	// As the offsets of messages are committed in order, the failed message blocks its partition
+	// until it is handled successfully, so it is suggested using with gmq.MiddlewareRetry and dead letter.
+	RetryInterval time.Duration
+}
+
+const (
+	// DefaultRetryInterval is the default interval for redelivering the failed message.
+	DefaultRetryInterval = time.Second
+	// headerId is the kafka header for message id, as kafka message has no id.
+	headerId = "x-gmq-id"
+)
+
+// New creates and returns a kafka broker with `brokers` addresses like: 127.0.0.1:9092.
+func New(brokers []string, option ...Option) *Broker {
+	b := &Broker{
+		brokers: brokers,
+		closing: make(chan struct{}),
+	}
+	if len(option) > 0 {
+		b.option = option[0]
+	}
+	if b.option.Logger == nil {
+		b.option.Logger = g.Log()
+	}
+	if b.option.RetryInterval <= 0 {
+		b.option.RetryInterval = DefaultRetryInterval
+	}
+	b.writer = &kafka.Writer{
+		Addr:                   kafka.TCP(brokers...),
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		AllowAutoTopicCreation: true,
+		Transport:              b.option.Transport,
+	}
+	return b
+}
+
+// Close implements the interface gmq.Producer and gmq.Consumer.
+// It stops fetching messages, waits for the messages being handled done, and then closes all connections.
+func (b *Broker) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	close(b.closing)
+	readers := b.readers
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.running.Wait()
+		close(done)
+	}()
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = gerror.WrapCode(gcode.CodeOperationFailed, ctx.Err(), `waiting for handling messages done failed`)
+	}
+	for _, reader := range readers {
+		if closeErr := reader.Close(); closeErr != nil && err == nil {
+			err = gerror.Wrap(closeErr, `kafka reader close failed`)
+		}
+	}
+	if closeErr := b.writer.Close(); closeErr != nil && err == nil {
+		err = gerror.Wrap(closeErr, `kafka writer close failed`)
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gmq"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+// Subscribe implements the interface gmq.Consumer.
+// The `group` is used as the kafka consumer group id.
+func (b *Broker) Subscribe(ctx context.Context, topic, group string, handler gmq.Handler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `kafka broker is closed`)
+	}
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: b.brokers,
+		GroupID: group,
+		Topic:   topic,
+		Dialer:  b.option.Dialer,
+	})
+	b.readers = append(b.readers, reader)
+	b.running.Add(1)
+	go b.consume(ctx, reader, handler)
+	return nil
+}
+
+// consume fetches and handles messages from `reader` until `ctx` is done or the broker is closed.
+// The offset of message is committed only after it is handled successfully.
+func (b *Broker) consume(ctx context.Context, reader *kafka.Reader, handler gmq.Handler) {
+	defer b.running.Done()
+	// The fetching is canceled once the broker is closing,
+	// but the message being handled is not affected.
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-fetchCtx.Done():
+		case <-b.closing:
+			cancel()
+		}
+	}()
+	for {
+		kafkaMessage, err := reader.FetchMessage(fetchCtx)
+		if err != nil {
+			if fetchCtx.Err() != nil {
+				return
+			}
+			b.option.Logger.Errorf(ctx, `kafka fetch message failed: %+v`, err)
+			if !b.wait(fetchCtx) {
+				return
+			}
+			continue
+		}
+		message := newMessageFromKafka(kafkaMessage)
+		for message.Attempt = 1; ; message.Attempt++ {
+			if err = b.handle(ctx, handler, message); err == nil {
+				break
+			}
+			// The message is not committed, which is redelivered after rebalancing if it stops here.
+			if !b.wait(fetchCtx) {
+				return
+			}
+		}
+		if err = reader.CommitMessages(ctx, kafkaMessage); err != nil {
+			b.option.Logger.Errorf(ctx, `kafka commit message "%s" failed: %+v`, message.Id, err)
+		}
+	}
+}
+
+// handle calls `handler` with panic recovered.
+func (b *Broker) handle(ctx context.Context, handler gmq.Handler, message *gmq.Message) (err error) {
+	gutil.TryCatch(ctx, func(ctx context.Context) {
+		err = handler(ctx, message)
+	}, func(ctx context.Context, exception error) {
+		err = exception
+	})
+	return
+}
+
+// wait waits for the retry interval, it returns false if `ctx` is done during waiting.
+func (b *Broker) wait(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(b.option.RetryInterval):
+		return true
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gmq"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// Publish implements the interface gmq.Producer.
+// The messages with the same key are published to the same partition, which keeps their order.
+func (b *Broker) Publish(ctx context.Context, messages ...*gmq.Message) error {
+	kafkaMessages := make([]kafka.Message, 0, len(messages))
+	for _, message := range messages {
+		if message.Id == "" {
+			message.Id = guid.S()
+		}
+		if message.Timestamp.IsZero() {
+			message.Timestamp = time.Now()
+		}
+		headers := make([]kafka.Header, 0, len(message.Header)+1)
+		headers = append(headers, kafka.Header{Key: headerId, Value: []byte(message.Id)})
+		for k, v := range message.Header {
+			headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+		}
+		kafkaMessage := kafka.Message{
+			Topic:   message.Topic,
+			Value:   message.Body,
+			Headers: headers,
+			Time:    message.Timestamp,
+		}
+		if message.Key != "" {
+			kafkaMessage.Key = []byte(message.Key)
+		}
+		kafkaMessages = append(kafkaMessages, kafkaMessage)
+	}
+	if err := b.writer.WriteMessages(ctx, kafkaMessages...); err != nil {
+		return gerror.Wrap(err, `kafka publish failed`)
+	}
+	return nil
+}
+
+// newMessageFromKafka creates gmq.Message from kafka message.
+func newMessageFromKafka(kafkaMessage kafka.Message) *gmq.Message {
+	message := &gmq.Message{
+		Topic:     kafkaMessage.Topic,
+		Key:       string(kafkaMessage.Key),
+		Header:    make(map[string]string, len(kafkaMessage.Headers)),
+		Body:      kafkaMessage.Value,
+		Timestamp: kafkaMessage.Time,
+	}
+	for _, header := range kafkaMessage.Headers {
+		if header.Key == headerId {
+			message.Id = string(header.Value)
+			continue
+		}
+		message.Header[header.Key] = string(header.Value)
+	}
+	return message
+}
@@ -0,0 +1,147 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package nats implements gmq.Broker using NATS JetStream.
+//
+// Each topic is stored in a JetStream stream which is created automatically if it does not exist,
+// and each consumer group is a durable queue consumer of the stream.
+package nats
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gmq"
+	"github.com/gogf/gf/v2/os/glog"
+)
+
+var (
+	_ gmq.Broker = &Broker{}
+)
+
+// Broker implements gmq.Broker using NATS JetStream.
+type Broker struct {
+	option  Option
+	conn    *nats.Conn
+	js      nats.JetStreamContext
+	streams sync.Map // Topics whose streams are ensured.
+	mu      sync.Mutex
+	closed  bool
+	drained chan struct{} // Closed when the connection is closed after draining.
+}
+
+// Option is the option for NATS broker.
+type Option struct {
+	Logger glog.ILogger
+	// Options is the options for NATS connection.
+	Options []nats.Option
+	// AckWait is the duration that the server waits for acknowledgement before redelivering,
+	// which is 30 seconds in default.
+	AckWait time.Duration
+	// RetryInterval is the delay for redelivering the failed message, which is 1 second in default.
+	RetryInterval time.Duration
+}
+
+const (
+	// DefaultAckWait is the default duration waiting for acknowledgement.
+	DefaultAckWait = 30 * time.Second
+	// DefaultRetryInterval is the default delay for redelivering the failed message.
+	DefaultRetryInterval = time.Second
+	// headerKey is the NATS header for message key.
+	headerKey = "X-Gmq-Key"
+)
+
+// New creates and returns a NATS broker with `url` like: nats://127.0.0.1:4222.
+func New(url string, option ...Option) (*Broker, error) {
+	b := &Broker{
+		drained: make(chan struct{}),
+	}
+	if len(option) > 0 {
+		b.option = option[0]
+	}
+	if b.option.Logger == nil {
+		b.option.Logger = g.Log()
+	}
+	if b.option.AckWait <= 0 {
+		b.option.AckWait = DefaultAckWait
+	}
+	if b.option.RetryInterval <= 0 {
+		b.option.RetryInterval = DefaultRetryInterval
+	}
+	var (
+		err     error
+		options = append(append([]nats.Option{}, b.option.Options...), nats.ClosedHandler(func(*nats.Conn) {
+			close(b.drained)
+		}))
+	)
+	if b.conn, err = nats.Connect(url, options...); err != nil {
+		return nil, gerror.Wrap(err, `nats connect failed`)
+	}
+	if b.js, err = b.conn.JetStream(); err != nil {
+		b.conn.Close()
+		return nil, gerror.Wrap(err, `nats create jetstream context failed`)
+	}
+	return b, nil
+}
+
+// Close implements the interface gmq.Producer and gmq.Consumer.
+// It drains all subscriptions, which handles the buffered messages before closing the connection.
+func (b *Broker) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	if err := b.conn.Drain(); err != nil {
+		b.conn.Close()
+		return gerror.Wrap(err, `nats drain failed`)
+	}
+	select {
+	case <-b.drained:
+		return nil
+	case <-ctx.Done():
+		b.conn.Close()
+		return gerror.WrapCode(gcode.CodeOperationFailed, ctx.Err(), `waiting for handling messages done failed`)
+	}
+}
+
+// ensureStream creates the stream of `topic` if it does not exist.
+func (b *Broker) ensureStream(topic string) error {
+	if _, ok := b.streams.Load(topic); ok {
+		return nil
+	}
+	name := streamName(topic)
+	if _, err := b.js.StreamInfo(name); err != nil {
+		if err != nats.ErrStreamNotFound {
+			return gerror.Wrapf(err, `nats retrieve stream "%s" failed`, name)
+		}
+		_, err = b.js.AddStream(&nats.StreamConfig{
+			Name:     name,
+			Subjects: []string{topic},
+			Storage:  nats.FileStorage,
+		})
+		if err != nil && err != nats.ErrStreamNameAlreadyInUse {
+			return gerror.Wrapf(err, `nats create stream "%s" failed`, name)
+		}
+	}
+	b.streams.Store(topic, struct{}{})
+	return nil
+}
+
+// streamName returns the stream name of `topic`, as the stream name cannot contain dots.
+func streamName(topic string) string {
+	return strings.NewReplacer(".", "_", "*", "_", ">", "_").Replace(topic)
+}
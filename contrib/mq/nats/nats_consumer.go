@@ -0,0 +1,74 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package nats
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gmq"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+// Subscribe implements the interface gmq.Consumer.
+// The `group` is used as both the queue group and the durable consumer name.
+func (b *Broker) Subscribe(ctx context.Context, topic, group string, handler gmq.Handler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `nats broker is closed`)
+	}
+	if err := b.ensureStream(topic); err != nil {
+		return err
+	}
+	subscription, err := b.js.QueueSubscribe(topic, group, func(msg *nats.Msg) {
+		b.handle(ctx, msg, handler)
+	},
+		nats.Durable(streamName(group)),
+		nats.ManualAck(),
+		nats.AckExplicit(),
+		nats.AckWait(b.option.AckWait),
+		nats.DeliverAll(),
+	)
+	if err != nil {
+		return gerror.Wrapf(err, `nats subscribe topic "%s" with group "%s" failed`, topic, group)
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			if err := subscription.Drain(); err != nil {
+				b.option.Logger.Errorf(ctx, `nats drain subscription failed: %+v`, err)
+			}
+		case <-b.drained:
+		}
+	}()
+	return nil
+}
+
+// handle calls `handler` with `msg`, and acknowledges it if success, or else redelivers it later.
+func (b *Broker) handle(ctx context.Context, msg *nats.Msg, handler gmq.Handler) {
+	var (
+		err     error
+		message = newMessageFromNats(msg)
+	)
+	gutil.TryCatch(ctx, func(ctx context.Context) {
+		err = handler(ctx, message)
+	}, func(ctx context.Context, exception error) {
+		err = exception
+	})
+	if err == nil {
+		err = msg.Ack()
+	} else {
+		err = msg.NakWithDelay(b.option.RetryInterval)
+	}
+	if err != nil {
+		b.option.Logger.Errorf(ctx, `nats acknowledge message "%s" failed: %+v`, message.Id, err)
+	}
+}
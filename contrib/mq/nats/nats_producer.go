@@ -0,0 +1,68 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package nats
+
+import (
+	"context"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gmq"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// Publish implements the interface gmq.Producer.
+// The message id is used for deduplication of JetStream, which makes the publishing retry safe.
+func (b *Broker) Publish(ctx context.Context, messages ...*gmq.Message) error {
+	for _, message := range messages {
+		if err := b.ensureStream(message.Topic); err != nil {
+			return err
+		}
+		if message.Id == "" {
+			message.Id = guid.S()
+		}
+		if message.Timestamp.IsZero() {
+			message.Timestamp = time.Now()
+		}
+		msg := nats.NewMsg(message.Topic)
+		msg.Data = message.Body
+		for k, v := range message.Header {
+			msg.Header.Set(k, v)
+		}
+		if message.Key != "" {
+			msg.Header.Set(headerKey, message.Key)
+		}
+		if _, err := b.js.PublishMsg(msg, nats.Context(ctx), nats.MsgId(message.Id)); err != nil {
+			return gerror.Wrapf(err, `nats publish message "%s" failed`, message.Id)
+		}
+	}
+	return nil
+}
+
+// newMessageFromNats creates gmq.Message from NATS message.
+func newMessageFromNats(msg *nats.Msg) *gmq.Message {
+	message := &gmq.Message{
+		Id:     msg.Header.Get(nats.MsgIdHdr),
+		Topic:  msg.Subject,
+		Key:    msg.Header.Get(headerKey),
+		Header: make(map[string]string, len(msg.Header)),
+		Body:   msg.Data,
+	}
+	for k := range msg.Header {
+		if k == nats.MsgIdHdr || k == headerKey {
+			continue
+		}
+		message.Header[k] = msg.Header.Get(k)
+	}
+	if metadata, err := msg.Metadata(); err == nil {
+		message.Attempt = int(metadata.NumDelivered)
+		message.Timestamp = metadata.Timestamp
+	}
+	return message
+}
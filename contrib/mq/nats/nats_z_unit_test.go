@@ -0,0 +1,104 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package nats_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/contrib/mq/nats/v2"
+	"github.com/gogf/gf/v2/net/gmq"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+var (
+	ctx = gctx.New()
+	url = "nats://127.0.0.1:4222"
+)
+
+func Test_Publish_Subscribe(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			topic    = "gmq_test_" + guid.S()
+			received = make(chan *gmq.Message, 1)
+		)
+		broker, err := nats.New(url)
+		t.AssertNil(err)
+		defer broker.Close(ctx)
+
+		t.AssertNil(broker.Subscribe(ctx, topic, "group", func(ctx context.Context, message *gmq.Message) error {
+			received <- message
+			return nil
+		}))
+		message, err := gmq.NewMessage(topic, "hello")
+		t.AssertNil(err)
+		message.Key = "key"
+		message.Header = map[string]string{"k": "v"}
+		t.AssertNil(broker.Publish(ctx, message))
+		select {
+		case m := <-received:
+			t.Assert(m.Id, message.Id)
+			t.Assert(m.Topic, topic)
+			t.Assert(m.Key, "key")
+			t.Assert(m.Header["k"], "v")
+			t.Assert(m.Body, `"hello"`)
+			t.Assert(m.Attempt, 1)
+		case <-time.After(30 * time.Second):
+			t.Fatal("receiving message timeout")
+		}
+	})
+}
+
+func Test_Redelivery(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			topic    = "gmq_test_" + guid.S()
+			attempts = make(chan int, 2)
+		)
+		broker, err := nats.New(url, nats.Option{
+			RetryInterval: 100 * time.Millisecond,
+		})
+		t.AssertNil(err)
+		defer broker.Close(ctx)
+
+		t.AssertNil(broker.Subscribe(ctx, topic, "group", func(ctx context.Context, message *gmq.Message) error {
+			attempts <- message.Attempt
+			if message.Attempt == 1 {
+				return errors.New("failed")
+			}
+			return nil
+		}))
+		message, err := gmq.NewMessage(topic, "hello")
+		t.AssertNil(err)
+		t.AssertNil(broker.Publish(ctx, message))
+		for _, expect := range []int{1, 2} {
+			select {
+			case attempt := <-attempts:
+				t.Assert(attempt, expect)
+			case <-time.After(30 * time.Second):
+				t.Fatal("receiving message timeout")
+			}
+		}
+	})
+}
+
+func Test_Close(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		broker, err := nats.New(url)
+		t.AssertNil(err)
+		t.AssertNil(broker.Close(ctx))
+		t.AssertNil(broker.Close(ctx))
+		err = broker.Subscribe(ctx, "topic", "group", func(ctx context.Context, message *gmq.Message) error {
+			return nil
+		})
+		t.AssertNE(err, nil)
+	})
+}
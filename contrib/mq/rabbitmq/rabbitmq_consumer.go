@@ -0,0 +1,102 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package rabbitmq
+
+import (
+	"context"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gmq"
+	"github.com/gogf/gf/v2/util/guid"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+// Subscribe implements the interface gmq.Consumer.
+// It declares the durable queue "<topic>.<group>" and binds it to the exchange with `topic`.
+func (b *Broker) Subscribe(ctx context.Context, topic, group string, handler gmq.Handler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `rabbitmq broker is closed`)
+	}
+	ch, err := b.conn.Channel()
+	if err != nil {
+		return gerror.Wrap(err, `rabbitmq open channel failed`)
+	}
+	var (
+		queue = topic + "." + group
+		c     = &consumer{ch: ch, tag: guid.S()}
+	)
+	if err = b.declareQueue(ch, queue, topic); err != nil {
+		_ = ch.Close()
+		return err
+	}
+	if err = ch.Qos(b.option.Prefetch, 0, false); err != nil {
+		_ = ch.Close()
+		return gerror.Wrap(err, `rabbitmq set qos failed`)
+	}
+	deliveries, err := ch.Consume(queue, c.tag, false, false, false, false, nil)
+	if err != nil {
+		_ = ch.Close()
+		return gerror.Wrapf(err, `rabbitmq consume queue "%s" failed`, queue)
+	}
+	b.consumers = append(b.consumers, c)
+	b.running.Add(1)
+	go b.consume(ctx, c, deliveries, handler)
+	return nil
+}
+
+// declareQueue declares durable `queue` and binds it to the exchange with routing key `topic`.
+func (b *Broker) declareQueue(ch *amqp.Channel, queue, topic string) error {
+	if _, err := ch.QueueDeclare(queue, true, false, false, false, nil); err != nil {
+		return gerror.Wrapf(err, `rabbitmq declare queue "%s" failed`, queue)
+	}
+	if err := ch.QueueBind(queue, topic, b.option.Exchange, false, nil); err != nil {
+		return gerror.Wrapf(err, `rabbitmq bind queue "%s" failed`, queue)
+	}
+	return nil
+}
+
+// consume handles `deliveries` until `ctx` is done or the deliveries channel is closed.
+// The message is acknowledged if it is handled successfully, or else it is requeued.
+func (b *Broker) consume(ctx context.Context, c *consumer, deliveries <-chan amqp.Delivery, handler gmq.Handler) {
+	defer b.running.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := c.ch.Cancel(c.tag, false); err != nil {
+				b.option.Logger.Errorf(ctx, `rabbitmq cancel consumer failed: %+v`, err)
+			}
+			return
+
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return
+			}
+			var (
+				err     error
+				message = newMessageFromDelivery(delivery)
+			)
+			gutil.TryCatch(ctx, func(ctx context.Context) {
+				err = handler(ctx, message)
+			}, func(ctx context.Context, exception error) {
+				err = exception
+			})
+			if err == nil {
+				err = delivery.Ack(false)
+			} else {
+				err = delivery.Nack(false, true)
+			}
+			if err != nil {
+				b.option.Logger.Errorf(ctx, `rabbitmq acknowledge message "%s" failed: %+v`, message.Id, err)
+			}
+		}
+	}
+}
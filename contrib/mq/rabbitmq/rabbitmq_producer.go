@@ -0,0 +1,91 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package rabbitmq
+
+import (
+	"context"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gmq"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// Publish implements the interface gmq.Producer.
+// It waits for the confirmations of broker, so that the messages are persisted if it returns nil.
+func (b *Broker) Publish(ctx context.Context, messages ...*gmq.Message) error {
+	b.publishMu.Lock()
+	defer b.publishMu.Unlock()
+	confirmations := make([]*amqp.DeferredConfirmation, 0, len(messages))
+	for _, message := range messages {
+		if message.Id == "" {
+			message.Id = guid.S()
+		}
+		if message.Timestamp.IsZero() {
+			message.Timestamp = time.Now()
+		}
+		headers := make(amqp.Table, len(message.Header))
+		for k, v := range message.Header {
+			headers[k] = v
+		}
+		confirmation, err := b.publishCh.PublishWithDeferredConfirmWithContext(
+			ctx, b.option.Exchange, message.Topic, false, false, amqp.Publishing{
+				Headers:       headers,
+				DeliveryMode:  amqp.Persistent,
+				CorrelationId: message.Key,
+				MessageId:     message.Id,
+				Timestamp:     message.Timestamp,
+				Body:          message.Body,
+			},
+		)
+		if err != nil {
+			return gerror.Wrapf(err, `rabbitmq publish message "%s" failed`, message.Id)
+		}
+		confirmations = append(confirmations, confirmation)
+	}
+	for i, confirmation := range confirmations {
+		acked, err := confirmation.WaitContext(ctx)
+		if err != nil {
+			return gerror.Wrapf(err, `rabbitmq waiting for confirmation of message "%s" failed`, messages[i].Id)
+		}
+		if !acked {
+			return gerror.NewCodef(
+				gcode.CodeOperationFailed, `rabbitmq message "%s" is not acknowledged by broker`, messages[i].Id,
+			)
+		}
+	}
+	return nil
+}
+
+// newMessageFromDelivery creates gmq.Message from RabbitMQ delivery.
+func newMessageFromDelivery(delivery amqp.Delivery) *gmq.Message {
+	message := &gmq.Message{
+		Id:        delivery.MessageId,
+		Topic:     delivery.RoutingKey,
+		Key:       delivery.CorrelationId,
+		Header:    make(map[string]string, len(delivery.Headers)),
+		Body:      delivery.Body,
+		Timestamp: delivery.Timestamp,
+		Attempt:   1,
+	}
+	for k, v := range delivery.Headers {
+		if k == headerDeliveryCount {
+			// The count does not include the first delivery.
+			message.Attempt = gconv.Int(v) + 1
+			continue
+		}
+		message.Header[k] = gconv.String(v)
+	}
+	if delivery.Redelivered && message.Attempt == 1 {
+		message.Attempt = 2
+	}
+	return message
+}
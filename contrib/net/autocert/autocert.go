@@ -0,0 +1,119 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package autocert implements the automatic certificate adapter of ghttp using ACME,
+// which acquires and renews certificates from Let's Encrypt or other ACME CAs.
+//
+// It registers itself to ghttp in package initialization, so that it only needs importing:
+//
+//	import _ "github.com/gogf/gf/contrib/net/autocert/v2"
+package autocert
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/gogf/gf/v2/database/gredis"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+// Manager implements ghttp.AutoCertManager using autocert.Manager.
+type Manager struct {
+	manager *autocert.Manager
+}
+
+// CacheRedis implements autocert.Cache using redis,
+// which shares the certificates among servers in cluster.
+type CacheRedis struct {
+	redis  *gredis.Redis
+	prefix string
+}
+
+const cacheRedisKeyPrefix = "gf:autocert:"
+
+func init() {
+	ghttp.RegisterAutoCertManagerCreator(func(ctx context.Context, config ghttp.AutoCertConfig) (ghttp.AutoCertManager, error) {
+		return New(config)
+	})
+}
+
+// New creates and returns a Manager with `config`.
+func New(config ghttp.AutoCertConfig) (*Manager, error) {
+	if len(config.Domains) == 0 {
+		return nil, gerror.NewCode(gcode.CodeInvalidConfiguration, `automatic certificate domains cannot be empty`)
+	}
+	var cache autocert.Cache
+	if config.CacheRedis != "" {
+		redis := g.Redis(config.CacheRedis)
+		if redis == nil {
+			return nil, gerror.NewCodef(
+				gcode.CodeMissingConfiguration, `redis configuration "%s" not found`, config.CacheRedis,
+			)
+		}
+		cache = NewCacheRedis(redis)
+	} else if config.CacheDir != "" {
+		cache = autocert.DirCache(config.CacheDir)
+	}
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(config.Domains...),
+		Cache:      cache,
+		Email:      config.Email,
+	}
+	if config.DirectoryURL != "" {
+		manager.Client = &acme.Client{DirectoryURL: config.DirectoryURL}
+	}
+	return &Manager{manager: manager}, nil
+}
+
+// TLSConfig implements the interface ghttp.AutoCertManager.
+func (m *Manager) TLSConfig() *tls.Config {
+	return m.manager.TLSConfig()
+}
+
+// HTTPHandler implements the interface ghttp.AutoCertManager.
+func (m *Manager) HTTPHandler(fallback http.Handler) http.Handler {
+	return m.manager.HTTPHandler(fallback)
+}
+
+// NewCacheRedis creates and returns a CacheRedis using `redis`.
+func NewCacheRedis(redis *gredis.Redis) *CacheRedis {
+	return &CacheRedis{
+		redis:  redis,
+		prefix: cacheRedisKeyPrefix,
+	}
+}
+
+// Get implements the interface autocert.Cache.
+func (c *CacheRedis) Get(ctx context.Context, key string) ([]byte, error) {
+	v, err := c.redis.Get(ctx, c.prefix+key)
+	if err != nil {
+		return nil, err
+	}
+	if v.IsNil() {
+		return nil, autocert.ErrCacheMiss
+	}
+	return v.Bytes(), nil
+}
+
+// Put implements the interface autocert.Cache.
+func (c *CacheRedis) Put(ctx context.Context, key string, data []byte) error {
+	_, err := c.redis.Set(ctx, c.prefix+key, data)
+	return err
+}
+
+// Delete implements the interface autocert.Cache.
+func (c *CacheRedis) Delete(ctx context.Context, key string) error {
+	_, err := c.redis.Del(ctx, c.prefix+key)
+	return err
+}
@@ -0,0 +1,102 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package http3 implements the HTTP/3 server adapter of ghttp using quic-go.
+//
+// It registers itself to ghttp in package initialization, so that it only needs importing:
+//
+//	import _ "github.com/gogf/gf/contrib/net/http3/v2"
+package http3
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/quic-go/quic-go/http3"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+// Server implements ghttp.HTTP3Server using quic-go.
+type Server struct {
+	server   *http3.Server
+	mu       sync.RWMutex   // Concurrent safety for `draining` and adding `active`.
+	draining bool           // Whether the server is shutting down.
+	active   sync.WaitGroup // Active requests.
+}
+
+func init() {
+	ghttp.RegisterHTTP3ServerCreator(func(tlsConfig *tls.Config, handler http.Handler) ghttp.HTTP3Server {
+		return New(tlsConfig, handler)
+	})
+}
+
+// New creates and returns a HTTP/3 server serving requests with `handler`.
+func New(tlsConfig *tls.Config, handler http.Handler) *Server {
+	s := &Server{}
+	s.server = &http3.Server{
+		TLSConfig: tlsConfig,
+		Handler:   s.wrapHandler(handler),
+	}
+	return s
+}
+
+// Serve implements ghttp.HTTP3Server.
+func (s *Server) Serve(conn net.PacketConn) error {
+	return s.server.Serve(conn)
+}
+
+// Shutdown implements ghttp.HTTP3Server.
+//
+// As quic-go does not support graceful shutdown, the new requests are rejected with header
+// "Alt-Svc: clear" making clients fall back to HTTPS, and the server is closed after all
+// active requests are done or `ctx` is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.setDraining()
+	done := make(chan struct{})
+	go func() {
+		s.active.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+	return s.server.Close()
+}
+
+// Close implements ghttp.HTTP3Server.
+func (s *Server) Close() error {
+	s.setDraining()
+	return s.server.Close()
+}
+
+// setDraining marks the server shutting down, after which no request is added to `active`.
+func (s *Server) setDraining() {
+	s.mu.Lock()
+	s.draining = true
+	s.mu.Unlock()
+}
+
+// wrapHandler wraps `handler` with tracking active requests for graceful shutdown.
+func (s *Server) wrapHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.RLock()
+		if s.draining {
+			s.mu.RUnlock()
+			w.Header().Set("Alt-Svc", "clear")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		s.active.Add(1)
+		s.mu.RUnlock()
+		defer s.active.Done()
+		handler.ServeHTTP(w, r)
+	})
+}
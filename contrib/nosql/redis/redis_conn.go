@@ -7,6 +7,7 @@
 package redis
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"reflect"
@@ -15,6 +16,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.18.0"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/gogf/gf/v2"
@@ -44,13 +46,12 @@ type traceItem struct {
 }
 
 const (
-	traceInstrumentName               = "github.com/gogf/gf/v2/database/gredis"
-	traceAttrRedisAddress             = "redis.address"
-	traceAttrRedisDb                  = "redis.db"
-	traceEventRedisExecution          = "redis.execution"
-	traceEventRedisExecutionCommand   = "redis.execution.command"
-	traceEventRedisExecutionCost      = "redis.execution.cost"
-	traceEventRedisExecutionArguments = "redis.execution.arguments"
+	traceInstrumentName             = "github.com/gogf/gf/v2/database/gredis"
+	traceAttrRedisAddress           = "redis.address"
+	traceAttrRedisDb                = "redis.db"
+	traceEventRedisExecution        = "redis.execution"
+	traceEventRedisExecutionCommand = "redis.execution.command"
+	traceEventRedisExecutionCost    = "redis.execution.cost"
 )
 
 // Do send a command to the server and returns the received reply.
@@ -80,20 +81,25 @@ func (c *Conn) Do(ctx context.Context, command string, args ...interface{}) (rep
 
 	// Trace span start.
 	tr := otel.GetTracerProvider().Tracer(traceInstrumentName, trace.WithInstrumentationVersion(gf.VERSION))
-	_, span := tr.Start(ctx, "Redis."+command, trace.WithSpanKind(trace.SpanKindInternal))
+	_, span := tr.Start(ctx, "Redis."+command, trace.WithSpanKind(trace.SpanKindClient))
 	defer span.End()
 
 	timestampMilli1 := gtime.TimestampMilli()
 	reply, err = c.doCommand(ctx, command, args...)
 	timestampMilli2 := gtime.TimestampMilli()
 
-	// Trace span end.
-	c.traceSpanEnd(ctx, span, &traceItem{
+	item := &traceItem{
 		err:       err,
 		command:   command,
 		args:      args,
 		costMilli: timestampMilli2 - timestampMilli1,
-	})
+	}
+
+	// Trace span end.
+	c.traceSpanEnd(ctx, span, item)
+
+	// Metrics.
+	c.handleMetricsAfterCommand(ctx, item)
 	return
 }
 
@@ -263,14 +269,26 @@ func (c *Conn) traceSpanEnd(ctx context.Context, span trace.Span, item *traceIte
 	span.SetAttributes(gtrace.CommonLabels()...)
 
 	span.SetAttributes(
+		semconv.DBSystemRedis,
+		semconv.DBStatement(sanitizeCommandStatement(item.command, len(item.args))),
+		semconv.DBRedisDBIndex(c.redis.config.Db),
 		attribute.String(traceAttrRedisAddress, c.redis.config.Address),
 		attribute.Int(traceAttrRedisDb, c.redis.config.Db),
 	)
 
-	jsonBytes, _ := gjson.Marshal(item.args)
+	// The argument values are not recorded, as they may contain passwords or sensitive data.
 	span.AddEvent(traceEventRedisExecution, trace.WithAttributes(
 		attribute.String(traceEventRedisExecutionCommand, item.command),
 		attribute.String(traceEventRedisExecutionCost, fmt.Sprintf(`%d ms`, item.costMilli)),
-		attribute.String(traceEventRedisExecutionArguments, string(jsonBytes)),
 	))
 }
+
+// sanitizeCommandStatement returns the redis statement with placeholders instead of argument values,
+// eg: "SET ? ?", which is used as the statement attribute of the tracing span.
+func sanitizeCommandStatement(command string, argLength int) string {
+	var buffer = bytes.NewBufferString(gstr.ToUpper(command))
+	for i := 0; i < argLength; i++ {
+		buffer.WriteString(" ?")
+	}
+	return buffer.String()
+}
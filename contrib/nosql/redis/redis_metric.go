@@ -0,0 +1,128 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package redis
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gmetric"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+type localMetricManager struct {
+	RedisClientCommandTotal         gmetric.Counter
+	RedisClientCommandDuration      gmetric.Histogram
+	RedisClientCommandDurationTotal gmetric.Counter
+}
+
+const (
+	metricAttrKeyDbSystem      = "db.system"
+	metricAttrKeyDbRedisIndex  = "db.redis.database_index"
+	metricAttrKeyDbOperation   = "db.operation"
+	metricAttrKeyServerAddress = "server.address"
+	metricAttrKeyErrorCode     = "error.code"
+)
+
+var (
+	// metricManager for redis client metrics.
+	metricManager = newMetricManager()
+)
+
+func newMetricManager() *localMetricManager {
+	meter := gmetric.GetGlobalProvider().Meter(gmetric.MeterOption{
+		Instrument:        traceInstrumentName,
+		InstrumentVersion: gf.VERSION,
+	})
+	mm := &localMetricManager{
+		RedisClientCommandTotal: meter.MustCounter(
+			"redis.client.command.total",
+			gmetric.MetricOption{
+				Help:       "Total processed redis command number.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		RedisClientCommandDuration: meter.MustHistogram(
+			"redis.client.command.duration",
+			gmetric.MetricOption{
+				Help:       "Measures the duration of redis commands.",
+				Unit:       "ms",
+				Attributes: gmetric.Attributes{},
+				Buckets: []float64{
+					1,
+					5,
+					10,
+					25,
+					50,
+					75,
+					100,
+					250,
+					500,
+					750,
+					1000,
+					2500,
+					5000,
+					10000,
+				},
+			},
+		),
+		RedisClientCommandDurationTotal: meter.MustCounter(
+			"redis.client.command.duration_total",
+			gmetric.MetricOption{
+				Help:       "Total execution duration of redis commands.",
+				Unit:       "ms",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+	}
+	return mm
+}
+
+func (m *localMetricManager) GetMetricAttributeMap(c *Conn, item *traceItem) gmetric.AttributeMap {
+	var (
+		attrMap = make(gmetric.AttributeMap)
+		errCode int
+	)
+	if item.err != nil {
+		errCode = gerror.Code(item.err).Code()
+	}
+	attrMap.Sets(gmetric.AttributeMap{
+		metricAttrKeyDbSystem:      "redis",
+		metricAttrKeyDbRedisIndex:  c.redis.config.Db,
+		metricAttrKeyDbOperation:   gstr.ToLower(item.command),
+		metricAttrKeyServerAddress: c.redis.config.Address,
+		metricAttrKeyErrorCode:     errCode,
+	})
+	return attrMap
+}
+
+// handleMetricsAfterCommand records the metrics of redis command if metric feature is enabled.
+func (c *Conn) handleMetricsAfterCommand(ctx context.Context, item *traceItem) {
+	if !gmetric.IsEnabled() {
+		return
+	}
+	var (
+		attrMap       = metricManager.GetMetricAttributeMap(c, item)
+		durationMilli = float64(item.costMilli)
+		commandOption = gmetric.Option{Attributes: attrMap.Pick(
+			metricAttrKeyDbSystem,
+			metricAttrKeyDbRedisIndex,
+			metricAttrKeyDbOperation,
+			metricAttrKeyServerAddress,
+			metricAttrKeyErrorCode,
+		)}
+		histogramOption = gmetric.Option{Attributes: attrMap.Pick(
+			metricAttrKeyDbSystem,
+			metricAttrKeyServerAddress,
+		)}
+	)
+	metricManager.RedisClientCommandTotal.Inc(ctx, commandOption)
+	metricManager.RedisClientCommandDurationTotal.Add(ctx, durationMilli, commandOption)
+	metricManager.RedisClientCommandDuration.Record(durationMilli, histogramOption)
+}
@@ -35,3 +35,10 @@ func Test_mustMergeOptionToArgs(t *testing.T) {
 		t.Assert(newArgs, []interface{}{"EX", 60, "NX", "Get"})
 	})
 }
+
+func Test_sanitizeCommandStatement(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(sanitizeCommandStatement("set", 2), "SET ? ?")
+		t.Assert(sanitizeCommandStatement("Ping", 0), "PING")
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package redis_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gjob"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func newJobStorageRedis(t *gtest.T) (storage *gjob.StorageRedis, clear func()) {
+	prefix := "gjob_test_" + guid.S() + ":"
+	storage, err := gjob.NewStorageRedis(redis, prefix)
+	t.AssertNil(err)
+	return storage, func() {
+		_, _ = redis.Del(ctx, prefix+"jobs", prefix+"scheduled", prefix+"idempotency")
+	}
+}
+
+func Test_JobStorageRedis_Basic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		storage, clear := newJobStorageRedis(t)
+		defer clear()
+
+		var (
+			now = time.Now()
+			job = &gjob.Job{
+				Id:             guid.S(),
+				Name:           "email",
+				Status:         gjob.StatusPending,
+				IdempotencyKey: "order-1",
+				RunAt:          now,
+			}
+		)
+		id, err := storage.Add(ctx, job)
+		t.AssertNil(err)
+		t.Assert(id, job.Id)
+
+		// Duplicated idempotency key.
+		id, err = storage.Add(ctx, &gjob.Job{Id: guid.S(), Name: "email", IdempotencyKey: "order-1", RunAt: now})
+		t.AssertNil(err)
+		t.Assert(id, job.Id)
+
+		jobs, err := storage.List(ctx, "")
+		t.AssertNil(err)
+		t.Assert(len(jobs), 1)
+
+		// The fetched job is leased and not fetched again until the lease passes.
+		jobs, err = storage.Fetch(ctx, now, 10, time.Minute)
+		t.AssertNil(err)
+		t.Assert(len(jobs), 1)
+		t.Assert(jobs[0].Id, job.Id)
+		jobs, err = storage.Fetch(ctx, now, 10, time.Minute)
+		t.AssertNil(err)
+		t.Assert(len(jobs), 0)
+		jobs, err = storage.Fetch(ctx, now.Add(2*time.Minute), 10, time.Minute)
+		t.AssertNil(err)
+		t.Assert(len(jobs), 1)
+
+		// The finished job is not fetched any more.
+		job.Status = gjob.StatusSucceeded
+		job.Attempt = 1
+		t.AssertNil(storage.Update(ctx, job))
+		jobs, err = storage.Fetch(ctx, now.Add(time.Hour), 10, time.Minute)
+		t.AssertNil(err)
+		t.Assert(len(jobs), 0)
+
+		got, err := storage.Get(ctx, job.Id)
+		t.AssertNil(err)
+		t.Assert(got.Status, gjob.StatusSucceeded)
+		t.Assert(got.Attempt, 1)
+		jobs, err = storage.List(ctx, gjob.StatusPending)
+		t.AssertNil(err)
+		t.Assert(len(jobs), 0)
+
+		// The idempotency key is released after removing.
+		t.AssertNil(storage.Remove(ctx, job.Id))
+		got, err = storage.Get(ctx, job.Id)
+		t.AssertNil(err)
+		t.Assert(got, nil)
+		id, err = storage.Add(ctx, &gjob.Job{Id: guid.S(), Name: "email", IdempotencyKey: "order-1", RunAt: now})
+		t.AssertNil(err)
+		t.AssertNE(id, job.Id)
+	})
+}
+
+func Test_JobStorageRedis_Manager(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		storage, clear := newJobStorageRedis(t)
+		defer clear()
+
+		var (
+			manager = gjob.New(storage, gjob.Option{
+				PollInterval: 10 * time.Millisecond,
+			})
+			done = make(chan string, 1)
+		)
+		manager.Register("email", func(ctx context.Context, job *gjob.Job) error {
+			var email string
+			if err := job.Scan(&email); err != nil {
+				return err
+			}
+			done <- email
+			return nil
+		})
+		t.AssertNil(manager.Start(ctx))
+		defer manager.Stop(ctx)
+
+		id, err := manager.Enqueue(ctx, "email", "john@goframe.org")
+		t.AssertNil(err)
+		select {
+		case email := <-done:
+			t.Assert(email, "john@goframe.org")
+		case <-time.After(5 * time.Second):
+			t.Fatal("processing job timeout")
+		}
+		time.Sleep(100 * time.Millisecond)
+		job, err := manager.Get(ctx, id)
+		t.AssertNil(err)
+		t.Assert(job.Status, gjob.StatusSucceeded)
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package consul implements service Registry and Discovery using consul.
+//
+// It communicates with the consul agent using its HTTP API, and keeps the registered
+// services healthy by TTL health checks.
+package consul
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+var (
+	_ gsvc.Registry = &Registry{}
+)
+
+// Registry implements gsvc.Registry interface using consul.
+type Registry struct {
+	client        *gclient.Client
+	ttl           time.Duration
+	deregisterTTL time.Duration
+	logger        glog.ILogger
+	heartbeats    sync.Map // Service instance id => *gtimer.Entry
+}
+
+// Option is the option for the consul registry.
+type Option struct {
+	Logger glog.ILogger
+	// Token is the ACL token for consul requests.
+	Token string
+	// TTL is the TTL of health check for registered service, which is refreshed at half of it.
+	TTL time.Duration
+	// DeregisterCriticalServiceAfter specifies that the service is deregistered automatically
+	// by consul if its health check is critical for longer than this duration.
+	DeregisterCriticalServiceAfter time.Duration
+}
+
+const (
+	// DefaultTTL is the default TTL of health check.
+	DefaultTTL = 10 * time.Second
+	// DefaultDeregisterCriticalServiceAfter is the default duration for automatic deregistering.
+	DefaultDeregisterCriticalServiceAfter = time.Minute
+)
+
+const (
+	metaKeyPrefix   = "gsvc_prefix"   // Consul meta key for the prefix of service.
+	metaKeyMetadata = "gsvc_metadata" // Consul meta key for the metadata of service in JSON.
+	headerToken     = "X-Consul-Token"
+	headerIndex     = "X-Consul-Index"
+)
+
+// New creates and returns a new consul registry with consul agent address like: 127.0.0.1:8500.
+func New(address string, option ...Option) gsvc.Registry {
+	address = gstr.Trim(address)
+	if address == "" {
+		panic(gerror.NewCode(gcode.CodeInvalidParameter, `invalid consul address ""`))
+	}
+	if !gstr.Contains(address, "://") {
+		address = "http://" + address
+	}
+	// The discovery is disabled to avoid recursive discovering of the registry itself.
+	client := gclient.New().Discovery(nil)
+	client.SetPrefix(gstr.TrimRight(address, "/"))
+	return NewWithClient(client, option...)
+}
+
+// NewWithClient creates and returns a new consul registry with the given client,
+// which should have the address of consul agent set as its prefix.
+func NewWithClient(client *gclient.Client, option ...Option) *Registry {
+	r := &Registry{
+		client: client,
+	}
+	if len(option) > 0 {
+		r.logger = option[0].Logger
+		r.ttl = option[0].TTL
+		r.deregisterTTL = option[0].DeregisterCriticalServiceAfter
+		if option[0].Token != "" {
+			r.client.SetHeader(headerToken, option[0].Token)
+		}
+	}
+	if r.logger == nil {
+		r.logger = g.Log()
+	}
+	if r.ttl == 0 {
+		r.ttl = DefaultTTL
+	}
+	if r.deregisterTTL == 0 {
+		r.deregisterTTL = DefaultDeregisterCriticalServiceAfter
+	}
+	return r
+}
+
+// request sends request to consul agent and decodes the response into `result` if it is not nil.
+// It returns the index of the response for blocking queries.
+func (r *Registry) request(
+	ctx context.Context, method, path string, data interface{}, result interface{},
+) (index uint64, err error) {
+	var response *gclient.Response
+	if data != nil {
+		response, err = r.client.ContentJson().DoRequest(ctx, method, path, data)
+	} else {
+		response, err = r.client.DoRequest(ctx, method, path)
+	}
+	if err != nil {
+		return 0, gerror.Wrapf(err, `consul request "%s %s" failed`, method, path)
+	}
+	defer response.Close()
+	content := response.ReadAll()
+	if response.StatusCode != http.StatusOK {
+		return 0, gerror.Newf(
+			`consul request "%s %s" failed with status %d: %s`,
+			method, path, response.StatusCode, content,
+		)
+	}
+	if result != nil {
+		if err = gjson.Unmarshal(content, result); err != nil {
+			return 0, gerror.Wrapf(err, `consul response of "%s %s" is invalid: %s`, method, path, content)
+		}
+	}
+	return gconv.Uint64(response.Header.Get(headerIndex)), nil
+}
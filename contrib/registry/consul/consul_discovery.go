@@ -0,0 +1,161 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package consul
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// serviceEntry is the item of consul health service response.
+type serviceEntry struct {
+	Service struct {
+		ID      string
+		Service string
+		Address string
+		Port    int
+		Meta    map[string]string
+	}
+}
+
+// Search searches and returns services with specified condition.
+func (r *Registry) Search(ctx context.Context, in gsvc.SearchInput) ([]gsvc.Service, error) {
+	var names []string
+	switch {
+	case in.Name != "":
+		names = []string{in.Name}
+	case in.Prefix != "":
+		name := getServiceNameFromPrefix(in.Prefix)
+		if name == "" {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid service prefix "%s"`, in.Prefix)
+		}
+		names = []string{name}
+	default:
+		var catalog map[string][]string
+		if _, err := r.request(ctx, http.MethodGet, "/v1/catalog/services", nil, &catalog); err != nil {
+			return nil, err
+		}
+		for name := range catalog {
+			names = append(names, name)
+		}
+	}
+	var services []gsvc.Service
+	for _, name := range names {
+		result, _, err := r.searchByName(ctx, name, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, result...)
+	}
+	// Service filter.
+	filteredServices := make([]gsvc.Service, 0)
+	for _, service := range services {
+		if in.Prefix != "" && !gstr.HasPrefix(service.GetKey(), in.Prefix) {
+			continue
+		}
+		if in.Name != "" && service.GetName() != in.Name {
+			continue
+		}
+		if in.Version != "" && service.GetVersion() != in.Version {
+			continue
+		}
+		if len(in.Metadata) != 0 {
+			m1 := gmap.NewStrAnyMapFrom(in.Metadata)
+			m2 := gmap.NewStrAnyMapFrom(service.GetMetadata())
+			if !m1.IsSubOf(m2) {
+				continue
+			}
+		}
+		resultItem := service
+		filteredServices = append(filteredServices, resultItem)
+	}
+	return filteredServices, nil
+}
+
+// Watch watches specified condition changes.
+// The `key` is the prefix of service key.
+func (r *Registry) Watch(ctx context.Context, key string) (gsvc.Watcher, error) {
+	name := getServiceNameFromPrefix(key)
+	if name == "" {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid service prefix "%s"`, key)
+	}
+	return newWatcher(r, key, name), nil
+}
+
+// searchByName retrieves the healthy services of `name`, which are merged by their prefixes.
+// If `index` is greater than 0, it blocks until the services change or `wait` duration passes,
+// which is the blocking query of consul.
+func (r *Registry) searchByName(
+	ctx context.Context, name string, index uint64, wait time.Duration,
+) (services []gsvc.Service, newIndex uint64, err error) {
+	path := fmt.Sprintf(`/v1/health/service/%s?passing=true`, url.PathEscape(name))
+	if index > 0 {
+		path += fmt.Sprintf(`&index=%d&wait=%s`, index, wait)
+	}
+	var entries []serviceEntry
+	if newIndex, err = r.request(ctx, http.MethodGet, path, nil, &entries); err != nil {
+		return nil, 0, err
+	}
+	servicePrefixMap := make(map[string]*Service)
+	for _, entry := range entries {
+		service, err := newServiceFromEntry(entry)
+		if err != nil {
+			return nil, 0, err
+		}
+		if v, ok := servicePrefixMap[service.GetPrefix()]; ok {
+			v.Endpoints = append(v.Endpoints, service.GetEndpoints()...)
+		} else {
+			servicePrefixMap[service.GetPrefix()] = service
+			services = append(services, service)
+		}
+	}
+	return services, newIndex, nil
+}
+
+// newServiceFromEntry creates Service from consul service entry.
+// The services that are not registered by this package are also supported, which use
+// the default attributes of gsvc.Service and the consul meta as their metadata.
+func newServiceFromEntry(entry serviceEntry) (*Service, error) {
+	var (
+		endpoint = fmt.Sprintf(`%s:%d`, entry.Service.Address, entry.Service.Port)
+		prefix   = entry.Service.Meta[metaKeyPrefix]
+	)
+	if prefix == "" {
+		service := gsvc.NewServiceWithName(entry.Service.Service).(*gsvc.LocalService)
+		service.Endpoints = gsvc.NewEndpoints(endpoint)
+		for k, v := range entry.Service.Meta {
+			service.Metadata[k] = v
+		}
+		return NewService(service), nil
+	}
+	service, err := gsvc.NewServiceWithKV(
+		prefix+gsvc.DefaultSeparator+endpoint, entry.Service.Meta[metaKeyMetadata],
+	)
+	if err != nil {
+		return nil, err
+	}
+	return NewService(service), nil
+}
+
+// getServiceNameFromPrefix returns the service name from service prefix like:
+// /service/default/default/hello.svc/latest
+func getServiceNameFromPrefix(prefix string) string {
+	array := gstr.Split(gstr.Trim(prefix, gsvc.DefaultSeparator), gsvc.DefaultSeparator)
+	if len(array) < 4 {
+		return ""
+	}
+	return array[3]
+}
@@ -0,0 +1,111 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package consul
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/os/gtimer"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// agentServiceRegistration is the request body for registering service to consul agent.
+type agentServiceRegistration struct {
+	ID      string
+	Name    string
+	Tags    []string
+	Address string
+	Port    int
+	Meta    map[string]string
+	Check   agentServiceCheck
+}
+
+// agentServiceCheck is the TTL health check of the service.
+type agentServiceCheck struct {
+	CheckID                        string
+	TTL                            string
+	DeregisterCriticalServiceAfter string
+}
+
+// Register registers `service` to Registry.
+// Note that it returns a new Service if it changes the input Service with custom one.
+//
+// Each endpoint of `service` is registered as a service instance of consul with a TTL health check,
+// which is kept passing in background until the service is deregistered.
+func (r *Registry) Register(ctx context.Context, service gsvc.Service) (gsvc.Service, error) {
+	service = NewService(service)
+	for _, endpoint := range service.GetEndpoints() {
+		var (
+			id           = getServiceInstanceId(service, endpoint)
+			checkId      = "service:" + id
+			registration = agentServiceRegistration{
+				ID:      id,
+				Name:    service.GetName(),
+				Tags:    []string{service.GetVersion()},
+				Address: endpoint.Host(),
+				Port:    endpoint.Port(),
+				Meta: map[string]string{
+					metaKeyPrefix:   service.GetPrefix(),
+					metaKeyMetadata: service.GetValue(),
+				},
+				Check: agentServiceCheck{
+					CheckID:                        checkId,
+					TTL:                            r.ttl.String(),
+					DeregisterCriticalServiceAfter: r.deregisterTTL.String(),
+				},
+			}
+		)
+		if _, err := r.request(ctx, http.MethodPut, "/v1/agent/service/register", registration, nil); err != nil {
+			return nil, err
+		}
+		// The TTL health check is critical until it is passed for the first time.
+		if err := r.passCheck(ctx, checkId); err != nil {
+			return nil, err
+		}
+		r.logger.Debugf(ctx, `consul register success with id "%s", address "%s"`, id, endpoint.String())
+		entry := gtimer.Add(context.Background(), r.ttl/2, func(ctx context.Context) {
+			if err := r.passCheck(ctx, checkId); err != nil {
+				r.logger.Noticef(ctx, `consul health check passing failed: %+v`, err)
+			}
+		})
+		if previous, loaded := r.heartbeats.Load(id); loaded {
+			previous.(*gtimer.Entry).Close()
+		}
+		r.heartbeats.Store(id, entry)
+	}
+	return service, nil
+}
+
+// Deregister off-lines and removes `service` from the Registry.
+func (r *Registry) Deregister(ctx context.Context, service gsvc.Service) error {
+	for _, endpoint := range service.GetEndpoints() {
+		id := getServiceInstanceId(service, endpoint)
+		if entry, loaded := r.heartbeats.LoadAndDelete(id); loaded {
+			entry.(*gtimer.Entry).Close()
+		}
+		_, err := r.request(ctx, http.MethodPut, "/v1/agent/service/deregister/"+url.PathEscape(id), nil, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// passCheck marks the TTL health check `checkId` as passing.
+func (r *Registry) passCheck(ctx context.Context, checkId string) error {
+	_, err := r.request(ctx, http.MethodPut, "/v1/agent/check/pass/"+url.PathEscape(checkId), nil, nil)
+	return err
+}
+
+// getServiceInstanceId returns the unique consul service id for `endpoint` of `service`.
+func getServiceInstanceId(service gsvc.Service, endpoint gsvc.Endpoint) string {
+	prefix := gstr.Replace(gstr.Trim(service.GetPrefix(), gsvc.DefaultSeparator), gsvc.DefaultSeparator, "-")
+	return prefix + "-" + endpoint.String()
+}
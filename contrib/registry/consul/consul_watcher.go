@@ -0,0 +1,86 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package consul
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+var (
+	_ gsvc.Watcher = &watcher{}
+)
+
+const (
+	// watchWaitTime is the max waiting time of each blocking query.
+	watchWaitTime = 5 * time.Minute
+)
+
+// watcher watches the changes of services using blocking queries of consul.
+type watcher struct {
+	registry *Registry
+	key      string
+	name     string
+	index    uint64 // Index of the last blocking query.
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+func newWatcher(registry *Registry, key, name string) *watcher {
+	w := &watcher{
+		registry: registry,
+		key:      key,
+		name:     name,
+	}
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	return w
+}
+
+// Proceed proceeds watch in blocking way.
+// It returns all complete services that watched by `key` if any change.
+func (w *watcher) Proceed() ([]gsvc.Service, error) {
+	for {
+		if w.index == 0 {
+			// The first query retrieves the current index for the following blocking queries.
+			_, index, err := w.registry.searchByName(w.ctx, w.name, 0, 0)
+			if err != nil {
+				return nil, err
+			}
+			w.index = index
+		}
+		services, index, err := w.registry.searchByName(w.ctx, w.name, w.index, watchWaitTime)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case index == w.index:
+			// Timeout of blocking query without changes.
+			continue
+		case index < w.index:
+			// The index goes backwards, which should be reset according to consul documents.
+			w.index = 0
+			continue
+		}
+		w.index = index
+		result := make([]gsvc.Service, 0, len(services))
+		for _, service := range services {
+			if gstr.HasPrefix(service.GetKey(), w.key) {
+				result = append(result, service)
+			}
+		}
+		return result, nil
+	}
+}
+
+// Close closes the watcher.
+func (w *watcher) Close() error {
+	w.cancel()
+	return nil
+}
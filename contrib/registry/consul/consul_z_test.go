@@ -0,0 +1,187 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package consul_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/contrib/registry/consul/v2"
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// agent is a fake consul agent implementing the HTTP API used by the registry.
+type agent struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	index    uint64
+	services map[string]map[string]interface{}
+	passing  map[string]bool
+}
+
+func newAgent() *agent {
+	a := &agent{
+		index:    1,
+		services: make(map[string]map[string]interface{}),
+		passing:  make(map[string]bool),
+	}
+	a.cond = sync.NewCond(&a.mu)
+	return a
+}
+
+func (a *agent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	switch path := r.URL.Path; {
+	case path == "/v1/agent/service/register":
+		var service map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&service)
+		a.services[service["ID"].(string)] = service
+		a.index++
+
+	case strings.HasPrefix(path, "/v1/agent/service/deregister/"):
+		id := strings.TrimPrefix(path, "/v1/agent/service/deregister/")
+		delete(a.services, id)
+		delete(a.passing, "service:"+id)
+		a.index++
+		a.cond.Broadcast()
+
+	case strings.HasPrefix(path, "/v1/agent/check/pass/"):
+		id := strings.TrimPrefix(path, "/v1/agent/check/pass/")
+		if !a.passing[id] {
+			a.passing[id] = true
+			a.index++
+			a.cond.Broadcast()
+		}
+
+	case path == "/v1/catalog/services":
+		catalog := make(map[string][]string)
+		for _, service := range a.services {
+			catalog[service["Name"].(string)] = nil
+		}
+		a.write(w, catalog)
+
+	case strings.HasPrefix(path, "/v1/health/service/"):
+		name := strings.TrimPrefix(path, "/v1/health/service/")
+		if index, _ := strconv.ParseUint(r.URL.Query().Get("index"), 10, 64); index > 0 {
+			for a.index == index {
+				a.cond.Wait()
+			}
+		}
+		entries := make([]map[string]interface{}, 0)
+		for id, service := range a.services {
+			if service["Name"] == name && a.passing["service:"+id] {
+				entries = append(entries, map[string]interface{}{
+					"Service": map[string]interface{}{
+						"ID":      id,
+						"Service": name,
+						"Address": service["Address"],
+						"Port":    service["Port"],
+						"Meta":    service["Meta"],
+					},
+				})
+			}
+		}
+		a.write(w, entries)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (a *agent) write(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(a.index, 10))
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+func TestRegistry(t *testing.T) {
+	var (
+		ctx      = gctx.GetInitCtx()
+		server   = httptest.NewServer(newAgent())
+		registry = consul.New(server.URL, consul.Option{TTL: time.Second})
+	)
+	defer server.Close()
+
+	svc := &gsvc.LocalService{
+		Name:      guid.S(),
+		Endpoints: gsvc.NewEndpoints("127.0.0.1:8888,127.0.0.1:8889"),
+		Metadata: map[string]interface{}{
+			"protocol": "https",
+		},
+	}
+	gtest.C(t, func(t *gtest.T) {
+		registered, err := registry.Register(ctx, svc)
+		t.AssertNil(err)
+		t.Assert(registered.GetName(), svc.GetName())
+	})
+
+	// Search by name.
+	gtest.C(t, func(t *gtest.T) {
+		result, err := registry.Search(ctx, gsvc.SearchInput{
+			Name: svc.Name,
+		})
+		t.AssertNil(err)
+		t.Assert(len(result), 1)
+		t.Assert(result[0].GetName(), svc.Name)
+		t.Assert(len(result[0].GetEndpoints()), 2)
+		t.Assert(result[0].GetMetadata(), svc.Metadata)
+	})
+
+	// Search by prefix and metadata.
+	gtest.C(t, func(t *gtest.T) {
+		result, err := registry.Search(ctx, gsvc.SearchInput{
+			Prefix: svc.GetPrefix(),
+		})
+		t.AssertNil(err)
+		t.Assert(len(result), 1)
+		t.Assert(result[0].GetPrefix(), svc.GetPrefix())
+
+		result, err = registry.Search(ctx, gsvc.SearchInput{
+			Metadata: map[string]interface{}{"protocol": "grpc"},
+		})
+		t.AssertNil(err)
+		t.Assert(len(result), 0)
+	})
+
+	// Watch and deregister.
+	gtest.C(t, func(t *gtest.T) {
+		watcher, err := registry.Watch(ctx, svc.GetPrefix())
+		t.AssertNil(err)
+		defer watcher.Close()
+
+		ch := make(chan []gsvc.Service, 1)
+		go func() {
+			services, _ := watcher.Proceed()
+			ch <- services
+		}()
+		time.Sleep(100 * time.Millisecond)
+		t.AssertNil(registry.Deregister(ctx, &gsvc.LocalService{
+			Name:      svc.Name,
+			Endpoints: gsvc.NewEndpoints("127.0.0.1:8888"),
+		}))
+		select {
+		case services := <-ch:
+			t.Assert(len(services), 1)
+			t.Assert(services[0].GetEndpoints().String(), "127.0.0.1:8889")
+		case <-time.After(5 * time.Second):
+			t.Error("watch timeout")
+		}
+
+		_, err = registry.Watch(ctx, "invalid")
+		t.AssertNE(err, nil)
+		t.AssertNil(registry.Deregister(ctx, svc))
+	})
+}
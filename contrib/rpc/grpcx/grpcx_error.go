@@ -0,0 +1,100 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package grpcx
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// trailerKeyErrorCode is the trailer key carrying the original error code of gcode,
+	// which makes the error code lossless between server and client.
+	trailerKeyErrorCode = "x-gf-error-code"
+)
+
+// grpcCodeMap maps the builtin error codes of gcode to grpc codes.
+var grpcCodeMap = map[int]codes.Code{
+	gcode.CodeInternalError.Code():            codes.Internal,
+	gcode.CodeValidationFailed.Code():         codes.InvalidArgument,
+	gcode.CodeDbOperationError.Code():         codes.Internal,
+	gcode.CodeInvalidParameter.Code():         codes.InvalidArgument,
+	gcode.CodeMissingParameter.Code():         codes.InvalidArgument,
+	gcode.CodeInvalidOperation.Code():         codes.FailedPrecondition,
+	gcode.CodeInvalidConfiguration.Code():     codes.FailedPrecondition,
+	gcode.CodeMissingConfiguration.Code():     codes.FailedPrecondition,
+	gcode.CodeNotImplemented.Code():           codes.Unimplemented,
+	gcode.CodeNotSupported.Code():             codes.Unimplemented,
+	gcode.CodeOperationFailed.Code():          codes.Aborted,
+	gcode.CodeNotAuthorized.Code():            codes.PermissionDenied,
+	gcode.CodeSecurityReason.Code():           codes.PermissionDenied,
+	gcode.CodeServerBusy.Code():               codes.Unavailable,
+	gcode.CodeUnknown.Code():                  codes.Unknown,
+	gcode.CodeNotFound.Code():                 codes.NotFound,
+	gcode.CodeInvalidRequest.Code():           codes.InvalidArgument,
+	gcode.CodeInternalPanic.Code():            codes.Internal,
+	gcode.CodeBusinessValidationFailed.Code(): codes.InvalidArgument,
+}
+
+// errorToGrpcStatus converts `err` to grpc status error in server side.
+//
+// The builtin error codes of gcode are mapped to their corresponding grpc codes,
+// and the other error codes are used as grpc codes directly.
+// The original error code is also sent to client in trailer.
+func errorToGrpcStatus(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	// The minus error codes are only used locally, like gcode.CodeNil.
+	code := gerror.Code(err)
+	if code.Code() < 0 {
+		return err
+	}
+	grpcCode, ok := grpcCodeMap[code.Code()]
+	if !ok {
+		grpcCode = codes.Code(code.Code())
+	}
+	// The error should never be sent as OK, which is treated as no error by grpc.
+	if grpcCode == codes.OK {
+		grpcCode = codes.Unknown
+	}
+	// It ignores the error as there might be no server stream in context, eg: the unit testing.
+	_ = grpc.SetTrailer(ctx, metadata.Pairs(trailerKeyErrorCode, strconv.Itoa(code.Code())))
+	return status.Error(grpcCode, err.Error())
+}
+
+// grpcStatusToError converts grpc status error `err` to error with gcode in client side.
+// It uses the original error code from `trailer` if given, or else the grpc code.
+func grpcStatusToError(err error, trailer metadata.MD) error {
+	if err == nil {
+		return nil
+	}
+	grpcStatus, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	if values := trailer.Get(trailerKeyErrorCode); len(values) > 0 {
+		if code, parseErr := strconv.Atoi(values[0]); parseErr == nil {
+			return gerror.NewCode(gcode.New(code, "", grpcStatus.Code()), grpcStatus.Message())
+		}
+	}
+	if code := grpcStatus.Code(); code != codes.OK {
+		return gerror.NewCode(gcode.New(int(code), "", nil), grpcStatus.Message())
+	}
+	return gerror.New(grpcStatus.Message())
+}
@@ -78,9 +78,11 @@ func (s modServer) New(conf ...*GrpcServerConfig) *GrpcServer {
 			s.UnaryRecover,
 			s.UnaryAllowNilRes,
 			s.UnaryError,
+			s.UnaryValidate,
 		),
 		s.ChainStream(
 			s.StreamTracing,
+			s.StreamError,
 		),
 	}, grpcServer.config.Options...)
 	grpcServer.Server = grpc.NewServer(grpcServer.config.Options...)
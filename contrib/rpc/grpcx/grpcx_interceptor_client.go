@@ -10,28 +10,18 @@ import (
 	"context"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/gogf/gf/contrib/rpc/grpcx/v2/internal/tracing"
-	"github.com/gogf/gf/v2/errors/gcode"
-	"github.com/gogf/gf/v2/errors/gerror"
 )
 
 // UnaryError handles the error types converting between grpc and gerror.
 // Note that, the minus error code is only used locally which will not be sent to other side.
 func (c modClient) UnaryError(ctx context.Context, method string, req, reply interface{},
 	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
-	err := invoker(ctx, method, req, reply, cc, opts...)
-	if err != nil {
-		grpcStatus, ok := status.FromError(err)
-		if ok {
-			if code := grpcStatus.Code(); code != 0 {
-				return gerror.NewCode(gcode.New(int(code), "", nil), grpcStatus.Message())
-			}
-			return gerror.New(grpcStatus.Message())
-		}
-	}
-	return err
+	var trailer metadata.MD
+	opts = append(opts, grpc.Trailer(&trailer))
+	return grpcStatusToError(invoker(ctx, method, req, reply, cc, opts...), trailer)
 }
 
 // UnaryTracing is a unary interceptor for adding tracing feature for gRPC client using OpenTelemetry.
@@ -8,11 +8,10 @@ package grpcx
 
 import (
 	"context"
-	"google.golang.org/protobuf/proto"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/gogf/gf/contrib/rpc/grpcx/v2/internal/tracing"
 	"github.com/gogf/gf/v2/errors/gcode"
@@ -42,13 +41,14 @@ func (s modServer) UnaryError(
 	ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
 ) (interface{}, error) {
 	res, err := handler(ctx, req)
-	if err != nil {
-		code := gerror.Code(err)
-		if code.Code() != -1 {
-			err = status.Error(codes.Code(code.Code()), err.Error())
-		}
-	}
-	return res, err
+	return res, errorToGrpcStatus(ctx, err)
+}
+
+// StreamError is the default stream interceptor for error converting from custom error to grpc error.
+func (s modServer) StreamError(
+	srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+) error {
+	return errorToGrpcStatus(ss.Context(), handler(srv, ss))
 }
 
 // UnaryRecover is the first interceptor that keep server not down from panics.
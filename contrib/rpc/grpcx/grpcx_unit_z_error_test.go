@@ -0,0 +1,60 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package grpcx
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Error_ToGrpcStatus(t *testing.T) {
+	ctx := context.Background()
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(errorToGrpcStatus(ctx, nil))
+
+		err := errorToGrpcStatus(ctx, gerror.NewCode(gcode.CodeNotFound, "user not found"))
+		t.Assert(status.Code(err), codes.NotFound)
+		t.Assert(status.Convert(err).Message(), "user not found")
+
+		err = errorToGrpcStatus(ctx, gerror.NewCode(gcode.New(10001, "", nil), "custom"))
+		t.Assert(status.Code(err), codes.Code(10001))
+
+		err = errorToGrpcStatus(ctx, gerror.NewCode(gcode.CodeOK, "ok"))
+		t.Assert(status.Code(err), codes.Unknown)
+
+		// The errors without code are not converted.
+		err = gerror.New("error")
+		t.Assert(errorToGrpcStatus(ctx, err), err)
+	})
+}
+
+func Test_Error_FromGrpcStatus(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(grpcStatusToError(nil, nil))
+
+		// The original error code from trailer.
+		trailer := metadata.Pairs(trailerKeyErrorCode, strconv.Itoa(gcode.CodeNotFound.Code()))
+		err := grpcStatusToError(status.Error(codes.NotFound, "user not found"), trailer)
+		t.Assert(gerror.Code(err).Code(), gcode.CodeNotFound.Code())
+		t.Assert(gerror.Code(err).Detail(), codes.NotFound)
+		t.Assert(err.Error(), "user not found")
+
+		// The grpc code if no trailer.
+		err = grpcStatusToError(status.Error(codes.Unavailable, "busy"), nil)
+		t.Assert(gerror.Code(err).Code(), int(codes.Unavailable))
+		t.Assert(err.Error(), "busy")
+	})
+}
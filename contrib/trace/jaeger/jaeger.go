@@ -13,6 +13,7 @@
 package jaeger
 
 import (
+	"context"
 	"strings"
 
 	"go.opentelemetry.io/otel"
@@ -25,6 +26,7 @@ import (
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/net/gipv4"
+	"github.com/gogf/gf/v2/net/gtrace"
 	"github.com/gogf/gf/v2/text/gregex"
 )
 
@@ -82,9 +84,16 @@ func Init(serviceName, endpoint string) (*trace.TracerProvider, error) {
 	if err != nil {
 		return nil, err
 	}
+	// It uses the default sampler of TracerProvider unless the sampler is configured
+	// using gtrace.SetSampler or the configuration node "tracing.sampler".
+	sampler, err := gtrace.GetSamplerWithDefault(context.Background(), trace.ParentBased(trace.AlwaysSample()))
+	if err != nil {
+		return nil, err
+	}
 	tp := trace.NewTracerProvider(
 		// Always be sure to batch in production.
 		trace.WithBatcher(exp),
+		trace.WithSampler(sampler),
 		// Record information about this application in a Resource.
 		trace.WithResource(resource.NewWithAttributes(
 			semconv.SchemaURL,
@@ -15,7 +15,6 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
-	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
@@ -23,6 +22,7 @@ import (
 
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/gipv4"
+	"github.com/gogf/gf/v2/net/gtrace"
 )
 
 const (
@@ -79,15 +79,21 @@ func Init(serviceName, endpoint, traceToken string) (func(), error) {
 		return nil, err
 	}
 
+	// It samples all spans unless the sampler is configured using gtrace.SetSampler
+	// or the configuration node "tracing.sampler".
+	sampler, err := gtrace.GetSamplerWithDefault(ctx, sdktrace.AlwaysSample())
+	if err != nil {
+		return nil, err
+	}
 	bsp := sdktrace.NewBatchSpanProcessor(traceExp)
 	tracerProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		sdktrace.WithSampler(sampler),
 		sdktrace.WithResource(res),
 		sdktrace.WithSpanProcessor(bsp),
 	)
 
-	// Set the global propagator to traceContext (not set by default).
-	otel.SetTextMapPropagator(propagation.TraceContext{})
+	// The global propagator is configured by gtrace, which is W3C tracecontext and baggage in default.
+	// It can be changed using gtrace.SetPropagators, eg: gtrace.SetPropagators("tracecontext", "b3").
 	otel.SetTracerProvider(tracerProvider)
 
 	return func() {
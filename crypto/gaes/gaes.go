@@ -23,6 +23,9 @@ const (
 )
 
 // Encrypt is alias of EncryptCBC.
+//
+// Note that CBC mode provides no integrity protection, so the cipher text can be tampered with
+// undetected. Use EncryptGCM or EncryptChaCha20Poly1305 for tokens and stored secrets.
 func Encrypt(plainText []byte, key []byte, iv ...[]byte) ([]byte, error) {
 	return EncryptCBC(plainText, key, iv...)
 }
@@ -0,0 +1,235 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gaes
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/binary"
+	"sync"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// NonceSizeGCM is the nonce size of AES-GCM mode.
+	NonceSizeGCM = 12
+	// NonceSizeChaCha20Poly1305 is the nonce size of ChaCha20-Poly1305.
+	NonceSizeChaCha20Poly1305 = 12
+)
+
+// EncryptGCM encrypts and authenticates `plainText` using AES-GCM mode with optional `additionalData`,
+// which is authenticated but not encrypted.
+// Note that the key must be 16/24/32 bit length.
+//
+// A random nonce is generated for each encryption and prepended to the returned cipher text,
+// so the result can be decrypted by DecryptGCM with the same key and additional data.
+func EncryptGCM(plainText []byte, key []byte, additionalData ...[]byte) ([]byte, error) {
+	aead, err := NewGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return aeadSeal(aead, plainText, additionalData...)
+}
+
+// DecryptGCM decrypts and verifies `cipherText` which is produced by EncryptGCM.
+// It returns an error if the cipher text or the additional data has been tampered with.
+func DecryptGCM(cipherText []byte, key []byte, additionalData ...[]byte) ([]byte, error) {
+	aead, err := NewGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return aeadOpen(aead, cipherText, additionalData...)
+}
+
+// EncryptGCMWithNonce encrypts and authenticates `plainText` using AES-GCM mode with given `nonce`,
+// which must be NonceSizeGCM bytes and must never be reused with the same key.
+// The nonce is not included in the returned cipher text.
+func EncryptGCMWithNonce(plainText []byte, key []byte, nonce []byte, additionalData ...[]byte) ([]byte, error) {
+	aead, err := NewGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return aeadSealWithNonce(aead, plainText, nonce, additionalData...)
+}
+
+// DecryptGCMWithNonce decrypts and verifies `cipherText` which is produced by EncryptGCMWithNonce.
+func DecryptGCMWithNonce(cipherText []byte, key []byte, nonce []byte, additionalData ...[]byte) ([]byte, error) {
+	aead, err := NewGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return aeadOpenWithNonce(aead, cipherText, nonce, additionalData...)
+}
+
+// NewGCM creates and returns an AES-GCM cipher.AEAD with given `key`.
+// Note that the key must be 16/24/32 bit length.
+func NewGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		err = gerror.WrapCodef(gcode.CodeInvalidParameter, err, `aes.NewCipher failed for key length %d`, len(key))
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		err = gerror.WrapCode(gcode.CodeInternalError, err, `cipher.NewGCM failed`)
+		return nil, err
+	}
+	return aead, nil
+}
+
+// NewChaCha20Poly1305 creates and returns a ChaCha20-Poly1305 cipher.AEAD defined in RFC 8439 with given `key`.
+// Note that the key must be 32 bytes length.
+func NewChaCha20Poly1305(key []byte) (cipher.AEAD, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		err = gerror.WrapCodef(gcode.CodeInvalidParameter, err, `chacha20poly1305.New failed for key length %d`, len(key))
+		return nil, err
+	}
+	return aead, nil
+}
+
+// EncryptChaCha20Poly1305 encrypts and authenticates `plainText` using ChaCha20-Poly1305 with optional
+// `additionalData`, which is preferable to AES-GCM on platforms without AES hardware acceleration.
+// Note that the key must be 32 bytes length.
+//
+// A random nonce is generated for each encryption and prepended to the returned cipher text.
+func EncryptChaCha20Poly1305(plainText []byte, key []byte, additionalData ...[]byte) ([]byte, error) {
+	aead, err := NewChaCha20Poly1305(key)
+	if err != nil {
+		return nil, err
+	}
+	return aeadSeal(aead, plainText, additionalData...)
+}
+
+// DecryptChaCha20Poly1305 decrypts and verifies `cipherText` which is produced by EncryptChaCha20Poly1305.
+func DecryptChaCha20Poly1305(cipherText []byte, key []byte, additionalData ...[]byte) ([]byte, error) {
+	aead, err := NewChaCha20Poly1305(key)
+	if err != nil {
+		return nil, err
+	}
+	return aeadOpen(aead, cipherText, additionalData...)
+}
+
+// EncryptChaCha20Poly1305WithNonce encrypts and authenticates `plainText` using ChaCha20-Poly1305 with
+// given `nonce`, which must be NonceSizeChaCha20Poly1305 bytes and must never be reused with the same key.
+func EncryptChaCha20Poly1305WithNonce(plainText []byte, key []byte, nonce []byte, additionalData ...[]byte) ([]byte, error) {
+	aead, err := NewChaCha20Poly1305(key)
+	if err != nil {
+		return nil, err
+	}
+	return aeadSealWithNonce(aead, plainText, nonce, additionalData...)
+}
+
+// DecryptChaCha20Poly1305WithNonce decrypts and verifies `cipherText` which is produced by
+// EncryptChaCha20Poly1305WithNonce.
+func DecryptChaCha20Poly1305WithNonce(cipherText []byte, key []byte, nonce []byte, additionalData ...[]byte) ([]byte, error) {
+	aead, err := NewChaCha20Poly1305(key)
+	if err != nil {
+		return nil, err
+	}
+	return aeadOpenWithNonce(aead, cipherText, nonce, additionalData...)
+}
+
+// NewNonce generates and returns a random nonce of `size` bytes using crypto/rand.
+func NewNonce(size int) ([]byte, error) {
+	nonce := make([]byte, size)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, `rand.Read failed for nonce`)
+	}
+	return nonce, nil
+}
+
+// NonceSequence generates unique 12 bytes nonces composed of a random 4 bytes prefix and
+// an increasing 8 bytes counter, which never repeats for the same NonceSequence.
+// It is concurrent-safe, and is useful when lots of messages are encrypted with the same key,
+// as the collision probability of random nonces grows with the count of messages.
+type NonceSequence struct {
+	mu      sync.Mutex
+	prefix  [4]byte
+	counter uint64
+}
+
+// NewNonceSequence creates and returns a NonceSequence with a random prefix.
+func NewNonceSequence() (*NonceSequence, error) {
+	s := &NonceSequence{}
+	if _, err := rand.Read(s.prefix[:]); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, `rand.Read failed for nonce prefix`)
+	}
+	return s, nil
+}
+
+// Next returns the next nonce of the sequence.
+// It returns an error if the counter is exhausted, in which case the key should be rotated.
+func (s *NonceSequence) Next() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counter == ^uint64(0) {
+		return nil, gerror.NewCode(gcode.CodeInvalidOperation, `nonce sequence exhausted`)
+	}
+	nonce := make([]byte, 12)
+	copy(nonce, s.prefix[:])
+	binary.BigEndian.PutUint64(nonce[4:], s.counter)
+	s.counter++
+	return nonce, nil
+}
+
+// CompareKey compares two keys in constant time, which should be used instead of bytes.Equal
+// for secrets to avoid timing attacks.
+func CompareKey(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+func aeadSeal(aead cipher.AEAD, plainText []byte, additionalData ...[]byte) ([]byte, error) {
+	nonce, err := NewNonce(aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plainText, getAdditionalData(additionalData)), nil
+}
+
+func aeadOpen(aead cipher.AEAD, cipherText []byte, additionalData ...[]byte) ([]byte, error) {
+	nonceSize := aead.NonceSize()
+	if len(cipherText) < nonceSize+aead.Overhead() {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, "cipherText too short")
+	}
+	return aeadOpenWithNonce(aead, cipherText[nonceSize:], cipherText[:nonceSize], additionalData...)
+}
+
+func aeadSealWithNonce(aead cipher.AEAD, plainText []byte, nonce []byte, additionalData ...[]byte) ([]byte, error) {
+	if len(nonce) != aead.NonceSize() {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter, `invalid nonce length %d, should be %d`, len(nonce), aead.NonceSize(),
+		)
+	}
+	return aead.Seal(nil, nonce, plainText, getAdditionalData(additionalData)), nil
+}
+
+func aeadOpenWithNonce(aead cipher.AEAD, cipherText []byte, nonce []byte, additionalData ...[]byte) ([]byte, error) {
+	if len(nonce) != aead.NonceSize() {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter, `invalid nonce length %d, should be %d`, len(nonce), aead.NonceSize(),
+		)
+	}
+	plainText, err := aead.Open(nil, nonce, cipherText, getAdditionalData(additionalData))
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `message authentication failed`)
+	}
+	return plainText, nil
+}
+
+func getAdditionalData(additionalData [][]byte) []byte {
+	if len(additionalData) > 0 {
+		return additionalData[0]
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gaes_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/gogf/gf/v2/crypto/gaes"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func TestGCM(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		for _, key := range [][]byte{key_16, key_24, key_32} {
+			encrypted, err := gaes.EncryptGCM(content, key, []byte("user:1"))
+			t.AssertNil(err)
+			t.Assert(len(encrypted), gaes.NonceSizeGCM+len(content)+16)
+
+			decrypted, err := gaes.DecryptGCM(encrypted, key, []byte("user:1"))
+			t.AssertNil(err)
+			t.Assert(decrypted, content)
+
+			// Random nonce for each encryption.
+			encrypted2, err := gaes.EncryptGCM(content, key, []byte("user:1"))
+			t.AssertNil(err)
+			t.AssertNE(encrypted2, encrypted)
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		encrypted, err := gaes.EncryptGCM(content, key_16, []byte("user:1"))
+		t.AssertNil(err)
+
+		_, err = gaes.DecryptGCM(encrypted, key_16, []byte("user:2"))
+		t.AssertNE(err, nil)
+		_, err = gaes.DecryptGCM(encrypted, key_16)
+		t.AssertNE(err, nil)
+		_, err = gaes.DecryptGCM(encrypted, keys[16:], []byte("user:1"))
+		t.AssertNE(err, nil)
+
+		encrypted[len(encrypted)-1] ^= 1
+		_, err = gaes.DecryptGCM(encrypted, key_16, []byte("user:1"))
+		t.AssertNE(err, nil)
+
+		_, err = gaes.DecryptGCM(encrypted[:10], key_16)
+		t.AssertNE(err, nil)
+		_, err = gaes.EncryptGCM(content, key_err)
+		t.AssertNE(err, nil)
+		_, err = gaes.DecryptGCM(encrypted, key_err)
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		nonce, err := gaes.NewNonce(gaes.NonceSizeGCM)
+		t.AssertNil(err)
+		encrypted, err := gaes.EncryptGCMWithNonce(content, key_32, nonce)
+		t.AssertNil(err)
+		t.Assert(len(encrypted), len(content)+16)
+
+		decrypted, err := gaes.DecryptGCMWithNonce(encrypted, key_32, nonce)
+		t.AssertNil(err)
+		t.Assert(decrypted, content)
+
+		_, err = gaes.EncryptGCMWithNonce(content, key_32, nonce[:8])
+		t.AssertNE(err, nil)
+		_, err = gaes.DecryptGCMWithNonce(encrypted, key_32, nonce[:8])
+		t.AssertNE(err, nil)
+	})
+}
+
+func TestChaCha20Poly1305(t *testing.T) {
+	// Test vector of RFC 8439 section 2.8.2.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			key, _       = hex.DecodeString("808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f")
+			nonce, _     = hex.DecodeString("070000004041424344454647")
+			aad, _       = hex.DecodeString("50515253c0c1c2c3c4c5c6c7")
+			plainText    = []byte("Ladies and Gentlemen of the class of '99: If I could offer you only one tip for the future, sunscreen would be it.")
+			cipherTag, _ = hex.DecodeString(
+				"d31a8d34648e60db7b86afbc53ef7ec2a4aded51296e08fea9e2b5a736ee62d63dbea45e8ca9671282fafb69da92728b" +
+					"1a71de0a9e060b2905d6a5b67ecd3b3692ddbd7f2d778b8c9803aee328091b58fab324e4fad675945585808b4831d7bc" +
+					"3ff4def08e4b7a9de576d26586cec64b6116" +
+					"1ae10b594f09e26a7e902ecbd0600691",
+			)
+		)
+		encrypted, err := gaes.EncryptChaCha20Poly1305WithNonce(plainText, key, nonce, aad)
+		t.AssertNil(err)
+		t.Assert(encrypted, cipherTag)
+
+		decrypted, err := gaes.DecryptChaCha20Poly1305WithNonce(encrypted, key, nonce, aad)
+		t.AssertNil(err)
+		t.Assert(decrypted, plainText)
+
+		encrypted[0] ^= 1
+		_, err = gaes.DecryptChaCha20Poly1305WithNonce(encrypted, key, nonce, aad)
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		for _, text := range [][]byte{nil, content, make([]byte, 1000)} {
+			encrypted, err := gaes.EncryptChaCha20Poly1305(text, key_32, []byte("user:1"))
+			t.AssertNil(err)
+			t.Assert(len(encrypted), gaes.NonceSizeChaCha20Poly1305+len(text)+16)
+
+			decrypted, err := gaes.DecryptChaCha20Poly1305(encrypted, key_32, []byte("user:1"))
+			t.AssertNil(err)
+			t.Assert(len(decrypted), len(text))
+			t.Assert(decrypted, text)
+
+			_, err = gaes.DecryptChaCha20Poly1305(encrypted, key_32, []byte("user:2"))
+			t.AssertNE(err, nil)
+			_, err = gaes.DecryptChaCha20Poly1305(encrypted, keys)
+			t.AssertNE(err, nil)
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gaes.EncryptChaCha20Poly1305(content, key_16)
+		t.AssertNE(err, nil)
+		_, err = gaes.DecryptChaCha20Poly1305(content, key_32)
+		t.AssertNE(err, nil)
+		_, err = gaes.NewChaCha20Poly1305(key_err)
+		t.AssertNE(err, nil)
+	})
+}
+
+func TestNonceSequence(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		sequence, err := gaes.NewNonceSequence()
+		t.AssertNil(err)
+		nonce1, err := sequence.Next()
+		t.AssertNil(err)
+		nonce2, err := sequence.Next()
+		t.AssertNil(err)
+		t.Assert(len(nonce1), gaes.NonceSizeGCM)
+		t.Assert(nonce1[:4], nonce2[:4])
+		t.Assert(nonce1[11], 0)
+		t.Assert(nonce2[11], 1)
+
+		encrypted, err := gaes.EncryptGCMWithNonce(content, key_16, nonce2)
+		t.AssertNil(err)
+		decrypted, err := gaes.DecryptGCMWithNonce(encrypted, key_16, nonce2)
+		t.AssertNil(err)
+		t.Assert(decrypted, content)
+	})
+}
+
+func TestCompareKey(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gaes.CompareKey(key_32, []byte("12345678912345678912345678912345")), true)
+		t.Assert(gaes.CompareKey(key_32, keys), false)
+		t.Assert(gaes.CompareKey(key_32, key_16), false)
+		t.Assert(gaes.CompareKey(nil, nil), true)
+	})
+}
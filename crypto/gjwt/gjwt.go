@@ -0,0 +1,149 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gjwt provides JSON Web Token (JWT) creation and verification.
+//
+// It supports HMAC (HS256/HS384/HS512), RSA (RS256/RS384/RS512), ECDSA (ES256/ES384/ES512) and
+// Ed25519 (EdDSA) algorithms, standard claims validation with leeway, and key rotation by key id "kid"
+// using static key sets or remote JWKS endpoints with caching.
+package gjwt
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Algorithm is the signing algorithm of JWT, which is the "alg" header.
+type Algorithm string
+
+const (
+	HS256 Algorithm = "HS256" // HMAC using SHA-256, the key should be []byte.
+	HS384 Algorithm = "HS384" // HMAC using SHA-384, the key should be []byte.
+	HS512 Algorithm = "HS512" // HMAC using SHA-512, the key should be []byte.
+	RS256 Algorithm = "RS256" // RSASSA-PKCS1-v1_5 using SHA-256, the key should be *rsa.PrivateKey or *rsa.PublicKey.
+	RS384 Algorithm = "RS384" // RSASSA-PKCS1-v1_5 using SHA-384, the key should be *rsa.PrivateKey or *rsa.PublicKey.
+	RS512 Algorithm = "RS512" // RSASSA-PKCS1-v1_5 using SHA-512, the key should be *rsa.PrivateKey or *rsa.PublicKey.
+	ES256 Algorithm = "ES256" // ECDSA using P-256 and SHA-256, the key should be *ecdsa.PrivateKey or *ecdsa.PublicKey.
+	ES384 Algorithm = "ES384" // ECDSA using P-384 and SHA-384, the key should be *ecdsa.PrivateKey or *ecdsa.PublicKey.
+	ES512 Algorithm = "ES512" // ECDSA using P-521 and SHA-512, the key should be *ecdsa.PrivateKey or *ecdsa.PublicKey.
+	EdDSA Algorithm = "EdDSA" // Ed25519, the key should be ed25519.PrivateKey or ed25519.PublicKey.
+)
+
+// Key is a key for signing or verifying tokens.
+type Key struct {
+	ID        string      // Key id, which is the "kid" header for key rotation.
+	Algorithm Algorithm   // Algorithm of the key, the token of other algorithms is never verified by this key.
+	Key       interface{} // Secret, private or public key, see Algorithm for the key types.
+}
+
+// Token is a parsed JWT.
+type Token struct {
+	Raw       string                 // The raw token string.
+	Header    map[string]interface{} // Header of the token.
+	Claims    Claims                 // Claims of the token.
+	Signature []byte                 // Decoded signature of the token.
+}
+
+var (
+	// ErrTokenMalformed is returned if the token cannot be parsed.
+	ErrTokenMalformed = gerror.NewWithOption(gerror.Option{
+		Text: "token is malformed",
+		Code: gcode.CodeInvalidParameter,
+	})
+	// ErrTokenUnverifiable is returned if there's no key for verifying the token.
+	ErrTokenUnverifiable = gerror.NewWithOption(gerror.Option{
+		Text: "token is unverifiable",
+		Code: gcode.CodeNotAuthorized,
+	})
+	// ErrTokenSignatureInvalid is returned if the signature of the token is invalid.
+	ErrTokenSignatureInvalid = gerror.NewWithOption(gerror.Option{
+		Text: "token signature is invalid",
+		Code: gcode.CodeNotAuthorized,
+	})
+	// ErrTokenExpired is returned if the token is expired.
+	ErrTokenExpired = gerror.NewWithOption(gerror.Option{
+		Text: "token is expired",
+		Code: gcode.CodeNotAuthorized,
+	})
+	// ErrTokenNotValidYet is returned if the token is used before its "nbf" claim.
+	ErrTokenNotValidYet = gerror.NewWithOption(gerror.Option{
+		Text: "token is not valid yet",
+		Code: gcode.CodeNotAuthorized,
+	})
+	// ErrTokenInvalidClaims is returned if the claims like "iss" and "aud" do not match the expected values.
+	ErrTokenInvalidClaims = gerror.NewWithOption(gerror.Option{
+		Text: "token has invalid claims",
+		Code: gcode.CodeNotAuthorized,
+	})
+)
+
+// Parse parses the `token` string WITHOUT verifying its signature and claims.
+// It is usually used for reading the header like "kid" before verification,
+// use Verify for untrusted tokens.
+func Parse(token string) (*Token, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, gerror.Wrapf(ErrTokenMalformed, `token should have 3 parts but has %d`, len(parts))
+	}
+	t := &Token{Raw: token}
+	if err := decodeSegment(parts[0], &t.Header); err != nil {
+		return nil, gerror.Wrap(err, `decode token header failed`)
+	}
+	if err := decodeSegment(parts[1], &t.Claims); err != nil {
+		return nil, gerror.Wrap(err, `decode token claims failed`)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, gerror.Wrapf(ErrTokenMalformed, `decode token signature failed: %s`, err.Error())
+	}
+	t.Signature = signature
+	if t.Header == nil {
+		t.Header = make(map[string]interface{})
+	}
+	if t.Claims == nil {
+		t.Claims = make(Claims)
+	}
+	return t, nil
+}
+
+// Algorithm returns the "alg" header of the token.
+func (t *Token) Algorithm() Algorithm {
+	return Algorithm(gconv.String(t.Header["alg"]))
+}
+
+// KeyID returns the "kid" header of the token.
+func (t *Token) KeyID() string {
+	return gconv.String(t.Header["kid"])
+}
+
+// signingInput returns the header and claims parts of the raw token, which are signed.
+func (t *Token) signingInput() string {
+	return t.Raw[:strings.LastIndexByte(t.Raw, '.')]
+}
+
+func encodeSegment(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeSegment(segment string, pointer interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return gerror.Wrapf(ErrTokenMalformed, `%s`, err.Error())
+	}
+	if err = json.UnmarshalUseNumber(b, pointer); err != nil {
+		return gerror.Wrapf(ErrTokenMalformed, `%s`, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjwt
+
+import (
+	"math"
+	"time"
+
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Claims is the payload of JWT, in which the registered claims can be retrieved by its methods.
+//
+// The time claims "exp", "nbf" and "iat" can be given as time.Time when signing,
+// which are converted to the numeric date of seconds automatically.
+type Claims map[string]interface{}
+
+// Registered claim names defined by RFC 7519.
+const (
+	ClaimIssuer    = "iss"
+	ClaimSubject   = "sub"
+	ClaimAudience  = "aud"
+	ClaimExpiresAt = "exp"
+	ClaimNotBefore = "nbf"
+	ClaimIssuedAt  = "iat"
+	ClaimID        = "jti"
+)
+
+// Issuer returns the "iss" claim.
+func (c Claims) Issuer() string {
+	return gconv.String(c[ClaimIssuer])
+}
+
+// Subject returns the "sub" claim.
+func (c Claims) Subject() string {
+	return gconv.String(c[ClaimSubject])
+}
+
+// Audience returns the "aud" claim, which can be a single string or a list of strings.
+func (c Claims) Audience() []string {
+	switch v := c[ClaimAudience].(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	default:
+		return gconv.Strings(v)
+	}
+}
+
+// ID returns the "jti" claim.
+func (c Claims) ID() string {
+	return gconv.String(c[ClaimID])
+}
+
+// ExpiresAt returns the "exp" claim, it returns false if the claim does not exist.
+func (c Claims) ExpiresAt() (time.Time, bool) {
+	return c.getTime(ClaimExpiresAt)
+}
+
+// NotBefore returns the "nbf" claim, it returns false if the claim does not exist.
+func (c Claims) NotBefore() (time.Time, bool) {
+	return c.getTime(ClaimNotBefore)
+}
+
+// IssuedAt returns the "iat" claim, it returns false if the claim does not exist.
+func (c Claims) IssuedAt() (time.Time, bool) {
+	return c.getTime(ClaimIssuedAt)
+}
+
+func (c Claims) getTime(name string) (time.Time, bool) {
+	v, ok := c[name]
+	if !ok || v == nil {
+		return time.Time{}, false
+	}
+	if t, ok := v.(time.Time); ok {
+		return t, true
+	}
+	seconds, fraction := math.Modf(gconv.Float64(v))
+	return time.Unix(int64(seconds), int64(fraction*1e9)), true
+}
+
+// numericDates returns a copy of claims in which the time.Time values of time claims are converted to seconds.
+func (c Claims) numericDates() Claims {
+	claims := make(Claims, len(c))
+	for k, v := range c {
+		switch k {
+		case ClaimExpiresAt, ClaimNotBefore, ClaimIssuedAt:
+			if t, ok := v.(time.Time); ok {
+				v = t.Unix()
+			}
+		}
+		claims[k] = v
+	}
+	return claims
+}
@@ -0,0 +1,345 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// KeySet provides verification keys for Verify.
+type KeySet interface {
+	// Keys returns the candidate keys for key id `kid`, in which `kid` is empty if the token has no "kid" header.
+	Keys(ctx context.Context, kid string) ([]Key, error)
+}
+
+// staticKeySet is a KeySet of fixed keys.
+type staticKeySet []Key
+
+// NewKeySet creates and returns a KeySet of given `keys`.
+// For key rotation, the new key and the old keys can be given together with different key ids.
+func NewKeySet(keys ...Key) KeySet {
+	return staticKeySet(keys)
+}
+
+// Keys returns the keys having id `kid` and the keys without id, or all keys if `kid` is empty.
+func (s staticKeySet) Keys(ctx context.Context, kid string) ([]Key, error) {
+	return filterKeys(s, kid), nil
+}
+
+// JWKSOption is the option for JWKS.
+type JWKSOption struct {
+	Client             *http.Client  // HTTP client for fetching, which has 10 seconds timeout in default.
+	CacheTTL           time.Duration // Duration of caching the fetched keys, which is 10 minutes in default.
+	MinRefreshInterval time.Duration // Minimum interval of refreshing for unknown key id, which is 1 minute in default.
+}
+
+// JWKS is a KeySet fetching keys from a remote JSON Web Key Set endpoint, which caches the keys and
+// refreshes them after CacheTTL, or when a token with unknown key id arrives for key rotation.
+// It is concurrent-safe.
+type JWKS struct {
+	url       string
+	option    JWKSOption
+	mu        sync.Mutex
+	keys      []Key
+	fetchedAt time.Time
+}
+
+const (
+	defaultJWKSTimeout            = 10 * time.Second
+	defaultJWKSCacheTTL           = 10 * time.Minute
+	defaultJWKSMinRefreshInterval = time.Minute
+	maxJWKSResponseSize           = 1 << 20
+)
+
+// NewJWKS creates and returns a JWKS for `url`, the keys are fetched lazily on first use.
+func NewJWKS(url string, option ...JWKSOption) *JWKS {
+	j := &JWKS{url: url}
+	if len(option) > 0 {
+		j.option = option[0]
+	}
+	if j.option.Client == nil {
+		j.option.Client = &http.Client{Timeout: defaultJWKSTimeout}
+	}
+	if j.option.CacheTTL <= 0 {
+		j.option.CacheTTL = defaultJWKSCacheTTL
+	}
+	if j.option.MinRefreshInterval <= 0 {
+		j.option.MinRefreshInterval = defaultJWKSMinRefreshInterval
+	}
+	return j
+}
+
+// Keys returns the keys having id `kid` and the keys without id, or all keys if `kid` is empty.
+// The stale cached keys are still used if refreshing fails.
+func (j *JWKS) Keys(ctx context.Context, kid string) ([]Key, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	var (
+		elapsed = time.Since(j.fetchedAt)
+		keys    = filterKeys(j.keys, kid)
+	)
+	if j.fetchedAt.IsZero() || elapsed >= j.option.CacheTTL ||
+		(len(keys) == 0 && elapsed >= j.option.MinRefreshInterval) {
+		if err := j.refresh(ctx); err != nil {
+			if len(j.keys) == 0 {
+				return nil, err
+			}
+		}
+		keys = filterKeys(j.keys, kid)
+	}
+	return keys, nil
+}
+
+// Refresh fetches the keys from remote endpoint immediately.
+func (j *JWKS) Refresh(ctx context.Context) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.refresh(ctx)
+}
+
+func (j *JWKS) refresh(ctx context.Context) error {
+	// It updates the fetching time even if it fails, to avoid flooding the remote endpoint.
+	j.fetchedAt = time.Now()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, j.url, nil)
+	if err != nil {
+		return gerror.WrapCodef(gcode.CodeInvalidParameter, err, `invalid JWKS url "%s"`, j.url)
+	}
+	response, err := j.option.Client.Do(request)
+	if err != nil {
+		return gerror.WrapCodef(gcode.CodeOperationFailed, err, `fetch JWKS from "%s" failed`, j.url)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return gerror.NewCodef(
+			gcode.CodeOperationFailed, `fetch JWKS from "%s" failed with status %d`, j.url, response.StatusCode,
+		)
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxJWKSResponseSize))
+	if err != nil {
+		return gerror.WrapCodef(gcode.CodeOperationFailed, err, `read JWKS from "%s" failed`, j.url)
+	}
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return err
+	}
+	j.keys = keys
+	return nil
+}
+
+// jwk is a JSON Web Key defined by RFC 7517.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Use string `json:"use,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	K   string `json:"k,omitempty"`
+}
+
+// EncodeJWKS encodes the public keys of `keys` as JSON Web Key Set, which is published for other
+// services verifying the tokens. The HMAC keys are ignored, as their secrets should never be published.
+func EncodeJWKS(keys ...Key) ([]byte, error) {
+	var set = struct {
+		Keys []jwk `json:"keys"`
+	}{
+		Keys: make([]jwk, 0, len(keys)),
+	}
+	for _, key := range keys {
+		item, ok, err := newJWK(key)
+		if err != nil {
+			return nil, gerror.Wrapf(err, `invalid key "%s"`, key.ID)
+		}
+		if ok {
+			set.Keys = append(set.Keys, item)
+		}
+	}
+	data, err := json.Marshal(set)
+	if err != nil {
+		return nil, gerror.Wrap(err, `encode JWKS failed`)
+	}
+	return data, nil
+}
+
+// ParseJWKS parses the JSON Web Key Set `data` and returns its signature verification keys.
+// The keys for encryption and of unsupported types are ignored.
+func ParseJWKS(data []byte) ([]Key, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid JWKS content`)
+	}
+	keys := make([]Key, 0, len(set.Keys))
+	for _, item := range set.Keys {
+		if item.Use != "" && item.Use != "sig" {
+			continue
+		}
+		key, ok, err := item.toKey()
+		if err != nil {
+			return nil, gerror.Wrapf(err, `invalid JWK "%s"`, item.Kid)
+		}
+		if ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// toKey converts the JWK to Key, it returns false if the key type is not supported.
+func (k jwk) toKey() (key Key, ok bool, err error) {
+	key = Key{ID: k.Kid, Algorithm: Algorithm(k.Alg)}
+	switch k.Kty {
+	case "RSA":
+		var n, e []byte
+		if n, err = decodeJWKField(k.N); err != nil {
+			return
+		}
+		if e, err = decodeJWKField(k.E); err != nil {
+			return
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return key, false, gerror.NewCode(gcode.CodeInvalidParameter, `invalid RSA exponent`)
+		}
+		key.Key = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+		if key.Algorithm == "" {
+			key.Algorithm = RS256
+		}
+
+	case "EC":
+		var (
+			x, y      []byte
+			curve     elliptic.Curve
+			algorithm Algorithm
+		)
+		switch k.Crv {
+		case "P-256":
+			curve, algorithm = elliptic.P256(), ES256
+		case "P-384":
+			curve, algorithm = elliptic.P384(), ES384
+		case "P-521":
+			curve, algorithm = elliptic.P521(), ES512
+		default:
+			return key, false, nil
+		}
+		if x, err = decodeJWKField(k.X); err != nil {
+			return
+		}
+		if y, err = decodeJWKField(k.Y); err != nil {
+			return
+		}
+		publicKey := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(publicKey.X, publicKey.Y) {
+			return key, false, gerror.NewCode(gcode.CodeInvalidParameter, `EC point is not on the curve`)
+		}
+		key.Key = publicKey
+		if key.Algorithm == "" {
+			key.Algorithm = algorithm
+		}
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return key, false, nil
+		}
+		var x []byte
+		if x, err = decodeJWKField(k.X); err != nil {
+			return
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return key, false, gerror.NewCode(gcode.CodeInvalidParameter, `invalid Ed25519 key size`)
+		}
+		key.Key, key.Algorithm = ed25519.PublicKey(x), EdDSA
+
+	case "oct":
+		var secret []byte
+		if secret, err = decodeJWKField(k.K); err != nil {
+			return
+		}
+		key.Key = secret
+		if key.Algorithm == "" {
+			key.Algorithm = HS256
+		}
+
+	default:
+		return key, false, nil
+	}
+	return key, true, nil
+}
+
+// newJWK creates the JWK of public part of `key`, it returns false if `key` has no public key.
+func newJWK(key Key) (item jwk, ok bool, err error) {
+	item = jwk{Kid: key.ID, Alg: string(key.Algorithm), Use: "sig"}
+	switch k := getPublicKey(key.Key).(type) {
+	case *rsa.PublicKey:
+		item.Kty = "RSA"
+		item.N = base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+		item.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())
+
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		item.Kty = "EC"
+		item.Crv = k.Curve.Params().Name
+		item.X = base64.RawURLEncoding.EncodeToString(k.X.FillBytes(make([]byte, size)))
+		item.Y = base64.RawURLEncoding.EncodeToString(k.Y.FillBytes(make([]byte, size)))
+
+	case ed25519.PublicKey:
+		item.Kty = "OKP"
+		item.Crv = "Ed25519"
+		item.X = base64.RawURLEncoding.EncodeToString(k)
+
+	case []byte:
+		return item, false, nil
+
+	default:
+		return item, false, gerror.NewCodef(gcode.CodeInvalidParameter, `unsupported key type "%T"`, key.Key)
+	}
+	return item, true, nil
+}
+
+func decodeJWKField(value string) ([]byte, error) {
+	if value == "" {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `missing key field`)
+	}
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid base64url key field`)
+	}
+	return b, nil
+}
+
+// filterKeys returns the keys having id `kid` and the keys without id, or all keys if `kid` is empty.
+func filterKeys(keys []Key, kid string) []Key {
+	if kid == "" {
+		return keys
+	}
+	var filtered []Key
+	for _, key := range keys {
+		if key.ID == "" || key.ID == kid {
+			filtered = append(filtered, key)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,211 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"math/big"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Sign signs `claims` with `key` and returns the token string.
+// The header contains "alg", "typ" and "kid" if the key id is not empty,
+// and the optional parameter `header` specifies extra header fields.
+func Sign(claims Claims, key Key, header ...map[string]interface{}) (string, error) {
+	tokenHeader := make(map[string]interface{})
+	if len(header) > 0 {
+		for k, v := range header[0] {
+			tokenHeader[k] = v
+		}
+	}
+	tokenHeader["alg"] = key.Algorithm
+	if _, ok := tokenHeader["typ"]; !ok {
+		tokenHeader["typ"] = "JWT"
+	}
+	if key.ID != "" {
+		tokenHeader["kid"] = key.ID
+	}
+	headerSegment, err := encodeSegment(tokenHeader)
+	if err != nil {
+		return "", gerror.Wrap(err, `encode token header failed`)
+	}
+	claimsSegment, err := encodeSegment(claims.numericDates())
+	if err != nil {
+		return "", gerror.Wrap(err, `encode token claims failed`)
+	}
+	signingInput := headerSegment + "." + claimsSegment
+	signature, err := sign(key, []byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// sign computes the signature of `data` using `key`.
+func sign(key Key, data []byte) ([]byte, error) {
+	hashFunc, err := getHash(key.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	switch k := key.Key.(type) {
+	case []byte:
+		if isHMAC(key.Algorithm) {
+			mac := hmac.New(hashFunc.New, k)
+			mac.Write(data)
+			return mac.Sum(nil), nil
+		}
+
+	case *rsa.PrivateKey:
+		if isRSA(key.Algorithm) {
+			signature, err := rsa.SignPKCS1v15(rand.Reader, k, hashFunc, digest(hashFunc, data))
+			if err != nil {
+				err = gerror.WrapCode(gcode.CodeInternalError, err, `rsa.SignPKCS1v15 failed`)
+			}
+			return signature, err
+		}
+
+	case *ecdsa.PrivateKey:
+		if isECDSA(key.Algorithm) && k.Curve == getCurve(key.Algorithm) {
+			r, s, err := ecdsa.Sign(rand.Reader, k, digest(hashFunc, data))
+			if err != nil {
+				return nil, gerror.WrapCode(gcode.CodeInternalError, err, `ecdsa.Sign failed`)
+			}
+			size := (k.Curve.Params().BitSize + 7) / 8
+			signature := make([]byte, 2*size)
+			r.FillBytes(signature[:size])
+			s.FillBytes(signature[size:])
+			return signature, nil
+		}
+
+	case ed25519.PrivateKey:
+		if key.Algorithm == EdDSA {
+			return ed25519.Sign(k, data), nil
+		}
+	}
+	return nil, gerror.NewCodef(
+		gcode.CodeInvalidParameter, `invalid signing key type "%T" for algorithm "%s"`, key.Key, key.Algorithm,
+	)
+}
+
+// verify checks the `signature` of `data` using `key`.
+func verify(key Key, data, signature []byte) error {
+	hashFunc, err := getHash(key.Algorithm)
+	if err != nil {
+		return err
+	}
+	var valid bool
+	switch k := getPublicKey(key.Key).(type) {
+	case []byte:
+		if !isHMAC(key.Algorithm) {
+			break
+		}
+		mac := hmac.New(hashFunc.New, k)
+		mac.Write(data)
+		valid = hmac.Equal(signature, mac.Sum(nil))
+
+	case *rsa.PublicKey:
+		if !isRSA(key.Algorithm) {
+			break
+		}
+		valid = rsa.VerifyPKCS1v15(k, hashFunc, digest(hashFunc, data), signature) == nil
+
+	case *ecdsa.PublicKey:
+		if !isECDSA(key.Algorithm) || k.Curve != getCurve(key.Algorithm) {
+			break
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return ErrTokenSignatureInvalid
+		}
+		var (
+			r = new(big.Int).SetBytes(signature[:size])
+			s = new(big.Int).SetBytes(signature[size:])
+		)
+		valid = ecdsa.Verify(k, digest(hashFunc, data), r, s)
+
+	case ed25519.PublicKey:
+		if key.Algorithm != EdDSA || len(k) != ed25519.PublicKeySize {
+			break
+		}
+		valid = ed25519.Verify(k, data, signature)
+
+	default:
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter, `invalid verification key type "%T" for algorithm "%s"`, key.Key, key.Algorithm,
+		)
+	}
+	if !valid {
+		return ErrTokenSignatureInvalid
+	}
+	return nil
+}
+
+// getPublicKey returns the public key of private key `key`, or else it returns `key` itself.
+func getPublicKey(key interface{}) interface{} {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return &k.PublicKey
+	case *ecdsa.PrivateKey:
+		return &k.PublicKey
+	case ed25519.PrivateKey:
+		return k.Public()
+	}
+	return key
+}
+
+func getHash(algorithm Algorithm) (crypto.Hash, error) {
+	switch algorithm {
+	case HS256, RS256, ES256:
+		return crypto.SHA256, nil
+	case HS384, RS384, ES384:
+		return crypto.SHA384, nil
+	case HS512, RS512, ES512, EdDSA:
+		return crypto.SHA512, nil
+	}
+	return 0, gerror.NewCodef(gcode.CodeNotSupported, `unsupported algorithm "%s"`, algorithm)
+}
+
+func getCurve(algorithm Algorithm) elliptic.Curve {
+	switch algorithm {
+	case ES256:
+		return elliptic.P256()
+	case ES384:
+		return elliptic.P384()
+	case ES512:
+		return elliptic.P521()
+	}
+	return nil
+}
+
+func digest(hashFunc crypto.Hash, data []byte) []byte {
+	h := hashFunc.New()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func isHMAC(algorithm Algorithm) bool {
+	return algorithm == HS256 || algorithm == HS384 || algorithm == HS512
+}
+
+func isRSA(algorithm Algorithm) bool {
+	return algorithm == RS256 || algorithm == RS384 || algorithm == RS512
+}
+
+func isECDSA(algorithm Algorithm) bool {
+	return algorithm == ES256 || algorithm == ES384 || algorithm == ES512
+}
@@ -0,0 +1,117 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjwt
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// VerifyOption is the option for Verify.
+type VerifyOption struct {
+	KeySet            KeySet           // KeySet provides the verification keys, which is required.
+	Algorithms        []Algorithm      // Allowed algorithms, all algorithms of the keys are allowed if empty.
+	Issuer            string           // Expected "iss" claim, which is not checked if empty.
+	Audience          string           // Expected value in "aud" claim, which is not checked if empty.
+	Leeway            time.Duration    // Leeway for time claims "exp" and "nbf" to tolerate clock skew.
+	RequireExpiration bool             // Whether the "exp" claim is required.
+	Now               func() time.Time // Custom function returning current time, which is time.Now in default.
+}
+
+// Verify parses `token`, verifies its signature using the keys of option.KeySet and validates its claims.
+//
+// The key is selected by the "kid" header of the token for key rotation, and the "alg" header must be
+// the algorithm of the key, so that the token of algorithm "none" or using a public key as HMAC secret
+// is never accepted.
+func Verify(ctx context.Context, token string, option VerifyOption) (*Token, error) {
+	if option.KeySet == nil {
+		return nil, gerror.NewCode(gcode.CodeMissingParameter, `KeySet is required for token verification`)
+	}
+	t, err := Parse(token)
+	if err != nil {
+		return nil, err
+	}
+	algorithm := t.Algorithm()
+	if len(option.Algorithms) > 0 && !containsAlgorithm(option.Algorithms, algorithm) {
+		return nil, gerror.Wrapf(ErrTokenUnverifiable, `algorithm "%s" is not allowed`, algorithm)
+	}
+	keys, err := option.KeySet.Keys(ctx, t.KeyID())
+	if err != nil {
+		return nil, err
+	}
+	var (
+		verified     bool
+		signingInput = []byte(t.signingInput())
+	)
+	for _, key := range keys {
+		if key.Algorithm != algorithm {
+			continue
+		}
+		if err = verify(key, signingInput, t.Signature); err == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		if err != nil {
+			return nil, err
+		}
+		return nil, gerror.Wrapf(
+			ErrTokenUnverifiable, `no key found for algorithm "%s" and key id "%s"`, algorithm, t.KeyID(),
+		)
+	}
+	if err = validateClaims(t.Claims, option); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// validateClaims validates the registered claims of `claims` according to `option`.
+func validateClaims(claims Claims, option VerifyOption) error {
+	now := time.Now()
+	if option.Now != nil {
+		now = option.Now()
+	}
+	if expiresAt, ok := claims.ExpiresAt(); ok {
+		if !now.Before(expiresAt.Add(option.Leeway)) {
+			return gerror.Wrapf(ErrTokenExpired, `token expired at %s`, expiresAt.Format(time.RFC3339))
+		}
+	} else if option.RequireExpiration {
+		return gerror.Wrap(ErrTokenInvalidClaims, `missing "exp" claim`)
+	}
+	if notBefore, ok := claims.NotBefore(); ok && now.Add(option.Leeway).Before(notBefore) {
+		return gerror.Wrapf(ErrTokenNotValidYet, `token is valid from %s`, notBefore.Format(time.RFC3339))
+	}
+	if option.Issuer != "" && claims.Issuer() != option.Issuer {
+		return gerror.Wrapf(ErrTokenInvalidClaims, `invalid issuer "%s"`, claims.Issuer())
+	}
+	if option.Audience != "" {
+		var found bool
+		for _, audience := range claims.Audience() {
+			if audience == option.Audience {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return gerror.Wrapf(ErrTokenInvalidClaims, `audience "%s" not found`, option.Audience)
+		}
+	}
+	return nil
+}
+
+func containsAlgorithm(algorithms []Algorithm, algorithm Algorithm) bool {
+	for _, v := range algorithms {
+		if v == algorithm {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,314 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjwt_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/crypto/gjwt"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+var (
+	ctx         = context.Background()
+	hmacKey     = []byte("0123456789abcdef0123456789abcdef")
+	rsaKey, _   = rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _    = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, edKey, _ = ed25519.GenerateKey(rand.Reader)
+)
+
+func Test_Sign_Verify(t *testing.T) {
+	keys := []gjwt.Key{
+		{Algorithm: gjwt.HS256, Key: hmacKey},
+		{Algorithm: gjwt.HS384, Key: hmacKey},
+		{Algorithm: gjwt.HS512, Key: hmacKey},
+		{Algorithm: gjwt.RS256, Key: rsaKey},
+		{Algorithm: gjwt.RS512, Key: rsaKey},
+		{Algorithm: gjwt.ES256, Key: ecKey},
+		{Algorithm: gjwt.EdDSA, Key: edKey},
+	}
+	gtest.C(t, func(t *gtest.T) {
+		for _, key := range keys {
+			token, err := gjwt.Sign(gjwt.Claims{
+				"sub":  "1",
+				"name": "john",
+				"exp":  time.Now().Add(time.Hour),
+			}, key)
+			t.AssertNil(err)
+			t.Assert(strings.Count(token, "."), 2)
+
+			parsed, err := gjwt.Verify(ctx, token, gjwt.VerifyOption{KeySet: gjwt.NewKeySet(key)})
+			t.AssertNil(err)
+			t.Assert(parsed.Algorithm(), key.Algorithm)
+			t.Assert(parsed.Claims.Subject(), "1")
+			t.Assert(parsed.Claims["name"], "john")
+			expiresAt, ok := parsed.Claims.ExpiresAt()
+			t.Assert(ok, true)
+			t.Assert(expiresAt.After(time.Now()), true)
+
+			// Tampered claims.
+			parts := strings.Split(token, ".")
+			parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"2"}`))
+			_, err = gjwt.Verify(ctx, strings.Join(parts, "."), gjwt.VerifyOption{KeySet: gjwt.NewKeySet(key)})
+			t.Assert(gerror.Is(err, gjwt.ErrTokenSignatureInvalid), true)
+			t.Assert(gerror.Code(err), gcode.CodeNotAuthorized)
+		}
+	})
+	// Verifying with public keys.
+	gtest.C(t, func(t *gtest.T) {
+		pairs := [][2]gjwt.Key{
+			{{Algorithm: gjwt.RS256, Key: rsaKey}, {Algorithm: gjwt.RS256, Key: &rsaKey.PublicKey}},
+			{{Algorithm: gjwt.ES256, Key: ecKey}, {Algorithm: gjwt.ES256, Key: &ecKey.PublicKey}},
+			{{Algorithm: gjwt.EdDSA, Key: edKey}, {Algorithm: gjwt.EdDSA, Key: edKey.Public()}},
+		}
+		for _, pair := range pairs {
+			token, err := gjwt.Sign(gjwt.Claims{"sub": "1"}, pair[0])
+			t.AssertNil(err)
+			_, err = gjwt.Verify(ctx, token, gjwt.VerifyOption{KeySet: gjwt.NewKeySet(pair[1])})
+			t.AssertNil(err)
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gjwt.Sign(gjwt.Claims{}, gjwt.Key{Algorithm: gjwt.RS256, Key: hmacKey})
+		t.AssertNE(err, nil)
+		_, err = gjwt.Sign(gjwt.Claims{}, gjwt.Key{Algorithm: "none", Key: hmacKey})
+		t.AssertNE(err, nil)
+		_, err = gjwt.Sign(gjwt.Claims{}, gjwt.Key{Algorithm: gjwt.ES384, Key: ecKey})
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Verify_Algorithm(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		// Token of algorithm "none".
+		token := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." +
+			base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"1"}`)) + "."
+		_, err := gjwt.Verify(ctx, token, gjwt.VerifyOption{
+			KeySet: gjwt.NewKeySet(gjwt.Key{Algorithm: gjwt.HS256, Key: hmacKey}),
+		})
+		t.Assert(gerror.Is(err, gjwt.ErrTokenUnverifiable), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// The token signed by HS256 cannot be verified by RS256 key.
+		token, err := gjwt.Sign(gjwt.Claims{"sub": "1"}, gjwt.Key{Algorithm: gjwt.HS256, Key: hmacKey})
+		t.AssertNil(err)
+		_, err = gjwt.Verify(ctx, token, gjwt.VerifyOption{
+			KeySet: gjwt.NewKeySet(gjwt.Key{Algorithm: gjwt.RS256, Key: &rsaKey.PublicKey}),
+		})
+		t.Assert(gerror.Is(err, gjwt.ErrTokenUnverifiable), true)
+
+		_, err = gjwt.Verify(ctx, token, gjwt.VerifyOption{
+			KeySet:     gjwt.NewKeySet(gjwt.Key{Algorithm: gjwt.HS256, Key: hmacKey}),
+			Algorithms: []gjwt.Algorithm{gjwt.RS256},
+		})
+		t.Assert(gerror.Is(err, gjwt.ErrTokenUnverifiable), true)
+
+		_, err = gjwt.Verify(ctx, token, gjwt.VerifyOption{})
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		for _, token := range []string{"", "a.b", "a.b.c", "e30.e30.!!"} {
+			_, err := gjwt.Parse(token)
+			t.Assert(gerror.Is(err, gjwt.ErrTokenMalformed), true)
+		}
+	})
+}
+
+func Test_Verify_Claims(t *testing.T) {
+	var (
+		now    = time.Unix(1700000000, 0)
+		key    = gjwt.Key{Algorithm: gjwt.HS256, Key: hmacKey}
+		keySet = gjwt.NewKeySet(key)
+		sign   = func(claims gjwt.Claims) string {
+			token, _ := gjwt.Sign(claims, key)
+			return token
+		}
+		verify = func(token string, option gjwt.VerifyOption) error {
+			option.KeySet = keySet
+			option.Now = func() time.Time { return now }
+			_, err := gjwt.Verify(ctx, token, option)
+			return err
+		}
+	)
+	gtest.C(t, func(t *gtest.T) {
+		token := sign(gjwt.Claims{"exp": now.Add(-time.Second)})
+		t.Assert(gerror.Is(verify(token, gjwt.VerifyOption{}), gjwt.ErrTokenExpired), true)
+		t.AssertNil(verify(token, gjwt.VerifyOption{Leeway: time.Minute}))
+
+		token = sign(gjwt.Claims{"nbf": now.Add(time.Second).Unix()})
+		t.Assert(gerror.Is(verify(token, gjwt.VerifyOption{}), gjwt.ErrTokenNotValidYet), true)
+		t.AssertNil(verify(token, gjwt.VerifyOption{Leeway: time.Minute}))
+
+		token = sign(gjwt.Claims{"sub": "1"})
+		t.AssertNil(verify(token, gjwt.VerifyOption{}))
+		t.Assert(gerror.Is(verify(token, gjwt.VerifyOption{RequireExpiration: true}), gjwt.ErrTokenInvalidClaims), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		token := sign(gjwt.Claims{"iss": "gf", "aud": []string{"web", "app"}})
+		t.AssertNil(verify(token, gjwt.VerifyOption{Issuer: "gf", Audience: "app"}))
+		t.Assert(gerror.Is(verify(token, gjwt.VerifyOption{Issuer: "other"}), gjwt.ErrTokenInvalidClaims), true)
+		t.Assert(gerror.Is(verify(token, gjwt.VerifyOption{Audience: "cli"}), gjwt.ErrTokenInvalidClaims), true)
+
+		token = sign(gjwt.Claims{"aud": "web"})
+		t.AssertNil(verify(token, gjwt.VerifyOption{Audience: "web"}))
+	})
+	gtest.C(t, func(t *gtest.T) {
+		token := sign(gjwt.Claims{"iat": now, "jti": "abc"})
+		parsed, err := gjwt.Parse(token)
+		t.AssertNil(err)
+		issuedAt, ok := parsed.Claims.IssuedAt()
+		t.Assert(ok, true)
+		t.Assert(issuedAt.Unix(), now.Unix())
+		t.Assert(parsed.Claims.ID(), "abc")
+		_, ok = parsed.Claims.NotBefore()
+		t.Assert(ok, false)
+		t.Assert(parsed.Header["typ"], "JWT")
+	})
+}
+
+func Test_KeyRotation(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			oldKey = gjwt.Key{ID: "2023", Algorithm: gjwt.HS256, Key: []byte("old-secret")}
+			newKey = gjwt.Key{ID: "2024", Algorithm: gjwt.HS256, Key: []byte("new-secret")}
+			keySet = gjwt.NewKeySet(oldKey, newKey)
+		)
+		oldToken, err := gjwt.Sign(gjwt.Claims{"sub": "1"}, oldKey)
+		t.AssertNil(err)
+		newToken, err := gjwt.Sign(gjwt.Claims{"sub": "2"}, newKey, map[string]interface{}{"cty": "JWT"})
+		t.AssertNil(err)
+
+		parsed, err := gjwt.Verify(ctx, oldToken, gjwt.VerifyOption{KeySet: keySet})
+		t.AssertNil(err)
+		t.Assert(parsed.KeyID(), "2023")
+		parsed, err = gjwt.Verify(ctx, newToken, gjwt.VerifyOption{KeySet: keySet})
+		t.AssertNil(err)
+		t.Assert(parsed.KeyID(), "2024")
+		t.Assert(parsed.Header["cty"], "JWT")
+
+		_, err = gjwt.Verify(ctx, newToken, gjwt.VerifyOption{KeySet: gjwt.NewKeySet(oldKey)})
+		t.Assert(gerror.Is(err, gjwt.ErrTokenUnverifiable), true)
+	})
+}
+
+func Test_JWKS(t *testing.T) {
+	var (
+		requests int32
+		kid      atomic.Value
+	)
+	kid.Store("k1")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		encode := func(i *big.Int) string {
+			return base64.RawURLEncoding.EncodeToString(i.Bytes())
+		}
+		fmt.Fprintf(w, `{"keys":[
+			{"kty":"RSA","kid":"%s","use":"sig","n":"%s","e":"AQAB"},
+			{"kty":"EC","kid":"ec","crv":"P-256","x":"%s","y":"%s"},
+			{"kty":"OKP","kid":"ed","crv":"Ed25519","x":"%s"},
+			{"kty":"RSA","kid":"enc","use":"enc","n":"%s","e":"AQAB"},
+			{"kty":"unknown","kid":"unknown"}
+		]}`,
+			kid.Load(), encode(rsaKey.N), encode(ecKey.X), encode(ecKey.Y),
+			base64.RawURLEncoding.EncodeToString(edKey.Public().(ed25519.PublicKey)), encode(rsaKey.N),
+		)
+	}))
+	defer server.Close()
+
+	gtest.C(t, func(t *gtest.T) {
+		jwks := gjwt.NewJWKS(server.URL, gjwt.JWKSOption{MinRefreshInterval: time.Millisecond})
+		keys, err := jwks.Keys(ctx, "")
+		t.AssertNil(err)
+		t.Assert(len(keys), 3)
+		t.Assert(keys[1].Algorithm, gjwt.ES256)
+		t.Assert(keys[2].Algorithm, gjwt.EdDSA)
+
+		token, err := gjwt.Sign(gjwt.Claims{"sub": "1"}, gjwt.Key{ID: "k1", Algorithm: gjwt.RS256, Key: rsaKey})
+		t.AssertNil(err)
+		_, err = gjwt.Verify(ctx, token, gjwt.VerifyOption{KeySet: jwks})
+		t.AssertNil(err)
+		t.Assert(atomic.LoadInt32(&requests), 1)
+
+		token, err = gjwt.Sign(gjwt.Claims{"sub": "1"}, gjwt.Key{ID: "ec", Algorithm: gjwt.ES256, Key: ecKey})
+		t.AssertNil(err)
+		_, err = gjwt.Verify(ctx, token, gjwt.VerifyOption{KeySet: jwks})
+		t.AssertNil(err)
+		t.Assert(atomic.LoadInt32(&requests), 1)
+
+		// The remote keys are rotated, and the unknown key id triggers refreshing.
+		kid.Store("k2")
+		time.Sleep(5 * time.Millisecond)
+		token, err = gjwt.Sign(gjwt.Claims{"sub": "1"}, gjwt.Key{ID: "k2", Algorithm: gjwt.RS256, Key: rsaKey})
+		t.AssertNil(err)
+		_, err = gjwt.Verify(ctx, token, gjwt.VerifyOption{KeySet: jwks})
+		t.AssertNil(err)
+		t.Assert(atomic.LoadInt32(&requests), 2)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		jwks := gjwt.NewJWKS(server.URL + "/404\x00")
+		_, err := jwks.Keys(ctx, "")
+		t.AssertNE(err, nil)
+		t.AssertNE(jwks.Refresh(ctx), nil)
+
+		_, err = gjwt.ParseJWKS([]byte(`{"keys":[{"kty":"RSA","n":"!!","e":"AQAB"}]}`))
+		t.AssertNE(err, nil)
+		_, err = gjwt.ParseJWKS([]byte(`invalid`))
+		t.AssertNE(err, nil)
+		keys, err := gjwt.ParseJWKS([]byte(`{"keys":[{"kty":"oct","kid":"h","k":"c2VjcmV0"}]}`))
+		t.AssertNil(err)
+		t.Assert(keys[0].Algorithm, gjwt.HS256)
+		t.Assert(keys[0].Key, []byte("secret"))
+	})
+}
+
+func Test_EncodeJWKS(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		keys := []gjwt.Key{
+			{ID: "rsa", Algorithm: gjwt.RS256, Key: rsaKey},
+			{ID: "ec", Algorithm: gjwt.ES256, Key: &ecKey.PublicKey},
+			{ID: "ed", Algorithm: gjwt.EdDSA, Key: edKey},
+			{ID: "hmac", Algorithm: gjwt.HS256, Key: hmacKey},
+		}
+		data, err := gjwt.EncodeJWKS(keys...)
+		t.AssertNil(err)
+		t.Assert(strings.Contains(string(data), `"hmac"`), false)
+		t.Assert(strings.Contains(string(data), `"d"`), false)
+
+		parsed, err := gjwt.ParseJWKS(data)
+		t.AssertNil(err)
+		t.Assert(len(parsed), 3)
+		for i, key := range keys[:3] {
+			t.Assert(parsed[i].ID, key.ID)
+			t.Assert(parsed[i].Algorithm, key.Algorithm)
+			token, err := gjwt.Sign(gjwt.Claims{"sub": "1"}, gjwt.Key{
+				ID: key.ID, Algorithm: key.Algorithm, Key: []interface{}{rsaKey, ecKey, edKey}[i],
+			})
+			t.AssertNil(err)
+			_, err = gjwt.Verify(ctx, token, gjwt.VerifyOption{KeySet: gjwt.NewKeySet(parsed...)})
+			t.AssertNil(err)
+		}
+
+		_, err = gjwt.EncodeJWKS(gjwt.Key{ID: "invalid", Algorithm: gjwt.RS256, Key: "invalid"})
+		t.AssertNE(err, nil)
+	})
+}
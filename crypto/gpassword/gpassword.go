@@ -0,0 +1,370 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gpassword provides password hashing and verification using argon2id, scrypt and bcrypt.
+//
+// The algorithm and its parameters are embedded in the encoded hash, so that the hashes produced
+// with different options can be verified together, and NeedsRehash tells whether a hash should be
+// upgraded to current option after successful login. The legacy unsalted MD5/SHA1 hex hashes can also
+// be verified for migration, which always need rehashing.
+//
+// The encoded formats:
+//
+//	argon2id: $argon2id$v=19$m=65536,t=1,p=4$<base64 salt>$<base64 key>
+//	scrypt:   $scrypt$ln=15,r=8,p=1$<base64 salt>$<base64 key>
+//	bcrypt:   $2a$10$<22 chars salt><31 chars hash>
+package gpassword
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/v2/crypto/gmd5"
+	"github.com/gogf/gf/v2/crypto/gsha1"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Algorithm is the password hashing algorithm.
+type Algorithm string
+
+const (
+	Argon2id Algorithm = "argon2id" // Argon2id, which is the default and recommended algorithm.
+	Scrypt   Algorithm = "scrypt"   // Scrypt.
+	Bcrypt   Algorithm = "bcrypt"   // Bcrypt, note that only the first 72 bytes of password are allowed.
+	MD5      Algorithm = "md5"      // Legacy unsalted MD5 hex hash, which is for verification only.
+	SHA1     Algorithm = "sha1"     // Legacy unsalted SHA1 hex hash, which is for verification only.
+)
+
+// Option is the option for password hashing, in which the zero attributes use the default values.
+type Option struct {
+	Algorithm     Algorithm // Hashing algorithm, which is Argon2id in default.
+	Argon2Time    uint32    // Count of passes for argon2id up to 16, which is 1 in default.
+	Argon2Memory  uint32    // Memory size in KiB for argon2id up to 1 GiB, which is 64*1024 in default.
+	Argon2Threads uint8     // Parallelism for argon2id up to 64, which is 4 in default.
+	ScryptN       int       // CPU/memory cost for scrypt that must be power of 2 and 128*N*r up to 1 GiB, which is 32768 in default.
+	ScryptR       int       // Block size for scrypt, which is 8 in default.
+	ScryptP       int       // Parallelism for scrypt up to 16, which is 1 in default.
+	BcryptCost    int       // Cost for bcrypt in range [4, 31], which is 10 in default.
+	SaltLength    int       // Salt length in bytes for argon2id and scrypt, which is 16 in default.
+	KeyLength     int       // Derived key length in bytes for argon2id and scrypt, which is 32 in default.
+}
+
+const (
+	defaultArgon2Time    = 1
+	defaultArgon2Memory  = 64 * 1024
+	defaultArgon2Threads = 4
+	defaultScryptN       = 1 << 15
+	defaultScryptR       = 8
+	defaultScryptP       = 1
+	defaultBcryptCost    = 10
+	defaultSaltLength    = 16
+	defaultKeyLength     = 32
+)
+
+// Hash hashes `password` with optional `option` and returns the encoded hash,
+// which contains the algorithm, parameters and random salt.
+func Hash(password string, option ...Option) (string, error) {
+	opt := getOption(option...)
+	switch opt.Algorithm {
+	case Argon2id:
+		salt, err := randomBytes(opt.SaltLength)
+		if err != nil {
+			return "", err
+		}
+		key, err := argon2id(
+			[]byte(password), salt, opt.Argon2Time, opt.Argon2Memory, opt.Argon2Threads, uint32(opt.KeyLength),
+		)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(
+			`$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s`,
+			argon2Version, opt.Argon2Memory, opt.Argon2Time, opt.Argon2Threads,
+			base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key),
+		), nil
+
+	case Scrypt:
+		salt, err := randomBytes(opt.SaltLength)
+		if err != nil {
+			return "", err
+		}
+		key, err := scryptKey([]byte(password), salt, opt.ScryptN, opt.ScryptR, opt.ScryptP, opt.KeyLength)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf(
+			`$scrypt$ln=%d,r=%d,p=%d$%s$%s`,
+			bits.TrailingZeros(uint(opt.ScryptN)), opt.ScryptR, opt.ScryptP,
+			base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key),
+		), nil
+
+	case Bcrypt:
+		return hashBcrypt([]byte(password), opt.BcryptCost)
+
+	default:
+		return "", gerror.NewCodef(gcode.CodeNotSupported, `unsupported hashing algorithm "%s"`, opt.Algorithm)
+	}
+}
+
+// MustHash performs as Hash, but it panics if any error occurs.
+func MustHash(password string, option ...Option) string {
+	hash, err := Hash(password, option...)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+}
+
+// Verify checks whether `password` matches the encoded `hash`, which can be produced by Hash,
+// or a legacy unsalted MD5/SHA1 hex hash. It returns an error if `hash` is malformed, or the argon2id/scrypt
+// parameters of `hash` exceed the upper bounds, which prevents crafted hashes from exhausting memory or CPU.
+func Verify(password string, hash string) (bool, error) {
+	parsed, err := parseHash(hash)
+	if err != nil {
+		return false, err
+	}
+	var computed []byte
+	switch parsed.algorithm {
+	case Argon2id:
+		computed, err = argon2id(
+			[]byte(password), parsed.salt, parsed.argon2Time, parsed.argon2Memory, parsed.argon2Threads,
+			uint32(len(parsed.key)),
+		)
+
+	case Scrypt:
+		computed, err = scryptKey([]byte(password), parsed.salt, parsed.scryptN, parsed.scryptR, parsed.scryptP, len(parsed.key))
+
+	case Bcrypt:
+		return verifyBcrypt([]byte(password), hash)
+
+	case MD5:
+		var encrypted string
+		encrypted, err = gmd5.EncryptString(password)
+		computed = []byte(encrypted)
+
+	case SHA1:
+		computed = []byte(gsha1.Encrypt(password))
+	}
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare(computed, parsed.key) == 1, nil
+}
+
+// NeedsRehash checks whether the encoded `hash` should be rehashed with `option`, which returns true if
+// the algorithm or parameters of `hash` differ from `option`, or `hash` is a legacy or malformed hash.
+// It is usually called after successful verification, when the plain password is available for rehashing.
+func NeedsRehash(hash string, option ...Option) bool {
+	parsed, err := parseHash(hash)
+	if err != nil {
+		return true
+	}
+	opt := getOption(option...)
+	if parsed.algorithm != opt.Algorithm {
+		return true
+	}
+	switch parsed.algorithm {
+	case Argon2id:
+		return parsed.argon2Time != opt.Argon2Time ||
+			parsed.argon2Memory != opt.Argon2Memory ||
+			parsed.argon2Threads != opt.Argon2Threads ||
+			len(parsed.key) != opt.KeyLength
+	case Scrypt:
+		return parsed.scryptN != opt.ScryptN ||
+			parsed.scryptR != opt.ScryptR ||
+			parsed.scryptP != opt.ScryptP ||
+			len(parsed.key) != opt.KeyLength
+	case Bcrypt:
+		return parsed.bcrypt.cost != opt.BcryptCost
+	}
+	return true
+}
+
+// VerifyAndRehash verifies `password` against `hash`, and returns a new hash of `password` with `option`
+// if it matches and NeedsRehash, which is used for migrating hashes transparently on login.
+// The returned `newHash` is empty if the password does not match or no rehash is needed.
+func VerifyAndRehash(password string, hash string, option ...Option) (ok bool, newHash string, err error) {
+	if ok, err = Verify(password, hash); err != nil || !ok {
+		return
+	}
+	if NeedsRehash(hash, option...) {
+		newHash, err = Hash(password, option...)
+	}
+	return
+}
+
+// Identify returns the algorithm of the encoded `hash`, it returns empty string if `hash` is unrecognized.
+func Identify(hash string) Algorithm {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		return Argon2id
+	case strings.HasPrefix(hash, "$scrypt$"):
+		return Scrypt
+	case isBcryptHash(hash):
+		return Bcrypt
+	case len(hash) == 32 && isHex(hash):
+		return MD5
+	case len(hash) == 40 && isHex(hash):
+		return SHA1
+	}
+	return ""
+}
+
+// parsedHash is the parsed encoded hash.
+type parsedHash struct {
+	algorithm     Algorithm
+	salt          []byte
+	key           []byte // Derived key, or the lower case hex hash for legacy hashes.
+	argon2Time    uint32
+	argon2Memory  uint32
+	argon2Threads uint8
+	scryptN       int
+	scryptR       int
+	scryptP       int
+	bcrypt        *bcryptHash
+}
+
+func parseHash(hash string) (*parsedHash, error) {
+	parsed := &parsedHash{algorithm: Identify(hash)}
+	switch parsed.algorithm {
+	case Argon2id:
+		// $argon2id$v=19$m=65536,t=1,p=4$salt$key
+		parts := strings.Split(hash, "$")
+		if len(parts) != 6 || parts[2] != fmt.Sprintf(`v=%d`, argon2Version) {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid argon2id hash`)
+		}
+		params, err := parseParams(parts[3], "m", "t", "p")
+		if err != nil || params[2] > 255 {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid argon2id hash parameters`)
+		}
+		parsed.argon2Memory, parsed.argon2Time, parsed.argon2Threads = uint32(params[0]), uint32(params[1]), uint8(params[2])
+		if parsed.salt, parsed.key, err = decodeSaltAndKey(parts[4], parts[5]); err != nil {
+			return nil, err
+		}
+
+	case Scrypt:
+		// $scrypt$ln=15,r=8,p=1$salt$key
+		parts := strings.Split(hash, "$")
+		if len(parts) != 5 {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid scrypt hash`)
+		}
+		params, err := parseParams(parts[2], "ln", "r", "p")
+		if err != nil || params[0] < 1 || params[0] > 62 {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid scrypt hash parameters`)
+		}
+		parsed.scryptN, parsed.scryptR, parsed.scryptP = 1<<uint(params[0]), int(params[1]), int(params[2])
+		if parsed.salt, parsed.key, err = decodeSaltAndKey(parts[3], parts[4]); err != nil {
+			return nil, err
+		}
+
+	case Bcrypt:
+		bcrypt, err := parseBcrypt(hash)
+		if err != nil {
+			return nil, err
+		}
+		parsed.bcrypt = bcrypt
+
+	case MD5, SHA1:
+		parsed.key = []byte(strings.ToLower(hash))
+
+	default:
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `unrecognized password hash`)
+	}
+	return parsed, nil
+}
+
+// parseParams parses the parameters like "m=65536,t=1,p=4" in given order of `names`.
+func parseParams(s string, names ...string) ([]int64, error) {
+	items := strings.Split(s, ",")
+	if len(items) != len(names) {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid parameters "%s"`, s)
+	}
+	values := make([]int64, len(names))
+	for i, item := range items {
+		name, value, ok := strings.Cut(item, "=")
+		if !ok || name != names[i] {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid parameters "%s"`, s)
+		}
+		v, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || v <= 0 {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid parameters "%s"`, s)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func decodeSaltAndKey(encodedSalt, encodedKey string) (salt, key []byte, err error) {
+	if salt, err = base64.RawStdEncoding.DecodeString(encodedSalt); err != nil {
+		return nil, nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid salt of hash`)
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(encodedKey); err != nil || len(key) < 4 {
+		return nil, nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid key of hash`)
+	}
+	return
+}
+
+func getOption(option ...Option) Option {
+	var opt Option
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Algorithm == "" {
+		opt.Algorithm = Argon2id
+	}
+	if opt.Argon2Time == 0 {
+		opt.Argon2Time = defaultArgon2Time
+	}
+	if opt.Argon2Memory == 0 {
+		opt.Argon2Memory = defaultArgon2Memory
+	}
+	if opt.Argon2Threads == 0 {
+		opt.Argon2Threads = defaultArgon2Threads
+	}
+	if opt.ScryptN == 0 {
+		opt.ScryptN = defaultScryptN
+	}
+	if opt.ScryptR == 0 {
+		opt.ScryptR = defaultScryptR
+	}
+	if opt.ScryptP == 0 {
+		opt.ScryptP = defaultScryptP
+	}
+	if opt.BcryptCost == 0 {
+		opt.BcryptCost = defaultBcryptCost
+	}
+	if opt.SaltLength == 0 {
+		opt.SaltLength = defaultSaltLength
+	}
+	if opt.KeyLength == 0 {
+		opt.KeyLength = defaultKeyLength
+	}
+	return opt
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, `rand.Read failed`)
+	}
+	return b, nil
+}
+
+func isHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
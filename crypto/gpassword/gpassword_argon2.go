@@ -0,0 +1,39 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gpassword
+
+import (
+	"golang.org/x/crypto/argon2"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	argon2Version = argon2.Version // Argon2 version 1.3.
+
+	// The upper bounds of argon2id parameters, which prevent the crafted hashes
+	// from exhausting memory or CPU in verification.
+	argon2MaxTime    = 16
+	argon2MaxMemory  = 1024 * 1024 // 1 GiB in KiB.
+	argon2MaxThreads = 64
+	argon2MaxKeyLen  = 1024
+)
+
+// argon2id derives a key of `keyLen` bytes from `password` and `salt` using Argon2id defined in RFC 9106,
+// in which `time` is the count of passes, `memory` is the memory size in KiB and `threads` is the parallelism.
+func argon2id(password, salt []byte, time, memory uint32, threads uint8, keyLen uint32) ([]byte, error) {
+	if time < 1 || time > argon2MaxTime ||
+		threads < 1 || threads > argon2MaxThreads ||
+		memory < 8*uint32(threads) || memory > argon2MaxMemory ||
+		keyLen < 4 || keyLen > argon2MaxKeyLen {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter, `invalid argon2id parameters m=%d t=%d p=%d`, memory, time, threads,
+		)
+	}
+	return argon2.IDKey(password, salt, time, memory, threads, keyLen), nil
+}
@@ -0,0 +1,94 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gpassword
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	bcryptMinCost        = 4
+	bcryptMaxCost        = 31
+	bcryptMaxPasswordLen = 72
+)
+
+// bcryptHash is the parsed bcrypt hash in format "$2a$10$<22 chars salt><31 chars hash>".
+type bcryptHash struct {
+	version string
+	cost    int
+}
+
+func hashBcrypt(password []byte, cost int) (string, error) {
+	if cost < bcryptMinCost || cost > bcryptMaxCost {
+		return "", gerror.NewCodef(
+			gcode.CodeInvalidParameter, `invalid bcrypt cost %d, should be in [%d, %d]`, cost, bcryptMinCost, bcryptMaxCost,
+		)
+	}
+	if err := checkBcryptPassword(password); err != nil {
+		return "", err
+	}
+	hash, err := bcrypt.GenerateFromPassword(password, cost)
+	if err != nil {
+		return "", gerror.WrapCode(gcode.CodeInternalError, err, `bcrypt.GenerateFromPassword failed`)
+	}
+	return string(hash), nil
+}
+
+// verifyBcrypt checks whether `password` matches the encoded bcrypt `hash`.
+func verifyBcrypt(password []byte, hash string) (bool, error) {
+	if err := checkBcryptPassword(password); err != nil {
+		return false, err
+	}
+	err := bcrypt.CompareHashAndPassword([]byte(hash), password)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+		return false, nil
+	default:
+		return false, gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid bcrypt hash`)
+	}
+}
+
+func checkBcryptPassword(password []byte) error {
+	if len(password) > bcryptMaxPasswordLen {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter, `password length should not exceed %d bytes for bcrypt`, bcryptMaxPasswordLen,
+		)
+	}
+	return nil
+}
+
+func parseBcrypt(encoded string) (*bcryptHash, error) {
+	// $2a$10$ + 22 chars salt + 31 chars hash.
+	if len(encoded) != 60 || encoded[0] != '$' || encoded[3] != '$' || encoded[6] != '$' {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `invalid bcrypt hash`)
+	}
+	h := &bcryptHash{version: encoded[1:3]}
+	switch h.version {
+	case "2a", "2b", "2y":
+	default:
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `unsupported bcrypt version "%s"`, h.version)
+	}
+	cost, err := strconv.Atoi(encoded[4:6])
+	if err != nil || cost < bcryptMinCost || cost > bcryptMaxCost {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid bcrypt cost "%s"`, encoded[4:6])
+	}
+	h.cost = cost
+	return h, nil
+}
+
+func isBcryptHash(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
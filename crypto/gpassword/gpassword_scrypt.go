@@ -0,0 +1,38 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gpassword
+
+import (
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// The upper bounds of scrypt parameters, which prevent the crafted hashes
+	// from exhausting memory or CPU in verification.
+	scryptMaxMemory = 1 << 30 // Maximum memory of 128*N*r in bytes.
+	scryptMaxP      = 16
+	scryptMaxKeyLen = 1024
+)
+
+// scryptKey derives a key of `keyLen` bytes from `password` and `salt` using scrypt defined in RFC 7914,
+// in which `n` is the CPU/memory cost that must be power of 2, `r` is the block size and `p` is the parallelism.
+func scryptKey(password, salt []byte, n, r, p, keyLen int) ([]byte, error) {
+	if n <= 1 || n&(n-1) != 0 {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `scrypt N should be power of 2 greater than 1, but given %d`, n)
+	}
+	if r <= 0 || p <= 0 || p > scryptMaxP || keyLen > scryptMaxKeyLen || n > scryptMaxMemory/128/r {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid scrypt parameters N=%d r=%d p=%d`, n, r, p)
+	}
+	key, err := scrypt.Key(password, salt, n, r, p, keyLen)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `scrypt.Key failed`)
+	}
+	return key, nil
+}
@@ -0,0 +1,184 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gpassword_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/crypto/gpassword"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+var (
+	// Cheap options for testing.
+	argon2Option = gpassword.Option{Argon2Memory: 64, Argon2Threads: 1}
+	scryptOption = gpassword.Option{Algorithm: gpassword.Scrypt, ScryptN: 1024}
+	bcryptOption = gpassword.Option{Algorithm: gpassword.Bcrypt, BcryptCost: 4}
+)
+
+func Test_Hash_Verify(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		for _, option := range []gpassword.Option{argon2Option, scryptOption, bcryptOption} {
+			hash, err := gpassword.Hash("123456", option)
+			t.AssertNil(err)
+
+			ok, err := gpassword.Verify("123456", hash)
+			t.AssertNil(err)
+			t.Assert(ok, true)
+
+			ok, err = gpassword.Verify("1234567", hash)
+			t.AssertNil(err)
+			t.Assert(ok, false)
+
+			// Random salt for each hashing.
+			hash2, err := gpassword.Hash("123456", option)
+			t.AssertNil(err)
+			t.AssertNE(hash2, hash)
+			t.Assert(gpassword.NeedsRehash(hash, option), false)
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		hash, err := gpassword.Hash("123456", argon2Option)
+		t.AssertNil(err)
+		t.Assert(strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"), true)
+		t.Assert(gpassword.Identify(hash), gpassword.Argon2id)
+
+		hash, err = gpassword.Hash("123456", scryptOption)
+		t.AssertNil(err)
+		t.Assert(strings.HasPrefix(hash, "$scrypt$ln=10,r=8,p=1$"), true)
+		t.Assert(gpassword.Identify(hash), gpassword.Scrypt)
+
+		hash, err = gpassword.Hash("123456", bcryptOption)
+		t.AssertNil(err)
+		t.Assert(strings.HasPrefix(hash, "$2a$04$"), true)
+		t.Assert(len(hash), 60)
+		t.Assert(gpassword.Identify(hash), gpassword.Bcrypt)
+	})
+}
+
+func Test_Verify_Vectors(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		hashes := []string{
+			// Argon2id verified with the reference implementation.
+			"$argon2id$v=19$m=64,t=1,p=1$c29tZXNhbHQxMjM0NTY3OA$KovyxkqWI8NiIbnVTsHJGtWda07axUqoJVIuPotqICc",
+			// Scrypt test vector of RFC 7914.
+			"$scrypt$ln=10,r=8,p=16$TmFDbA$/bq+HJ00cgB4VucZDQHp/nxq18vII3gw53N2Y0s3MWIurzDZLiKjiG/xCSedmDDaxyevuUqD7m2DYMvfoswGQA",
+			// Bcrypt test vectors of OpenWall.
+			"$2a$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW",
+		}
+		passwords := []string{"password", "password", "U*U"}
+		for i, hash := range hashes {
+			ok, err := gpassword.Verify(passwords[i], hash)
+			t.AssertNil(err)
+			t.Assert(ok, true)
+		}
+		ok, err := gpassword.Verify("", "$2b$05$CCCCCCCCCCCCCCCCCCCCC.7uG0VCzI2bS7j6ymqJi9CdcdxiRTWNy")
+		t.AssertNil(err)
+		t.Assert(ok, true)
+	})
+}
+
+func Test_Verify_Legacy(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			md5Hash  = "e10adc3949ba59abbe56e057f20f883e"
+			sha1Hash = "7c4a8d09ca3762af61e59520943dc26494f8941b"
+		)
+		t.Assert(gpassword.Identify(md5Hash), gpassword.MD5)
+		t.Assert(gpassword.Identify(sha1Hash), gpassword.SHA1)
+		t.Assert(gpassword.Identify(strings.ToUpper(md5Hash)), gpassword.MD5)
+
+		for _, hash := range []string{md5Hash, sha1Hash, strings.ToUpper(sha1Hash)} {
+			ok, err := gpassword.Verify("123456", hash)
+			t.AssertNil(err)
+			t.Assert(ok, true)
+			ok, err = gpassword.Verify("654321", hash)
+			t.AssertNil(err)
+			t.Assert(ok, false)
+			t.Assert(gpassword.NeedsRehash(hash), true)
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		ok, newHash, err := gpassword.VerifyAndRehash("123456", "e10adc3949ba59abbe56e057f20f883e", bcryptOption)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+		t.Assert(gpassword.Identify(newHash), gpassword.Bcrypt)
+
+		ok, newHash2, err := gpassword.VerifyAndRehash("123456", newHash, bcryptOption)
+		t.AssertNil(err)
+		t.Assert(ok, true)
+		t.Assert(newHash2, "")
+
+		ok, newHash, err = gpassword.VerifyAndRehash("654321", "e10adc3949ba59abbe56e057f20f883e", bcryptOption)
+		t.AssertNil(err)
+		t.Assert(ok, false)
+		t.Assert(newHash, "")
+	})
+}
+
+func Test_NeedsRehash(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		hash, err := gpassword.Hash("123456", argon2Option)
+		t.AssertNil(err)
+		t.Assert(gpassword.NeedsRehash(hash, argon2Option), false)
+		t.Assert(gpassword.NeedsRehash(hash), true)
+		t.Assert(gpassword.NeedsRehash(hash, gpassword.Option{Argon2Memory: 64, Argon2Threads: 1, Argon2Time: 2}), true)
+		t.Assert(gpassword.NeedsRehash(hash, bcryptOption), true)
+
+		hash, err = gpassword.Hash("123456", bcryptOption)
+		t.AssertNil(err)
+		t.Assert(gpassword.NeedsRehash(hash, bcryptOption), false)
+		t.Assert(gpassword.NeedsRehash(hash, gpassword.Option{Algorithm: gpassword.Bcrypt, BcryptCost: 5}), true)
+
+		t.Assert(gpassword.NeedsRehash("invalid"), true)
+	})
+}
+
+func Test_Errors(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gpassword.Hash("123456", gpassword.Option{Algorithm: gpassword.MD5})
+		t.AssertNE(err, nil)
+		_, err = gpassword.Hash("123456", gpassword.Option{Algorithm: gpassword.Bcrypt, BcryptCost: 3})
+		t.AssertNE(err, nil)
+		_, err = gpassword.Hash(strings.Repeat("a", 73), bcryptOption)
+		t.AssertNE(err, nil)
+		_, err = gpassword.Hash("123456", gpassword.Option{Algorithm: gpassword.Scrypt, ScryptN: 1000})
+		t.AssertNE(err, nil)
+		_, err = gpassword.Hash("123456", gpassword.Option{Argon2Memory: 4, Argon2Threads: 1})
+		t.AssertNE(err, nil)
+
+		defer func() {
+			t.AssertNE(recover(), nil)
+		}()
+		gpassword.MustHash("123456", gpassword.Option{Algorithm: "unknown"})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		for _, hash := range []string{
+			"",
+			"invalid",
+			"$argon2id$v=16$m=64,t=1,p=1$c29tZXNhbHQ$c29tZXNhbHQ",
+			"$argon2id$v=19$m=64,t=1$c29tZXNhbHQ$c29tZXNhbHQ",
+			"$argon2id$v=19$m=64,t=1,p=1$!!$c29tZXNhbHQ",
+			"$scrypt$ln=10,r=8$c29tZXNhbHQ$c29tZXNhbHQ",
+			"$scrypt$ln=0,r=8,p=1$c29tZXNhbHQ$c29tZXNhbHQ",
+			"$2a$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOe",
+			"$2x$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW",
+			"$2a$99$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW",
+			// Crafted hashes with huge parameters.
+			"$argon2id$v=19$m=2147483647,t=1,p=1$c29tZXNhbHQ$c29tZXNhbHQ",
+			"$argon2id$v=19$m=64,t=2147483647,p=1$c29tZXNhbHQ$c29tZXNhbHQ",
+			"$argon2id$v=19$m=65536,t=1,p=255$c29tZXNhbHQ$c29tZXNhbHQ",
+			"$scrypt$ln=30,r=8,p=1$c29tZXNhbHQ$c29tZXNhbHQ",
+			"$scrypt$ln=10,r=8,p=1000000$c29tZXNhbHQ$c29tZXNhbHQ",
+		} {
+			ok, err := gpassword.Verify("password", hash)
+			t.AssertNE(err, nil)
+			t.Assert(ok, false)
+		}
+	})
+}
@@ -0,0 +1,227 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package grsa provides key generation and PEM utilities for RSA, ECDSA and Ed25519 keys,
+// and creation of CSRs and self-signed certificates.
+package grsa
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Format is the encoding format of keys in PEM.
+type Format string
+
+const (
+	FormatPKCS8 Format = "PKCS8" // PKCS#8 "PRIVATE KEY" for all private keys, which is the default private key format.
+	FormatPKCS1 Format = "PKCS1" // PKCS#1 "RSA PRIVATE KEY" and "RSA PUBLIC KEY" for RSA keys only.
+	FormatSEC1  Format = "SEC1"  // SEC 1 "EC PRIVATE KEY" for ECDSA private keys only.
+	FormatPKIX  Format = "PKIX"  // PKIX "PUBLIC KEY" for all public keys, which is the default public key format.
+)
+
+// PEM block types.
+const (
+	pemTypePrivateKey     = "PRIVATE KEY"
+	pemTypeRSAPrivateKey  = "RSA PRIVATE KEY"
+	pemTypeECPrivateKey   = "EC PRIVATE KEY"
+	pemTypePublicKey      = "PUBLIC KEY"
+	pemTypeRSAPublicKey   = "RSA PUBLIC KEY"
+	pemTypeCertificate    = "CERTIFICATE"
+	pemTypeCertificateCSR = "CERTIFICATE REQUEST"
+)
+
+const defaultRSABits = 2048
+
+// GenerateRSAKey generates and returns a RSA private key of `bits` size, which is 2048 in default.
+func GenerateRSAKey(bits ...int) (*rsa.PrivateKey, error) {
+	size := defaultRSABits
+	if len(bits) > 0 && bits[0] > 0 {
+		size = bits[0]
+	}
+	key, err := rsa.GenerateKey(rand.Reader, size)
+	if err != nil {
+		err = gerror.WrapCodef(gcode.CodeInternalError, err, `rsa.GenerateKey failed for bits %d`, size)
+	}
+	return key, err
+}
+
+// GenerateECDSAKey generates and returns an ECDSA private key of `curve`, which is elliptic.P256 in default.
+func GenerateECDSAKey(curve ...elliptic.Curve) (*ecdsa.PrivateKey, error) {
+	c := elliptic.P256()
+	if len(curve) > 0 && curve[0] != nil {
+		c = curve[0]
+	}
+	key, err := ecdsa.GenerateKey(c, rand.Reader)
+	if err != nil {
+		err = gerror.WrapCodef(gcode.CodeInternalError, err, `ecdsa.GenerateKey failed for curve "%s"`, c.Params().Name)
+	}
+	return key, err
+}
+
+// GenerateEd25519Key generates and returns an Ed25519 private key.
+// Its public key can be retrieved by its Public method.
+func GenerateEd25519Key() (ed25519.PrivateKey, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		err = gerror.WrapCode(gcode.CodeInternalError, err, `ed25519.GenerateKey failed`)
+	}
+	return key, err
+}
+
+// MarshalPrivateKeyPEM marshals the RSA, ECDSA or Ed25519 private `key` to PEM in `format`,
+// which is FormatPKCS8 in default.
+func MarshalPrivateKeyPEM(key crypto.PrivateKey, format ...Format) ([]byte, error) {
+	var (
+		der       []byte
+		blockType string
+		err       error
+	)
+	switch getFormat(FormatPKCS8, format...) {
+	case FormatPKCS8:
+		blockType = pemTypePrivateKey
+		der, err = x509.MarshalPKCS8PrivateKey(key)
+
+	case FormatPKCS1:
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `PKCS1 format requires RSA key, but given "%T"`, key)
+		}
+		blockType = pemTypeRSAPrivateKey
+		der = x509.MarshalPKCS1PrivateKey(rsaKey)
+
+	case FormatSEC1:
+		ecKey, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `SEC1 format requires ECDSA key, but given "%T"`, key)
+		}
+		blockType = pemTypeECPrivateKey
+		der, err = x509.MarshalECPrivateKey(ecKey)
+
+	default:
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid private key format "%s"`, format[0])
+	}
+	if err != nil {
+		return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `marshal private key "%T" failed`, key)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), nil
+}
+
+// ParsePrivateKeyPEM parses the first private key in PEM `data`, which can be in PKCS#8, PKCS#1 or SEC 1 format.
+// The returned key is *rsa.PrivateKey, *ecdsa.PrivateKey or ed25519.PrivateKey.
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	for {
+		var block *pem.Block
+		if block, data = pem.Decode(data); block == nil {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, `no private key found in PEM data`)
+		}
+		var (
+			key interface{}
+			err error
+		)
+		switch block.Type {
+		case pemTypePrivateKey:
+			key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		case pemTypeRSAPrivateKey:
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		case pemTypeECPrivateKey:
+			key, err = x509.ParseECPrivateKey(block.Bytes)
+		default:
+			// It skips other blocks like "EC PARAMETERS".
+			continue
+		}
+		if err != nil {
+			return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `parse "%s" failed`, block.Type)
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, gerror.NewCodef(gcode.CodeNotSupported, `unsupported private key type "%T"`, key)
+		}
+		return signer, nil
+	}
+}
+
+// MarshalPublicKeyPEM marshals the RSA, ECDSA or Ed25519 public `key` to PEM in `format`,
+// which is FormatPKIX in default. The private key is also accepted, in which case its public key is marshaled.
+func MarshalPublicKeyPEM(key crypto.PublicKey, format ...Format) ([]byte, error) {
+	if signer, ok := key.(crypto.Signer); ok {
+		key = signer.Public()
+	}
+	var (
+		der       []byte
+		blockType string
+		err       error
+	)
+	switch getFormat(FormatPKIX, format...) {
+	case FormatPKIX:
+		blockType = pemTypePublicKey
+		der, err = x509.MarshalPKIXPublicKey(key)
+
+	case FormatPKCS1:
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `PKCS1 format requires RSA key, but given "%T"`, key)
+		}
+		blockType = pemTypeRSAPublicKey
+		der = x509.MarshalPKCS1PublicKey(rsaKey)
+
+	default:
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid public key format "%s"`, format[0])
+	}
+	if err != nil {
+		return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `marshal public key "%T" failed`, key)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), nil
+}
+
+// ParsePublicKeyPEM parses the first public key in PEM `data`, which can be in PKIX or PKCS#1 format,
+// or the public key of a certificate.
+// The returned key is *rsa.PublicKey, *ecdsa.PublicKey or ed25519.PublicKey.
+func ParsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	for {
+		var block *pem.Block
+		if block, data = pem.Decode(data); block == nil {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, `no public key found in PEM data`)
+		}
+		var (
+			key interface{}
+			err error
+		)
+		switch block.Type {
+		case pemTypePublicKey:
+			key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case pemTypeRSAPublicKey:
+			key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		case pemTypeCertificate:
+			var cert *x509.Certificate
+			if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+				key = cert.PublicKey
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `parse "%s" failed`, block.Type)
+		}
+		return key, nil
+	}
+}
+
+func getFormat(defaultFormat Format, format ...Format) Format {
+	if len(format) > 0 && format[0] != "" {
+		return format[0]
+	}
+	return defaultFormat
+}
@@ -0,0 +1,181 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package grsa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// CertificateOption is the option for creating CSRs and self-signed certificates.
+type CertificateOption struct {
+	CommonName         string        // Common name of the subject.
+	Organization       []string      // Organizations of the subject.
+	OrganizationalUnit []string      // Organizational units of the subject.
+	Country            []string      // Countries of the subject.
+	Hosts              []string      // Host names or IP addresses, which are added as DNS names or IP addresses.
+	EmailAddresses     []string      // Email addresses of the subject.
+	Duration           time.Duration // Validity duration of self-signed certificate, which is 1 year in default.
+	IsCA               bool          // Whether the self-signed certificate is a CA, which can sign other certificates.
+}
+
+const defaultCertificateDuration = 365 * 24 * time.Hour
+
+// CreateCSR creates and returns a certificate signing request in PEM signed by `key`.
+func CreateCSR(key crypto.Signer, option CertificateOption) ([]byte, error) {
+	dnsNames, ipAddresses := splitHosts(option.Hosts)
+	template := &x509.CertificateRequest{
+		Subject:        option.subject(),
+		DNSNames:       dnsNames,
+		IPAddresses:    ipAddresses,
+		EmailAddresses: option.EmailAddresses,
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `x509.CreateCertificateRequest failed`)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypeCertificateCSR, Bytes: der}), nil
+}
+
+// CreateSelfSignedCert creates and returns a self-signed certificate in PEM signed by `key`,
+// which is valid for both server and client authentication.
+func CreateSelfSignedCert(key crypto.Signer, option CertificateOption) ([]byte, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, `generate serial number failed`)
+	}
+	duration := option.Duration
+	if duration <= 0 {
+		duration = defaultCertificateDuration
+	}
+	var (
+		now                   = time.Now()
+		dnsNames, ipAddresses = splitHosts(option.Hosts)
+		keyUsage              = x509.KeyUsageDigitalSignature
+	)
+	if _, ok := key.Public().(*rsa.PublicKey); ok {
+		keyUsage |= x509.KeyUsageKeyEncipherment
+	}
+	if option.IsCA {
+		keyUsage |= x509.KeyUsageCertSign
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               option.subject(),
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(duration),
+		KeyUsage:              keyUsage,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  option.IsCA,
+		DNSNames:              dnsNames,
+		IPAddresses:           ipAddresses,
+		EmailAddresses:        option.EmailAddresses,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `x509.CreateCertificate failed`)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypeCertificate, Bytes: der}), nil
+}
+
+// GenerateSelfSignedTLS generates an ECDSA P-256 key and a self-signed certificate for `hosts`,
+// and returns them as tls.Certificate, which is usually used for TLS bootstrap in development and testing.
+func GenerateSelfSignedTLS(hosts ...string) (tls.Certificate, error) {
+	key, err := GenerateECDSAKey()
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	option := CertificateOption{Hosts: hosts}
+	if len(hosts) > 0 {
+		option.CommonName = hosts[0]
+	}
+	certPEM, err := CreateSelfSignedCert(key, option)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	keyPEM, err := MarshalPrivateKeyPEM(key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		err = gerror.WrapCode(gcode.CodeInternalError, err, `tls.X509KeyPair failed`)
+	}
+	return certificate, err
+}
+
+// ParseCertificatesPEM parses and returns all certificates in PEM `data`.
+func ParseCertificatesPEM(data []byte) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	for {
+		var block *pem.Block
+		if block, data = pem.Decode(data); block == nil {
+			break
+		}
+		if block.Type != pemTypeCertificate {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `x509.ParseCertificate failed`)
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(certificates) == 0 {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `no certificate found in PEM data`)
+	}
+	return certificates, nil
+}
+
+// ParseCSRPEM parses the certificate signing request in PEM `data` and checks its signature.
+func ParseCSRPEM(data []byte) (*x509.CertificateRequest, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != pemTypeCertificateCSR {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `no certificate request found in PEM data`)
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `x509.ParseCertificateRequest failed`)
+	}
+	if err = csr.CheckSignature(); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid certificate request signature`)
+	}
+	return csr, nil
+}
+
+func (o CertificateOption) subject() pkix.Name {
+	return pkix.Name{
+		CommonName:         o.CommonName,
+		Organization:       o.Organization,
+		OrganizationalUnit: o.OrganizationalUnit,
+		Country:            o.Country,
+	}
+}
+
+// splitHosts splits `hosts` into DNS names and IP addresses.
+func splitHosts(hosts []string) (dnsNames []string, ipAddresses []net.IP) {
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			ipAddresses = append(ipAddresses, ip)
+		} else if host != "" {
+			dnsNames = append(dnsNames, host)
+		}
+	}
+	return
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/gogf/gf/v2/container/garray"
 	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gset"
 	"github.com/gogf/gf/v2/container/gtype"
 	"github.com/gogf/gf/v2/container/gvar"
 	"github.com/gogf/gf/v2/errors/gcode"
@@ -178,6 +179,8 @@ type DB interface {
 	ConvertValueForLocal(ctx context.Context, fieldType string, fieldValue interface{}) (interface{}, error) // See Core.ConvertValueForLocal
 	CheckLocalTypeForField(ctx context.Context, fieldType string, fieldValue interface{}) (LocalType, error) // See Core.CheckLocalTypeForField
 	FormatUpsert(columns []string, list List, option DoInsertOption) (string, error)                         // See Core.DoFormatUpsert
+	FormatJSON(operation JSONOperation, column string, path []string) (expr string, args []interface{})      // See Core.FormatJSON
+	FormatReturning(sql string, columns []string) string                                                     // See Core.FormatReturning
 }
 
 // TX defines the interfaces for ORM transaction operations.
@@ -254,6 +257,9 @@ type StatsItem interface {
 
 	// Stats returns the connection stat for current node.
 	Stats() sql.DBStats
+
+	// StmtCacheStats returns the prepared statement cache stat for current node.
+	StmtCacheStats() StmtCacheStats
 }
 
 // Core is the base struct for database management.
@@ -264,7 +270,10 @@ type Core struct {
 	schema        string          // Custom schema for this object.
 	debug         *gtype.Bool     // Enable debug mode for the database, which can be changed in runtime.
 	cache         *gcache.Cache   // Cache manager, SQL result cache only.
+	cacheTags     *gset.StrSet    // cacheTags is the tags used by the cached sql results, see CacheOption.Tags.
 	links         *gmap.Map       // links caches all created links by node.
+	stmtCaches    *gmap.Map       // stmtCaches caches the prepared statement cache by underlying *sql.DB.
+	poolExhausted *gmap.Map       // poolExhausted marks whether the connection pool is exhausted, *sql.DB => bool.
 	logger        glog.ILogger    // Logger for logging functionality.
 	config        *ConfigNode     // Current config node.
 	dynamicConfig dynamicConfig   // Dynamic configurations, which can be changed in runtime.
@@ -384,12 +393,17 @@ const (
 	ctxTimeoutTypePrepare                 = 2
 	cachePrefixTableFields                = `TableFields:`
 	cachePrefixSelectCache                = `SelectCache:`
+	cachePrefixSelectCacheTag             = `SelectCacheTag:`
 	commandEnvKeyForDryRun                = "gf.gdb.dryrun"
 	modelForDaoSuffix                     = `ForDao`
 	dbRoleSlave                           = `slave`
 	ctxKeyForDB               gctx.StrKey = `CtxKeyForDB`
 	ctxKeyCatchSQL            gctx.StrKey = `CtxKeyCatchSQL`
 	ctxKeyInternalProducedSQL gctx.StrKey = `CtxKeyInternalProducedSQL`
+	ctxKeyCursor              gctx.StrKey = `CtxKeyCursor`
+	ctxKeyAuditActor          gctx.StrKey = `CtxKeyAuditActor`
+	ctxKeyReturning           gctx.StrKey = `CtxKeyReturning`
+	ctxKeyReplicaSession      gctx.StrKey = `CtxKeyReplicaSession`
 
 	// type:[username[:password]@][protocol[(address)]]/dbname[?param1=value1&...&paramN=valueN]
 	linkPattern = `(\w+):([\w\-\$]*):(.*?)@(\w+?)\((.+?)\)/{0,1}([^\?]*)\?{0,1}(.*)`
@@ -427,6 +441,14 @@ const (
 	InsertOnDuplicateKeyUpdate = "ON DUPLICATE KEY UPDATE"
 )
 
+// JSONOperation is the operation on JSON column for DB.FormatJSON.
+type JSONOperation string
+
+const (
+	JSONOperationExtract  JSONOperation = "extract"  // Extracts the value at the path of JSON column.
+	JSONOperationContains JSONOperation = "contains" // Checks whether the value at the path contains the JSON document of placeholder.
+)
+
 type SqlType string
 
 const (
@@ -588,7 +610,10 @@ func newDBByConfigNode(node *ConfigNode, group string) (db DB, err error) {
 		group:         group,
 		debug:         gtype.NewBool(),
 		cache:         gcache.New(),
+		cacheTags:     gset.NewStrSet(true),
 		links:         gmap.New(true),
+		stmtCaches:    gmap.New(true),
+		poolExhausted: gmap.New(true),
 		logger:        glog.New(),
 		config:        node,
 		innerMemCache: gcache.New(),
@@ -653,14 +678,10 @@ func getConfigNodeByGroup(group string, master bool) (*ConfigNode, error) {
 				"at least one master node configuration's need to make sense",
 			)
 		}
-		if len(slaveList) < 1 {
-			slaveList = masterList
-		}
-		if master {
+		if master || len(slaveList) < 1 {
 			return getConfigNodeByWeight(masterList), nil
-		} else {
-			return getConfigNodeByWeight(slaveList), nil
 		}
+		return getReplicaConfigNode(group, masterList, slaveList), nil
 	}
 	return nil, gerror.NewCodef(
 		gcode.CodeInvalidConfiguration,
@@ -717,18 +738,29 @@ func getConfigNodeByWeight(cg ConfigGroup) *ConfigNode {
 // master-slave nodes are configured.
 func (c *Core) getSqlDb(master bool, schema ...string) (sqlDb *sql.DB, err error) {
 	var (
-		node *ConfigNode
-		ctx  = c.db.GetCtx()
+		node       *ConfigNode
+		ctx        = c.db.GetCtx()
+		replicaKey string
 	)
 	if c.group != "" {
 		// Load balance.
 		configs.RLock()
 		defer configs.RUnlock()
+		if !master {
+			// It reads on master node after writing in the same session, for reading the written data.
+			master = c.isReplicaSessionWritten(ctx)
+		}
 		// Value COPY for node.
 		node, err = getConfigNodeByGroup(c.group, master)
 		if err != nil {
 			return nil, err
 		}
+		if !master {
+			c.checkReplicasIfNecessary(ctx)
+		}
+		if node.Role == dbRoleSlave {
+			replicaKey = getReplicaNodeKey(node)
+		}
 	} else {
 		// Value COPY for node.
 		n := *c.db.GetConfig()
@@ -747,6 +779,22 @@ func (c *Core) getSqlDb(master bool, schema ...string) (sqlDb *sql.DB, err error
 		return
 	}
 
+	sqlDb, err = c.getSqlDbByNode(node)
+	if err == nil && sqlDb != nil && replicaKey != "" {
+		c.setReplicaNodeKey(sqlDb, replicaKey)
+	}
+	if node.Debug {
+		c.db.SetDebug(node.Debug)
+	}
+	if node.DryRun {
+		c.db.SetDryRun(node.DryRun)
+	}
+	return
+}
+
+// getSqlDbByNode retrieves and returns the underlying database connection object of `node`,
+// which is cached by node.
+func (c *Core) getSqlDbByNode(node *ConfigNode) (sqlDb *sql.DB, err error) {
 	// Cache the underlying connection pool object by node.
 	var (
 		instanceCacheFunc = func() interface{} {
@@ -771,6 +819,7 @@ func (c *Core) getSqlDb(master bool, schema ...string) (sqlDb *sql.DB, err error
 			} else {
 				sqlDb.SetConnMaxLifetime(defaultMaxConnLifeTime)
 			}
+			poolCores.Set(c, struct{}{})
 			return sqlDb
 		}
 		// it here uses node value not pointer as the cache key, in case of oracle ORA-12516 error.
@@ -780,11 +829,5 @@ func (c *Core) getSqlDb(master bool, schema ...string) (sqlDb *sql.DB, err error
 		// It reads from instance map.
 		sqlDb = instanceValue.(*sql.DB)
 	}
-	if node.Debug {
-		c.db.SetDebug(node.Debug)
-	}
-	if node.DryRun {
-		c.db.SetDryRun(node.DryRun)
-	}
 	return
 }
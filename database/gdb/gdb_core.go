@@ -107,6 +107,13 @@ func (c *Core) Close(ctx context.Context) (err error) {
 	if err = c.cache.Close(ctx); err != nil {
 		return err
 	}
+	poolCores.Remove(c)
+	c.stmtCaches.LockFunc(func(m map[any]any) {
+		for k, v := range m {
+			v.(*stmtCache).Close()
+			delete(m, k)
+		}
+	})
 	c.links.LockFunc(func(m map[any]any) {
 		for k, v := range m {
 			if db, ok := v.(*sql.DB); ok {
@@ -53,6 +53,12 @@ type ConfigNode struct {
 	UpdatedAt            string        `json:"updatedAt"`            // (Optional) The field name of table for automatic-filled updated datetime.
 	DeletedAt            string        `json:"deletedAt"`            // (Optional) The field name of table for automatic-filled updated datetime.
 	TimeMaintainDisabled bool          `json:"timeMaintainDisabled"` // (Optional) Disable the automatic time maintaining feature.
+	ReplicaPolicy        string        `json:"replicaPolicy"`        // (Optional, "random" in default) Policy selecting slave node of the group: random, weighted, roundRobin, latency.
+	PrimaryAfterWrite    time.Duration `json:"primaryAfterWrite"`    // (Optional) Duration reading from master node after writing in the same session of WithReplicaSession, for reading the written data.
+	HealthCheckInterval  time.Duration `json:"healthCheckInterval"`  // (Optional) Interval of health checking of slave nodes, which removes the dead ones from selecting.
+	StmtCacheSize        int           `json:"stmtCacheSize"`        // (Optional) Max number of prepared statements cached by LRU for each node, which disables the caching if it's 0.
+	SlowThreshold        time.Duration `json:"slowThreshold"`        // (Optional) Min execution duration of slow query, which is logged in warning level or handled by SetSlowQueryHandler.
+	TxNestedPolicy       string        `json:"txNestedPolicy"`       // (Optional, "savepoint" in default) Policy of nested transaction: savepoint, join.
 }
 
 const (
@@ -7,13 +7,17 @@
 package gdb
 
 import (
+	"context"
 	"database/sql"
 )
 
 // dbLink is used to implement interface Link for DB.
 type dbLink struct {
-	*sql.DB         // Underlying DB object.
-	isOnMaster bool // isOnMaster marks whether current link is operated on master node.
+	*sql.DB               // Underlying DB object.
+	isOnMaster bool       // isOnMaster marks whether current link is operated on master node.
+	stmtCache  *stmtCache // Prepared statement cache of the DB, which is nil if it's disabled.
+	schema     string     // Schema of the link, which is used for selecting another node in failover.
+	replicaKey string     // Node key of the slave node, which is empty if the link is not on slave node.
 }
 
 // txLink is used to implement interface Link for TX.
@@ -31,6 +35,24 @@ func (l *dbLink) IsOnMaster() bool {
 	return l.isOnMaster
 }
 
+// ExecContext executes a query without returning any rows,
+// using the cached prepared statement if the prepared statement cache is enabled.
+func (l *dbLink) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if l.stmtCache != nil {
+		return l.stmtCache.ExecContext(ctx, query, args...)
+	}
+	return l.DB.ExecContext(ctx, query, args...)
+}
+
+// QueryContext executes a query that returns rows,
+// using the cached prepared statement if the prepared statement cache is enabled.
+func (l *dbLink) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if l.stmtCache != nil {
+		return l.stmtCache.QueryContext(ctx, query, args...)
+	}
+	return l.DB.QueryContext(ctx, query, args...)
+}
+
 // IsTransaction returns if current Link is a transaction.
 func (l *txLink) IsTransaction() bool {
 	return true
@@ -0,0 +1,306 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/os/gmetric"
+)
+
+type localMetricManager struct {
+	DbClientOperationDuration      gmetric.Histogram
+	DbClientOperationTotal         gmetric.Counter
+	DbClientOperationDurationTotal gmetric.Counter
+	DbClientOperationRowsAffected  gmetric.Counter
+	DbClientStmtCacheHits          gmetric.Counter
+	DbClientStmtCacheMisses        gmetric.Counter
+	DbClientStmtCacheEvictions     gmetric.Counter
+	DbClientConnectionCount        gmetric.ObservableGauge
+	DbClientConnectionMax          gmetric.ObservableGauge
+	DbClientConnectionWaitCount    gmetric.ObservableCounter
+	DbClientConnectionWaitDuration gmetric.ObservableCounter
+	DbClientConnectionClosed       gmetric.ObservableCounter
+}
+
+const (
+	metricAttrKeyDbSystem      = "db.system"
+	metricAttrKeyDbName        = "db.name"
+	metricAttrKeyDbGroup       = "db.group"
+	metricAttrKeyDbOperation   = "db.operation"
+	metricAttrKeyServerAddress = "server.address"
+	metricAttrKeyServerPort    = "server.port"
+	metricAttrKeyErrorCode     = "error.code"
+	metricAttrKeyPoolState     = "db.client.connection.state"
+	metricAttrKeyPoolReason    = "db.client.connection.closed_reason"
+)
+
+var (
+	// metricManager for database client metrics.
+	metricManager = newMetricManager()
+)
+
+func newMetricManager() *localMetricManager {
+	meter := gmetric.GetGlobalProvider().Meter(gmetric.MeterOption{
+		Instrument:        traceInstrumentName,
+		InstrumentVersion: gf.VERSION,
+	})
+	mm := &localMetricManager{
+		DbClientOperationDuration: meter.MustHistogram(
+			"db.client.operation.duration",
+			gmetric.MetricOption{
+				Help:       "Measures the duration of database operations.",
+				Unit:       "ms",
+				Attributes: gmetric.Attributes{},
+				Buckets: []float64{
+					1,
+					5,
+					10,
+					25,
+					50,
+					75,
+					100,
+					250,
+					500,
+					750,
+					1000,
+					2500,
+					5000,
+					7500,
+					10000,
+					30000,
+					60000,
+				},
+			},
+		),
+		DbClientOperationTotal: meter.MustCounter(
+			"db.client.operation.total",
+			gmetric.MetricOption{
+				Help:       "Total processed database operation number.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		DbClientOperationDurationTotal: meter.MustCounter(
+			"db.client.operation.duration_total",
+			gmetric.MetricOption{
+				Help:       "Total execution duration of database operations.",
+				Unit:       "ms",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		DbClientOperationRowsAffected: meter.MustCounter(
+			"db.client.operation.rows_affected",
+			gmetric.MetricOption{
+				Help:       "Total rows affected or retrieved by database operations.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		DbClientStmtCacheHits: meter.MustCounter(
+			"db.client.stmt_cache.hits",
+			gmetric.MetricOption{
+				Help:       "Total queries using cached prepared statement.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		DbClientStmtCacheMisses: meter.MustCounter(
+			"db.client.stmt_cache.misses",
+			gmetric.MetricOption{
+				Help:       "Total queries preparing statement as it's not cached.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		DbClientStmtCacheEvictions: meter.MustCounter(
+			"db.client.stmt_cache.evictions",
+			gmetric.MetricOption{
+				Help:       "Total prepared statements evicted from cache.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		DbClientConnectionCount: meter.MustObservableGauge(
+			"db.client.connection.count",
+			gmetric.MetricOption{
+				Help:       "Number of connections of the pool in state idle or used.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		DbClientConnectionMax: meter.MustObservableGauge(
+			"db.client.connection.max",
+			gmetric.MetricOption{
+				Help:       "Max number of open connections of the pool.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		DbClientConnectionWaitCount: meter.MustObservableCounter(
+			"db.client.connection.wait_count",
+			gmetric.MetricOption{
+				Help:       "Total number of connections waited for as the pool is exhausted.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		DbClientConnectionWaitDuration: meter.MustObservableCounter(
+			"db.client.connection.wait_duration",
+			gmetric.MetricOption{
+				Help:       "Total time blocked waiting for new connections.",
+				Unit:       "ms",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		DbClientConnectionClosed: meter.MustObservableCounter(
+			"db.client.connection.closed",
+			gmetric.MetricOption{
+				Help:       "Total number of connections closed by max_idle, max_idle_time or max_lifetime.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+	}
+	meter.MustRegisterCallback(
+		mm.observePoolMetrics,
+		mm.DbClientConnectionCount,
+		mm.DbClientConnectionMax,
+		mm.DbClientConnectionWaitCount,
+		mm.DbClientConnectionWaitDuration,
+		mm.DbClientConnectionClosed,
+	)
+	return mm
+}
+
+func (m *localMetricManager) GetMetricAttributeMap(c *Core, sqlObj *Sql) gmetric.AttributeMap {
+	var (
+		config  = c.db.GetConfig()
+		attrMap = make(gmetric.AttributeMap)
+		errCode int
+	)
+	if sqlObj.Error != nil && sqlObj.Error != sql.ErrNoRows {
+		errCode = gcode.CodeDbOperationError.Code()
+	}
+	attrMap.Sets(gmetric.AttributeMap{
+		metricAttrKeyDbSystem:      config.Type,
+		metricAttrKeyDbName:        config.Name,
+		metricAttrKeyDbGroup:       c.db.GetGroup(),
+		metricAttrKeyDbOperation:   string(sqlObj.Type),
+		metricAttrKeyServerAddress: config.Host,
+		metricAttrKeyServerPort:    config.Port,
+		metricAttrKeyErrorCode:     errCode,
+	})
+	return attrMap
+}
+
+func (m *localMetricManager) GetMetricOptionForHistogramByMap(attrMap gmetric.AttributeMap) gmetric.Option {
+	return gmetric.Option{
+		Attributes: attrMap.Pick(
+			metricAttrKeyDbSystem,
+			metricAttrKeyDbGroup,
+			metricAttrKeyServerAddress,
+			metricAttrKeyServerPort,
+		),
+	}
+}
+
+func (m *localMetricManager) GetMetricOptionForOperationByMap(attrMap gmetric.AttributeMap) gmetric.Option {
+	return gmetric.Option{
+		Attributes: attrMap.Pick(
+			metricAttrKeyDbSystem,
+			metricAttrKeyDbName,
+			metricAttrKeyDbGroup,
+			metricAttrKeyDbOperation,
+			metricAttrKeyServerAddress,
+			metricAttrKeyServerPort,
+			metricAttrKeyErrorCode,
+		),
+	}
+}
+
+// handleMetricsAfterCommit records the metrics of sql execution if metric feature is enabled.
+func (c *Core) handleMetricsAfterCommit(ctx context.Context, sqlObj *Sql) {
+	if !gmetric.IsEnabled() {
+		return
+	}
+	var (
+		attrMap         = metricManager.GetMetricAttributeMap(c, sqlObj)
+		durationMilli   = float64(sqlObj.End - sqlObj.Start)
+		operationOption = metricManager.GetMetricOptionForOperationByMap(attrMap)
+		histogramOption = metricManager.GetMetricOptionForHistogramByMap(attrMap)
+	)
+	metricManager.DbClientOperationTotal.Inc(ctx, operationOption)
+	metricManager.DbClientOperationDurationTotal.Add(ctx, durationMilli, operationOption)
+	metricManager.DbClientOperationDuration.Record(durationMilli, histogramOption)
+	if sqlObj.RowsAffected > 0 {
+		metricManager.DbClientOperationRowsAffected.Add(
+			ctx, float64(sqlObj.RowsAffected), operationOption,
+		)
+	}
+}
+
+// handleStmtCacheMetrics increases the prepared statement cache `counter` if metric feature is enabled.
+func (c *Core) handleStmtCacheMetrics(ctx context.Context, counter gmetric.Counter) {
+	if !gmetric.IsEnabled() {
+		return
+	}
+	var config = c.db.GetConfig()
+	counter.Inc(ctx, gmetric.Option{
+		Attributes: gmetric.Attributes{
+			gmetric.NewAttribute(metricAttrKeyDbSystem, config.Type),
+			gmetric.NewAttribute(metricAttrKeyDbGroup, c.db.GetGroup()),
+			gmetric.NewAttribute(metricAttrKeyServerAddress, config.Host),
+			gmetric.NewAttribute(metricAttrKeyServerPort, config.Port),
+		},
+	})
+}
+
+// observePoolMetrics observes the stats of all the connection pools that are established,
+// which is called when the metrics are read.
+func (m *localMetricManager) observePoolMetrics(ctx context.Context, obs gmetric.Observer) error {
+	poolCores.Iterator(func(k, _ any) bool {
+		m.observeCorePoolMetrics(k.(*Core), obs)
+		return true
+	})
+	return nil
+}
+
+// observeCorePoolMetrics observes the stats of the connection pools of Core `c`.
+func (m *localMetricManager) observeCorePoolMetrics(c *Core, obs gmetric.Observer) {
+	c.links.Iterator(func(k, v any) bool {
+		var (
+			node  = k.(ConfigNode)
+			stats = v.(*sql.DB).Stats()
+			attrs = gmetric.Attributes{
+				gmetric.NewAttribute(metricAttrKeyDbSystem, node.Type),
+				gmetric.NewAttribute(metricAttrKeyDbName, node.Name),
+				gmetric.NewAttribute(metricAttrKeyDbGroup, c.db.GetGroup()),
+				gmetric.NewAttribute(metricAttrKeyServerAddress, node.Host),
+				gmetric.NewAttribute(metricAttrKeyServerPort, node.Port),
+			}
+			withAttr = func(key string, value any) gmetric.Option {
+				return gmetric.Option{
+					Attributes: append(attrs[:len(attrs):len(attrs)], gmetric.NewAttribute(key, value)),
+				}
+			}
+			option = gmetric.Option{Attributes: attrs}
+		)
+		obs.Observe(m.DbClientConnectionCount, float64(stats.Idle), withAttr(metricAttrKeyPoolState, "idle"))
+		obs.Observe(m.DbClientConnectionCount, float64(stats.InUse), withAttr(metricAttrKeyPoolState, "used"))
+		obs.Observe(m.DbClientConnectionMax, float64(stats.MaxOpenConnections), option)
+		obs.Observe(m.DbClientConnectionWaitCount, float64(stats.WaitCount), option)
+		obs.Observe(m.DbClientConnectionWaitDuration, float64(stats.WaitDuration.Milliseconds()), option)
+		obs.Observe(m.DbClientConnectionClosed, float64(stats.MaxIdleClosed), withAttr(metricAttrKeyPoolReason, "max_idle"))
+		obs.Observe(m.DbClientConnectionClosed, float64(stats.MaxIdleTimeClosed), withAttr(metricAttrKeyPoolReason, "max_idle_time"))
+		obs.Observe(m.DbClientConnectionClosed, float64(stats.MaxLifetimeClosed), withAttr(metricAttrKeyPoolReason, "max_lifetime"))
+		return true
+	})
+}
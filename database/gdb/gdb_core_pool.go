@@ -0,0 +1,78 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/gogf/gf/v2/container/gmap"
+)
+
+// PoolExhaustedEvent is the event that all the connections of the pool are in use,
+// in which the operations wait for the connections released by others.
+type PoolExhaustedEvent struct {
+	Group string      // Configuration group name.
+	Node  ConfigNode  // Configuration node of the pool.
+	Stats sql.DBStats // Stats of the pool when it's exhausted.
+}
+
+// PoolExhaustedHandler handles the pool exhausted event, which is called synchronously
+// before the operation, so it should return as soon as possible.
+type PoolExhaustedHandler func(ctx context.Context, event *PoolExhaustedEvent)
+
+var (
+	// poolExhaustedHandlers is the pool exhausted handlers, group name => PoolExhaustedHandler.
+	poolExhaustedHandlers = gmap.NewStrAnyMap(true)
+
+	// poolCores is the Core objects that have established connection pools,
+	// whose pool stats are observed by metrics.
+	poolCores = gmap.New(true)
+)
+
+// SetPoolExhaustedHandler sets the handler called when the connection pool of configuration `group`
+// becomes exhausted, which means all the MaxOpenConnCount connections are in use.
+// The handler is called once for each exhaustion, until the pool has free connections again.
+// It removes the handler if `handler` is nil.
+func SetPoolExhaustedHandler(group string, handler PoolExhaustedHandler) {
+	if handler == nil {
+		poolExhaustedHandlers.Remove(group)
+		return
+	}
+	poolExhaustedHandlers.Set(group, handler)
+}
+
+// checkPoolExhausted calls the pool exhausted handler if the pool `db` becomes exhausted.
+func (c *Core) checkPoolExhausted(db *sql.DB) {
+	v := poolExhaustedHandlers.Get(c.db.GetGroup())
+	if v == nil {
+		return
+	}
+	var (
+		stats     = db.Stats()
+		exhausted = stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections
+	)
+	if wasExhausted, _ := c.poolExhausted.Get(db).(bool); wasExhausted == exhausted {
+		return
+	}
+	c.poolExhausted.Set(db, exhausted)
+	if !exhausted {
+		return
+	}
+	event := &PoolExhaustedEvent{
+		Group: c.db.GetGroup(),
+		Stats: stats,
+	}
+	c.links.Iterator(func(k, v any) bool {
+		if v == db {
+			event.Node = k.(ConfigNode)
+			return false
+		}
+		return true
+	})
+	v.(PoolExhaustedHandler)(c.db.GetCtx(), event)
+}
@@ -0,0 +1,431 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+const (
+	ReplicaPolicyRandom     = "random"     // Selects slave node randomly by weight, which is the default policy.
+	ReplicaPolicyWeighted   = "weighted"   // Selects slave node by smooth weighted round-robin.
+	ReplicaPolicyRoundRobin = "roundRobin" // Selects slave node by round-robin ignoring weight.
+	ReplicaPolicyLatency    = "latency"    // Selects slave node of the lowest latency measured by health checking.
+)
+
+const (
+	defaultReplicaHealthCheckTimeout = 3 * time.Second
+	defaultReplicaFailoverDuration   = 10 * time.Second // Duration a slave node is skipped after failing over from it.
+)
+
+// ReplicaNode is a healthy slave node for ReplicaPolicy selecting.
+type ReplicaNode struct {
+	ConfigNode
+	Latency time.Duration // Ping latency of the latest health checking, which is 0 if it's not checked yet.
+}
+
+// ReplicaPolicy selects the slave node for reading from the healthy slave nodes of a configuration group.
+type ReplicaPolicy interface {
+	// Select returns the index of selected node in `replicas`, which contains at least one node.
+	Select(replicas []ReplicaNode) int
+}
+
+// replicaState is the state of slave nodes selecting of a configuration group.
+type replicaState struct {
+	mu         sync.RWMutex
+	policy     ReplicaPolicy             // Policy in use, which is created by name of configuration if not custom.
+	policyName string                    // Configured policy name of current policy.
+	custom     bool                      // Whether the policy is set by SetReplicaPolicy.
+	health     map[string]*replicaHealth // Node key => health checking result.
+	nodeKeys   map[*sql.DB]string        // Connection pool of slave node => node key, for failover.
+	lastCheck  *gtype.Int64              // Timestamp in nanoseconds of the latest health checking.
+}
+
+// replicaSession is the session created by WithReplicaSession, which is shared by the derived contexts.
+type replicaSession struct {
+	lastWrite *gmap.StrAnyMap // Group name => timestamp in nanoseconds of the latest writing on master node.
+}
+
+// replicaHealth is the health checking result of a slave node.
+type replicaHealth struct {
+	dead     bool
+	latency  time.Duration
+	expireAt time.Time // Time that the dead mark of failover expires, which is zero for health checking result.
+}
+
+// replicaOption is the replica configuration of a group, which is from the nodes of the group.
+type replicaOption struct {
+	Policy              string
+	PrimaryAfterWrite   time.Duration
+	HealthCheckInterval time.Duration
+}
+
+var (
+	// replicaStates is the replica state of all configuration groups, group name => *replicaState.
+	replicaStates = gmap.NewStrAnyMap(true)
+)
+
+// SetReplicaPolicy sets custom policy selecting slave node for configuration `group`,
+// which overwrites the policy configured by attribute ReplicaPolicy of ConfigNode.
+func SetReplicaPolicy(group string, policy ReplicaPolicy) {
+	state := getReplicaState(group)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.policy = policy
+	state.custom = policy != nil
+	state.policyName = ""
+}
+
+// NewReplicaPolicy creates and returns a builtin ReplicaPolicy by name,
+// which is ReplicaPolicyRandom if `name` is unknown.
+func NewReplicaPolicy(name string) ReplicaPolicy {
+	switch name {
+	case ReplicaPolicyWeighted:
+		return &replicaPolicyWeighted{current: make(map[string]int)}
+	case ReplicaPolicyRoundRobin:
+		return &replicaPolicyRoundRobin{counter: gtype.NewUint64()}
+	case ReplicaPolicyLatency:
+		return replicaPolicyLatency{}
+	default:
+		return replicaPolicyRandom{}
+	}
+}
+
+// getReplicaState returns the replica state of `group`, which is created if it does not exist.
+func getReplicaState(group string) *replicaState {
+	return replicaStates.GetOrSetFuncLock(group, func() interface{} {
+		return &replicaState{
+			health:    make(map[string]*replicaHealth),
+			nodeKeys:  make(map[*sql.DB]string),
+			lastCheck: gtype.NewInt64(),
+		}
+	}).(*replicaState)
+}
+
+// getReplicaOption returns the replica configuration of nodes, which is the first configured value of the nodes.
+func getReplicaOption(list ConfigGroup) replicaOption {
+	var option replicaOption
+	for _, node := range list {
+		if option.Policy == "" {
+			option.Policy = node.ReplicaPolicy
+		}
+		if option.PrimaryAfterWrite == 0 {
+			option.PrimaryAfterWrite = node.PrimaryAfterWrite
+		}
+		if option.HealthCheckInterval == 0 {
+			option.HealthCheckInterval = node.HealthCheckInterval
+		}
+	}
+	return option
+}
+
+// getReplicaNodeKey returns the key identifying the database of `node`.
+func getReplicaNodeKey(node *ConfigNode) string {
+	return fmt.Sprintf(
+		`%s|%s|%s|%s|%s|%s`, node.Type, node.Link, node.Host, node.Port, node.User, node.Name,
+	)
+}
+
+// WithReplicaSession returns a new context of a session reading the data written by itself,
+// like the context of an HTTP request, eg:
+//
+//	ctx = gdb.WithReplicaSession(r.Context())
+//	r.SetCtx(ctx)
+//
+// The reading using the context, or any context derived from it, is on master node
+// within ConfigNode.PrimaryAfterWrite after the writing using the context of the same session.
+// It returns `ctx` itself if it is already of a session.
+func WithReplicaSession(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(ctxKeyReplicaSession).(*replicaSession); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxKeyReplicaSession, &replicaSession{
+		lastWrite: gmap.NewStrAnyMap(true),
+	})
+}
+
+// getReplicaConfigNode selects and returns a copy of node for reading in `group`.
+// It returns master node if all the slave nodes are dead.
+func getReplicaConfigNode(group string, masterList, slaveList ConfigGroup) *ConfigNode {
+	var (
+		state  = getReplicaState(group)
+		option = getReplicaOption(append(append(ConfigGroup{}, masterList...), slaveList...))
+	)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	var (
+		now      = time.Now()
+		replicas = make([]ReplicaNode, 0, len(slaveList))
+	)
+	for _, node := range slaveList {
+		replica := ReplicaNode{ConfigNode: node}
+		if health, ok := state.health[getReplicaNodeKey(&node)]; ok {
+			if health.dead && (health.expireAt.IsZero() || now.Before(health.expireAt)) {
+				continue
+			}
+			replica.Latency = health.latency
+		}
+		replicas = append(replicas, replica)
+	}
+	if len(replicas) == 0 {
+		return getConfigNodeByWeight(masterList)
+	}
+	if !state.custom && (state.policy == nil || state.policyName != option.Policy) {
+		state.policy = NewReplicaPolicy(option.Policy)
+		state.policyName = option.Policy
+	}
+	index := state.policy.Select(replicas)
+	if index < 0 || index >= len(replicas) {
+		index = 0
+	}
+	node := replicas[index].ConfigNode
+	return &node
+}
+
+// setReplicaNodeKey records the node key of connection pool `sqlDb` of slave node.
+func (c *Core) setReplicaNodeKey(sqlDb *sql.DB, key string) {
+	state := getReplicaState(c.group)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.nodeKeys[sqlDb] = key
+}
+
+// getReplicaNodeKeyByDb returns the node key of connection pool `sqlDb`,
+// which is empty if it's not a slave node.
+func (c *Core) getReplicaNodeKeyByDb(sqlDb *sql.DB) string {
+	if c.group == "" {
+		return ""
+	}
+	state := getReplicaState(c.group)
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+	return state.nodeKeys[sqlDb]
+}
+
+// getReplicaFailoverLink marks the slave node of `link` dead if `err` is retryable,
+// which is checked by gerror.IsRetryable, and returns the link of another node for retrying.
+// It returns nil if `link` is not on slave node or `err` is not retryable.
+func (c *Core) getReplicaFailoverLink(ctx context.Context, link Link, err error) Link {
+	l, ok := link.(*dbLink)
+	if !ok || l.replicaKey == "" || !gerror.IsRetryable(err) {
+		return nil
+	}
+	state := getReplicaState(c.group)
+	state.mu.Lock()
+	state.health[l.replicaKey] = &replicaHealth{
+		dead:     true,
+		expireAt: time.Now().Add(defaultReplicaFailoverDuration),
+	}
+	state.mu.Unlock()
+	intlog.Printf(ctx, `replica node "%s" of group "%s" fails over: %v`, l.replicaKey, c.group, err)
+
+	failoverLink, e := c.SlaveLink(l.schema)
+	if e != nil {
+		intlog.Errorf(ctx, `%+v`, e)
+		return nil
+	}
+	return failoverLink
+}
+
+// markReplicaWrite marks the writing on master node of current group in the session of `ctx`
+// for ConfigNode.PrimaryAfterWrite, which does nothing if `ctx` is not of a session.
+func (c *Core) markReplicaWrite(ctx context.Context) {
+	if c.group == "" || ctx == nil {
+		return
+	}
+	if session, ok := ctx.Value(ctxKeyReplicaSession).(*replicaSession); ok {
+		session.lastWrite.Set(c.group, time.Now().UnixNano())
+	}
+}
+
+// isReplicaSessionWritten checks and returns whether it is within ConfigNode.PrimaryAfterWrite
+// after the writing of current group in the session of `ctx`.
+// Note that the caller should hold the read lock of configs.
+func (c *Core) isReplicaSessionWritten(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	session, ok := ctx.Value(ctxKeyReplicaSession).(*replicaSession)
+	if !ok {
+		return false
+	}
+	option := getReplicaOption(configs.config[c.group])
+	if option.PrimaryAfterWrite <= 0 {
+		return false
+	}
+	lastWrite, ok := session.lastWrite.Get(c.group).(int64)
+	return ok && time.Duration(time.Now().UnixNano()-lastWrite) < option.PrimaryAfterWrite
+}
+
+// checkReplicasIfNecessary checks the health of slave nodes of current group asynchronously,
+// if ConfigNode.HealthCheckInterval is configured and it's the time.
+// Note that the caller should hold the read lock of configs.
+func (c *Core) checkReplicasIfNecessary(ctx context.Context) {
+	var (
+		list   = configs.config[c.group]
+		option = getReplicaOption(list)
+	)
+	if option.HealthCheckInterval <= 0 {
+		return
+	}
+	var (
+		state = getReplicaState(c.group)
+		now   = time.Now().UnixNano()
+		last  = state.lastCheck.Val()
+	)
+	if time.Duration(now-last) < option.HealthCheckInterval || !state.lastCheck.Cas(last, now) {
+		return
+	}
+	slaveList := make(ConfigGroup, 0)
+	for _, node := range list {
+		if node.Role == dbRoleSlave {
+			slaveList = append(slaveList, node)
+		}
+	}
+	if len(slaveList) == 0 {
+		return
+	}
+	go c.checkReplicas(gctx.NeverDone(ctx), state, slaveList)
+}
+
+// checkReplicas pings the slave nodes, and updates their health in `state`.
+func (c *Core) checkReplicas(ctx context.Context, state *replicaState, slaveList ConfigGroup) {
+	for _, node := range slaveList {
+		var (
+			key     = getReplicaNodeKey(&node)
+			health  = &replicaHealth{}
+			started = time.Now()
+		)
+		if node.Charset == "" {
+			node.Charset = defaultCharset
+		}
+		if c.schema != "" {
+			node.Name = c.schema
+		}
+		sqlDb, err := c.getSqlDbByNode(&node)
+		if err == nil && sqlDb != nil {
+			pingCtx, cancel := context.WithTimeout(ctx, defaultReplicaHealthCheckTimeout)
+			err = sqlDb.PingContext(pingCtx)
+			cancel()
+		}
+		if err != nil || sqlDb == nil {
+			health.dead = true
+			intlog.Printf(ctx, `replica node "%s" of group "%s" is dead: %v`, key, c.group, err)
+		} else {
+			health.latency = time.Since(started)
+		}
+		state.mu.Lock()
+		state.health[key] = health
+		state.mu.Unlock()
+	}
+}
+
+// replicaPolicyRandom selects node randomly by weight, in which the weights are all 1 if they're not configured.
+type replicaPolicyRandom struct{}
+
+// Select implements the interface ReplicaPolicy.
+func (p replicaPolicyRandom) Select(replicas []ReplicaNode) int {
+	var total int
+	for _, replica := range replicas {
+		total += replica.Weight
+	}
+	if total <= 0 {
+		return grand.N(0, len(replicas)-1)
+	}
+	random := grand.N(0, total-1)
+	for i, replica := range replicas {
+		if random < replica.Weight {
+			return i
+		}
+		random -= replica.Weight
+	}
+	return 0
+}
+
+// replicaPolicyWeighted selects node by smooth weighted round-robin like nginx,
+// in which the weight is 1 if it's not configured.
+type replicaPolicyWeighted struct {
+	mu      sync.Mutex
+	current map[string]int // Node key => current weight.
+}
+
+// Select implements the interface ReplicaPolicy.
+func (p *replicaPolicyWeighted) Select(replicas []ReplicaNode) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var (
+		best      = -1
+		bestKey   string
+		total     int
+		available = make(map[string]struct{}, len(replicas))
+	)
+	for i, replica := range replicas {
+		var (
+			key    = getReplicaNodeKey(&replica.ConfigNode)
+			weight = replica.Weight
+		)
+		if weight <= 0 {
+			weight = 1
+		}
+		available[key] = struct{}{}
+		p.current[key] += weight
+		total += weight
+		if best == -1 || p.current[key] > p.current[bestKey] {
+			best, bestKey = i, key
+		}
+	}
+	// Removes the state of the nodes that are no longer available.
+	for key := range p.current {
+		if _, ok := available[key]; !ok {
+			delete(p.current, key)
+		}
+	}
+	p.current[bestKey] -= total
+	return best
+}
+
+// replicaPolicyRoundRobin selects node one by one.
+type replicaPolicyRoundRobin struct {
+	counter *gtype.Uint64
+}
+
+// Select implements the interface ReplicaPolicy.
+func (p *replicaPolicyRoundRobin) Select(replicas []ReplicaNode) int {
+	return int((p.counter.Add(1) - 1) % uint64(len(replicas)))
+}
+
+// replicaPolicyLatency selects node of the lowest latency,
+// or randomly by weight if no latency is measured yet.
+type replicaPolicyLatency struct{}
+
+// Select implements the interface ReplicaPolicy.
+func (p replicaPolicyLatency) Select(replicas []ReplicaNode) int {
+	var best = -1
+	for i, replica := range replicas {
+		if replica.Latency <= 0 {
+			continue
+		}
+		if best == -1 || replica.Latency < replicas[best].Latency {
+			best = i
+		}
+	}
+	if best == -1 {
+		return replicaPolicyRandom{}.Select(replicas)
+	}
+	return best
+}
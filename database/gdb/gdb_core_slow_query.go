@@ -0,0 +1,79 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/debug/gdebug"
+)
+
+// SlowQuery is the information of the SQL whose execution exceeds ConfigNode.SlowThreshold.
+type SlowQuery struct {
+	*Sql                    // The executed SQL, which contains the arguments and rows.
+	Duration  time.Duration // Execution duration.
+	Threshold time.Duration // Configured slow query threshold.
+	Stack     string        // Caller stack of the SQL, in which the frames of gdb and drivers are filtered.
+}
+
+// SlowQueryHandler handles the slow query, which is called synchronously after the SQL execution.
+type SlowQueryHandler func(ctx context.Context, query *SlowQuery)
+
+const (
+	traceAttrDbSlowQuery = "db.slow_query"
+)
+
+var (
+	// slowQueryHandlers is the custom slow query handlers, group name => SlowQueryHandler.
+	slowQueryHandlers = gmap.NewStrAnyMap(true)
+
+	// slowQueryStackFilters filters the frames of gdb and drivers from the caller stack.
+	slowQueryStackFilters = []string{"/database/gdb/", "/gogf/gf/contrib/drivers/"}
+)
+
+// SetSlowQueryHandler sets custom handler for the slow queries of configuration `group`,
+// which replaces the default warning logging. It removes the handler if `handler` is nil.
+func SetSlowQueryHandler(group string, handler SlowQueryHandler) {
+	if handler == nil {
+		slowQueryHandlers.Remove(group)
+		return
+	}
+	slowQueryHandlers.Set(group, handler)
+}
+
+// handleSlowQuery tags the tracing span and logs the SQL or calls the custom handler,
+// if its execution duration exceeds ConfigNode.SlowThreshold.
+func (c *Core) handleSlowQuery(ctx context.Context, span trace.Span, sql *Sql) {
+	var (
+		threshold = c.db.GetConfig().SlowThreshold
+		duration  = time.Duration(sql.End-sql.Start) * time.Millisecond
+	)
+	if threshold <= 0 || duration < threshold {
+		return
+	}
+	span.SetAttributes(attribute.Bool(traceAttrDbSlowQuery, true))
+	query := &SlowQuery{
+		Sql:       sql,
+		Duration:  duration,
+		Threshold: threshold,
+		Stack:     gdebug.StackWithFilters(slowQueryStackFilters),
+	}
+	if v := slowQueryHandlers.Get(sql.Group); v != nil {
+		v.(SlowQueryHandler)(ctx, query)
+		return
+	}
+	c.logger.Warning(ctx, fmt.Sprintf(
+		"[SLOW] [%3d ms] [%s] [%s] [rows:%-3d] %s\nStack:\n%s",
+		sql.End-sql.Start, sql.Group, sql.Schema, sql.RowsAffected, sql.Format, query.Stack,
+	))
+}
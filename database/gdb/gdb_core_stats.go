@@ -13,8 +13,9 @@ import (
 )
 
 type localStatsItem struct {
-	node  *ConfigNode
-	stats sql.DBStats
+	node           *ConfigNode
+	stats          sql.DBStats
+	stmtCacheStats StmtCacheStats
 }
 
 // Node returns the configuration node info.
@@ -27,6 +28,11 @@ func (item *localStatsItem) Stats() sql.DBStats {
 	return item.stats
 }
 
+// StmtCacheStats returns the prepared statement cache stat for current node.
+func (item *localStatsItem) StmtCacheStats() StmtCacheStats {
+	return item.stmtCacheStats
+}
+
 // Stats retrieves and returns the pool stat for all nodes that have been established.
 func (c *Core) Stats(ctx context.Context) []StatsItem {
 	var items = make([]StatsItem, 0)
@@ -35,10 +41,14 @@ func (c *Core) Stats(ctx context.Context) []StatsItem {
 			node  = k.(ConfigNode)
 			sqlDB = v.(*sql.DB)
 		)
-		items = append(items, &localStatsItem{
+		item := &localStatsItem{
 			node:  &node,
 			stats: sqlDB.Stats(),
-		})
+		}
+		if cache := c.stmtCaches.Get(sqlDB); cache != nil {
+			item.stmtCacheStats = cache.(*stmtCache).Stats()
+		}
+		items = append(items, item)
 		return true
 	})
 	return items
@@ -0,0 +1,198 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+// StmtCacheStats is the stats of prepared statement cache for a configuration node.
+type StmtCacheStats struct {
+	Size      int   // Number of cached prepared statements.
+	Hits      int64 // Number of queries using cached prepared statement.
+	Misses    int64 // Number of queries preparing statement as it's not cached.
+	Evictions int64 // Number of prepared statements evicted and closed as the cache is full.
+}
+
+// stmtCache is the LRU cache of prepared statements for an underlying connection pool,
+// which is enabled by ConfigNode.StmtCacheSize.
+// Note that the sql.Stmt prepares itself on the connections of the pool automatically.
+type stmtCache struct {
+	mu        sync.Mutex
+	core      *Core
+	db        *sql.DB
+	size      int                      // Max number of cached prepared statements.
+	list      *list.List               // LRU list of *stmtCacheItem, in which the front is the most recently used.
+	items     map[string]*list.Element // SQL => element of list.
+	hits      *gtype.Int64
+	misses    *gtype.Int64
+	evictions *gtype.Int64
+}
+
+// stmtCacheItem is the cached prepared statement.
+type stmtCacheItem struct {
+	query   string
+	stmt    *sql.Stmt
+	refs    int  // Number of operations using the statement.
+	evicted bool // Evicted statement is closed after it's no longer used.
+}
+
+// newStmtCache creates and returns a prepared statement cache for `db`.
+func newStmtCache(core *Core, db *sql.DB, size int) *stmtCache {
+	return &stmtCache{
+		core:      core,
+		db:        db,
+		size:      size,
+		list:      list.New(),
+		items:     make(map[string]*list.Element),
+		hits:      gtype.NewInt64(),
+		misses:    gtype.NewInt64(),
+		evictions: gtype.NewInt64(),
+	}
+}
+
+// getStmtCache returns the prepared statement cache for `db`,
+// or nil if it's disabled by ConfigNode.StmtCacheSize.
+func (c *Core) getStmtCache(db *sql.DB) *stmtCache {
+	size := c.db.GetConfig().StmtCacheSize
+	if size <= 0 {
+		return nil
+	}
+	return c.stmtCaches.GetOrSetFuncLock(db, func() interface{} {
+		return newStmtCache(c, db, size)
+	}).(*stmtCache)
+}
+
+// ExecContext executes `query` with `args` using the cached prepared statement.
+func (s *stmtCache) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	item, err := s.get(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer s.release(item)
+	return item.stmt.ExecContext(ctx, args...)
+}
+
+// QueryContext queries `query` with `args` using the cached prepared statement.
+// The statement can be evicted before the returned rows are closed,
+// as the closing of sql.Stmt waits for the rows.
+func (s *stmtCache) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	item, err := s.get(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer s.release(item)
+	return item.stmt.QueryContext(ctx, args...)
+}
+
+// Stats returns the stats of the cache.
+func (s *stmtCache) Stats() StmtCacheStats {
+	s.mu.Lock()
+	size := s.list.Len()
+	s.mu.Unlock()
+	return StmtCacheStats{
+		Size:      size,
+		Hits:      s.hits.Val(),
+		Misses:    s.misses.Val(),
+		Evictions: s.evictions.Val(),
+	}
+}
+
+// Close closes all the cached prepared statements.
+func (s *stmtCache) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for element := s.list.Front(); element != nil; element = element.Next() {
+		s.evict(element.Value.(*stmtCacheItem))
+	}
+	s.list.Init()
+	s.items = make(map[string]*list.Element)
+}
+
+// get returns the cached prepared statement of `query`, which prepares and caches it if it's not cached.
+// The returned item should be released by function release after use.
+func (s *stmtCache) get(ctx context.Context, query string) (*stmtCacheItem, error) {
+	s.mu.Lock()
+	if element, ok := s.items[query]; ok {
+		item := element.Value.(*stmtCacheItem)
+		item.refs++
+		s.list.MoveToFront(element)
+		s.mu.Unlock()
+		s.hits.Add(1)
+		s.core.handleStmtCacheMetrics(ctx, metricManager.DbClientStmtCacheHits)
+		return item, nil
+	}
+	s.mu.Unlock()
+	s.misses.Add(1)
+	s.core.handleStmtCacheMetrics(ctx, metricManager.DbClientStmtCacheMisses)
+
+	// It prepares the statement without lock, as it costs a round trip to the server.
+	stmt, err := s.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if element, ok := s.items[query]; ok {
+		// It was prepared and cached by another goroutine.
+		if err = stmt.Close(); err != nil {
+			intlog.Errorf(ctx, `%+v`, err)
+		}
+		item := element.Value.(*stmtCacheItem)
+		item.refs++
+		s.list.MoveToFront(element)
+		return item, nil
+	}
+	item := &stmtCacheItem{
+		query: query,
+		stmt:  stmt,
+		refs:  1,
+	}
+	s.items[query] = s.list.PushFront(item)
+	for s.list.Len() > s.size {
+		element := s.list.Back()
+		s.list.Remove(element)
+		delete(s.items, element.Value.(*stmtCacheItem).query)
+		s.evict(element.Value.(*stmtCacheItem))
+		s.evictions.Add(1)
+		s.core.handleStmtCacheMetrics(ctx, metricManager.DbClientStmtCacheEvictions)
+	}
+	return item, nil
+}
+
+// release releases the item that is returned by function get,
+// which closes the statement if it's evicted and no longer used.
+func (s *stmtCache) release(item *stmtCacheItem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item.refs--
+	if item.evicted && item.refs == 0 {
+		s.closeStmt(item)
+	}
+}
+
+// evict marks the item evicted, and closes its statement if it's not in use.
+// Note that the caller should hold the lock.
+func (s *stmtCache) evict(item *stmtCacheItem) {
+	item.evicted = true
+	if item.refs == 0 {
+		s.closeStmt(item)
+	}
+}
+
+// closeStmt closes the statement of item.
+func (s *stmtCache) closeStmt(item *stmtCacheItem) {
+	if err := item.stmt.Close(); err != nil {
+		intlog.Errorf(context.Background(), `%+v`, err)
+	}
+}
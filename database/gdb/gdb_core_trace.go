@@ -33,9 +33,32 @@ const (
 	traceEventDbExecutionRows = "db.execution.rows"
 	traceEventDbExecutionTxID = "db.execution.txid"
 	traceEventDbExecutionType = "db.execution.type"
+	traceAttrDbRowsAffected   = "db.rows_affected"
 )
 
-// addSqlToTracing adds sql information to tracer if it's enabled.
+// traceDbSystems maps the database type of configuration to OpenTelemetry semantic db.system value.
+var traceDbSystems = map[string]attribute.KeyValue{
+	"mysql":      semconv.DBSystemMySQL,
+	"mariadb":    semconv.DBSystemMariaDB,
+	"tidb":       semconv.DBSystemMySQL,
+	"pgsql":      semconv.DBSystemPostgreSQL,
+	"mssql":      semconv.DBSystemMSSQL,
+	"sqlite":     semconv.DBSystemSqlite,
+	"oracle":     semconv.DBSystemOracle,
+	"clickhouse": semconv.DBSystemClickhouse,
+}
+
+// traceDbSystem returns the OpenTelemetry semantic db.system attribute for current database.
+func (c *Core) traceDbSystem() attribute.KeyValue {
+	if v, ok := traceDbSystems[c.db.GetConfig().Type]; ok {
+		return v
+	}
+	return semconv.DBSystemOtherSQL
+}
+
+// traceSpanEnd adds sql information to tracer if it's enabled.
+// Note that the statement attribute is the sql with placeholders but without argument values,
+// so that no sensitive data is leaked to the tracing backend.
 func (c *Core) traceSpanEnd(ctx context.Context, span trace.Span, sql *Sql) {
 	if gtrace.IsUsingDefaultProvider() || !gtrace.IsTracingInternal() {
 		return
@@ -46,8 +69,10 @@ func (c *Core) traceSpanEnd(ctx context.Context, span trace.Span, sql *Sql) {
 	labels := make([]attribute.KeyValue, 0)
 	labels = append(labels, gtrace.CommonLabels()...)
 	labels = append(labels,
+		c.traceDbSystem(),
 		attribute.String(traceAttrDbType, c.db.GetConfig().Type),
-		semconv.DBStatement(sql.Format),
+		semconv.DBStatement(sql.Sql),
+		attribute.Int64(traceAttrDbRowsAffected, sql.RowsAffected),
 	)
 	if c.db.GetConfig().Host != "" {
 		labels = append(labels, attribute.String(traceAttrDbHost, c.db.GetConfig().Host))
@@ -21,13 +21,14 @@ import (
 
 // TXCore is the struct for transaction management.
 type TXCore struct {
-	db               DB              // db is the current gdb database manager.
-	tx               *sql.Tx         // tx is the raw and underlying transaction manager.
-	ctx              context.Context // ctx is the context for this transaction only.
-	master           *sql.DB         // master is the raw and underlying database manager.
-	transactionId    string          // transactionId is a unique id generated by this object for this transaction.
-	transactionCount int             // transactionCount marks the times that Begins.
-	isClosed         bool            // isClosed marks this transaction has already been committed or rolled back.
+	db               DB                          // db is the current gdb database manager.
+	tx               *sql.Tx                     // tx is the raw and underlying transaction manager.
+	ctx              context.Context             // ctx is the context for this transaction only.
+	master           *sql.DB                     // master is the raw and underlying database manager.
+	transactionId    string                      // transactionId is a unique id generated by this object for this transaction.
+	transactionCount int                         // transactionCount marks the times that Begins.
+	isClosed         bool                        // isClosed marks this transaction has already been committed or rolled back.
+	afterCommit      []func(ctx context.Context) // afterCommit is the functions called after the transaction is committed.
 }
 
 const (
@@ -36,6 +37,11 @@ const (
 	transactionIdForLoggerCtx   = "TransactionId"
 )
 
+const (
+	TxNestedPolicySavepoint = "savepoint" // Nested transaction creates a savepoint, and rollbacks to it on error, which is the default policy.
+	TxNestedPolicyJoin      = "join"      // Nested transaction joins the outer transaction without savepoint, whose error rollbacks the outer transaction.
+)
+
 var transactionIdGenerator = gtype.NewUint64()
 
 // Begin starts and returns the transaction object.
@@ -68,6 +74,8 @@ func (c *Core) doBeginCtx(ctx context.Context) (TX, error) {
 //
 // Note that, you should not Commit or Rollback the transaction in function `f`
 // as it is automatically handled by this function.
+//
+// It starts a nested transaction if there's already transaction in `ctx`, see TXCore.Transaction.
 func (c *Core) Transaction(ctx context.Context, f func(ctx context.Context, tx TX) error) (err error) {
 	if ctx == nil {
 		ctx = c.db.GetCtx()
@@ -197,6 +205,10 @@ func (tx *TXCore) Commit() error {
 	})
 	if err == nil {
 		tx.isClosed = true
+		for _, f := range tx.afterCommit {
+			f(tx.ctx)
+		}
+		tx.afterCommit = nil
 	}
 	return err
 }
@@ -218,6 +230,7 @@ func (tx *TXCore) Rollback() error {
 	})
 	if err == nil {
 		tx.isClosed = true
+		tx.afterCommit = nil
 	}
 	return err
 }
@@ -258,6 +271,11 @@ func (tx *TXCore) RollbackTo(point string) error {
 //
 // Note that, you should not Commit or Rollback the transaction in function `f`
 // as it is automatically handled by this function.
+//
+// The nested transaction behaves as ConfigNode.TxNestedPolicy, which creates a savepoint
+// and rollbacks to it if function `f` returns error in default, so that the outer transaction
+// can go on. It calls function `f` in the outer transaction without savepoint
+// if the policy is TxNestedPolicyJoin.
 func (tx *TXCore) Transaction(ctx context.Context, f func(ctx context.Context, tx TX) error) (err error) {
 	if ctx != nil {
 		tx.ctx = ctx
@@ -267,6 +285,9 @@ func (tx *TXCore) Transaction(ctx context.Context, f func(ctx context.Context, t
 		// Inject transaction object into context.
 		tx.ctx = WithTX(tx.ctx, tx)
 	}
+	if tx.db.GetConfig().TxNestedPolicy == TxNestedPolicyJoin {
+		return f(tx.ctx, tx)
+	}
 	err = tx.Begin()
 	if err != nil {
 		return err
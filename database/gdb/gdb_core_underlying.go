@@ -83,6 +83,18 @@ func (c *Core) DoQuery(ctx context.Context, link Link, sql string, args ...inter
 		Type:          SqlTypeQueryContext,
 		IsTransaction: link.IsTransaction(),
 	})
+	// It fails over to another node if the slave node fails with retryable error,
+	// like the refused or broken connection.
+	if err != nil {
+		if failoverLink := c.getReplicaFailoverLink(ctx, link, err); failoverLink != nil {
+			out, err = c.db.DoCommit(ctx, DoCommitInput{
+				Link: failoverLink,
+				Sql:  sql,
+				Args: args,
+				Type: SqlTypeQueryContext,
+			})
+		}
+	}
 	return out.Records, err
 }
 
@@ -117,6 +129,18 @@ func (c *Core) DoExec(ctx context.Context, link Link, sql string, args ...interf
 		defer cancelFunc()
 	}
 
+	// RETURNING clause, which executes the statement as query.
+	var (
+		sqlType   = SqlTypeExecContext
+		returning = getReturningFromCtx(ctx)
+	)
+	if returning != nil && !c.db.GetDryRun() {
+		if returningSql := c.db.FormatReturning(sql, returning.Columns); returningSql != "" {
+			sql, sqlType = returningSql, SqlTypeQueryContext
+		} else if err = returning.callFallback(ctx, link); err != nil {
+			return nil, err
+		}
+	}
 	// SQL filtering.
 	sql, args = c.FormatSqlBeforeExecuting(sql, args)
 	sql, args, err = c.db.DoFilter(ctx, link, sql, args)
@@ -141,10 +165,19 @@ func (c *Core) DoExec(ctx context.Context, link Link, sql string, args ...interf
 		Sql:           sql,
 		Args:          args,
 		Stmt:          nil,
-		Type:          SqlTypeExecContext,
+		Type:          sqlType,
 		IsTransaction: link.IsTransaction(),
 	})
-	return out.Result, err
+	if err != nil {
+		return out.Result, err
+	}
+	c.markReplicaWrite(ctx)
+	if sqlType == SqlTypeQueryContext {
+		returning.Returned = true
+		returning.Records = append(returning.Records, out.Records...)
+		return &SqlResult{Affected: int64(len(out.Records)), Records: out.Records}, nil
+	}
+	return out.Result, nil
 }
 
 // DoFilter is a hook function, which filters the sql and its arguments before it's committed to underlying driver.
@@ -171,7 +204,7 @@ func (c *Core) DoCommit(ctx context.Context, in DoCommitInput) (out DoCommitOutp
 
 	// Trace span start.
 	tr := otel.GetTracerProvider().Tracer(traceInstrumentName, trace.WithInstrumentationVersion(gf.VERSION))
-	ctx, span := tr.Start(ctx, string(in.Type), trace.WithSpanKind(trace.SpanKindInternal))
+	ctx, span := tr.Start(ctx, string(in.Type), trace.WithSpanKind(trace.SpanKindClient))
 	defer span.End()
 
 	// Execution cased by type.
@@ -242,6 +275,9 @@ func (c *Core) DoCommit(ctx context.Context, in DoCommitInput) (out DoCommitOutp
 		rowsAffected, err = sqlResult.RowsAffected()
 		out.Result = sqlResult
 
+	case sqlRows != nil && ctx.Value(ctxKeyCursor) != nil:
+		// The rows are read by Cursor.
+
 	case sqlRows != nil:
 		out.Records, err = c.RowsToResult(ctx, sqlRows)
 		rowsAffected = int64(len(out.Records))
@@ -274,6 +310,12 @@ func (c *Core) DoCommit(ctx context.Context, in DoCommitInput) (out DoCommitOutp
 	// Tracing.
 	c.traceSpanEnd(ctx, span, sqlObj)
 
+	// Metrics.
+	c.handleMetricsAfterCommit(ctx, sqlObj)
+
+	// Slow query.
+	c.handleSlowQuery(ctx, span, sqlObj)
+
 	// Logging.
 	if c.db.GetDebug() {
 		c.writeSqlToLogger(ctx, sqlObj)
@@ -350,6 +392,36 @@ func (c *Core) DoPrepare(ctx context.Context, link Link, sql string) (stmt *Stmt
 	return out.Stmt, err
 }
 
+// FormatJSON formats and returns SQL expression of `operation` on JSON `column` at `path`,
+// in which the items of `path` are object keys or array indexes.
+// The path is bound as placeholder of the expression, whose value is returned as `args`.
+// Note that the placeholders of `args` are in front of the value placeholder of JSONOperationContains.
+// In default implements, this function performs JSON functions of MySQL like:
+// `JSON_UNQUOTE(JSON_EXTRACT(column, ?))` and `JSON_CONTAINS(JSON_EXTRACT(column, ?), ?)`.
+func (c *Core) FormatJSON(operation JSONOperation, column string, path []string) (expr string, args []interface{}) {
+	switch operation {
+	case JSONOperationContains:
+		if len(path) == 0 {
+			return fmt.Sprintf(`JSON_CONTAINS(%s, ?)`, column), nil
+		}
+		return fmt.Sprintf(`JSON_CONTAINS(JSON_EXTRACT(%s, ?), ?)`, column), []interface{}{FormatJSONPath(path)}
+
+	default:
+		if len(path) == 0 {
+			return column, nil
+		}
+		return fmt.Sprintf(`JSON_UNQUOTE(JSON_EXTRACT(%s, ?))`, column), []interface{}{FormatJSONPath(path)}
+	}
+}
+
+// FormatReturning formats and returns the INSERT/UPDATE/DELETE statement `sql` with RETURNING clause
+// of quoted `columns`, which returns the changed records in one round trip.
+// In default implements, it returns empty string as MySQL does not support RETURNING clause,
+// in which case Model.Returning falls back to querying the records.
+func (c *Core) FormatReturning(sql string, columns []string) string {
+	return ""
+}
+
 // FormatUpsert formats and returns SQL clause part for upsert statement.
 // In default implements, this function performs upsert statement for MySQL like:
 // `INSERT INTO ... ON DUPLICATE KEY UPDATE x=VALUES(z),m=VALUES(y)...`
@@ -433,19 +505,9 @@ func (c *Core) RowsToResult(ctx context.Context, rows *sql.Rows) (Result, error)
 		if err = rows.Scan(scanArgs...); err != nil {
 			return result, err
 		}
-		record := Record{}
-		for i, value := range values {
-			if value == nil {
-				// DO NOT use `gvar.New(nil)` here as it creates an initialized object
-				// which will cause struct converting issue.
-				record[columnTypes[i].Name()] = nil
-			} else {
-				var convertedValue interface{}
-				if convertedValue, err = c.columnValueToLocalValue(ctx, value, columnTypes[i]); err != nil {
-					return nil, err
-				}
-				record[columnTypes[i].Name()] = gvar.New(convertedValue)
-			}
+		record, err := c.valuesToRecord(ctx, values, columnTypes)
+		if err != nil {
+			return nil, err
 		}
 		result = append(result, record)
 		if !rows.Next() {
@@ -455,6 +517,25 @@ func (c *Core) RowsToResult(ctx context.Context, rows *sql.Rows) (Result, error)
 	return result, nil
 }
 
+// valuesToRecord converts the scanned values of a row to Record.
+func (c *Core) valuesToRecord(ctx context.Context, values []interface{}, columnTypes []*sql.ColumnType) (Record, error) {
+	record := Record{}
+	for i, value := range values {
+		if value == nil {
+			// DO NOT use `gvar.New(nil)` here as it creates an initialized object
+			// which will cause struct converting issue.
+			record[columnTypes[i].Name()] = nil
+		} else {
+			convertedValue, err := c.columnValueToLocalValue(ctx, value, columnTypes[i])
+			if err != nil {
+				return nil, err
+			}
+			record[columnTypes[i].Name()] = gvar.New(convertedValue)
+		}
+	}
+	return record, nil
+}
+
 func (c *Core) columnValueToLocalValue(ctx context.Context, value interface{}, columnType *sql.ColumnType) (interface{}, error) {
 	var scanType = columnType.ScanType()
 	if scanType != nil {
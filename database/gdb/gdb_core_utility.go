@@ -13,6 +13,8 @@ import (
 
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/gogf/gf/v2/text/gregex"
 	"github.com/gogf/gf/v2/text/gstr"
 	"github.com/gogf/gf/v2/util/gutil"
@@ -52,9 +54,11 @@ func (c *Core) MasterLink(schema ...string) (Link, error) {
 	if err != nil {
 		return nil, err
 	}
+	c.checkPoolExhausted(db)
 	return &dbLink{
 		DB:         db,
 		isOnMaster: true,
+		stmtCache:  c.getStmtCache(db),
 	}, nil
 }
 
@@ -66,9 +70,13 @@ func (c *Core) SlaveLink(schema ...string) (Link, error) {
 	if err != nil {
 		return nil, err
 	}
+	c.checkPoolExhausted(db)
 	return &dbLink{
 		DB:         db,
 		isOnMaster: false,
+		stmtCache:  c.getStmtCache(db),
+		schema:     gutil.GetOrDefaultStr("", schema...),
+		replicaKey: c.getReplicaNodeKeyByDb(db),
 	}, nil
 }
 
@@ -188,6 +196,31 @@ func (c *Core) ClearCache(ctx context.Context, table string) (err error) {
 	return
 }
 
+// ClearCacheByTags invalidates the cached sql results tagged with any of `tags`, see CacheOption.Tags.
+// It works with any cache adapter, as it changes the versions of the tags instead of removing the results.
+func (c *Core) ClearCacheByTags(ctx context.Context, tags ...string) error {
+	var cacheObj = c.db.GetCache()
+	for _, tag := range tags {
+		// The tag that is never used by caching is ignored.
+		_, _, err := cacheObj.Update(ctx, genSelectCacheTagKey(c.db.GetGroup(), tag), gtime.TimestampNano())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isCacheTagUsed checks and returns whether `tag` may be used by the cached sql results.
+// It is false only if the tag is never used in current process and the cache is in memory,
+// as the cache of other adapters like redis may be shared with other processes.
+func (c *Core) isCacheTagUsed(tag string) bool {
+	if c.cacheTags.Contains(tag) {
+		return true
+	}
+	_, ok := c.db.GetCache().GetAdapter().(*gcache.AdapterMemory)
+	return !ok
+}
+
 // ClearCacheAll removes all cached sql result from cache
 func (c *Core) ClearCacheAll(ctx context.Context) (err error) {
 	if err = c.db.GetCache().Clear(ctx); err != nil {
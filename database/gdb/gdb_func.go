@@ -72,6 +72,12 @@ var (
 	// quoteWordReg is the regular expression object for a word check.
 	quoteWordReg = regexp.MustCompile(`^[a-zA-Z0-9\-_]+$`)
 
+	// jsonPathKeyReg is the regular expression for JSON path key that needs no quoting.
+	jsonPathKeyReg = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	// jsonPathKeyReplacer escapes the quoted JSON path key.
+	jsonPathKeyReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
 	// structTagPriority tags for struct converting for orm field mapping.
 	structTagPriority = append([]string{OrmTagForStruct}, gtag.StructTagPriority...)
 )
@@ -947,6 +953,45 @@ func FormatMultiLineSqlToSingle(sql string) (string, error) {
 	return sql, nil
 }
 
+// FormatJSONPath formats JSON `path` items to path expression of SQL standard like `$.a[0]."b c"`,
+// which should be bound as argument of SQL statement but not be embedded in the statement.
+// The item is treated as array index if it's a number.
+func FormatJSONPath(path []string) string {
+	var buffer = bytes.NewBufferString("$")
+	for _, item := range path {
+		switch {
+		case gstr.IsNumeric(item):
+			buffer.WriteString(fmt.Sprintf(`[%s]`, item))
+		case jsonPathKeyReg.MatchString(item):
+			buffer.WriteString("." + item)
+		default:
+			buffer.WriteString(fmt.Sprintf(`."%s"`, jsonPathKeyReplacer.Replace(item)))
+		}
+	}
+	return buffer.String()
+}
+
+// parseJSONPath parses `path` like "extra.address.city" or "extra.tags[0]",
+// and returns the JSON column and the path items in the column.
+func parseJSONPath(path string) (column string, items []string) {
+	for i, part := range gstr.Split(path, ".") {
+		var indexes []string
+		if pos := gstr.Pos(part, "["); pos >= 0 {
+			for _, index := range gstr.Split(part[pos+1:], "[") {
+				indexes = append(indexes, gstr.Trim(index, "]"))
+			}
+			part = part[:pos]
+		}
+		if i == 0 {
+			column = part
+		} else {
+			items = append(items, part)
+		}
+		items = append(items, indexes...)
+	}
+	return
+}
+
 func genTableFieldsCacheKey(group, schema, table string) string {
 	return fmt.Sprintf(
 		`%s%s@%s#%s`,
@@ -969,3 +1014,7 @@ func genSelectCacheKey(table, group, schema, name, sql string, args ...interface
 	}
 	return fmt.Sprintf(`%s%s`, cachePrefixSelectCache, name)
 }
+
+func genSelectCacheTagKey(group, tag string) string {
+	return fmt.Sprintf(`%s%s@%s`, cachePrefixSelectCacheTag, group, tag)
+}
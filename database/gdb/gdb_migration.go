@@ -0,0 +1,648 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/text/gregex"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+const (
+	defaultMigrationTable       = "schema_migrations"
+	defaultMigrationLockTable   = "schema_migrations_lock"
+	defaultMigrationLockTimeout = time.Minute
+	defaultMigrationLockTTL     = 10 * time.Minute
+	migrationLockRetryInterval  = 200 * time.Millisecond
+	migrationLockId             = 1
+	migrationFilePattern        = `^(\d+)_(.+)\.(up|down)\.sql$`
+)
+
+// MigrationFunc is the Go function of a migration, which is called in transaction `tx`.
+type MigrationFunc func(ctx context.Context, tx TX) error
+
+// Migration is a versioned schema migration using SQL or Go functions.
+// The SQL is executed before the Go function if both are given.
+type Migration struct {
+	Version int64         // Unique version, which is recommended using time like 20240102150405.
+	Name    string        // Readable name like "create_user".
+	UpSQL   string        // SQL statements applying the migration, which are separated by ";", see splitMigrationSql.
+	DownSQL string        // SQL statements reverting the migration, which are separated by ";", see splitMigrationSql.
+	Up      MigrationFunc // Go function applying the migration.
+	Down    MigrationFunc // Go function reverting the migration.
+}
+
+// MigrationStatus is the status of a migration.
+type MigrationStatus struct {
+	Version   int64  // Version of the migration.
+	Name      string // Name of the migration.
+	Applied   bool   // Whether the migration is applied.
+	AppliedAt string // Time the migration applied, which is empty if it is not applied.
+}
+
+// MigratorOption is the option for NewMigrator.
+type MigratorOption struct {
+	Table       string        // Table of the applied versions, which is "schema_migrations" in default.
+	LockTable   string        // Table of the lock coordinating migrators, which is "schema_migrations_lock" in default.
+	LockTimeout time.Duration // Max duration waiting for the lock held by others, which is 1 minute in default.
+	LockTTL     time.Duration // Duration after which the lock not renewed is stale and released, which is 10 minutes in default.
+	DryRun      bool          // Logging the pending migrations and their SQL without applying them.
+}
+
+// Migrator manages the versioned schema migrations of a database.
+type Migrator struct {
+	db         DB
+	option     MigratorOption
+	migrations map[int64]*Migration
+}
+
+// NewMigrator creates and returns a migrator of `db`, eg:
+//
+//	migrator := gdb.NewMigrator(g.DB())
+//	if err := migrator.AddPath("manifest/migrations"); err != nil {
+//	    return err
+//	}
+//	migrated, err := migrator.Up(ctx)
+//
+// The applied versions are stored in table "schema_migrations", and the migrators of multiple replicas are
+// coordinated using the lock stored in table "schema_migrations_lock", so that they do not race on startup.
+// The lock is renewed periodically while migrating, and the migrating is canceled if the lock is lost.
+// Each migration is applied in a transaction, but note that the DDL of some databases like MySQL
+// commits the transaction implicitly.
+func NewMigrator(db DB, option ...MigratorOption) *Migrator {
+	m := &Migrator{
+		db:         db,
+		migrations: make(map[int64]*Migration),
+	}
+	if len(option) > 0 {
+		m.option = option[0]
+	}
+	if m.option.Table == "" {
+		m.option.Table = defaultMigrationTable
+	}
+	if m.option.LockTable == "" {
+		m.option.LockTable = defaultMigrationLockTable
+	}
+	if m.option.LockTimeout <= 0 {
+		m.option.LockTimeout = defaultMigrationLockTimeout
+	}
+	if m.option.LockTTL <= 0 {
+		m.option.LockTTL = defaultMigrationLockTTL
+	}
+	return m
+}
+
+// Add adds migrations to the migrator, which returns error if any version is added already.
+func (m *Migrator) Add(migrations ...Migration) error {
+	for _, migration := range migrations {
+		if migration.Version <= 0 {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, `invalid migration version "%d"`, migration.Version)
+		}
+		if _, ok := m.migrations[migration.Version]; ok {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, `duplicated migration version "%d"`, migration.Version)
+		}
+		migration := migration
+		m.migrations[migration.Version] = &migration
+	}
+	return nil
+}
+
+// AddPath adds the migrations of SQL files in directory `path`, which are named like "{version}_{name}.up.sql"
+// and "{version}_{name}.down.sql", eg: "20240102150405_create_user.up.sql".
+func (m *Migrator) AddPath(path string) error {
+	files, err := gfile.ScanDirFile(path, "*.sql")
+	if err != nil {
+		return err
+	}
+	migrations := make(map[int64]*Migration)
+	for _, file := range files {
+		match, _ := gregex.MatchString(migrationFilePattern, gfile.Basename(file))
+		if len(match) == 0 {
+			continue
+		}
+		version := gconv.Int64(match[1])
+		migration, ok := migrations[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			migrations[version] = migration
+		} else if migration.Name != match[2] {
+			return gerror.NewCodef(
+				gcode.CodeInvalidParameter,
+				`duplicated migration version "%d" of names "%s" and "%s"`,
+				version, migration.Name, match[2],
+			)
+		}
+		if match[3] == "up" {
+			migration.UpSQL = gfile.GetContents(file)
+		} else {
+			migration.DownSQL = gfile.GetContents(file)
+		}
+	}
+	for _, migration := range migrations {
+		if err = m.Add(*migration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Up applies the pending migrations in order of version, and returns the applied ones.
+// It applies at most `steps` migrations if `steps` is given and greater than 0.
+func (m *Migrator) Up(ctx context.Context, steps ...int) (migrated []Migration, err error) {
+	err = m.run(ctx, func(ctx context.Context, applied map[int64]string) error {
+		for _, migration := range m.sortedMigrations() {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if len(steps) > 0 && steps[0] > 0 && len(migrated) >= steps[0] {
+				break
+			}
+			if err := m.apply(ctx, migration, true); err != nil {
+				return err
+			}
+			migrated = append(migrated, *migration)
+		}
+		return nil
+	})
+	return
+}
+
+// Down reverts the applied migrations in reverse order of version, and returns the reverted ones.
+// It reverts only the last applied migration if `steps` is not given, and all if `steps` is less than 0.
+func (m *Migrator) Down(ctx context.Context, steps ...int) (migrated []Migration, err error) {
+	limit := 1
+	if len(steps) > 0 && steps[0] != 0 {
+		limit = steps[0]
+	}
+	err = m.run(ctx, func(ctx context.Context, applied map[int64]string) error {
+		versions := make([]int64, 0, len(applied))
+		for version := range applied {
+			versions = append(versions, version)
+		}
+		sort.Slice(versions, func(i, j int) bool {
+			return versions[i] > versions[j]
+		})
+		for _, version := range versions {
+			if limit > 0 && len(migrated) >= limit {
+				break
+			}
+			migration, ok := m.migrations[version]
+			if !ok {
+				return gerror.NewCodef(gcode.CodeNotFound, `migration of applied version "%d" not found`, version)
+			}
+			if err := m.apply(ctx, migration, false); err != nil {
+				return err
+			}
+			migrated = append(migrated, *migration)
+		}
+		return nil
+	})
+	return
+}
+
+// Status returns the status of all migrations in order of version,
+// including the applied versions that are not added to the migrator.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.getApplied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		appliedAt, ok := applied[migration.Version]
+		statuses = append(statuses, MigrationStatus{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		})
+	}
+	for version, appliedAt := range applied {
+		if _, ok := m.migrations[version]; !ok {
+			statuses = append(statuses, MigrationStatus{Version: version, Applied: true, AppliedAt: appliedAt})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses, nil
+}
+
+// run calls `f` with the applied versions holding the lock, which creates the tables of migrator if necessary.
+// It calls `f` without lock in dry-run mode.
+func (m *Migrator) run(ctx context.Context, f func(ctx context.Context, applied map[int64]string) error) error {
+	if m.option.DryRun {
+		applied, err := m.getApplied(ctx)
+		if err != nil {
+			return err
+		}
+		return f(ctx, applied)
+	}
+	if err := m.createTables(ctx); err != nil {
+		return err
+	}
+	owner, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer m.unlock(ctx, owner)
+
+	// The lock is renewed until `f` returns, and `f` is canceled if the lock is lost.
+	var (
+		lockCtx, cancel = context.WithCancel(ctx)
+		lockLost        = gtype.NewBool()
+		renewDone       = make(chan struct{})
+	)
+	go func() {
+		defer close(renewDone)
+		if !m.renewLock(lockCtx, owner) {
+			lockLost.Set(true)
+			cancel()
+		}
+	}()
+	defer func() {
+		cancel()
+		<-renewDone
+	}()
+
+	applied, err := m.getApplied(lockCtx)
+	if err == nil {
+		err = f(lockCtx, applied)
+	}
+	if err != nil && lockLost.Val() {
+		return gerror.WrapCode(gcode.CodeOperationFailed, err, `migration lock is lost`)
+	}
+	return err
+}
+
+// apply applies or reverts `migration` in transaction.
+func (m *Migrator) apply(ctx context.Context, migration *Migration, up bool) error {
+	var (
+		direction = "down"
+		sqlText   = migration.DownSQL
+		fn        = migration.Down
+	)
+	if up {
+		direction, sqlText, fn = "up", migration.UpSQL, migration.Up
+	}
+	if m.option.DryRun {
+		m.db.GetLogger().Infof(
+			ctx, "[dry-run] migrate %s %d_%s\n%s", direction, migration.Version, migration.Name, sqlText,
+		)
+		return nil
+	}
+	err := m.db.Transaction(ctx, func(ctx context.Context, tx TX) error {
+		for _, statement := range splitMigrationSql(sqlText) {
+			if _, err := tx.Exec(statement); err != nil {
+				return err
+			}
+		}
+		if fn != nil {
+			if err := fn(ctx, tx); err != nil {
+				return err
+			}
+		}
+		var (
+			err   error
+			table = m.db.GetCore().QuoteWord(m.option.Table)
+		)
+		if up {
+			_, err = tx.Exec(
+				fmt.Sprintf(`INSERT INTO %s(version,name,applied_at) VALUES(?,?,?)`, table),
+				migration.Version, migration.Name, gtime.Now().String(),
+			)
+		} else {
+			_, err = tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE version=?`, table), migration.Version)
+		}
+		return err
+	})
+	if err != nil {
+		return gerror.Wrapf(err, `migrate %s %d_%s failed`, direction, migration.Version, migration.Name)
+	}
+	m.db.GetLogger().Infof(ctx, "migrate %s %d_%s", direction, migration.Version, migration.Name)
+	return nil
+}
+
+// getApplied returns the applied versions and their applied time.
+func (m *Migrator) getApplied(ctx context.Context) (map[int64]string, error) {
+	applied := make(map[int64]string)
+	exists, err := m.hasTable(ctx, m.option.Table)
+	if err != nil || !exists {
+		return applied, err
+	}
+	result, err := m.db.GetAll(ctx, fmt.Sprintf(
+		`SELECT version,applied_at FROM %s`, m.db.GetCore().QuoteWord(m.option.Table),
+	))
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range result {
+		applied[record["version"].Int64()] = record["applied_at"].String()
+	}
+	return applied, nil
+}
+
+// createTables creates the tables of migrator if they do not exist.
+func (m *Migrator) createTables(ctx context.Context) error {
+	var (
+		core       = m.db.GetCore()
+		bigintType = "BIGINT"
+	)
+	if strings.EqualFold(m.db.GetConfig().Type, "oracle") {
+		bigintType = "NUMBER(20)"
+	}
+	tables := map[string]string{
+		m.option.Table: fmt.Sprintf(
+			`CREATE TABLE %s(version %s NOT NULL PRIMARY KEY, name VARCHAR(255) NOT NULL, applied_at VARCHAR(64) NOT NULL)`,
+			core.QuoteWord(m.option.Table), bigintType,
+		),
+		m.option.LockTable: fmt.Sprintf(
+			`CREATE TABLE %s(id INTEGER NOT NULL PRIMARY KEY, owner VARCHAR(64) NOT NULL, locked_at %s NOT NULL)`,
+			core.QuoteWord(m.option.LockTable), bigintType,
+		),
+	}
+	for table, createSql := range tables {
+		exists, err := m.hasTable(ctx, table)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if _, err = m.db.Exec(ctx, createSql); err != nil {
+			// It might be created by another migrator just now.
+			if exists, _ = m.hasTable(ctx, table); !exists {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasTable checks whether `table` exists.
+func (m *Migrator) hasTable(ctx context.Context, table string) (bool, error) {
+	tables, err := m.db.Tables(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, t := range tables {
+		if strings.EqualFold(t, table) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// lock acquires the lock of migrators, which waits for the lock held by others until LockTimeout,
+// and releases the stale lock older than LockTTL. It returns the owner id of the lock.
+func (m *Migrator) lock(ctx context.Context) (string, error) {
+	var (
+		owner     = guid.S()
+		table     = m.db.GetCore().QuoteWord(m.option.LockTable)
+		startTime = time.Now()
+	)
+	for {
+		_, err := m.db.Exec(
+			ctx,
+			fmt.Sprintf(`INSERT INTO %s(id,owner,locked_at) VALUES(?,?,?)`, table),
+			migrationLockId, owner, time.Now().Unix(),
+		)
+		if err == nil {
+			return owner, nil
+		}
+		// The stale lock is released, which might be held by a crashed migrator.
+		_, _ = m.db.Exec(
+			ctx,
+			fmt.Sprintf(`DELETE FROM %s WHERE id=? AND locked_at<?`, table),
+			migrationLockId, time.Now().Add(-m.option.LockTTL).Unix(),
+		)
+		if time.Since(startTime) > m.option.LockTimeout {
+			return "", gerror.WrapCodef(
+				gcode.CodeOperationFailed, err,
+				`waiting for migration lock timeout after %s`, m.option.LockTimeout,
+			)
+		}
+		timer := time.NewTimer(migrationLockRetryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// renewLock renews the lock of `owner` periodically until `ctx` is done, so that it is not released as stale.
+// It returns false if the lock is lost, which might be released by others as stale.
+func (m *Migrator) renewLock(ctx context.Context, owner string) bool {
+	ticker := time.NewTicker(m.option.LockTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case <-ticker.C:
+		}
+		result, err := m.db.Exec(
+			ctx,
+			fmt.Sprintf(`UPDATE %s SET locked_at=? WHERE id=? AND owner=?`, m.db.GetCore().QuoteWord(m.option.LockTable)),
+			time.Now().Unix(), migrationLockId, owner,
+		)
+		if err != nil {
+			// It retries in next period, as the error might be temporary.
+			if ctx.Err() == nil {
+				m.db.GetLogger().Errorf(ctx, `renew migration lock failed: %+v`, err)
+			}
+			continue
+		}
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			m.db.GetLogger().Errorf(ctx, `migration lock of owner "%s" is lost`, owner)
+			return false
+		}
+	}
+}
+
+// unlock releases the lock of `owner`.
+func (m *Migrator) unlock(ctx context.Context, owner string) {
+	_, err := m.db.Exec(
+		ctx,
+		fmt.Sprintf(`DELETE FROM %s WHERE id=? AND owner=?`, m.db.GetCore().QuoteWord(m.option.LockTable)),
+		migrationLockId, owner,
+	)
+	if err != nil {
+		m.db.GetLogger().Errorf(ctx, `release migration lock failed: %+v`, err)
+	}
+}
+
+// sortedMigrations returns the migrations sorted by version.
+func (m *Migrator) sortedMigrations() []*Migration {
+	migrations := make([]*Migration, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations
+}
+
+// splitMigrationSql splits `sqlText` into statements, which are separated by ";" out of the string literals,
+// quoted identifiers, dollar-quoted strings and comments. The line comments starting with "--" are removed.
+//
+// The statements containing ";" like stored procedures are separated by the delimiter set using
+// the directive line "DELIMITER" like the MySQL client, eg:
+//
+//	DELIMITER $$
+//	CREATE PROCEDURE p() BEGIN SELECT 1; SELECT 2; END$$
+//	DELIMITER ;
+func splitMigrationSql(sqlText string) []string {
+	var (
+		statements = make([]string, 0)
+		buffer     strings.Builder
+		delimiter  = ";"
+		hasContent bool // Whether the buffer contains anything other than spaces and comments.
+		lineStart  = true
+		length     = len(sqlText)
+	)
+	flush := func() {
+		if statement := strings.TrimSpace(buffer.String()); hasContent && statement != "" {
+			statements = append(statements, statement)
+		}
+		buffer.Reset()
+		hasContent = false
+	}
+	for i := 0; i < length; {
+		// The delimiter directive is a whole line out of statement.
+		if lineStart && !hasContent {
+			end := strings.IndexByte(sqlText[i:], '\n')
+			if end < 0 {
+				end = length
+			} else {
+				end += i
+			}
+			line := strings.TrimSpace(sqlText[i:end])
+			if fields := strings.Fields(line); len(fields) == 2 && strings.EqualFold(fields[0], "DELIMITER") {
+				delimiter = fields[1]
+				i = end
+				continue
+			}
+		}
+		lineStart = false
+		c := sqlText[i]
+		switch {
+		case strings.HasPrefix(sqlText[i:], delimiter):
+			flush()
+			i += len(delimiter)
+			continue
+
+		case c == '\n':
+			lineStart = true
+
+		case c == '-' && strings.HasPrefix(sqlText[i:], "--"):
+			end := strings.IndexByte(sqlText[i:], '\n')
+			if end < 0 {
+				i = length
+			} else {
+				i += end
+			}
+			continue
+
+		case c == '/' && strings.HasPrefix(sqlText[i:], "/*"):
+			end := strings.Index(sqlText[i+2:], "*/")
+			if end < 0 {
+				end = length
+			} else {
+				end += i + 4
+			}
+			buffer.WriteString(sqlText[i:end])
+			i = end
+			continue
+
+		case c == '\'' || c == '"' || c == '`':
+			end := migrationQuotedEnd(sqlText, i)
+			buffer.WriteString(sqlText[i:end])
+			hasContent = true
+			i = end
+			continue
+
+		case c == '$':
+			if end := migrationDollarQuotedEnd(sqlText, i); end > 0 {
+				buffer.WriteString(sqlText[i:end])
+				hasContent = true
+				i = end
+				continue
+			}
+		}
+		if c != ' ' && c != '\t' && c != '\r' && c != '\n' {
+			hasContent = true
+		}
+		buffer.WriteByte(c)
+		i++
+	}
+	flush()
+	return statements
+}
+
+// migrationQuotedEnd returns the end position of the string literal or quoted identifier starting at `start`.
+// The quote is escaped by doubling it, and by backslash in string literals.
+func migrationQuotedEnd(sqlText string, start int) int {
+	quote := sqlText[start]
+	for i := start + 1; i < len(sqlText); i++ {
+		switch sqlText[i] {
+		case '\\':
+			if quote == '\'' {
+				i++
+			}
+		case quote:
+			if i+1 < len(sqlText) && sqlText[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(sqlText)
+}
+
+// migrationDollarQuotedEnd returns the end position of the dollar-quoted string of PostgreSQL like
+// $$...$$ or $tag$...$tag$ starting at `start`, or 0 if it is not a dollar-quoted string.
+func migrationDollarQuotedEnd(sqlText string, start int) int {
+	if start > 0 && isMigrationTagChar(sqlText[start-1]) {
+		return 0
+	}
+	i := start + 1
+	// It is a parameter like $1 if it starts with digit.
+	if i < len(sqlText) && sqlText[i] >= '0' && sqlText[i] <= '9' {
+		return 0
+	}
+	for i < len(sqlText) && isMigrationTagChar(sqlText[i]) {
+		i++
+	}
+	if i >= len(sqlText) || sqlText[i] != '$' {
+		return 0
+	}
+	tag := sqlText[start : i+1]
+	if end := strings.Index(sqlText[i+1:], tag); end >= 0 {
+		return i + 1 + end + len(tag)
+	}
+	return len(sqlText)
+}
+
+func isMigrationTagChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -53,6 +53,13 @@ type Model struct {
 	onConflict     interface{}       // onConflict is used for conflict keys on Upsert clause.
 	tableAliasMap  map[string]string // Table alias to true table name, usually used in join statements.
 	softTimeOption SoftTimeOption    // SoftTimeOption is the option to customize soft time feature for Model.
+	sharding       *modelSharding    // Sharding rule and value for table and database sharding.
+	version        *modelVersion     // Version attribute of updating struct for optimistic locking.
+	trashedScope   softDeletedScope  // Scope of soft deleted records, see WithTrashed and OnlyTrashed.
+	ctes           []modelCTE        // Common table expressions of WITH clause for select statement.
+	cteRecursive   bool              // Whether using WITH RECURSIVE clause.
+	encryption     []encryptedField  // Encrypted attributes of the struct data, see SetEncryptionKeyProvider.
+	returning      []string          // Columns of RETURNING clause for Insert/Update/Delete operations.
 }
 
 // ModelHandler is a function that handles given Model and returns a new Model that is custom modified.
@@ -289,6 +296,10 @@ func (m *Model) Clone() *Model {
 		newModel.withArray = make([]interface{}, n)
 		copy(newModel.withArray, m.withArray)
 	}
+	if n := len(m.ctes); n > 0 {
+		newModel.ctes = make([]modelCTE, n)
+		copy(newModel.ctes, m.ctes)
+	}
 	return newModel
 }
 
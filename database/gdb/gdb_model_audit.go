@@ -0,0 +1,296 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gvar"
+	"github.com/gogf/gf/v2/os/gtime"
+)
+
+// AuditOperation is the data changing operation recorded by audit trail.
+type AuditOperation string
+
+const (
+	AuditOperationInsert AuditOperation = "insert" // Insert/Replace/Save operation.
+	AuditOperationUpdate AuditOperation = "update" // Update operation, including soft deleting.
+	AuditOperationDelete AuditOperation = "delete" // Delete operation.
+)
+
+// AuditEntry is the audit trail of a data changing operation of Model.
+type AuditEntry struct {
+	Table     string         // Table name of the operation.
+	Operation AuditOperation // Data changing operation.
+	Actor     string         // Actor of the operation, which is from context by WithAuditActor.
+	Before    Result         // Records before the operation, which is nil for inserting.
+	After     Result         // Records after the operation, which is nil for deleting.
+	Time      *gtime.Time    // Time of the operation.
+}
+
+// AuditHandler handles the audit entry, which is called synchronously after the operation.
+// The returned error is returned by the operation, which rolls back the operation.
+type AuditHandler func(ctx context.Context, entry *AuditEntry) error
+
+// AuditOption is the option of audit trail for a configuration group.
+//
+// If Table is specified, the audit entries are inserted into the audit table in the same transaction
+// of the operation, which should have columns:
+// table_name, operation, actor, before_data, after_data, created_at.
+// The before_data and after_data are the records in JSON.
+type AuditOption struct {
+	Table   string       // (Optional) Audit table that the entries are inserted into.
+	Handler AuditHandler // (Optional) Custom handler of the entries.
+	Tables  []string     // (Optional) Tables to be audited, which are all tables in default.
+}
+
+// auditInput is the input for Model.doWithAudit and Model.doWithReturning.
+type auditInput struct {
+	Link      Link           // Link for the operation, which is also used for querying the records.
+	Operation AuditOperation // Data changing operation.
+	Table     string         // Table string of the operation, which is quoted and may have alias.
+	Condition string         // Condition string with WHERE prefix for updating/deleting.
+	Args      []interface{}  // Arguments of the operation, whose tail is the arguments of Condition.
+	Data      List           // Records for inserting.
+}
+
+var (
+	// auditOptions is the audit options, group name => *AuditOption.
+	auditOptions = gmap.NewStrAnyMap(true)
+)
+
+// SetAuditOption enables audit trail for the insert/update/delete operations of Model
+// of configuration `group`, which captures the before/after records of each operation.
+// It disables the audit trail if `option` is nil.
+//
+// Note that the operations by raw SQL like DB.Exec are not audited.
+func SetAuditOption(group string, option *AuditOption) {
+	if option == nil {
+		auditOptions.Remove(group)
+		return
+	}
+	auditOptions.Set(group, option)
+}
+
+// WithAuditActor returns a new context containing the actor for the audit trail.
+func WithAuditActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, ctxKeyAuditActor, actor)
+}
+
+// AuditActorFromCtx retrieves and returns the actor for the audit trail from context.
+func AuditActorFromCtx(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if v, ok := ctx.Value(ctxKeyAuditActor).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// getAuditOption returns the audit option if `table` should be audited, or else nil.
+func (m *Model) getAuditOption(table string) *AuditOption {
+	v := auditOptions.Get(m.db.GetGroup())
+	if v == nil || table == "" {
+		return nil
+	}
+	option := v.(*AuditOption)
+	// The audit table itself is never audited.
+	if option.Table != "" && (table == option.Table || table == m.db.GetPrefix()+option.Table) {
+		return nil
+	}
+	if len(option.Tables) == 0 {
+		return option
+	}
+	for _, v := range option.Tables {
+		if table == v || table == m.db.GetPrefix()+v {
+			return option
+		}
+	}
+	return nil
+}
+
+// doWithAudit calls `f` doing the data changing operation with the link of `in`,
+// and records the audit entry if audit trail is enabled for the table.
+// The operation and the audit entry are committed in one transaction,
+// which is started if the link of `in` is not in transaction.
+func (m *Model) doWithAudit(
+	ctx context.Context, in auditInput, f func(in auditInput) (sql.Result, error),
+) (result sql.Result, err error) {
+	var (
+		core   = m.db.GetCore()
+		table  = core.guessPrimaryTableName(in.Table)
+		option = m.getAuditOption(table)
+	)
+	if option == nil {
+		return f(in)
+	}
+	if in.Link.IsTransaction() {
+		return m.doAudit(ctx, in, table, option, f)
+	}
+	err = m.db.Transaction(ctx, func(ctx context.Context, tx TX) error {
+		in.Link = &txLink{tx.GetSqlTX()}
+		result, err = m.doAudit(ctx, in, table, option, f)
+		return err
+	})
+	return result, err
+}
+
+// doAudit calls `f` doing the data changing operation, and records the audit entry of `table`.
+func (m *Model) doAudit(
+	ctx context.Context, in auditInput, table string, option *AuditOption, f func(in auditInput) (sql.Result, error),
+) (result sql.Result, err error) {
+	var (
+		primaryKeys = m.getTablePrimaryKeys(ctx, table)
+		entry       = &AuditEntry{
+			Table:     table,
+			Operation: in.Operation,
+			Actor:     AuditActorFromCtx(ctx),
+			Time:      gtime.Now(),
+		}
+	)
+	if in.Operation != AuditOperationInsert {
+		if entry.Before, err = m.selectAuditRecords(ctx, in, in.Condition, in.conditionArgs()); err != nil {
+			return nil, err
+		}
+	}
+	if result, err = f(in); err != nil {
+		return result, err
+	}
+	switch in.Operation {
+	case AuditOperationInsert:
+		entry.After = make(Result, len(in.Data))
+		for i, item := range in.Data {
+			entry.After[i] = make(Record, len(item))
+			for k, v := range item {
+				entry.After[i][k] = gvar.New(v)
+			}
+		}
+		// The auto-increment value of single record.
+		if len(entry.After) == 1 && len(primaryKeys) == 1 {
+			if _, ok := entry.After[0][primaryKeys[0]]; !ok {
+				if id, e := result.LastInsertId(); e == nil && id > 0 {
+					entry.After[0][primaryKeys[0]] = gvar.New(id)
+				}
+			}
+		}
+
+	case AuditOperationUpdate:
+		// It queries the updated records by primary keys,
+		// as the condition columns may be updated.
+		if len(primaryKeys) > 0 && len(entry.Before) > 0 {
+			condition, args := m.formatPrimaryKeysCondition(primaryKeys, entry.Before)
+			entry.After, err = m.selectAuditRecords(ctx, in, condition, args)
+		} else {
+			entry.After, err = m.selectAuditRecords(ctx, in, in.Condition, in.conditionArgs())
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, m.handleAuditEntry(ctx, in.Link, option, entry)
+}
+
+// formatPrimaryKeysCondition formats and returns the condition with WHERE prefix matching `records`
+// by `primaryKeys`, like: WHERE `id` IN(?,?) or WHERE (`uid`=? AND `gid`=?) OR (`uid`=? AND `gid`=?).
+func (m *Model) formatPrimaryKeysCondition(primaryKeys []string, records Result) (string, []interface{}) {
+	var (
+		core = m.db.GetCore()
+		args = make([]interface{}, 0, len(records)*len(primaryKeys))
+	)
+	if len(primaryKeys) == 1 {
+		for _, v := range records.Array(primaryKeys[0]) {
+			args = append(args, v.Val())
+		}
+		return fmt.Sprintf(
+			` WHERE %s IN(%s)`, core.QuoteWord(primaryKeys[0]), strings.TrimSuffix(strings.Repeat("?,", len(args)), ","),
+		), args
+	}
+	var (
+		keyConditions = make([]string, len(primaryKeys))
+		conditions    = make([]string, len(records))
+	)
+	for i, key := range primaryKeys {
+		keyConditions[i] = core.QuoteWord(key) + "=?"
+	}
+	for i, record := range records {
+		for _, key := range primaryKeys {
+			args = append(args, record[key].Val())
+		}
+		conditions[i] = "(" + strings.Join(keyConditions, " AND ") + ")"
+	}
+	return " WHERE " + strings.Join(conditions, " OR "), args
+}
+
+// selectAuditRecords queries and returns the records of `in.Table` by `condition` and `args`.
+func (m *Model) selectAuditRecords(
+	ctx context.Context, in auditInput, condition string, args []interface{},
+) (Result, error) {
+	return m.db.DoSelect(ctx, in.Link, fmt.Sprintf(`SELECT * FROM %s%s`, in.Table, condition), args...)
+}
+
+// getTablePrimaryKeys returns the primary keys of `table` in order of field index.
+func (m *Model) getTablePrimaryKeys(ctx context.Context, table string) []string {
+	fields, err := m.db.TableFields(ctx, table)
+	if err != nil {
+		return nil
+	}
+	return getPrimaryKeysOfFields(fields)
+}
+
+// getTablePrimaryKey returns the primary key of `table`,
+// or empty string if it has no primary key or has composite primary keys.
+func (m *Model) getTablePrimaryKey(ctx context.Context, table string) string {
+	if keys := m.getTablePrimaryKeys(ctx, table); len(keys) == 1 {
+		return keys[0]
+	}
+	return ""
+}
+
+// handleAuditEntry calls the custom handler and inserts `entry` into the audit table.
+func (m *Model) handleAuditEntry(ctx context.Context, link Link, option *AuditOption, entry *AuditEntry) error {
+	if option.Handler != nil {
+		if err := option.Handler(ctx, entry); err != nil {
+			return err
+		}
+	}
+	if option.Table == "" {
+		return nil
+	}
+	var record = Map{
+		"table_name":  entry.Table,
+		"operation":   string(entry.Operation),
+		"actor":       entry.Actor,
+		"before_data": nil,
+		"after_data":  nil,
+		"created_at":  entry.Time,
+	}
+	if entry.Before != nil {
+		record["before_data"] = entry.Before.Json()
+	}
+	if entry.After != nil {
+		record["after_data"] = entry.After.Json()
+	}
+	_, err := m.db.DoInsert(
+		ctx, link, m.db.GetCore().QuotePrefixTableName(option.Table), List{record},
+		DoInsertOption{InsertOption: InsertOptionDefault},
+	)
+	return err
+}
+
+// conditionArgs returns the arguments of the condition, which are at the tail of the arguments.
+func (in auditInput) conditionArgs() []interface{} {
+	n := strings.Count(in.Condition, "?")
+	if n > len(in.Args) {
+		n = len(in.Args)
+	}
+	return in.Args[len(in.Args)-n:]
+}
@@ -9,9 +9,26 @@ package gdb
 import (
 	"fmt"
 
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/internal/json"
 	"github.com/gogf/gf/v2/text/gstr"
 )
 
+// jsonExtractOperators is the operators allowed for WhereJSONExtract.
+var jsonExtractOperators = map[string]bool{
+	"=":        true,
+	"!=":       true,
+	"<>":       true,
+	">":        true,
+	">=":       true,
+	"<":        true,
+	"<=":       true,
+	"LIKE":     true,
+	"NOT LIKE": true,
+}
+
 // doWhereType sets the condition statement for the model. The parameter `where` can be type of
 // string/map/gmap/slice/struct/*struct, etc. Note that, if it's called more than one times,
 // multiple conditions will be joined into where statement using "AND".
@@ -159,3 +176,36 @@ func (b *WhereBuilder) WhereNotNull(columns ...string) *WhereBuilder {
 	}
 	return builder
 }
+
+// WhereJSONContains builds condition that the value at JSON `path` contains `value`, in which `path`
+// is the JSON column with optional path in the column like "tags" or "extra.address".
+// The `value` is encoded as JSON document, eg:
+// WhereJSONContains("tags", "go")               => JSON_CONTAINS(`tags`, '"go"')
+// WhereJSONContains("extra.roles", g.Slice{1}) => JSON_CONTAINS(JSON_EXTRACT(`extra`, '$.roles'), '[1]')
+func (b *WhereBuilder) WhereJSONContains(path string, value interface{}) *WhereBuilder {
+	var (
+		column, items  = parseJSONPath(path)
+		jsonValue, err = json.Marshal(value)
+	)
+	if err != nil {
+		intlog.Errorf(b.model.GetCtx(), `%+v`, err)
+	}
+	expr, args := b.model.db.FormatJSON(JSONOperationContains, b.model.QuoteWord(column), items)
+	return b.Where(expr, append(args, string(jsonValue))...)
+}
+
+// WhereJSONExtract builds `extracted operator value` statement, in which the extracted value is
+// at JSON `path` like "extra.address.city" or "extra.tags[0]", eg:
+// WhereJSONExtract("extra.address.city", "=", "shanghai") => JSON_UNQUOTE(JSON_EXTRACT(`extra`, '$.address.city')) = 'shanghai'
+//
+// The `operator` should be one of comparison operators: =, !=, <>, >, >=, <, <=, LIKE and NOT LIKE,
+// or else it panics.
+func (b *WhereBuilder) WhereJSONExtract(path string, operator string, value interface{}) *WhereBuilder {
+	normalizedOperator := gstr.ToUpper(gstr.Join(gstr.SplitAndTrim(operator, " "), " "))
+	if !jsonExtractOperators[normalizedOperator] {
+		panic(gerror.NewCodef(gcode.CodeInvalidParameter, `invalid operator "%s" for WhereJSONExtract`, operator))
+	}
+	column, items := parseJSONPath(path)
+	expr, args := b.model.db.FormatJSON(JSONOperationExtract, b.model.QuoteWord(column), items)
+	return b.Where(fmt.Sprintf(`%s %s ?`, expr, normalizedOperator), append(args, value)...)
+}
@@ -11,6 +11,8 @@ import (
 	"time"
 
 	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/text/gstr"
 )
 
 // CacheOption is options for model cache control in query.
@@ -29,12 +31,24 @@ type CacheOption struct {
 	// Force caches the query result whatever the result is nil or not.
 	// It is used to avoid Cache Penetration.
 	Force bool
+
+	// Tags are the optional tags of the cache, which invalidate the cache if any of them
+	// is invalidated by Core.ClearCacheByTags.
+	// A table name as tag is also invalidated automatically by the insert/update/delete
+	// operations of Model on the table.
+	Tags []string
+
+	// TagTables tags the cache with the table names of the model automatically,
+	// so that the cache is invalidated by the writing operations on the tables.
+	// Note that the joined tables are not tagged, which can be specified by Tags.
+	TagTables bool
 }
 
 // selectCacheItem is the cache item for SELECT statement result.
 type selectCacheItem struct {
-	Result            Result // Sql result of SELECT statement.
-	FirstResultColumn string // The first column name of result, for Value/Count functions.
+	Result            Result           // Sql result of SELECT statement.
+	FirstResultColumn string           // The first column name of result, for Value/Count functions.
+	TagVersions       map[string]int64 // Versions of the cache tags when the result is cached.
 }
 
 // Cache sets the cache feature for the model. It caches the result of the sql, which means
@@ -51,17 +65,88 @@ func (m *Model) Cache(option CacheOption) *Model {
 }
 
 // checkAndRemoveSelectCache checks and removes the cache in insert/update/delete statement if
-// cache feature is enabled.
+// cache feature is enabled. It also invalidates the cache tagged with the table name.
+// The cache is removed after the transaction is committed if the statement is in transaction,
+// in case of caching the stale result before committing.
 func (m *Model) checkAndRemoveSelectCache(ctx context.Context) {
+	var (
+		core     = m.db.GetCore()
+		cacheKey string
+		table    = core.guessPrimaryTableName(m.tablesInit)
+	)
 	if m.cacheEnabled && m.cacheOption.Duration < 0 && len(m.cacheOption.Name) > 0 {
-		var cacheKey = m.makeSelectCacheKey("")
-		if _, err := m.db.GetCache().Remove(ctx, cacheKey); err != nil {
-			intlog.Errorf(ctx, `%+v`, err)
+		cacheKey = m.makeSelectCacheKey("")
+	}
+	if table != "" && !core.isCacheTagUsed(table) {
+		table = ""
+	}
+	if cacheKey == "" && table == "" {
+		return
+	}
+	removeFunc := func(ctx context.Context) {
+		if cacheKey != "" {
+			if _, err := m.db.GetCache().Remove(ctx, cacheKey); err != nil {
+				intlog.Errorf(ctx, `%+v`, err)
+			}
+		}
+		if table != "" {
+			if err := core.ClearCacheByTags(ctx, table); err != nil {
+				intlog.Errorf(ctx, `%+v`, err)
+			}
+		}
+	}
+	tx := m.tx
+	if tx == nil {
+		tx = TXFromCtx(ctx, m.db.GetGroup())
+	}
+	if txCore, ok := tx.(*TXCore); ok && !txCore.IsClosed() {
+		txCore.afterCommit = append(txCore.afterCommit, removeFunc)
+		return
+	}
+	removeFunc(ctx)
+}
+
+// getSelectCacheTags returns the cache tags of the model.
+func (m *Model) getSelectCacheTags() []string {
+	tags := m.cacheOption.Tags
+	if m.cacheOption.TagTables {
+		tags = append([]string{}, tags...)
+		for _, v := range gstr.SplitAndTrim(m.tablesInit, ",") {
+			if table := m.db.GetCore().guessPrimaryTableName(v); table != "" {
+				tags = append(tags, table)
+			}
+		}
+	}
+	return tags
+}
+
+// getSelectCacheTagVersions returns the current versions of the cache tags of the model.
+// The version is created if the tag is never used.
+func (m *Model) getSelectCacheTagVersions(ctx context.Context) (map[string]int64, error) {
+	tags := m.getSelectCacheTags()
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	var (
+		cacheObj = m.db.GetCache()
+		versions = make(map[string]int64, len(tags))
+	)
+	for _, tag := range tags {
+		m.db.GetCore().cacheTags.Add(tag)
+		v, err := cacheObj.GetOrSet(ctx, genSelectCacheTagKey(m.db.GetGroup(), tag), gtime.TimestampNano(), 0)
+		if err != nil {
+			return nil, err
 		}
+		versions[tag] = v.Int64()
 	}
+	return versions, nil
 }
 
-func (m *Model) getSelectResultFromCache(ctx context.Context, sql string, args ...interface{}) (result Result, err error) {
+// getSelectResultFromCache returns the cached result of the sql,
+// and the versions of the cache tags which are used for saving the result.
+func (m *Model) getSelectResultFromCache(
+	ctx context.Context, sql string, args ...interface{},
+) (result Result, tagVersions map[string]int64, err error) {
 	if !m.cacheEnabled || m.tx != nil {
 		return
 	}
@@ -71,6 +156,11 @@ func (m *Model) getSelectResultFromCache(ctx context.Context, sql string, args .
 		cacheObj  = m.db.GetCache()
 		core      = m.db.GetCore()
 	)
+	// The tag versions are retrieved before querying the database,
+	// in case of caching the stale result that is invalidated during querying.
+	if tagVersions, err = m.getSelectCacheTagVersions(ctx); err != nil {
+		return nil, nil, err
+	}
 	defer func() {
 		if cacheItem != nil {
 			if internalData := core.getInternalColumnFromCtx(ctx); internalData != nil {
@@ -82,15 +172,23 @@ func (m *Model) getSelectResultFromCache(ctx context.Context, sql string, args .
 	}()
 	if v, _ := cacheObj.Get(ctx, cacheKey); !v.IsNil() {
 		if err = v.Scan(&cacheItem); err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		for tag, version := range tagVersions {
+			if cacheItem.TagVersions[tag] != version {
+				// It is invalidated by tag.
+				cacheItem = nil
+				return nil, tagVersions, nil
+			}
 		}
-		return cacheItem.Result, nil
+		return cacheItem.Result, tagVersions, nil
 	}
 	return
 }
 
 func (m *Model) saveSelectResultToCache(
-	ctx context.Context, queryType queryType, result Result, sql string, args ...interface{},
+	ctx context.Context, queryType queryType, tagVersions map[string]int64,
+	result Result, sql string, args ...interface{},
 ) (err error) {
 	if !m.cacheEnabled || m.tx != nil {
 		return
@@ -129,7 +227,8 @@ func (m *Model) saveSelectResultToCache(
 	var (
 		core      = m.db.GetCore()
 		cacheItem = &selectCacheItem{
-			Result: result,
+			Result:      result,
+			TagVersions: tagVersions,
 		}
 	)
 	if internalData := core.getInternalColumnFromCtx(ctx); internalData != nil {
@@ -0,0 +1,88 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// modelCTE is the common table expression of the WITH clause for select statement.
+type modelCTE struct {
+	Name   string        // Name of the CTE, which can contain columns like "tree(id, pid)".
+	Holder string        // Sub-query with placeholders.
+	Args   []interface{} // Arguments of the sub-query.
+}
+
+// WithCTE adds a common table expression `name` to the WITH clause of select statement for the model.
+// The parameter `subQuery` can be type of *Model or raw sql string with placeholders for `args`.
+// The CTE can be used as table by the model or joined tables, eg:
+//
+//	db.Model("top_users").WithCTE("top_users", db.Model("user").Where("score>?", 90)).All()
+//	=> WITH top_users AS (SELECT * FROM `user` WHERE score>90) SELECT * FROM `top_users`
+//
+// Note that the WITH clause is only for select statement.
+func (m *Model) WithCTE(name string, subQuery interface{}, args ...interface{}) *Model {
+	model := m.getModel()
+	cte := modelCTE{
+		Name: name,
+	}
+	if v, ok := subQuery.(*Model); ok {
+		cte.Holder, cte.Args = v.getHolderAndArgsAsSubModel(model.GetCtx())
+	} else {
+		cte.Holder, cte.Args = gconv.String(subQuery), args
+	}
+	// The arguments of CTE are inserted into extra arguments after those of previous CTEs,
+	// as the WITH clause is in front of the statement.
+	var offset = 0
+	for _, v := range model.ctes {
+		offset += len(v.Args)
+	}
+	extraArgs := make([]interface{}, 0, len(model.extraArgs)+len(cte.Args))
+	extraArgs = append(extraArgs, model.extraArgs[:offset]...)
+	extraArgs = append(extraArgs, cte.Args...)
+	model.extraArgs = append(extraArgs, model.extraArgs[offset:]...)
+	model.ctes = append(model.ctes, cte)
+	return model
+}
+
+// WithRecursiveCTE is like WithCTE, but it uses WITH RECURSIVE clause,
+// in which the sub-query can reference the CTE itself, eg:
+//
+//	db.Model("tree").WithRecursiveCTE(
+//		"tree",
+//		"SELECT id,pid FROM category WHERE id=? UNION ALL SELECT c.id,c.pid FROM category c JOIN tree t ON c.pid=t.id",
+//		1,
+//	).All()
+//
+// Note that some databases like SQL Server and Oracle do not support RECURSIVE keyword,
+// in which WithCTE can be used for recursive CTE.
+func (m *Model) WithRecursiveCTE(name string, subQuery interface{}, args ...interface{}) *Model {
+	model := m.WithCTE(name, subQuery, args...)
+	model.cteRecursive = true
+	return model
+}
+
+// formatCTE prepends the WITH clause to `sql` if there's any CTE for the model.
+func (m *Model) formatCTE(sql string) string {
+	if len(m.ctes) == 0 {
+		return sql
+	}
+	var (
+		keyword = "WITH"
+		array   = make([]string, len(m.ctes))
+	)
+	if m.cteRecursive {
+		keyword = "WITH RECURSIVE"
+	}
+	for i, cte := range m.ctes {
+		array[i] = fmt.Sprintf(`%s AS (%s)`, cte.Name, cte.Holder)
+	}
+	return fmt.Sprintf(`%s %s %s`, keyword, strings.Join(array, ", "), sql)
+}
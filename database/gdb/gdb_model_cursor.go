@@ -0,0 +1,221 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+// Cursor streams the selected rows one by one from the underlying driver,
+// without loading all of them into memory like Result.
+// It holds a connection until it's closed, so it must be closed after use.
+type Cursor struct {
+	ctx         context.Context
+	core        *Core
+	rows        *sql.Rows
+	columnTypes []*sql.ColumnType
+	values      []interface{}      // Scanned values of current row.
+	scanArgs    []interface{}      // Pointers to values for scanning.
+	record      Record             // Current record.
+	err         error              // Error that occurs in iterating.
+	cancelFunc  context.CancelFunc // Cancels the context of query with timeout.
+}
+
+// ScanIteratorHandler is the function handling the row that is scanned to the pointer in ScanIterator.
+// It stops the iterating if it returns error, which is returned by ScanIterator.
+type ScanIteratorHandler func() error
+
+// Cursor does "SELECT FROM ..." statement for the model, and returns a Cursor streaming the rows, eg:
+//
+//	cursor, err := db.Model("user").Where("status", 1).Cursor(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	defer cursor.Close()
+//	for cursor.Next() {
+//	    record := cursor.Record()
+//	}
+//	return cursor.Err()
+//
+// Note that the select cache and select hook do not take effect for Cursor.
+func (m *Model) Cursor(ctx context.Context) (*Cursor, error) {
+	if ctx == nil {
+		ctx = m.GetCtx()
+	}
+	if m.sharding != nil {
+		return nil, gerror.NewCode(gcode.CodeNotSupported, `cursor is not supported for sharding`)
+	}
+	var (
+		core                      = m.db.GetCore()
+		sqlWithHolder, holderArgs = m.getFormattedSqlAndArgs(ctx, queryTypeNormal, false)
+	)
+	return core.doQueryCursor(ctx, m.getLink(false), sqlWithHolder, m.mergeArguments(holderArgs)...)
+}
+
+// ScanIterator does "SELECT FROM ..." statement for the model, and scans the rows to `pointer`
+// one by one, in which `handler` is called after each row is scanned, eg:
+//
+//	var user *User
+//	err := db.Model("user").ScanIterator(ctx, &user, func() error {
+//	    return writer.Write(user)
+//	})
+//
+// The parameter `pointer` should be type of *struct/**struct.
+func (m *Model) ScanIterator(ctx context.Context, pointer interface{}, handler ScanIteratorHandler) error {
+	cursor, err := m.Cursor(ctx)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for cursor.Next() {
+		if err = cursor.Scan(pointer); err != nil {
+			return err
+		}
+		if err = handler(); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// doQueryCursor commits the query `query` to underlying driver through `link`,
+// and returns a Cursor of the rows that are not read yet.
+func (c *Core) doQueryCursor(ctx context.Context, link Link, query string, args ...interface{}) (*Cursor, error) {
+	// Transaction checks.
+	if !link.IsTransaction() {
+		if tx := TXFromCtx(ctx, c.db.GetGroup()); tx != nil {
+			link = &txLink{tx.GetSqlTX()}
+		}
+	}
+	var (
+		err    error
+		cursor = &Cursor{
+			core: c,
+		}
+	)
+	// The query timeout applies only to the query but not the iterating of rows,
+	// as the rows are closed once the context of query is done.
+	if timeout := c.db.GetConfig().QueryTimeout; timeout > 0 {
+		ctx, cursor.cancelFunc = context.WithCancel(ctx)
+		timer := time.AfterFunc(timeout, cursor.cancelFunc)
+		defer timer.Stop()
+	}
+	cursor.ctx = ctx
+	// Sql filtering.
+	query, args = c.FormatSqlBeforeExecuting(query, args)
+	query, args, err = c.db.DoFilter(ctx, link, query, args)
+	if err != nil {
+		cursor.Close()
+		return nil, err
+	}
+	// SQL format and retrieve.
+	if v := ctx.Value(ctxKeyCatchSQL); v != nil {
+		var manager = v.(*CatchSQLManager)
+		manager.SQLArray.Append(FormatSqlWithArgs(query, args))
+		if !manager.DoCommit && ctx.Value(ctxKeyInternalProducedSQL) == nil {
+			return cursor, nil
+		}
+	}
+	out, err := c.db.DoCommit(context.WithValue(ctx, ctxKeyCursor, true), DoCommitInput{
+		Link:          link,
+		Sql:           query,
+		Args:          args,
+		Type:          SqlTypeQueryContext,
+		IsTransaction: link.IsTransaction(),
+	})
+	if err != nil {
+		cursor.Close()
+		return nil, err
+	}
+	cursor.rows, _ = out.RawResult.(*sql.Rows)
+	if cursor.rows == nil {
+		return cursor, nil
+	}
+	if cursor.columnTypes, err = cursor.rows.ColumnTypes(); err != nil {
+		cursor.Close()
+		return nil, err
+	}
+	cursor.values = make([]interface{}, len(cursor.columnTypes))
+	cursor.scanArgs = make([]interface{}, len(cursor.columnTypes))
+	for i := range cursor.values {
+		cursor.scanArgs[i] = &cursor.values[i]
+	}
+	return cursor, nil
+}
+
+// Next reads the next row, which can be retrieved by Record or Scan.
+// It returns false if there's no more row or any error occurs, in which case the cursor is closed
+// and the error can be retrieved by Err.
+func (c *Cursor) Next() bool {
+	c.record = nil
+	if c.rows == nil || c.err != nil {
+		return false
+	}
+	if !c.rows.Next() {
+		c.err = c.rows.Err()
+		c.Close()
+		return false
+	}
+	if c.err = c.rows.Scan(c.scanArgs...); c.err != nil {
+		c.Close()
+		return false
+	}
+	if c.record, c.err = c.core.valuesToRecord(c.ctx, c.values, c.columnTypes); c.err != nil {
+		c.Close()
+		return false
+	}
+	return true
+}
+
+// Record returns the current row read by Next.
+func (c *Cursor) Record() Record {
+	return c.record
+}
+
+// Scan converts the current row read by Next to `pointer`, which should be type of *struct/**struct.
+func (c *Cursor) Scan(pointer interface{}) error {
+	if c.record == nil {
+		return sql.ErrNoRows
+	}
+	return c.record.Struct(pointer)
+}
+
+// Batch reads at most `size` rows, which are fetched one by one from the driver.
+// It returns empty Result if there's no more row.
+func (c *Cursor) Batch(size int) (Result, error) {
+	result := make(Result, 0, size)
+	for len(result) < size && c.Next() {
+		result = append(result, c.record)
+	}
+	return result, c.err
+}
+
+// Err returns the error that occurs in iterating.
+func (c *Cursor) Err() error {
+	return c.err
+}
+
+// Close closes the cursor and releases its connection, which can be called multiple times.
+func (c *Cursor) Close() error {
+	var err error
+	if c.rows != nil {
+		if err = c.rows.Close(); err != nil {
+			intlog.Errorf(c.ctx, `%+v`, err)
+		}
+	}
+	if c.cancelFunc != nil {
+		c.cancelFunc()
+		c.cancelFunc = nil
+	}
+	return err
+}
@@ -23,6 +23,15 @@ func (m *Model) Delete(where ...interface{}) (result sql.Result, err error) {
 	if len(where) > 0 {
 		return m.Where(where[0], where[1:]...).Delete()
 	}
+	if m.sharding != nil {
+		models, err := m.getShardingModels()
+		if err != nil {
+			return nil, err
+		}
+		return doShardingExec(models, func(model *Model) (sql.Result, error) {
+			return model.Delete()
+		})
+	}
 	defer func() {
 		if err == nil {
 			m.checkAndRemoveSelectCache(ctx)
@@ -38,7 +47,8 @@ func (m *Model) Delete(where ...interface{}) (result sql.Result, err error) {
 	if m.unscoped {
 		fieldNameDelete = ""
 	}
-	if !gstr.ContainsI(conditionStr, " WHERE ") || (fieldNameDelete != "" && !gstr.ContainsI(conditionStr, " AND ")) {
+	var hasSoftDeletingCondition = fieldNameDelete != "" && m.trashedScope != softDeletedScopeWith
+	if !gstr.ContainsI(conditionStr, " WHERE ") || (hasSoftDeletingCondition && !gstr.ContainsI(conditionStr, " AND ")) {
 		intlog.Printf(
 			ctx,
 			`sql condition string "%s" has no WHERE for DELETE operation, fieldNameDelete: %s`,
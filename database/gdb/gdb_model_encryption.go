@@ -0,0 +1,326 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"reflect"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gvar"
+	"github.com/gogf/gf/v2/crypto/gaes"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/empty"
+	"github.com/gogf/gf/v2/internal/reflection"
+	"github.com/gogf/gf/v2/os/gstructs"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+const (
+	// TagValueForEncrypted marks the attribute as encrypted column using tag `gdb:"encrypted"`,
+	// which uses random nonce that the same value is encrypted to different cipher texts.
+	// The tag `gdb:"encrypted,deterministic"` encrypts the same value to the same cipher text,
+	// which can be used in equality queries, see Core.Encrypt.
+	TagValueForEncrypted     = "encrypted"
+	TagValueForDeterministic = "deterministic"
+)
+
+// KeyProvider provides the keys for field-level encryption, which are AES keys of 16/24/32 bytes.
+// The keys are not used directly, but the subkeys of AES-GCM and of the nonce of deterministic
+// encryption are derived from them using HKDF.
+//
+// The key id is stored with the cipher text, so that the values encrypted by old keys can still be
+// decrypted after key rotation. A KMS based provider can implement it by caching the data keys
+// decrypted by KMS.
+type KeyProvider interface {
+	// CurrentKey returns the id and data of the key for encrypting.
+	CurrentKey(ctx context.Context) (id string, key []byte, err error)
+
+	// Key returns the data of the key with `id` for decrypting.
+	Key(ctx context.Context, id string) (key []byte, err error)
+}
+
+// LocalKeyProvider implements the KeyProvider interface with keys in memory.
+type LocalKeyProvider struct {
+	currentId string            // Id of the key for encrypting.
+	keys      map[string][]byte // Key id to key data.
+}
+
+// encryptedField is the attribute of struct that is encrypted.
+type encryptedField struct {
+	name          string // Name of the attribute in data map, which is the orm tag or attribute name.
+	deterministic bool   // Whether using deterministic encryption.
+}
+
+const (
+	// encryptedValuePrefix is the prefix of encrypted value,
+	// which is formatted as: $enc$key id$base64 encoded nonce and cipher text.
+	encryptedValuePrefix = "$enc$"
+
+	// hkdfInfoCipherKey and hkdfInfoNonceKey are the HKDF info deriving the subkeys of the key,
+	// which are the key of AES-GCM and the key of HMAC deriving the nonce of deterministic encryption.
+	hkdfInfoCipherKey = "gdb field encryption cipher key"
+	hkdfInfoNonceKey  = "gdb field encryption nonce key"
+	nonceKeySize      = 32
+)
+
+var (
+	// keyProviders is the key providers for field-level encryption, group name => KeyProvider.
+	keyProviders = gmap.NewStrAnyMap(true)
+)
+
+// NewLocalKeyProvider creates and returns a key provider using `key` with `id` for encrypting.
+func NewLocalKeyProvider(id string, key []byte) *LocalKeyProvider {
+	return &LocalKeyProvider{
+		currentId: id,
+		keys:      map[string][]byte{id: key},
+	}
+}
+
+// AddKey adds an old key `key` with `id`, which is only used for decrypting after key rotation.
+// Note that it is not concurrent-safe, which should be called before the provider is used.
+func (p *LocalKeyProvider) AddKey(id string, key []byte) *LocalKeyProvider {
+	p.keys[id] = key
+	return p
+}
+
+// CurrentKey implements the KeyProvider interface.
+func (p *LocalKeyProvider) CurrentKey(ctx context.Context) (id string, key []byte, err error) {
+	return p.currentId, p.keys[p.currentId], nil
+}
+
+// Key implements the KeyProvider interface.
+func (p *LocalKeyProvider) Key(ctx context.Context, id string) (key []byte, err error) {
+	if key, ok := p.keys[id]; ok {
+		return key, nil
+	}
+	return nil, gerror.NewCodef(gcode.CodeNotFound, `encryption key "%s" not found`, id)
+}
+
+// SetEncryptionKeyProvider sets the key provider for field-level encryption of configuration `group`.
+// It removes the key provider if `provider` is nil.
+//
+// The attributes of struct with tag `gdb:"encrypted"` are encrypted when the struct is written
+// using Model.Data, and decrypted when the records are read using Model.Scan, eg:
+//
+//	type User struct {
+//		Id     int
+//		CardNo string `gdb:"encrypted,deterministic"`
+//		Phone  string `gdb:"encrypted"`
+//	}
+func SetEncryptionKeyProvider(group string, provider KeyProvider) {
+	if provider == nil {
+		keyProviders.Remove(group)
+		return
+	}
+	keyProviders.Set(group, provider)
+}
+
+// Encrypt encrypts `value` using the current key of the key provider set by SetEncryptionKeyProvider.
+// If `deterministic` is true, the same value is encrypted to the same cipher text with the same key,
+// which can be used as condition for the column of attribute having tag `gdb:"encrypted,deterministic"`:
+//
+//	cardNo, err := db.GetCore().Encrypt(ctx, "6222020000000000", true)
+//	db.Model("user").Where("card_no", cardNo).One()
+func (c *Core) Encrypt(ctx context.Context, value interface{}, deterministic bool) (string, error) {
+	provider, err := c.getKeyProvider()
+	if err != nil {
+		return "", err
+	}
+	id, key, err := provider.CurrentKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	cipherKey, err := deriveEncryptionKey(key, hkdfInfoCipherKey, len(key))
+	if err != nil {
+		return "", err
+	}
+	var (
+		plainText  = gconv.Bytes(gconv.String(value))
+		cipherText []byte
+	)
+	if deterministic {
+		// The nonce is derived from the plain text, which is the same for the same value and key.
+		// The HMAC key is derived independently of the cipher key, as using the same key for both is unsafe.
+		nonceKey, err := deriveEncryptionKey(key, hkdfInfoNonceKey, nonceKeySize)
+		if err != nil {
+			return "", err
+		}
+		nonceMac := hmac.New(sha256.New, nonceKey)
+		nonceMac.Write(plainText)
+		nonce := nonceMac.Sum(nil)[:gaes.NonceSizeGCM]
+		if cipherText, err = gaes.EncryptGCMWithNonce(plainText, cipherKey, nonce); err != nil {
+			return "", err
+		}
+		cipherText = append(append([]byte(nil), nonce...), cipherText...)
+	} else if cipherText, err = gaes.EncryptGCM(plainText, cipherKey); err != nil {
+		return "", err
+	}
+	return encryptedValuePrefix + id + "$" + base64.RawURLEncoding.EncodeToString(cipherText), nil
+}
+
+// Decrypt decrypts `value` that is encrypted by Encrypt.
+// It returns `value` directly if it is not encrypted, like the values written before encryption enabled.
+func (c *Core) Decrypt(ctx context.Context, value string) (string, error) {
+	if !strings.HasPrefix(value, encryptedValuePrefix) {
+		return value, nil
+	}
+	var (
+		content = value[len(encryptedValuePrefix):]
+		pos     = strings.LastIndex(content, "$")
+	)
+	if pos == -1 {
+		return "", gerror.NewCode(gcode.CodeInvalidParameter, `invalid encrypted value`)
+	}
+	cipherText, err := base64.RawURLEncoding.DecodeString(content[pos+1:])
+	if err != nil {
+		return "", gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid encrypted value`)
+	}
+	provider, err := c.getKeyProvider()
+	if err != nil {
+		return "", err
+	}
+	key, err := provider.Key(ctx, content[:pos])
+	if err != nil {
+		return "", err
+	}
+	cipherKey, err := deriveEncryptionKey(key, hkdfInfoCipherKey, len(key))
+	if err != nil {
+		return "", err
+	}
+	plainText, err := gaes.DecryptGCM(cipherText, cipherKey)
+	if err != nil {
+		return "", err
+	}
+	return string(plainText), nil
+}
+
+// deriveEncryptionKey derives and returns the subkey of `size` bytes from `key` for `info` using HKDF.
+func deriveEncryptionKey(key []byte, info string, size int) ([]byte, error) {
+	subKey := make([]byte, size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, key, nil, []byte(info)), subKey); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInternalError, err, `derive encryption key failed`)
+	}
+	return subKey, nil
+}
+
+// getKeyProvider returns the key provider of current configuration group.
+func (c *Core) getKeyProvider() (KeyProvider, error) {
+	if v := keyProviders.Get(c.db.GetGroup()); v != nil {
+		return v.(KeyProvider), nil
+	}
+	return nil, gerror.NewCodef(
+		gcode.CodeMissingConfiguration,
+		`encryption key provider is not set for configuration group "%s"`, c.db.GetGroup(),
+	)
+}
+
+// getStructEncryptedFields returns the attributes of struct `value` that have tag `gdb:"encrypted"`.
+func getStructEncryptedFields(value interface{}) []encryptedField {
+	fields, err := gstructs.Fields(gstructs.FieldsInput{
+		Pointer:         value,
+		RecursiveOption: gstructs.RecursiveOptionEmbeddedNoTag,
+	})
+	if err != nil {
+		return nil
+	}
+	var encryptedFields []encryptedField
+	for _, field := range fields {
+		tagValues := gstr.SplitAndTrim(field.Tag(TagForGdb), ",")
+		if len(tagValues) == 0 || tagValues[0] != TagValueForEncrypted {
+			continue
+		}
+		name := gstr.Split(field.Tag(OrmTagForStruct), ",")[0]
+		if name == "" {
+			name = field.TagPriorityName()
+		}
+		encryptedFields = append(encryptedFields, encryptedField{
+			name:          name,
+			deterministic: len(tagValues) > 1 && tagValues[1] == TagValueForDeterministic,
+		})
+	}
+	return encryptedFields
+}
+
+// getPointerEncryptedFields returns the encrypted attributes of the struct element of `pointer`
+// for scanning, which can be type of *struct/**struct/*[]struct/*[]*struct or their reflect.Value.
+func getPointerEncryptedFields(pointer interface{}) []encryptedField {
+	reflectType := reflection.OriginTypeAndKind(pointer).OriginType
+	for reflectType != nil {
+		switch reflectType.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			reflectType = reflectType.Elem()
+			continue
+		case reflect.Struct:
+			return getStructEncryptedFields(reflect.New(reflectType).Interface())
+		}
+		break
+	}
+	return nil
+}
+
+// encryptDataMap returns a copy of `data` in which the values of encrypted attributes are encrypted.
+func (m *Model) encryptDataMap(data Map) (Map, error) {
+	var (
+		err     error
+		ctx     = m.GetCtx()
+		core    = m.db.GetCore()
+		newData = gutil.MapCopy(data)
+	)
+	for _, field := range m.encryption {
+		foundKey, foundValue := gutil.MapPossibleItemByKey(newData, field.name)
+		if foundKey == "" || empty.IsNil(foundValue) {
+			continue
+		}
+		if newData[foundKey], err = core.Encrypt(ctx, foundValue, field.deterministic); err != nil {
+			return nil, err
+		}
+	}
+	return newData, nil
+}
+
+// decryptResult decrypts the values of `fields` in `result` for scanning.
+func (m *Model) decryptResult(result Result, fields []encryptedField) error {
+	if len(fields) == 0 || len(result) == 0 {
+		return nil
+	}
+	var (
+		ctx     = m.GetCtx()
+		core    = m.db.GetCore()
+		columns = make(map[string]interface{}, len(result[0]))
+	)
+	for k := range result[0] {
+		columns[k] = nil
+	}
+	for _, field := range fields {
+		column, _ := gutil.MapPossibleItemByKey(columns, field.name)
+		if column == "" {
+			continue
+		}
+		for _, record := range result {
+			if record[column] == nil || record[column].IsNil() {
+				continue
+			}
+			value, err := core.Decrypt(ctx, record[column].String())
+			if err != nil {
+				return err
+			}
+			record[column] = gvar.New(value)
+		}
+	}
+	return nil
+}
@@ -8,8 +8,11 @@ package gdb
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/text/gstr"
 	"github.com/gogf/gf/v2/util/gconv"
 )
@@ -119,6 +122,83 @@ func (m *Model) FieldAvg(column string, as ...string) *Model {
 	return m.appendFieldsByStr(fmt.Sprintf(`AVG(%s)%s`, m.QuoteWord(column), asStr))
 }
 
+// FieldJSON formats and appends the value at JSON `path` like "extra.address.city" or "extra.tags[0]"
+// to the select fields of model, in which the first item of `path` is the JSON column.
+//
+// As the select fields have no arguments, the JSON path is embedded in the field as string literal,
+// so it panics if any item of `path` contains quote or backslash character.
+func (m *Model) FieldJSON(path string, as ...string) *Model {
+	var (
+		column, items = parseJSONPath(path)
+		asStr         = ""
+	)
+	for _, item := range items {
+		if gstr.ContainsAny(item, `'"\`) {
+			panic(gerror.NewCodef(
+				gcode.CodeInvalidParameter, `invalid JSON path "%s" for FieldJSON, quote and backslash are not allowed`, path,
+			))
+		}
+	}
+	if len(as) > 0 && as[0] != "" {
+		asStr = fmt.Sprintf(` AS %s`, m.db.GetCore().QuoteWord(as[0]))
+	}
+	expr, args := m.db.FormatJSON(JSONOperationExtract, m.QuoteWord(column), items)
+	for _, arg := range args {
+		expr = gstr.Replace(expr, "?", fmt.Sprintf(`'%s'`, arg), 1)
+	}
+	return m.appendFieldsByStr(expr + asStr)
+}
+
+// Window is the window specification of window function, which is formatted as
+// `OVER (PARTITION BY ... ORDER BY ... frame)`.
+type Window struct {
+	PartitionBy []string // (Optional) Columns of PARTITION BY clause.
+	OrderBy     string   // (Optional) ORDER BY clause like "score DESC, id".
+	Frame       string   // (Optional) Frame clause like "ROWS BETWEEN 1 PRECEDING AND CURRENT ROW".
+}
+
+// FieldOver formats and appends window function field `function OVER (...)` to the select fields of model.
+// The parameter `function` is the window function like "ROW_NUMBER()", "SUM(score)", "LAG(score, 1)".
+//
+// Eg:
+// FieldOver("SUM(score)", Window{PartitionBy: []string{"class"}}, "class_score")
+// => SUM(score) OVER (PARTITION BY `class`) AS `class_score`.
+func (m *Model) FieldOver(function string, window Window, as ...string) *Model {
+	var (
+		core    = m.db.GetCore()
+		clauses = make([]string, 0, 3)
+		asStr   = ""
+	)
+	if len(window.PartitionBy) > 0 {
+		clauses = append(clauses, "PARTITION BY "+core.QuoteString(strings.Join(window.PartitionBy, ",")))
+	}
+	if window.OrderBy != "" {
+		clauses = append(clauses, "ORDER BY "+core.QuoteString(window.OrderBy))
+	}
+	if window.Frame != "" {
+		clauses = append(clauses, window.Frame)
+	}
+	if len(as) > 0 && as[0] != "" {
+		asStr = fmt.Sprintf(` AS %s`, core.QuoteWord(as[0]))
+	}
+	return m.appendFieldsByStr(fmt.Sprintf(`%s OVER (%s)%s`, function, strings.Join(clauses, " "), asStr))
+}
+
+// FieldRowNumber formats and appends window function field `ROW_NUMBER() OVER (...)` to the select fields of model.
+func (m *Model) FieldRowNumber(window Window, as ...string) *Model {
+	return m.FieldOver("ROW_NUMBER()", window, as...)
+}
+
+// FieldRank formats and appends window function field `RANK() OVER (...)` to the select fields of model.
+func (m *Model) FieldRank(window Window, as ...string) *Model {
+	return m.FieldOver("RANK()", window, as...)
+}
+
+// FieldDenseRank formats and appends window function field `DENSE_RANK() OVER (...)` to the select fields of model.
+func (m *Model) FieldDenseRank(window Window, as ...string) *Model {
+	return m.FieldOver("DENSE_RANK()", window, as...)
+}
+
 // GetFieldsStr retrieves and returns all fields from the table, joined with char ','.
 // The optional parameter `prefix` specifies the prefix for each field, eg: GetFieldsStr("u.").
 func (m *Model) GetFieldsStr(prefix ...string) string {
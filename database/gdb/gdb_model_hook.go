@@ -172,7 +172,17 @@ func (h *HookInsertInput) Next(ctx context.Context) (result sql.Result, err erro
 			return
 		}
 	}
-	return h.Model.db.DoInsert(ctx, h.link, h.Table, h.Data, h.Option)
+	in := auditInput{
+		Link:      h.link,
+		Operation: AuditOperationInsert,
+		Table:     h.Table,
+		Data:      h.Data,
+	}
+	return h.Model.doWithAudit(ctx, in, func(in auditInput) (sql.Result, error) {
+		return h.Model.doWithReturning(ctx, in, func(ctx context.Context) (sql.Result, error) {
+			return h.Model.db.DoInsert(ctx, in.Link, h.Table, h.Data, h.Option)
+		})
+	})
 }
 
 // Next calls the next hook handler.
@@ -202,7 +212,18 @@ func (h *HookUpdateInput) Next(ctx context.Context) (result sql.Result, err erro
 			return
 		}
 	}
-	return h.Model.db.DoUpdate(ctx, h.link, h.Table, h.Data, h.Condition, h.Args...)
+	in := auditInput{
+		Link:      h.link,
+		Operation: AuditOperationUpdate,
+		Table:     h.Table,
+		Condition: h.Condition,
+		Args:      h.Args,
+	}
+	return h.Model.doWithAudit(ctx, in, func(in auditInput) (sql.Result, error) {
+		return h.Model.doWithReturning(ctx, in, func(ctx context.Context) (sql.Result, error) {
+			return h.Model.db.DoUpdate(ctx, in.Link, h.Table, h.Data, h.Condition, h.Args...)
+		})
+	})
 }
 
 // Next calls the next hook handler.
@@ -232,7 +253,18 @@ func (h *HookDeleteInput) Next(ctx context.Context) (result sql.Result, err erro
 			return
 		}
 	}
-	return h.Model.db.DoDelete(ctx, h.link, h.Table, h.Condition, h.Args...)
+	in := auditInput{
+		Link:      h.link,
+		Operation: AuditOperationDelete,
+		Table:     h.Table,
+		Condition: h.Condition,
+		Args:      h.Args,
+	}
+	return h.Model.doWithAudit(ctx, in, func(in auditInput) (sql.Result, error) {
+		return h.Model.doWithReturning(ctx, in, func(ctx context.Context) (sql.Result, error) {
+			return h.Model.db.DoDelete(ctx, in.Link, h.Table, h.Condition, h.Args...)
+		})
+	})
 }
 
 // Hook sets the hook functions for current model.
@@ -40,6 +40,8 @@ func (m *Model) Batch(batch int) *Model {
 // Data(g.Slice{g.Map{"uid": 10000, "name":"john"}, g.Map{"uid": 20000, "name":"smith"}).
 func (m *Model) Data(data ...interface{}) *Model {
 	var model = m.getModel()
+	model.version = nil
+	model.encryption = nil
 	if len(data) > 1 {
 		if s := gconv.String(data[0]); gstr.Contains(s, "?") {
 			model.data = s
@@ -82,6 +84,9 @@ func (m *Model) Data(data ...interface{}) *Model {
 						model.option |= optionOmitNilDataInternal
 					}
 				}
+				if reflectInfo.OriginValue.Len() > 0 {
+					model.encryption = getStructEncryptedFields(reflectInfo.OriginValue.Index(0).Interface())
+				}
 				list := make(List, reflectInfo.OriginValue.Len())
 				for i := 0; i < reflectInfo.OriginValue.Len(); i++ {
 					list[i] = anyValueToMapBeforeToRecord(reflectInfo.OriginValue.Index(i).Interface())
@@ -106,6 +111,8 @@ func (m *Model) Data(data ...interface{}) *Model {
 					model.data = list
 				} else {
 					model.data = anyValueToMapBeforeToRecord(data[0])
+					model.version = getStructVersion(data[0])
+					model.encryption = getStructEncryptedFields(data[0])
 				}
 
 			case reflect.Map:
@@ -163,6 +170,17 @@ func (m *Model) OnDuplicate(onDuplicate ...interface{}) *Model {
 	return model
 }
 
+// DoUpdate sets the columns to be updated when conflict occurs for Save, which is the same as
+// OnDuplicate but reads better in bulk upsert with OnConflict, eg:
+//
+//	db.Model("user").Data(list).OnConflict("id").DoUpdate("nickname", "age").Save()
+//
+// It generates "ON DUPLICATE KEY UPDATE", "ON CONFLICT DO UPDATE" or "MERGE" statement according to
+// the driver, and the records are saved in batches, see Model.Batch.
+func (m *Model) DoUpdate(columns ...interface{}) *Model {
+	return m.OnDuplicate(columns...)
+}
+
 // OnDuplicateEx sets the excluding columns for operations when columns conflict occurs.
 // In MySQL, this is used for "ON DUPLICATE KEY UPDATE" statement.
 // In PgSQL, this is used for "ON CONFLICT (id) DO UPDATE SET" statement.
@@ -259,6 +277,9 @@ func (m *Model) doInsertWithOption(ctx context.Context, insertOption InsertOptio
 	if m.data == nil {
 		return nil, gerror.NewCode(gcode.CodeMissingParameter, "inserting into table with empty data")
 	}
+	if m.sharding != nil {
+		return m.doShardingInsert(ctx, insertOption)
+	}
 	var (
 		list                             List
 		stm                              = m.softTimeMaintainer()
@@ -0,0 +1,153 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// returningHolder holds the RETURNING clause of the executing statement,
+// which is passed to Core.DoExec through context.
+type returningHolder struct {
+	Columns  []string                                   // Quoted columns of RETURNING clause.
+	Records  Result                                     // Records returned by RETURNING clause or fallback queries.
+	Returned bool                                       // Whether the records are returned by RETURNING clause.
+	Fallback func(ctx context.Context, link Link) error // Called before executing if RETURNING clause is not supported.
+}
+
+// Returning sets the columns of RETURNING clause for Insert/Update/Delete operations,
+// which returns the changed records in one round trip, eg:
+//
+//	r, err := db.Model("user").Returning("id", "created_at").Data(data).Insert()
+//	records := r.(*gdb.SqlResult).Records
+//
+// For the databases that do not support RETURNING clause like MySQL, it falls back to querying
+// the records by primary key, using LastInsertId for inserting single record. Note that the records
+// are not returned in fallback for inserting multiple records.
+func (m *Model) Returning(columns ...string) *Model {
+	model := m.getModel()
+	model.returning = gstr.SplitAndTrim(strings.Join(columns, ","), ",")
+	return model
+}
+
+// ReturningColumnsFromCtx retrieves and returns the quoted columns of RETURNING clause from context,
+// which are set by Model.Returning for the executing statement.
+// It is mainly used by drivers that have custom DoExec implements.
+func ReturningColumnsFromCtx(ctx context.Context) []string {
+	if returning := getReturningFromCtx(ctx); returning != nil {
+		return returning.Columns
+	}
+	return nil
+}
+
+// getReturningFromCtx retrieves and returns the RETURNING clause holder from context.
+func getReturningFromCtx(ctx context.Context) *returningHolder {
+	if ctx == nil {
+		return nil
+	}
+	if v, ok := ctx.Value(ctxKeyReturning).(*returningHolder); ok {
+		return v
+	}
+	return nil
+}
+
+// callFallback calls the fallback function only once.
+func (h *returningHolder) callFallback(ctx context.Context, link Link) error {
+	if h.Fallback == nil {
+		return nil
+	}
+	fallback := h.Fallback
+	h.Fallback = nil
+	return fallback(ctx, link)
+}
+
+// doWithReturning calls `f` doing the data changing operation with RETURNING clause if Returning is set,
+// and returns the result containing the returned records.
+func (m *Model) doWithReturning(
+	ctx context.Context, in auditInput, f func(ctx context.Context) (sql.Result, error),
+) (result sql.Result, err error) {
+	if len(m.returning) == 0 {
+		return f(ctx)
+	}
+	var (
+		core       = m.db.GetCore()
+		table      = core.guessPrimaryTableName(in.Table)
+		primaryKey = m.getTablePrimaryKey(ctx, table)
+		columns    = make([]string, len(m.returning))
+		holder     = &returningHolder{Columns: columns}
+		primaryIds []interface{}
+	)
+	for i, column := range m.returning {
+		columns[i] = core.QuoteWord(column)
+	}
+	switch in.Operation {
+	case AuditOperationUpdate:
+		// It queries the primary keys before updating, as the condition columns may be updated.
+		if primaryKey != "" {
+			holder.Fallback = func(ctx context.Context, link Link) error {
+				records, err := m.selectReturningRecords(ctx, in, core.QuoteWord(primaryKey), in.Condition, in.conditionArgs())
+				if err == nil {
+					for _, v := range records.Array(primaryKey) {
+						primaryIds = append(primaryIds, v.Val())
+					}
+				}
+				return err
+			}
+		}
+
+	case AuditOperationDelete:
+		holder.Fallback = func(ctx context.Context, link Link) (err error) {
+			holder.Records, err = m.selectReturningRecords(
+				ctx, in, strings.Join(columns, ","), in.Condition, in.conditionArgs(),
+			)
+			return
+		}
+	}
+	if result, err = f(context.WithValue(ctx, ctxKeyReturning, holder)); err != nil {
+		return result, err
+	}
+	if !holder.Returned {
+		switch in.Operation {
+		case AuditOperationInsert:
+			if len(in.Data) == 1 && primaryKey != "" {
+				if id, ok := in.Data[0][primaryKey]; ok {
+					primaryIds = []interface{}{id}
+				} else if id, e := result.LastInsertId(); e == nil && id > 0 {
+					primaryIds = []interface{}{id}
+				}
+			}
+
+		case AuditOperationUpdate:
+			if primaryKey == "" {
+				holder.Records, err = m.selectReturningRecords(
+					ctx, in, strings.Join(columns, ","), in.Condition, in.conditionArgs(),
+				)
+			}
+		}
+		if len(primaryIds) > 0 {
+			holder.Records, err = m.selectReturningRecords(ctx, in, strings.Join(columns, ","), fmt.Sprintf(
+				` WHERE %s IN(%s)`, core.QuoteWord(primaryKey), strings.TrimSuffix(strings.Repeat("?,", len(primaryIds)), ","),
+			), primaryIds)
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+	return &SqlResult{Result: result, Records: holder.Records}, nil
+}
+
+// selectReturningRecords queries and returns the `columns` of `in.Table` by `condition` and `args`.
+func (m *Model) selectReturningRecords(
+	ctx context.Context, in auditInput, columns string, condition string, args []interface{},
+) (Result, error) {
+	return m.db.DoSelect(ctx, in.Link, fmt.Sprintf(`SELECT %s FROM %s%s`, columns, in.Table, condition), args...)
+}
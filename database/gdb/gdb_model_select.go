@@ -187,6 +187,11 @@ func (m *Model) doStruct(pointer interface{}, where ...interface{}) error {
 	if err != nil {
 		return err
 	}
+	if one != nil {
+		if err = model.decryptResult(Result{one}, getPointerEncryptedFields(pointer)); err != nil {
+			return err
+		}
+	}
 	if err = one.Struct(pointer); err != nil {
 		return err
 	}
@@ -231,6 +236,9 @@ func (m *Model) doStructs(pointer interface{}, where ...interface{}) error {
 	if err != nil {
 		return err
 	}
+	if err = model.decryptResult(all, getPointerEncryptedFields(pointer)); err != nil {
+		return err
+	}
 	if err = all.Structs(pointer); err != nil {
 		return err
 	}
@@ -393,6 +401,9 @@ func (m *Model) Value(fieldsAndWhere ...interface{}) (Value, error) {
 			return m.Fields(gconv.String(fieldsAndWhere[0])).Value()
 		}
 	}
+	if m.sharding != nil {
+		return m.doShardingValue()
+	}
 	var (
 		sqlWithHolder, holderArgs = m.getFormattedSqlAndArgs(ctx, queryTypeValue, true)
 		all, err                  = m.doGetAllBySql(ctx, queryTypeValue, sqlWithHolder, holderArgs...)
@@ -426,6 +437,9 @@ func (m *Model) Count(where ...interface{}) (int, error) {
 	if len(where) > 0 {
 		return m.Where(where[0], where[1:]...).Count()
 	}
+	if m.sharding != nil {
+		return m.doShardingCount()
+	}
 	var (
 		sqlWithHolder, holderArgs = m.getFormattedSqlAndArgs(ctx, queryTypeCount, false)
 		all, err                  = m.doGetAllBySql(ctx, queryTypeCount, sqlWithHolder, holderArgs...)
@@ -580,13 +594,17 @@ func (m *Model) doGetAll(ctx context.Context, limit1 bool, where ...interface{})
 	if len(where) > 0 {
 		return m.Where(where[0], where[1:]...).All()
 	}
+	if m.sharding != nil {
+		return m.doShardingGetAll(ctx, limit1)
+	}
 	sqlWithHolder, holderArgs := m.getFormattedSqlAndArgs(ctx, queryTypeNormal, limit1)
 	return m.doGetAllBySql(ctx, queryTypeNormal, sqlWithHolder, holderArgs...)
 }
 
 // doGetAllBySql does the select statement on the database.
 func (m *Model) doGetAllBySql(ctx context.Context, queryType queryType, sql string, args ...interface{}) (result Result, err error) {
-	if result, err = m.getSelectResultFromCache(ctx, sql, args...); err != nil || result != nil {
+	var tagVersions map[string]int64
+	if result, tagVersions, err = m.getSelectResultFromCache(ctx, sql, args...); err != nil || result != nil {
 		return
 	}
 
@@ -606,7 +624,7 @@ func (m *Model) doGetAllBySql(ctx context.Context, queryType queryType, sql stri
 		return
 	}
 
-	err = m.saveSelectResultToCache(ctx, queryType, result, sql, args...)
+	err = m.saveSelectResultToCache(ctx, queryType, tagVersions, result, sql, args...)
 	return
 }
 
@@ -624,14 +642,14 @@ func (m *Model) getFormattedSqlAndArgs(
 		// Raw SQL Model.
 		if m.rawSql != "" {
 			sqlWithHolder = fmt.Sprintf("SELECT %s FROM (%s) AS T", queryFields, m.rawSql)
-			return sqlWithHolder, nil
+			return m.formatCTE(sqlWithHolder), nil
 		}
 		conditionWhere, conditionExtra, conditionArgs := m.formatCondition(ctx, false, true)
 		sqlWithHolder = fmt.Sprintf("SELECT %s FROM %s%s", queryFields, m.tables, conditionWhere+conditionExtra)
 		if len(m.groupBy) > 0 {
 			sqlWithHolder = fmt.Sprintf("SELECT COUNT(1) FROM (%s) count_alias", sqlWithHolder)
 		}
-		return sqlWithHolder, conditionArgs
+		return m.formatCTE(sqlWithHolder), conditionArgs
 
 	default:
 		conditionWhere, conditionExtra, conditionArgs := m.formatCondition(ctx, limit1, false)
@@ -642,7 +660,7 @@ func (m *Model) getFormattedSqlAndArgs(
 				m.rawSql,
 				conditionWhere+conditionExtra,
 			)
-			return sqlWithHolder, conditionArgs
+			return m.formatCTE(sqlWithHolder), conditionArgs
 		}
 		// DO NOT quote the m.fields where, in case of fields like:
 		// DISTINCT t.user_id uid
@@ -650,7 +668,7 @@ func (m *Model) getFormattedSqlAndArgs(
 			"SELECT %s%s FROM %s%s",
 			m.distinct, m.getFieldsFiltered(), m.tables, conditionWhere+conditionExtra,
 		)
-		return sqlWithHolder, conditionArgs
+		return m.formatCTE(sqlWithHolder), conditionArgs
 	}
 }
 
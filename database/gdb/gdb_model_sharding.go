@@ -0,0 +1,392 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/crc32"
+	"reflect"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+// ShardingFunc computes the shard index in [0, count) of the sharding key `value`.
+type ShardingFunc func(value interface{}, count int) (index int, err error)
+
+// ShardingRule is the rule of sharding for Model.Sharding.
+type ShardingRule struct {
+	Key    string       // Field name of the sharding key, eg: "user_id".
+	Tables int          // Count of the sharded tables, eg: 64 for tables from "user_0" to "user_63".
+	Groups []string     // Configuration groups of the physical databases, which the tables are distributed to evenly in order.
+	Func   ShardingFunc // Function computing the shard index of the key value, which is ShardingHash in default.
+}
+
+// modelSharding is the sharding rule and the explicit sharding value of Model.
+type modelSharding struct {
+	rule     ShardingRule
+	value    interface{}
+	hasValue bool
+}
+
+// Sharding sets the sharding rule for current operation, which routes the operation to the
+// sharded tables named "{table}_{index}", and also the physical databases if ShardingRule.Groups is given, eg:
+//
+//	db.Model("user").Sharding(gdb.ShardingRule{Key: "user_id", Tables: 64}).Where("user_id", 10001).One()
+//
+// The shard is determined by the sharding value of ShardingValue, or the equal condition of the sharding key
+// like Where("user_id", 10001) or Where(g.Map{"user_id": 10001}), and the inserting data are routed by the
+// values of the sharding key in each record.
+//
+// The select/update/delete operations without sharding value are performed on all shards one by one,
+// in which the selected results are merged in shard order and the counts and affected rows are summed.
+// Note that the ordering and paging of the merged results are not supported, and neither are the
+// aggregate functions like Sum/Max except Count.
+func (m *Model) Sharding(rule ShardingRule) *Model {
+	model := m.getModel()
+	model.sharding = &modelSharding{rule: rule}
+	return model
+}
+
+// ShardingValue sets the sharding key value explicitly for current operation, which has priority over
+// the conditions and data. It makes sense only if the sharding rule is set by Sharding.
+func (m *Model) ShardingValue(value interface{}) *Model {
+	model := m.getModel()
+	if model.sharding != nil {
+		model.sharding = &modelSharding{
+			rule:     model.sharding.rule,
+			value:    value,
+			hasValue: true,
+		}
+	}
+	return model
+}
+
+// ShardingHash is the default ShardingFunc, which uses the modulus of integer value,
+// or the modulus of crc32 checksum of other values like string.
+func ShardingHash(value interface{}, count int) (int, error) {
+	if count <= 0 {
+		return 0, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid sharding count: %d`, count)
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v := gconv.Int64(value) % int64(count)
+		if v < 0 {
+			v = -v
+		}
+		return int(v), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int(gconv.Uint64(value) % uint64(count)), nil
+
+	default:
+		return int(crc32.ChecksumIEEE([]byte(gconv.String(value))) % uint32(count)), nil
+	}
+}
+
+// ShardingRange returns a ShardingFunc using ranges of integer value, in which `bounds` are the ascending
+// lower bounds of shards from index 1, eg: ShardingRange(1000000, 2000000) routes value less than 1000000
+// to shard 0, value in [1000000, 2000000) to shard 1, and the others to shard 2.
+func ShardingRange(bounds ...int64) ShardingFunc {
+	return func(value interface{}, count int) (int, error) {
+		var (
+			v     = gconv.Int64(value)
+			index = 0
+		)
+		for _, bound := range bounds {
+			if v < bound {
+				break
+			}
+			index++
+		}
+		if index >= count {
+			return 0, gerror.NewCodef(
+				gcode.CodeInvalidParameter,
+				`sharding value "%v" is out of range of %d shards`, value, count,
+			)
+		}
+		return index, nil
+	}
+}
+
+// getShardingModels returns the models of the shards that current operation is performed on,
+// which is one model if the sharding value is determined, or else the models of all shards.
+func (m *Model) getShardingModels() ([]*Model, error) {
+	if value, ok := m.getShardingValue(); ok {
+		index, err := m.getShardingIndex(value)
+		if err != nil {
+			return nil, err
+		}
+		model, err := m.getShardingModel(index)
+		if err != nil {
+			return nil, err
+		}
+		return []*Model{model}, nil
+	}
+	if m.sharding.rule.Tables <= 0 {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter, `invalid sharding table count: %d`, m.sharding.rule.Tables,
+		)
+	}
+	models := make([]*Model, m.sharding.rule.Tables)
+	for i := range models {
+		model, err := m.getShardingModel(i)
+		if err != nil {
+			return nil, err
+		}
+		models[i] = model
+	}
+	return models, nil
+}
+
+// getShardingValue returns the explicit sharding value, or the value from the equal condition of
+// sharding key. It returns false if there's no sharding value or there's OR condition.
+func (m *Model) getShardingValue() (value interface{}, ok bool) {
+	if m.sharding.hasValue {
+		return m.sharding.value, true
+	}
+	for _, holder := range m.whereBuilder.whereHolder {
+		if holder.Operator == whereHolderOperatorOr {
+			return nil, false
+		}
+		if v, found := getShardingValueFromWhereHolder(holder, m.sharding.rule.Key); found {
+			value, ok = v, true
+		}
+	}
+	return
+}
+
+// getShardingValueFromWhereHolder returns the value of the equal condition of `key` in `holder`.
+func getShardingValueFromWhereHolder(holder WhereHolder, key string) (interface{}, bool) {
+	switch where := holder.Where.(type) {
+	case string:
+		if len(holder.Args) != 1 {
+			return nil, false
+		}
+		field := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(where), "?"))
+		field = strings.TrimSpace(strings.TrimSuffix(field, "="))
+		if !strings.EqualFold(field, key) || isShardingMultipleValue(holder.Args[0]) {
+			return nil, false
+		}
+		return holder.Args[0], true
+
+	case Map:
+		for k, v := range where {
+			if strings.EqualFold(strings.TrimSpace(k), key) && !isShardingMultipleValue(v) {
+				return v, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// isShardingMultipleValue checks whether `value` is a slice for IN condition.
+func isShardingMultipleValue(value interface{}) bool {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Slice, reflect.Array:
+		_, isBytes := value.([]byte)
+		return !isBytes
+	}
+	return false
+}
+
+// getShardingIndex computes the shard index of sharding value `value`.
+func (m *Model) getShardingIndex(value interface{}) (int, error) {
+	var (
+		rule = m.sharding.rule
+		fn   = rule.Func
+	)
+	if fn == nil {
+		fn = ShardingHash
+	}
+	index, err := fn(value, rule.Tables)
+	if err != nil {
+		return 0, err
+	}
+	if index < 0 || index >= rule.Tables {
+		return 0, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`sharding index %d of value "%v" is out of range of %d tables`, index, value, rule.Tables,
+		)
+	}
+	return index, nil
+}
+
+// getShardingModel returns a model without sharding, which operates on the table and database of shard `index`.
+func (m *Model) getShardingModel(index int) (*Model, error) {
+	var (
+		rule         = m.sharding.rule
+		db           = m.db
+		charL, charR = m.db.GetChars()
+		table        = gstr.Trim(m.tablesInit, charL+charR)
+	)
+	if table == "" || gstr.ContainsAny(table, " ,.") {
+		return nil, gerror.NewCodef(
+			gcode.CodeNotSupported, `sharding is supported only for model of single table, but given "%s"`, m.tablesInit,
+		)
+	}
+	if len(rule.Groups) > 0 {
+		if m.tx != nil {
+			return nil, gerror.NewCode(
+				gcode.CodeNotSupported, `database sharding is not supported in transaction`,
+			)
+		}
+		instance, err := Instance(rule.Groups[index*len(rule.Groups)/rule.Tables])
+		if err != nil {
+			return nil, err
+		}
+		db = instance.Ctx(m.GetCtx())
+	}
+	var (
+		model      = m.Clone()
+		shardTable = db.GetCore().QuoteWord(fmt.Sprintf(`%s_%d`, table, index))
+	)
+	model.sharding = nil
+	if db != m.db {
+		model.db = db
+		model.schema = db.GetSchema()
+	}
+	model.tables = gstr.Replace(model.tables, model.tablesInit, shardTable)
+	model.tablesInit = shardTable
+	return model, nil
+}
+
+// doShardingGetAll does the select statement on the shards, and merges the results.
+func (m *Model) doShardingGetAll(ctx context.Context, limit1 bool) (Result, error) {
+	models, err := m.getShardingModels()
+	if err != nil {
+		return nil, err
+	}
+	if len(models) > 1 && m.start > 0 {
+		return nil, gerror.NewCode(
+			gcode.CodeNotSupported, `paging is not supported for the select across shards`,
+		)
+	}
+	var result = make(Result, 0)
+	for _, model := range models {
+		all, err := model.doGetAll(ctx, limit1)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, all...)
+		if limit1 && len(result) > 0 {
+			return result[:1], nil
+		}
+		if m.limit > 0 && len(result) >= m.limit {
+			return result[:m.limit], nil
+		}
+	}
+	return result, nil
+}
+
+// doShardingCount does the count statement on the shards, and sums the counts.
+func (m *Model) doShardingCount() (int, error) {
+	models, err := m.getShardingModels()
+	if err != nil {
+		return 0, err
+	}
+	var total int
+	for _, model := range models {
+		count, err := model.Count()
+		if err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// doShardingValue does the value statement on the shard, which requires the sharding value.
+func (m *Model) doShardingValue() (Value, error) {
+	models, err := m.getShardingModels()
+	if err != nil {
+		return nil, err
+	}
+	if len(models) > 1 {
+		return nil, gerror.NewCode(
+			gcode.CodeNotSupported, `value query across shards is not supported, sharding value is required`,
+		)
+	}
+	return models[0].Value()
+}
+
+// doShardingInsert groups the inserting records by shard, and inserts them into each shard.
+func (m *Model) doShardingInsert(ctx context.Context, insertOption InsertOption) (sql.Result, error) {
+	var list List
+	switch value := m.data.(type) {
+	case List:
+		list = value
+	case Map:
+		list = List{value}
+	default:
+		return nil, gerror.NewCodef(
+			gcode.CodeNotSupported, `unsupported data type "%T" for sharding insert`, m.data,
+		)
+	}
+	var (
+		indexes = make([]int, 0)
+		groups  = make(map[int]List)
+	)
+	for _, record := range list {
+		value := m.sharding.value
+		if !m.sharding.hasValue {
+			var foundKey string
+			if foundKey, value = gutil.MapPossibleItemByKey(record, m.sharding.rule.Key); foundKey == "" {
+				return nil, gerror.NewCodef(
+					gcode.CodeMissingParameter, `sharding key "%s" not found in inserting data`, m.sharding.rule.Key,
+				)
+			}
+		}
+		index, err := m.getShardingIndex(value)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := groups[index]; !ok {
+			indexes = append(indexes, index)
+		}
+		groups[index] = append(groups[index], record)
+	}
+	models := make([]*Model, len(indexes))
+	for i, index := range indexes {
+		model, err := m.getShardingModel(index)
+		if err != nil {
+			return nil, err
+		}
+		model.data = groups[index]
+		models[i] = model
+	}
+	return doShardingExec(models, func(model *Model) (sql.Result, error) {
+		return model.doInsertWithOption(ctx, insertOption)
+	})
+}
+
+// doShardingExec executes `handler` on the shards, and sums the affected rows.
+// Note that the executions on the shards are not atomic.
+func doShardingExec(models []*Model, handler func(model *Model) (sql.Result, error)) (sql.Result, error) {
+	var (
+		result   sql.Result
+		affected int64
+		err      error
+	)
+	for _, model := range models {
+		if result, err = handler(model); err != nil {
+			return nil, err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		affected += n
+	}
+	return &SqlResult{Result: result, Affected: affected}, nil
+}
@@ -8,6 +8,7 @@ package gdb
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
 
@@ -33,13 +34,25 @@ const (
 	SoftTimeTypeTimestampMilli SoftTimeType = 3 // In unix milliseconds.
 	SoftTimeTypeTimestampMicro SoftTimeType = 4 // In unix microseconds.
 	SoftTimeTypeTimestampNano  SoftTimeType = 5 // In unix nanoseconds.
+	SoftTimeTypeFlag           SoftTimeType = 6 // Using 1 as deleted and 0 as alive for soft deleting field, and auto detecting for others.
 )
 
 // SoftTimeOption is the option to customize soft time feature for Model.
 type SoftTimeOption struct {
-	SoftTimeType SoftTimeType // The value type for soft time field.
+	SoftTimeType    SoftTimeType // The value type for soft time field.
+	DeletedField    string       // Custom field name for soft deleting, which overrides ConfigNode.DeletedAt.
+	DeletedNullable bool         // NULL means alive for soft deleting field of any type, which is only for datetime field in default.
 }
 
+// softDeletedScope is the scope of soft deleted records for select statement.
+type softDeletedScope int
+
+const (
+	softDeletedScopeExclude softDeletedScope = iota // Excludes soft deleted records, which is the default.
+	softDeletedScopeWith                            // Includes soft deleted records.
+	softDeletedScopeOnly                            // Only soft deleted records.
+)
+
 type softTimeMaintainer struct {
 	*Model
 }
@@ -97,6 +110,45 @@ func (m *Model) Unscoped() *Model {
 	return model
 }
 
+// WithTrashed includes the soft deleted records for the operations of Model.
+// Unlike Unscoped, it does not disable the soft time feature,
+// which means the Delete operation is still soft deleting.
+func (m *Model) WithTrashed() *Model {
+	model := m.getModel()
+	model.trashedScope = softDeletedScopeWith
+	return model
+}
+
+// OnlyTrashed limits the operations of Model to the soft deleted records.
+func (m *Model) OnlyTrashed() *Model {
+	model := m.getModel()
+	model.trashedScope = softDeletedScopeOnly
+	return model
+}
+
+// Restore restores the soft deleted records by resetting the soft deleting field to alive value.
+// The optional parameter `where` is the same as the parameter of Model.Where function,
+// see Model.Where.
+func (m *Model) Restore(where ...interface{}) (result sql.Result, err error) {
+	if len(where) > 0 {
+		return m.Where(where[0], where[1:]...).Restore()
+	}
+	var (
+		ctx                  = m.GetCtx()
+		stm                  = m.softTimeMaintainer()
+		fieldName, fieldType = stm.GetFieldNameAndTypeForDelete(ctx, "", m.tablesInit)
+	)
+	if fieldName == "" {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidOperation,
+			`there's no soft deleting field for table "%s"`, m.tablesInit,
+		)
+	}
+	return m.OnlyTrashed().Data(
+		fieldName, stm.GetValueByFieldTypeForCreateOrUpdate(ctx, fieldType, true),
+	).Update()
+}
+
 func (m *Model) softTimeMaintainer() iSoftTimeMaintainer {
 	return &softTimeMaintainer{
 		m,
@@ -173,6 +225,11 @@ func (m *softTimeMaintainer) GetFieldNameAndTypeForDelete(
 	} else {
 		tableName = m.tablesInit
 	}
+	if m.softTimeOption.DeletedField != "" {
+		return m.getSoftFieldNameAndType(
+			ctx, schema, tableName, []string{m.softTimeOption.DeletedField},
+		)
+	}
 	config := m.db.GetConfig()
 	if config.DeletedAt != "" {
 		return m.getSoftFieldNameAndType(
@@ -245,7 +302,7 @@ func (m *softTimeMaintainer) getSoftFieldNameAndType(
 // "user LEFT JOIN user_detail ON(user_detail.uid=user.uid)"
 // "user u LEFT JOIN user_detail ud ON(ud.uid=u.uid) LEFT JOIN user_stats us ON(us.uid=u.uid)".
 func (m *softTimeMaintainer) GetWhereConditionForDelete(ctx context.Context) string {
-	if m.unscoped {
+	if m.unscoped || m.trashedScope == softDeletedScopeWith {
 		return ""
 	}
 	conditionArray := garray.NewStrArray()
@@ -322,7 +379,11 @@ func (m *softTimeMaintainer) GetDataByFieldNameAndTypeForDelete(
 		quotedFieldName = fmt.Sprintf(`%s.%s`, quotedFieldPrefix, quotedFieldName)
 	}
 	dataHolder = fmt.Sprintf(`%s=?`, quotedFieldName)
-	dataValue = m.GetValueByFieldTypeForCreateOrUpdate(ctx, fieldType, false)
+	if m.softTimeOption.SoftTimeType == SoftTimeTypeFlag {
+		dataValue = 1
+	} else {
+		dataValue = m.GetValueByFieldTypeForCreateOrUpdate(ctx, fieldType, false)
+	}
 	return
 }
 
@@ -336,13 +397,24 @@ func (m *softTimeMaintainer) getConditionByFieldNameAndTypeForSoftDeleting(
 	if quotedFieldPrefix != "" {
 		quotedFieldName = fmt.Sprintf(`%s.%s`, quotedFieldPrefix, quotedFieldName)
 	}
+	var (
+		conditionForNull = fmt.Sprintf(`%s IS NULL`, quotedFieldName)
+		conditionForZero = fmt.Sprintf(`%s=0`, quotedFieldName)
+	)
+	if m.trashedScope == softDeletedScopeOnly {
+		conditionForNull = fmt.Sprintf(`%s IS NOT NULL`, quotedFieldName)
+		conditionForZero = fmt.Sprintf(`%s<>0`, quotedFieldName)
+	}
+	if m.softTimeOption.DeletedNullable {
+		return conditionForNull
+	}
 	switch m.softTimeOption.SoftTimeType {
 	case SoftTimeTypeAuto:
 		switch fieldType {
 		case LocalTypeDate, LocalTypeDatetime:
-			return fmt.Sprintf(`%s IS NULL`, quotedFieldName)
+			return conditionForNull
 		case LocalTypeInt, LocalTypeUint, LocalTypeInt64, LocalTypeUint64, LocalTypeBool:
-			return fmt.Sprintf(`%s=0`, quotedFieldName)
+			return conditionForZero
 		default:
 			intlog.Errorf(
 				ctx,
@@ -352,10 +424,10 @@ func (m *softTimeMaintainer) getConditionByFieldNameAndTypeForSoftDeleting(
 		}
 
 	case SoftTimeTypeTime:
-		return fmt.Sprintf(`%s IS NULL`, quotedFieldName)
+		return conditionForNull
 
 	default:
-		return fmt.Sprintf(`%s=0`, quotedFieldName)
+		return conditionForZero
 	}
 	return ""
 }
@@ -367,6 +439,9 @@ func (m *softTimeMaintainer) GetValueByFieldTypeForCreateOrUpdate(
 ) any {
 	var value any
 	if isDeletedField {
+		if m.softTimeOption.DeletedNullable {
+			return nil
+		}
 		switch fieldType {
 		case LocalTypeDate, LocalTypeDatetime:
 			value = nil
@@ -376,7 +451,7 @@ func (m *softTimeMaintainer) GetValueByFieldTypeForCreateOrUpdate(
 		return value
 	}
 	switch m.softTimeOption.SoftTimeType {
-	case SoftTimeTypeAuto:
+	case SoftTimeTypeAuto, SoftTimeTypeFlag:
 		switch fieldType {
 		case LocalTypeDate, LocalTypeDatetime:
 			value = gtime.Now()
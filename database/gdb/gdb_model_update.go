@@ -36,6 +36,18 @@ func (m *Model) Update(dataAndWhere ...interface{}) (result sql.Result, err erro
 			return m.Data(dataAndWhere[0]).Update()
 		}
 	}
+	if m.version != nil {
+		return m.doUpdateWithVersion()
+	}
+	if m.sharding != nil {
+		models, err := m.getShardingModels()
+		if err != nil {
+			return nil, err
+		}
+		return doShardingExec(models, func(model *Model) (sql.Result, error) {
+			return model.Update()
+		})
+	}
 	defer func() {
 		if err == nil {
 			m.checkAndRemoveSelectCache(ctx)
@@ -7,6 +7,7 @@
 package gdb
 
 import (
+	"sort"
 	"time"
 
 	"github.com/gogf/gf/v2/container/gset"
@@ -137,6 +138,11 @@ func (m *Model) filterDataForInsertOrUpdate(data interface{}) (interface{}, erro
 // Note that, it does not filter list item, which is also type of map, for "omit empty" feature.
 func (m *Model) doMappingAndFilterForInsertOrUpdateDataMap(data Map, allowOmitEmpty bool) (Map, error) {
 	var err error
+	if len(m.encryption) > 0 {
+		if data, err = m.encryptDataMap(data); err != nil {
+			return nil, err
+		}
+	}
 	data, err = m.db.GetCore().mappingAndFilterData(
 		m.GetCtx(), m.schema, m.tablesInit, data, m.filter,
 	)
@@ -259,6 +265,31 @@ func (m *Model) getPrimaryKey() string {
 	return ""
 }
 
+// getPrimaryKeys retrieves and returns the primary key names of the model table in order of field index,
+// which contains multiple names for composite primary key.
+func (m *Model) getPrimaryKeys() []string {
+	table := gstr.SplitAndTrim(m.tablesInit, " ")[0]
+	tableFields, err := m.TableFields(table)
+	if err != nil {
+		return nil
+	}
+	return getPrimaryKeysOfFields(tableFields)
+}
+
+// getPrimaryKeysOfFields returns the primary key names of `fields` in order of field index.
+func getPrimaryKeysOfFields(fields map[string]*TableField) []string {
+	keys := make([]string, 0)
+	for name, field := range fields {
+		if gstr.ContainsI(field.Key, "pri") {
+			keys = append(keys, name)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fields[keys[i]].Index < fields[keys[j]].Index
+	})
+	return keys
+}
+
 // mergeArguments creates and returns new arguments by merging `m.extraArgs` and given `args`.
 func (m *Model) mergeArguments(args []interface{}) []interface{} {
 	if len(m.extraArgs) > 0 {
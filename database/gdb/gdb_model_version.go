@@ -0,0 +1,152 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gdb
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/empty"
+	"github.com/gogf/gf/v2/os/gstructs"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+const (
+	// TagForGdb is the struct tag for gdb features of the attribute, eg:
+	// `gdb:"version"` marks the attribute as version column for optimistic locking.
+	TagForGdb          = "gdb"
+	TagValueForVersion = "version"
+)
+
+// VersionConflictError is the error of optimistic locking that occurs when updating the record
+// by struct having version attribute, if the record was updated by others after it's read,
+// which can be checked using errors.As or gerror.Code as gcode.CodeOperationFailed.
+type VersionConflictError struct {
+	Table   string      // Table of the record.
+	Column  string      // Version column.
+	Version interface{} // Version of the record that is read before updating.
+}
+
+// modelVersion is the version attribute of the updating struct for optimistic locking.
+type modelVersion struct {
+	name  string        // Name of the attribute in data map, which is the orm tag or attribute name.
+	value interface{}   // Version that is read.
+	field reflect.Value // The attribute, which is increased after updating if it's settable.
+}
+
+// Error implements the interface error.
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf(
+		`version conflict: record of table %s with %s=%v was modified by others`,
+		e.Table, e.Column, e.Version,
+	)
+}
+
+// Code implements the interface gerror.ICode.
+func (e *VersionConflictError) Code() gcode.Code {
+	return gcode.CodeOperationFailed
+}
+
+// getStructVersion returns the version attribute of struct `value` that has tag `gdb:"version"`.
+func getStructVersion(value interface{}) *modelVersion {
+	fields, err := gstructs.Fields(gstructs.FieldsInput{
+		Pointer:         value,
+		RecursiveOption: gstructs.RecursiveOptionEmbeddedNoTag,
+	})
+	if err != nil {
+		return nil
+	}
+	for _, field := range fields {
+		if field.Tag(TagForGdb) != TagValueForVersion {
+			continue
+		}
+		name := gstr.Split(field.Tag(OrmTagForStruct), ",")[0]
+		if name == "" {
+			name = field.TagPriorityName()
+		}
+		return &modelVersion{
+			name:  name,
+			value: field.Value.Interface(),
+			field: field.Value,
+		}
+	}
+	return nil
+}
+
+// doUpdateWithVersion updates the record with condition of the version, and increases the version.
+// The record is specified by the WHERE condition of the model, or the primary key in the data if there's
+// no WHERE condition. It returns VersionConflictError if no record is updated.
+func (m *Model) doUpdateWithVersion() (result sql.Result, err error) {
+	var (
+		version = m.version
+		model   = m.Clone()
+		column  = version.name
+	)
+	if columns := m.mappingAndFilterToTableFields(m.tablesInit, []string{column}, false); len(columns) > 0 {
+		column = columns[0]
+	}
+	data := make(map[string]interface{})
+	for k, v := range gconv.Map(m.data) {
+		data[k] = v
+	}
+	if foundKey, _ := gutil.MapPossibleItemByKey(data, version.name); foundKey != "" {
+		delete(data, foundKey)
+	}
+	// The version condition is not a condition specifying the record,
+	// so it requires the WHERE condition or the primary key in the data, like the updating without version.
+	if conditionWhere, _ := m.whereBuilder.Build(); conditionWhere == "" {
+		primaryKeys := m.getPrimaryKeys()
+		if len(primaryKeys) == 0 {
+			return nil, gerror.NewCode(
+				gcode.CodeMissingParameter,
+				"there should be WHERE condition statement for UPDATE operation",
+			)
+		}
+		for _, primaryKey := range primaryKeys {
+			foundKey, foundValue := gutil.MapPossibleItemByKey(data, primaryKey)
+			if foundKey == "" || empty.IsEmpty(foundValue) {
+				return nil, gerror.NewCode(
+					gcode.CodeMissingParameter,
+					"there should be WHERE condition statement for UPDATE operation",
+				)
+			}
+			delete(data, foundKey)
+			model = model.Where(primaryKey, foundValue)
+		}
+	}
+	data[column] = &Counter{Field: column, Value: 1}
+	model.version = nil
+	model.data = data
+	if result, err = model.Where(column, version.value).Update(); err != nil {
+		return
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return result, err
+	}
+	if affected == 0 {
+		return result, &VersionConflictError{
+			Table:   m.tablesInit,
+			Column:  column,
+			Version: version.value,
+		}
+	}
+	if version.field.CanSet() {
+		switch version.field.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			version.field.SetInt(version.field.Int() + 1)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			version.field.SetUint(version.field.Uint() + 1)
+		}
+	}
+	return result, nil
+}
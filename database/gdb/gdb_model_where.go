@@ -117,3 +117,15 @@ func (m *Model) WhereNotIn(column string, in interface{}) *Model {
 func (m *Model) WhereNotNull(columns ...string) *Model {
 	return m.callWhereBuilder(m.whereBuilder.WhereNotNull(columns...))
 }
+
+// WhereJSONContains builds condition that the value at JSON `path` contains `value`.
+// See WhereBuilder.WhereJSONContains.
+func (m *Model) WhereJSONContains(path string, value interface{}) *Model {
+	return m.callWhereBuilder(m.whereBuilder.WhereJSONContains(path, value))
+}
+
+// WhereJSONExtract builds `extracted operator value` statement on JSON `path`.
+// See WhereBuilder.WhereJSONExtract.
+func (m *Model) WhereJSONExtract(path string, operator string, value interface{}) *Model {
+	return m.callWhereBuilder(m.whereBuilder.WhereJSONExtract(path, operator, value))
+}
@@ -17,6 +17,7 @@ import (
 type SqlResult struct {
 	Result   sql.Result
 	Affected int64
+	Records  Result // Records returned by RETURNING clause, see Model.Returning.
 }
 
 // MustGetAffected returns the affected rows count, if any error occurs, it panics.
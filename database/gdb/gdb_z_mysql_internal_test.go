@@ -9,6 +9,7 @@ package gdb
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/gogf/gf/v2/test/gtest"
 	"github.com/gogf/gf/v2/text/gregex"
@@ -320,3 +321,173 @@ func Test_isSubQuery(t *testing.T) {
 		t.Assert(isSubQuery("select 1"), true)
 	})
 }
+
+func Test_ReplicaPolicy(t *testing.T) {
+	replicas := []ReplicaNode{
+		{ConfigNode: ConfigNode{Host: "127.0.0.1", Weight: 2}, Latency: 3 * time.Millisecond},
+		{ConfigNode: ConfigNode{Host: "127.0.0.2", Weight: 1}, Latency: time.Millisecond},
+		{ConfigNode: ConfigNode{Host: "127.0.0.3", Weight: 1}},
+	}
+	gtest.C(t, func(t *gtest.T) {
+		policy := NewReplicaPolicy(ReplicaPolicyRoundRobin)
+		selected := make([]int, 0)
+		for i := 0; i < 4; i++ {
+			selected = append(selected, policy.Select(replicas))
+		}
+		t.Assert(selected, []int{0, 1, 2, 0})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		policy := NewReplicaPolicy(ReplicaPolicyWeighted)
+		selected := make([]int, 0)
+		for i := 0; i < 4; i++ {
+			selected = append(selected, policy.Select(replicas))
+		}
+		t.Assert(selected, []int{0, 1, 2, 0})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(NewReplicaPolicy(ReplicaPolicyLatency).Select(replicas), 1)
+		t.Assert(NewReplicaPolicy(ReplicaPolicyLatency).Select(replicas[2:]), 0)
+		for i := 0; i < 10; i++ {
+			t.AssertLT(NewReplicaPolicy(ReplicaPolicyRandom).Select(replicas), 3)
+		}
+	})
+}
+
+func Test_getReplicaConfigNode(t *testing.T) {
+	var (
+		group      = "test_replica"
+		masterList = ConfigGroup{{Host: "127.0.0.1"}}
+		slaveList  = ConfigGroup{
+			{Host: "127.0.0.2", Role: dbRoleSlave, ReplicaPolicy: ReplicaPolicyRoundRobin},
+			{Host: "127.0.0.3", Role: dbRoleSlave},
+		}
+	)
+	defer replicaStates.Remove(group)
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(getReplicaConfigNode(group, masterList, slaveList).Host, "127.0.0.2")
+		t.Assert(getReplicaConfigNode(group, masterList, slaveList).Host, "127.0.0.3")
+
+		// Dead replica is removed from selecting.
+		state := getReplicaState(group)
+		state.health[getReplicaNodeKey(&slaveList[0])] = &replicaHealth{dead: true}
+		t.Assert(getReplicaConfigNode(group, masterList, slaveList).Host, "127.0.0.3")
+		t.Assert(getReplicaConfigNode(group, masterList, slaveList).Host, "127.0.0.3")
+		state.health[getReplicaNodeKey(&slaveList[1])] = &replicaHealth{dead: true}
+		t.Assert(getReplicaConfigNode(group, masterList, slaveList).Host, "127.0.0.1")
+		state.health = make(map[string]*replicaHealth)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		policy := NewReplicaPolicy(ReplicaPolicyRoundRobin)
+		SetReplicaPolicy(group, policy)
+		t.Assert(getReplicaState(group).policy, policy)
+	})
+}
+
+func Test_parseJSONPath(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		column, items := parseJSONPath("extra")
+		t.Assert(column, "extra")
+		t.Assert(len(items), 0)
+
+		column, items = parseJSONPath("extra.address.city")
+		t.Assert(column, "extra")
+		t.Assert(items, []string{"address", "city"})
+
+		column, items = parseJSONPath("tags[0][1].name")
+		t.Assert(column, "tags")
+		t.Assert(items, []string{"0", "1", "name"})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(FormatJSONPath(nil), "$")
+		t.Assert(FormatJSONPath([]string{"address", "0", "city"}), "$.address[0].city")
+		t.Assert(FormatJSONPath([]string{"first name", `it's`}), `$."first name"."it's"`)
+		t.Assert(FormatJSONPath([]string{`a"b`, `c\`}), `$."a\"b"."c\\"`)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		core := &Core{}
+		expr, args := core.FormatJSON(JSONOperationExtract, "`extra`", nil)
+		t.Assert(expr, "`extra`")
+		t.Assert(len(args), 0)
+
+		expr, args = core.FormatJSON(JSONOperationExtract, "`extra`", []string{"city"})
+		t.Assert(expr, "JSON_UNQUOTE(JSON_EXTRACT(`extra`, ?))")
+		t.Assert(args, []interface{}{"$.city"})
+
+		expr, args = core.FormatJSON(JSONOperationContains, "`tags`", nil)
+		t.Assert(expr, "JSON_CONTAINS(`tags`, ?)")
+		t.Assert(len(args), 0)
+
+		expr, args = core.FormatJSON(JSONOperationContains, "`extra`", []string{"tags"})
+		t.Assert(expr, "JSON_CONTAINS(JSON_EXTRACT(`extra`, ?), ?)")
+		t.Assert(args, []interface{}{"$.tags"})
+	})
+}
+
+func Test_splitMigrationSql(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		statements := splitMigrationSql(`
+-- Create table.
+CREATE TABLE user (
+  id INT PRIMARY KEY, -- The id; it is unique.
+  name VARCHAR(45) DEFAULT 'a;b'
+);
+INSERT INTO user VALUES(1, 'it''s;'), (2, 'c:\\'); INSERT INTO user VALUES(3, "x;y");
+/* comment; */ SELECT 1;
+-- The last comment;
+`)
+		t.Assert(len(statements), 4)
+		t.Assert(statements[0], "CREATE TABLE user (\n  id INT PRIMARY KEY, \n  name VARCHAR(45) DEFAULT 'a;b'\n)")
+		t.Assert(statements[1], `INSERT INTO user VALUES(1, 'it''s;'), (2, 'c:\\')`)
+		t.Assert(statements[2], `INSERT INTO user VALUES(3, "x;y")`)
+		t.Assert(statements[3], `/* comment; */ SELECT 1`)
+	})
+	// Delimiter directive.
+	gtest.C(t, func(t *gtest.T) {
+		statements := splitMigrationSql(`
+DELIMITER $$
+CREATE PROCEDURE p()
+BEGIN
+  SELECT 1;
+  SELECT 2;
+END$$
+DELIMITER ;
+CALL p();
+`)
+		t.Assert(len(statements), 2)
+		t.Assert(statements[0], "CREATE PROCEDURE p()\nBEGIN\n  SELECT 1;\n  SELECT 2;\nEND")
+		t.Assert(statements[1], `CALL p()`)
+	})
+	// Dollar-quoted string of PostgreSQL.
+	gtest.C(t, func(t *gtest.T) {
+		statements := splitMigrationSql(`
+CREATE FUNCTION f() RETURNS INT AS $body$
+BEGIN
+  RETURN 1;
+END;
+$body$ LANGUAGE plpgsql;
+SELECT $1, $$a;b$$;
+`)
+		t.Assert(len(statements), 2)
+		t.Assert(statements[0], "CREATE FUNCTION f() RETURNS INT AS $body$\nBEGIN\n  RETURN 1;\nEND;\n$body$ LANGUAGE plpgsql")
+		t.Assert(statements[1], `SELECT $1, $$a;b$$`)
+	})
+}
+
+func Test_deriveEncryptionKey(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		key := []byte("0123456789abcdef0123456789abcdef")
+		cipherKey, err := deriveEncryptionKey(key, hkdfInfoCipherKey, len(key))
+		t.AssertNil(err)
+		nonceKey, err := deriveEncryptionKey(key, hkdfInfoNonceKey, nonceKeySize)
+		t.AssertNil(err)
+		t.Assert(len(cipherKey), len(key))
+		t.Assert(len(nonceKey), nonceKeySize)
+		t.AssertNE(cipherKey, key)
+		t.AssertNE(nonceKey, key)
+		t.AssertNE(cipherKey, nonceKey)
+
+		again, err := deriveEncryptionKey(key, hkdfInfoCipherKey, len(key))
+		t.AssertNil(err)
+		t.Assert(again, cipherKey)
+	})
+}
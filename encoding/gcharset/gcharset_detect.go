@@ -0,0 +1,278 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcharset
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+const (
+	// detectSize is the size of content at the beginning of stream for charset detection.
+	detectSize = 4096
+)
+
+// multiByteCharset describes the byte structure of a legacy multi-byte charset for detection.
+type multiByteCharset struct {
+	name string
+	// score returns the byte length of the character at the beginning of `data` and its score,
+	// the higher score means the character is more commonly used in the charset.
+	// It returns length 0 if `data` is not valid, or -1 if `data` is incomplete.
+	score func(data []byte) (length int, score int)
+}
+
+// multiByteCharsets are the charsets for detection in priority order,
+// the former one is preferred if the scores are the same.
+var multiByteCharsets = []multiByteCharset{
+	{name: "GB18030", score: scoreGB18030},
+	{name: "Big5", score: scoreBig5},
+	{name: "EUC-JP", score: scoreEUCJP},
+	{name: "Shift_JIS", score: scoreShiftJIS},
+	{name: "EUC-KR", score: scoreEUCKR},
+}
+
+// Detect detects and returns the charset of `content`, or empty string if it cannot be detected.
+//
+// It recognizes the BOM of UTF-8/UTF-16BE/UTF-16LE first, then checks whether the content is
+// UTF-8 or UTF-16 without BOM, and finally guesses among GB18030/Big5/EUC-JP/Shift_JIS/EUC-KR
+// by the byte structure of their commonly used characters. The guessing is heuristic,
+// which is more accurate for longer content.
+func Detect(content []byte) string {
+	charset, _ := detect(content, false)
+	return charset
+}
+
+// detect detects the charset of `data`, and also returns the length of its BOM.
+// The parameter `partial` specifies whether `data` is only the beginning of the content,
+// in which case the last character of `data` might be incomplete.
+func detect(data []byte, partial bool) (charset string, bomLength int) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xef, 0xbb, 0xbf}):
+		return "UTF-8", 3
+	case bytes.HasPrefix(data, []byte{0xfe, 0xff}):
+		return "UTF-16BE", 2
+	case bytes.HasPrefix(data, []byte{0xff, 0xfe}):
+		return "UTF-16LE", 2
+	}
+	if charset = detectUTF16(data); charset != "" {
+		return charset, 0
+	}
+	if isValidUTF8(data, partial) {
+		return "UTF-8", 0
+	}
+	bestScore := -1
+	for _, c := range multiByteCharsets {
+		if score := scoreMultiByteCharset(data, partial, c); score > bestScore {
+			charset, bestScore = c.name, score
+		}
+	}
+	return charset, 0
+}
+
+// detectUTF16 detects UTF-16 content without BOM by the zero bytes of ASCII characters.
+func detectUTF16(data []byte) string {
+	if len(data) < 2 {
+		return ""
+	}
+	var evenZeros, oddZeros int
+	for i := 0; i+1 < len(data); i += 2 {
+		if data[i] == 0 {
+			evenZeros++
+		}
+		if data[i+1] == 0 {
+			oddZeros++
+		}
+	}
+	pairs := len(data) / 2
+	switch {
+	case evenZeros*10 > pairs*3 && oddZeros*10 < pairs:
+		return "UTF-16BE"
+	case oddZeros*10 > pairs*3 && evenZeros*10 < pairs:
+		return "UTF-16LE"
+	}
+	return ""
+}
+
+// isValidUTF8 checks whether `data` is valid UTF-8 content, which ignores the incomplete
+// character at the end of `data` if `partial` is true.
+func isValidUTF8(data []byte, partial bool) bool {
+	if partial {
+		// The last character is at most utf8.UTFMax bytes.
+		for i := 0; i < utf8.UTFMax && i < len(data); i++ {
+			if utf8.RuneStart(data[len(data)-1-i]) {
+				if !utf8.FullRune(data[len(data)-1-i:]) {
+					data = data[:len(data)-1-i]
+				}
+				break
+			}
+		}
+	}
+	return utf8.Valid(data)
+}
+
+// scoreMultiByteCharset returns the total score of the characters in `data` for charset `c`,
+// or -1 if `data` is not valid for `c`.
+func scoreMultiByteCharset(data []byte, partial bool, c multiByteCharset) int {
+	total := 0
+	for len(data) > 0 {
+		if data[0] < 0x80 {
+			data = data[1:]
+			continue
+		}
+		length, score := c.score(data)
+		switch {
+		case length == -1 && partial:
+			return total
+		case length <= 0:
+			return -1
+		}
+		total += score
+		data = data[length:]
+	}
+	return total
+}
+
+// scoreGB18030 scores the characters of GB18030, in which the GB2312 Chinese characters
+// are most commonly used.
+func scoreGB18030(data []byte) (int, int) {
+	if data[0] == 0x80 || data[0] == 0xff {
+		return 0, 0
+	}
+	if len(data) < 2 {
+		return -1, 0
+	}
+	switch second := data[1]; {
+	case second >= 0x30 && second <= 0x39:
+		// Four bytes character.
+		if len(data) < 4 {
+			return -1, 0
+		}
+		if data[2] < 0x81 || data[2] > 0xfe || data[3] < 0x30 || data[3] > 0x39 {
+			return 0, 0
+		}
+		return 4, 0
+
+	case second >= 0x40 && second <= 0xfe && second != 0x7f:
+		switch {
+		case data[0] >= 0xb0 && data[0] <= 0xf7 && second >= 0xa1:
+			return 2, 2
+		case data[0] >= 0xa1 && data[0] <= 0xa9 && second >= 0xa1:
+			return 2, 1
+		}
+		return 2, 0
+	}
+	return 0, 0
+}
+
+// scoreBig5 scores the characters of Big5, in which the frequently used Chinese characters
+// are from 0xA440 to 0xC67E. The second byte from 0x40 to 0x7E is more significant,
+// as it is not used by the EUC charsets.
+func scoreBig5(data []byte) (int, int) {
+	if data[0] < 0x81 || data[0] == 0xff {
+		return 0, 0
+	}
+	if len(data) < 2 {
+		return -1, 0
+	}
+	second := data[1]
+	if !(second >= 0x40 && second <= 0x7e) && !(second >= 0xa1 && second <= 0xfe) {
+		return 0, 0
+	}
+	switch {
+	case data[0] >= 0xa4 && data[0] <= 0xc6 && second <= 0x7e:
+		return 2, 2
+	case data[0] >= 0xa1 && data[0] <= 0xc6, data[0] >= 0xc9 && data[0] <= 0xf9:
+		return 2, 1
+	}
+	return 2, 0
+}
+
+// scoreEUCJP scores the characters of EUC-JP, in which the kana are most commonly used.
+func scoreEUCJP(data []byte) (int, int) {
+	switch {
+	case data[0] == 0x8e:
+		// Half-width katakana.
+		if len(data) < 2 {
+			return -1, 0
+		}
+		if data[1] < 0xa1 || data[1] > 0xdf {
+			return 0, 0
+		}
+		return 2, 0
+
+	case data[0] == 0x8f:
+		// JIS X 0212 character.
+		if len(data) < 3 {
+			return -1, 0
+		}
+		if data[1] < 0xa1 || data[1] > 0xfe || data[2] < 0xa1 || data[2] > 0xfe {
+			return 0, 0
+		}
+		return 3, 0
+
+	case data[0] >= 0xa1 && data[0] <= 0xfe:
+		if len(data) < 2 {
+			return -1, 0
+		}
+		if data[1] < 0xa1 || data[1] > 0xfe {
+			return 0, 0
+		}
+		switch {
+		case data[0] == 0xa4 || data[0] == 0xa5:
+			return 2, 2
+		case data[0] >= 0xb0 && data[0] <= 0xf4:
+			return 2, 1
+		}
+		return 2, 0
+	}
+	return 0, 0
+}
+
+// scoreShiftJIS scores the characters of Shift_JIS, in which the kana are most commonly used.
+func scoreShiftJIS(data []byte) (int, int) {
+	switch {
+	case data[0] >= 0xa1 && data[0] <= 0xdf:
+		// Half-width katakana.
+		return 1, 0
+
+	case (data[0] >= 0x81 && data[0] <= 0x9f) || (data[0] >= 0xe0 && data[0] <= 0xfc):
+		if len(data) < 2 {
+			return -1, 0
+		}
+		if data[1] < 0x40 || data[1] > 0xfc || data[1] == 0x7f {
+			return 0, 0
+		}
+		switch {
+		case data[0] == 0x82 && data[1] >= 0x9f && data[1] <= 0xf1,
+			data[0] == 0x83 && data[1] >= 0x40 && data[1] <= 0x96:
+			return 2, 2
+		case (data[0] >= 0x88 && data[0] <= 0x9f) || (data[0] >= 0xe0 && data[0] <= 0xea):
+			return 2, 1
+		}
+		return 2, 0
+	}
+	return 0, 0
+}
+
+// scoreEUCKR scores the characters of EUC-KR, in which the Hangul syllables are most commonly used.
+// The Hangul syllables have higher score than the other charsets, as their bytes are also
+// the commonly used Chinese characters of GB18030.
+func scoreEUCKR(data []byte) (int, int) {
+	if data[0] < 0xa1 || data[0] == 0xff {
+		return 0, 0
+	}
+	if len(data) < 2 {
+		return -1, 0
+	}
+	if data[1] < 0xa1 || data[1] > 0xfe {
+		return 0, 0
+	}
+	if data[0] >= 0xb0 && data[0] <= 0xc8 {
+		return 2, 3
+	}
+	return 2, 0
+}
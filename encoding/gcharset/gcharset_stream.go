@@ -0,0 +1,79 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcharset
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"golang.org/x/text/transform"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// NewReader returns a reader that converts the content of `r` from charset `from` to UTF-8
+// while reading, which does not buffer the whole content in memory.
+//
+// If `from` is empty, the charset is detected from the beginning of the content using Detect,
+// and the BOM of the content is removed if there's one.
+func NewReader(r io.Reader, from string) (io.Reader, error) {
+	if from == "" {
+		var (
+			reader     = bufio.NewReaderSize(r, detectSize)
+			prefix, _  = reader.Peek(detectSize)
+			partial    = len(prefix) == detectSize
+			charset, n = detect(prefix, partial)
+		)
+		if charset == "" {
+			return nil, gerror.NewCode(gcode.CodeInvalidParameter, `cannot detect charset of the content`)
+		}
+		if _, err := reader.Discard(n); err != nil {
+			return nil, gerror.Wrap(err, `discard BOM failed`)
+		}
+		r, from = reader, charset
+	}
+	if isUTF8(from) {
+		return r, nil
+	}
+	e := getEncoding(from)
+	if e == nil {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `unsupported charset "%s"`, from)
+	}
+	return transform.NewReader(r, e.NewDecoder()), nil
+}
+
+// NewWriter returns a writer that converts the UTF-8 content written to it to charset `to`,
+// and writes the converted content to `w`.
+//
+// The returned writer must be closed to flush the buffered content, which does not close `w`.
+func NewWriter(w io.Writer, to string) (io.WriteCloser, error) {
+	if isUTF8(to) {
+		return nopWriteCloser{w}, nil
+	}
+	e := getEncoding(to)
+	if e == nil {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `unsupported charset "%s"`, to)
+	}
+	return transform.NewWriter(w, e.NewEncoder()), nil
+}
+
+// nopWriteCloser is the writer for UTF-8 content, which needs no conversion.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+// Close does nothing as there's no buffered content.
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// isUTF8 checks whether `charset` is UTF-8.
+func isUTF8(charset string) bool {
+	return strings.EqualFold(charset, "UTF-8") || strings.EqualFold(charset, "UTF8")
+}
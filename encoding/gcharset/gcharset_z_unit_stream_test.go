@@ -0,0 +1,108 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcharset_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gcharset"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+var detectData = []struct {
+	text, charset string
+}{
+	{"Hello, world! 你好，世界！", "UTF-8"},
+	{"花间一壶酒，独酌无相亲。举杯邀明月，对影成三人。", "GB18030"},
+	{"常用國字標準字體表，花間一壺酒，獨酌無相親。", "Big5"},
+	{"これは漢字です。ひらがなとカタカナもあります。", "EUC-JP"},
+	{"これは漢字です。ひらがなとカタカナもあります。", "Shift_JIS"},
+	{"한국어 텍스트입니다. 안녕하세요!", "EUC-KR"},
+	{"Hello, world! こんにちは", "UTF-16LE"},
+	{"Hello, world! こんにちは", "UTF-16BE"},
+}
+
+func Test_Detect(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		for _, item := range detectData {
+			content, err := gcharset.UTF8To(item.charset, item.text)
+			t.AssertNil(err)
+			t.Assert(gcharset.Detect([]byte(content)), item.charset)
+		}
+		t.Assert(gcharset.Detect([]byte("\xef\xbb\xbfabc")), "UTF-8")
+		t.Assert(gcharset.Detect([]byte("\xff\xfea\x00")), "UTF-16LE")
+		t.Assert(gcharset.Detect([]byte("\xfe\xff\x00a")), "UTF-16BE")
+		t.Assert(gcharset.Detect([]byte("\xff\xff\xff")), "")
+	})
+}
+
+func Test_NewReader(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			text       = strings.Repeat("花间一壶酒，独酌无相亲。", 1000)
+			content, _ = gcharset.UTF8To("GBK", text)
+		)
+		reader, err := gcharset.NewReader(strings.NewReader(content), "GBK")
+		t.AssertNil(err)
+		result, err := io.ReadAll(reader)
+		t.AssertNil(err)
+		t.Assert(string(result), text)
+
+		// Auto detection with content longer than the detection size.
+		reader, err = gcharset.NewReader(strings.NewReader(content), "")
+		t.AssertNil(err)
+		result, err = io.ReadAll(reader)
+		t.AssertNil(err)
+		t.Assert(string(result), text)
+
+		reader, err = gcharset.NewReader(strings.NewReader("\xef\xbb\xbf"+text), "")
+		t.AssertNil(err)
+		result, err = io.ReadAll(reader)
+		t.AssertNil(err)
+		t.Assert(string(result), text)
+
+		_, err = gcharset.NewReader(strings.NewReader(content), "unknown")
+		t.AssertNE(err, nil)
+		_, err = gcharset.NewReader(strings.NewReader("\xff\xff"), "")
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_NewWriter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			text    = strings.Repeat("常用國字標準字體表", 1000)
+			buffer  = bytes.NewBuffer(nil)
+			writer  io.WriteCloser
+			err     error
+			big5, _ = gcharset.UTF8To("Big5", text)
+		)
+		writer, err = gcharset.NewWriter(buffer, "Big5")
+		t.AssertNil(err)
+		for _, s := range strings.SplitAfter(text, "表") {
+			_, err = writer.Write([]byte(s))
+			t.AssertNil(err)
+		}
+		t.AssertNil(writer.Close())
+		t.Assert(buffer.String(), big5)
+		t.Assert(gcharset.Detect(buffer.Bytes()), "Big5")
+
+		buffer.Reset()
+		writer, err = gcharset.NewWriter(buffer, "UTF-8")
+		t.AssertNil(err)
+		_, err = writer.Write([]byte(text))
+		t.AssertNil(err)
+		t.AssertNil(writer.Close())
+		t.Assert(buffer.String(), text)
+
+		_, err = gcharset.NewWriter(buffer, "unknown")
+		t.AssertNE(err, nil)
+	})
+}
@@ -6,3 +6,14 @@
 
 // Package gcompress provides kinds of compression algorithms for binary/bytes data.
 package gcompress
+
+import (
+	"io"
+)
+
+// WriteFlushCloser is the streaming compressing writer, which supports flushing the buffered data,
+// so that the written data can be decompressed by the reader before the writer is closed.
+type WriteFlushCloser interface {
+	io.WriteCloser
+	Flush() error
+}
@@ -0,0 +1,265 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcompress
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+const (
+	BrotliQualityFastest = 1  // Fastest compression quality of brotli.
+	BrotliQualityDefault = 6  // Default compression quality of brotli, which is common for dynamic web content.
+	BrotliQualityBest    = 11 // Best compression quality of brotli, which is common for static web assets.
+
+	brotliWindowMin     = 10
+	brotliWindowMax     = 24
+	brotliWindowDefault = 22
+)
+
+// BrotliOption is the option for brotli compression.
+type BrotliOption struct {
+	// Quality specifies the compression quality from 1 to 11, which is BrotliQualityDefault if it is 0.
+	Quality int
+	// Window specifies the log of sliding window size from 10 to 24, which is 22 if it is 0.
+	// The decoder needs memory of the window size.
+	Window int
+}
+
+// BrotliAdapter is the interface of brotli implementation.
+// As brotli is not in the standard library, it is implemented by an adapter, eg:
+// github.com/gogf/gf/contrib/compress/brotli/v2, which registers itself using SetBrotliAdapter.
+type BrotliAdapter interface {
+	// NewWriter creates and returns a writer compressing data written to it into `writer`.
+	// The quality and window of `option` are already checked and defaulted.
+	NewWriter(writer io.Writer, option BrotliOption) (WriteFlushCloser, error)
+
+	// NewReader creates and returns a reader decompressing data read from `reader`.
+	NewReader(reader io.Reader) (io.ReadCloser, error)
+}
+
+// brotliAdapter is the registered brotli adapter.
+var brotliAdapter BrotliAdapter
+
+// SetBrotliAdapter sets the brotli adapter, which is commonly called by the adapter in its package initialization.
+func SetBrotliAdapter(adapter BrotliAdapter) {
+	brotliAdapter = adapter
+}
+
+// GetBrotliAdapter returns the registered brotli adapter, which is nil if no adapter is registered.
+func GetBrotliAdapter() BrotliAdapter {
+	return brotliAdapter
+}
+
+// Brotli compresses `data` using brotli algorithm.
+// The optional parameter `quality` specifies the compression quality from
+// 1 to 11 which means from the fastest to the best compression.
+//
+// Note that it returns error if given `quality` is invalid.
+func Brotli(data []byte, quality ...int) ([]byte, error) {
+	option := BrotliOption{}
+	if len(quality) > 0 {
+		option.Quality = quality[0]
+	}
+	return BrotliWithOption(data, option)
+}
+
+// BrotliWithOption compresses `data` using brotli algorithm with `option`.
+func BrotliWithOption(data []byte, option BrotliOption) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer, err := NewBrotliWriter(&buffer, option)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnBrotli decompresses `data` with brotli algorithm.
+func UnBrotli(data []byte) ([]byte, error) {
+	reader, err := NewBrotliReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var buffer bytes.Buffer
+	if _, err = io.Copy(&buffer, reader); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// BrotliFile compresses the file `src` to `dst` using brotli algorithm.
+func BrotliFile(srcFilePath, dstFilePath string, quality ...int) error {
+	srcFile, err := gfile.Open(srcFilePath)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+	dstFile, err := gfile.Create(dstFilePath)
+	if err != nil {
+		return err
+	}
+	defer dstFile.Close()
+
+	option := BrotliOption{}
+	if len(quality) > 0 {
+		option.Quality = quality[0]
+	}
+	writer, err := NewBrotliWriter(dstFile, option)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(writer, srcFile); err != nil {
+		return gerror.Wrap(err, `io.Copy failed`)
+	}
+	return writer.Close()
+}
+
+// UnBrotliFile decompresses srcFilePath `src` to `dst` using brotli algorithm.
+func UnBrotliFile(srcFilePath, dstFilePath string) error {
+	srcFile, err := gfile.Open(srcFilePath)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+	dstFile, err := gfile.Create(dstFilePath)
+	if err != nil {
+		return err
+	}
+	defer dstFile.Close()
+
+	reader, err := NewBrotliReader(srcFile)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if _, err = io.Copy(dstFile, reader); err != nil {
+		return gerror.Wrap(err, `io.Copy failed`)
+	}
+	return nil
+}
+
+// BrotliWriter is an io.WriteCloser that compresses data written to it as a brotli stream.
+// The stream is completed only when Close is called.
+type BrotliWriter struct {
+	writer WriteFlushCloser
+	closed bool
+}
+
+// NewBrotliWriter creates and returns a BrotliWriter writing compressed data to `writer`.
+func NewBrotliWriter(writer io.Writer, option ...BrotliOption) (*BrotliWriter, error) {
+	var opt BrotliOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Quality == 0 {
+		opt.Quality = BrotliQualityDefault
+	}
+	if opt.Quality < BrotliQualityFastest || opt.Quality > BrotliQualityBest {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`invalid brotli quality "%d", it should be from %d to %d`,
+			opt.Quality, BrotliQualityFastest, BrotliQualityBest,
+		)
+	}
+	if opt.Window == 0 {
+		opt.Window = brotliWindowDefault
+	}
+	if opt.Window < brotliWindowMin || opt.Window > brotliWindowMax {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`invalid brotli window "%d", it should be from %d to %d`,
+			opt.Window, brotliWindowMin, brotliWindowMax,
+		)
+	}
+	adapter, err := getBrotliAdapter()
+	if err != nil {
+		return nil, err
+	}
+	w, err := adapter.NewWriter(writer, opt)
+	if err != nil {
+		return nil, err
+	}
+	return &BrotliWriter{writer: w}, nil
+}
+
+// Write compresses `p` and writes the compressed meta-blocks to the underlying writer.
+// Note that it buffers the data until a full meta-block is available.
+func (w *BrotliWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, gerror.NewCode(gcode.CodeInvalidOperation, `brotli writer is closed`)
+	}
+	return w.writer.Write(p)
+}
+
+// Flush compresses all buffered data and writes it to the underlying writer,
+// so that the written data can be decompressed by the reader without closing the stream.
+func (w *BrotliWriter) Flush() error {
+	if w.closed {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `brotli writer is closed`)
+	}
+	return w.writer.Flush()
+}
+
+// Close compresses all buffered data and ends the stream.
+// It does not close the underlying writer.
+func (w *BrotliWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.writer.Close()
+}
+
+// BrotliReader is an io.ReadCloser that decompresses a brotli stream read from the underlying reader.
+type BrotliReader struct {
+	reader io.ReadCloser
+}
+
+// NewBrotliReader creates and returns a BrotliReader reading compressed data from `reader`.
+func NewBrotliReader(reader io.Reader) (*BrotliReader, error) {
+	adapter, err := getBrotliAdapter()
+	if err != nil {
+		return nil, err
+	}
+	r, err := adapter.NewReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	return &BrotliReader{reader: r}, nil
+}
+
+// Read reads decompressed data into `p`.
+func (r *BrotliReader) Read(p []byte) (int, error) {
+	return r.reader.Read(p)
+}
+
+// Close closes the reader, which does not close the underlying reader.
+func (r *BrotliReader) Close() error {
+	return r.reader.Close()
+}
+
+// getBrotliAdapter returns the registered brotli adapter, or an error if no adapter is registered.
+func getBrotliAdapter() (BrotliAdapter, error) {
+	if brotliAdapter == nil {
+		return nil, gerror.NewCode(
+			gcode.CodeNecessaryPackageNotImport,
+			`brotli adapter is not registered, import the adapter like: _ "github.com/gogf/gf/contrib/compress/brotli/v2"`,
+		)
+	}
+	return brotliAdapter, nil
+}
@@ -0,0 +1,615 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcompress
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/text/gregex"
+)
+
+// TarSymlinkPolicy specifies how symbolic links and hard links are handled in tar archives.
+type TarSymlinkPolicy int
+
+const (
+	// TarSymlinkSkip skips links when creating and extracting, which is the default policy.
+	TarSymlinkSkip TarSymlinkPolicy = iota
+	// TarSymlinkKeep archives and extracts links as links.
+	// The extracted links must point inside the destination folder.
+	TarSymlinkKeep
+	// TarSymlinkFollow archives the targets of symbolic links instead of the links,
+	// and it performs as TarSymlinkKeep when extracting.
+	TarSymlinkFollow
+	// TarSymlinkError returns error if any link is met.
+	TarSymlinkError
+)
+
+// TarOption is the option for creating and extracting tar archives.
+//
+// The glob patterns of Include and Exclude are matched against the slash-separated entry path
+// without Prefix, or against the base name of entry path if the pattern contains no '/'.
+// See gregex.Glob for the glob syntax.
+type TarOption struct {
+	// Prefix is the path prefix of entries when creating.
+	// When extracting, only the entries under Prefix are extracted, with Prefix removed.
+	Prefix string
+	// Gzip compresses the archive using gzip when creating, which makes a tar.gz archive.
+	// The gzip compressed archive is detected automatically when extracting.
+	Gzip bool
+	// Include specifies the glob patterns of files to archive or extract, which are all files if empty.
+	Include []string
+	// Exclude specifies the glob patterns of files and directories not to archive or extract,
+	// which takes precedence over Include. An excluded directory excludes all its contents.
+	Exclude []string
+	// Symlink specifies how links are handled.
+	Symlink TarSymlinkPolicy
+	// Progress is called after each entry is archived or extracted.
+	Progress func(progress TarProgress)
+}
+
+// TarProgress is the progress of creating or extracting a tar archive.
+type TarProgress struct {
+	Path  string // Path of current entry in archive.
+	Size  int64  // Content size of current entry.
+	Count int    // Count of processed entries, including current entry.
+	Bytes int64  // Total content size of processed entries.
+}
+
+// TarPath archives `fileOrFolderPaths` to tar file `dstFilePath`.
+//
+// The parameter `fileOrFolderPaths` can be either a directory or a file, which
+// supports multiple paths join with ','.
+func TarPath(fileOrFolderPaths, dstFilePath string, option ...TarOption) error {
+	writer, err := gfile.Create(dstFilePath)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+	return doTarPathWriter(fileOrFolderPaths, gfile.RealPath(dstFilePath), writer, getTarOption(option))
+}
+
+// TarPathWriter archives `fileOrFolderPaths` to `writer` in tar format.
+//
+// Note that the parameter `fileOrFolderPaths` can be either a directory or a file, which
+// supports multiple paths join with ','.
+func TarPathWriter(fileOrFolderPaths string, writer io.Writer, option ...TarOption) error {
+	return doTarPathWriter(fileOrFolderPaths, "", writer, getTarOption(option))
+}
+
+// UnTarFile extracts tar or tar.gz file `tarFilePath` to `dstFolderPath`.
+//
+// It returns error if any entry would be written outside `dstFolderPath`,
+// either by its path or through links.
+func UnTarFile(tarFilePath, dstFolderPath string, option ...TarOption) error {
+	file, err := os.Open(tarFilePath)
+	if err != nil {
+		err = gerror.Wrapf(err, `os.Open failed for name "%s"`, tarFilePath)
+		return err
+	}
+	defer file.Close()
+	return UnTarReader(file, dstFolderPath, option...)
+}
+
+// UnTarReader extracts tar or tar.gz stream from `reader` to `dstFolderPath`.
+//
+// It returns error if any entry would be written outside `dstFolderPath`,
+// either by its path or through links.
+func UnTarReader(reader io.Reader, dstFolderPath string, option ...TarOption) error {
+	opt := getTarOption(option)
+	filter, err := newTarFilter(opt)
+	if err != nil {
+		return err
+	}
+	bufferedReader := bufio.NewReader(reader)
+	if magic, _ := bufferedReader.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gzipReader, err := gzip.NewReader(bufferedReader)
+		if err != nil {
+			err = gerror.Wrap(err, `gzip.NewReader failed`)
+			return err
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	} else {
+		reader = bufferedReader
+	}
+	if err = os.MkdirAll(dstFolderPath, 0755); err != nil {
+		err = gerror.Wrapf(err, `os.MkdirAll failed for path "%s"`, dstFolderPath)
+		return err
+	}
+	extractor := &tarExtractor{
+		reader: tar.NewReader(reader),
+		root:   dstFolderPath,
+		option: opt,
+		filter: filter,
+	}
+	return extractor.extract()
+}
+
+func getTarOption(option []TarOption) TarOption {
+	if len(option) > 0 {
+		return option[0]
+	}
+	return TarOption{}
+}
+
+// tarFilter filters entries with the Include and Exclude patterns.
+type tarFilter struct {
+	include []*gregex.Glob
+	exclude []*gregex.Glob
+}
+
+func newTarFilter(option TarOption) (*tarFilter, error) {
+	var (
+		filter = &tarFilter{}
+		err    error
+	)
+	if filter.include, err = compileTarGlobs(option.Include); err != nil {
+		return nil, err
+	}
+	if filter.exclude, err = compileTarGlobs(option.Exclude); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+func compileTarGlobs(patterns []string) ([]*gregex.Glob, error) {
+	globs := make([]*gregex.Glob, 0, len(patterns))
+	for _, pattern := range patterns {
+		glob, err := gregex.CompileGlob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		globs = append(globs, glob)
+	}
+	return globs, nil
+}
+
+// includeAll checks whether all files are included, in which case all directories are kept.
+func (f *tarFilter) includeAll() bool {
+	return len(f.include) == 0
+}
+
+// match checks whether the entry of `name` is archived or extracted.
+// The Include patterns apply only to files.
+func (f *tarFilter) match(name string, isDir bool) bool {
+	for parent := name; parent != "." && parent != "/"; parent = path.Dir(parent) {
+		if matchTarGlobs(f.exclude, parent) {
+			return false
+		}
+	}
+	return isDir || f.includeAll() || matchTarGlobs(f.include, name)
+}
+
+func matchTarGlobs(globs []*gregex.Glob, name string) bool {
+	for _, glob := range globs {
+		target := name
+		if !strings.Contains(glob.String(), "/") {
+			target = path.Base(name)
+		}
+		if glob.Match(target) {
+			return true
+		}
+	}
+	return false
+}
+
+// tarArchiver writes files and directories to a tar archive.
+type tarArchiver struct {
+	writer   *tar.Writer
+	option   TarOption
+	filter   *tarFilter
+	prefix   string          // Path prefix of entries, which ends with '/' if not empty.
+	exclude  string          // File path not to archive, commonly the archive file itself.
+	written  map[string]bool // Written directory entries.
+	visited  map[string]bool // Visited directories through symbolic links, which avoids loops.
+	progress TarProgress
+}
+
+func doTarPathWriter(fileOrFolderPaths, exclude string, writer io.Writer, option TarOption) (err error) {
+	filter, err := newTarFilter(option)
+	if err != nil {
+		return err
+	}
+	if option.Gzip {
+		gzipWriter := gzip.NewWriter(writer)
+		defer func() {
+			if closeErr := gzipWriter.Close(); closeErr != nil && err == nil {
+				err = gerror.Wrap(closeErr, `gzip.Writer.Close failed`)
+			}
+		}()
+		writer = gzipWriter
+	}
+	archiver := &tarArchiver{
+		writer:  tar.NewWriter(writer),
+		option:  option,
+		filter:  filter,
+		exclude: exclude,
+		written: make(map[string]bool),
+		visited: make(map[string]bool),
+	}
+	if prefix := strings.Trim(strings.ReplaceAll(option.Prefix, `\`, `/`), "/"); prefix != "" {
+		archiver.prefix = prefix + "/"
+	}
+	for _, fileOrFolderPath := range strings.Split(fileOrFolderPaths, ",") {
+		fileOrFolderPath, err = gfile.Search(strings.TrimSpace(fileOrFolderPath))
+		if err != nil {
+			return err
+		}
+		info, err := os.Lstat(fileOrFolderPath)
+		if err != nil {
+			return gerror.Wrapf(err, `os.Lstat failed for name "%s"`, fileOrFolderPath)
+		}
+		if err = archiver.add(fileOrFolderPath, filepath.Base(fileOrFolderPath), info); err != nil {
+			return err
+		}
+	}
+	if err = archiver.writer.Close(); err != nil {
+		err = gerror.Wrap(err, `tar.Writer.Close failed`)
+	}
+	return err
+}
+
+// add archives the file or directory of `filePath` as entry `name` recursively.
+func (a *tarArchiver) add(filePath, name string, info os.FileInfo) error {
+	if filePath == a.exclude {
+		return nil
+	}
+	var (
+		link string
+		err  error
+	)
+	if info.Mode()&os.ModeSymlink != 0 {
+		switch a.option.Symlink {
+		case TarSymlinkKeep:
+			if link, err = os.Readlink(filePath); err != nil {
+				return gerror.Wrapf(err, `os.Readlink failed for name "%s"`, filePath)
+			}
+		case TarSymlinkFollow:
+			if info, err = os.Stat(filePath); err != nil {
+				return gerror.Wrapf(err, `os.Stat failed for name "%s"`, filePath)
+			}
+			if info.IsDir() {
+				realPath, err := filepath.EvalSymlinks(filePath)
+				if err != nil {
+					return gerror.Wrapf(err, `filepath.EvalSymlinks failed for name "%s"`, filePath)
+				}
+				if a.visited[realPath] {
+					return nil
+				}
+				a.visited[realPath] = true
+			}
+		case TarSymlinkError:
+			return gerror.NewCodef(gcode.CodeSecurityReason, `symbolic link "%s" is not allowed`, filePath)
+		default:
+			return nil
+		}
+	}
+	if !a.filter.match(name, info.IsDir()) {
+		return nil
+	}
+	if !info.IsDir() || link != "" {
+		if err = a.writeParents(filePath, name); err != nil {
+			return err
+		}
+		return a.writeEntry(filePath, name, info, link)
+	}
+	if a.filter.includeAll() {
+		if err = a.writeParents(filePath, name); err != nil {
+			return err
+		}
+		if err = a.writeEntry(filePath, name, info, ""); err != nil {
+			return err
+		}
+	}
+	entries, err := os.ReadDir(filePath)
+	if err != nil {
+		return gerror.Wrapf(err, `os.ReadDir failed for name "%s"`, filePath)
+	}
+	for _, entry := range entries {
+		childInfo, err := entry.Info()
+		if err != nil {
+			return gerror.Wrapf(err, `get file info failed for name "%s"`, entry.Name())
+		}
+		if err = a.add(filepath.Join(filePath, entry.Name()), name+"/"+entry.Name(), childInfo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeParents writes the parent directory entries of `name` that are not written yet,
+// so that the directories keep their permissions even if they are filtered lazily.
+func (a *tarArchiver) writeParents(filePath, name string) error {
+	var parents []string
+	for parent := path.Dir(name); parent != "." && !a.written[parent]; parent = path.Dir(parent) {
+		parents = append(parents, parent)
+	}
+	for i := len(parents) - 1; i >= 0; i-- {
+		parentPath := filePath
+		for j := 0; j <= i; j++ {
+			parentPath = filepath.Dir(parentPath)
+		}
+		info, err := os.Stat(parentPath)
+		if err != nil {
+			return gerror.Wrapf(err, `os.Stat failed for name "%s"`, parentPath)
+		}
+		if err = a.writeEntry(parentPath, parents[i], info, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeEntry writes the header and content of a single entry.
+func (a *tarArchiver) writeEntry(filePath, name string, info os.FileInfo, link string) error {
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		err = gerror.Wrapf(err, `tar.FileInfoHeader failed for name "%s"`, filePath)
+		return err
+	}
+	header.Name = a.prefix + name
+	if info.IsDir() && link == "" {
+		header.Name += "/"
+		a.written[name] = true
+	}
+	if err = a.writer.WriteHeader(header); err != nil {
+		err = gerror.Wrapf(err, `tar.Writer.WriteHeader failed for name "%s"`, header.Name)
+		return err
+	}
+	if header.Typeflag == tar.TypeReg {
+		file, err := os.Open(filePath)
+		if err != nil {
+			err = gerror.Wrapf(err, `os.Open failed for name "%s"`, filePath)
+			return err
+		}
+		defer file.Close()
+		if _, err = io.Copy(a.writer, file); err != nil {
+			err = gerror.Wrapf(err, `io.Copy failed from "%s" to "%s"`, filePath, header.Name)
+			return err
+		}
+	}
+	a.progress.Path = header.Name
+	a.progress.Size = header.Size
+	a.progress.Count++
+	a.progress.Bytes += header.Size
+	if a.option.Progress != nil {
+		a.option.Progress(a.progress)
+	}
+	return nil
+}
+
+// tarExtractor extracts entries of a tar archive to the root directory.
+type tarExtractor struct {
+	reader   *tar.Reader
+	root     string
+	option   TarOption
+	filter   *tarFilter
+	dirs     []tarDir // Directory entries, whose permissions are applied after all entries are extracted.
+	progress TarProgress
+}
+
+// tarDir is an extracted directory entry.
+type tarDir struct {
+	target string
+	header *tar.Header
+}
+
+func (e *tarExtractor) extract() error {
+	for {
+		header, err := e.reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return gerror.Wrap(err, `tar.Reader.Next failed`)
+		}
+		name, err := e.entryName(header.Name)
+		if err != nil {
+			return err
+		}
+		if name == "" || !e.filter.match(name, header.Typeflag == tar.TypeDir) {
+			continue
+		}
+		if err = e.checkParents(name); err != nil {
+			return err
+		}
+		target := filepath.Join(e.root, filepath.FromSlash(name))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = e.extractDir(header, target)
+		case tar.TypeReg, tar.TypeRegA:
+			err = e.extractFile(header, target)
+		case tar.TypeSymlink, tar.TypeLink:
+			err = e.extractLink(header, name, target)
+		default:
+			// Devices and FIFOs are not extracted.
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		e.progress.Path = header.Name
+		e.progress.Size = header.Size
+		e.progress.Count++
+		e.progress.Bytes += header.Size
+		if e.option.Progress != nil {
+			e.option.Progress(e.progress)
+		}
+	}
+	// The deepest directories are handled first, as the permissions might forbid changes of children.
+	sort.SliceStable(e.dirs, func(i, j int) bool {
+		return len(e.dirs[i].target) > len(e.dirs[j].target)
+	})
+	for _, dir := range e.dirs {
+		if info, err := os.Lstat(dir.target); err != nil || !info.IsDir() {
+			continue
+		}
+		if err := os.Chmod(dir.target, dir.header.FileInfo().Mode().Perm()); err != nil {
+			return gerror.Wrapf(err, `os.Chmod failed for name "%s"`, dir.target)
+		}
+		_ = os.Chtimes(dir.target, dir.header.ModTime, dir.header.ModTime)
+	}
+	return nil
+}
+
+// entryName checks and returns the cleaned entry path with Prefix removed.
+// It returns empty string if the entry is not under Prefix or is the root.
+func (e *tarExtractor) entryName(name string) (string, error) {
+	name, err := cleanTarPath(name)
+	if err != nil || name == "" {
+		return "", err
+	}
+	if prefix := strings.Trim(strings.ReplaceAll(e.option.Prefix, `\`, `/`), "/"); prefix != "" {
+		if name == prefix || !strings.HasPrefix(name, prefix+"/") {
+			return "", nil
+		}
+		name = name[len(prefix)+1:]
+	}
+	return name, nil
+}
+
+// cleanTarPath cleans the slash-separated `name` and checks that it stays inside the root,
+// which protects from path traversal. It returns empty string for the root itself.
+func cleanTarPath(name string) (string, error) {
+	name = strings.ReplaceAll(name, `\`, `/`)
+	cleaned := path.Clean(name)
+	if path.IsAbs(name) || filepath.VolumeName(name) != "" ||
+		cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", gerror.NewCodef(gcode.CodeSecurityReason, `illegal path "%s" in tar archive`, name)
+	}
+	if cleaned == "." {
+		return "", nil
+	}
+	return cleaned, nil
+}
+
+// checkParents checks that no parent of entry `name` is a symbolic link,
+// so that entries cannot be written outside the root through extracted links.
+func (e *tarExtractor) checkParents(name string) error {
+	current := e.root
+	parts := strings.Split(name, "/")
+	for _, part := range parts[:len(parts)-1] {
+		current = filepath.Join(current, part)
+		info, err := os.Lstat(current)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return gerror.Wrapf(err, `os.Lstat failed for name "%s"`, current)
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return gerror.NewCodef(gcode.CodeSecurityReason, `path "%s" in tar archive is through symbolic link`, name)
+		}
+	}
+	return nil
+}
+
+// removeLink removes `target` if it is a link, so that the link target is not overwritten.
+func removeLink(target string) error {
+	if info, err := os.Lstat(target); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if err = os.Remove(target); err != nil {
+			return gerror.Wrapf(err, `os.Remove failed for name "%s"`, target)
+		}
+	}
+	return nil
+}
+
+func (e *tarExtractor) extractDir(header *tar.Header, target string) error {
+	e.dirs = append(e.dirs, tarDir{target: target, header: header})
+	if !e.filter.includeAll() {
+		return nil
+	}
+	if err := removeLink(target); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(target, 0755); err != nil {
+		return gerror.Wrapf(err, `os.MkdirAll failed for path "%s"`, target)
+	}
+	return nil
+}
+
+func (e *tarExtractor) extractFile(header *tar.Header, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return gerror.Wrapf(err, `os.MkdirAll failed for path "%s"`, filepath.Dir(target))
+	}
+	if err := removeLink(target); err != nil {
+		return err
+	}
+	perm := header.FileInfo().Mode().Perm()
+	file, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return gerror.Wrapf(err, `os.OpenFile failed for name "%s"`, target)
+	}
+	defer file.Close()
+	if _, err = io.Copy(file, e.reader); err != nil {
+		return gerror.Wrapf(err, `io.Copy failed from "%s" to "%s"`, header.Name, target)
+	}
+	// The permission of created file is masked by umask, so it is set explicitly.
+	if err = file.Chmod(perm); err != nil {
+		return gerror.Wrapf(err, `chmod failed for name "%s"`, target)
+	}
+	_ = os.Chtimes(target, header.ModTime, header.ModTime)
+	return nil
+}
+
+func (e *tarExtractor) extractLink(header *tar.Header, name, target string) error {
+	switch e.option.Symlink {
+	case TarSymlinkKeep, TarSymlinkFollow:
+	case TarSymlinkError:
+		return gerror.NewCodef(gcode.CodeSecurityReason, `link "%s" in tar archive is not allowed`, header.Name)
+	default:
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return gerror.Wrapf(err, `os.MkdirAll failed for path "%s"`, filepath.Dir(target))
+	}
+	if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+		return gerror.Wrapf(err, `os.Remove failed for name "%s"`, target)
+	}
+	if header.Typeflag == tar.TypeSymlink {
+		// The link target is relative to the directory of link, which must stay inside the root.
+		linkName := strings.ReplaceAll(header.Linkname, `\`, `/`)
+		if path.IsAbs(linkName) {
+			return gerror.NewCodef(gcode.CodeSecurityReason, `illegal link "%s" to "%s" in tar archive`, header.Name, header.Linkname)
+		}
+		if _, err := cleanTarPath(path.Join(path.Dir(name), linkName)); err != nil {
+			return gerror.NewCodef(gcode.CodeSecurityReason, `illegal link "%s" to "%s" in tar archive`, header.Name, header.Linkname)
+		}
+		if err := os.Symlink(filepath.FromSlash(linkName), target); err != nil {
+			return gerror.Wrapf(err, `os.Symlink failed for name "%s"`, target)
+		}
+		return nil
+	}
+	// The hard link target is an entry path in the archive.
+	linkName, err := e.entryName(header.Linkname)
+	if err != nil || linkName == "" {
+		return gerror.NewCodef(gcode.CodeSecurityReason, `illegal link "%s" to "%s" in tar archive`, header.Name, header.Linkname)
+	}
+	if err = e.checkParents(linkName); err != nil {
+		return err
+	}
+	linkTarget := filepath.Join(e.root, filepath.FromSlash(linkName))
+	if info, err := os.Lstat(linkTarget); err != nil || !info.Mode().IsRegular() {
+		return gerror.NewCodef(gcode.CodeSecurityReason, `illegal link "%s" to "%s" in tar archive`, header.Name, header.Linkname)
+	}
+	if err = os.Link(linkTarget, target); err != nil {
+		return gerror.Wrapf(err, `os.Link failed for name "%s"`, target)
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcompress_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gcompress"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// createTarSource creates a directory for archiving:
+//
+//	src/a.txt
+//	src/b.log
+//	src/sub/c.txt (0600)
+//	src/sub/empty/
+//	src/link.txt -> a.txt
+func createTarSource(t *gtest.T) string {
+	srcPath := gfile.Temp(gtime.TimestampNanoStr(), "src")
+	t.AssertNil(gfile.PutContents(gfile.Join(srcPath, "a.txt"), "a"))
+	t.AssertNil(gfile.PutContents(gfile.Join(srcPath, "b.log"), "b"))
+	t.AssertNil(gfile.PutContents(gfile.Join(srcPath, "sub", "c.txt"), "c"))
+	t.AssertNil(os.Chmod(gfile.Join(srcPath, "sub", "c.txt"), 0600))
+	t.AssertNil(gfile.Mkdir(gfile.Join(srcPath, "sub", "empty")))
+	t.AssertNil(os.Symlink("a.txt", gfile.Join(srcPath, "link.txt")))
+	return srcPath
+}
+
+func Test_Tar_UnTar(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			srcPath  = createTarSource(t)
+			dstPath  = gfile.Temp(gtime.TimestampNanoStr(), "src.tar.gz")
+			untarDir = gfile.Temp(gtime.TimestampNanoStr())
+			paths    []string
+			bytes    int64
+		)
+		defer gfile.Remove(gfile.Dir(srcPath))
+		defer gfile.Remove(gfile.Dir(dstPath))
+		defer gfile.Remove(untarDir)
+
+		err := gcompress.TarPath(srcPath, dstPath, gcompress.TarOption{
+			Gzip: true,
+			Progress: func(progress gcompress.TarProgress) {
+				paths = append(paths, progress.Path)
+				bytes = progress.Bytes
+			},
+		})
+		t.AssertNil(err)
+		t.Assert(paths, []string{"src/", "src/a.txt", "src/b.log", "src/sub/", "src/sub/c.txt", "src/sub/empty/"})
+		t.Assert(bytes, 3)
+
+		t.AssertNil(gcompress.UnTarFile(dstPath, untarDir))
+		t.Assert(gfile.GetContents(gfile.Join(untarDir, "src", "a.txt")), "a")
+		t.Assert(gfile.GetContents(gfile.Join(untarDir, "src", "sub", "c.txt")), "c")
+		t.Assert(gfile.IsDir(gfile.Join(untarDir, "src", "sub", "empty")), true)
+		t.Assert(gfile.Exists(gfile.Join(untarDir, "src", "link.txt")), false)
+		info, err := os.Stat(gfile.Join(untarDir, "src", "sub", "c.txt"))
+		t.AssertNil(err)
+		t.Assert(info.Mode().Perm(), os.FileMode(0600))
+	})
+	// Include, exclude and prefix.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			srcPath  = createTarSource(t)
+			buffer   = bytes.NewBuffer(nil)
+			untarDir = gfile.Temp(gtime.TimestampNanoStr())
+		)
+		defer gfile.Remove(gfile.Dir(srcPath))
+		defer gfile.Remove(untarDir)
+
+		err := gcompress.TarPathWriter(srcPath, buffer, gcompress.TarOption{
+			Prefix:  "backup",
+			Include: []string{"*.txt", "*.log"},
+			Exclude: []string{"*.log"},
+		})
+		t.AssertNil(err)
+		t.AssertNil(gcompress.UnTarReader(bytes.NewReader(buffer.Bytes()), untarDir, gcompress.TarOption{
+			Prefix: "backup/src",
+		}))
+		t.Assert(gfile.GetContents(gfile.Join(untarDir, "a.txt")), "a")
+		t.Assert(gfile.GetContents(gfile.Join(untarDir, "sub", "c.txt")), "c")
+		t.Assert(gfile.Exists(gfile.Join(untarDir, "b.log")), false)
+		t.Assert(gfile.Exists(gfile.Join(untarDir, "sub", "empty")), false)
+
+		untarDir2 := gfile.Temp(gtime.TimestampNanoStr())
+		defer gfile.Remove(untarDir2)
+		t.AssertNil(gcompress.UnTarReader(bytes.NewReader(buffer.Bytes()), untarDir2, gcompress.TarOption{
+			Exclude: []string{"sub"},
+		}))
+		t.Assert(gfile.Exists(gfile.Join(untarDir2, "backup", "src", "a.txt")), true)
+		t.Assert(gfile.Exists(gfile.Join(untarDir2, "backup", "src", "sub")), false)
+	})
+}
+
+func Test_Tar_Symlink(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		srcPath := createTarSource(t)
+		defer gfile.Remove(gfile.Dir(srcPath))
+
+		// Keep.
+		buffer := bytes.NewBuffer(nil)
+		option := gcompress.TarOption{Symlink: gcompress.TarSymlinkKeep}
+		t.AssertNil(gcompress.TarPathWriter(srcPath, buffer, option))
+		untarDir := gfile.Temp(gtime.TimestampNanoStr())
+		defer gfile.Remove(untarDir)
+		t.AssertNil(gcompress.UnTarReader(buffer, untarDir, option))
+		link, err := os.Readlink(gfile.Join(untarDir, "src", "link.txt"))
+		t.AssertNil(err)
+		t.Assert(link, "a.txt")
+
+		// Follow.
+		buffer.Reset()
+		option = gcompress.TarOption{Symlink: gcompress.TarSymlinkFollow}
+		t.AssertNil(gcompress.TarPathWriter(srcPath, buffer, option))
+		untarDir2 := gfile.Temp(gtime.TimestampNanoStr())
+		defer gfile.Remove(untarDir2)
+		t.AssertNil(gcompress.UnTarReader(buffer, untarDir2, option))
+		info, err := os.Lstat(gfile.Join(untarDir2, "src", "link.txt"))
+		t.AssertNil(err)
+		t.Assert(info.Mode().IsRegular(), true)
+		t.Assert(gfile.GetContents(gfile.Join(untarDir2, "src", "link.txt")), "a")
+
+		// Error.
+		buffer.Reset()
+		err = gcompress.TarPathWriter(srcPath, buffer, gcompress.TarOption{Symlink: gcompress.TarSymlinkError})
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_UnTar_Unsafe(t *testing.T) {
+	createTar := func(headers ...*tar.Header) []byte {
+		buffer := bytes.NewBuffer(nil)
+		writer := tar.NewWriter(buffer)
+		for _, header := range headers {
+			if header.Typeflag == tar.TypeReg {
+				header.Size = 1
+			}
+			header.Mode = 0644
+			_ = writer.WriteHeader(header)
+			if header.Typeflag == tar.TypeReg {
+				_, _ = writer.Write([]byte("x"))
+			}
+		}
+		_ = writer.Close()
+		return buffer.Bytes()
+	}
+	keep := gcompress.TarOption{Symlink: gcompress.TarSymlinkKeep}
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			parentDir = gfile.Temp(gtime.TimestampNanoStr())
+			untarDir  = gfile.Join(parentDir, "dst")
+		)
+		defer gfile.Remove(parentDir)
+
+		cases := []struct {
+			data   []byte
+			option gcompress.TarOption
+		}{
+			// Path traversal.
+			{createTar(&tar.Header{Name: "../evil.txt", Typeflag: tar.TypeReg}), keep},
+			{createTar(&tar.Header{Name: "a/../../evil.txt", Typeflag: tar.TypeReg}), keep},
+			{createTar(&tar.Header{Name: "/evil.txt", Typeflag: tar.TypeReg}), keep},
+			// Symbolic link pointing outside.
+			{createTar(&tar.Header{Name: "link", Linkname: "..", Typeflag: tar.TypeSymlink}), keep},
+			{createTar(&tar.Header{Name: "link", Linkname: parentDir, Typeflag: tar.TypeSymlink}), keep},
+			// Hard link pointing outside.
+			{createTar(&tar.Header{Name: "link", Linkname: "../evil.txt", Typeflag: tar.TypeLink}), keep},
+			// Writing through symbolic link.
+			{createTar(
+				&tar.Header{Name: "dir", Linkname: ".", Typeflag: tar.TypeSymlink},
+				&tar.Header{Name: "dir/evil.txt", Typeflag: tar.TypeReg},
+			), keep},
+			// Links are not allowed.
+			{createTar(&tar.Header{Name: "link", Linkname: "a", Typeflag: tar.TypeSymlink}), gcompress.TarOption{
+				Symlink: gcompress.TarSymlinkError,
+			}},
+		}
+		for _, c := range cases {
+			t.AssertNE(gcompress.UnTarReader(bytes.NewReader(c.data), untarDir, c.option), nil)
+			t.Assert(gfile.Exists(gfile.Join(parentDir, "evil.txt")), false)
+			t.AssertNil(gfile.Remove(untarDir))
+		}
+
+		// Links are skipped in default.
+		data := createTar(
+			&tar.Header{Name: "link", Linkname: "..", Typeflag: tar.TypeSymlink},
+			&tar.Header{Name: "./a.txt", Typeflag: tar.TypeReg},
+		)
+		t.AssertNil(gcompress.UnTarReader(bytes.NewReader(data), untarDir))
+		t.Assert(gfile.Exists(gfile.Join(untarDir, "link")), false)
+		t.Assert(gfile.GetContents(gfile.Join(untarDir, "a.txt")), "x")
+	})
+}
@@ -0,0 +1,36 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcompress_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gcompress"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Zstd_WithoutAdapter(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gcompress.GetZstdAdapter(), nil)
+
+		_, err := gcompress.Zstd([]byte("Hello World!!"))
+		t.Assert(gerror.Code(err), gcode.CodeNecessaryPackageNotImport)
+		_, err = gcompress.UnZstd([]byte("Hello World!!"))
+		t.Assert(gerror.Code(err), gcode.CodeNecessaryPackageNotImport)
+		_, err = gcompress.NewZstdReader(bytes.NewReader(nil))
+		t.Assert(gerror.Code(err), gcode.CodeNecessaryPackageNotImport)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gcompress.Zstd([]byte("Hello World!!"), 23)
+		t.Assert(gerror.Code(err), gcode.CodeInvalidParameter)
+		_, err = gcompress.Zstd([]byte("Hello World!!"), -1)
+		t.Assert(gerror.Code(err), gcode.CodeInvalidParameter)
+	})
+}
@@ -0,0 +1,262 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcompress
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+const (
+	ZstdLevelFastest = 1  // Fastest compression level of zstd.
+	ZstdLevelDefault = 3  // Default compression level of zstd.
+	ZstdLevelBest    = 19 // Best compression level of zstd in common use, levels up to 22 are also supported.
+	zstdLevelMax     = 22
+)
+
+// ZstdOption is the option for zstd compression and decompression.
+type ZstdOption struct {
+	// Level specifies the compression level from 1 to 22, which is ZstdLevelDefault if it is 0.
+	Level int
+	// Dict is the dictionary shared by compression and decompression,
+	// which is either in zstd dictionary format or raw content.
+	Dict []byte
+}
+
+// ZstdAdapter is the interface of zstd implementation.
+// As zstd is not in the standard library, it is implemented by an adapter, eg:
+// github.com/gogf/gf/contrib/compress/zstd/v2, which registers itself using SetZstdAdapter.
+type ZstdAdapter interface {
+	// NewWriter creates and returns a writer compressing data written to it into `writer`.
+	// The level of `option` is already checked and defaulted.
+	NewWriter(writer io.Writer, option ZstdOption) (WriteFlushCloser, error)
+
+	// NewReader creates and returns a reader decompressing data read from `reader`,
+	// which decompresses the concatenated frames as a whole.
+	NewReader(reader io.Reader, option ZstdOption) (io.ReadCloser, error)
+}
+
+// zstdAdapter is the registered zstd adapter.
+var zstdAdapter ZstdAdapter
+
+// SetZstdAdapter sets the zstd adapter, which is commonly called by the adapter in its package initialization.
+func SetZstdAdapter(adapter ZstdAdapter) {
+	zstdAdapter = adapter
+}
+
+// GetZstdAdapter returns the registered zstd adapter, which is nil if no adapter is registered.
+func GetZstdAdapter() ZstdAdapter {
+	return zstdAdapter
+}
+
+// Zstd compresses `data` using zstd algorithm.
+// The optional parameter `level` specifies the compression level from
+// 1 to 22 which means from the fastest to the best compression.
+//
+// Note that it returns error if given `level` is invalid.
+func Zstd(data []byte, level ...int) ([]byte, error) {
+	option := ZstdOption{}
+	if len(level) > 0 {
+		option.Level = level[0]
+	}
+	return ZstdWithOption(data, option)
+}
+
+// ZstdWithOption compresses `data` using zstd algorithm with `option`.
+func ZstdWithOption(data []byte, option ZstdOption) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer, err := NewZstdWriter(&buffer, option)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnZstd decompresses `data` with zstd algorithm.
+func UnZstd(data []byte) ([]byte, error) {
+	return UnZstdWithOption(data, ZstdOption{})
+}
+
+// UnZstdWithOption decompresses `data` with zstd algorithm using the dictionary of `option`.
+func UnZstdWithOption(data []byte, option ZstdOption) ([]byte, error) {
+	reader, err := NewZstdReader(bytes.NewReader(data), option)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var buffer bytes.Buffer
+	if _, err = io.Copy(&buffer, reader); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// ZstdFile compresses the file `src` to `dst` using zstd algorithm.
+func ZstdFile(srcFilePath, dstFilePath string, level ...int) error {
+	srcFile, err := gfile.Open(srcFilePath)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+	dstFile, err := gfile.Create(dstFilePath)
+	if err != nil {
+		return err
+	}
+	defer dstFile.Close()
+
+	option := ZstdOption{}
+	if len(level) > 0 {
+		option.Level = level[0]
+	}
+	writer, err := NewZstdWriter(dstFile, option)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(writer, srcFile); err != nil {
+		return gerror.Wrap(err, `io.Copy failed`)
+	}
+	return writer.Close()
+}
+
+// UnZstdFile decompresses srcFilePath `src` to `dst` using zstd algorithm.
+func UnZstdFile(srcFilePath, dstFilePath string) error {
+	srcFile, err := gfile.Open(srcFilePath)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+	dstFile, err := gfile.Create(dstFilePath)
+	if err != nil {
+		return err
+	}
+	defer dstFile.Close()
+
+	reader, err := NewZstdReader(srcFile)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	if _, err = io.Copy(dstFile, reader); err != nil {
+		return gerror.Wrap(err, `io.Copy failed`)
+	}
+	return nil
+}
+
+// ZstdWriter is an io.WriteCloser that compresses data written to it as a zstd frame.
+// The frame is completed only when Close is called.
+type ZstdWriter struct {
+	writer WriteFlushCloser
+	closed bool
+}
+
+// NewZstdWriter creates and returns a ZstdWriter writing compressed data to `writer`.
+func NewZstdWriter(writer io.Writer, option ...ZstdOption) (*ZstdWriter, error) {
+	var opt ZstdOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Level == 0 {
+		opt.Level = ZstdLevelDefault
+	}
+	if opt.Level < 1 || opt.Level > zstdLevelMax {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter, `invalid zstd level "%d", it should be from 1 to %d`, opt.Level, zstdLevelMax,
+		)
+	}
+	adapter, err := getZstdAdapter()
+	if err != nil {
+		return nil, err
+	}
+	w, err := adapter.NewWriter(writer, opt)
+	if err != nil {
+		return nil, err
+	}
+	return &ZstdWriter{writer: w}, nil
+}
+
+// Write compresses `p` and writes the compressed blocks to the underlying writer.
+// Note that it buffers the data until a full block is available.
+func (w *ZstdWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, gerror.NewCode(gcode.CodeInvalidOperation, `zstd writer is closed`)
+	}
+	return w.writer.Write(p)
+}
+
+// Flush compresses all buffered data and writes it to the underlying writer,
+// so that the written data can be decompressed by the reader without closing the frame.
+func (w *ZstdWriter) Flush() error {
+	if w.closed {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `zstd writer is closed`)
+	}
+	return w.writer.Flush()
+}
+
+// Close compresses all buffered data and ends the frame.
+// It does not close the underlying writer.
+func (w *ZstdWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.writer.Close()
+}
+
+// ZstdReader is an io.ReadCloser that decompresses zstd frames read from the underlying reader.
+// The concatenated frames are decompressed as a whole.
+type ZstdReader struct {
+	reader io.ReadCloser
+}
+
+// NewZstdReader creates and returns a ZstdReader reading compressed data from `reader`.
+// It should be closed after use, which releases the resources of decompression.
+func NewZstdReader(reader io.Reader, option ...ZstdOption) (*ZstdReader, error) {
+	var opt ZstdOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	adapter, err := getZstdAdapter()
+	if err != nil {
+		return nil, err
+	}
+	r, err := adapter.NewReader(reader, opt)
+	if err != nil {
+		return nil, err
+	}
+	return &ZstdReader{reader: r}, nil
+}
+
+// Read reads decompressed data into `p`.
+func (r *ZstdReader) Read(p []byte) (int, error) {
+	return r.reader.Read(p)
+}
+
+// Close closes the reader, which does not close the underlying reader.
+func (r *ZstdReader) Close() error {
+	return r.reader.Close()
+}
+
+// getZstdAdapter returns the registered zstd adapter, or an error if no adapter is registered.
+func getZstdAdapter() (ZstdAdapter, error) {
+	if zstdAdapter == nil {
+		return nil, gerror.NewCode(
+			gcode.CodeNecessaryPackageNotImport,
+			`zstd adapter is not registered, import the adapter like: _ "github.com/gogf/gf/contrib/compress/zstd/v2"`,
+		)
+	}
+	return zstdAdapter, nil
+}
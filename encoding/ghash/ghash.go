@@ -5,4 +5,8 @@
 // You can obtain one at https://github.com/gogf/gf.
 
 // Package ghash provides some classic hash functions(uint32/uint64) in go.
+//
+// All the hash algorithms also support streaming by the New* functions, which return
+// hash.Hash32/hash.Hash64/Hash128 implementing io.Writer, so that large input like files
+// can be hashed with io.Copy without loading them into memory.
 package ghash
@@ -6,11 +6,32 @@
 
 package ghash
 
+import "hash"
+
 // AP implements the classic AP hash algorithm for 32 bits.
 func AP(str []byte) uint32 {
-	var hash uint32
+	return ap(0, 0, str)
+}
+
+// AP64 implements the classic AP hash algorithm for 64 bits.
+func AP64(str []byte) uint64 {
+	return ap64(0, 0, str)
+}
+
+// NewAP returns a new hash.Hash32 computing the AP hash in streaming.
+func NewAP() hash.Hash32 {
+	return newDigest32(0, ap)
+}
+
+// NewAP64 returns a new hash.Hash64 computing the AP64 hash in streaming.
+func NewAP64() hash.Hash64 {
+	return newDigest64(0, ap64)
+}
+
+// ap updates `hash` with `str` for AP algorithm, in which `offset` is the count of bytes hashed before.
+func ap(hash uint32, offset int, str []byte) uint32 {
 	for i := 0; i < len(str); i++ {
-		if (i & 1) == 0 {
+		if ((offset + i) & 1) == 0 {
 			hash ^= (hash << 7) ^ uint32(str[i]) ^ (hash >> 3)
 		} else {
 			hash ^= ^((hash << 11) ^ uint32(str[i]) ^ (hash >> 5)) + 1
@@ -19,11 +40,10 @@ func AP(str []byte) uint32 {
 	return hash
 }
 
-// AP64 implements the classic AP hash algorithm for 64 bits.
-func AP64(str []byte) uint64 {
-	var hash uint64
+// ap64 updates `hash` with `str` for AP64 algorithm, in which `offset` is the count of bytes hashed before.
+func ap64(hash uint64, offset int, str []byte) uint64 {
 	for i := 0; i < len(str); i++ {
-		if (i & 1) == 0 {
+		if ((offset + i) & 1) == 0 {
 			hash ^= (hash << 7) ^ uint64(str[i]) ^ (hash >> 3)
 		} else {
 			hash ^= ^((hash << 11) ^ uint64(str[i]) ^ (hash >> 5)) + 1
@@ -6,24 +6,40 @@
 
 package ghash
 
+import "hash"
+
 // BKDR implements the classic BKDR hash algorithm for 32 bits.
 func BKDR(str []byte) uint32 {
-	var (
-		seed uint32 = 131 // 31 131 1313 13131 131313 etc..
-		hash uint32 = 0
-	)
+	return bkdr(0, 0, str)
+}
+
+// BKDR64 implements the classic BKDR hash algorithm for 64 bits.
+func BKDR64(str []byte) uint64 {
+	return bkdr64(0, 0, str)
+}
+
+// NewBKDR returns a new hash.Hash32 computing the BKDR hash in streaming.
+func NewBKDR() hash.Hash32 {
+	return newDigest32(0, bkdr)
+}
+
+// NewBKDR64 returns a new hash.Hash64 computing the BKDR64 hash in streaming.
+func NewBKDR64() hash.Hash64 {
+	return newDigest64(0, bkdr64)
+}
+
+// bkdr updates `hash` with `str` for BKDR algorithm.
+func bkdr(hash uint32, _ int, str []byte) uint32 {
+	var seed uint32 = 131 // 31 131 1313 13131 131313 etc..
 	for i := 0; i < len(str); i++ {
 		hash = hash*seed + uint32(str[i])
 	}
 	return hash
 }
 
-// BKDR64 implements the classic BKDR hash algorithm for 64 bits.
-func BKDR64(str []byte) uint64 {
-	var (
-		seed uint64 = 131 // 31 131 1313 13131 131313 etc..
-		hash uint64 = 0
-	)
+// bkdr64 updates `hash` with `str` for BKDR64 algorithm.
+func bkdr64(hash uint64, _ int, str []byte) uint64 {
+	var seed uint64 = 131 // 31 131 1313 13131 131313 etc..
 	for i := 0; i < len(str); i++ {
 		hash = hash*seed + uint64(str[i])
 	}
@@ -6,18 +6,38 @@
 
 package ghash
 
+import "hash"
+
 // DJB implements the classic DJB hash algorithm for 32 bits.
 func DJB(str []byte) uint32 {
-	var hash uint32 = 5381
+	return djb(5381, 0, str)
+}
+
+// DJB64 implements the classic DJB hash algorithm for 64 bits.
+func DJB64(str []byte) uint64 {
+	return djb64(5381, 0, str)
+}
+
+// NewDJB returns a new hash.Hash32 computing the DJB hash in streaming.
+func NewDJB() hash.Hash32 {
+	return newDigest32(5381, djb)
+}
+
+// NewDJB64 returns a new hash.Hash64 computing the DJB64 hash in streaming.
+func NewDJB64() hash.Hash64 {
+	return newDigest64(5381, djb64)
+}
+
+// djb updates `hash` with `str` for DJB algorithm.
+func djb(hash uint32, _ int, str []byte) uint32 {
 	for i := 0; i < len(str); i++ {
 		hash += (hash << 5) + uint32(str[i])
 	}
 	return hash
 }
 
-// DJB64 implements the classic DJB hash algorithm for 64 bits.
-func DJB64(str []byte) uint64 {
-	var hash uint64 = 5381
+// djb64 updates `hash` with `str` for DJB64 algorithm.
+func djb64(hash uint64, _ int, str []byte) uint64 {
 	for i := 0; i < len(str); i++ {
 		hash += (hash << 5) + uint64(str[i])
 	}
@@ -6,12 +6,31 @@
 
 package ghash
 
+import "hash"
+
 // ELF implements the classic ELF hash algorithm for 32 bits.
 func ELF(str []byte) uint32 {
-	var (
-		hash uint32
-		x    uint32
-	)
+	return elf(0, 0, str)
+}
+
+// ELF64 implements the classic ELF hash algorithm for 64 bits.
+func ELF64(str []byte) uint64 {
+	return elf64(0, 0, str)
+}
+
+// NewELF returns a new hash.Hash32 computing the ELF hash in streaming.
+func NewELF() hash.Hash32 {
+	return newDigest32(0, elf)
+}
+
+// NewELF64 returns a new hash.Hash64 computing the ELF64 hash in streaming.
+func NewELF64() hash.Hash64 {
+	return newDigest64(0, elf64)
+}
+
+// elf updates `hash` with `str` for ELF algorithm.
+func elf(hash uint32, _ int, str []byte) uint32 {
+	var x uint32
 	for i := 0; i < len(str); i++ {
 		hash = (hash << 4) + uint32(str[i])
 		if x = hash & 0xF0000000; x != 0 {
@@ -22,12 +41,9 @@ func ELF(str []byte) uint32 {
 	return hash
 }
 
-// ELF64 implements the classic ELF hash algorithm for 64 bits.
-func ELF64(str []byte) uint64 {
-	var (
-		hash uint64
-		x    uint64
-	)
+// elf64 updates `hash` with `str` for ELF64 algorithm.
+func elf64(hash uint64, _ int, str []byte) uint64 {
+	var x uint64
 	for i := 0; i < len(str); i++ {
 		hash = (hash << 4) + uint64(str[i])
 		if x = hash & 0xF000000000000000; x != 0 {
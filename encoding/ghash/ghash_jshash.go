@@ -6,18 +6,38 @@
 
 package ghash
 
+import "hash"
+
 // JS implements the classic JS hash algorithm for 32 bits.
 func JS(str []byte) uint32 {
-	var hash uint32 = 1315423911
+	return js(1315423911, 0, str)
+}
+
+// JS64 implements the classic JS hash algorithm for 64 bits.
+func JS64(str []byte) uint64 {
+	return js64(1315423911, 0, str)
+}
+
+// NewJS returns a new hash.Hash32 computing the JS hash in streaming.
+func NewJS() hash.Hash32 {
+	return newDigest32(1315423911, js)
+}
+
+// NewJS64 returns a new hash.Hash64 computing the JS64 hash in streaming.
+func NewJS64() hash.Hash64 {
+	return newDigest64(1315423911, js64)
+}
+
+// js updates `hash` with `str` for JS algorithm.
+func js(hash uint32, _ int, str []byte) uint32 {
 	for i := 0; i < len(str); i++ {
 		hash ^= (hash << 5) + uint32(str[i]) + (hash >> 2)
 	}
 	return hash
 }
 
-// JS64 implements the classic JS hash algorithm for 64 bits.
-func JS64(str []byte) uint64 {
-	var hash uint64 = 1315423911
+// js64 updates `hash` with `str` for JS64 algorithm.
+func js64(hash uint64, _ int, str []byte) uint64 {
 	for i := 0; i < len(str); i++ {
 		hash ^= (hash << 5) + uint64(str[i]) + (hash >> 2)
 	}
@@ -0,0 +1,176 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghash
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+const (
+	murmur3C1 uint64 = 0x87c37b91114253d5
+	murmur3C2 uint64 = 0x4cf5ad432745937f
+)
+
+// murmur3Digest implements Hash128 for MurmurHash3 x64_128 algorithm.
+type murmur3Digest struct {
+	seed   uint32
+	h1, h2 uint64
+	total  uint64   // Total count of written bytes.
+	buffer [16]byte // Buffer for the bytes that are not enough for a block.
+	n      int      // Count of bytes in buffer.
+}
+
+// Murmur3 implements the MurmurHash3 x64_128 algorithm, which returns the 128 bits hash as two 64 bits parts.
+// The optional parameter `seed` specifies the seed of the hash, which is 0 in default.
+func Murmur3(str []byte, seed ...uint32) (h1, h2 uint64) {
+	d := NewMurmur3(seed...).(*murmur3Digest)
+	return d.finalize(str[d.blocks(str):])
+}
+
+// NewMurmur3 returns a new Hash128 computing the MurmurHash3 x64_128 hash in streaming.
+// The optional parameter `seed` specifies the seed of the hash, which is 0 in default.
+func NewMurmur3(seed ...uint32) Hash128 {
+	d := &murmur3Digest{}
+	if len(seed) > 0 {
+		d.seed = seed[0]
+	}
+	d.Reset()
+	return d
+}
+
+// Reset resets the hash to its initial state.
+func (d *murmur3Digest) Reset() {
+	d.h1 = uint64(d.seed)
+	d.h2 = uint64(d.seed)
+	d.total = 0
+	d.n = 0
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *murmur3Digest) Size() int {
+	return 16
+}
+
+// BlockSize returns the hash's underlying block size.
+func (d *murmur3Digest) BlockSize() int {
+	return 16
+}
+
+// Write implements io.Writer, which never returns an error.
+func (d *murmur3Digest) Write(p []byte) (n int, err error) {
+	n = len(p)
+	if d.n+len(p) < 16 {
+		d.n += copy(d.buffer[d.n:], p)
+		return
+	}
+	if d.n > 0 {
+		c := copy(d.buffer[d.n:], p)
+		d.blocks(d.buffer[:])
+		p = p[c:]
+		d.n = 0
+	}
+	p = p[d.blocks(p):]
+	d.n = copy(d.buffer[:], p)
+	return
+}
+
+// Sum appends the current hash to `b` in big-endian and returns the resulting slice.
+func (d *murmur3Digest) Sum(b []byte) []byte {
+	h1, h2 := d.Sum128()
+	return appendUint64(appendUint64(b, h1), h2)
+}
+
+// Sum128 returns the current hash as two 64 bits parts.
+func (d *murmur3Digest) Sum128() (h1, h2 uint64) {
+	return d.finalize(d.buffer[:d.n])
+}
+
+// blocks consumes all the 16 bytes blocks of `p` and returns the count of consumed bytes.
+func (d *murmur3Digest) blocks(p []byte) int {
+	var (
+		n      = len(p) - len(p)%16
+		h1, h2 = d.h1, d.h2
+	)
+	for i := 0; i < n; i += 16 {
+		k1 := binary.LittleEndian.Uint64(p[i:])
+		k2 := binary.LittleEndian.Uint64(p[i+8:])
+
+		k1 *= murmur3C1
+		k1 = bits.RotateLeft64(k1, 31)
+		k1 *= murmur3C2
+		h1 ^= k1
+		h1 = bits.RotateLeft64(h1, 27)
+		h1 += h2
+		h1 = h1*5 + 0x52dce729
+
+		k2 *= murmur3C2
+		k2 = bits.RotateLeft64(k2, 33)
+		k2 *= murmur3C1
+		h2 ^= k2
+		h2 = bits.RotateLeft64(h2, 31)
+		h2 += h1
+		h2 = h2*5 + 0x38495ab5
+	}
+	d.h1, d.h2 = h1, h2
+	d.total += uint64(n)
+	return n
+}
+
+// finalize computes the hash with the leftover bytes `tail` which are less than 16 bytes,
+// which does not change the state of the digest.
+func (d *murmur3Digest) finalize(tail []byte) (h1, h2 uint64) {
+	var (
+		k1, k2 uint64
+		total  = d.total + uint64(len(tail))
+	)
+	h1, h2 = d.h1, d.h2
+	for i := len(tail) - 1; i >= 8; i-- {
+		k2 ^= uint64(tail[i]) << (8 * (i - 8))
+	}
+	if len(tail) > 8 {
+		k2 *= murmur3C2
+		k2 = bits.RotateLeft64(k2, 33)
+		k2 *= murmur3C1
+		h2 ^= k2
+	}
+	for i := minInt(len(tail), 8) - 1; i >= 0; i-- {
+		k1 ^= uint64(tail[i]) << (8 * i)
+	}
+	if len(tail) > 0 {
+		k1 *= murmur3C1
+		k1 = bits.RotateLeft64(k1, 31)
+		k1 *= murmur3C2
+		h1 ^= k1
+	}
+
+	h1 ^= total
+	h2 ^= total
+	h1 += h2
+	h2 += h1
+	h1 = murmur3Fmix64(h1)
+	h2 = murmur3Fmix64(h2)
+	h1 += h2
+	h2 += h1
+	return h1, h2
+}
+
+func murmur3Fmix64(k uint64) uint64 {
+	k ^= k >> 33
+	k *= 0xff51afd7ed558ccd
+	k ^= k >> 33
+	k *= 0xc4ceb9fe1a85ec53
+	k ^= k >> 33
+	return k
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
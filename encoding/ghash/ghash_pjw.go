@@ -6,14 +6,35 @@
 
 package ghash
 
+import "hash"
+
 // PJW implements the classic PJW hash algorithm for 32 bits.
 func PJW(str []byte) uint32 {
+	return pjw(0, 0, str)
+}
+
+// PJW64 implements the classic PJW hash algorithm for 64 bits.
+func PJW64(str []byte) uint64 {
+	return pjw64(0, 0, str)
+}
+
+// NewPJW returns a new hash.Hash32 computing the PJW hash in streaming.
+func NewPJW() hash.Hash32 {
+	return newDigest32(0, pjw)
+}
+
+// NewPJW64 returns a new hash.Hash64 computing the PJW64 hash in streaming.
+func NewPJW64() hash.Hash64 {
+	return newDigest64(0, pjw64)
+}
+
+// pjw updates `hash` with `str` for PJW algorithm.
+func pjw(hash uint32, _ int, str []byte) uint32 {
 	var (
 		BitsInUnsignedInt uint32 = 32 // 4 * 8
 		ThreeQuarters            = (BitsInUnsignedInt * 3) / 4
 		OneEighth                = BitsInUnsignedInt / 8
 		HighBits          uint32 = (0xFFFFFFFF) << (BitsInUnsignedInt - OneEighth)
-		hash              uint32
 		test              uint32
 	)
 	for i := 0; i < len(str); i++ {
@@ -25,14 +46,13 @@ func PJW(str []byte) uint32 {
 	return hash
 }
 
-// PJW64 implements the classic PJW hash algorithm for 64 bits.
-func PJW64(str []byte) uint64 {
+// pjw64 updates `hash` with `str` for PJW64 algorithm.
+func pjw64(hash uint64, _ int, str []byte) uint64 {
 	var (
 		BitsInUnsignedInt uint64 = 32 // 4 * 8
 		ThreeQuarters            = (BitsInUnsignedInt * 3) / 4
 		OneEighth                = BitsInUnsignedInt / 8
 		HighBits          uint64 = (0xFFFFFFFFFFFFFFFF) << (BitsInUnsignedInt - OneEighth)
-		hash              uint64
 		test              uint64
 	)
 	for i := 0; i < len(str); i++ {
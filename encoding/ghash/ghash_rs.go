@@ -6,13 +6,40 @@
 
 package ghash
 
+import "hash"
+
 // RS implements the classic RS hash algorithm for 32 bits.
 func RS(str []byte) uint32 {
+	return rs(0, 0, str)
+}
+
+// RS64 implements the classic RS hash algorithm for 64 bits.
+func RS64(str []byte) uint64 {
+	return rs64(0, 0, str)
+}
+
+// NewRS returns a new hash.Hash32 computing the RS hash in streaming.
+func NewRS() hash.Hash32 {
+	return newDigest32(0, rs)
+}
+
+// NewRS64 returns a new hash.Hash64 computing the RS64 hash in streaming.
+func NewRS64() hash.Hash64 {
+	return newDigest64(0, rs64)
+}
+
+// rs updates `hash` with `str` for RS algorithm, in which `offset` is the count of bytes hashed before.
+func rs(hash uint32, offset int, str []byte) uint32 {
 	var (
-		b    uint32 = 378551
-		a    uint32 = 63689
-		hash uint32 = 0
+		b uint32 = 378551
+		a uint32 = 63689
 	)
+	// The multiplier `a` is multiplied by `b` for each hashed byte.
+	for p, n := b, offset; n > 0; p, n = p*p, n>>1 {
+		if n&1 == 1 {
+			a *= p
+		}
+	}
 	for i := 0; i < len(str); i++ {
 		hash = hash*a + uint32(str[i])
 		a *= b
@@ -20,13 +47,18 @@ func RS(str []byte) uint32 {
 	return hash
 }
 
-// RS64 implements the classic RS hash algorithm for 64 bits.
-func RS64(str []byte) uint64 {
+// rs64 updates `hash` with `str` for RS64 algorithm, in which `offset` is the count of bytes hashed before.
+func rs64(hash uint64, offset int, str []byte) uint64 {
 	var (
-		b    uint64 = 378551
-		a    uint64 = 63689
-		hash uint64 = 0
+		b uint64 = 378551
+		a uint64 = 63689
 	)
+	// The multiplier `a` is multiplied by `b` for each hashed byte.
+	for p, n := b, offset; n > 0; p, n = p*p, n>>1 {
+		if n&1 == 1 {
+			a *= p
+		}
+	}
 	for i := 0; i < len(str); i++ {
 		hash = hash*a + uint64(str[i])
 		a *= b
@@ -6,9 +6,30 @@
 
 package ghash
 
+import "hash"
+
 // SDBM implements the classic SDBM hash algorithm for 32 bits.
 func SDBM(str []byte) uint32 {
-	var hash uint32
+	return sdbm(0, 0, str)
+}
+
+// SDBM64 implements the classic SDBM hash algorithm for 64 bits.
+func SDBM64(str []byte) uint64 {
+	return sdbm64(0, 0, str)
+}
+
+// NewSDBM returns a new hash.Hash32 computing the SDBM hash in streaming.
+func NewSDBM() hash.Hash32 {
+	return newDigest32(0, sdbm)
+}
+
+// NewSDBM64 returns a new hash.Hash64 computing the SDBM64 hash in streaming.
+func NewSDBM64() hash.Hash64 {
+	return newDigest64(0, sdbm64)
+}
+
+// sdbm updates `hash` with `str` for SDBM algorithm.
+func sdbm(hash uint32, _ int, str []byte) uint32 {
 	for i := 0; i < len(str); i++ {
 		// equivalent to: hash = 65599*hash + uint32(str[i]);
 		hash = uint32(str[i]) + (hash << 6) + (hash << 16) - hash
@@ -16,9 +37,8 @@ func SDBM(str []byte) uint32 {
 	return hash
 }
 
-// SDBM64 implements the classic SDBM hash algorithm for 64 bits.
-func SDBM64(str []byte) uint64 {
-	var hash uint64
+// sdbm64 updates `hash` with `str` for SDBM64 algorithm.
+func sdbm64(hash uint64, _ int, str []byte) uint64 {
 	for i := 0; i < len(str); i++ {
 		// equivalent to: hash = 65599*hash + uint32(str[i])
 		hash = uint64(str[i]) + (hash << 6) + (hash << 16) - hash
@@ -0,0 +1,126 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghash
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+// Hash128 is the common interface implemented by all 128-bit hash functions.
+type Hash128 interface {
+	hash.Hash
+	// Sum128 returns the current 128 bits hash as two 64 bits parts,
+	// which does not change the underlying hash state.
+	Sum128() (h1, h2 uint64)
+}
+
+// digest32 implements hash.Hash32 for the classic 32 bits hash algorithms,
+// which are computed byte by byte with the function `update`.
+type digest32 struct {
+	init   uint32
+	hash   uint32
+	offset int
+	update func(hash uint32, offset int, str []byte) uint32
+}
+
+// digest64 implements hash.Hash64 for the classic 64 bits hash algorithms,
+// which are computed byte by byte with the function `update`.
+type digest64 struct {
+	init   uint64
+	hash   uint64
+	offset int
+	update func(hash uint64, offset int, str []byte) uint64
+}
+
+func newDigest32(init uint32, update func(hash uint32, offset int, str []byte) uint32) *digest32 {
+	return &digest32{init: init, hash: init, update: update}
+}
+
+func newDigest64(init uint64, update func(hash uint64, offset int, str []byte) uint64) *digest64 {
+	return &digest64{init: init, hash: init, update: update}
+}
+
+// Write implements io.Writer, which never returns an error.
+func (d *digest32) Write(p []byte) (n int, err error) {
+	d.hash = d.update(d.hash, d.offset, p)
+	d.offset += len(p)
+	return len(p), nil
+}
+
+// Sum appends the current hash to `b` in big-endian and returns the resulting slice.
+func (d *digest32) Sum(b []byte) []byte {
+	return appendUint32(b, d.hash)
+}
+
+// Sum32 returns the current hash.
+func (d *digest32) Sum32() uint32 {
+	return d.hash
+}
+
+// Reset resets the hash to its initial state.
+func (d *digest32) Reset() {
+	d.hash = d.init
+	d.offset = 0
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *digest32) Size() int {
+	return 4
+}
+
+// BlockSize returns the hash's underlying block size.
+func (d *digest32) BlockSize() int {
+	return 1
+}
+
+// Write implements io.Writer, which never returns an error.
+func (d *digest64) Write(p []byte) (n int, err error) {
+	d.hash = d.update(d.hash, d.offset, p)
+	d.offset += len(p)
+	return len(p), nil
+}
+
+// Sum appends the current hash to `b` in big-endian and returns the resulting slice.
+func (d *digest64) Sum(b []byte) []byte {
+	return appendUint64(b, d.hash)
+}
+
+// Sum64 returns the current hash.
+func (d *digest64) Sum64() uint64 {
+	return d.hash
+}
+
+// Reset resets the hash to its initial state.
+func (d *digest64) Reset() {
+	d.hash = d.init
+	d.offset = 0
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *digest64) Size() int {
+	return 8
+}
+
+// BlockSize returns the hash's underlying block size.
+func (d *digest64) BlockSize() int {
+	return 1
+}
+
+// appendUint32 appends `v` to `b` in big-endian.
+func appendUint32(b []byte, v uint32) []byte {
+	var buffer [4]byte
+	binary.BigEndian.PutUint32(buffer[:], v)
+	return append(b, buffer[:]...)
+}
+
+// appendUint64 appends `v` to `b` in big-endian.
+func appendUint64(b []byte, v uint64) []byte {
+	var buffer [8]byte
+	binary.BigEndian.PutUint64(buffer[:], v)
+	return append(b, buffer[:]...)
+}
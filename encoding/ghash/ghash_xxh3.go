@@ -0,0 +1,372 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghash
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+const (
+	xxh3PrimeMx1              uint64 = 0x165667919E3779F9
+	xxh3PrimeMx2              uint64 = 0x9FB21C651E98DF25
+	xxh3SecretSize                   = 192
+	xxh3SecretSizeMin                = 136
+	xxh3SecretConsumeRate            = 8
+	xxh3StripeLen                    = 64
+	xxh3StripesPerBlock              = (xxh3SecretSize - xxh3StripeLen) / xxh3SecretConsumeRate
+	xxh3BlockLen                     = xxh3StripeLen * xxh3StripesPerBlock
+	xxh3AccNb                        = xxh3StripeLen / 8
+	xxh3MidSizeMax                   = 240
+	xxh3MidSizeStartOffset           = 3
+	xxh3MidSizeLastOffset            = 17
+	xxh3InternalBufferSize           = 256
+	xxh3InternalBufferStripes        = xxh3InternalBufferSize / xxh3StripeLen
+	xxh3ScrambleSecretStart          = xxh3SecretSize - xxh3StripeLen
+	xxh3LastStripeSecretStart        = xxh3ScrambleSecretStart - 7
+	xxh3MergeAccsSecretStart         = 11
+)
+
+// xxh3Secret is the default secret of XXH3 algorithm.
+var xxh3Secret = [xxh3SecretSize]byte{
+	0xb8, 0xfe, 0x6c, 0x39, 0x23, 0xa4, 0x4b, 0xbe, 0x7c, 0x01, 0x81, 0x2c, 0xf7, 0x21, 0xad, 0x1c,
+	0xde, 0xd4, 0x6d, 0xe9, 0x83, 0x90, 0x97, 0xdb, 0x72, 0x40, 0xa4, 0xa4, 0xb7, 0xb3, 0x67, 0x1f,
+	0xcb, 0x79, 0xe6, 0x4e, 0xcc, 0xc0, 0xe5, 0x78, 0x82, 0x5a, 0xd0, 0x7d, 0xcc, 0xff, 0x72, 0x21,
+	0xb8, 0x08, 0x46, 0x74, 0xf7, 0x43, 0x24, 0x8e, 0xe0, 0x35, 0x90, 0xe6, 0x81, 0x3a, 0x26, 0x4c,
+	0x3c, 0x28, 0x52, 0xbb, 0x91, 0xc3, 0x00, 0xcb, 0x88, 0xd0, 0x65, 0x8b, 0x1b, 0x53, 0x2e, 0xa3,
+	0x71, 0x64, 0x48, 0x97, 0xa2, 0x0d, 0xf9, 0x4e, 0x38, 0x19, 0xef, 0x46, 0xa9, 0xde, 0xac, 0xd8,
+	0xa8, 0xfa, 0x76, 0x3f, 0xe3, 0x9c, 0x34, 0x3f, 0xf9, 0xdc, 0xbb, 0xc7, 0xc7, 0x0b, 0x4f, 0x1d,
+	0x8a, 0x51, 0xe0, 0x4b, 0xcd, 0xb4, 0x59, 0x31, 0xc8, 0x9f, 0x7e, 0xc9, 0xd9, 0x78, 0x73, 0x64,
+	0xea, 0xc5, 0xac, 0x83, 0x34, 0xd3, 0xeb, 0xc3, 0xc5, 0x81, 0xa0, 0xff, 0xfa, 0x13, 0x63, 0xeb,
+	0x17, 0x0d, 0xdd, 0x51, 0xb7, 0xf0, 0xda, 0x49, 0xd3, 0x16, 0x55, 0x26, 0x29, 0xd4, 0x68, 0x9e,
+	0x2b, 0x16, 0xbe, 0x58, 0x7d, 0x47, 0xa1, 0xfc, 0x8f, 0xf8, 0xb8, 0xd1, 0x7a, 0xd0, 0x31, 0xce,
+	0x45, 0xcb, 0x3a, 0x8f, 0x95, 0x16, 0x04, 0x28, 0xaf, 0xd7, 0xfb, 0xca, 0xbb, 0x4b, 0x40, 0x7e,
+}
+
+// xxh3Digest implements hash.Hash64 for XXH3 algorithm.
+type xxh3Digest struct {
+	seed          uint64
+	secret        [xxh3SecretSize]byte         // Secret derived from seed, which is used for long input.
+	acc           [xxh3AccNb]uint64            // Accumulators for long input.
+	buffer        [xxh3InternalBufferSize]byte // Buffer for the latest input.
+	n             int                          // Count of bytes in buffer.
+	stripesInLoop int                          // Count of consumed stripes in current block.
+	total         uint64                       // Total count of written bytes.
+}
+
+// XXH3 implements the 64 bits xxHash3 algorithm, which is much faster than XXH64 especially for small input.
+// The optional parameter `seed` specifies the seed of the hash, which is 0 in default.
+func XXH3(str []byte, seed ...uint64) uint64 {
+	var s uint64
+	if len(seed) > 0 {
+		s = seed[0]
+	}
+	length := len(str)
+	switch {
+	case length <= 16:
+		return xxh3Len0To16(str, s)
+	case length <= 128:
+		return xxh3Len17To128(str, s)
+	case length <= xxh3MidSizeMax:
+		return xxh3Len129To240(str, s)
+	}
+	var (
+		acc    = xxh3InitAcc()
+		secret = xxh3DeriveSecret(s)
+	)
+	xxh3HashLongLoop(&acc, str, secret[:])
+	return xxh3MergeAccs(&acc, secret[xxh3MergeAccsSecretStart:], uint64(length)*xxPrime64v1)
+}
+
+// NewXXH3 returns a new hash.Hash64 computing the XXH3 hash in streaming.
+// The optional parameter `seed` specifies the seed of the hash, which is 0 in default.
+func NewXXH3(seed ...uint64) hash.Hash64 {
+	d := &xxh3Digest{}
+	if len(seed) > 0 {
+		d.seed = seed[0]
+	}
+	d.secret = xxh3DeriveSecret(d.seed)
+	d.Reset()
+	return d
+}
+
+// Reset resets the hash to its initial state.
+func (d *xxh3Digest) Reset() {
+	d.acc = xxh3InitAcc()
+	d.n = 0
+	d.stripesInLoop = 0
+	d.total = 0
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *xxh3Digest) Size() int {
+	return 8
+}
+
+// BlockSize returns the hash's underlying block size.
+func (d *xxh3Digest) BlockSize() int {
+	return xxh3StripeLen
+}
+
+// Write implements io.Writer, which never returns an error.
+//
+// Note that the latest input is always kept in buffer instead of being consumed,
+// as the last stripe of the input is processed in a different way.
+func (d *xxh3Digest) Write(p []byte) (n int, err error) {
+	n = len(p)
+	d.total += uint64(len(p))
+	if d.n+len(p) <= xxh3InternalBufferSize {
+		d.n += copy(d.buffer[d.n:], p)
+		return
+	}
+	if d.n > 0 {
+		c := copy(d.buffer[d.n:], p)
+		p = p[c:]
+		d.consumeStripes(d.buffer[:], xxh3InternalBufferStripes)
+		d.n = 0
+	}
+	if len(p) > xxh3InternalBufferSize {
+		consumed := 0
+		for len(p)-consumed > xxh3InternalBufferSize {
+			d.consumeStripes(p[consumed:], xxh3InternalBufferStripes)
+			consumed += xxh3InternalBufferSize
+		}
+		// Keeps the last consumed stripe for the case that the leftover bytes are less than a stripe.
+		copy(d.buffer[xxh3InternalBufferSize-xxh3StripeLen:], p[consumed-xxh3StripeLen:consumed])
+		p = p[consumed:]
+	}
+	d.n = copy(d.buffer[:], p)
+	return
+}
+
+// Sum appends the current hash to `b` in big-endian and returns the resulting slice.
+func (d *xxh3Digest) Sum(b []byte) []byte {
+	return appendUint64(b, d.Sum64())
+}
+
+// Sum64 returns the current hash.
+func (d *xxh3Digest) Sum64() uint64 {
+	if d.total <= xxh3MidSizeMax {
+		return XXH3(d.buffer[:d.n], d.seed)
+	}
+	var (
+		acc           = d.acc
+		stripesInLoop = d.stripesInLoop
+		lastStripe    []byte
+	)
+	if d.n >= xxh3StripeLen {
+		stripes := (d.n - 1) / xxh3StripeLen
+		xxh3ConsumeStripes(&acc, &stripesInLoop, d.buffer[:], stripes, d.secret[:])
+		lastStripe = d.buffer[d.n-xxh3StripeLen : d.n]
+	} else {
+		lastStripe = make([]byte, 0, xxh3StripeLen)
+		lastStripe = append(lastStripe, d.buffer[xxh3InternalBufferSize-(xxh3StripeLen-d.n):]...)
+		lastStripe = append(lastStripe, d.buffer[:d.n]...)
+	}
+	xxh3Accumulate512(&acc, lastStripe, d.secret[xxh3LastStripeSecretStart:])
+	return xxh3MergeAccs(&acc, d.secret[xxh3MergeAccsSecretStart:], d.total*xxPrime64v1)
+}
+
+// consumeStripes consumes `stripes` count of stripes from `p` into accumulators.
+func (d *xxh3Digest) consumeStripes(p []byte, stripes int) {
+	xxh3ConsumeStripes(&d.acc, &d.stripesInLoop, p, stripes, d.secret[:])
+}
+
+func xxh3ConsumeStripes(acc *[xxh3AccNb]uint64, stripesInLoop *int, p []byte, stripes int, secret []byte) {
+	if xxh3StripesPerBlock-*stripesInLoop <= stripes {
+		stripesToEnd := xxh3StripesPerBlock - *stripesInLoop
+		xxh3Accumulate(acc, p, secret[*stripesInLoop*xxh3SecretConsumeRate:], stripesToEnd)
+		xxh3ScrambleAcc(acc, secret[xxh3ScrambleSecretStart:])
+		xxh3Accumulate(acc, p[stripesToEnd*xxh3StripeLen:], secret, stripes-stripesToEnd)
+		*stripesInLoop = stripes - stripesToEnd
+	} else {
+		xxh3Accumulate(acc, p, secret[*stripesInLoop*xxh3SecretConsumeRate:], stripes)
+		*stripesInLoop += stripes
+	}
+}
+
+func xxh3InitAcc() [xxh3AccNb]uint64 {
+	return [xxh3AccNb]uint64{
+		uint64(xxPrime32v3), xxPrime64v1, xxPrime64v2, xxPrime64v3,
+		xxPrime64v4, uint64(xxPrime32v2), xxPrime64v5, uint64(xxPrime32v1),
+	}
+}
+
+// xxh3DeriveSecret derives the secret for long input from `seed`.
+func xxh3DeriveSecret(seed uint64) [xxh3SecretSize]byte {
+	secret := xxh3Secret
+	if seed == 0 {
+		return secret
+	}
+	for i := 0; i < xxh3SecretSize; i += 16 {
+		binary.LittleEndian.PutUint64(secret[i:], binary.LittleEndian.Uint64(xxh3Secret[i:])+seed)
+		binary.LittleEndian.PutUint64(secret[i+8:], binary.LittleEndian.Uint64(xxh3Secret[i+8:])-seed)
+	}
+	return secret
+}
+
+func xxh3Len0To16(p []byte, seed uint64) uint64 {
+	length := len(p)
+	switch {
+	case length > 8:
+		var (
+			bitflip1 = (readUint64(xxh3Secret[24:]) ^ readUint64(xxh3Secret[32:])) + seed
+			bitflip2 = (readUint64(xxh3Secret[40:]) ^ readUint64(xxh3Secret[48:])) - seed
+			inputLo  = readUint64(p) ^ bitflip1
+			inputHi  = readUint64(p[length-8:]) ^ bitflip2
+			acc      = uint64(length) + bits.ReverseBytes64(inputLo) + inputHi + xxh3Mul128Fold64(inputLo, inputHi)
+		)
+		return xxh3Avalanche(acc)
+
+	case length >= 4:
+		seed ^= uint64(bits.ReverseBytes32(uint32(seed))) << 32
+		var (
+			input1  = binary.LittleEndian.Uint32(p)
+			input2  = binary.LittleEndian.Uint32(p[length-4:])
+			bitflip = (readUint64(xxh3Secret[8:]) ^ readUint64(xxh3Secret[16:])) - seed
+			input64 = uint64(input2) + uint64(input1)<<32
+		)
+		return xxh3Rrmxmx(input64^bitflip, uint64(length))
+
+	case length > 0:
+		var (
+			c1       = uint32(p[0])
+			c2       = uint32(p[length>>1])
+			c3       = uint32(p[length-1])
+			combined = c1<<16 | c2<<24 | c3 | uint32(length)<<8
+			bitflip  = uint64(binary.LittleEndian.Uint32(xxh3Secret[:])^binary.LittleEndian.Uint32(xxh3Secret[4:])) + seed
+		)
+		return xxh64Avalanche(uint64(combined) ^ bitflip)
+
+	default:
+		return xxh64Avalanche(seed ^ readUint64(xxh3Secret[56:]) ^ readUint64(xxh3Secret[64:]))
+	}
+}
+
+func xxh3Len17To128(p []byte, seed uint64) uint64 {
+	var (
+		length = len(p)
+		acc    = uint64(length) * xxPrime64v1
+	)
+	if length > 32 {
+		if length > 64 {
+			if length > 96 {
+				acc += xxh3Mix16B(p[48:], xxh3Secret[96:], seed)
+				acc += xxh3Mix16B(p[length-64:], xxh3Secret[112:], seed)
+			}
+			acc += xxh3Mix16B(p[32:], xxh3Secret[64:], seed)
+			acc += xxh3Mix16B(p[length-48:], xxh3Secret[80:], seed)
+		}
+		acc += xxh3Mix16B(p[16:], xxh3Secret[32:], seed)
+		acc += xxh3Mix16B(p[length-32:], xxh3Secret[48:], seed)
+	}
+	acc += xxh3Mix16B(p, xxh3Secret[:], seed)
+	acc += xxh3Mix16B(p[length-16:], xxh3Secret[16:], seed)
+	return xxh3Avalanche(acc)
+}
+
+func xxh3Len129To240(p []byte, seed uint64) uint64 {
+	var (
+		length = len(p)
+		acc    = uint64(length) * xxPrime64v1
+		rounds = length / 16
+	)
+	for i := 0; i < 8; i++ {
+		acc += xxh3Mix16B(p[16*i:], xxh3Secret[16*i:], seed)
+	}
+	acc = xxh3Avalanche(acc)
+	for i := 8; i < rounds; i++ {
+		acc += xxh3Mix16B(p[16*i:], xxh3Secret[16*(i-8)+xxh3MidSizeStartOffset:], seed)
+	}
+	acc += xxh3Mix16B(p[length-16:], xxh3Secret[xxh3SecretSizeMin-xxh3MidSizeLastOffset:], seed)
+	return xxh3Avalanche(acc)
+}
+
+// xxh3HashLongLoop consumes all the input `p` which is longer than 240 bytes into accumulators.
+func xxh3HashLongLoop(acc *[xxh3AccNb]uint64, p []byte, secret []byte) {
+	var (
+		length = len(p)
+		blocks = (length - 1) / xxh3BlockLen
+	)
+	for n := 0; n < blocks; n++ {
+		xxh3Accumulate(acc, p[n*xxh3BlockLen:], secret, xxh3StripesPerBlock)
+		xxh3ScrambleAcc(acc, secret[xxh3ScrambleSecretStart:])
+	}
+	// Last partial block.
+	stripes := ((length - 1) - xxh3BlockLen*blocks) / xxh3StripeLen
+	xxh3Accumulate(acc, p[blocks*xxh3BlockLen:], secret, stripes)
+	// Last stripe.
+	xxh3Accumulate512(acc, p[length-xxh3StripeLen:], secret[xxh3LastStripeSecretStart:])
+}
+
+func xxh3Accumulate(acc *[xxh3AccNb]uint64, p []byte, secret []byte, stripes int) {
+	for n := 0; n < stripes; n++ {
+		xxh3Accumulate512(acc, p[n*xxh3StripeLen:], secret[n*xxh3SecretConsumeRate:])
+	}
+}
+
+func xxh3Accumulate512(acc *[xxh3AccNb]uint64, p []byte, secret []byte) {
+	for i := 0; i < xxh3AccNb; i++ {
+		var (
+			dataVal = readUint64(p[8*i:])
+			dataKey = dataVal ^ readUint64(secret[8*i:])
+		)
+		acc[i^1] += dataVal
+		acc[i] += uint64(uint32(dataKey)) * (dataKey >> 32)
+	}
+}
+
+func xxh3ScrambleAcc(acc *[xxh3AccNb]uint64, secret []byte) {
+	for i := 0; i < xxh3AccNb; i++ {
+		a := acc[i]
+		a ^= a >> 47
+		a ^= readUint64(secret[8*i:])
+		a *= uint64(xxPrime32v1)
+		acc[i] = a
+	}
+}
+
+func xxh3MergeAccs(acc *[xxh3AccNb]uint64, secret []byte, start uint64) uint64 {
+	result := start
+	for i := 0; i < 4; i++ {
+		result += xxh3Mul128Fold64(acc[2*i]^readUint64(secret[16*i:]), acc[2*i+1]^readUint64(secret[16*i+8:]))
+	}
+	return xxh3Avalanche(result)
+}
+
+func xxh3Mix16B(p []byte, secret []byte, seed uint64) uint64 {
+	return xxh3Mul128Fold64(
+		readUint64(p)^(readUint64(secret)+seed),
+		readUint64(p[8:])^(readUint64(secret[8:])-seed),
+	)
+}
+
+func xxh3Mul128Fold64(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return hi ^ lo
+}
+
+func xxh3Avalanche(h uint64) uint64 {
+	h ^= h >> 37
+	h *= xxh3PrimeMx1
+	h ^= h >> 32
+	return h
+}
+
+func xxh3Rrmxmx(h uint64, length uint64) uint64 {
+	h ^= bits.RotateLeft64(h, 49) ^ bits.RotateLeft64(h, 24)
+	h *= xxh3PrimeMx2
+	h ^= (h >> 35) + length
+	h *= xxh3PrimeMx2
+	h ^= h >> 28
+	return h
+}
+
+func readUint64(p []byte) uint64 {
+	return binary.LittleEndian.Uint64(p)
+}
@@ -0,0 +1,160 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghash
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+const (
+	xxPrime32v1 uint32 = 0x9E3779B1
+	xxPrime32v2 uint32 = 0x85EBCA77
+	xxPrime32v3 uint32 = 0xC2B2AE3D
+	xxPrime64v1 uint64 = 0x9E3779B185EBCA87
+	xxPrime64v2 uint64 = 0xC2B2AE3D27D4EB4F
+	xxPrime64v3 uint64 = 0x165667B19E3779F9
+	xxPrime64v4 uint64 = 0x85EBCA77C2B2AE63
+	xxPrime64v5 uint64 = 0x27D4EB2F165667C5
+)
+
+// xxh64Digest implements hash.Hash64 for XXH64 algorithm.
+type xxh64Digest struct {
+	seed   uint64
+	v      [4]uint64 // Accumulators for 32 bytes stripes.
+	total  uint64    // Total count of written bytes.
+	buffer [32]byte  // Buffer for the bytes that are not enough for a stripe.
+	n      int       // Count of bytes in buffer.
+}
+
+// XXH64 implements the xxHash algorithm for 64 bits, which is extremely fast with good quality.
+// The optional parameter `seed` specifies the seed of the hash, which is 0 in default.
+func XXH64(str []byte, seed ...uint64) uint64 {
+	d := NewXXH64(seed...).(*xxh64Digest)
+	return d.finalize(str[d.stripes(str):])
+}
+
+// NewXXH64 returns a new hash.Hash64 computing the XXH64 hash in streaming.
+// The optional parameter `seed` specifies the seed of the hash, which is 0 in default.
+func NewXXH64(seed ...uint64) hash.Hash64 {
+	d := &xxh64Digest{}
+	if len(seed) > 0 {
+		d.seed = seed[0]
+	}
+	d.Reset()
+	return d
+}
+
+// Reset resets the hash to its initial state.
+func (d *xxh64Digest) Reset() {
+	d.v[0] = d.seed + xxPrime64v1 + xxPrime64v2
+	d.v[1] = d.seed + xxPrime64v2
+	d.v[2] = d.seed
+	d.v[3] = d.seed - xxPrime64v1
+	d.total = 0
+	d.n = 0
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *xxh64Digest) Size() int {
+	return 8
+}
+
+// BlockSize returns the hash's underlying block size.
+func (d *xxh64Digest) BlockSize() int {
+	return 32
+}
+
+// Write implements io.Writer, which never returns an error.
+func (d *xxh64Digest) Write(p []byte) (n int, err error) {
+	n = len(p)
+	if d.n+len(p) < 32 {
+		d.n += copy(d.buffer[d.n:], p)
+		return
+	}
+	if d.n > 0 {
+		c := copy(d.buffer[d.n:], p)
+		d.stripes(d.buffer[:])
+		p = p[c:]
+		d.n = 0
+	}
+	p = p[d.stripes(p):]
+	d.n = copy(d.buffer[:], p)
+	return
+}
+
+// Sum appends the current hash to `b` in big-endian and returns the resulting slice.
+func (d *xxh64Digest) Sum(b []byte) []byte {
+	return appendUint64(b, d.Sum64())
+}
+
+// Sum64 returns the current hash.
+func (d *xxh64Digest) Sum64() uint64 {
+	return d.finalize(d.buffer[:d.n])
+}
+
+// stripes consumes all the 32 bytes stripes of `p` and returns the count of consumed bytes.
+func (d *xxh64Digest) stripes(p []byte) int {
+	n := len(p) - len(p)%32
+	for i := 0; i < n; i += 32 {
+		d.v[0] = xxh64Round(d.v[0], binary.LittleEndian.Uint64(p[i:]))
+		d.v[1] = xxh64Round(d.v[1], binary.LittleEndian.Uint64(p[i+8:]))
+		d.v[2] = xxh64Round(d.v[2], binary.LittleEndian.Uint64(p[i+16:]))
+		d.v[3] = xxh64Round(d.v[3], binary.LittleEndian.Uint64(p[i+24:]))
+	}
+	d.total += uint64(n)
+	return n
+}
+
+// finalize computes the hash with the leftover bytes `tail` which are less than 32 bytes,
+// which does not change the state of the digest.
+func (d *xxh64Digest) finalize(tail []byte) uint64 {
+	var (
+		h     uint64
+		total = d.total + uint64(len(tail))
+	)
+	if total >= 32 {
+		h = bits.RotateLeft64(d.v[0], 1) + bits.RotateLeft64(d.v[1], 7) +
+			bits.RotateLeft64(d.v[2], 12) + bits.RotateLeft64(d.v[3], 18)
+		for _, v := range d.v {
+			h = (h^xxh64Round(0, v))*xxPrime64v1 + xxPrime64v4
+		}
+	} else {
+		h = d.seed + xxPrime64v5
+	}
+	h += total
+	for ; len(tail) >= 8; tail = tail[8:] {
+		h ^= xxh64Round(0, binary.LittleEndian.Uint64(tail))
+		h = bits.RotateLeft64(h, 27)*xxPrime64v1 + xxPrime64v4
+	}
+	if len(tail) >= 4 {
+		h ^= uint64(binary.LittleEndian.Uint32(tail)) * xxPrime64v1
+		h = bits.RotateLeft64(h, 23)*xxPrime64v2 + xxPrime64v3
+		tail = tail[4:]
+	}
+	for _, c := range tail {
+		h ^= uint64(c) * xxPrime64v5
+		h = bits.RotateLeft64(h, 11) * xxPrime64v1
+	}
+	return xxh64Avalanche(h)
+}
+
+func xxh64Round(acc, input uint64) uint64 {
+	acc += input * xxPrime64v2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * xxPrime64v1
+}
+
+func xxh64Avalanche(h uint64) uint64 {
+	h ^= h >> 33
+	h *= xxPrime64v2
+	h ^= h >> 29
+	h *= xxPrime64v3
+	h ^= h >> 32
+	return h
+}
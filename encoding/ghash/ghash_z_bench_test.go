@@ -113,3 +113,21 @@ func Benchmark_AP64(b *testing.B) {
 		ghash.AP64(str)
 	}
 }
+
+func Benchmark_XXH64(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ghash.XXH64(str)
+	}
+}
+
+func Benchmark_XXH3(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ghash.XXH3(str)
+	}
+}
+
+func Benchmark_Murmur3(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ghash.Murmur3(str)
+	}
+}
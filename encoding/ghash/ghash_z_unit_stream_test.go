@@ -0,0 +1,155 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghash_test
+
+import (
+	"bytes"
+	"hash"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/ghash"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+// writeInChunks writes `data` to `h` in random sized chunks.
+func writeInChunks(h io.Writer, data []byte, r *rand.Rand) {
+	for len(data) > 0 {
+		n := r.Intn(600) + 1
+		if n > len(data) {
+			n = len(data)
+		}
+		h.Write(data[:n])
+		data = data[n:]
+	}
+}
+
+func Test_XXH64(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(ghash.XXH64(nil), uint64(0xef46db3751d8e999))
+		t.Assert(ghash.XXH64([]byte("abc")), uint64(0x44bc2cf5ad770999))
+		t.AssertNE(ghash.XXH64(strBasic), ghash.XXH64(strBasic, 1))
+	})
+}
+
+func Test_XXH3(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(ghash.XXH3(nil), uint64(0x2d06800538d394c2))
+		t.Assert(ghash.XXH3([]byte("a")), uint64(0xe6c632b61e964e1f))
+		t.Assert(ghash.XXH3([]byte("abc")), uint64(0x78af5f94892f3950))
+		t.AssertNE(ghash.XXH3(strBasic), ghash.XXH3(strBasic, 1))
+	})
+}
+
+func Test_Murmur3(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		h1, h2 := ghash.Murmur3(nil)
+		t.Assert(h1, uint64(0))
+		t.Assert(h2, uint64(0))
+		h1, h2 = ghash.Murmur3([]byte("hello"))
+		t.Assert(h1, uint64(0xcbd8a7b341bd9b02))
+		t.Assert(h2, uint64(0x5b1e906a48ae1d19))
+		h1, h2 = ghash.Murmur3([]byte("The quick brown fox jumps over the lazy dog."))
+		t.Assert(h1, uint64(0xcd99481f9ee902c9))
+		t.Assert(h2, uint64(0x695da1a38987b6e7))
+	})
+}
+
+func Test_Stream_Equal(t *testing.T) {
+	var (
+		r       = rand.New(rand.NewSource(1))
+		lengths = []int{0, 1, 3, 4, 8, 9, 16, 17, 33, 65, 97, 128, 129, 240, 241, 256, 257, 1024, 1025, 4099}
+	)
+	gtest.C(t, func(t *gtest.T) {
+		hash32 := map[string]struct {
+			New func() hash.Hash32
+			Sum func([]byte) uint32
+		}{
+			"BKDR": {ghash.NewBKDR, ghash.BKDR},
+			"SDBM": {ghash.NewSDBM, ghash.SDBM},
+			"RS":   {ghash.NewRS, ghash.RS},
+			"JS":   {ghash.NewJS, ghash.JS},
+			"PJW":  {ghash.NewPJW, ghash.PJW},
+			"ELF":  {ghash.NewELF, ghash.ELF},
+			"DJB":  {ghash.NewDJB, ghash.DJB},
+			"AP":   {ghash.NewAP, ghash.AP},
+		}
+		for _, length := range lengths {
+			data := make([]byte, length)
+			r.Read(data)
+			for _, item := range hash32 {
+				h := item.New()
+				writeInChunks(h, data, r)
+				t.Assert(h.Sum32(), item.Sum(data))
+				h.Reset()
+				h.Write(data)
+				t.Assert(h.Sum32(), item.Sum(data))
+			}
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		hash64 := map[string]struct {
+			New func() hash.Hash64
+			Sum func([]byte) uint64
+		}{
+			"BKDR64": {ghash.NewBKDR64, ghash.BKDR64},
+			"SDBM64": {ghash.NewSDBM64, ghash.SDBM64},
+			"RS64":   {ghash.NewRS64, ghash.RS64},
+			"JS64":   {ghash.NewJS64, ghash.JS64},
+			"PJW64":  {ghash.NewPJW64, ghash.PJW64},
+			"ELF64":  {ghash.NewELF64, ghash.ELF64},
+			"DJB64":  {ghash.NewDJB64, ghash.DJB64},
+			"AP64":   {ghash.NewAP64, ghash.AP64},
+			"XXH64": {
+				func() hash.Hash64 { return ghash.NewXXH64(7) },
+				func(b []byte) uint64 { return ghash.XXH64(b, 7) },
+			},
+			"XXH3": {
+				func() hash.Hash64 { return ghash.NewXXH3(7) },
+				func(b []byte) uint64 { return ghash.XXH3(b, 7) },
+			},
+		}
+		for _, length := range lengths {
+			data := make([]byte, length)
+			r.Read(data)
+			for _, item := range hash64 {
+				h := item.New()
+				writeInChunks(h, data, r)
+				t.Assert(h.Sum64(), item.Sum(data))
+				h.Reset()
+				h.Write(data)
+				t.Assert(h.Sum64(), item.Sum(data))
+			}
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		for _, length := range lengths {
+			data := make([]byte, length)
+			r.Read(data)
+			h := ghash.NewMurmur3(7)
+			writeInChunks(h, data, r)
+			h1, h2 := h.Sum128()
+			w1, w2 := ghash.Murmur3(data, 7)
+			t.Assert(h1, w1)
+			t.Assert(h2, w2)
+			t.Assert(len(h.Sum(nil)), 16)
+		}
+	})
+}
+
+func Test_Stream_Reader(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		data := bytes.Repeat(strBasic, 10000)
+		h := ghash.NewXXH3()
+		n, err := io.Copy(h, bytes.NewReader(data))
+		t.AssertNil(err)
+		t.Assert(n, len(data))
+		t.Assert(h.Sum64(), ghash.XXH3(data))
+		t.Assert(h.Sum([]byte{1}), append([]byte{1}, h.Sum(nil)...))
+	})
+}
@@ -32,6 +32,7 @@ const (
 	ContentTypeYml        ContentType = `yml`
 	ContentTypeToml       ContentType = `toml`
 	ContentTypeProperties ContentType = `properties`
+	ContentTypeMsgPack    ContentType = `msgpack`
 )
 
 const (
@@ -8,6 +8,7 @@ package gjson
 
 import (
 	"github.com/gogf/gf/v2/encoding/gini"
+	"github.com/gogf/gf/v2/encoding/gmsgpack"
 	"github.com/gogf/gf/v2/encoding/gproperties"
 	"github.com/gogf/gf/v2/encoding/gtoml"
 	"github.com/gogf/gf/v2/encoding/gxml"
@@ -225,3 +226,22 @@ func (j *Json) MustToProperties() []byte {
 func (j *Json) MustToPropertiesString() string {
 	return string(j.MustToProperties())
 }
+
+// ========================================================================
+// MessagePack
+// ========================================================================
+
+// ToMsgPack encodes the data of current Json object as MessagePack format content.
+func (j *Json) ToMsgPack() ([]byte, error) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return gmsgpack.Encode(*(j.p))
+}
+
+func (j *Json) MustToMsgPack() []byte {
+	result, err := j.ToMsgPack()
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
@@ -11,6 +11,7 @@ import (
 	"reflect"
 
 	"github.com/gogf/gf/v2/encoding/gini"
+	"github.com/gogf/gf/v2/encoding/gmsgpack"
 	"github.com/gogf/gf/v2/encoding/gproperties"
 	"github.com/gogf/gf/v2/encoding/gtoml"
 	"github.com/gogf/gf/v2/encoding/gxml"
@@ -186,6 +187,17 @@ func LoadProperties(data interface{}, safe ...bool) (*Json, error) {
 	return doLoadContentWithOptions(gconv.Bytes(data), option)
 }
 
+// LoadMsgPack creates a Json object from given MessagePack format content.
+func LoadMsgPack(data interface{}, safe ...bool) (*Json, error) {
+	option := Options{
+		Type: ContentTypeMsgPack,
+	}
+	if len(safe) > 0 && safe[0] {
+		option.Safe = true
+	}
+	return doLoadContentWithOptions(gconv.Bytes(data), option)
+}
+
 // LoadContent creates a Json object from given content, it checks the data type of `content`
 // automatically, supporting data content type as follows:
 // JSON, XML, INI, YAML and TOML.
@@ -205,8 +217,8 @@ func LoadContentType(dataType ContentType, data interface{}, safe ...bool) (*Jso
 	if len(content) == 0 {
 		return New(nil, safe...), nil
 	}
-	// ignore UTF8-BOM
-	if content[0] == 0xEF && content[1] == 0xBB && content[2] == 0xBF {
+	// ignore UTF8-BOM, which is not applicable for binary content.
+	if dataType != ContentTypeMsgPack && bytes.HasPrefix(content, []byte{0xEF, 0xBB, 0xBF}) {
 		content = content[3:]
 	}
 	options := Options{
@@ -236,7 +248,8 @@ func IsValidDataType(dataType ContentType) bool {
 		ContentTypeYml,
 		ContentTypeToml,
 		ContentTypeIni,
-		ContentTypeProperties:
+		ContentTypeProperties,
+		ContentTypeMsgPack:
 		return true
 	}
 	return false
@@ -258,8 +271,8 @@ func loadContentTypeWithOptions(data interface{}, options Options) (*Json, error
 	if len(content) == 0 {
 		return NewWithOptions(nil, options), nil
 	}
-	// ignore UTF8-BOM
-	if content[0] == 0xEF && content[1] == 0xBB && content[2] == 0xBF {
+	// ignore UTF8-BOM, which is not applicable for binary content.
+	if options.Type != ContentTypeMsgPack && bytes.HasPrefix(content, []byte{0xEF, 0xBB, 0xBF}) {
 		content = content[3:]
 	}
 	return doLoadContentWithOptions(content, options)
@@ -309,6 +322,13 @@ func doLoadContentWithOptions(data []byte, options Options) (*Json, error) {
 			return nil, err
 		}
 
+	case ContentTypeMsgPack:
+		// It does not convert the content to JSON, which keeps the integer and binary values.
+		if result, err = gmsgpack.Decode(data); err != nil {
+			return nil, err
+		}
+		return NewWithOptions(result, options), nil
+
 	default:
 		err = gerror.NewCodef(
 			gcode.CodeInvalidParameter,
@@ -416,3 +416,36 @@ DBINFO.password=password
 		t.AssertNE(err, nil)
 	})
 }
+
+func Test_Load_MsgPack(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		data, err := gjson.New(g.Map{
+			"name":  "john",
+			"id":    int64(9007199254740993),
+			"roles": g.Slice{"admin", "user"},
+			"token": []byte{0xef, 0x00},
+		}).ToMsgPack()
+		t.AssertNil(err)
+
+		j, err := gjson.LoadMsgPack(data, true)
+		t.AssertNil(err)
+		t.Assert(j.Get("name"), "john")
+		t.Assert(j.Get("id").Int64(), int64(9007199254740993))
+		t.Assert(j.Get("roles.1"), "user")
+		t.Assert(j.Get("token").Bytes(), []byte{0xef, 0x00})
+		t.Assert(j.MustToMsgPack(), data)
+
+		j, err = gjson.LoadContentType(gjson.ContentTypeMsgPack, data)
+		t.AssertNil(err)
+		t.Assert(j.Get("name"), "john")
+		t.Assert(gjson.IsValidDataType(gjson.ContentTypeMsgPack), true)
+
+		// The single byte 0xef is a negative fixint but not the UTF-8 BOM.
+		j, err = gjson.LoadContentType(gjson.ContentTypeMsgPack, []byte{0xef})
+		t.AssertNil(err)
+		t.Assert(j.Var().Int(), -17)
+
+		_, err = gjson.LoadMsgPack(data[:len(data)-1])
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,137 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gmsgpack provides accessing and converting for MessagePack content.
+//
+// It implements the MessagePack specification: https://github.com/msgpack/msgpack/blob/master/spec.md
+package gmsgpack
+
+import (
+	"reflect"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gtag"
+)
+
+// Extension is the MessagePack extension value of application specific type.
+// The timestamp extension of type -1 is decoded as time.Time but not Extension.
+type Extension struct {
+	Type int8   // Type is the extension type, in which the negative types are reserved by the specification.
+	Data []byte // Data is the raw data of the extension.
+}
+
+// Encode encodes `value` to MessagePack format content as bytes.
+//
+// The struct attributes are named in the same way of gconv, in which the `msgpack` tag has the
+// most priority and the tags of gconv.StructTagPriority are then checked. The time.Time values are
+// encoded using the timestamp extension type.
+func Encode(value interface{}) (out []byte, err error) {
+	encoder := &encoder{
+		buffer: make([]byte, 0, 64),
+	}
+	if err = encoder.encode(reflect.ValueOf(value), 0); err != nil {
+		return nil, err
+	}
+	return encoder.buffer, nil
+}
+
+// Decode parses MessagePack `content` and returns the decoded value.
+//
+// The maps are decoded as map[string]interface{}, and the arrays are decoded as []interface{}.
+// The integers are decoded as int64, or uint64 if they overflow int64.
+// The binary values are decoded as []byte, the timestamps are decoded as time.Time,
+// and the other extension values are decoded as *Extension.
+func Decode(content []byte) (interface{}, error) {
+	decoder := &decoder{
+		data: content,
+	}
+	value, err := decoder.decode(0)
+	if err != nil {
+		return nil, err
+	}
+	if decoder.offset != len(decoder.data) {
+		return nil, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`invalid msgpack content, %d extra bytes after the value`,
+			len(decoder.data)-decoder.offset,
+		)
+	}
+	return value, nil
+}
+
+// DecodeTo parses MessagePack `content` into `result`, which should be a pointer.
+// It uses the `msgpack` tag as the most priority tag for struct converting.
+func DecodeTo(content []byte, result interface{}) (err error) {
+	reflectValue := reflect.ValueOf(result)
+	if reflectValue.Kind() != reflect.Ptr || reflectValue.IsNil() {
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`destination should be a non-nil pointer, but got "%T"`,
+			result,
+		)
+	}
+	value, err := Decode(content)
+	if err != nil {
+		return err
+	}
+	var (
+		elem     = reflectValue.Elem()
+		elemType = elem.Type()
+	)
+	if value == nil {
+		elem.Set(reflect.Zero(elemType))
+		return nil
+	}
+	if valueReflectValue := reflect.ValueOf(value); valueReflectValue.Type().AssignableTo(elemType) {
+		elem.Set(valueReflectValue)
+		return nil
+	}
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	switch elemType.Kind() {
+	case reflect.Struct:
+		return gconv.StructTag(value, result, gtag.MsgPack)
+
+	case reflect.Slice, reflect.Array:
+		itemType := elemType.Elem()
+		for itemType.Kind() == reflect.Ptr {
+			itemType = itemType.Elem()
+		}
+		if itemType.Kind() == reflect.Struct {
+			return gconv.StructsTag(value, result, gtag.MsgPack)
+		}
+		return gconv.Scan(value, result)
+
+	case reflect.Map:
+		return gconv.Scan(value, result)
+
+	default:
+		converted := reflect.ValueOf(gconv.Convert(value, elem.Type().String()))
+		if !converted.IsValid() || !converted.Type().AssignableTo(elem.Type()) {
+			return gerror.NewCodef(
+				gcode.CodeInvalidParameter,
+				`cannot decode msgpack value of type "%T" into "%s"`,
+				value, elem.Type().String(),
+			)
+		}
+		elem.Set(converted)
+	}
+	return nil
+}
+
+// ToJson converts MessagePack `content` to JSON format content.
+// Note that the binary values are converted to base64 strings in JSON.
+func ToJson(content []byte) (out []byte, err error) {
+	var result interface{}
+	if result, err = Decode(content); err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
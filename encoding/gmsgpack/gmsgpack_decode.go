@@ -0,0 +1,251 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmsgpack
+
+import (
+	"math"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+type decoder struct {
+	data   []byte
+	offset int
+}
+
+func (d *decoder) decode(depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `msgpack decoding exceeds the max depth %d`, maxDepth)
+	}
+	code, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case code <= 0x7f:
+		return int64(code), nil
+	case code >= 0xe0:
+		return int64(int8(code)), nil
+	case code&0xf0 == 0x80:
+		return d.decodeMap(int(code&0x0f), depth)
+	case code&0xf0 == 0x90:
+		return d.decodeArray(int(code&0x0f), depth)
+	case code&0xe0 == 0xa0:
+		return d.readString(int(code & 0x1f))
+	}
+	switch code {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+
+	case 0xc4, 0xc5, 0xc6:
+		length, err := d.readLength(code - 0xc4)
+		if err != nil {
+			return nil, err
+		}
+		data, err := d.read(length)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), data...), nil
+
+	case 0xc7, 0xc8, 0xc9:
+		length, err := d.readLength(code - 0xc7)
+		if err != nil {
+			return nil, err
+		}
+		return d.readExtension(length)
+
+	case 0xca:
+		v, err := d.readUint(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(uint32(v))), nil
+
+	case 0xcb:
+		v, err := d.readUint(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(v), nil
+
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		v, err := d.readUint(1 << (code - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+		if v > math.MaxInt64 {
+			return v, nil
+		}
+		return int64(v), nil
+
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (code - 0xd0)
+		v, err := d.readUint(size)
+		if err != nil {
+			return nil, err
+		}
+		// Sign extension from the highest bit of the value.
+		shift := 64 - uint(size)*8
+		return int64(v<<shift) >> shift, nil
+
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return d.readExtension(1 << (code - 0xd4))
+
+	case 0xd9, 0xda, 0xdb:
+		length, err := d.readLength(code - 0xd9)
+		if err != nil {
+			return nil, err
+		}
+		return d.readString(length)
+
+	case 0xdc, 0xdd:
+		length, err := d.readLength(code - 0xdc + 1)
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(length, depth)
+
+	case 0xde, 0xdf:
+		length, err := d.readLength(code - 0xde + 1)
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeMap(length, depth)
+	}
+	return nil, gerror.NewCodef(
+		gcode.CodeInvalidParameter,
+		`invalid msgpack content, unknown format code 0x%x at offset %d`,
+		code, d.offset-1,
+	)
+}
+
+func (d *decoder) decodeArray(length int, depth int) (interface{}, error) {
+	// Each element takes at least one byte, which avoids allocating huge memory for invalid length.
+	if length > len(d.data)-d.offset {
+		return nil, d.errorTruncated()
+	}
+	array := make([]interface{}, length)
+	for i := range array {
+		v, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		array[i] = v
+	}
+	return array, nil
+}
+
+func (d *decoder) decodeMap(length int, depth int) (interface{}, error) {
+	if length > (len(d.data)-d.offset)/2 {
+		return nil, d.errorTruncated()
+	}
+	m := make(map[string]interface{}, length)
+	for i := 0; i < length; i++ {
+		key, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		value, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if s, ok := key.(string); ok {
+			m[s] = value
+		} else {
+			m[gconv.String(key)] = value
+		}
+	}
+	return m, nil
+}
+
+func (d *decoder) readExtension(length int) (interface{}, error) {
+	extType, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	data, err := d.read(length)
+	if err != nil {
+		return nil, err
+	}
+	if int8(extType) != timestampType {
+		return &Extension{
+			Type: int8(extType),
+			Data: append([]byte(nil), data...),
+		}, nil
+	}
+	switch length {
+	case 4:
+		return time.Unix(int64(bytesToUint(data)), 0), nil
+	case 8:
+		v := bytesToUint(data)
+		return time.Unix(int64(v&(1<<34-1)), int64(v>>34)), nil
+	case 12:
+		return time.Unix(int64(bytesToUint(data[4:])), int64(bytesToUint(data[:4]))), nil
+	}
+	return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `invalid msgpack timestamp length %d`, length)
+}
+
+func (d *decoder) readString(length int) (interface{}, error) {
+	data, err := d.read(length)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// readLength reads the length of 1, 2 or 4 bytes, which is specified by `sizeLog` of 0, 1 or 2.
+func (d *decoder) readLength(sizeLog byte) (int, error) {
+	v, err := d.readUint(1 << sizeLog)
+	if err != nil {
+		return 0, err
+	}
+	return int(v), nil
+}
+
+func (d *decoder) readUint(size int) (uint64, error) {
+	data, err := d.read(size)
+	if err != nil {
+		return 0, err
+	}
+	return bytesToUint(data), nil
+}
+
+func (d *decoder) readByte() (byte, error) {
+	if d.offset >= len(d.data) {
+		return 0, d.errorTruncated()
+	}
+	d.offset++
+	return d.data[d.offset-1], nil
+}
+
+func (d *decoder) read(size int) ([]byte, error) {
+	if size > len(d.data)-d.offset {
+		return nil, d.errorTruncated()
+	}
+	d.offset += size
+	return d.data[d.offset-size : d.offset], nil
+}
+
+func (d *decoder) errorTruncated() error {
+	return gerror.NewCode(gcode.CodeInvalidParameter, `invalid msgpack content, unexpected end of data`)
+}
+
+func bytesToUint(data []byte) uint64 {
+	var v uint64
+	for _, b := range data {
+		v = v<<8 | uint64(b)
+	}
+	return v
+}
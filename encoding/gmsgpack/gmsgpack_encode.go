@@ -0,0 +1,493 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmsgpack
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/util/gtag"
+)
+
+const (
+	// maxDepth limits the nesting depth of encoding and decoding,
+	// which also avoids endless recursion for cyclic pointers.
+	maxDepth = 10000
+
+	// timestampType is the extension type of timestamp defined by the specification.
+	timestampType int8 = -1
+)
+
+var (
+	// structFieldsCache caches the encoding fields of struct types, type: map[reflect.Type][]structField.
+	structFieldsCache sync.Map
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// iVal is used for type assert api for Val(), which is implemented by gvar.Var.
+type iVal interface {
+	Val() interface{}
+}
+
+// structField is the encoding field of struct.
+type structField struct {
+	index     []int  // Index sequence for reflect.Value.FieldByIndex.
+	name      string // Encoded name of the field.
+	omitEmpty bool   // Whether the field is omitted if it is empty.
+}
+
+type encoder struct {
+	buffer []byte
+}
+
+func (e *encoder) encode(value reflect.Value, depth int) error {
+	if depth > maxDepth {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `msgpack encoding exceeds the max depth %d`, maxDepth)
+	}
+	if !value.IsValid() {
+		e.writeNil()
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			e.writeNil()
+			return nil
+		}
+	}
+	if value.CanInterface() {
+		switch v := value.Interface().(type) {
+		case time.Time:
+			e.writeTime(v)
+			return nil
+		case *gtime.Time:
+			e.writeTime(v.Time)
+			return nil
+		case gtime.Time:
+			e.writeTime(v.Time)
+			return nil
+		case Extension:
+			e.writeExtension(v.Type, v.Data)
+			return nil
+		case *Extension:
+			e.writeExtension(v.Type, v.Data)
+			return nil
+		case json.Number:
+			e.writeNumber(v)
+			return nil
+		case iVal:
+			return e.encode(reflect.ValueOf(v.Val()), depth+1)
+		}
+	}
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return e.encode(value.Elem(), depth+1)
+
+	case reflect.Bool:
+		if value.Bool() {
+			e.buffer = append(e.buffer, 0xc3)
+		} else {
+			e.buffer = append(e.buffer, 0xc2)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.writeInt(value.Int())
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.writeUint(value.Uint())
+
+	case reflect.Float32:
+		e.buffer = append(e.buffer, 0xca)
+		e.buffer = appendUint32(e.buffer, math.Float32bits(float32(value.Float())))
+
+	case reflect.Float64:
+		e.buffer = append(e.buffer, 0xcb)
+		e.buffer = appendUint64(e.buffer, math.Float64bits(value.Float()))
+
+	case reflect.String:
+		e.writeString(value.String())
+
+	case reflect.Slice:
+		if value.IsNil() {
+			e.writeNil()
+			return nil
+		}
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			e.writeBinary(value.Bytes())
+			return nil
+		}
+		return e.encodeArray(value, depth)
+
+	case reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, value.Len())
+			reflect.Copy(reflect.ValueOf(data), value)
+			e.writeBinary(data)
+			return nil
+		}
+		return e.encodeArray(value, depth)
+
+	case reflect.Map:
+		if value.IsNil() {
+			e.writeNil()
+			return nil
+		}
+		return e.encodeMap(value, depth)
+
+	case reflect.Struct:
+		return e.encodeStruct(value, depth)
+
+	default:
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`unsupported type "%s" for msgpack encoding`,
+			value.Type().String(),
+		)
+	}
+	return nil
+}
+
+func (e *encoder) encodeArray(value reflect.Value, depth int) error {
+	length := value.Len()
+	e.writeArrayHeader(length)
+	for i := 0; i < length; i++ {
+		if err := e.encode(value.Index(i), depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *encoder) encodeMap(value reflect.Value, depth int) error {
+	keys := value.MapKeys()
+	// The string keys are sorted for stable output.
+	if value.Type().Key().Kind() == reflect.String {
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i].String() < keys[j].String()
+		})
+	}
+	e.writeMapHeader(len(keys))
+	for _, key := range keys {
+		if err := e.encode(key, depth+1); err != nil {
+			return err
+		}
+		if err := e.encode(value.MapIndex(key), depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *encoder) encodeStruct(value reflect.Value, depth int) error {
+	var (
+		fields = getStructFields(value.Type())
+		values = make([]reflect.Value, 0, len(fields))
+		names  = make([]string, 0, len(fields))
+	)
+	for _, field := range fields {
+		fieldValue, ok := fieldByIndex(value, field.index)
+		if !ok || (field.omitEmpty && isEmptyValue(fieldValue)) {
+			continue
+		}
+		values = append(values, fieldValue)
+		names = append(names, field.name)
+	}
+	e.writeMapHeader(len(values))
+	for i, fieldValue := range values {
+		e.writeString(names[i])
+		if err := e.encode(fieldValue, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *encoder) writeNil() {
+	e.buffer = append(e.buffer, 0xc0)
+}
+
+func (e *encoder) writeInt(v int64) {
+	switch {
+	case v >= 0:
+		e.writeUint(uint64(v))
+	case v >= -32:
+		e.buffer = append(e.buffer, byte(v))
+	case v >= math.MinInt8:
+		e.buffer = append(e.buffer, 0xd0, byte(v))
+	case v >= math.MinInt16:
+		e.buffer = append(e.buffer, 0xd1)
+		e.buffer = appendUint16(e.buffer, uint16(v))
+	case v >= math.MinInt32:
+		e.buffer = append(e.buffer, 0xd2)
+		e.buffer = appendUint32(e.buffer, uint32(v))
+	default:
+		e.buffer = append(e.buffer, 0xd3)
+		e.buffer = appendUint64(e.buffer, uint64(v))
+	}
+}
+
+func (e *encoder) writeUint(v uint64) {
+	switch {
+	case v <= 0x7f:
+		e.buffer = append(e.buffer, byte(v))
+	case v <= math.MaxUint8:
+		e.buffer = append(e.buffer, 0xcc, byte(v))
+	case v <= math.MaxUint16:
+		e.buffer = append(e.buffer, 0xcd)
+		e.buffer = appendUint16(e.buffer, uint16(v))
+	case v <= math.MaxUint32:
+		e.buffer = append(e.buffer, 0xce)
+		e.buffer = appendUint32(e.buffer, uint32(v))
+	default:
+		e.buffer = append(e.buffer, 0xcf)
+		e.buffer = appendUint64(e.buffer, v)
+	}
+}
+
+// writeNumber writes json.Number as integer if possible, or else as float.
+func (e *encoder) writeNumber(v json.Number) {
+	if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+		e.writeInt(i)
+	} else if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+		e.writeUint(u)
+	} else if f, err := strconv.ParseFloat(string(v), 64); err == nil {
+		e.buffer = append(e.buffer, 0xcb)
+		e.buffer = appendUint64(e.buffer, math.Float64bits(f))
+	} else {
+		e.writeString(string(v))
+	}
+}
+
+func (e *encoder) writeString(v string) {
+	length := len(v)
+	switch {
+	case length <= 31:
+		e.buffer = append(e.buffer, 0xa0|byte(length))
+	case length <= math.MaxUint8:
+		e.buffer = append(e.buffer, 0xd9, byte(length))
+	case length <= math.MaxUint16:
+		e.buffer = append(e.buffer, 0xda)
+		e.buffer = appendUint16(e.buffer, uint16(length))
+	default:
+		e.buffer = append(e.buffer, 0xdb)
+		e.buffer = appendUint32(e.buffer, uint32(length))
+	}
+	e.buffer = append(e.buffer, v...)
+}
+
+func (e *encoder) writeBinary(v []byte) {
+	length := len(v)
+	switch {
+	case length <= math.MaxUint8:
+		e.buffer = append(e.buffer, 0xc4, byte(length))
+	case length <= math.MaxUint16:
+		e.buffer = append(e.buffer, 0xc5)
+		e.buffer = appendUint16(e.buffer, uint16(length))
+	default:
+		e.buffer = append(e.buffer, 0xc6)
+		e.buffer = appendUint32(e.buffer, uint32(length))
+	}
+	e.buffer = append(e.buffer, v...)
+}
+
+func (e *encoder) writeArrayHeader(length int) {
+	switch {
+	case length <= 15:
+		e.buffer = append(e.buffer, 0x90|byte(length))
+	case length <= math.MaxUint16:
+		e.buffer = append(e.buffer, 0xdc)
+		e.buffer = appendUint16(e.buffer, uint16(length))
+	default:
+		e.buffer = append(e.buffer, 0xdd)
+		e.buffer = appendUint32(e.buffer, uint32(length))
+	}
+}
+
+func (e *encoder) writeMapHeader(length int) {
+	switch {
+	case length <= 15:
+		e.buffer = append(e.buffer, 0x80|byte(length))
+	case length <= math.MaxUint16:
+		e.buffer = append(e.buffer, 0xde)
+		e.buffer = appendUint16(e.buffer, uint16(length))
+	default:
+		e.buffer = append(e.buffer, 0xdf)
+		e.buffer = appendUint32(e.buffer, uint32(length))
+	}
+}
+
+func (e *encoder) writeExtension(extType int8, data []byte) {
+	length := len(data)
+	switch length {
+	case 1:
+		e.buffer = append(e.buffer, 0xd4)
+	case 2:
+		e.buffer = append(e.buffer, 0xd5)
+	case 4:
+		e.buffer = append(e.buffer, 0xd6)
+	case 8:
+		e.buffer = append(e.buffer, 0xd7)
+	case 16:
+		e.buffer = append(e.buffer, 0xd8)
+	default:
+		switch {
+		case length <= math.MaxUint8:
+			e.buffer = append(e.buffer, 0xc7, byte(length))
+		case length <= math.MaxUint16:
+			e.buffer = append(e.buffer, 0xc8)
+			e.buffer = appendUint16(e.buffer, uint16(length))
+		default:
+			e.buffer = append(e.buffer, 0xc9)
+			e.buffer = appendUint32(e.buffer, uint32(length))
+		}
+	}
+	e.buffer = append(e.buffer, byte(extType))
+	e.buffer = append(e.buffer, data...)
+}
+
+// writeTime writes time using the smallest one of timestamp 32, 64 and 96 formats.
+func (e *encoder) writeTime(t time.Time) {
+	var (
+		sec  = t.Unix()
+		nsec = int64(t.Nanosecond())
+	)
+	switch {
+	case sec>>32 == 0 && nsec == 0:
+		e.writeExtension(timestampType, appendUint32(nil, uint32(sec)))
+	case sec >= 0 && sec>>34 == 0:
+		e.writeExtension(timestampType, appendUint64(nil, uint64(nsec)<<34|uint64(sec)))
+	default:
+		data := appendUint32(make([]byte, 0, 12), uint32(nsec))
+		e.writeExtension(timestampType, appendUint64(data, uint64(sec)))
+	}
+}
+
+// getStructFields retrieves and caches the encoding fields of struct type `structType`.
+func getStructFields(structType reflect.Type) []structField {
+	if v, ok := structFieldsCache.Load(structType); ok {
+		return v.([]structField)
+	}
+	var (
+		fields = make([]structField, 0, structType.NumField())
+		names  = make(map[string]struct{})
+	)
+	appendStructFields(structType, nil, &fields, names)
+	structFieldsCache.Store(structType, fields)
+	return fields
+}
+
+func appendStructFields(structType reflect.Type, index []int, fields *[]structField, names map[string]struct{}) {
+	for i := 0; i < structType.NumField(); i++ {
+		var (
+			field      = structType.Field(i)
+			fieldIndex = append(append(make([]int, 0, len(index)+1), index...), i)
+			name, opts = getFieldTag(field)
+		)
+		if name == "-" {
+			continue
+		}
+		// The embedded struct without name tag is flattened like encoding/json.
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct && fieldType != timeType {
+				appendStructFields(fieldType, fieldIndex, fields, names)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		// The field of outer struct has priority over the embedded one with the same name.
+		if _, ok := names[name]; ok {
+			continue
+		}
+		names[name] = struct{}{}
+		*fields = append(*fields, structField{
+			index:     fieldIndex,
+			name:      name,
+			omitEmpty: strings.Contains(opts, "omitempty"),
+		})
+	}
+}
+
+// getFieldTag retrieves the encoded name and options of struct field,
+// in which the `msgpack` tag is checked first and then tags of gtag.StructTagPriority.
+func getFieldTag(field reflect.StructField) (name, opts string) {
+	for _, tagName := range append([]string{gtag.MsgPack}, gtag.StructTagPriority...) {
+		tag, ok := field.Tag.Lookup(tagName)
+		if !ok || tag == "" {
+			continue
+		}
+		if pos := strings.IndexByte(tag, ','); pos != -1 {
+			return strings.TrimSpace(tag[:pos]), tag[pos+1:]
+		}
+		return strings.TrimSpace(tag), ""
+	}
+	return "", ""
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but it returns false if it meets nil embedded pointer.
+func fieldByIndex(value reflect.Value, index []int) (reflect.Value, bool) {
+	for i, n := range index {
+		if i > 0 && value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return reflect.Value{}, false
+			}
+			value = value.Elem()
+		}
+		value = value.Field(n)
+	}
+	return value, true
+}
+
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Bool:
+		return !value.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return value.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return value.IsNil()
+	}
+	return false
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	return append(
+		b, byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32),
+		byte(v>>24), byte(v>>16), byte(v>>8), byte(v),
+	)
+}
@@ -0,0 +1,222 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmsgpack_test
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gmsgpack"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Encode(t *testing.T) {
+	// Vectors of the MessagePack specification.
+	gtest.C(t, func(t *gtest.T) {
+		var vectors = []struct {
+			value interface{}
+			data  []byte
+		}{
+			{nil, []byte{0xc0}},
+			{false, []byte{0xc2}},
+			{true, []byte{0xc3}},
+			{0, []byte{0x00}},
+			{127, []byte{0x7f}},
+			{128, []byte{0xcc, 0x80}},
+			{256, []byte{0xcd, 0x01, 0x00}},
+			{65536, []byte{0xce, 0x00, 0x01, 0x00, 0x00}},
+			{uint64(math.MaxUint64), []byte{0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+			{-1, []byte{0xff}},
+			{-32, []byte{0xe0}},
+			{-33, []byte{0xd0, 0xdf}},
+			{-129, []byte{0xd1, 0xff, 0x7f}},
+			{-32769, []byte{0xd2, 0xff, 0xff, 0x7f, 0xff}},
+			{int64(math.MinInt64), []byte{0xd3, 0x80, 0, 0, 0, 0, 0, 0, 0}},
+			{float32(1.5), []byte{0xca, 0x3f, 0xc0, 0x00, 0x00}},
+			{1.5, []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+			{"", []byte{0xa0}},
+			{"abc", []byte{0xa3, 'a', 'b', 'c'}},
+			{[]byte{1, 2}, []byte{0xc4, 0x02, 0x01, 0x02}},
+			{[]int{1, 2}, []byte{0x92, 0x01, 0x02}},
+			{map[string]int{"b": 2, "a": 1}, []byte{0x82, 0xa1, 'a', 0x01, 0xa1, 'b', 0x02}},
+			{time.Unix(1, 0), []byte{0xd6, 0xff, 0, 0, 0, 1}},
+			{time.Unix(1, 1), []byte{0xd7, 0xff, 0, 0, 0, 0x04, 0, 0, 0, 0x01}},
+			{time.Unix(-1, 0), []byte{0xc7, 12, 0xff, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+			{gmsgpack.Extension{Type: 1, Data: []byte{1, 2, 3}}, []byte{0xc7, 3, 0x01, 1, 2, 3}},
+		}
+		for _, v := range vectors {
+			data, err := gmsgpack.Encode(v.value)
+			t.AssertNil(err)
+			t.Assert(data, v.data)
+		}
+		data, err := gmsgpack.Encode(strings.Repeat("a", 32))
+		t.AssertNil(err)
+		t.Assert(data[:2], []byte{0xd9, 32})
+		data, err = gmsgpack.Encode(make([]int, 16))
+		t.AssertNil(err)
+		t.Assert(data[:3], []byte{0xdc, 0, 16})
+
+		_, err = gmsgpack.Encode(make(chan int))
+		t.AssertNE(err, nil)
+	})
+	// Struct attributes naming.
+	gtest.C(t, func(t *gtest.T) {
+		type Base struct {
+			Id int `json:"id"`
+		}
+		type User struct {
+			Base
+			Name     string `msgpack:"name" json:"nickname"`
+			Password string `json:"-"`
+			Email    string `p:"email,omitempty"`
+			Age      int
+			secret   string
+		}
+		data, err := gmsgpack.Encode(&User{Base: Base{Id: 1}, Name: "john", Password: "123", secret: "s"})
+		t.AssertNil(err)
+		result, err := gmsgpack.Decode(data)
+		t.AssertNil(err)
+		t.Assert(result, map[string]interface{}{
+			"id":   1,
+			"name": "john",
+			"Age":  0,
+		})
+	})
+}
+
+func Test_Decode(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			now = time.Now()
+			src = map[string]interface{}{
+				"nil":     nil,
+				"bool":    true,
+				"int":     -1000,
+				"uint":    uint64(math.MaxUint64),
+				"float":   1.25,
+				"string":  strings.Repeat("s", 70000),
+				"binary":  []byte{0, 1, 2},
+				"array":   []interface{}{1, "a", []interface{}{}},
+				"map":     map[int]string{1: "a"},
+				"time":    now,
+				"gtime":   gtime.New(now),
+				"ext":     &gmsgpack.Extension{Type: 10, Data: []byte{1}},
+				"pointer": &now,
+			}
+		)
+		data, err := gmsgpack.Encode(src)
+		t.AssertNil(err)
+		result, err := gmsgpack.Decode(data)
+		t.AssertNil(err)
+
+		m := result.(map[string]interface{})
+		t.Assert(m["nil"], nil)
+		t.Assert(m["bool"], true)
+		t.Assert(m["int"], int64(-1000))
+		t.Assert(m["uint"], uint64(math.MaxUint64))
+		t.Assert(m["float"], 1.25)
+		t.Assert(m["string"], src["string"])
+		t.Assert(m["binary"], []byte{0, 1, 2})
+		t.Assert(m["array"], []interface{}{1, "a", []interface{}{}})
+		t.Assert(m["map"], map[string]interface{}{"1": "a"})
+		t.Assert(m["time"].(time.Time).Equal(now), true)
+		t.Assert(m["gtime"].(time.Time).Equal(now), true)
+		t.Assert(m["ext"], &gmsgpack.Extension{Type: 10, Data: []byte{1}})
+		t.Assert(m["pointer"].(time.Time).Equal(now), true)
+	})
+	// Invalid content.
+	gtest.C(t, func(t *gtest.T) {
+		data, err := gmsgpack.Encode(map[string]interface{}{"a": []int{1, 2, 3}})
+		t.AssertNil(err)
+		for i := 0; i < len(data); i++ {
+			_, err = gmsgpack.Decode(data[:i])
+			t.AssertNE(err, nil)
+		}
+		_, err = gmsgpack.Decode(append(data, 0x00))
+		t.AssertNE(err, nil)
+		_, err = gmsgpack.Decode([]byte{0xc1})
+		t.AssertNE(err, nil)
+		_, err = gmsgpack.Decode([]byte{0xdd, 0xff, 0xff, 0xff, 0xff})
+		t.AssertNE(err, nil)
+		_, err = gmsgpack.Decode(bytes.Repeat([]byte{0x91}, 20000))
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_DecodeTo(t *testing.T) {
+	type Item struct {
+		Id   int
+		Tags []string
+	}
+	type Order struct {
+		Id      int64     `msgpack:"order_id"`
+		Items   []*Item   `json:"items"`
+		Amount  float64   `json:"amount"`
+		Created time.Time `json:"created"`
+		Data    []byte    `json:"data"`
+	}
+	gtest.C(t, func(t *gtest.T) {
+		src := Order{
+			Id:      1,
+			Items:   []*Item{{Id: 1, Tags: []string{"a", "b"}}, {Id: 2}},
+			Amount:  9.9,
+			Created: time.Unix(1700000000, 100),
+			Data:    []byte{0xff, 0x00},
+		}
+		data, err := gmsgpack.Encode(src)
+		t.AssertNil(err)
+
+		var order *Order
+		t.AssertNil(gmsgpack.DecodeTo(data, &order))
+		t.Assert(order.Id, 1)
+		t.Assert(len(order.Items), 2)
+		t.Assert(order.Items[0].Tags, []string{"a", "b"})
+		t.Assert(order.Amount, 9.9)
+		t.Assert(order.Created.Equal(src.Created), true)
+		t.Assert(order.Data, src.Data)
+
+		var m map[string]interface{}
+		t.AssertNil(gmsgpack.DecodeTo(data, &m))
+		t.Assert(m["order_id"], 1)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		data, err := gmsgpack.Encode([]map[string]interface{}{{"Id": 1}, {"Id": 2}})
+		t.AssertNil(err)
+		var items []Item
+		t.AssertNil(gmsgpack.DecodeTo(data, &items))
+		t.Assert(len(items), 2)
+		t.Assert(items[1].Id, 2)
+
+		data, err = gmsgpack.Encode(100)
+		t.AssertNil(err)
+		var i int
+		t.AssertNil(gmsgpack.DecodeTo(data, &i))
+		t.Assert(i, 100)
+
+		t.AssertNE(gmsgpack.DecodeTo(data, i), nil)
+	})
+}
+
+func Test_ToJson(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		data, err := gmsgpack.Encode(map[string]interface{}{
+			"name":  "john",
+			"score": []float64{1.5, 2},
+		})
+		t.AssertNil(err)
+		content, err := gmsgpack.ToJson(data)
+		t.AssertNil(err)
+		t.Assert(content, `{"name":"john","score":[1.5,2]}`)
+
+		_, err = gmsgpack.ToJson(data[1:])
+		t.AssertNE(err, nil)
+	})
+}
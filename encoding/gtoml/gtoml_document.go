@@ -0,0 +1,1064 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtoml
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Document is an editable TOML document, which keeps the comments, key order and
+// formatting of the original content when its values are modified.
+//
+// The modifications are applied to the original content in place, so that the untouched
+// lines are kept as they are. The new keys are appended to the end of their tables,
+// and the new tables are appended to the end of the document.
+//
+// The `pattern` parameter of its functions is the hierarchical key path separated by char '.',
+// in which the integer keys are used as indexes for arrays, like: "server.address", "servers.0.name".
+type Document struct {
+	content []byte
+	tables  []*documentTable
+	entries []*documentEntry
+}
+
+// documentTable is a table defined by header in the document, or the root table.
+type documentTable struct {
+	path      []string // Resolved key path, in which the indexes of array tables are included.
+	indexed   bool     // Whether the path contains indexes of array tables.
+	start     int      // Offset of the header line, which is 0 for the root table.
+	headerEnd int      // Offset after the header line.
+	end       int      // Offset of the next header line or the end of the document.
+}
+
+// documentEntry is a key/value pair defined in the document.
+type documentEntry struct {
+	table      *documentTable
+	keys       []string // Full key path including the path of table.
+	start      int      // Offset of the line of the key.
+	valueStart int      // Offset of the value.
+	valueEnd   int      // Offset after the value.
+	end        int      // Offset after the line of the value, including its comment.
+}
+
+// LoadDocument parses `content` and returns an editable TOML document.
+func LoadDocument(content []byte) (*Document, error) {
+	d := &Document{}
+	if err := d.load(content); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Bytes returns the current content of the document.
+func (d *Document) Bytes() []byte {
+	return d.content
+}
+
+// String returns the current content of the document as string.
+func (d *Document) String() string {
+	return string(d.content)
+}
+
+// Contains checks whether the value of `pattern` exists in the document.
+func (d *Document) Contains(pattern string) bool {
+	_, ok := d.get(splitDocumentPattern(pattern))
+	return ok
+}
+
+// Get returns the value of `pattern`, or nil if it does not exist.
+func (d *Document) Get(pattern string) interface{} {
+	value, _ := d.get(splitDocumentPattern(pattern))
+	return value
+}
+
+// Map returns the whole document as map.
+func (d *Document) Map() map[string]interface{} {
+	var m map[string]interface{}
+	_ = toml.Unmarshal(d.content, &m)
+	return m
+}
+
+// Set sets the value of `pattern` to `value`, which creates the parent tables if they do not exist.
+//
+// If the value of an existing table is set with map, the keys of the table are set one by one,
+// which keeps the comments and order of the existing keys.
+func (d *Document) Set(pattern string, value interface{}) error {
+	keys := splitDocumentPattern(pattern)
+	if len(keys) == 0 {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `pattern should not be empty`)
+	}
+	normalized, err := normalizeDocumentValue(value)
+	if err != nil {
+		return err
+	}
+	return d.set(keys, normalized)
+}
+
+// Remove deletes the value of `pattern` from the document along with its comments.
+// It does nothing if `pattern` does not exist.
+func (d *Document) Remove(pattern string) error {
+	keys := splitDocumentPattern(pattern)
+	if len(keys) == 0 {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `pattern should not be empty`)
+	}
+	return d.remove(keys)
+}
+
+func (d *Document) load(content []byte) error {
+	var m map[string]interface{}
+	if err := toml.Unmarshal(content, &m); err != nil {
+		return gerror.Wrap(err, `toml.Unmarshal failed`)
+	}
+	scanner := &documentScanner{data: content}
+	tables, entries, err := scanner.scanDocument()
+	if err != nil {
+		return err
+	}
+	d.content, d.tables, d.entries = content, tables, entries
+	return nil
+}
+
+// update replaces the content of [start, end) with `replacement` and reloads the document.
+// The document is not changed if the result is not valid TOML content.
+func (d *Document) update(start, end int, replacement []byte) error {
+	content := make([]byte, 0, len(d.content)+len(replacement))
+	content = append(content, d.content[:start]...)
+	content = append(content, replacement...)
+	content = append(content, d.content[end:]...)
+	return d.load(content)
+}
+
+func (d *Document) get(keys []string) (interface{}, bool) {
+	return getDocumentValueIn(d.Map(), keys)
+}
+
+// getDocumentValueIn retrieves the value of `keys` in `value`.
+func getDocumentValueIn(value interface{}, keys []string) (interface{}, bool) {
+	for _, key := range keys {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if value, ok = v[key]; !ok {
+				return nil, false
+			}
+
+		case []map[string]interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, false
+			}
+			value = v[index]
+
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, false
+			}
+			value = v[index]
+
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func (d *Document) set(keys []string, value interface{}) error {
+	// The value of existing key is replaced in place.
+	if entry := d.findEntry(keys); entry != nil {
+		rendered, err := renderDocumentValue(value, d.content[entry.valueStart:entry.valueEnd])
+		if err != nil {
+			return err
+		}
+		return d.update(entry.valueStart, entry.valueEnd, rendered)
+	}
+	// The value is in the inline table or array of an existing key.
+	if entry := d.findParentEntry(keys); entry != nil {
+		current, _ := d.get(entry.keys)
+		modified, err := setDocumentValueIn(current, keys[len(entry.keys):], value)
+		if err != nil {
+			return err
+		}
+		rendered, err := renderDocumentValue(modified, d.content[entry.valueStart:entry.valueEnd])
+		if err != nil {
+			return err
+		}
+		return d.update(entry.valueStart, entry.valueEnd, rendered)
+	}
+	// The existing table is set key by key.
+	if current, ok := d.get(keys); ok {
+		if currentMap, ok := current.(map[string]interface{}); ok {
+			if valueMap, ok := value.(map[string]interface{}); ok {
+				for _, key := range sortedDocumentKeys(valueMap) {
+					if err := d.set(appendDocumentKeys(keys, key), valueMap[key]); err != nil {
+						return err
+					}
+				}
+				for _, key := range sortedDocumentKeys(currentMap) {
+					if _, ok = valueMap[key]; !ok {
+						if err := d.remove(appendDocumentKeys(keys, key)); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			}
+		}
+		if err := d.remove(keys); err != nil {
+			return err
+		}
+	}
+	return d.insert(keys, value)
+}
+
+// insert inserts the new key `keys` with `value` into the document.
+func (d *Document) insert(keys []string, value interface{}) error {
+	var (
+		table     = d.findTable(keys[:len(keys)-1])
+		relative  = keys[len(table.path):]
+		_, isMap  = value.(map[string]interface{})
+		lastError error
+	)
+	// The new key in the existing table.
+	if len(relative) == 1 && (!isMap || table.indexed) {
+		return d.insertEntry(table, relative, value)
+	}
+	// The new table is appended to the end of the document,
+	// which is not available for the sub table of array table.
+	if !table.indexed {
+		var (
+			buffer = bytes.NewBuffer(nil)
+			path   = keys
+		)
+		if !isMap {
+			path = keys[:len(keys)-1]
+		}
+		if len(d.content) > 0 {
+			if d.content[len(d.content)-1] != '\n' {
+				buffer.WriteByte('\n')
+			}
+			buffer.WriteByte('\n')
+		}
+		buffer.WriteString("[" + renderDocumentKeys(path) + "]\n")
+		if isMap {
+			valueMap := value.(map[string]interface{})
+			for _, key := range sortedDocumentKeys(valueMap) {
+				rendered, err := renderDocumentValue(valueMap[key], nil)
+				if err != nil {
+					return err
+				}
+				buffer.WriteString(renderDocumentKeys([]string{key}) + " = ")
+				buffer.Write(rendered)
+				buffer.WriteByte('\n')
+			}
+		} else {
+			rendered, err := renderDocumentValue(value, nil)
+			if err != nil {
+				return err
+			}
+			buffer.WriteString(renderDocumentKeys(keys[len(keys)-1:]) + " = ")
+			buffer.Write(rendered)
+			buffer.WriteByte('\n')
+		}
+		if lastError = d.update(len(d.content), len(d.content), buffer.Bytes()); lastError == nil {
+			return nil
+		}
+	}
+	// The table might be defined by dotted keys, which cannot be defined again by header.
+	if err := d.insertEntry(table, relative, value); err != nil {
+		if lastError != nil {
+			return lastError
+		}
+		return err
+	}
+	return nil
+}
+
+// insertEntry inserts key/value line of `keys` relative to `table` at the end of `table`.
+func (d *Document) insertEntry(table *documentTable, keys []string, value interface{}) error {
+	rendered, err := renderDocumentValue(value, nil)
+	if err != nil {
+		return err
+	}
+	var (
+		position = -1
+		indent   []byte
+	)
+	for _, entry := range d.entries {
+		if entry.table == table {
+			position = entry.end
+			indent = d.content[entry.start : entry.start+documentLineIndent(d.content[entry.start:])]
+		}
+	}
+	if position == -1 {
+		if table.start == 0 && table.headerEnd == 0 {
+			// The root table without keys, it inserts the key before the comments of the first table.
+			position = documentHeadCommentStart(d.content, table.end)
+			for position > 0 && documentLineStart(d.content, position-1) < position-1 &&
+				len(bytes.TrimSpace(d.content[documentLineStart(d.content, position-1):position])) == 0 {
+				position = documentLineStart(d.content, position-1)
+			}
+		} else {
+			position = table.headerEnd
+		}
+	}
+	var buffer bytes.Buffer
+	if position > 0 && d.content[position-1] != '\n' {
+		buffer.WriteByte('\n')
+	}
+	buffer.Write(indent)
+	buffer.WriteString(renderDocumentKeys(keys) + " = ")
+	buffer.Write(rendered)
+	buffer.WriteByte('\n')
+	return d.update(position, position, buffer.Bytes())
+}
+
+func (d *Document) remove(keys []string) error {
+	if _, ok := d.get(keys); !ok {
+		return nil
+	}
+	// The value is in the inline table or array of an existing key.
+	if entry := d.findParentEntry(keys); entry != nil {
+		current, _ := d.get(entry.keys)
+		modified, err := removeDocumentValueIn(current, keys[len(entry.keys):])
+		if err != nil {
+			return err
+		}
+		rendered, err := renderDocumentValue(modified, d.content[entry.valueStart:entry.valueEnd])
+		if err != nil {
+			return err
+		}
+		return d.update(entry.valueStart, entry.valueEnd, rendered)
+	}
+	// It removes all the keys and tables under `keys`.
+	type documentSpan struct {
+		start, end int
+	}
+	var spans []documentSpan
+	for _, entry := range d.entries {
+		if hasDocumentKeysPrefix(entry.keys, keys) {
+			spans = append(spans, documentSpan{documentHeadCommentStart(d.content, entry.start), entry.end})
+		}
+	}
+	for _, table := range d.tables {
+		if table.headerEnd > 0 && hasDocumentKeysPrefix(table.path, keys) {
+			var (
+				start = documentHeadCommentStart(d.content, table.start)
+				end   = documentTrimEnd(d.content, table.headerEnd, table.end)
+			)
+			// It also removes the blank line after the table if there's one before it.
+			if end < len(d.content) && (start == 0 || documentIsBlankLine(d.content, documentLineStart(d.content, start-1))) &&
+				documentIsBlankLine(d.content, end) {
+				end = documentLineEnd(d.content, end)
+			}
+			spans = append(spans, documentSpan{start, end})
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start < spans[j].start
+	})
+	var (
+		content = make([]byte, 0, len(d.content))
+		offset  = 0
+	)
+	for _, span := range spans {
+		if span.start < offset {
+			span.start = offset
+		}
+		if span.end > span.start {
+			content = append(content, d.content[offset:span.start]...)
+			offset = span.end
+		}
+	}
+	content = append(content, d.content[offset:]...)
+	// The blank lines left at the end of the document by the removed tables are also removed.
+	if !bytes.HasSuffix(d.content, []byte("\n\n")) {
+		for bytes.HasSuffix(content, []byte("\n\n")) {
+			content = content[:len(content)-1]
+		}
+	}
+	return d.load(content)
+}
+
+func (d *Document) findEntry(keys []string) *documentEntry {
+	for _, entry := range d.entries {
+		if equalDocumentKeys(entry.keys, keys) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// findParentEntry returns the entry whose value contains `keys`.
+func (d *Document) findParentEntry(keys []string) *documentEntry {
+	for _, entry := range d.entries {
+		if len(entry.keys) < len(keys) && hasDocumentKeysPrefix(keys, entry.keys) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// findTable returns the deepest table defined by header that contains `keys`, or the root table.
+func (d *Document) findTable(keys []string) *documentTable {
+	result := d.tables[0]
+	for _, table := range d.tables {
+		if len(table.path) > len(result.path) && hasDocumentKeysPrefix(keys, table.path) {
+			result = table
+		}
+	}
+	return result
+}
+
+// documentScanner scans the structure of TOML content, which is already validated.
+type documentScanner struct {
+	data []byte
+	pos  int
+}
+
+func (s *documentScanner) scanDocument() (tables []*documentTable, entries []*documentEntry, err error) {
+	var (
+		table       = &documentTable{}
+		arrayCounts = make(map[string]int)
+	)
+	tables = append(tables, table)
+	for {
+		s.skipSpacesAndNewlines()
+		if s.pos >= len(s.data) {
+			break
+		}
+		lineStart := documentLineStart(s.data, s.pos)
+		switch s.data[s.pos] {
+		case '#':
+			s.skipLine()
+
+		case '[':
+			isArray := s.pos+1 < len(s.data) && s.data[s.pos+1] == '['
+			if isArray {
+				s.pos += 2
+			} else {
+				s.pos++
+			}
+			var keys []string
+			if keys, err = s.scanKeys(); err != nil {
+				return
+			}
+			s.skipSpaces()
+			if isArray {
+				s.pos += 2
+			} else {
+				s.pos++
+			}
+			s.skipLine()
+			table.end = lineStart
+			table = &documentTable{
+				start:     lineStart,
+				headerEnd: s.pos,
+				end:       len(s.data),
+			}
+			table.path, table.indexed = resolveDocumentTablePath(keys, isArray, arrayCounts)
+			tables = append(tables, table)
+
+		default:
+			entry := &documentEntry{
+				table: table,
+				start: lineStart,
+			}
+			var keys []string
+			if keys, err = s.scanKeys(); err != nil {
+				return
+			}
+			entry.keys = appendDocumentKeys(table.path, keys...)
+			s.skipSpaces()
+			if s.pos >= len(s.data) || s.data[s.pos] != '=' {
+				return nil, nil, s.error(`expecting "="`)
+			}
+			s.pos++
+			s.skipSpaces()
+			entry.valueStart = s.pos
+			if err = s.skipValue(); err != nil {
+				return
+			}
+			entry.valueEnd = s.pos
+			s.skipLine()
+			entry.end = s.pos
+			entries = append(entries, entry)
+		}
+	}
+	if table.end == 0 {
+		table.end = len(s.data)
+	}
+	return
+}
+
+// scanKeys scans the dotted keys like: a."b.c".'d'.
+func (s *documentScanner) scanKeys() ([]string, error) {
+	var keys []string
+	for {
+		s.skipSpaces()
+		if s.pos >= len(s.data) {
+			return nil, s.error(`unexpected end of key`)
+		}
+		switch s.data[s.pos] {
+		case '"':
+			start := s.pos
+			if err := s.skipBasicString(); err != nil {
+				return nil, err
+			}
+			key, err := strconv.Unquote(string(s.data[start:s.pos]))
+			if err != nil {
+				return nil, s.error(`invalid quoted key`)
+			}
+			keys = append(keys, key)
+
+		case '\'':
+			start := s.pos
+			if err := s.skipLiteralString(); err != nil {
+				return nil, err
+			}
+			keys = append(keys, string(s.data[start+1:s.pos-1]))
+
+		default:
+			start := s.pos
+			for s.pos < len(s.data) && isDocumentBareKeyChar(s.data[s.pos]) {
+				s.pos++
+			}
+			if s.pos == start {
+				return nil, s.error(`invalid key`)
+			}
+			keys = append(keys, string(s.data[start:s.pos]))
+		}
+		s.skipSpaces()
+		if s.pos >= len(s.data) || s.data[s.pos] != '.' {
+			return keys, nil
+		}
+		s.pos++
+	}
+}
+
+// skipValue skips the value at current position.
+func (s *documentScanner) skipValue() error {
+	if s.pos >= len(s.data) {
+		return s.error(`unexpected end of value`)
+	}
+	switch s.data[s.pos] {
+	case '"':
+		if bytes.HasPrefix(s.data[s.pos:], []byte(`"""`)) {
+			return s.skipMultilineString('"')
+		}
+		return s.skipBasicString()
+
+	case '\'':
+		if bytes.HasPrefix(s.data[s.pos:], []byte(`'''`)) {
+			return s.skipMultilineString('\'')
+		}
+		return s.skipLiteralString()
+
+	case '[':
+		s.pos++
+		for {
+			s.skipSpacesNewlinesAndComments()
+			if s.pos >= len(s.data) {
+				return s.error(`unexpected end of array`)
+			}
+			if s.data[s.pos] == ']' {
+				s.pos++
+				return nil
+			}
+			if err := s.skipValue(); err != nil {
+				return err
+			}
+			s.skipSpacesNewlinesAndComments()
+			if s.pos < len(s.data) && s.data[s.pos] == ',' {
+				s.pos++
+			}
+		}
+
+	case '{':
+		s.pos++
+		for {
+			s.skipSpaces()
+			if s.pos >= len(s.data) {
+				return s.error(`unexpected end of inline table`)
+			}
+			if s.data[s.pos] == '}' {
+				s.pos++
+				return nil
+			}
+			if _, err := s.scanKeys(); err != nil {
+				return err
+			}
+			s.skipSpaces()
+			if s.pos >= len(s.data) || s.data[s.pos] != '=' {
+				return s.error(`expecting "="`)
+			}
+			s.pos++
+			s.skipSpaces()
+			if err := s.skipValue(); err != nil {
+				return err
+			}
+			s.skipSpaces()
+			if s.pos < len(s.data) && s.data[s.pos] == ',' {
+				s.pos++
+			}
+		}
+
+	default:
+		start := s.pos
+		s.skipBareValue()
+		if s.pos == start {
+			return s.error(`invalid value`)
+		}
+		// The date time might be separated by space, like: 1979-05-27 07:32:00Z.
+		if s.pos-start == 10 && s.data[start+4] == '-' && s.pos+1 < len(s.data) &&
+			s.data[s.pos] == ' ' && s.data[s.pos+1] >= '0' && s.data[s.pos+1] <= '9' {
+			s.pos++
+			s.skipBareValue()
+		}
+		return nil
+	}
+}
+
+func (s *documentScanner) skipBareValue() {
+	for s.pos < len(s.data) && !bytes.ContainsRune([]byte(" \t\r\n,]}#"), rune(s.data[s.pos])) {
+		s.pos++
+	}
+}
+
+func (s *documentScanner) skipBasicString() error {
+	for s.pos++; s.pos < len(s.data); s.pos++ {
+		switch s.data[s.pos] {
+		case '\\':
+			s.pos++
+		case '"':
+			s.pos++
+			return nil
+		case '\n':
+			return s.error(`unexpected new line in string`)
+		}
+	}
+	return s.error(`unexpected end of string`)
+}
+
+func (s *documentScanner) skipLiteralString() error {
+	end := bytes.IndexAny(s.data[s.pos+1:], "'\n")
+	if end == -1 || s.data[s.pos+1+end] != '\'' {
+		return s.error(`unexpected end of string`)
+	}
+	s.pos += end + 2
+	return nil
+}
+
+// skipMultilineString skips the multi-line string with delimiter of three `quote` chars.
+func (s *documentScanner) skipMultilineString(quote byte) error {
+	for s.pos += 3; s.pos+2 < len(s.data); s.pos++ {
+		if quote == '"' && s.data[s.pos] == '\\' {
+			s.pos++
+			continue
+		}
+		if s.data[s.pos] == quote && s.data[s.pos+1] == quote && s.data[s.pos+2] == quote {
+			s.pos += 3
+			// There might be at most two quotes right before the delimiter.
+			for i := 0; i < 2 && s.pos < len(s.data) && s.data[s.pos] == quote; i++ {
+				s.pos++
+			}
+			return nil
+		}
+	}
+	return s.error(`unexpected end of multi-line string`)
+}
+
+func (s *documentScanner) skipSpaces() {
+	for s.pos < len(s.data) && (s.data[s.pos] == ' ' || s.data[s.pos] == '\t') {
+		s.pos++
+	}
+}
+
+func (s *documentScanner) skipSpacesAndNewlines() {
+	for s.pos < len(s.data) && bytes.IndexByte([]byte(" \t\r\n"), s.data[s.pos]) != -1 {
+		s.pos++
+	}
+}
+
+func (s *documentScanner) skipSpacesNewlinesAndComments() {
+	for {
+		s.skipSpacesAndNewlines()
+		if s.pos >= len(s.data) || s.data[s.pos] != '#' {
+			return
+		}
+		s.skipLine()
+	}
+}
+
+// skipLine skips the rest of current line including the new line char.
+func (s *documentScanner) skipLine() {
+	s.pos = documentLineEnd(s.data, s.pos)
+}
+
+func (s *documentScanner) error(message string) error {
+	return gerror.NewCodef(
+		gcode.CodeInvalidParameter,
+		`invalid toml content at line %d: %s`,
+		bytes.Count(s.data[:s.pos], []byte("\n"))+1, message,
+	)
+}
+
+// resolveDocumentTablePath resolves the path of table header `keys`,
+// in which the indexes of array tables are inserted after their keys.
+func resolveDocumentTablePath(keys []string, isArray bool, arrayCounts map[string]int) (path []string, indexed bool) {
+	for i, key := range keys {
+		path = append(path, key)
+		if i == len(keys)-1 && isArray {
+			arrayCounts[strings.Join(path, "\x00")]++
+		}
+		if count := arrayCounts[strings.Join(path, "\x00")]; count > 0 {
+			path = append(path, strconv.Itoa(count-1))
+			indexed = true
+		}
+	}
+	return
+}
+
+// normalizeDocumentValue converts `value` to the types of TOML decoding result,
+// which also applies the `toml` tags of struct.
+func normalizeDocumentValue(value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `toml does not support nil value`)
+	}
+	buffer := bytes.NewBuffer(nil)
+	if err := toml.NewEncoder(buffer).Encode(map[string]interface{}{"v": value}); err != nil {
+		return nil, gerror.Wrap(err, `toml.Encoder.Encode failed`)
+	}
+	var result map[string]interface{}
+	if err := toml.Unmarshal(buffer.Bytes(), &result); err != nil {
+		return nil, gerror.Wrap(err, `toml.Unmarshal failed`)
+	}
+	if v, ok := result["v"]; ok {
+		return v, nil
+	}
+	// The empty map is encoded as empty table.
+	return map[string]interface{}{}, nil
+}
+
+// renderDocumentValue renders `value` as inline TOML value. If `old` is a literal string,
+// the new string value is also rendered as literal string if possible.
+func renderDocumentValue(value interface{}, old []byte) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		if len(old) > 1 && old[0] == '\'' && old[1] != '\'' && !strings.ContainsAny(v, "'\r\n") {
+			return []byte("'" + v + "'"), nil
+		}
+		return []byte(quoteDocumentString(v)), nil
+
+	case bool:
+		return []byte(strconv.FormatBool(v)), nil
+
+	case int64:
+		return []byte(strconv.FormatInt(v, 10)), nil
+
+	case float64:
+		switch {
+		case math.IsNaN(v):
+			return []byte("nan"), nil
+		case math.IsInf(v, 1):
+			return []byte("inf"), nil
+		case math.IsInf(v, -1):
+			return []byte("-inf"), nil
+		}
+		s := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".eEn") {
+			s += ".0"
+		}
+		return []byte(s), nil
+
+	case time.Time:
+		switch v.Location().String() {
+		case "date-local":
+			return []byte(v.Format("2006-01-02")), nil
+		case "datetime-local":
+			return []byte(v.Format("2006-01-02T15:04:05.999999999")), nil
+		case "time-local":
+			return []byte(v.Format("15:04:05.999999999")), nil
+		}
+		return []byte(v.Format(time.RFC3339Nano)), nil
+
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return []byte("{}"), nil
+		}
+		buffer := bytes.NewBufferString("{ ")
+		for i, key := range sortedDocumentKeys(v) {
+			if i > 0 {
+				buffer.WriteString(", ")
+			}
+			rendered, err := renderDocumentValue(v[key], nil)
+			if err != nil {
+				return nil, err
+			}
+			buffer.WriteString(renderDocumentKeys([]string{key}) + " = ")
+			buffer.Write(rendered)
+		}
+		buffer.WriteString(" }")
+		return buffer.Bytes(), nil
+	}
+	reflectValue := reflect.ValueOf(value)
+	if reflectValue.Kind() == reflect.Slice {
+		buffer := bytes.NewBufferString("[")
+		for i := 0; i < reflectValue.Len(); i++ {
+			if i > 0 {
+				buffer.WriteString(", ")
+			}
+			rendered, err := renderDocumentValue(reflectValue.Index(i).Interface(), nil)
+			if err != nil {
+				return nil, err
+			}
+			buffer.Write(rendered)
+		}
+		buffer.WriteString("]")
+		return buffer.Bytes(), nil
+	}
+	return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `unsupported toml value type "%T"`, value)
+}
+
+// renderDocumentKeys renders `keys` as dotted keys, which quotes the keys that are not bare keys.
+func renderDocumentKeys(keys []string) string {
+	array := make([]string, len(keys))
+	for i, key := range keys {
+		array[i] = key
+		if key == "" {
+			array[i] = `""`
+			continue
+		}
+		for j := 0; j < len(key); j++ {
+			if !isDocumentBareKeyChar(key[j]) {
+				array[i] = quoteDocumentString(key)
+				break
+			}
+		}
+	}
+	return strings.Join(array, ".")
+}
+
+// quoteDocumentString quotes `s` as TOML basic string.
+func quoteDocumentString(s string) string {
+	var builder strings.Builder
+	builder.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			builder.WriteString(`\"`)
+		case '\\':
+			builder.WriteString(`\\`)
+		case '\b':
+			builder.WriteString(`\b`)
+		case '\t':
+			builder.WriteString(`\t`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\f':
+			builder.WriteString(`\f`)
+		case '\r':
+			builder.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				builder.WriteString(`\u` + strconv.FormatInt(int64(r)+0x10000, 16)[1:])
+			} else {
+				builder.WriteRune(r)
+			}
+		}
+	}
+	builder.WriteByte('"')
+	return builder.String()
+}
+
+// setDocumentValueIn sets `value` of `keys` in `current` value, and returns the modified value.
+func setDocumentValueIn(current interface{}, keys []string, value interface{}) (interface{}, error) {
+	if len(keys) == 0 {
+		return value, nil
+	}
+	switch v := current.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v)+1)
+		for key, item := range v {
+			m[key] = item
+		}
+		item, err := setDocumentValueIn(m[keys[0]], keys[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		m[keys[0]] = item
+		return m, nil
+
+	case []interface{}, []map[string]interface{}:
+		var (
+			reflectValue = reflect.ValueOf(v)
+			array        = make([]interface{}, reflectValue.Len())
+		)
+		for i := range array {
+			array[i] = reflectValue.Index(i).Interface()
+		}
+		index, err := strconv.Atoi(keys[0])
+		if err != nil || index < 0 || index > len(array) {
+			return nil, gerror.NewCodef(
+				gcode.CodeInvalidParameter,
+				`invalid index "%s" for array of length %d`,
+				keys[0], len(array),
+			)
+		}
+		if index == len(array) {
+			array = append(array, nil)
+		}
+		if array[index], err = setDocumentValueIn(array[index], keys[1:], value); err != nil {
+			return nil, err
+		}
+		return array, nil
+	}
+	return setDocumentValueIn(map[string]interface{}{}, keys, value)
+}
+
+// removeDocumentValueIn removes the value of `keys` from `current` value, and returns the modified value.
+func removeDocumentValueIn(current interface{}, keys []string) (interface{}, error) {
+	if len(keys) == 1 {
+		switch v := current.(type) {
+		case map[string]interface{}:
+			m := make(map[string]interface{}, len(v))
+			for key, item := range v {
+				if key != keys[0] {
+					m[key] = item
+				}
+			}
+			return m, nil
+
+		case []interface{}, []map[string]interface{}:
+			var (
+				reflectValue = reflect.ValueOf(v)
+				array        = make([]interface{}, 0, reflectValue.Len())
+			)
+			for i := 0; i < reflectValue.Len(); i++ {
+				if strconv.Itoa(i) != keys[0] {
+					array = append(array, reflectValue.Index(i).Interface())
+				}
+			}
+			return array, nil
+		}
+		return current, nil
+	}
+	child, ok := getDocumentValueIn(current, keys[:1])
+	if !ok {
+		return current, nil
+	}
+	modified, err := removeDocumentValueIn(child, keys[1:])
+	if err != nil {
+		return nil, err
+	}
+	return setDocumentValueIn(current, keys[:1], modified)
+}
+
+// documentHeadCommentStart returns the offset of the comment lines right above the line of `offset`.
+func documentHeadCommentStart(content []byte, offset int) int {
+	offset = documentLineStart(content, offset)
+	for offset > 0 {
+		previous := documentLineStart(content, offset-1)
+		if !bytes.HasPrefix(bytes.TrimSpace(content[previous:offset]), []byte("#")) {
+			break
+		}
+		offset = previous
+	}
+	return offset
+}
+
+// documentTrimEnd trims the trailing blank and comment lines in [start, end) of `content`,
+// and returns the new end offset.
+func documentTrimEnd(content []byte, start, end int) int {
+	for end > start {
+		previous := documentLineStart(content, end-1)
+		if previous < start {
+			break
+		}
+		if line := bytes.TrimSpace(content[previous:end]); len(line) > 0 && line[0] != '#' {
+			break
+		}
+		end = previous
+	}
+	return end
+}
+
+// documentLineStart returns the start offset of the line containing `offset`.
+func documentLineStart(content []byte, offset int) int {
+	return bytes.LastIndexByte(content[:offset], '\n') + 1
+}
+
+// documentLineEnd returns the offset after the new line char of the line containing `offset`.
+func documentLineEnd(content []byte, offset int) int {
+	if pos := bytes.IndexByte(content[offset:], '\n'); pos != -1 {
+		return offset + pos + 1
+	}
+	return len(content)
+}
+
+func documentIsBlankLine(content []byte, offset int) bool {
+	return len(bytes.TrimSpace(content[offset:documentLineEnd(content, offset)])) == 0
+}
+
+func documentLineIndent(line []byte) int {
+	indent := 0
+	for indent < len(line) && (line[indent] == ' ' || line[indent] == '\t') {
+		indent++
+	}
+	return indent
+}
+
+func isDocumentBareKeyChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '-'
+}
+
+func splitDocumentPattern(pattern string) []string {
+	if pattern == "" {
+		return nil
+	}
+	return strings.Split(pattern, ".")
+}
+
+func appendDocumentKeys(keys []string, more ...string) []string {
+	return append(append(make([]string, 0, len(keys)+len(more)), keys...), more...)
+}
+
+func equalDocumentKeys(a, b []string) bool {
+	return len(a) == len(b) && hasDocumentKeysPrefix(a, b)
+}
+
+func hasDocumentKeysPrefix(keys, prefix []string) bool {
+	if len(keys) < len(prefix) {
+		return false
+	}
+	for i, key := range prefix {
+		if keys[i] != key {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedDocumentKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
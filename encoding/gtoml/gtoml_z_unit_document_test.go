@@ -0,0 +1,135 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtoml_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gtoml"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+var documentContent = `# Application config.
+title = "demo"   # title
+
+# Server settings.
+[server]
+  address = ":8000" # listening address
+  name = 'demo'
+  ports = [
+    8000, # main
+    8001,
+  ]
+
+[[users]]
+name = "john"
+
+[[users]]
+name = "smith"
+age = 20
+`
+
+func Test_Document_Get(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		doc, err := gtoml.LoadDocument([]byte(documentContent))
+		t.AssertNil(err)
+		t.Assert(doc.String(), documentContent)
+		t.Assert(doc.Get("title"), "demo")
+		t.Assert(doc.Get("server.ports.1"), 8001)
+		t.Assert(doc.Get("users.1.age"), 20)
+		t.Assert(doc.Get("users.2"), nil)
+		t.Assert(doc.Contains("server.name"), true)
+		t.Assert(doc.Contains("server.none"), false)
+		t.Assert(doc.Map()["title"], "demo")
+
+		_, err = gtoml.LoadDocument([]byte("a = "))
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Document_Set(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		doc, err := gtoml.LoadDocument([]byte(documentContent))
+		t.AssertNil(err)
+		t.AssertNil(doc.Set("server.address", ":9000"))
+		t.AssertNil(doc.Set("server.name", "test"))
+		t.AssertNil(doc.Set("server.timeout", 1.5))
+		t.AssertNil(doc.Set("users.0.age", 18))
+		t.AssertNil(doc.Set("version", 2))
+		t.AssertNil(doc.Set("redis.default", "127.0.0.1:6379"))
+		t.Assert(doc.String(), `# Application config.
+title = "demo"   # title
+version = 2
+
+# Server settings.
+[server]
+  address = ":9000" # listening address
+  name = 'test'
+  ports = [
+    8000, # main
+    8001,
+  ]
+  timeout = 1.5
+
+[[users]]
+name = "john"
+age = 18
+
+[[users]]
+name = "smith"
+age = 20
+
+[redis]
+default = "127.0.0.1:6379"
+`)
+	})
+	// Inline values and tables.
+	gtest.C(t, func(t *gtest.T) {
+		doc, err := gtoml.LoadDocument([]byte(documentContent))
+		t.AssertNil(err)
+		t.AssertNil(doc.Set("server.ports.1", 9001))
+		t.Assert(doc.Get("server.ports"), []interface{}{8000, 9001})
+		t.AssertNE(doc.Set("server.ports.5", 1), nil)
+
+		t.AssertNil(doc.Set("server", map[string]interface{}{
+			"address": ":80",
+			"limits":  map[string]interface{}{"rate": 10},
+		}))
+		t.Assert(doc.Get("server"), map[string]interface{}{
+			"address": ":80",
+			"limits":  map[string]interface{}{"rate": 10},
+		})
+		t.AssertNil(doc.Set("server.limits.burst", 20))
+		t.Assert(doc.Get("server.limits.burst"), 20)
+		t.AssertNE(doc.Set("server.address", nil), nil)
+	})
+}
+
+func Test_Document_Remove(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		doc, err := gtoml.LoadDocument([]byte(documentContent))
+		t.AssertNil(err)
+		t.AssertNil(doc.Remove("server.name"))
+		t.AssertNil(doc.Remove("users"))
+		t.AssertNil(doc.Remove("none.key"))
+		t.Assert(doc.String(), `# Application config.
+title = "demo"   # title
+
+# Server settings.
+[server]
+  address = ":8000" # listening address
+  ports = [
+    8000, # main
+    8001,
+  ]
+`)
+		t.AssertNil(doc.Remove("server"))
+		t.Assert(doc.String(), `# Application config.
+title = "demo"   # title
+`)
+	})
+}
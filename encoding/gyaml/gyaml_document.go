@@ -0,0 +1,713 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gyaml
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// defaultDocumentIndent is the indentation for rendering if it cannot be detected from content,
+	// which is the same as the one of Encode.
+	defaultDocumentIndent = 4
+)
+
+// Document is an editable YAML document, which keeps the comments, key order and
+// formatting of the original content when its values are modified.
+//
+// The modifications are applied to the original content in place as much as possible,
+// so that the untouched lines are kept as they are. The content is re-encoded as a whole
+// only if it cannot be edited in place, eg: modifying values in flow style collections,
+// in which case the comments and key order are still kept but the formatting may change.
+//
+// The `pattern` parameter of its functions is the hierarchical key path separated by char '.',
+// in which the integer keys are used as indexes for sequences, like: "server.address", "list.0".
+type Document struct {
+	content []byte
+	node    *yaml.Node // The document node, which is nil if there's no YAML content.
+	indent  int        // Indentation for rendering new content.
+}
+
+// documentLocation is the location of a value node in the document.
+type documentLocation struct {
+	parent *yaml.Node // Parent mapping or sequence node.
+	index  int        // Index of the key node in the mapping, or index of the item in the sequence.
+}
+
+// LoadDocument parses `content` and returns an editable YAML document.
+func LoadDocument(content []byte) (*Document, error) {
+	d := &Document{}
+	if err := d.load(content); err != nil {
+		return nil, err
+	}
+	d.indent = d.detectIndent()
+	return d, nil
+}
+
+// Bytes returns the current content of the document.
+func (d *Document) Bytes() []byte {
+	return d.content
+}
+
+// String returns the current content of the document as string.
+func (d *Document) String() string {
+	return string(d.content)
+}
+
+// Contains checks whether the value of `pattern` exists in the document.
+func (d *Document) Contains(pattern string) bool {
+	keys := splitDocumentPattern(pattern)
+	node, locations := d.search(keys)
+	return node != nil && len(locations) == len(keys)
+}
+
+// Get returns the value of `pattern`, or nil if it does not exist.
+func (d *Document) Get(pattern string) interface{} {
+	keys := splitDocumentPattern(pattern)
+	node, locations := d.search(keys)
+	if node == nil || len(locations) != len(keys) {
+		return nil
+	}
+	var result interface{}
+	if err := node.Decode(&result); err != nil {
+		return nil
+	}
+	return result
+}
+
+// Map returns the whole document as map.
+func (d *Document) Map() map[string]interface{} {
+	m, _ := Decode(d.content)
+	return m
+}
+
+// Set sets the value of `pattern` to `value`, which creates the parent mappings if they do not exist.
+// The comments of the replaced value are kept if possible.
+func (d *Document) Set(pattern string, value interface{}) error {
+	keys := splitDocumentPattern(pattern)
+	if len(keys) == 0 {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `pattern should not be empty`)
+	}
+	var (
+		node, locations = d.search(keys)
+		depth           = len(locations)
+		content         []byte
+		newNode         *yaml.Node
+		err             error
+	)
+	switch {
+	case node == nil:
+		// Empty document.
+		if newNode, err = newDocumentValueNode(keys, value); err != nil {
+			return err
+		}
+		content, err = d.appendRoot(newNode)
+
+	case depth == len(keys) || (node.Kind != yaml.MappingNode && node.Kind != yaml.SequenceNode):
+		// The existing value or the scalar in the path is replaced.
+		if newNode, err = newDocumentValueNode(keys[depth:], value); err != nil {
+			return err
+		}
+		content, err = d.replace(node, locations, newNode)
+
+	case node.Kind == yaml.MappingNode:
+		if newNode, err = newDocumentValueNode(keys[depth+1:], value); err != nil {
+			return err
+		}
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: keys[depth]}
+		content, err = d.appendEntry(node, locations, keyNode, newNode)
+
+	default:
+		// Only appending is allowed for the index that does not exist.
+		if keys[depth] != strconv.Itoa(len(node.Content)) {
+			return gerror.NewCodef(
+				gcode.CodeInvalidParameter,
+				`invalid index "%s" for sequence of length %d`,
+				keys[depth], len(node.Content),
+			)
+		}
+		if newNode, err = newDocumentValueNode(keys[depth+1:], value); err != nil {
+			return err
+		}
+		content, err = d.appendItem(node, locations, newNode)
+	}
+	if err != nil {
+		return err
+	}
+	return d.load(content)
+}
+
+// Remove deletes the value of `pattern` from the document along with its comments.
+// It does nothing if `pattern` does not exist.
+func (d *Document) Remove(pattern string) error {
+	keys := splitDocumentPattern(pattern)
+	if len(keys) == 0 {
+		return gerror.NewCode(gcode.CodeInvalidParameter, `pattern should not be empty`)
+	}
+	node, locations := d.search(keys)
+	if node == nil || len(locations) != len(keys) {
+		return nil
+	}
+	var (
+		location = locations[len(locations)-1]
+		parent   = location.parent
+		lines    = splitDocumentLines(d.content)
+	)
+	// The last entry of the nested collection is removed, which leaves the collection empty but not null.
+	if (parent.Kind == yaml.MappingNode && len(parent.Content) == 2) || len(parent.Content) == 1 {
+		if len(locations) > 1 {
+			parent.Content = nil
+			parent.Style = yaml.FlowStyle
+			content, err := d.renderModified(locations[:len(locations)-1])
+			if err != nil {
+				return err
+			}
+			return d.load(content)
+		}
+	}
+	if start, end, ok := d.span(lines, location); ok {
+		start = headCommentStart(lines, start)
+		return d.load(joinDocumentLines(lines[:start], nil, lines[end:]))
+	}
+	if parent.Kind == yaml.MappingNode {
+		parent.Content = append(parent.Content[:location.index], parent.Content[location.index+2:]...)
+	} else {
+		parent.Content = append(parent.Content[:location.index], parent.Content[location.index+1:]...)
+	}
+	content, err := d.renderModified(locations[:len(locations)-1])
+	if err != nil {
+		return err
+	}
+	return d.load(content)
+}
+
+func (d *Document) load(content []byte) error {
+	var node yaml.Node
+	if err := yaml.Unmarshal(content, &node); err != nil {
+		return gerror.Wrap(err, `yaml.Unmarshal failed`)
+	}
+	d.content = content
+	d.node = nil
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		d.node = &node
+	}
+	return nil
+}
+
+// search searches the node of `keys`, it returns the deepest existing node in the path
+// and the locations of the matched keys.
+func (d *Document) search(keys []string) (node *yaml.Node, locations []documentLocation) {
+	if d.node == nil {
+		return nil, nil
+	}
+	node = d.node.Content[0]
+	for len(locations) < len(keys) {
+		var (
+			key   = keys[len(locations)]
+			found = false
+		)
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					locations = append(locations, documentLocation{parent: node, index: i})
+					node, found = node.Content[i+1], true
+					break
+				}
+			}
+
+		case yaml.SequenceNode:
+			if index, err := strconv.Atoi(key); err == nil && index >= 0 && index < len(node.Content) {
+				locations = append(locations, documentLocation{parent: node, index: index})
+				node, found = node.Content[index], true
+			}
+		}
+		if !found {
+			break
+		}
+		if node.Kind == yaml.AliasNode && node.Alias != nil {
+			node = node.Alias
+		}
+	}
+	return
+}
+
+// replace replaces the value `node` of the last one of `locations` with `newNode`.
+func (d *Document) replace(node *yaml.Node, locations []documentLocation, newNode *yaml.Node) ([]byte, error) {
+	var (
+		lines = splitDocumentLines(d.content)
+		count = len(locations)
+	)
+	if count > 0 && locations[count-1].parent.Style&yaml.FlowStyle == 0 {
+		location := locations[count-1]
+		// It only replaces the scalar in its line, which keeps the comment and formatting of the line.
+		if node.Kind == yaml.ScalarNode && newNode.Kind == yaml.ScalarNode {
+			if line, ok := replaceScalar(lines, node, newNode, d.ownerIndent(lines, location)); ok {
+				lines[node.Line-1] = line
+				return bytes.Join(lines, nil), nil
+			}
+		}
+		if node.Kind == yaml.ScalarNode && newNode.LineComment == "" {
+			newNode.LineComment = node.LineComment
+		}
+		if location.parent.Kind == yaml.MappingNode {
+			keyNode := location.parent.Content[location.index]
+			if keyNode.LineComment == "" && newNode.Kind != yaml.ScalarNode {
+				keyNode.LineComment, newNode.LineComment = newNode.LineComment, ""
+			}
+		}
+		newNode.HeadComment = node.HeadComment
+		location.parent.Content[d.valueIndex(location)] = newNode
+		return d.renderModified(locations)
+	}
+	newNode.HeadComment = node.HeadComment
+	newNode.LineComment = node.LineComment
+	newNode.FootComment = node.FootComment
+	*node = *newNode
+	return d.renderModified(locations)
+}
+
+// appendEntry appends entry of `keyNode` and `valueNode` to the end of `mapping`,
+// which is the value of the last one of `locations`.
+func (d *Document) appendEntry(mapping *yaml.Node, locations []documentLocation, keyNode, valueNode *yaml.Node) ([]byte, error) {
+	if mapping.Style&yaml.FlowStyle == 0 && len(mapping.Content) > 0 {
+		var (
+			lines   = splitDocumentLines(d.content)
+			lastKey = mapping.Content[len(mapping.Content)-2]
+			indent  = lastKey.Column - 1
+			end     = entryEnd(lines, lastKey.Line-1, indent, mapping.Content[len(mapping.Content)-1], lastKey.Column)
+		)
+		rendered, err := d.renderEntry(keyNode, valueNode, indent)
+		if err != nil {
+			return nil, err
+		}
+		return joinDocumentLines(lines[:end], rendered, lines[end:]), nil
+	}
+	if len(mapping.Content) == 0 {
+		mapping.Style = 0
+	}
+	mapping.Content = append(mapping.Content, keyNode, valueNode)
+	return d.renderModified(locations)
+}
+
+// appendItem appends `valueNode` to the end of `sequence`, which is the value of the last one of `locations`.
+func (d *Document) appendItem(sequence *yaml.Node, locations []documentLocation, valueNode *yaml.Node) ([]byte, error) {
+	if sequence.Style&yaml.FlowStyle == 0 && len(sequence.Content) > 0 {
+		lines := splitDocumentLines(d.content)
+		location := documentLocation{parent: sequence, index: len(sequence.Content) - 1}
+		if start, end, ok := d.span(lines, location); ok {
+			rendered, err := d.renderItem(valueNode, lineIndent(lines[start]))
+			if err != nil {
+				return nil, err
+			}
+			return joinDocumentLines(lines[:end], rendered, lines[end:]), nil
+		}
+	}
+	if len(sequence.Content) == 0 {
+		sequence.Style = 0
+	}
+	sequence.Content = append(sequence.Content, valueNode)
+	return d.renderModified(locations)
+}
+
+// renderModified renders the content after the node tree is modified under the last one of `locations`.
+// It re-renders only the nearest entry that can be edited in place, or else the whole document.
+func (d *Document) renderModified(locations []documentLocation) ([]byte, error) {
+	lines := splitDocumentLines(d.content)
+	for i := len(locations) - 1; i >= 0; i-- {
+		start, end, ok := d.span(lines, locations[i])
+		if !ok {
+			continue
+		}
+		var (
+			rendered  []byte
+			err       error
+			location  = locations[i]
+			valueNode = *location.parent.Content[d.valueIndex(location)]
+		)
+		// The head comment is kept in the lines above the entry.
+		valueNode.HeadComment = ""
+		if location.parent.Kind == yaml.MappingNode {
+			keyNode := *location.parent.Content[location.index]
+			keyNode.HeadComment = ""
+			rendered, err = d.renderEntry(&keyNode, &valueNode, keyNode.Column-1)
+		} else {
+			rendered, err = d.renderItem(&valueNode, lineIndent(lines[start]))
+		}
+		if err != nil {
+			return nil, err
+		}
+		return joinDocumentLines(lines[:start], rendered, lines[end:]), nil
+	}
+	return d.render(d.node, 0)
+}
+
+// valueIndex returns the index of the value node at `location` in its parent.
+func (d *Document) valueIndex(location documentLocation) int {
+	if location.parent.Kind == yaml.MappingNode {
+		return location.index + 1
+	}
+	return location.index
+}
+
+// appendRoot appends `node` to the empty document, which keeps the comments in the document.
+func (d *Document) appendRoot(node *yaml.Node) ([]byte, error) {
+	rendered, err := d.render(node, 0)
+	if err != nil {
+		return nil, err
+	}
+	content := d.content
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		content = append(content, '\n')
+	}
+	return append(content, rendered...), nil
+}
+
+// span returns the line range [start, end) of the value at `location` including its key,
+// in which `ok` is false if the value cannot be edited in place.
+func (d *Document) span(lines [][]byte, location documentLocation) (start, end int, ok bool) {
+	if location.parent.Style&yaml.FlowStyle != 0 {
+		return 0, 0, false
+	}
+	if location.parent.Kind == yaml.MappingNode {
+		keyNode := location.parent.Content[location.index]
+		start = keyNode.Line - 1
+		if start >= len(lines) || !isSpaces(lines[start][:columnOffset(lines[start], keyNode.Column)]) {
+			return 0, 0, false
+		}
+		indent := keyNode.Column - 1
+		return start, entryEnd(lines, start, indent, location.parent.Content[location.index+1], keyNode.Column), true
+	}
+	// The item of sequence should start with "- " in its line.
+	node := location.parent.Content[location.index]
+	start = node.Line - 1
+	if start >= len(lines) {
+		return 0, 0, false
+	}
+	var (
+		line   = lines[start]
+		prefix = line[:columnOffset(line, node.Column)]
+		indent = lineIndent(line)
+	)
+	if len(prefix) <= indent || prefix[indent] != '-' || !isSpaces(prefix[indent+1:]) {
+		return 0, 0, false
+	}
+	return start, entryEnd(lines, start, indent, nil, 0), true
+}
+
+// ownerIndent returns the indentation of the key or sequence item line at `location`.
+func (d *Document) ownerIndent(lines [][]byte, location documentLocation) int {
+	if location.parent.Kind == yaml.MappingNode {
+		return location.parent.Content[location.index].Column - 1
+	}
+	if line := location.parent.Content[location.index].Line - 1; line < len(lines) {
+		return lineIndent(lines[line])
+	}
+	return 0
+}
+
+// renderEntry renders mapping entry of `keyNode` and `valueNode` with `indent`.
+func (d *Document) renderEntry(keyNode, valueNode *yaml.Node, indent int) ([]byte, error) {
+	return d.render(&yaml.Node{
+		Kind:    yaml.MappingNode,
+		Tag:     "!!map",
+		Content: []*yaml.Node{keyNode, valueNode},
+	}, indent)
+}
+
+// renderItem renders sequence item of `valueNode` with `indent`.
+func (d *Document) renderItem(valueNode *yaml.Node, indent int) ([]byte, error) {
+	return d.render(&yaml.Node{
+		Kind:    yaml.SequenceNode,
+		Tag:     "!!seq",
+		Content: []*yaml.Node{valueNode},
+	}, indent)
+}
+
+// render encodes `node` and indents each line of the result with `indent` spaces.
+func (d *Document) render(node *yaml.Node, indent int) ([]byte, error) {
+	var (
+		buffer  = bytes.NewBuffer(nil)
+		encoder = yaml.NewEncoder(buffer)
+	)
+	encoder.SetIndent(d.indent)
+	if err := encoder.Encode(node); err != nil {
+		return nil, gerror.Wrap(err, `yaml.Encoder.Encode failed`)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, gerror.Wrap(err, `yaml.Encoder.Close failed`)
+	}
+	if indent == 0 {
+		return buffer.Bytes(), nil
+	}
+	var (
+		prefix = bytes.Repeat([]byte{' '}, indent)
+		result = make([]byte, 0, buffer.Len()*2)
+	)
+	for _, line := range splitDocumentLines(buffer.Bytes()) {
+		if len(bytes.TrimSpace(line)) > 0 {
+			result = append(result, prefix...)
+		}
+		result = append(result, line...)
+	}
+	return result, nil
+}
+
+// detectIndent detects the indentation of the content by the first nested block mapping or sequence.
+func (d *Document) detectIndent() int {
+	if d.node == nil {
+		return defaultDocumentIndent
+	}
+	var detect func(node *yaml.Node) int
+	detect = func(node *yaml.Node) int {
+		if node.Kind != yaml.MappingNode || node.Style&yaml.FlowStyle != 0 {
+			return 0
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			var (
+				key   = node.Content[i]
+				value = node.Content[i+1]
+			)
+			if value.Style&yaml.FlowStyle != 0 || value.Line <= key.Line {
+				continue
+			}
+			switch value.Kind {
+			case yaml.MappingNode:
+				if value.Column > key.Column {
+					return value.Column - key.Column
+				}
+			case yaml.SequenceNode:
+				if value.Column > key.Column {
+					return value.Column - key.Column
+				}
+				continue
+			}
+			if indent := detect(value); indent > 0 {
+				return indent
+			}
+		}
+		return 0
+	}
+	if indent := detect(d.node.Content[0]); indent > 0 {
+		return indent
+	}
+	return defaultDocumentIndent
+}
+
+// replaceScalar replaces the scalar `node` in its line with `newNode`, which keeps the quoting style
+// if the new value is also a string. It returns false if the scalar is not in a single line.
+func replaceScalar(lines [][]byte, node, newNode *yaml.Node, ownerIndent int) ([]byte, bool) {
+	if node.Anchor != "" || node.Style&(yaml.TaggedStyle|yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return nil, false
+	}
+	index := node.Line - 1
+	if index >= len(lines) {
+		return nil, false
+	}
+	var (
+		line  = lines[index]
+		start = columnOffset(line, node.Column)
+		end   = -1
+	)
+	switch node.Style {
+	case yaml.DoubleQuotedStyle:
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\\' {
+				i++
+			} else if line[i] == '"' {
+				end = i + 1
+				break
+			}
+		}
+
+	case yaml.SingleQuotedStyle:
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\'' {
+				if i+1 < len(line) && line[i+1] == '\'' {
+					i++
+					continue
+				}
+				end = i + 1
+				break
+			}
+		}
+
+	default:
+		end = len(bytes.TrimRight(line, "\r\n"))
+		if pos := bytes.Index(line[start:], []byte(" #")); pos != -1 {
+			end = start + pos
+		}
+		for end > start && (line[end-1] == ' ' || line[end-1] == '\t') {
+			end--
+		}
+		// The plain scalar might be continued in the next lines.
+		for i := index + 1; i < len(lines); i++ {
+			if isBlankOrComment(lines[i]) {
+				continue
+			}
+			if lineIndent(lines[i]) > ownerIndent {
+				return nil, false
+			}
+			break
+		}
+	}
+	if end <= start || string(bytes.TrimSpace(line[start:end])) != string(line[start:end]) {
+		return nil, false
+	}
+	if newNode.Tag == "!!str" && (node.Style == yaml.DoubleQuotedStyle || node.Style == yaml.SingleQuotedStyle) {
+		newNode.Style = node.Style
+	}
+	rendered, err := yaml.Marshal(newNode)
+	if err != nil {
+		return nil, false
+	}
+	rendered = bytes.TrimSuffix(rendered, []byte("\n"))
+	if bytes.ContainsAny(rendered, "\r\n") {
+		return nil, false
+	}
+	result := make([]byte, 0, len(line)+len(rendered))
+	result = append(result, line[:start]...)
+	result = append(result, rendered...)
+	return append(result, line[end:]...), true
+}
+
+// entryEnd returns the end line of the entry starting from line `start` with `indent`,
+// which ends before the next line with the same or smaller indentation.
+// The trailing blank and comment lines are not included in the entry.
+func entryEnd(lines [][]byte, start, indent int, value *yaml.Node, keyColumn int) int {
+	// The sequence value of the mapping might be in the same indentation with the key.
+	indentlessSequence := value != nil && value.Kind == yaml.SequenceNode &&
+		value.Style&yaml.FlowStyle == 0 && value.Column == keyColumn
+	end := start + 1
+	for ; end < len(lines); end++ {
+		line := lines[end]
+		if isBlankOrComment(line) {
+			continue
+		}
+		lineIndent := lineIndent(line)
+		if lineIndent > indent {
+			continue
+		}
+		if indentlessSequence && lineIndent == indent && line[lineIndent] == '-' &&
+			(len(line) == lineIndent+1 || line[lineIndent+1] == ' ' || line[lineIndent+1] == '\n' || line[lineIndent+1] == '\r') {
+			continue
+		}
+		break
+	}
+	for end > start+1 && isBlankOrComment(lines[end-1]) {
+		end--
+	}
+	return end
+}
+
+// headCommentStart returns the first line of the comment lines right above line `start`
+// in the same indentation, which are considered as the head comment of the entry.
+func headCommentStart(lines [][]byte, start int) int {
+	indent := lineIndent(lines[start])
+	for start > 0 && isComment(lines[start-1]) && lineIndent(lines[start-1]) == indent {
+		start--
+	}
+	return start
+}
+
+// newDocumentValueNode creates node for `value`, which is nested in mappings of `keys`.
+func newDocumentValueNode(keys []string, value interface{}) (*yaml.Node, error) {
+	node := &yaml.Node{}
+	if err := node.Encode(value); err != nil {
+		return nil, gerror.Wrap(err, `yaml.Node.Encode failed`)
+	}
+	for i := len(keys) - 1; i >= 0; i-- {
+		node = &yaml.Node{
+			Kind: yaml.MappingNode,
+			Tag:  "!!map",
+			Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: keys[i]},
+				node,
+			},
+		}
+	}
+	return node, nil
+}
+
+func splitDocumentPattern(pattern string) []string {
+	if pattern == "" {
+		return nil
+	}
+	return strings.Split(pattern, ".")
+}
+
+func splitDocumentLines(content []byte) [][]byte {
+	if len(content) == 0 {
+		return nil
+	}
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	if len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// joinDocumentLines joins the lines and the rendered content, which makes sure there's
+// new line character between them.
+func joinDocumentLines(before [][]byte, rendered []byte, after [][]byte) []byte {
+	var buffer bytes.Buffer
+	for _, line := range before {
+		buffer.Write(line)
+	}
+	if buffer.Len() > 0 && buffer.Bytes()[buffer.Len()-1] != '\n' {
+		buffer.WriteByte('\n')
+	}
+	buffer.Write(rendered)
+	for _, line := range after {
+		buffer.Write(line)
+	}
+	return buffer.Bytes()
+}
+
+// columnOffset converts the 1-based character `column` to byte offset of `line`.
+func columnOffset(line []byte, column int) int {
+	offset := 0
+	for i := 1; i < column && offset < len(line); i++ {
+		_, size := utf8.DecodeRune(line[offset:])
+		offset += size
+	}
+	return offset
+}
+
+func lineIndent(line []byte) int {
+	indent := 0
+	for indent < len(line) && line[indent] == ' ' {
+		indent++
+	}
+	return indent
+}
+
+func isSpaces(b []byte) bool {
+	for _, c := range b {
+		if c != ' ' {
+			return false
+		}
+	}
+	return true
+}
+
+func isComment(line []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(line), []byte("#"))
+}
+
+func isBlankOrComment(line []byte) bool {
+	line = bytes.TrimSpace(line)
+	return len(line) == 0 || line[0] == '#'
+}
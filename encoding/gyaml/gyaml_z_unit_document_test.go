@@ -0,0 +1,129 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gyaml_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/encoding/gyaml"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+var documentContent = `# Server configuration.
+
+server:
+  # The listening address.
+  address: ":8000"   # line comment
+  name:    'demo'
+
+  list:
+    - a   # a
+    - b
+database:
+  link: mysql:root@tcp(127.0.0.1)/test
+  flow: {a: 1, b: [1, 2]}
+
+# Logger.
+logger:
+  level: all
+`
+
+func Test_Document_Get(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		doc, err := gyaml.LoadDocument([]byte(documentContent))
+		t.AssertNil(err)
+		t.Assert(doc.String(), documentContent)
+		t.Assert(doc.Get("server.address"), ":8000")
+		t.Assert(doc.Get("server.list.1"), "b")
+		t.Assert(doc.Get("database.flow.b"), []interface{}{1, 2})
+		t.Assert(doc.Get("server.none"), nil)
+		t.Assert(doc.Contains("logger.level"), true)
+		t.Assert(doc.Contains("logger.path"), false)
+		t.Assert(doc.Map()["logger"], map[string]interface{}{"level": "all"})
+
+		_, err = gyaml.LoadDocument([]byte("a: [b"))
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Document_Set(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		doc, err := gyaml.LoadDocument([]byte(documentContent))
+		t.AssertNil(err)
+		t.AssertNil(doc.Set("server.address", ":9000"))
+		t.AssertNil(doc.Set("server.name", "test"))
+		t.AssertNil(doc.Set("server.list.1", "c"))
+		t.AssertNil(doc.Set("server.list.2", "d"))
+		t.AssertNil(doc.Set("logger.path", "/var/log"))
+		t.AssertNil(doc.Set("redis.default", "127.0.0.1:6379"))
+		t.Assert(doc.String(), `# Server configuration.
+
+server:
+  # The listening address.
+  address: ":9000"   # line comment
+  name:    'test'
+
+  list:
+    - a   # a
+    - c
+    - d
+database:
+  link: mysql:root@tcp(127.0.0.1)/test
+  flow: {a: 1, b: [1, 2]}
+
+# Logger.
+logger:
+  level: all
+  path: /var/log
+redis:
+  default: 127.0.0.1:6379
+`)
+	})
+	// Flow collections and invalid index.
+	gtest.C(t, func(t *gtest.T) {
+		doc, err := gyaml.LoadDocument([]byte(documentContent))
+		t.AssertNil(err)
+		t.AssertNil(doc.Set("database.flow.c", 3))
+		t.Assert(doc.Get("database.flow"), map[string]interface{}{"a": 1, "b": []interface{}{1, 2}, "c": 3})
+		t.Assert(doc.Get("database.link"), "mysql:root@tcp(127.0.0.1)/test")
+		t.Assert(doc.Get("logger.level"), "all")
+		t.AssertNE(doc.Set("server.list.5", "e"), nil)
+		t.AssertNE(doc.Set("", 1), nil)
+	})
+	// Empty document.
+	gtest.C(t, func(t *gtest.T) {
+		doc, err := gyaml.LoadDocument(nil)
+		t.AssertNil(err)
+		t.AssertNil(doc.Set("a.b", 1))
+		t.Assert(doc.Get("a.b"), 1)
+	})
+}
+
+func Test_Document_Remove(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		doc, err := gyaml.LoadDocument([]byte(documentContent))
+		t.AssertNil(err)
+		t.AssertNil(doc.Remove("server.address"))
+		t.AssertNil(doc.Remove("server.list.0"))
+		t.AssertNil(doc.Remove("database"))
+		t.AssertNil(doc.Remove("none.key"))
+		t.Assert(doc.String(), `# Server configuration.
+
+server:
+  name:    'demo'
+
+  list:
+    - b
+
+# Logger.
+logger:
+  level: all
+`)
+		t.AssertNil(doc.Remove("logger.level"))
+		t.Assert(doc.Get("logger"), map[string]interface{}{})
+	})
+}
@@ -0,0 +1,113 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcode
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CatalogItem is the machine-readable description of a registered error code,
+// which can be used by document or SDK generators to describe error responses.
+type CatalogItem struct {
+	Code        int    `json:"code"                  yaml:"code"`
+	Message     string `json:"message"               yaml:"message"`
+	HttpStatus  int    `json:"httpStatus,omitempty"  yaml:"httpStatus,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+var (
+	// catalogMap stores all registered error codes by their integer code.
+	catalogMap = make(map[int]CatalogItem)
+	// catalogMu is the mutex for catalogMap.
+	catalogMu sync.RWMutex
+)
+
+func init() {
+	var items = []struct {
+		Code        Code
+		HttpStatus  int
+		Description string
+	}{
+		{CodeOK, http.StatusOK, "It is OK."},
+		{CodeInternalError, http.StatusInternalServerError, "An error occurred internally."},
+		{CodeValidationFailed, http.StatusBadRequest, "Data validation failed."},
+		{CodeDbOperationError, http.StatusInternalServerError, "Database operation error."},
+		{CodeInvalidParameter, http.StatusBadRequest, "The given parameter for current operation is invalid."},
+		{CodeMissingParameter, http.StatusBadRequest, "Parameter for current operation is missing."},
+		{CodeInvalidOperation, http.StatusBadRequest, "The function cannot be used like this."},
+		{CodeInvalidConfiguration, http.StatusInternalServerError, "The configuration is invalid for current operation."},
+		{CodeMissingConfiguration, http.StatusInternalServerError, "The configuration is missing for current operation."},
+		{CodeNotImplemented, http.StatusNotImplemented, "The operation is not implemented yet."},
+		{CodeNotSupported, http.StatusNotImplemented, "The operation is not supported yet."},
+		{CodeOperationFailed, http.StatusInternalServerError, "The operation failed."},
+		{CodeNotAuthorized, http.StatusUnauthorized, "Not Authorized."},
+		{CodeSecurityReason, http.StatusForbidden, "The operation is forbidden for security reason."},
+		{CodeServerBusy, http.StatusServiceUnavailable, "Server is busy, please try again later."},
+		{CodeUnknown, http.StatusInternalServerError, "Unknown error."},
+		{CodeNotFound, http.StatusNotFound, "Resource does not exist."},
+		{CodeInvalidRequest, http.StatusBadRequest, "Invalid request."},
+		{CodeNecessaryPackageNotImport, http.StatusInternalServerError, "It needs necessary package import."},
+		{CodeInternalPanic, http.StatusInternalServerError, "A panic occurred internally."},
+		{CodeBusinessValidationFailed, http.StatusBadRequest, "Business validation failed."},
+	}
+	for _, item := range items {
+		Register(item.Code, item.HttpStatus, item.Description)
+	}
+}
+
+// Register registers `code` into the error code catalog with its HTTP status mapping and description.
+// The parameter `httpStatus` can be 0 if the code has no HTTP status mapping.
+// It overwrites the registered item if the integer code is already registered.
+func Register(code Code, httpStatus int, description string) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	catalogMap[code.Code()] = CatalogItem{
+		Code:        code.Code(),
+		Message:     code.Message(),
+		HttpStatus:  httpStatus,
+		Description: description,
+	}
+}
+
+// GetCatalogItem retrieves and returns the registered catalog item of `code`.
+// The returned `ok` is false if the code is not registered.
+func GetCatalogItem(code int) (item CatalogItem, ok bool) {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	item, ok = catalogMap[code]
+	return
+}
+
+// Catalog returns all registered error codes, which are sorted by their integer code.
+func Catalog() []CatalogItem {
+	catalogMu.RLock()
+	items := make([]CatalogItem, 0, len(catalogMap))
+	for _, item := range catalogMap {
+		items = append(items, item)
+	}
+	catalogMu.RUnlock()
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Code < items[j].Code
+	})
+	return items
+}
+
+// CatalogJson returns all registered error codes as JSON content.
+// Note that it uses the standard encoding/json package, as package gcode is imported by internal/json.
+func CatalogJson() ([]byte, error) {
+	return json.Marshal(Catalog())
+}
+
+// CatalogYaml returns all registered error codes as YAML content.
+func CatalogYaml() ([]byte, error) {
+	return yaml.Marshal(Catalog())
+}
@@ -7,6 +7,7 @@
 package gcode_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/gogf/gf/v2/errors/gcode"
@@ -36,3 +37,26 @@ func Test_WithCode(t *testing.T) {
 		t.Assert(c.Detail(), "CodeInternalError")
 	})
 }
+
+func Test_Catalog(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		item, ok := gcode.GetCatalogItem(gcode.CodeNotFound.Code())
+		t.Assert(ok, true)
+		t.Assert(item.Message, "Not Found")
+		t.Assert(item.HttpStatus, 404)
+
+		gcode.Register(gcode.New(10001, "User Not Found", nil), 404, "The user does not exist.")
+		items := gcode.Catalog()
+		t.Assert(items[0].Code, gcode.CodeOK.Code())
+		t.Assert(items[len(items)-1].Code, 10001)
+		t.Assert(items[len(items)-1].Description, "The user does not exist.")
+
+		content, err := gcode.CatalogJson()
+		t.AssertNil(err)
+		t.Assert(strings.Contains(string(content), `{"code":10001,"message":"User Not Found","httpStatus":404,"description":"The user does not exist."}`), true)
+
+		content, err = gcode.CatalogYaml()
+		t.AssertNil(err)
+		t.Assert(strings.Contains(string(content), "message: User Not Found"), true)
+	})
+}
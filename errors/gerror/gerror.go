@@ -51,6 +51,13 @@ type IUnwrap interface {
 	Unwrap() error
 }
 
+// IRetryable is the interface for Retryable feature.
+// Errors implementing this interface classify themselves as retryable(transient) or not.
+type IRetryable interface {
+	Error() string
+	Retryable() bool
+}
+
 const (
 	// commaSeparatorSpace is the comma separator with space.
 	commaSeparatorSpace = ", "
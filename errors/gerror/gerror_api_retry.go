@@ -0,0 +1,79 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gerror
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"syscall"
+)
+
+// iTemporary is the interface for errors from standard library that can be temporary, eg: net.Error.
+type iTemporary interface {
+	Temporary() bool
+}
+
+// iTimeout is the interface for errors from standard library that can be timeout, eg: net.Error.
+type iTimeout interface {
+	Timeout() bool
+}
+
+// MarkRetryable wraps and marks `err` as retryable(transient), which means the failed operation
+// can be retried later. The marked error keeps the code and text of `err`.
+// It returns nil if given `err` is nil.
+func MarkRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{
+		error:     err,
+		stack:     callers(),
+		retryable: true,
+	}
+}
+
+// IsRetryable checks and reports whether `err` is a retryable(transient) error.
+//
+// An error is considered retryable if any error in its chain:
+// 1. Is marked by MarkRetryable or implements IRetryable returning true;
+// 2. Implements Temporary or Timeout returning true, like net.Error;
+// 3. Is a connection refused/reset/aborted, unexpected EOF or driver.ErrBadConn error.
+//
+// Note that it always returns false if `err` is caused by context cancellation or deadline,
+// as the retry would never succeed with the same context.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	for err != nil {
+		if e, ok := err.(IRetryable); ok && e.Retryable() {
+			return true
+		}
+		if e, ok := err.(iTemporary); ok && e.Temporary() {
+			return true
+		}
+		if e, ok := err.(iTimeout); ok && e.Timeout() {
+			return true
+		}
+		switch err {
+		case
+			io.ErrUnexpectedEOF,
+			driver.ErrBadConn,
+			syscall.ECONNREFUSED,
+			syscall.ECONNRESET,
+			syscall.ECONNABORTED:
+			return true
+		}
+		err = errors.Unwrap(err)
+	}
+	return false
+}
@@ -21,6 +21,8 @@ type Error struct {
 	stack stack      // Stack array, which records the stack information when this error is created or wrapped.
 	text  string     // Custom Error text when Error is created, might be empty when its code is not nil.
 	code  gcode.Code // Error code if necessary.
+
+	retryable bool // Whether this error is marked as retryable(transient).
 }
 
 const (
@@ -95,6 +97,8 @@ func (err *Error) Current() error {
 		stack: err.stack,
 		text:  err.text,
 		code:  err.code,
+
+		retryable: err.retryable,
 	}
 }
 
@@ -16,7 +16,7 @@ func (err *Error) Code() gcode.Code {
 	if err == nil {
 		return gcode.CodeNil
 	}
-	if err.code == gcode.CodeNil {
+	if err.code == nil || err.code == gcode.CodeNil {
 		return Code(err.Unwrap())
 	}
 	return err.code
@@ -0,0 +1,16 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gerror
+
+// Retryable reports whether current error is marked as retryable.
+// Note that it does not check its wrapped errors, use IsRetryable for the whole error chain.
+func (err *Error) Retryable() bool {
+	if err == nil {
+		return false
+	}
+	return err.retryable
+}
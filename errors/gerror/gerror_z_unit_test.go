@@ -7,8 +7,11 @@
 package gerror_test
 
 import (
+	"context"
+	"database/sql/driver"
 	"errors"
 	"fmt"
+	"syscall"
 	"testing"
 
 	"github.com/gogf/gf/v2/errors/gcode"
@@ -472,3 +475,27 @@ func Test_NewOption(t *testing.T) {
 		}), gerror.New("NewOptionError"))
 	})
 }
+
+func Test_Retryable(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gerror.IsRetryable(nil), false)
+		t.AssertNil(gerror.MarkRetryable(nil))
+
+		err1 := gerror.NewCode(gcode.CodeNotAuthorized, "1")
+		err2 := gerror.MarkRetryable(err1)
+		err3 := gerror.Wrap(err2, "3")
+		t.Assert(gerror.IsRetryable(err1), false)
+		t.Assert(gerror.IsRetryable(err2), true)
+		t.Assert(gerror.IsRetryable(err3), true)
+		t.Assert(err2.Error(), "1")
+		t.Assert(gerror.Code(err2).Code(), gcode.CodeNotAuthorized.Code())
+		t.Assert(gerror.Current(err2).(gerror.IRetryable).Retryable(), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gerror.IsRetryable(gerror.Wrap(context.DeadlineExceeded, "timeout")), false)
+		t.Assert(gerror.IsRetryable(gerror.MarkRetryable(context.Canceled)), false)
+		t.Assert(gerror.IsRetryable(gerror.Wrap(driver.ErrBadConn, "bad")), true)
+		t.Assert(gerror.IsRetryable(fmt.Errorf("wrap: %w", syscall.ECONNRESET)), true)
+		t.Assert(gerror.IsRetryable(errors.New("permanent")), false)
+	})
+}
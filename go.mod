@@ -15,6 +15,7 @@ require (
 	go.opentelemetry.io/otel v1.14.0
 	go.opentelemetry.io/otel/sdk v1.14.0
 	go.opentelemetry.io/otel/trace v1.14.0
+	golang.org/x/crypto v0.22.0
 	golang.org/x/net v0.24.0
 	golang.org/x/text v0.14.0
 	gopkg.in/yaml.v3 v3.0.1
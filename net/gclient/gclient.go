@@ -15,6 +15,8 @@ import (
 	"os"
 	"time"
 
+	"go.opentelemetry.io/otel/propagation"
+
 	"github.com/gogf/gf/v2"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/net/gsel"
@@ -33,9 +35,15 @@ type Client struct {
 	retryCount        int               // Retry count when request fails.
 	noUrlEncode       bool              // No url encoding for request parameters.
 	retryInterval     time.Duration     // Retry interval when request fails.
+	retryOption       *RetryOption      // Retry option with backoff, which overwrites retry count and interval.
+	hostLimiter       *hostLimiter      // Limiter of concurrent requests to each host.
+	breaker           *circuitBreaker   // Circuit breaker of each host.
 	middlewareHandler []HandlerFunc     // Interceptor handlers
 	discovery         gsvc.Discovery    // Discovery for service.
 	builder           gsel.Builder      // Builder for request balance.
+
+	// Propagator injecting tracing context into request headers, which is nil if using the global propagator.
+	propagator propagation.TextMapPropagator
 }
 
 const (
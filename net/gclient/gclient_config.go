@@ -16,6 +16,7 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/propagation"
 	"golang.org/x/net/proxy"
 
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -207,6 +208,16 @@ func (c *Client) SetTLSConfig(tlsConfig *tls.Config) error {
 	return gerror.New(`cannot set TLSClientConfig for custom Transport of the client`)
 }
 
+// SetPropagator sets the propagator injecting tracing context into request headers,
+// which overwrites the global propagator for the requests of this client, eg:
+//
+//	propagator, err := gtrace.NewPropagator("tracecontext", "b3multi")
+//	client.SetPropagator(propagator)
+func (c *Client) SetPropagator(propagator propagation.TextMapPropagator) *Client {
+	c.propagator = propagator
+	return c
+}
+
 // SetBuilder sets the load balance builder for client.
 func (c *Client) SetBuilder(builder gsel.Builder) {
 	c.builder = builder
@@ -0,0 +1,360 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+const (
+	defaultCookieJarCacheKey = "gf.gclient.cookie.jar"
+	cookieJarFilePerm        = 0600 // The cookies file is readable only by owner, as it contains credentials.
+)
+
+// CookieJarOption is the option for NewCookieJar.
+type CookieJarOption struct {
+	File     string        // File persisting the cookies, which are not persisted to file if empty.
+	CacheKey string        // Cache key of the cookies in Cache, which is "gf.gclient.cookie.jar" in default.
+	Cache    *gcache.Cache // Cache persisting the cookies, eg: gcache.NewAdapterRedis for sharing between processes.
+
+	// PublicSuffixList rejects the cookies of domains like "co.uk", no checking if nil.
+	// It can be golang.org/x/net/publicsuffix.List.
+	PublicSuffixList cookiejar.PublicSuffixList
+}
+
+// CookieJar is an implementation of http.CookieJar, which persists the cookies to file or cache,
+// and can be shared across clients. It matches the cookies by domain and path as RFC 6265.
+type CookieJar struct {
+	mu      sync.Mutex
+	option  CookieJarOption
+	entries map[string]*cookieEntry // Key => cookie, in which the key is "domain;path;name".
+}
+
+// cookieEntry is a stored cookie.
+type cookieEntry struct {
+	Name     string    `json:"name"`
+	Value    string    `json:"value"`
+	Domain   string    `json:"domain"`   // Domain without leading dot.
+	Path     string    `json:"path"`     // Path starting with "/".
+	HostOnly bool      `json:"hostOnly"` // Whether it is sent to the host exactly, which has no attribute Domain.
+	Secure   bool      `json:"secure"`
+	HttpOnly bool      `json:"httpOnly"`
+	SameSite int       `json:"sameSite"`
+	Expires  time.Time `json:"expires"` // Expiry time, which is zero for session cookie.
+	Created  time.Time `json:"created"`
+}
+
+// NewCookieJar creates and returns a cookie jar, which loads the persisted cookies, eg:
+//
+//	jar, err := gclient.NewCookieJar(gclient.CookieJarOption{
+//	    File: "/var/lib/crawler/cookies.json",
+//	})
+//	client.SetCookieJar(jar)
+//
+// The cookies are persisted once they are changed. Note that the session cookies having no expiry
+// are also persisted, so that the sessions are kept across restarts.
+// The cookies are reloaded from Cache for each request, as they might be changed by other processes.
+func NewCookieJar(option ...CookieJarOption) (*CookieJar, error) {
+	jar := &CookieJar{
+		entries: make(map[string]*cookieEntry),
+	}
+	if len(option) > 0 {
+		jar.option = option[0]
+	}
+	if jar.option.CacheKey == "" {
+		jar.option.CacheKey = defaultCookieJarCacheKey
+	}
+	if err := jar.load(context.Background()); err != nil {
+		return nil, err
+	}
+	return jar, nil
+}
+
+// SetCookieJar sets the cookie jar of the client, which can be shared across clients.
+func (c *Client) SetCookieJar(jar http.CookieJar) *Client {
+	c.Jar = jar
+	return c
+}
+
+// SetCookies implements the interface http.CookieJar.
+func (j *CookieJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return
+	}
+	var (
+		ctx  = context.Background()
+		host = canonicalCookieHost(u.Host)
+		now  = time.Now()
+	)
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.option.Cache != nil {
+		if err := j.load(ctx); err != nil {
+			intlog.Errorf(ctx, `%+v`, err)
+		}
+	}
+	var changed bool
+	for _, cookie := range cookies {
+		entry, ok := j.newEntry(u, host, cookie, now)
+		if !ok {
+			continue
+		}
+		key := entry.Domain + ";" + entry.Path + ";" + entry.Name
+		if !entry.Expires.IsZero() && !entry.Expires.After(now) {
+			if _, ok = j.entries[key]; ok {
+				delete(j.entries, key)
+				changed = true
+			}
+			continue
+		}
+		// The creation time of the replaced cookie is kept as RFC 6265.
+		if old, ok := j.entries[key]; ok {
+			entry.Created = old.Created
+		}
+		j.entries[key] = entry
+		changed = true
+	}
+	if changed {
+		if err := j.save(ctx); err != nil {
+			intlog.Errorf(ctx, `%+v`, err)
+		}
+	}
+}
+
+// Cookies implements the interface http.CookieJar.
+func (j *CookieJar) Cookies(u *url.URL) []*http.Cookie {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil
+	}
+	var (
+		ctx     = context.Background()
+		host    = canonicalCookieHost(u.Host)
+		path    = u.Path
+		now     = time.Now()
+		matched = make([]*cookieEntry, 0)
+	)
+	if path == "" {
+		path = "/"
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.option.Cache != nil {
+		if err := j.load(ctx); err != nil {
+			intlog.Errorf(ctx, `%+v`, err)
+		}
+	}
+	for key, entry := range j.entries {
+		if !entry.Expires.IsZero() && !entry.Expires.After(now) {
+			delete(j.entries, key)
+			continue
+		}
+		if entry.Secure && u.Scheme != "https" {
+			continue
+		}
+		if entry.HostOnly {
+			if host != entry.Domain {
+				continue
+			}
+		} else if !isCookieDomainMatch(host, entry.Domain) {
+			continue
+		}
+		if !isCookiePathMatch(path, entry.Path) {
+			continue
+		}
+		matched = append(matched, entry)
+	}
+	// The cookies with longer paths are listed first, and then the earlier created ones as RFC 6265.
+	sort.Slice(matched, func(i, k int) bool {
+		if len(matched[i].Path) != len(matched[k].Path) {
+			return len(matched[i].Path) > len(matched[k].Path)
+		}
+		return matched[i].Created.Before(matched[k].Created)
+	})
+	cookies := make([]*http.Cookie, len(matched))
+	for i, entry := range matched {
+		cookies[i] = &http.Cookie{Name: entry.Name, Value: entry.Value}
+	}
+	return cookies
+}
+
+// Clear removes all cookies of the jar, including the persisted ones.
+func (j *CookieJar) Clear(ctx context.Context) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.entries = make(map[string]*cookieEntry)
+	return j.save(ctx)
+}
+
+// newEntry creates a cookie entry of `cookie` set by `u`, which returns false if the cookie is rejected.
+func (j *CookieJar) newEntry(u *url.URL, host string, cookie *http.Cookie, now time.Time) (*cookieEntry, bool) {
+	entry := &cookieEntry{
+		Name:     cookie.Name,
+		Value:    cookie.Value,
+		Path:     cookie.Path,
+		Secure:   cookie.Secure,
+		HttpOnly: cookie.HttpOnly,
+		SameSite: int(cookie.SameSite),
+		Created:  now,
+	}
+	// The secure cookie cannot be set by insecure request.
+	if entry.Secure && u.Scheme != "https" {
+		return nil, false
+	}
+	domain := strings.TrimPrefix(strings.ToLower(cookie.Domain), ".")
+	switch {
+	case domain == "" || domain == host:
+		entry.Domain, entry.HostOnly = host, cookie.Domain == ""
+
+	case net.ParseIP(host) != nil, strings.HasSuffix(domain, "."), !isCookieDomainMatch(host, domain):
+		return nil, false
+
+	default:
+		if j.option.PublicSuffixList != nil && j.option.PublicSuffixList.PublicSuffix(domain) == domain {
+			return nil, false
+		}
+		entry.Domain = domain
+	}
+	if entry.Path == "" || entry.Path[0] != '/' {
+		entry.Path = defaultCookiePath(u.Path)
+	}
+	switch {
+	case cookie.MaxAge < 0:
+		entry.Expires = time.Unix(1, 0)
+	case cookie.MaxAge > 0:
+		entry.Expires = now.Add(time.Duration(cookie.MaxAge) * time.Second)
+	case !cookie.Expires.IsZero():
+		entry.Expires = cookie.Expires
+		if !entry.Expires.After(now) {
+			entry.Expires = time.Unix(1, 0)
+		}
+	}
+	return entry, true
+}
+
+// load loads the persisted cookies from cache or file.
+func (j *CookieJar) load(ctx context.Context) error {
+	var content []byte
+	if j.option.Cache != nil {
+		v, err := j.option.Cache.Get(ctx, j.option.CacheKey)
+		if err != nil {
+			return err
+		}
+		if v.IsNil() {
+			return nil
+		}
+		content = v.Bytes()
+	} else if j.option.File != "" && gfile.Exists(j.option.File) {
+		content = gfile.GetBytes(j.option.File)
+	}
+	if len(content) == 0 {
+		return nil
+	}
+	entries := make(map[string]*cookieEntry)
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return err
+	}
+	j.entries = entries
+	return nil
+}
+
+// save persists the cookies to cache or file, which removes the expired cookies.
+func (j *CookieJar) save(ctx context.Context) error {
+	if j.option.Cache == nil && j.option.File == "" {
+		return nil
+	}
+	now := time.Now()
+	for key, entry := range j.entries {
+		if !entry.Expires.IsZero() && !entry.Expires.After(now) {
+			delete(j.entries, key)
+		}
+	}
+	content, err := json.Marshal(j.entries)
+	if err != nil {
+		return err
+	}
+	if j.option.Cache != nil {
+		return j.option.Cache.Set(ctx, j.option.CacheKey, string(content), 0)
+	}
+	return writeCookieJarFile(j.option.File, content)
+}
+
+// writeCookieJarFile writes `content` to cookies file `path` with permission cookieJarFilePerm,
+// which also changes the permission of the existing file.
+func writeCookieJarFile(path string, content []byte) error {
+	if dir := gfile.Dir(path); !gfile.Exists(dir) {
+		if err := gfile.Mkdir(dir); err != nil {
+			return err
+		}
+	}
+	file, err := gfile.OpenWithFlagPerm(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, cookieJarFilePerm)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err = file.Chmod(cookieJarFilePerm); err != nil {
+		return gerror.Wrapf(err, `change permission of cookies file "%s" failed`, path)
+	}
+	if _, err = file.Write(content); err != nil {
+		return gerror.Wrapf(err, `write cookies file "%s" failed`, path)
+	}
+	return nil
+}
+
+// canonicalCookieHost returns the lower case host of `host` without port and trailing dot.
+func canonicalCookieHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimPrefix(strings.TrimSuffix(host, "]"), "[")
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+// isCookieDomainMatch checks whether `host` domain-matches `domain` as RFC 6265.
+func isCookieDomainMatch(host, domain string) bool {
+	if host == domain {
+		return true
+	}
+	return net.ParseIP(host) == nil && strings.HasSuffix(host, "."+domain)
+}
+
+// isCookiePathMatch checks whether request path `path` path-matches cookie path `cookiePath` as RFC 6265.
+func isCookiePathMatch(path, cookiePath string) bool {
+	if path == cookiePath {
+		return true
+	}
+	if !strings.HasPrefix(path, cookiePath) {
+		return false
+	}
+	return strings.HasSuffix(cookiePath, "/") || path[len(cookiePath)] == '/'
+}
+
+// defaultCookiePath returns the default cookie path of request path `path` as RFC 6265.
+func defaultCookiePath(path string) string {
+	if path == "" || path[0] != '/' {
+		return "/"
+	}
+	index := strings.LastIndex(path, "/")
+	if index == 0 {
+		return "/"
+	}
+	return path[:index]
+}
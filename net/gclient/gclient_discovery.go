@@ -34,7 +34,7 @@ func (n *discoveryNode) Address() string {
 	return n.address
 }
 
-// service prefix to its selector map cache.
+// service prefix to its selector map cache, the value is a map of builder name to its selector.
 var clientSelectorMap = gmap.New(true)
 
 // internalMiddlewareDiscovery is a client middleware that enables service discovery feature for client.
@@ -49,9 +49,12 @@ func internalMiddlewareDiscovery(c *Client, r *http.Request) (response *Response
 	service, err = gsvc.GetAndWatchWithDiscovery(ctx, c.discovery, r.URL.Host, func(service gsvc.Service) {
 		intlog.Printf(ctx, `http client watching service "%s" changed`, service.GetPrefix())
 		if v := clientSelectorMap.Get(service.GetPrefix()); v != nil {
-			if err = updateSelectorNodesByService(ctx, v.(gsel.Selector), service); err != nil {
-				intlog.Errorf(ctx, `%+v`, err)
-			}
+			v.(*gmap.StrAnyMap).Iterator(func(builderName string, selector interface{}) bool {
+				if err := updateSelectorNodesByService(ctx, selector.(gsel.Selector), service); err != nil {
+					intlog.Errorf(ctx, `%+v`, err)
+				}
+				return true
+			})
 		}
 	})
 	if err != nil {
@@ -69,9 +72,14 @@ func internalMiddlewareDiscovery(c *Client, r *http.Request) (response *Response
 	}
 	// Balancer.
 	var (
-		selectorMapKey   = service.GetPrefix()
-		selectorMapValue = clientSelectorMap.GetOrSetFuncLock(selectorMapKey, func() interface{} {
-			intlog.Printf(ctx, `http client create selector for service "%s"`, selectorMapKey)
+		selectorMapKey = service.GetPrefix()
+		builderMap     = clientSelectorMap.GetOrSetFuncLock(selectorMapKey, func() interface{} {
+			return gmap.NewStrAnyMap(true)
+		}).(*gmap.StrAnyMap)
+		selectorMapValue = builderMap.GetOrSetFuncLock(c.builder.Name(), func() interface{} {
+			intlog.Printf(
+				ctx, `http client create selector "%s" for service "%s"`, c.builder.Name(), selectorMapKey,
+			)
 			selector := c.builder.Build()
 			// Update selector nodes.
 			if err = updateSelectorNodesByService(ctx, selector, service); err != nil {
@@ -89,12 +97,29 @@ func internalMiddlewareDiscovery(c *Client, r *http.Request) (response *Response
 	if err != nil {
 		return nil, err
 	}
-	if done != nil {
-		defer done(ctx, gsel.DoneInfo{})
+	if node == nil {
+		return nil, gerror.NewCodef(
+			gcode.CodeServerBusy, `no available node for service "%s"`, service.GetName(),
+		)
 	}
 	r.Host = node.Address()
 	r.URL.Host = node.Address()
-	return c.Next(r)
+	response, err = c.Next(r)
+	if done != nil {
+		doneInfo := gsel.DoneInfo{
+			Err:           err,
+			BytesSent:     response != nil,
+			BytesReceived: response != nil,
+		}
+		// The server errors are reported as failures of node, which are used for ejection.
+		if err == nil && response.StatusCode >= http.StatusInternalServerError {
+			doneInfo.Err = gerror.NewCodef(
+				gcode.CodeServerBusy, `server "%s" responded with status %d`, node.Address(), response.StatusCode,
+			)
+		}
+		done(ctx, doneInfo)
+	}
+	return response, err
 }
 
 func updateSelectorNodesByService(ctx context.Context, selector gsel.Selector, service gsvc.Service) error {
@@ -0,0 +1,454 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+const (
+	harVersion        = "1.2"
+	harCreatorName    = "GClient"
+	harEncodingBase64 = "base64"
+)
+
+// HARRecorder is a transport wrapper recording the requests and responses in HAR format.
+type HARRecorder struct {
+	mu      sync.Mutex
+	next    http.RoundTripper
+	entries []harEntry
+}
+
+// HARReplayer is a transport responding the recorded responses in HAR file without sending requests.
+type HARReplayer struct {
+	mu      sync.Mutex
+	entries []harEntry
+	used    []bool // Whether the entry of same index is replayed.
+}
+
+// harFile is the HAR document, see http://www.softwareishard.com/blog/har-12-spec/.
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"` // Elapsed milliseconds of the request.
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	Cookies     []harNameValue `json:"cookies"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Cookies     []harNameValue `json:"cookies"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"` // Extension of HAR for binary body, which is "base64" or empty.
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// RecordHAR wraps the transport of the client using a HARRecorder and returns it, eg:
+//
+//	recorder := client.RecordHAR()
+//	// Requests...
+//	err := recorder.Save("testdata/api.har")
+//
+// Note that it should be called after the configurations of transport like SetProxy and SetTLSConfig,
+// and the recorded headers like Authorization should be removed from the file if it is shared.
+func (c *Client) RecordHAR() *HARRecorder {
+	recorder := NewHARRecorder(c.Transport)
+	c.Transport = recorder
+	return recorder
+}
+
+// ReplayHAR sets the transport of the client to a HARReplayer of HAR file `path`,
+// so that the requests are responded by the recorded responses without hitting real upstreams, eg:
+//
+//	err := client.ReplayHAR("testdata/api.har")
+func (c *Client) ReplayHAR(path string) error {
+	replayer, err := NewHARReplayer(path)
+	if err != nil {
+		return err
+	}
+	c.Transport = replayer
+	return nil
+}
+
+// NewHARRecorder creates and returns a HARRecorder sending requests using `next`,
+// which is http.DefaultTransport if `next` is nil.
+func NewHARRecorder(next http.RoundTripper) *HARRecorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &HARRecorder{
+		next: next,
+	}
+}
+
+// RoundTrip implements the interface http.RoundTripper.
+func (r *HARRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var (
+		startTime = time.Now()
+		reqBody   []byte
+		err       error
+	)
+	if req.Body != nil && req.Body != http.NoBody {
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	waitTime := time.Since(startTime)
+	resBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	entry := harEntry{
+		StartedDateTime: startTime,
+		Time:            harMilliseconds(time.Since(startTime)),
+		Request:         newHARRequest(req, reqBody),
+		Response:        newHARResponse(resp, resBody),
+		Timings: harTimings{
+			Wait:    harMilliseconds(waitTime),
+			Receive: harMilliseconds(time.Since(startTime) - waitTime),
+		},
+	}
+	r.mu.Lock()
+	r.entries = append(r.entries, entry)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Bytes returns the recorded requests and responses in HAR format.
+func (r *HARRecorder) Bytes() ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := r.entries
+	if entries == nil {
+		entries = make([]harEntry, 0)
+	}
+	return json.MarshalIndent(harFile{
+		Log: harLog{
+			Version: harVersion,
+			Creator: harCreator{Name: harCreatorName, Version: gf.VERSION},
+			Entries: entries,
+		},
+	}, "", "  ")
+}
+
+// Save saves the recorded requests and responses to HAR file `path`.
+func (r *HARRecorder) Save(path string) error {
+	content, err := r.Bytes()
+	if err != nil {
+		return err
+	}
+	return gfile.PutBytes(path, content)
+}
+
+// NewHARReplayer creates and returns a HARReplayer of HAR file `path`.
+func NewHARReplayer(path string) (*HARReplayer, error) {
+	if !gfile.Exists(path) {
+		return nil, gerror.NewCodef(gcode.CodeNotFound, `HAR file "%s" does not exist`, path)
+	}
+	return NewHARReplayerWithContent(gfile.GetBytes(path))
+}
+
+// NewHARReplayerWithContent creates and returns a HARReplayer of HAR `content`.
+func NewHARReplayerWithContent(content []byte) (*HARReplayer, error) {
+	var har harFile
+	if err := json.Unmarshal(content, &har); err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid HAR content`)
+	}
+	return &HARReplayer{
+		entries: har.Log.Entries,
+		used:    make([]bool, len(har.Log.Entries)),
+	}, nil
+}
+
+// RoundTrip implements the interface http.RoundTripper.
+// The request is matched by method, URL and body. The query string of URL and the form body are matched
+// regardless of the order of parameters. The recorded entries of the same request are replayed
+// in order, and the last one is replayed repeatedly after all are used.
+func (r *HARReplayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if reqBody, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+	var (
+		url     = req.URL.String()
+		reqURL  = harNormalizeURL(url)
+		matched = -1
+	)
+	reqBody = harNormalizeBody(req.Header.Get(httpHeaderContentType), reqBody)
+	r.mu.Lock()
+	for i, entry := range r.entries {
+		if entry.Request.Method != req.Method || harNormalizeURL(entry.Request.URL) != reqURL {
+			continue
+		}
+		if !bytes.Equal(entry.Request.normalizedBody(), reqBody) {
+			continue
+		}
+		matched = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if matched >= 0 {
+		r.used[matched] = true
+	}
+	r.mu.Unlock()
+	if matched < 0 {
+		return nil, gerror.NewCodef(gcode.CodeNotFound, `no recorded response for request "%s %s"`, req.Method, url)
+	}
+	return r.entries[matched].Response.toResponse(req)
+}
+
+// newHARRequest creates and returns the HAR request of `req`.
+func newHARRequest(req *http.Request, body []byte) harRequest {
+	request := harRequest{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		HTTPVersion: req.Proto,
+		Headers:     newHARHeaders(req.Header),
+		QueryString: make([]harNameValue, 0),
+		Cookies:     make([]harNameValue, 0),
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+	for name, values := range req.URL.Query() {
+		for _, value := range values {
+			request.QueryString = append(request.QueryString, harNameValue{Name: name, Value: value})
+		}
+	}
+	for _, cookie := range req.Cookies() {
+		request.Cookies = append(request.Cookies, harNameValue{Name: cookie.Name, Value: cookie.Value})
+	}
+	if len(body) > 0 {
+		text, encoding := harEncodeText(body)
+		request.PostData = &harPostData{
+			MimeType: req.Header.Get(httpHeaderContentType),
+			Text:     text,
+			Encoding: encoding,
+		}
+	}
+	return request
+}
+
+// newHARResponse creates and returns the HAR response of `resp`.
+func newHARResponse(resp *http.Response, body []byte) harResponse {
+	text, encoding := harEncodeText(body)
+	response := harResponse{
+		Status:      resp.StatusCode,
+		StatusText:  http.StatusText(resp.StatusCode),
+		HTTPVersion: resp.Proto,
+		Headers:     newHARHeaders(resp.Header),
+		Cookies:     make([]harNameValue, 0),
+		Content: harContent{
+			Size:     len(body),
+			MimeType: resp.Header.Get(httpHeaderContentType),
+			Text:     text,
+			Encoding: encoding,
+		},
+		RedirectURL: resp.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+	for _, cookie := range resp.Cookies() {
+		response.Cookies = append(response.Cookies, harNameValue{Name: cookie.Name, Value: cookie.Value})
+	}
+	return response
+}
+
+// newHARHeaders converts `header` to HAR headers.
+func newHARHeaders(header http.Header) []harNameValue {
+	headers := make([]harNameValue, 0, len(header))
+	for name, values := range header {
+		for _, value := range values {
+			headers = append(headers, harNameValue{Name: name, Value: value})
+		}
+	}
+	return headers
+}
+
+// body returns the decoded body of the HAR request.
+func (r harRequest) body() []byte {
+	if r.PostData == nil {
+		return nil
+	}
+	return harDecodeText(r.PostData.Text, r.PostData.Encoding)
+}
+
+// normalizedBody returns the decoded body of the HAR request, which is normalized for matching.
+func (r harRequest) normalizedBody() []byte {
+	if r.PostData == nil {
+		return nil
+	}
+	return harNormalizeBody(r.PostData.MimeType, r.body())
+}
+
+// toResponse creates and returns the http response of recorded HAR response for `req`.
+func (r harResponse) toResponse(req *http.Request) (*http.Response, error) {
+	header := make(http.Header)
+	for _, h := range r.Headers {
+		header.Add(h.Name, h.Value)
+	}
+	var (
+		body       = harDecodeText(r.Content.Text, r.Content.Encoding)
+		statusText = r.StatusText
+	)
+	if statusText == "" {
+		statusText = http.StatusText(r.Status)
+	}
+	proto := r.HTTPVersion
+	major, minor, ok := http.ParseHTTPVersion(proto)
+	if !ok {
+		proto, major, minor = "HTTP/1.1", 1, 1
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf(`%d %s`, r.Status, statusText),
+		StatusCode:    r.Status,
+		Proto:         proto,
+		ProtoMajor:    major,
+		ProtoMinor:    minor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// harEncodeText returns the text of `content`, which is encoded using base64 if it is not valid UTF-8.
+func harEncodeText(content []byte) (text, encoding string) {
+	if utf8.Valid(content) {
+		return string(content), ""
+	}
+	return base64.StdEncoding.EncodeToString(content), harEncodingBase64
+}
+
+// harDecodeText decodes `text` of HAR content using `encoding`.
+func harDecodeText(text, encoding string) []byte {
+	if encoding == harEncodingBase64 {
+		if content, err := base64.StdEncoding.DecodeString(text); err == nil {
+			return content
+		}
+	}
+	return []byte(text)
+}
+
+// harNormalizeURL returns `rawURL` with query parameters sorted by name for matching.
+func harNormalizeURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.RawQuery == "" {
+		return rawURL
+	}
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return rawURL
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// harNormalizeBody returns the form `body` with parameters sorted by name for matching,
+// or else `body` itself if it is not form content of `mimeType`.
+func harNormalizeBody(mimeType string, body []byte) []byte {
+	if len(body) == 0 {
+		return nil
+	}
+	if mediaType, _, err := mime.ParseMediaType(mimeType); err != nil || mediaType != httpHeaderContentTypeForm {
+		return body
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return body
+	}
+	return []byte(form.Encode())
+}
+
+// harMilliseconds returns the milliseconds of `d` for HAR timings.
+func harMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
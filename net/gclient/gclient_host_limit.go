@@ -0,0 +1,226 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	defaultCircuitBreakerFailures     = 5
+	defaultCircuitBreakerOpenDuration = 10 * time.Second
+)
+
+// HostLimitOption is the option of limiting concurrent requests to each host.
+type HostLimitOption struct {
+	MaxConns     int           // Max concurrent requests to each host, no limit if 0.
+	QueueTimeout time.Duration // Max waiting duration for a free slot of the host, which waits until the request is done if 0.
+}
+
+// CircuitBreakerOption is the option of circuit breaker for each host.
+type CircuitBreakerOption struct {
+	Failures     int           // Consecutive failures of a host opening its breaker, which is 5 in default.
+	OpenDuration time.Duration // Duration of rejecting requests before a probing request, which is 10 seconds in default.
+
+	// IsFailure checks whether the request fails, which is failed if it has error or 5XX status in default.
+	// The `resp` is nil if `err` is not nil.
+	IsFailure func(resp *http.Response, err error) bool
+}
+
+// hostLimiter limits the concurrent requests of each host using semaphores.
+type hostLimiter struct {
+	option HostLimitOption
+	slots  *gmap.StrAnyMap // Host => chan struct{}.
+}
+
+// circuitBreaker is the circuit breaker of all hosts.
+type circuitBreaker struct {
+	option CircuitBreakerOption
+	hosts  *gmap.StrAnyMap // Host => *hostBreaker.
+}
+
+// hostBreaker is the breaker state of a host.
+type hostBreaker struct {
+	mu       sync.Mutex
+	failures int       // Consecutive failures.
+	open     bool      // Whether the breaker is open.
+	probing  bool      // Whether a probing request is in sending after OpenDuration.
+	openTime time.Time // Time the breaker opened.
+}
+
+// hostReleaseBody releases the slot of host when the response body is closed.
+type hostReleaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+// SetHostLimit limits the concurrent requests to each host, so that one slow upstream cannot
+// exhaust the connections of the client, eg:
+//
+//	client.SetHostLimit(gclient.HostLimitOption{
+//	    MaxConns:     50,
+//	    QueueTimeout: time.Second,
+//	})
+//
+// The requests exceeding MaxConns are queued, and fail with code gcode.CodeServerBusy if they wait longer
+// than QueueTimeout. The slot is held until the response body is closed, so the response MUST be closed.
+// The clients cloned from current client share the limit.
+func (c *Client) SetHostLimit(option HostLimitOption) *Client {
+	if option.MaxConns <= 0 {
+		c.hostLimiter = nil
+		return c
+	}
+	c.hostLimiter = &hostLimiter{
+		option: option,
+		slots:  gmap.NewStrAnyMap(true),
+	}
+	return c
+}
+
+// SetCircuitBreaker enables the circuit breaker for each host, eg:
+//
+//	client.SetCircuitBreaker(gclient.CircuitBreakerOption{
+//	    Failures:     3,
+//	    OpenDuration: 5 * time.Second,
+//	})
+//
+// The breaker of a host opens after Failures consecutive failed requests, and then the requests to the host
+// fail immediately with code gcode.CodeServerBusy. After OpenDuration, one probing request is sent, which closes
+// the breaker if it succeeds, or opens it again if it fails. The rejected requests are not retryable in default.
+// The clients cloned from current client share the breaker.
+func (c *Client) SetCircuitBreaker(option CircuitBreakerOption) *Client {
+	if option.Failures <= 0 {
+		option.Failures = defaultCircuitBreakerFailures
+	}
+	if option.OpenDuration <= 0 {
+		option.OpenDuration = defaultCircuitBreakerOpenDuration
+	}
+	if option.IsFailure == nil {
+		option.IsFailure = func(resp *http.Response, err error) bool {
+			return err != nil || resp.StatusCode >= http.StatusInternalServerError
+		}
+	}
+	c.breaker = &circuitBreaker{
+		option: option,
+		hosts:  gmap.NewStrAnyMap(true),
+	}
+	return c
+}
+
+// acquire waits for a free slot of the host of `req`, and returns the function releasing the slot.
+func (l *hostLimiter) acquire(req *http.Request) (release func(), err error) {
+	var (
+		host = req.URL.Host
+		slot = l.slots.GetOrSetFuncLock(host, func() interface{} {
+			return make(chan struct{}, l.option.MaxConns)
+		}).(chan struct{})
+		timeout <-chan time.Time
+	)
+	if l.option.QueueTimeout > 0 {
+		timer := time.NewTimer(l.option.QueueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case slot <- struct{}{}:
+		return func() { <-slot }, nil
+
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+
+	case <-timeout:
+		return nil, gerror.NewCodef(
+			gcode.CodeServerBusy,
+			`waiting for connection of host "%s" timeout after %s`,
+			host, l.option.QueueTimeout,
+		)
+	}
+}
+
+// allow checks whether the request to the host of `req` is allowed.
+func (b *circuitBreaker) allow(req *http.Request) (*hostBreaker, error) {
+	host := req.URL.Host
+	breaker := b.hosts.GetOrSetFuncLock(host, func() interface{} {
+		return &hostBreaker{}
+	}).(*hostBreaker)
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	if breaker.open {
+		if breaker.probing || time.Since(breaker.openTime) < b.option.OpenDuration {
+			return nil, gerror.NewCodef(gcode.CodeServerBusy, `circuit breaker of host "%s" is open`, host)
+		}
+		breaker.probing = true
+	}
+	return breaker, nil
+}
+
+// done counts the result of the request allowed by the breaker.
+func (b *circuitBreaker) done(breaker *hostBreaker, resp *http.Response, err error) {
+	if err != nil {
+		resp = nil
+	}
+	failed := b.option.IsFailure(resp, err)
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+	if !failed {
+		breaker.failures, breaker.open, breaker.probing = 0, false, false
+		return
+	}
+	breaker.failures++
+	if breaker.probing || breaker.failures >= b.option.Failures {
+		breaker.open, breaker.probing, breaker.openTime = true, false, time.Now()
+	}
+}
+
+// Close implements the interface io.Closer, which releases the slot of host only once.
+func (b *hostReleaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// doWithHostGuard sends `req` using the host limiter and circuit breaker of the client if any.
+func (c *Client) doWithHostGuard(req *http.Request) (resp *http.Response, err error) {
+	var breaker *hostBreaker
+	if c.breaker != nil {
+		if breaker, err = c.breaker.allow(req); err != nil {
+			return nil, err
+		}
+	}
+	var release func()
+	if c.hostLimiter != nil {
+		if release, err = c.hostLimiter.acquire(req); err != nil {
+			if breaker != nil {
+				// It does not count for the host as the request is not sent.
+				breaker.mu.Lock()
+				breaker.probing = false
+				breaker.mu.Unlock()
+			}
+			return nil, err
+		}
+	}
+	resp, err = c.Do(req)
+	if breaker != nil {
+		c.breaker.done(breaker, resp, err)
+	}
+	if release != nil {
+		if resp != nil && resp.Body != nil {
+			resp.Body = &hostReleaseBody{ReadCloser: resp.Body, release: release}
+		} else {
+			release()
+		}
+	}
+	return resp, err
+}
@@ -36,6 +36,7 @@ const (
 	tracingAttrHttpDnsDone                      = "http.dns.done"
 	tracingAttrHttpConnectStart                 = "http.connect.start"
 	tracingAttrHttpConnectDone                  = "http.connect.done"
+	tracingAttrHttpUrl                          = "http.url"
 	tracingEventHttpRequest                     = "http.request"
 	tracingEventHttpRequestHeaders              = "http.request.headers"
 	tracingEventHttpRequestBaggage              = "http.request.baggage"
@@ -60,13 +61,19 @@ func internalMiddlewareObservability(c *Client, r *http.Request) (response *Resp
 		instrumentName,
 		trace.WithInstrumentationVersion(gf.VERSION),
 	)
-	ctx, span := tr.Start(ctx, r.URL.String(), trace.WithSpanKind(trace.SpanKindClient))
+	// The span is named by the method, as the URL has high cardinality and may contain sensitive data.
+	ctx, span := tr.Start(ctx, "HTTP "+r.Method, trace.WithSpanKind(trace.SpanKindClient))
 	defer span.End()
 
 	span.SetAttributes(gtrace.CommonLabels()...)
+	span.SetAttributes(attribute.String(tracingAttrHttpUrl, gtrace.RedactContent(ctx, r.URL.String())))
 
 	// Inject tracing content into http header.
-	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
+	if c.propagator != nil {
+		c.propagator.Inject(ctx, propagation.HeaderCarrier(r.Header))
+	} else {
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
+	}
 
 	// Inject ClientTrace into context for http request.
 	var (
@@ -102,17 +109,24 @@ func internalMiddlewareObservability(c *Client, r *http.Request) (response *Resp
 		return
 	}
 
-	reqBodyContentBytes, _ := io.ReadAll(response.Body)
-	response.Body = utils.NewReadCloser(reqBodyContentBytes, false)
+	// The body of streaming response is not buffered.
+	var resBodyContent string
+	if !isStreamingRequest(ctx) {
+		reqBodyContentBytes, _ := io.ReadAll(response.Body)
+		response.Body = utils.NewReadCloser(reqBodyContentBytes, false)
 
-	resBodyContent, err := gtrace.SafeContentForHttp(reqBodyContentBytes, response.Header)
-	if err != nil {
-		span.SetStatus(codes.Error, fmt.Sprintf(`converting safe content failed: %s`, err.Error()))
+		resBodyContent, err = gtrace.SafeContentForHttp(reqBodyContentBytes, response.Header)
+		if err != nil {
+			span.SetStatus(codes.Error, fmt.Sprintf(`converting safe content failed: %s`, err.Error()))
+		}
 	}
 
 	span.AddEvent(tracingEventHttpResponse, trace.WithAttributes(
-		attribute.String(tracingEventHttpResponseHeaders, gconv.String(httputil.HeaderToMap(response.Header))),
-		attribute.String(tracingEventHttpResponseBody, resBodyContent),
+		attribute.String(
+			tracingEventHttpResponseHeaders,
+			gconv.String(gtrace.RedactHeader(ctx, httputil.HeaderToMap(response.Header))),
+		),
+		attribute.String(tracingEventHttpResponseBody, gtrace.RedactContent(ctx, resBodyContent)),
 	))
 	return
 }
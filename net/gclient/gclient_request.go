@@ -349,24 +349,37 @@ func (c *Client) callRequest(req *http.Request) (resp *Response, err error) {
 	// raw HTTP request-response procedure.
 	reqBodyContent, _ := io.ReadAll(req.Body)
 	resp.requestBody = reqBodyContent
-	for {
+	var (
+		retry     = c.getRetryOption()
+		startTime = time.Now()
+	)
+	for attempt := 1; ; attempt++ {
+		// The buffered body is re-sent in retries.
 		req.Body = utils.NewReadCloser(reqBodyContent, false)
-		if resp.Response, err = c.Do(req); err != nil {
+		if resp.Response, err = c.doWithHostGuard(req); err != nil {
 			err = gerror.Wrapf(err, `request failed`)
 			// The response might not be nil when err != nil.
 			if resp.Response != nil {
 				_ = resp.Response.Body.Close()
 			}
-			if c.retryCount > 0 {
-				c.retryCount--
-				time.Sleep(c.retryInterval)
-			} else {
-				// return resp, err
-				break
-			}
-		} else {
+		}
+		if attempt > retry.Count || !retry.isRetryable(resp.Response, err) {
 			break
 		}
+		interval := retry.interval(attempt, resp.Response)
+		if retry.Budget > 0 && time.Since(startTime)+interval > retry.Budget {
+			break
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return resp, err
+		case <-timer.C:
+		}
+		if err == nil {
+			_ = resp.Response.Body.Close()
+		}
 	}
 	return resp, err
 }
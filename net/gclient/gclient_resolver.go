@@ -0,0 +1,296 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	defaultDNSCacheTTL         = time.Minute
+	defaultDNSCacheNegativeTTL = 5 * time.Second
+	defaultResolverDialTimeout = 30 * time.Second
+	minResolverAddrDialTimeout = 2 * time.Second
+)
+
+// Resolver resolves the host name to IP addresses, which is implemented by *net.Resolver.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) (addrs []string, err error)
+}
+
+// TTLResolver is a Resolver that also returns the TTL of the resolved addresses,
+// which is used by DNSCache instead of DNSCacheOption.TTL if it is less.
+type TTLResolver interface {
+	Resolver
+	LookupHostTTL(ctx context.Context, host string) (addrs []string, ttl time.Duration, err error)
+}
+
+// StaticResolver resolves the host names using a static hosts map like file /etc/hosts.
+type StaticResolver struct {
+	hosts    map[string][]string
+	fallback Resolver
+}
+
+// DNSCacheOption is the option for NewDNSCache.
+type DNSCacheOption struct {
+	TTL         time.Duration // Max duration of caching the resolved addresses, which is 1 minute in default.
+	NegativeTTL time.Duration // Duration of caching the resolving failure, which is 5 seconds in default. Use -1 for no caching.
+}
+
+// DNSCache is a Resolver caching the results of another Resolver.
+type DNSCache struct {
+	mu        sync.Mutex
+	resolver  Resolver
+	option    DNSCacheOption
+	entries   map[string]*dnsCacheEntry
+	evictedAt time.Time // Last time of evicting the expired entries.
+}
+
+// dnsCacheEntry is the cached result of a host, which is available after `done` is closed.
+type dnsCacheEntry struct {
+	done     chan struct{}
+	addrs    []string
+	err      error
+	expires  time.Time
+	canceled bool // The resolving is canceled by the context of its lookup, which is retried by other lookups.
+}
+
+// SetResolver sets the resolver of host names for the dialing of client, eg:
+//
+//	client.SetResolver(gclient.NewDNSCache(nil))
+//
+// The `resolver` can be a *net.Resolver using custom DNS server like consul DNS:
+//
+//	client.SetResolver(gclient.NewDNSCache(&net.Resolver{
+//	    PreferGo: true,
+//	    Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+//	        return new(net.Dialer).DialContext(ctx, network, "127.0.0.1:8600")
+//	    },
+//	}))
+//
+// or custom implementation like DNS-over-HTTPS. The resolved addresses are dialed in order until one succeeds,
+// and the dialing timeout is split across the addresses, so that an unreachable address does not use up the timeout.
+// Note that it overwrites the dialing of SetProxy using socks5 proxy.
+func (c *Client) SetResolver(resolver Resolver) error {
+	v, ok := c.Transport.(*http.Transport)
+	if !ok {
+		return gerror.New(`cannot set resolver for custom Transport of the client`)
+	}
+	dialer := &net.Dialer{
+		Timeout:   defaultResolverDialTimeout,
+		KeepAlive: defaultResolverDialTimeout,
+	}
+	v.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		if net.ParseIP(host) != nil {
+			return dialer.DialContext(ctx, network, address)
+		}
+		addrs, err := resolver.LookupHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		if len(addrs) == 0 {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+		return dialResolvedAddrs(ctx, dialer, network, port, addrs)
+	}
+	return nil
+}
+
+// dialResolvedAddrs dials `addrs` in order until one succeeds.
+// Like the dialing of net.Dialer, each address has an equal part of the remaining time before the deadline,
+// which is not less than minResolverAddrDialTimeout if there is enough time.
+func dialResolvedAddrs(ctx context.Context, dialer *net.Dialer, network, port string, addrs []string) (conn net.Conn, err error) {
+	deadline := time.Now().Add(dialer.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	for i, addr := range addrs {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			break
+		}
+		timeout := remaining / time.Duration(len(addrs)-i)
+		if timeout < minResolverAddrDialTimeout {
+			timeout = minResolverAddrDialTimeout
+			if remaining < timeout {
+				timeout = remaining
+			}
+		}
+		dialCtx, cancel := context.WithTimeout(ctx, timeout)
+		conn, err = dialer.DialContext(dialCtx, network, net.JoinHostPort(addr, port))
+		cancel()
+		if err == nil {
+			return conn, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+	}
+	if err == nil {
+		err = context.DeadlineExceeded
+	}
+	return nil, err
+}
+
+// NewStaticResolver creates and returns a StaticResolver using `hosts` mapping host names to addresses.
+// The host names not in `hosts` are resolved by `fallback` if it is given, or else they fail.
+func NewStaticResolver(hosts map[string][]string, fallback ...Resolver) *StaticResolver {
+	resolver := &StaticResolver{
+		hosts: make(map[string][]string, len(hosts)),
+	}
+	for host, addrs := range hosts {
+		resolver.hosts[strings.ToLower(host)] = addrs
+	}
+	if len(fallback) > 0 {
+		resolver.fallback = fallback[0]
+	}
+	return resolver
+}
+
+// LookupHost implements the interface Resolver.
+func (r *StaticResolver) LookupHost(ctx context.Context, host string) (addrs []string, err error) {
+	if addrs = r.hosts[strings.ToLower(host)]; len(addrs) > 0 {
+		return addrs, nil
+	}
+	if r.fallback != nil {
+		return r.fallback.LookupHost(ctx, host)
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+// NewDNSCache creates and returns a DNSCache caching the results of `resolver`,
+// which is net.DefaultResolver if `resolver` is nil.
+// The concurrent lookups of the same host share one resolving, and the failures are also cached
+// for DNSCacheOption.NegativeTTL, so that a failing DNS server is not flooded.
+func NewDNSCache(resolver Resolver, option ...DNSCacheOption) *DNSCache {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	cache := &DNSCache{
+		resolver: resolver,
+		entries:  make(map[string]*dnsCacheEntry),
+	}
+	if len(option) > 0 {
+		cache.option = option[0]
+	}
+	if cache.option.TTL <= 0 {
+		cache.option.TTL = defaultDNSCacheTTL
+	}
+	if cache.option.NegativeTTL == 0 {
+		cache.option.NegativeTTL = defaultDNSCacheNegativeTTL
+	}
+	return cache
+}
+
+// LookupHost implements the interface Resolver.
+func (c *DNSCache) LookupHost(ctx context.Context, host string) (addrs []string, err error) {
+	host = strings.ToLower(host)
+	for {
+		c.mu.Lock()
+		now := time.Now()
+		entry, ok := c.entries[host]
+		if ok {
+			select {
+			case <-entry.done:
+				if now.Before(entry.expires) {
+					c.mu.Unlock()
+					return entry.addrs, entry.err
+				}
+			default:
+				// It is resolving by another lookup.
+				c.mu.Unlock()
+				select {
+				case <-entry.done:
+					// The resolving canceled by another lookup is retried using the context of this lookup.
+					if entry.canceled {
+						continue
+					}
+					return entry.addrs, entry.err
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+		}
+		c.evictExpired(now)
+		entry = &dnsCacheEntry{done: make(chan struct{})}
+		c.entries[host] = entry
+		c.mu.Unlock()
+		return c.resolve(ctx, host, entry)
+	}
+}
+
+// resolve resolves `host` for `entry` using the resolver of cache, and completes `entry` with the result.
+func (c *DNSCache) resolve(ctx context.Context, host string, entry *dnsCacheEntry) ([]string, error) {
+	var ttl = c.option.TTL
+	if resolver, ok := c.resolver.(TTLResolver); ok {
+		var recordTTL time.Duration
+		if entry.addrs, recordTTL, entry.err = resolver.LookupHostTTL(ctx, host); recordTTL > 0 && recordTTL < ttl {
+			ttl = recordTTL
+		}
+	} else {
+		entry.addrs, entry.err = c.resolver.LookupHost(ctx, host)
+	}
+	if entry.err != nil {
+		ttl = c.option.NegativeTTL
+		// The canceled lookup is not cached.
+		if ctx.Err() != nil {
+			ttl = 0
+			entry.canceled = true
+			c.mu.Lock()
+			if c.entries[host] == entry {
+				delete(c.entries, host)
+			}
+			c.mu.Unlock()
+		}
+	}
+	entry.expires = time.Now().Add(ttl)
+	close(entry.done)
+	return entry.addrs, entry.err
+}
+
+// evictExpired removes the expired entries, which is done at most once in DNSCacheOption.TTL.
+// Note that it should be called with the lock.
+func (c *DNSCache) evictExpired(now time.Time) {
+	if now.Sub(c.evictedAt) < c.option.TTL {
+		return
+	}
+	c.evictedAt = now
+	for host, entry := range c.entries {
+		select {
+		case <-entry.done:
+			if !now.Before(entry.expires) {
+				delete(c.entries, host)
+			}
+		default:
+		}
+	}
+}
+
+// Remove removes the cached result of `host`.
+func (c *DNSCache) Remove(host string) {
+	c.mu.Lock()
+	delete(c.entries, strings.ToLower(host))
+	c.mu.Unlock()
+}
+
+// Clear removes all cached results.
+func (c *DNSCache) Clear() {
+	c.mu.Lock()
+	c.entries = make(map[string]*dnsCacheEntry)
+	c.mu.Unlock()
+}
@@ -0,0 +1,165 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+const (
+	defaultRetryInterval = 100 * time.Millisecond
+	httpHeaderRetryAfter = "Retry-After"
+)
+
+// defaultRetryStatusCodes are the retryable response status codes if RetryOption.StatusCodes is empty.
+var defaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// RetryOption is the option of automatic retries for failed requests.
+type RetryOption struct {
+	Count       int           // Max retries after the first request, no retry if 0.
+	Interval    time.Duration // Interval before the first retry, which is doubled for each next retry. It is 100ms in default.
+	MaxInterval time.Duration // Max interval of the exponential backoff, no limit if 0.
+	Jitter      float64       // Random factor from 0 to 1 of each interval, eg: 0.2 means random in [0.8, 1.2] of interval.
+	Budget      time.Duration // Max duration of a request including all retries, no limit if 0.
+	StatusCodes []int         // Retryable response status codes, which are 429, 502, 503 and 504 in default.
+
+	// Backoff returns the interval before the `attempt` retry starting from 1,
+	// which replaces the exponential backoff of Interval, MaxInterval and Jitter.
+	Backoff func(attempt int) time.Duration
+
+	// RetryIf checks whether the request should be retried, which replaces the checking of retryable
+	// errors and StatusCodes. The `resp` is nil if `err` is not nil.
+	RetryIf func(resp *http.Response, err error) bool
+}
+
+// SetRetryOption sets the automatic retries with exponential backoff for failed requests, eg:
+//
+//	client.SetRetryOption(gclient.RetryOption{
+//	    Count:    3,
+//	    Interval: 200 * time.Millisecond,
+//	    Jitter:   0.2,
+//	    Budget:   5 * time.Second,
+//	})
+//
+// The requests are retried if they fail with retryable errors checked by gerror.IsRetryable,
+// or respond the retryable status codes. The header Retry-After of response is honored,
+// but it stops retrying if the waiting exceeds the Budget.
+// The request body is buffered by the client, so it is re-sent safely in retries.
+func (c *Client) SetRetryOption(option RetryOption) *Client {
+	if option.Interval <= 0 {
+		option.Interval = defaultRetryInterval
+	}
+	if len(option.StatusCodes) == 0 {
+		option.StatusCodes = defaultRetryStatusCodes
+	}
+	c.retryOption = &option
+	return c
+}
+
+// RetryWith is a chaining function,
+// which sets the automatic retries using `option` for next request, see SetRetryOption.
+func (c *Client) RetryWith(option RetryOption) *Client {
+	newClient := c.Clone()
+	newClient.SetRetryOption(option)
+	return newClient
+}
+
+// getRetryOption returns the retry option of client, which is created from SetRetry if no option is set.
+// The retries of SetRetry keep their original behavior, which retry on any error with fixed interval.
+func (c *Client) getRetryOption() *RetryOption {
+	if c.retryOption != nil {
+		return c.retryOption
+	}
+	interval := c.retryInterval
+	return &RetryOption{
+		Count: c.retryCount,
+		Backoff: func(attempt int) time.Duration {
+			return interval
+		},
+		RetryIf: func(resp *http.Response, err error) bool {
+			return err != nil
+		},
+	}
+}
+
+// isRetryable checks whether the request should be retried using the response or error.
+func (o *RetryOption) isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		resp = nil
+	}
+	if o.RetryIf != nil {
+		return o.RetryIf(resp, err)
+	}
+	if err != nil {
+		return gerror.IsRetryable(err)
+	}
+	for _, code := range o.StatusCodes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// interval returns the interval before the `attempt` retry.
+// It uses the header Retry-After of `resp` if it has one.
+func (o *RetryOption) interval(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get(httpHeaderRetryAfter)); ok {
+			return d
+		}
+	}
+	if o.Backoff != nil {
+		return o.Backoff(attempt)
+	}
+	interval := o.Interval
+	for i := 1; i < attempt; i++ {
+		if (o.MaxInterval > 0 && interval >= o.MaxInterval) || interval > math.MaxInt64/2 {
+			break
+		}
+		interval *= 2
+	}
+	if o.MaxInterval > 0 && interval > o.MaxInterval {
+		interval = o.MaxInterval
+	}
+	if o.Jitter > 0 && interval > 0 {
+		delta := int(float64(interval) * o.Jitter)
+		interval += time.Duration(grand.N(-delta, delta))
+	}
+	return interval
+}
+
+// parseRetryAfter parses the header Retry-After, which is delay seconds or http date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
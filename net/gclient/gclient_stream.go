@@ -0,0 +1,222 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gfile"
+)
+
+const (
+	httpHeaderRange                    = `Range`
+	httpHeaderContentRange             = `Content-Range`
+	streamingRequestKey    gctx.StrKey = `HttpClientStreamingRequest`
+)
+
+// StreamProgress is the progress of a streaming download.
+type StreamProgress struct {
+	Read  int64   // Bytes received including the Offset of resumed download.
+	Total int64   // Total bytes of the resource, which is -1 if unknown.
+	Speed float64 // Average bytes per second of current download.
+}
+
+// StreamOption is the option for GetStream and DownloadFile.
+type StreamOption struct {
+	Offset           int64         // Offset of the resource to resume downloading from using header Range.
+	ProgressInterval time.Duration // Min interval between calls of OnProgress, which is called for each read if 0.
+
+	// OnProgress is called with the progress while reading the stream, and always called at the end of stream.
+	OnProgress func(progress StreamProgress)
+}
+
+// streamReader reads the response body and reports the progress.
+type streamReader struct {
+	response     *Response
+	option       StreamOption
+	read         int64     // Bytes read of current download.
+	total        int64     // Total bytes of the resource, which is -1 if unknown.
+	startTime    time.Time // Start time of current download.
+	lastReported time.Time // Time of last progress reported.
+}
+
+// GetStream sends GET request and returns the response body as stream, which does not buffer the content
+// into memory like GetBytes, eg:
+//
+//	reader, err := client.GetStream(ctx, url, gclient.StreamOption{
+//	    OnProgress: func(p gclient.StreamProgress) {
+//	        g.Log().Infof(ctx, "%d/%d bytes, %.0f bytes/s", p.Read, p.Total, p.Speed)
+//	    },
+//	})
+//
+// It requests the content from StreamOption.Offset if it is greater than 0, and returns error if the server
+// does not support range requests. It also returns error if the response status is not 2XX.
+// Note that the returned reader MUST be closed.
+func (c *Client) GetStream(ctx context.Context, url string, option ...StreamOption) (io.ReadCloser, error) {
+	var opt StreamOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	resp, err := c.getStream(ctx, url, opt)
+	if err != nil {
+		return nil, err
+	}
+	if opt.Offset > 0 && resp.StatusCode != http.StatusPartialContent {
+		_ = resp.Close()
+		return nil, gerror.NewCodef(
+			gcode.CodeNotSupported, `range request is not supported by "%s"`, url,
+		)
+	}
+	return newStreamReader(resp, opt), nil
+}
+
+// DownloadFile downloads the content of `url` to file `path` using stream, eg:
+//
+//	err := client.DownloadFile(ctx, url, "/tmp/big.iso")
+//
+// It resumes downloading from the end of `path` if it exists, and downloads the whole content again
+// if the server does not support range requests. The StreamOption.Offset is ignored.
+func (c *Client) DownloadFile(ctx context.Context, url, path string, option ...StreamOption) error {
+	var opt StreamOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	opt.Offset = 0
+	if gfile.Exists(path) {
+		opt.Offset = gfile.Size(path)
+	}
+	resp, err := c.getStream(ctx, url, opt)
+	if err != nil {
+		// The file is already completely downloaded.
+		if opt.Offset > 0 && gerror.Code(err) == gcode.CodeOperationFailed &&
+			resp != nil && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			return nil
+		}
+		return err
+	}
+	flag := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if resp.StatusCode != http.StatusPartialContent {
+		opt.Offset = 0
+		flag = os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	}
+	reader := newStreamReader(resp, opt)
+	defer reader.Close()
+
+	if err = gfile.Mkdir(gfile.Dir(path)); err != nil {
+		return err
+	}
+	file, err := gfile.OpenFile(path, flag, gfile.DefaultPermOpen)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err = io.Copy(file, reader); err != nil {
+		return gerror.Wrapf(err, `download "%s" to "%s" failed`, url, path)
+	}
+	return nil
+}
+
+// getStream sends GET request of streaming, which returns the response and error if the status is not 2XX.
+func (c *Client) getStream(ctx context.Context, url string, opt StreamOption) (*Response, error) {
+	client := c
+	if opt.Offset > 0 {
+		client = c.Clone()
+		client.SetHeader(httpHeaderRange, fmt.Sprintf(`bytes=%d-`, opt.Offset))
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// The response body is not buffered by tracing for streaming request.
+	ctx = context.WithValue(ctx, streamingRequestKey, 1)
+	resp, err := client.Get(ctx, url)
+	if err != nil {
+		if resp != nil {
+			_ = resp.Close()
+		}
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		_ = resp.Close()
+		return resp, gerror.NewCodef(
+			gcode.CodeOperationFailed, `unexpected response status "%s" of "%s"`, resp.Status, url,
+		)
+	}
+	return resp, nil
+}
+
+// newStreamReader creates and returns a reader of streaming response `resp`.
+func newStreamReader(resp *Response, opt StreamOption) *streamReader {
+	total := resp.ContentLength
+	if resp.StatusCode == http.StatusPartialContent {
+		total = parseContentRangeTotal(resp.Header.Get(httpHeaderContentRange))
+	}
+	return &streamReader{
+		response:  resp,
+		option:    opt,
+		total:     total,
+		startTime: time.Now(),
+	}
+}
+
+// Read implements the interface io.Reader.
+func (r *streamReader) Read(p []byte) (n int, err error) {
+	n, err = r.response.Body.Read(p)
+	r.read += int64(n)
+	if r.option.OnProgress != nil {
+		if err != nil || r.option.ProgressInterval <= 0 || time.Since(r.lastReported) >= r.option.ProgressInterval {
+			r.lastReported = time.Now()
+			r.option.OnProgress(r.progress())
+		}
+	}
+	return
+}
+
+// Close implements the interface io.Closer.
+func (r *streamReader) Close() error {
+	return r.response.Close()
+}
+
+// progress returns the current progress.
+func (r *streamReader) progress() StreamProgress {
+	progress := StreamProgress{
+		Read:  r.option.Offset + r.read,
+		Total: r.total,
+	}
+	if seconds := time.Since(r.startTime).Seconds(); seconds > 0 {
+		progress.Speed = float64(r.read) / seconds
+	}
+	return progress
+}
+
+// parseContentRangeTotal returns the total size in header Content-Range like "bytes 100-199/1000",
+// which is -1 if unknown.
+func parseContentRangeTotal(contentRange string) int64 {
+	index := strings.LastIndex(contentRange, "/")
+	if index < 0 {
+		return -1
+	}
+	total, err := strconv.ParseInt(contentRange[index+1:], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return total
+}
+
+// isStreamingRequest checks whether the request of `ctx` is streaming, whose response body should not be buffered.
+func isStreamingRequest(ctx context.Context) bool {
+	return ctx.Value(streamingRequestKey) != nil
+}
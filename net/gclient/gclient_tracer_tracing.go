@@ -224,8 +224,8 @@ func (ct *clientTracerTracing) WroteRequest(info httptrace.WroteRequestInfo) {
 	}
 
 	ct.span.AddEvent(tracingEventHttpRequest, trace.WithAttributes(
-		attribute.String(tracingEventHttpRequestHeaders, gconv.String(ct.headers)),
+		attribute.String(tracingEventHttpRequestHeaders, gconv.String(gtrace.RedactHeader(ct.Context, ct.headers))),
 		attribute.String(tracingEventHttpRequestBaggage, gtrace.GetBaggageMap(ct.Context).String()),
-		attribute.String(tracingEventHttpRequestBody, reqBodyContent),
+		attribute.String(tracingEventHttpRequestBody, gtrace.RedactContent(ct.Context, reqBodyContent)),
 	))
 }
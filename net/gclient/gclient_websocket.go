@@ -7,24 +7,315 @@
 package gclient
 
 import (
+	"context"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
 )
 
 // WebSocketClient wraps the underlying websocket client connection
 // and provides convenient functions.
 type WebSocketClient struct {
 	*websocket.Dialer
+	option WebSocketOption
+}
+
+// WebSocketOption is the option for WebSocketClient.
+type WebSocketOption struct {
+	Subprotocols     []string                                       // Subprotocols requested in handshake, in order of preference.
+	Compression      bool                                           // Negotiates permessage-deflate compression with server.
+	CompressionLevel int                                            // Flate compression level, the default level is used if 0.
+	PingInterval     time.Duration                                  // Interval sending ping, no keepalive if 0.
+	PongTimeout      time.Duration                                  // Timeout waiting pong or any message, which is twice PingInterval if 0.
+	Reconnect        bool                                           // Reconnects automatically if the connection is broken.
+	ReconnectMax     int                                            // Max reconnecting attempts for each broken, no limit if 0.
+	BackoffMin       time.Duration                                  // Min interval between reconnecting attempts, which is 1 second if 0.
+	BackoffMax       time.Duration                                  // Max interval between reconnecting attempts, which is 30 seconds if 0.
+	OnConnect        func(ctx context.Context, conn *WebSocketConn) // Callback after each successful connecting, eg: for re-subscribing.
+}
+
+// WebSocketConn is the websocket connection created by WebSocketClient.Connect,
+// which keeps alive with ping-pong and reconnects automatically if enabled.
+//
+// Like the underlying connection, it supports one concurrent reader and one concurrent writer.
+type WebSocketConn struct {
+	mu       sync.RWMutex
+	dialMu   sync.Mutex // Serializes reconnecting from reader and writer.
+	ctx      context.Context
+	cancel   context.CancelFunc
+	client   *WebSocketClient
+	url      string
+	header   http.Header
+	conn     *websocket.Conn
+	response *http.Response
+	version  int           // Increases in each connecting, to avoid reconnecting for the same broken repeatedly.
+	closed   bool          // Closed by Close, no more reconnecting.
+	closeCh  chan struct{} // Notifies keepalive goroutines of closing.
 }
 
+const (
+	defaultWebSocketBackoffMin = time.Second
+	defaultWebSocketBackoffMax = 30 * time.Second
+)
+
 // NewWebSocket creates and returns a new WebSocketClient object.
 func NewWebSocket() *WebSocketClient {
 	return &WebSocketClient{
-		&websocket.Dialer{
+		Dialer: &websocket.Dialer{
 			Proxy:            http.ProxyFromEnvironment,
 			HandshakeTimeout: 45 * time.Second,
 		},
 	}
 }
+
+// WebSocket creates and returns a new WebSocketClient object with `option`,
+// which is used for creating connections with keepalive and reconnecting using Connect.
+func WebSocket(option ...WebSocketOption) *WebSocketClient {
+	c := NewWebSocket()
+	if len(option) > 0 {
+		c.option = option[0]
+	}
+	if c.option.BackoffMin <= 0 {
+		c.option.BackoffMin = defaultWebSocketBackoffMin
+	}
+	if c.option.BackoffMax < c.option.BackoffMin {
+		c.option.BackoffMax = defaultWebSocketBackoffMax
+		if c.option.BackoffMax < c.option.BackoffMin {
+			c.option.BackoffMax = c.option.BackoffMin
+		}
+	}
+	if c.option.PingInterval > 0 && c.option.PongTimeout <= 0 {
+		c.option.PongTimeout = 2 * c.option.PingInterval
+	}
+	c.Subprotocols = c.option.Subprotocols
+	c.EnableCompression = c.option.Compression
+	return c
+}
+
+// Connect connects to websocket `url` and returns the connection.
+// The optional `header` is sent in each handshake, including the reconnecting ones.
+// Reconnecting only happens for broken connections, the first connecting error is returned directly.
+func (c *WebSocketClient) Connect(ctx context.Context, url string, header ...http.Header) (*WebSocketConn, error) {
+	conn := &WebSocketConn{
+		client:  c,
+		url:     url,
+		closeCh: make(chan struct{}),
+	}
+	if len(header) > 0 {
+		conn.header = header[0]
+	}
+	conn.ctx, conn.cancel = context.WithCancel(ctx)
+	if err := conn.dial(); err != nil {
+		conn.cancel()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// dial creates the underlying connection and starts its keepalive.
+func (c *WebSocketConn) dial() error {
+	wsConn, response, err := c.client.DialContext(c.ctx, c.url, c.header)
+	if err != nil {
+		return gerror.Wrapf(err, `websocket connect to "%s" failed`, c.url)
+	}
+	option := c.client.option
+	if option.Compression {
+		wsConn.EnableWriteCompression(true)
+		if option.CompressionLevel != 0 {
+			if err = wsConn.SetCompressionLevel(option.CompressionLevel); err != nil {
+				_ = wsConn.Close()
+				return gerror.Wrap(err, `websocket set compression level failed`)
+			}
+		}
+	}
+	if option.PongTimeout > 0 {
+		_ = wsConn.SetReadDeadline(time.Now().Add(option.PongTimeout))
+		wsConn.SetPongHandler(func(string) error {
+			return wsConn.SetReadDeadline(time.Now().Add(option.PongTimeout))
+		})
+	}
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		_ = wsConn.Close()
+		return gerror.NewCode(gcode.CodeInvalidOperation, `websocket connection closed`)
+	}
+	c.conn = wsConn
+	c.response = response
+	c.version++
+	c.mu.Unlock()
+
+	if option.PingInterval > 0 {
+		go c.keepalive(wsConn)
+	}
+	if option.OnConnect != nil {
+		option.OnConnect(c.ctx, c)
+	}
+	return nil
+}
+
+// keepalive sends ping in interval until `wsConn` is broken or replaced.
+func (c *WebSocketConn) keepalive(wsConn *websocket.Conn) {
+	ticker := time.NewTicker(c.client.option.PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.closeCh:
+			return
+		case <-ticker.C:
+			deadline := time.Now().Add(c.client.option.PongTimeout)
+			if err := wsConn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				// The broken connection is closed, which makes the reader reconnect.
+				_ = wsConn.Close()
+				return
+			}
+		}
+	}
+}
+
+// current returns the underlying connection and its version.
+func (c *WebSocketConn) current() (*websocket.Conn, int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.conn, c.version
+}
+
+// reconnect reconnects if the connection of `version` is broken and reconnecting is enabled,
+// with exponential backoff between attempts. It returns `cause` if no reconnecting happens.
+func (c *WebSocketConn) reconnect(version int, cause error) error {
+	c.dialMu.Lock()
+	defer c.dialMu.Unlock()
+	c.mu.Lock()
+	if c.closed || !c.client.option.Reconnect {
+		c.mu.Unlock()
+		return cause
+	}
+	if c.version != version {
+		// It is already reconnected by another reader or writer.
+		c.mu.Unlock()
+		return nil
+	}
+	_ = c.conn.Close()
+	c.mu.Unlock()
+
+	var (
+		option  = c.client.option
+		backoff = option.BackoffMin
+		err     error
+	)
+	for attempt := 1; option.ReconnectMax <= 0 || attempt <= option.ReconnectMax; attempt++ {
+		select {
+		case <-c.ctx.Done():
+			return gerror.Wrap(c.ctx.Err(), `websocket reconnecting cancelled`)
+		case <-time.After(backoff):
+		}
+		if err = c.dial(); err == nil {
+			return nil
+		}
+		if backoff *= 2; backoff > option.BackoffMax {
+			backoff = option.BackoffMax
+		}
+	}
+	return gerror.Wrapf(err, `websocket reconnecting failed after %d attempts`, option.ReconnectMax)
+}
+
+// ReadMessage reads the next message, and reconnects if the connection is broken.
+// It returns error only if reconnecting is disabled or failed, or the connection is closed.
+func (c *WebSocketConn) ReadMessage() (messageType int, data []byte, err error) {
+	for {
+		wsConn, version := c.current()
+		if messageType, data, err = wsConn.ReadMessage(); err == nil {
+			return
+		}
+		if c.isClosed() || websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+			return
+		}
+		if err = c.reconnect(version, err); err != nil {
+			return
+		}
+	}
+}
+
+// WriteMessage writes a message, and reconnects and retries once if the connection is broken.
+func (c *WebSocketConn) WriteMessage(messageType int, data []byte) error {
+	wsConn, version := c.current()
+	err := wsConn.WriteMessage(messageType, data)
+	if err == nil || c.isClosed() {
+		return err
+	}
+	if err = c.reconnect(version, err); err != nil {
+		return err
+	}
+	wsConn, _ = c.current()
+	return wsConn.WriteMessage(messageType, data)
+}
+
+// ReadJSON reads the next JSON-encoded message and stores it in `v`.
+func (c *WebSocketConn) ReadJSON(v interface{}) error {
+	_, data, err := c.ReadMessage()
+	if err != nil {
+		return err
+	}
+	return json.UnmarshalUseNumber(data, v)
+}
+
+// WriteJSON writes the JSON encoding of `v` as a text message.
+func (c *WebSocketConn) WriteJSON(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.WriteMessage(websocket.TextMessage, data)
+}
+
+// Subprotocol returns the subprotocol negotiated with server.
+func (c *WebSocketConn) Subprotocol() string {
+	wsConn, _ := c.current()
+	return wsConn.Subprotocol()
+}
+
+// Response returns the handshake response of current connection.
+func (c *WebSocketConn) Response() *http.Response {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.response
+}
+
+// Conn returns the current underlying connection, which changes after reconnecting.
+func (c *WebSocketConn) Conn() *websocket.Conn {
+	wsConn, _ := c.current()
+	return wsConn
+}
+
+// Close sends the close message to server and closes the connection without reconnecting.
+func (c *WebSocketConn) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	close(c.closeCh)
+	wsConn := c.conn
+	c.mu.Unlock()
+
+	c.cancel()
+	_ = wsConn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(time.Second),
+	)
+	return wsConn.Close()
+}
+
+func (c *WebSocketConn) isClosed() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.closed
+}
@@ -0,0 +1,157 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/gsel"
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// testDiscovery is a static discovery that never changes.
+type testDiscovery struct {
+	services []gsvc.Service
+}
+
+func (d *testDiscovery) Search(ctx context.Context, in gsvc.SearchInput) ([]gsvc.Service, error) {
+	result := make([]gsvc.Service, 0)
+	for _, service := range d.services {
+		if service.GetName() == in.Name {
+			result = append(result, service)
+		}
+	}
+	return result, nil
+}
+
+func (d *testDiscovery) Watch(ctx context.Context, key string) (gsvc.Watcher, error) {
+	return &testWatcher{closed: make(chan struct{})}, nil
+}
+
+type testWatcher struct {
+	closed chan struct{}
+}
+
+func (w *testWatcher) Proceed() ([]gsvc.Service, error) {
+	<-w.closed
+	return nil, context.Canceled
+}
+
+func (w *testWatcher) Close() error {
+	close(w.closed)
+	return nil
+}
+
+func newDiscoveryTestServer(content string, status int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(content))
+	}))
+}
+
+func newDiscoveryTestService(servers ...*httptest.Server) *gsvc.LocalService {
+	addresses := make([]string, 0, len(servers))
+	for _, server := range servers {
+		addresses = append(addresses, strings.TrimPrefix(server.URL, "http://"))
+	}
+	return &gsvc.LocalService{
+		Name:      guid.S(),
+		Endpoints: gsvc.NewEndpoints(strings.Join(addresses, gsvc.EndpointsDelimiter)),
+	}
+}
+
+func Test_Client_Discovery_RoundRobin(t *testing.T) {
+	var (
+		s1 = newDiscoveryTestServer("1", http.StatusOK)
+		s2 = newDiscoveryTestServer("2", http.StatusOK)
+	)
+	defer s1.Close()
+	defer s2.Close()
+	service := newDiscoveryTestService(s1, s2)
+	gtest.C(t, func(t *gtest.T) {
+		client := gclient.New().Discovery(&testDiscovery{services: []gsvc.Service{service}})
+		client.SetBuilder(gsel.NewBuilderRoundRobin())
+		contents := make(map[string]int)
+		for i := 0; i < 10; i++ {
+			contents[client.GetContent(ctx, "http://"+service.Name+"/")]++
+		}
+		t.Assert(contents, map[string]int{"1": 5, "2": 5})
+	})
+}
+
+func Test_Client_Discovery_Subset(t *testing.T) {
+	var (
+		s1 = newDiscoveryTestServer("1", http.StatusOK)
+		s2 = newDiscoveryTestServer("2", http.StatusOK)
+		s3 = newDiscoveryTestServer("3", http.StatusOK)
+	)
+	defer s1.Close()
+	defer s2.Close()
+	defer s3.Close()
+	service := newDiscoveryTestService(s1, s2, s3)
+	gtest.C(t, func(t *gtest.T) {
+		client := gclient.New().Discovery(&testDiscovery{services: []gsvc.Service{service}})
+		client.SetBuilder(gsel.NewBuilderSubset(gsel.NewBuilderRoundRobin(), 2))
+		contents := make(map[string]int)
+		for i := 0; i < 10; i++ {
+			contents[client.GetContent(ctx, "http://"+service.Name+"/")]++
+		}
+		t.Assert(len(contents), 2)
+	})
+}
+
+func Test_Client_Discovery_Ejection(t *testing.T) {
+	var (
+		s1 = newDiscoveryTestServer("1", http.StatusOK)
+		s2 = newDiscoveryTestServer("2", http.StatusInternalServerError)
+	)
+	defer s1.Close()
+	defer s2.Close()
+	service := newDiscoveryTestService(s1, s2)
+	gtest.C(t, func(t *gtest.T) {
+		client := gclient.New().Discovery(&testDiscovery{services: []gsvc.Service{service}})
+		client.SetBuilder(gsel.NewBuilderEjection(gsel.NewBuilderRoundRobin(), gsel.EjectionConfig{
+			MaxFailures:      1,
+			BaseEjectionTime: time.Minute,
+		}))
+		// The failed node is ejected after its first failure.
+		contents := make(map[string]int)
+		for i := 0; i < 10; i++ {
+			contents[client.GetContent(ctx, "http://"+service.Name+"/")]++
+		}
+		t.Assert(contents["2"], 1)
+		t.Assert(contents["1"], 9)
+	})
+}
+
+func Test_Client_Discovery_NoNode(t *testing.T) {
+	service := &gsvc.LocalService{
+		Name:      guid.S(),
+		Endpoints: gsvc.NewEndpoints("127.0.0.1:1"),
+	}
+	gtest.C(t, func(t *gtest.T) {
+		client := gclient.New().Discovery(&testDiscovery{services: []gsvc.Service{service}})
+		client.SetBuilder(gsel.NewBuilderEjection(gsel.NewBuilderRoundRobin(), gsel.EjectionConfig{
+			MaxFailures:        1,
+			MaxEjectionPercent: 100,
+		}))
+		_, err := client.Get(ctx, "http://"+service.Name+"/")
+		t.AssertNE(err, nil)
+		// The only node is ejected.
+		_, err = client.Get(ctx, "http://"+service.Name+"/")
+		t.AssertNE(err, nil)
+		t.Assert(strings.Contains(err.Error(), "no available node"), true)
+	})
+}
@@ -15,11 +15,13 @@ import (
 
 	"go.opentelemetry.io/otel"
 	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/internal/tracing"
 	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/net/gtrace"
 	"github.com/gogf/gf/v2/test/gtest"
 	"github.com/gogf/gf/v2/util/guid"
 )
@@ -75,3 +77,83 @@ func TestClient_CustomProvider(t *testing.T) {
 		t.Assert(resp.ReadAllString(), "{\"field\":\"test_for_response_body\"}")
 	})
 }
+
+func TestClient_Propagator(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/trace", func(r *ghttp.Request) {
+		r.Response.Write(r.Header.Get("X-B3-TraceId"), ",", gtrace.GetTraceID(r.Context()))
+	})
+	s.SetTracingPropagators(gtrace.PropagatorB3Multi)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+		traceCtx, err := gtrace.WithTraceID(ctx, traceID)
+		t.AssertNil(err)
+
+		propagator, err := gtrace.NewPropagator(gtrace.PropagatorB3Multi)
+		t.AssertNil(err)
+		url := fmt.Sprintf("http://127.0.0.1:%d/trace", s.GetListenedPort())
+		t.Assert(g.Client().SetPropagator(propagator).GetContent(traceCtx, url), traceID+","+traceID)
+
+		// The server extracts no tracing context from the W3C headers of global propagator.
+		content := g.Client().GetContent(traceCtx, url)
+		t.Assert(content[:1], ",")
+		t.AssertNE(content, ","+traceID)
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		s := g.Server(guid.S())
+		s.SetTracingPropagators("none")
+		s.SetDumpRouterMap(false)
+		t.AssertNE(s.Start(), nil)
+	})
+}
+
+func TestClient_Tracing_SpanName(t *testing.T) {
+	var (
+		recorder    = tracetest.NewSpanRecorder()
+		oldProvider = otel.GetTracerProvider()
+	)
+	otel.SetTracerProvider(sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(oldProvider)
+
+	gtrace.AddRedactFields("token")
+	defer gtrace.ResetRedaction()
+
+	s := g.Server(guid.S())
+	s.BindHandler("/user/{id}", func(r *ghttp.Request) {
+		r.Response.Write(r.Get("id"))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+		t.Assert(g.Client().GetContent(ctx, prefix+"/user/1?token=secret"), "1")
+		t.Assert(g.Client().PostContent(ctx, prefix+"/user/2"), "2")
+
+		var (
+			names = make([]string, 0)
+			urls  = make([]string, 0)
+		)
+		for _, span := range recorder.Ended() {
+			if span.SpanKind() != trace.SpanKindClient {
+				continue
+			}
+			names = append(names, span.Name())
+			for _, attr := range span.Attributes() {
+				if attr.Key == "http.url" {
+					urls = append(urls, attr.Value.Emit())
+				}
+			}
+		}
+		t.Assert(names, []string{"HTTP GET", "HTTP POST"})
+		t.Assert(urls, []string{prefix + "/user/1?token=" + gtrace.RedactMask, prefix + "/user/2"})
+	})
+}
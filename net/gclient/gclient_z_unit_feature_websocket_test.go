@@ -0,0 +1,127 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gclient_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_WebSocket_Option(t *testing.T) {
+	var (
+		pings = gtype.NewInt()
+		s     = g.Server(guid.S())
+	)
+	s.BindHandler("/ws", func(r *ghttp.Request) {
+		ws, err := r.WebSocket(ghttp.WebSocketOption{
+			Subprotocols: []string{"v2", "v1"},
+			Compression:  true,
+		})
+		if err != nil {
+			r.Exit()
+		}
+		defaultPingHandler := ws.PingHandler()
+		ws.SetPingHandler(func(appData string) error {
+			pings.Add(1)
+			return defaultPingHandler(appData)
+		})
+		for {
+			msgType, msg, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+			// It breaks the connection without closing message.
+			if string(msg) == "break" {
+				_ = ws.UnderlyingConn().Close()
+				return
+			}
+			if err = ws.WriteMessage(msgType, msg); err != nil {
+				return
+			}
+		}
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	// No closing in case of DATA RACE due to keep alive connection of WebSocket.
+	// defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	url := fmt.Sprintf("ws://127.0.0.1:%d/ws", s.GetListenedPort())
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			connects = gtype.NewInt()
+			client   = gclient.WebSocket(gclient.WebSocketOption{
+				Subprotocols: []string{"v1", "v2"},
+				Compression:  true,
+				PingInterval: 50 * time.Millisecond,
+				Reconnect:    true,
+				BackoffMin:   10 * time.Millisecond,
+				OnConnect: func(ctx context.Context, conn *gclient.WebSocketConn) {
+					connects.Add(1)
+				},
+			})
+		)
+		conn, err := client.Connect(ctx, url)
+		t.AssertNil(err)
+		defer conn.Close()
+
+		t.Assert(conn.Subprotocol(), "v2")
+		t.Assert(conn.Response().Header.Get("Sec-WebSocket-Extensions") != "", true)
+
+		t.AssertNil(conn.WriteJSON(g.Map{"name": "john"}))
+		var data g.Map
+		t.AssertNil(conn.ReadJSON(&data))
+		t.Assert(data["name"], "john")
+
+		// Keepalive.
+		time.Sleep(200 * time.Millisecond)
+		t.Assert(pings.Val() > 0, true)
+
+		// Reconnecting transparently in reading.
+		t.AssertNil(conn.WriteMessage(ghttp.WsMsgText, []byte("break")))
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, msg, err := conn.ReadMessage()
+			t.AssertNil(err)
+			t.Assert(msg, "after")
+		}()
+		for connects.Val() < 2 {
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.AssertNil(conn.WriteMessage(ghttp.WsMsgText, []byte("after")))
+		<-done
+		t.Assert(connects.Val(), 2)
+	})
+
+	// No reconnecting.
+	gtest.C(t, func(t *gtest.T) {
+		conn, err := gclient.WebSocket().Connect(ctx, url)
+		t.AssertNil(err)
+		defer conn.Close()
+
+		t.Assert(conn.Subprotocol(), "")
+		t.AssertNil(conn.WriteMessage(ghttp.WsMsgText, []byte("break")))
+		_, _, err = conn.ReadMessage()
+		t.AssertNE(err, nil)
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gclient.WebSocket().Connect(ctx, "ws://127.0.0.1:1/ws")
+		t.AssertNE(err, nil)
+	})
+}
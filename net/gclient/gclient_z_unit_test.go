@@ -13,16 +13,24 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"os"
+	"runtime"
 	"testing"
 	"time"
 
 	"github.com/gorilla/websocket"
 
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gtype"
 	"github.com/gogf/gf/v2/debug/gdebug"
+	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/gclient"
 	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gcache"
 	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/test/gtest"
 	"github.com/gogf/gf/v2/text/gstr"
@@ -685,3 +693,537 @@ func TestClient_NoUrlEncode(t *testing.T) {
 		t.Assert(c.NoUrlEncode().GetContent(ctx, `/`, params), `path=/data/binlog`)
 	})
 }
+
+func TestClient_RetryWith(t *testing.T) {
+	var (
+		counts = gmap.NewStrIntMap(true)
+		bodies = garray.NewStrArray(true)
+		incr   = func(key string) (n int) {
+			counts.LockFunc(func(m map[string]int) {
+				m[key]++
+				n = m[key]
+			})
+			return
+		}
+	)
+	s := g.Server(guid.S())
+	s.BindHandler("/unavailable", func(r *ghttp.Request) {
+		bodies.Append(r.GetBodyString())
+		if incr(r.URL.Path) < 3 {
+			r.Response.WriteStatus(http.StatusServiceUnavailable)
+			return
+		}
+		r.Response.Write("ok")
+	})
+	s.BindHandler("/retry-after", func(r *ghttp.Request) {
+		if incr(r.URL.Path) < 2 {
+			r.Response.Header().Set("Retry-After", "1")
+			r.Response.WriteStatus(http.StatusTooManyRequests)
+			return
+		}
+		r.Response.Write("ok")
+	})
+	s.BindHandler("/bad-request", func(r *ghttp.Request) {
+		incr(r.URL.Path)
+		r.Response.WriteStatus(http.StatusBadRequest)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		c := g.Client().RetryWith(gclient.RetryOption{
+			Count:    3,
+			Interval: 50 * time.Millisecond,
+			Jitter:   0.2,
+		})
+		c.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// The body is re-sent in retries.
+		startTime := time.Now()
+		t.Assert(c.PostContent(ctx, "/unavailable", "name=john"), "ok")
+		t.Assert(counts.Get("/unavailable"), 3)
+		t.Assert(bodies.Slice(), []string{"name=john", "name=john", "name=john"})
+		// The backoff intervals are about 50ms and 100ms.
+		t.Assert(time.Since(startTime) > 110*time.Millisecond, true)
+
+		t.Assert(c.GetContent(ctx, "/bad-request"), "Bad Request")
+		t.Assert(counts.Get("/bad-request"), 1)
+
+		startTime = time.Now()
+		t.Assert(c.GetContent(ctx, "/retry-after"), "ok")
+		t.Assert(counts.Get("/retry-after"), 2)
+		t.Assert(time.Since(startTime) >= time.Second, true)
+
+		// The Retry-After exceeds the budget.
+		counts.Remove("/retry-after")
+		budgetClient := c.RetryWith(gclient.RetryOption{Count: 3, Budget: 500 * time.Millisecond})
+		startTime = time.Now()
+		t.Assert(budgetClient.GetContent(ctx, "/retry-after"), "Too Many Requests")
+		t.Assert(counts.Get("/retry-after"), 1)
+		t.Assert(time.Since(startTime) < 500*time.Millisecond, true)
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		counts.Clear()
+		c := g.Client().RetryWith(gclient.RetryOption{
+			Count: 1,
+			RetryIf: func(resp *http.Response, err error) bool {
+				return resp != nil && resp.StatusCode == http.StatusBadRequest
+			},
+			Backoff: func(attempt int) time.Duration {
+				return time.Millisecond
+			},
+		})
+		c.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(c.GetContent(ctx, "/bad-request"), "Bad Request")
+		t.Assert(counts.Get("/bad-request"), 2)
+		t.Assert(c.GetContent(ctx, "/unavailable"), "Service Unavailable")
+		t.Assert(counts.Get("/unavailable"), 1)
+	})
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClient_SetRetry(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			count = gtype.NewInt()
+			c     = g.Client().Retry(2, time.Millisecond)
+		)
+		// The legacy retries are done for any error, even it is not retryable.
+		c.Transport = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			count.Add(1)
+			return nil, gerror.New("not retryable")
+		})
+		_, err := c.Get(ctx, "http://127.0.0.1/retry")
+		t.AssertNE(err, nil)
+		t.Assert(count.Val(), 3)
+
+		count.Set(0)
+		_, err = c.RetryWith(gclient.RetryOption{Count: 2, Interval: time.Millisecond}).Get(ctx, "http://127.0.0.1/retry")
+		t.AssertNE(err, nil)
+		t.Assert(count.Val(), 1)
+	})
+}
+
+func TestClient_SetHostLimit(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/slow", func(r *ghttp.Request) {
+		time.Sleep(300 * time.Millisecond)
+		r.Response.Write("ok")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		c := g.Client().SetHostLimit(gclient.HostLimitOption{
+			MaxConns:     1,
+			QueueTimeout: 100 * time.Millisecond,
+		})
+		c.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		done := make(chan string)
+		go func() {
+			done <- c.GetContent(ctx, "/slow")
+		}()
+		time.Sleep(50 * time.Millisecond)
+
+		// The host has no free slot in QueueTimeout.
+		_, err := c.Get(ctx, "/slow")
+		t.AssertNE(err, nil)
+		t.Assert(gerror.Code(err), gcode.CodeServerBusy)
+		t.Assert(<-done, "ok")
+
+		// The slot is released after the response is closed.
+		t.Assert(c.GetContent(ctx, "/slow"), "ok")
+	})
+}
+
+func TestClient_SetCircuitBreaker(t *testing.T) {
+	var (
+		counts    = gmap.NewStrIntMap(true)
+		recovered = gtype.NewBool()
+	)
+	s := g.Server(guid.S())
+	s.BindHandler("/upstream", func(r *ghttp.Request) {
+		counts.LockFunc(func(m map[string]int) {
+			m[r.URL.Path]++
+		})
+		if !recovered.Val() {
+			r.Response.WriteStatus(http.StatusInternalServerError)
+			return
+		}
+		r.Response.Write("ok")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		c := g.Client().SetCircuitBreaker(gclient.CircuitBreakerOption{
+			Failures:     2,
+			OpenDuration: 200 * time.Millisecond,
+		})
+		c.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		t.Assert(c.GetContent(ctx, "/upstream"), "Internal Server Error")
+		t.Assert(c.GetContent(ctx, "/upstream"), "Internal Server Error")
+
+		// The breaker is open after consecutive failures.
+		_, err := c.Get(ctx, "/upstream")
+		t.Assert(gerror.Code(err), gcode.CodeServerBusy)
+		t.Assert(counts.Get("/upstream"), 2)
+
+		// The failed probing request opens the breaker again.
+		time.Sleep(250 * time.Millisecond)
+		t.Assert(c.GetContent(ctx, "/upstream"), "Internal Server Error")
+		_, err = c.Get(ctx, "/upstream")
+		t.Assert(gerror.Code(err), gcode.CodeServerBusy)
+		t.Assert(counts.Get("/upstream"), 3)
+
+		// The successful probing request closes the breaker.
+		recovered.Set(true)
+		time.Sleep(250 * time.Millisecond)
+		t.Assert(c.GetContent(ctx, "/upstream"), "ok")
+		t.Assert(c.GetContent(ctx, "/upstream"), "ok")
+		t.Assert(counts.Get("/upstream"), 5)
+	})
+}
+
+func TestClient_GetStream(t *testing.T) {
+	var (
+		content = []byte(gstr.Repeat("0123456789", 10000))
+		srcPath = gfile.Temp(guid.S())
+	)
+	if err := gfile.PutBytes(srcPath, content); err != nil {
+		t.Fatal(err)
+	}
+	defer gfile.Remove(srcPath)
+
+	s := g.Server(guid.S())
+	s.BindHandler("/file", func(r *ghttp.Request) {
+		r.Response.ServeFile(srcPath)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			c        = g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+			progress gclient.StreamProgress
+		)
+		reader, err := c.GetStream(ctx, "/file", gclient.StreamOption{
+			OnProgress: func(p gclient.StreamProgress) {
+				progress = p
+			},
+		})
+		t.AssertNil(err)
+		data, err := io.ReadAll(reader)
+		t.AssertNil(err)
+		t.AssertNil(reader.Close())
+		t.Assert(data, content)
+		t.Assert(progress.Read, len(content))
+		t.Assert(progress.Total, len(content))
+		t.Assert(progress.Speed > 0, true)
+
+		// Resuming from offset using Range.
+		reader, err = c.GetStream(ctx, "/file", gclient.StreamOption{
+			Offset: 1000,
+			OnProgress: func(p gclient.StreamProgress) {
+				progress = p
+			},
+		})
+		t.AssertNil(err)
+		data, err = io.ReadAll(reader)
+		t.AssertNil(err)
+		t.AssertNil(reader.Close())
+		t.Assert(data, content[1000:])
+		t.Assert(progress.Read, len(content))
+		t.Assert(progress.Total, len(content))
+
+		_, err = c.GetStream(ctx, "/not-found")
+		t.AssertNE(err, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			c       = g.Client().Prefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+			dstPath = gfile.Temp(guid.S(), "file")
+		)
+		defer gfile.Remove(gfile.Dir(dstPath))
+
+		// The partially downloaded file is resumed.
+		t.AssertNil(gfile.PutBytes(dstPath, content[:1000]))
+		t.AssertNil(c.DownloadFile(ctx, "/file", dstPath))
+		t.Assert(gfile.GetBytes(dstPath), content)
+
+		// The completely downloaded file is not changed.
+		t.AssertNil(c.DownloadFile(ctx, "/file", dstPath))
+		t.Assert(gfile.GetBytes(dstPath), content)
+	})
+}
+
+func TestClient_SetCookieJar(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/login", func(r *ghttp.Request) {
+		r.Cookie.Set("session", "john")
+	})
+	s.BindHandler("/profile", func(r *ghttp.Request) {
+		r.Response.Write(r.Cookie.Get("session"))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			prefix = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+			file   = gfile.Temp(guid.S())
+		)
+		defer gfile.Remove(file)
+		// The permission of existing file is also changed.
+		t.AssertNil(gfile.PutContents(file, ""))
+
+		jar, err := gclient.NewCookieJar(gclient.CookieJarOption{File: file})
+		t.AssertNil(err)
+		c := g.Client().Prefix(prefix).SetCookieJar(jar)
+		t.Assert(c.GetContent(ctx, "/profile"), "")
+		t.Assert(c.GetContent(ctx, "/login"), "")
+		t.Assert(c.GetContent(ctx, "/profile"), "john")
+		if runtime.GOOS != "windows" {
+			info, err := gfile.Stat(file)
+			t.AssertNil(err)
+			t.Assert(info.Mode().Perm(), os.FileMode(0600))
+		}
+
+		// The cookies are loaded from file after restart.
+		jar, err = gclient.NewCookieJar(gclient.CookieJarOption{File: file})
+		t.AssertNil(err)
+		t.Assert(g.Client().Prefix(prefix).SetCookieJar(jar).GetContent(ctx, "/profile"), "john")
+
+		t.AssertNil(jar.Clear(ctx))
+		jar, err = gclient.NewCookieJar(gclient.CookieJarOption{File: file})
+		t.AssertNil(err)
+		t.Assert(g.Client().Prefix(prefix).SetCookieJar(jar).GetContent(ctx, "/profile"), "")
+	})
+}
+
+func TestCookieJar_Match(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		jar, err := gclient.NewCookieJar()
+		t.AssertNil(err)
+		u, _ := url.Parse("http://www.example.com/a/b")
+		jar.SetCookies(u, []*http.Cookie{
+			{Name: "host", Value: "1"},
+			{Name: "domain", Value: "2", Domain: ".example.com", Path: "/"},
+			{Name: "path", Value: "3", Path: "/a/b"},
+			{Name: "secure", Value: "4", Secure: true},
+			{Name: "other", Value: "5", Domain: "other.com"},
+			{Name: "expired", Value: "6", MaxAge: -1},
+		})
+		names := func(rawURL string) []string {
+			u, _ := url.Parse(rawURL)
+			array := make([]string, 0)
+			for _, cookie := range jar.Cookies(u) {
+				array = append(array, cookie.Name)
+			}
+			return array
+		}
+		t.Assert(names("http://www.example.com/a/b/c"), []string{"path", "host", "domain"})
+		t.Assert(names("http://www.example.com/a"), []string{"host", "domain"})
+		t.Assert(names("http://www.example.com/ab"), []string{"domain"})
+		t.Assert(names("http://api.example.com/a"), []string{"domain"})
+		t.Assert(names("http://example.com/a"), []string{"domain"})
+		t.Assert(names("http://other.com/a"), []string{})
+
+		// The cookie is removed by expiring.
+		jar.SetCookies(u, []*http.Cookie{{Name: "domain", Domain: "example.com", Path: "/", MaxAge: -1}})
+		t.Assert(names("http://www.example.com/a"), []string{"host"})
+	})
+	// The cookies are shared by cache between jars.
+	gtest.C(t, func(t *gtest.T) {
+		cache := gcache.New()
+		jar1, err := gclient.NewCookieJar(gclient.CookieJarOption{Cache: cache})
+		t.AssertNil(err)
+		jar2, err := gclient.NewCookieJar(gclient.CookieJarOption{Cache: cache})
+		t.AssertNil(err)
+		u, _ := url.Parse("https://example.com/")
+		jar1.SetCookies(u, []*http.Cookie{{Name: "name", Value: "john", Secure: true}})
+		cookies := jar2.Cookies(u)
+		t.Assert(len(cookies), 1)
+		t.Assert(cookies[0].Value, "john")
+		u, _ = url.Parse("http://example.com/")
+		t.Assert(len(jar2.Cookies(u)), 0)
+	})
+}
+
+func TestClient_RecordHAR(t *testing.T) {
+	var counts = gmap.NewStrIntMap(true)
+	s := g.Server(guid.S())
+	s.BindHandler("/count", func(r *ghttp.Request) {
+		counts.LockFunc(func(m map[string]int) {
+			m[r.URL.Path]++
+			r.Response.Write(m[r.URL.Path])
+		})
+	})
+	s.BindHandler("/echo", func(r *ghttp.Request) {
+		r.Response.Write(r.GetBodyString())
+	})
+	s.BindHandler("/binary", func(r *ghttp.Request) {
+		r.Response.Write([]byte{0xff, 0xfe, 0x00, 0x01})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			prefix = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+			file   = gfile.Temp(guid.S() + ".har")
+		)
+		defer gfile.Remove(file)
+
+		c := g.Client().Prefix(prefix)
+		recorder := c.RecordHAR()
+		t.Assert(c.GetContent(ctx, "/count"), "1")
+		t.Assert(c.GetContent(ctx, "/count"), "2")
+		t.Assert(c.PostContent(ctx, "/echo", "a"), "a")
+		t.Assert(c.PostContent(ctx, "/echo", "b"), "b")
+		t.Assert(c.GetBytes(ctx, "/binary"), []byte{0xff, 0xfe, 0x00, 0x01})
+		t.Assert(c.GetContent(ctx, "/echo?a=1&b=2"), "")
+		t.Assert(c.PostContent(ctx, "/echo", "a=1&b=2"), "a=1&b=2")
+		t.AssertNil(recorder.Save(file))
+
+		// The recorded responses are replayed without hitting the server.
+		replayClient := g.Client().Prefix(prefix)
+		t.AssertNil(replayClient.ReplayHAR(file))
+		t.Assert(replayClient.GetContent(ctx, "/count"), "1")
+		t.Assert(replayClient.GetContent(ctx, "/count"), "2")
+		t.Assert(replayClient.GetContent(ctx, "/count"), "2")
+		t.Assert(replayClient.PostContent(ctx, "/echo", "b"), "b")
+		t.Assert(replayClient.PostContent(ctx, "/echo", "a"), "a")
+		t.Assert(replayClient.GetBytes(ctx, "/binary"), []byte{0xff, 0xfe, 0x00, 0x01})
+		t.Assert(counts.Get("/count"), 2)
+
+		// The query string and form body are matched regardless of the order of parameters.
+		resp, err := replayClient.Get(ctx, "/echo?b=2&a=1")
+		t.AssertNil(err)
+		resp.Close()
+		t.Assert(replayClient.PostContent(ctx, "/echo", "b=2&a=1"), "a=1&b=2")
+		_, err = replayClient.Post(ctx, "/echo", "b=1&a=2")
+		t.Assert(gerror.Code(err), gcode.CodeNotFound)
+
+		_, err = replayClient.Get(ctx, "/not-recorded")
+		t.Assert(gerror.Code(err), gcode.CodeNotFound)
+	})
+}
+
+type testCountResolver struct {
+	gclient.Resolver
+	count *gtype.Int
+	ttl   time.Duration
+}
+
+func (r *testCountResolver) LookupHostTTL(ctx context.Context, host string) ([]string, time.Duration, error) {
+	r.count.Add(1)
+	addrs, err := r.Resolver.LookupHost(ctx, host)
+	return addrs, r.ttl, err
+}
+
+func TestClient_SetResolver(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/host", func(r *ghttp.Request) {
+		r.Response.Write(r.Host)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			resolver = &testCountResolver{
+				Resolver: gclient.NewStaticResolver(map[string][]string{
+					"user.svc": {"127.0.0.2", "127.0.0.1"},
+				}),
+				count: gtype.NewInt(),
+				ttl:   200 * time.Millisecond,
+			}
+			cache = gclient.NewDNSCache(resolver, gclient.DNSCacheOption{
+				TTL:         time.Minute,
+				NegativeTTL: time.Minute,
+			})
+			host = fmt.Sprintf("user.svc:%d", s.GetListenedPort())
+			c    = g.Client().Prefix("http://" + host)
+		)
+		t.AssertNil(c.SetResolver(cache))
+		t.Assert(c.GetContent(ctx, "/host"), host)
+		t.Assert(c.GetContent(ctx, "/host"), host)
+		t.Assert(resolver.count.Val(), 1)
+
+		// The TTL of resolver is respected.
+		time.Sleep(300 * time.Millisecond)
+		t.Assert(c.GetContent(ctx, "/host"), host)
+		t.Assert(resolver.count.Val(), 2)
+
+		// The failure is cached.
+		c = g.Client()
+		t.AssertNil(c.SetResolver(cache))
+		_, err := c.Get(ctx, "http://unknown.svc/host")
+		t.AssertNE(err, nil)
+		_, err = c.Get(ctx, "http://unknown.svc/host")
+		t.AssertNE(err, nil)
+		t.Assert(resolver.count.Val(), 3)
+	})
+}
+
+type testBlockingResolver struct {
+	gclient.Resolver
+	count *gtype.Int
+}
+
+// LookupHost blocks the first lookup until its context is done.
+func (r *testBlockingResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if r.count.Add(1) == 1 {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return r.Resolver.LookupHost(ctx, host)
+}
+
+func TestClient_SetResolver_Canceled(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			resolver = &testBlockingResolver{
+				Resolver: gclient.NewStaticResolver(map[string][]string{
+					"user.svc": {"127.0.0.1"},
+				}),
+				count: gtype.NewInt(),
+			}
+			cache              = gclient.NewDNSCache(resolver)
+			timeoutCtx, cancel = context.WithTimeout(ctx, 200*time.Millisecond)
+		)
+		defer cancel()
+		go func() {
+			_, _ = cache.LookupHost(timeoutCtx, "user.svc")
+		}()
+		time.Sleep(50 * time.Millisecond)
+		// The waiting lookup retries with its own context after the resolving is canceled.
+		addrs, err := cache.LookupHost(ctx, "user.svc")
+		t.AssertNil(err)
+		t.Assert(addrs, []string{"127.0.0.1"})
+		t.Assert(resolver.count.Val(), 2)
+	})
+}
@@ -8,12 +8,14 @@
 package ghttp
 
 import (
+	"context"
 	"net/http"
 	"reflect"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/propagation"
 
 	"github.com/gogf/gf/v2/container/gmap"
 	"github.com/gogf/gf/v2/container/gtype"
@@ -35,6 +37,9 @@ type (
 		config           ServerConfig              // Server configuration.
 		plugins          []Plugin                  // Plugin array to extend server functionality.
 		servers          []*gracefulServer         // Underlying http.Server array.
+		http3Servers     []*http3Server            // Underlying HTTP/3 server array.
+		http3AltSvc      *gtype.String             // Alt-Svc header value advertising the HTTP/3 listening ports.
+		autoCertManager  AutoCertManager           // Manager of automatic certificates, which is nil if not enabled.
 		serverCount      *gtype.Int                // Underlying http.Server number for internal usage.
 		closeChan        chan struct{}             // Used for underlying server closing event notification.
 		serveTree        map[string]interface{}    // The route maps tree.
@@ -46,6 +51,15 @@ type (
 		serviceMu        sync.Mutex                // Concurrent safety for operations of attribute service.
 		service          gsvc.Service              // The service for Registry.
 		registrar        gsvc.Registrar            // Registrar for service register.
+		shutdownHooks    []func(context.Context)   // Hooks called when the server starts shutting down.
+		inflight         *gtype.Int                // Number of the requests in serving.
+		draining         *gtype.Bool               // Whether the server is draining the in-flight requests in shutting down.
+		metricHooks      []MetricHook              // Hooks called after each request is done for custom metrics.
+		spanNameFunc     SpanNameFunc              // Custom function naming the server tracing span of request.
+
+		// Propagator of TracingPropagators extracting tracing context from request,
+		// which is nil if using the global propagator.
+		propagator propagation.TextMapPropagator
 	}
 
 	// Router object.
@@ -97,6 +111,8 @@ type (
 		InitFunc   HandlerFunc     // Initialization function when request enters the object (only available for object register type).
 		ShutFunc   HandlerFunc     // Shutdown function when request leaves out the object (only available for object register type).
 		Middleware []HandlerFunc   // Bound middleware array.
+		BodyLimit  int64           // Max request body size, which overrides server ClientMaxBodySize if not 0.
+		Priority   int             // Middleware priority, the higher one executes first, only available for the middleware type.
 		HookName   HookName        // Hook type name, only available for the hook type.
 		Router     *Router         // Router object.
 		Source     string          // Registering source file `path:line`.
@@ -155,6 +171,7 @@ const (
 	contentTypeHtml                         = "text/html"
 	contentTypeJson                         = "application/json"
 	contentTypeJavascript                   = "application/javascript"
+	contentTypeMsgPack                      = "application/msgpack"
 	swaggerUIPackedPath                     = "/goframe/swaggerui"
 	responseHeaderTraceID                   = "Trace-ID"
 	responseHeaderContentLength             = "Content-Length"
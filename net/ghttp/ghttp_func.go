@@ -60,3 +60,9 @@ func niceCallFunc(f func()) {
 	}()
 	f()
 }
+
+// isMsgPackMediaType checks and returns whether given media type is MessagePack,
+// like: application/msgpack, application/x-msgpack, application/vnd.msgpack.
+func isMsgPackMediaType(mediaType string) bool {
+	return gstr.ContainsI(mediaType, "msgpack")
+}
@@ -0,0 +1,227 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2/container/gmap"
+)
+
+// CircuitBreakerState is the state of circuit breaker.
+type CircuitBreakerState int
+
+const (
+	CircuitBreakerClosed   CircuitBreakerState = iota // Requests pass through and the failures are counted.
+	CircuitBreakerOpen                                // Requests are rejected using the fallback.
+	CircuitBreakerHalfOpen                            // Limited probing requests pass through to check recovery.
+)
+
+const (
+	tracingEventCircuitBreakerStateChange = "http.circuit_breaker.state_change"
+	tracingEventCircuitBreakerRejected    = "http.circuit_breaker.rejected"
+	tracingAttrKeyCircuitBreakerFrom      = "circuit_breaker.from"
+	tracingAttrKeyCircuitBreakerTo        = "circuit_breaker.to"
+)
+
+const (
+	defaultCircuitBreakerErrorRate        = 0.5
+	defaultCircuitBreakerMinRequests      = 10
+	defaultCircuitBreakerWindow           = 10 * time.Second
+	defaultCircuitBreakerOpenDuration     = 5 * time.Second
+	defaultCircuitBreakerHalfOpenRequests = 1
+)
+
+// CircuitBreakerOption is the option for MiddlewareCircuitBreaker.
+type CircuitBreakerOption struct {
+	ErrorRate        float64       // Failure rate from 0 to 1 in window opening the breaker, which is 0.5 in default.
+	Latency          time.Duration // Requests slower than it are counted as failures, no latency threshold if 0.
+	MinRequests      int           // Min requests in window before the failure rate is checked, which is 10 in default.
+	Window           time.Duration // Window of counting requests in closed state, which is 10 seconds in default.
+	OpenDuration     time.Duration // Duration of open state before half-open probing, which is 5 seconds in default.
+	HalfOpenRequests int           // Successful probes in half-open state closing the breaker, which is 1 in default.
+	Fallback         HandlerFunc   // Handler for rejected requests, which responds http.StatusServiceUnavailable in default.
+
+	// IsFailure checks whether the request fails, which is failed if it has error or 5XX status in default.
+	IsFailure func(r *Request) bool
+
+	// OnStateChange is called when the state of breaker of `route` changes.
+	OnStateChange func(ctx context.Context, route string, from, to CircuitBreakerState)
+}
+
+// circuitBreaker is the breaker of a route.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	option    *CircuitBreakerOption
+	route     string
+	state     CircuitBreakerState
+	total     int       // Requests in current window or half-open probes finished.
+	failures  int       // Failed requests in current window.
+	probing   int       // Half-open probes in serving.
+	startTime time.Time // Start time of current window or open state.
+}
+
+// String returns the state name.
+func (s CircuitBreakerState) String() string {
+	switch s {
+	case CircuitBreakerOpen:
+		return "open"
+	case CircuitBreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// MiddlewareCircuitBreaker returns a middleware of circuit breaker for each route,
+// which stops calling the handler of route failing frequently, so that the failures of downstream
+// dependencies do not cascade, eg:
+//
+//	group.Middleware(ghttp.MiddlewareCircuitBreaker(ghttp.CircuitBreakerOption{
+//	    ErrorRate: 0.3,
+//	    Latency:   time.Second,
+//	}))
+//
+// The breaker opens if the failure rate in window reaches ErrorRate, and then the requests are handled
+// by Fallback. After OpenDuration, it allows probing requests in half-open state, which close the breaker
+// if they succeed, or open it again if any fails.
+//
+// The state changes are added as events of the span of tracing middleware.
+func MiddlewareCircuitBreaker(option ...CircuitBreakerOption) HandlerFunc {
+	var opt CircuitBreakerOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.ErrorRate <= 0 {
+		opt.ErrorRate = defaultCircuitBreakerErrorRate
+	}
+	if opt.MinRequests <= 0 {
+		opt.MinRequests = defaultCircuitBreakerMinRequests
+	}
+	if opt.Window <= 0 {
+		opt.Window = defaultCircuitBreakerWindow
+	}
+	if opt.OpenDuration <= 0 {
+		opt.OpenDuration = defaultCircuitBreakerOpenDuration
+	}
+	if opt.HalfOpenRequests <= 0 {
+		opt.HalfOpenRequests = defaultCircuitBreakerHalfOpenRequests
+	}
+	if opt.IsFailure == nil {
+		opt.IsFailure = func(r *Request) bool {
+			return r.GetError() != nil || r.Response.Status >= http.StatusInternalServerError
+		}
+	}
+	breakers := gmap.NewStrAnyMap(true)
+	return func(r *Request) {
+		route := r.URL.Path
+		if handler := r.GetServeHandler(); handler != nil && handler.Handler.Router != nil {
+			route = handler.Handler.Router.Method + ":" + handler.Handler.Router.Uri
+		}
+		breaker := breakers.GetOrSetFuncLock(route, func() interface{} {
+			return &circuitBreaker{
+				option:    &opt,
+				route:     route,
+				startTime: time.Now(),
+			}
+		}).(*circuitBreaker)
+
+		if !breaker.allow(r) {
+			trace.SpanFromContext(r.Context()).AddEvent(tracingEventCircuitBreakerRejected, trace.WithAttributes(
+				attribute.String(metricAttrKeyHttpRoute, route),
+			))
+			if opt.Fallback != nil {
+				opt.Fallback(r)
+			} else {
+				r.Response.WriteStatus(http.StatusServiceUnavailable)
+			}
+			return
+		}
+		startTime := time.Now()
+		r.Middleware.Next()
+		failed := opt.IsFailure(r) || (opt.Latency > 0 && time.Since(startTime) > opt.Latency)
+		breaker.done(r, failed)
+	}
+}
+
+// allow checks whether the request is allowed to pass through.
+func (b *circuitBreaker) allow(r *Request) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case CircuitBreakerOpen:
+		if time.Since(b.startTime) < b.option.OpenDuration {
+			return false
+		}
+		b.setState(r, CircuitBreakerHalfOpen)
+		fallthrough
+
+	case CircuitBreakerHalfOpen:
+		if b.probing+b.total >= b.option.HalfOpenRequests {
+			return false
+		}
+		b.probing++
+
+	default:
+		if time.Since(b.startTime) > b.option.Window {
+			b.total, b.failures, b.startTime = 0, 0, time.Now()
+		}
+	}
+	return true
+}
+
+// done counts the result of the request passed through.
+func (b *circuitBreaker) done(r *Request, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case CircuitBreakerHalfOpen:
+		b.probing--
+		if failed {
+			b.setState(r, CircuitBreakerOpen)
+			return
+		}
+		if b.total++; b.total >= b.option.HalfOpenRequests {
+			b.setState(r, CircuitBreakerClosed)
+		}
+
+	case CircuitBreakerClosed:
+		b.total++
+		if failed {
+			b.failures++
+		}
+		if b.total >= b.option.MinRequests && float64(b.failures) >= b.option.ErrorRate*float64(b.total) {
+			b.setState(r, CircuitBreakerOpen)
+		}
+
+	default:
+		// The request passed through before the breaker opened by others.
+	}
+}
+
+// setState changes the state and resets the counting, which should be called with lock.
+func (b *circuitBreaker) setState(r *Request, state CircuitBreakerState) {
+	from := b.state
+	b.state = state
+	b.total, b.failures, b.probing, b.startTime = 0, 0, 0, time.Now()
+
+	ctx := r.Context()
+	trace.SpanFromContext(ctx).AddEvent(tracingEventCircuitBreakerStateChange, trace.WithAttributes(
+		attribute.String(metricAttrKeyHttpRoute, b.route),
+		attribute.String(tracingAttrKeyCircuitBreakerFrom, from.String()),
+		attribute.String(tracingAttrKeyCircuitBreakerTo, state.String()),
+	))
+	if b.option.OnStateChange != nil {
+		b.option.OnStateChange(ctx, b.route, from, state)
+	}
+}
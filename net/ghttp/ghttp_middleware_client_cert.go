@@ -0,0 +1,81 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"crypto/x509"
+	"net/http"
+
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// ClientCertOption is the option for MiddlewareClientCert.
+type ClientCertOption struct {
+	// CAs verifies the client certificate chain for the route group, which is commonly loaded by LoadCertPool.
+	// If it is nil, the client certificate should be verified by server using HTTPSClientAuth.
+	CAs *x509.CertPool
+
+	// AllowedNames specifies the allowed common names or DNS names of client certificates, any if empty.
+	AllowedNames []string
+}
+
+// MiddlewareClientCert returns a middleware authenticating requests using client certificates,
+// which responds 401 if no client certificate is given, and 403 if the certificate is not verified or allowed.
+//
+// It is used for the route groups requiring client certificates, with server HTTPSClientAuth
+// configured as ClientAuthVerifyIfGiven, or ClientAuthRequest if the groups use different CAs.
+func MiddlewareClientCert(option ...ClientCertOption) HandlerFunc {
+	var opt ClientCertOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	return func(r *Request) {
+		if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+			r.Response.WriteStatus(http.StatusUnauthorized)
+			return
+		}
+		if opt.CAs != nil {
+			intermediates := x509.NewCertPool()
+			for _, cert := range r.TLS.PeerCertificates[1:] {
+				intermediates.AddCert(cert)
+			}
+			_, err := r.TLS.PeerCertificates[0].Verify(x509.VerifyOptions{
+				Roots:         opt.CAs,
+				Intermediates: intermediates,
+				KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			})
+			if err != nil {
+				r.Response.WriteStatus(http.StatusForbidden)
+				return
+			}
+			r.certVerified = true
+		}
+		info := r.GetClientTLSInfo()
+		if !info.Verified {
+			r.Response.WriteStatus(http.StatusForbidden)
+			return
+		}
+		if len(opt.AllowedNames) > 0 && !isClientCertAllowed(info, opt.AllowedNames) {
+			r.Response.WriteStatus(http.StatusForbidden)
+			return
+		}
+		r.Middleware.Next()
+	}
+}
+
+// isClientCertAllowed checks whether the common name or any DNS name of certificate is in `names`.
+func isClientCertAllowed(info *ClientTLSInfo, names []string) bool {
+	if gstr.InArray(names, info.CommonName) {
+		return true
+	}
+	for _, name := range info.DNSNames {
+		if gstr.InArray(names, name) {
+			return true
+		}
+	}
+	return false
+}
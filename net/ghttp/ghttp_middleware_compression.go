@@ -0,0 +1,199 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gogf/gf/v2/encoding/gcompress"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+const (
+	CompressionGzip   = "gzip"
+	CompressionBrotli = "br"
+	CompressionZstd   = "zstd"
+)
+
+const (
+	defaultCompressionMinSize = 1024
+	headerAcceptEncoding      = "Accept-Encoding"
+	headerContentEncoding     = "Content-Encoding"
+)
+
+// defaultCompressionContentTypes are the compressible content types if CompressionOption.ContentTypes is empty.
+var defaultCompressionContentTypes = []string{
+	"text/",
+	"application/json",
+	"application/javascript",
+	"application/xml",
+	"application/x-javascript",
+	"image/svg+xml",
+}
+
+// CompressionOption is the option for MiddlewareCompression.
+type CompressionOption struct {
+	MinSize      int      // Min size of response content to compress, which is 1024 if 0. Use -1 for no limit.
+	ContentTypes []string // Prefixes of compressible content types, common text types in default.
+	Encodings    []string // Supported encodings in order of preference, which is br, zstd and gzip in default.
+	Level        int      // Compression level of the chosen encoding, its default level is used if 0.
+}
+
+// MiddlewareCompression returns a middleware compressing the buffered response content
+// using the encoding negotiated with request header Accept-Encoding, eg:
+//
+//	s.Use(ghttp.MiddlewareCompression(ghttp.CompressionOption{MinSize: 512}))
+//
+// The content that is already encoded, streamed or flushed to client is not compressed.
+// The br and zstd encodings are negotiated only if the adapters of gcompress are imported, eg:
+// github.com/gogf/gf/contrib/compress/brotli/v2 and github.com/gogf/gf/contrib/compress/zstd/v2.
+// It should be bound before MiddlewareHandlerResponse, so that the response content is written before compressing.
+func MiddlewareCompression(option ...CompressionOption) HandlerFunc {
+	var opt CompressionOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.MinSize == 0 {
+		opt.MinSize = defaultCompressionMinSize
+	}
+	if len(opt.ContentTypes) == 0 {
+		opt.ContentTypes = defaultCompressionContentTypes
+	}
+	if len(opt.Encodings) == 0 {
+		opt.Encodings = []string{CompressionBrotli, CompressionZstd, CompressionGzip}
+	}
+	return func(r *Request) {
+		header := r.Response.Header()
+		header.Add("Vary", headerAcceptEncoding)
+		r.Middleware.Next()
+
+		if r.Method == http.MethodHead ||
+			r.Response.IsHijacked() ||
+			r.Response.BytesWritten() > 0 ||
+			r.Response.BufferLength() == 0 ||
+			r.Response.BufferLength() < opt.MinSize ||
+			header.Get(headerContentEncoding) != "" {
+			return
+		}
+		switch r.Response.Status {
+		case http.StatusNoContent, http.StatusNotModified, http.StatusPartialContent:
+			return
+		}
+		content := r.Response.Buffer()
+		contentType := header.Get("Content-Type")
+		if contentType == "" {
+			// The content type is not sniffed by net/http for encoded content.
+			contentType = http.DetectContentType(content)
+			header.Set("Content-Type", contentType)
+		}
+		if !isCompressibleContentType(contentType, opt.ContentTypes) {
+			return
+		}
+		encoding := negotiateEncoding(r.Header.Get(headerAcceptEncoding), opt.Encodings)
+		if encoding == "" {
+			return
+		}
+		compressed, err := compressContent(encoding, content, opt.Level)
+		if err != nil {
+			r.SetError(err)
+			return
+		}
+		r.Response.SetBuffer(compressed)
+		header.Set(headerContentEncoding, encoding)
+		header.Del(responseHeaderContentLength)
+	}
+}
+
+// compressContent compresses `content` using `encoding`.
+func compressContent(encoding string, content []byte, level int) ([]byte, error) {
+	var levels []int
+	if level != 0 {
+		levels = []int{level}
+	}
+	switch encoding {
+	case CompressionBrotli:
+		return gcompress.Brotli(content, levels...)
+	case CompressionZstd:
+		return gcompress.Zstd(content, levels...)
+	default:
+		return gcompress.Gzip(content, levels...)
+	}
+}
+
+// isCompressibleContentType checks whether `contentType` matches any of `prefixes`.
+func isCompressibleContentType(contentType string, prefixes []string) bool {
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(contentType, strings.ToLower(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// negotiateEncoding returns the encoding of `supported` with the highest quality in `acceptEncoding`,
+// in which the earlier one of `supported` is preferred for the same quality.
+// It returns empty string if none is acceptable.
+func negotiateEncoding(acceptEncoding string, supported []string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+	var (
+		qualities    = make(map[string]float64)
+		wildcard     = -1.0
+		bestEncoding string
+		bestQuality  float64
+	)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		var (
+			items   = strings.Split(part, ";")
+			name    = strings.ToLower(strings.TrimSpace(items[0]))
+			quality = 1.0
+		)
+		for _, param := range items[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				quality = gconv.Float64(strings.TrimSpace(param[2:]))
+			}
+		}
+		if name == "*" {
+			wildcard = quality
+		} else if name != "" {
+			qualities[name] = quality
+		}
+	}
+	for _, encoding := range supported {
+		if !isEncodingAvailable(encoding) {
+			continue
+		}
+		quality, ok := qualities[encoding]
+		if !ok {
+			if wildcard < 0 {
+				continue
+			}
+			quality = wildcard
+		}
+		if quality > bestQuality {
+			bestEncoding, bestQuality = encoding, quality
+		}
+	}
+	return bestEncoding
+}
+
+// isEncodingAvailable checks whether `encoding` can be used for compressing,
+// as the encodings not in the standard library need the adapters of gcompress.
+func isEncodingAvailable(encoding string) bool {
+	switch encoding {
+	case CompressionBrotli:
+		return gcompress.GetBrotliAdapter() != nil
+	case CompressionZstd:
+		return gcompress.GetZstdAdapter() != nil
+	default:
+		return true
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gview"
+)
+
+const (
+	CSRFModeCookie  = "cookie"  // Double-submit cookie mode, the token is stored in cookie readable by scripts.
+	CSRFModeSession = "session" // Synchronizer token mode, the token is stored in session.
+)
+
+const (
+	// Template variables of the CSRF token, which are used in templates like:
+	// <input type="hidden" name="{{.CSRFField}}" value="{{.CSRFToken}}">
+	CSRFViewVarToken = "CSRFToken"
+	CSRFViewVarField = "CSRFField"
+)
+
+const (
+	defaultCSRFTokenLength             = 32
+	defaultCSRFCookieName              = "_csrf"
+	defaultCSRFHeaderName              = "X-CSRF-Token"
+	defaultCSRFFormField               = "_csrf"
+	csrfSessionKey                     = "gf.ghttp.csrf.token"
+	ctxKeyForCSRFToken     gctx.StrKey = "gHttpCSRFToken"
+)
+
+// CSRFOption is the option for MiddlewareCSRF.
+type CSRFOption struct {
+	Mode          string      // CSRFModeCookie or CSRFModeSession, which is CSRFModeCookie in default.
+	TokenLength   int         // Byte length of random token, which is 32 in default.
+	CookieName    string      // Cookie name of the token in CSRFModeCookie, which is "_csrf" in default.
+	HeaderName    string      // Request header submitting the token, which is "X-CSRF-Token" in default.
+	FormField     string      // Form field submitting the token, which is "_csrf" in default.
+	ExcludeRoutes []string    // Route patterns or paths not checked, eg: "/api/*" for all paths with prefix "/api/".
+	ErrorHandler  HandlerFunc // Handler for failed requests, which responds http.StatusForbidden in default.
+}
+
+// MiddlewareCSRF returns a middleware of CSRF protection using tokens, eg:
+//
+//	group.Middleware(ghttp.MiddlewareCSRF(ghttp.CSRFOption{
+//	    ExcludeRoutes: []string{"/api/*"},
+//	}))
+//
+// It creates the token of the client if it has no token, which is assigned to templates as variable "CSRFToken"
+// and can also be retrieved using Request.GetCSRFToken. The requests of unsafe methods like POST should
+// submit the token using header "X-CSRF-Token" or form field "_csrf", or else they are rejected.
+func MiddlewareCSRF(option ...CSRFOption) HandlerFunc {
+	var opt CSRFOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Mode == "" {
+		opt.Mode = CSRFModeCookie
+	}
+	if opt.TokenLength <= 0 {
+		opt.TokenLength = defaultCSRFTokenLength
+	}
+	if opt.CookieName == "" {
+		opt.CookieName = defaultCSRFCookieName
+	}
+	if opt.HeaderName == "" {
+		opt.HeaderName = defaultCSRFHeaderName
+	}
+	if opt.FormField == "" {
+		opt.FormField = defaultCSRFFormField
+	}
+	return func(r *Request) {
+		if r.matchRoutes(opt.ExcludeRoutes) {
+			r.Middleware.Next()
+			return
+		}
+		token, err := getOrCreateCSRFToken(r, opt)
+		if err != nil {
+			r.SetError(err)
+			r.Response.WriteStatus(http.StatusInternalServerError)
+			return
+		}
+		r.SetCtxVar(ctxKeyForCSRFToken, token)
+		r.Assigns(gview.Params{
+			CSRFViewVarToken: token,
+			CSRFViewVarField: opt.FormField,
+		})
+
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		default:
+			submitted := r.Header.Get(opt.HeaderName)
+			if submitted == "" {
+				submitted = r.GetForm(opt.FormField).String()
+			}
+			if submitted == "" || subtle.ConstantTimeCompare([]byte(submitted), []byte(token)) != 1 {
+				if opt.ErrorHandler != nil {
+					opt.ErrorHandler(r)
+				} else {
+					r.Response.WriteStatus(http.StatusForbidden, `invalid CSRF token`)
+				}
+				return
+			}
+		}
+		r.Middleware.Next()
+	}
+}
+
+// GetCSRFToken returns the CSRF token of current client, which is available with MiddlewareCSRF.
+func (r *Request) GetCSRFToken() string {
+	return r.GetCtxVar(ctxKeyForCSRFToken).String()
+}
+
+// getOrCreateCSRFToken returns the token stored in cookie or session, and creates one if it has no token.
+// Note that the new token created for unsafe methods fails the checking, as the client submits no token.
+func getOrCreateCSRFToken(r *Request, opt CSRFOption) (string, error) {
+	var token string
+	if opt.Mode == CSRFModeSession {
+		v, err := r.Session.Get(csrfSessionKey)
+		if err != nil {
+			return "", err
+		}
+		token = v.String()
+	} else {
+		token = r.Cookie.Get(opt.CookieName).String()
+	}
+	if token != "" {
+		return token, nil
+	}
+	buffer := make([]byte, opt.TokenLength)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+	token = hex.EncodeToString(buffer)
+	if opt.Mode == CSRFModeSession {
+		return token, r.Session.Set(csrfSessionKey, token)
+	}
+	// The cookie should be readable by scripts, which submit it using header.
+	r.Cookie.SetCookie(
+		opt.CookieName,
+		token,
+		r.Server.GetCookieDomain(),
+		r.Server.GetCookiePath(),
+		r.Server.GetCookieMaxAge(),
+		CookieOptions{
+			SameSite: r.Server.GetCookieSameSite(),
+			Secure:   r.Server.GetCookieSecure() || r.TLS != nil,
+		},
+	)
+	return token, nil
+}
@@ -0,0 +1,40 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"strings"
+
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gfeature"
+)
+
+const (
+	// HeaderFeatureFlags is the response header of enabled feature flags, which are separated by comma.
+	HeaderFeatureFlags = "X-Feature-Flags"
+)
+
+// MiddlewareFeature evaluates all feature flags for current request, and then exposes the enabled
+// flags in response header HeaderFeatureFlags.
+//
+// The evaluated flags are stored in the request context, so that the gfeature.Enabled using
+// the request context returns the same result in the whole request. The attributes for evaluating
+// should be set in context using gfeature.WithAttributes by the middleware before this one,
+// like authentication middleware.
+func MiddlewareFeature(r *Request) {
+	ctx, err := gfeature.WithEvaluated(r.Context())
+	if err != nil {
+		intlog.Errorf(r.Context(), `%+v`, err)
+	} else {
+		r.SetCtx(ctx)
+		evaluated, _ := gfeature.Evaluate(ctx)
+		if names := gfeature.EnabledNames(evaluated); len(names) > 0 {
+			r.Response.Header().Set(HeaderFeatureFlags, strings.Join(names, ","))
+		}
+	}
+	r.Middleware.Next()
+}
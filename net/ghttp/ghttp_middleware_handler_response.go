@@ -29,6 +29,11 @@ func MiddlewareHandlerResponse(r *Request) {
 		return
 	}
 
+	// The response is already written directly, like streaming, it then exits current handler.
+	if r.Response.Writer.BytesWritten() > 0 {
+		return
+	}
+
 	var (
 		msg  string
 		err  = r.GetError()
@@ -59,9 +64,15 @@ func MiddlewareHandlerResponse(r *Request) {
 		}
 	}
 
-	r.Response.WriteJson(DefaultHandlerResponse{
+	response := DefaultHandlerResponse{
 		Code:    code.Code(),
 		Message: msg,
 		Data:    res,
-	})
+	}
+	// The client prefers MessagePack to JSON, which is commonly used between services.
+	if r.IsMsgPackAccepted() {
+		r.Response.WriteMsgPack(response)
+		return
+	}
+	r.Response.WriteJson(response)
 }
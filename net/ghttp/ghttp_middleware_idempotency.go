@@ -0,0 +1,183 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gogf/gf/v2/crypto/gsha1"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+const (
+	defaultIdempotencyHeader     = "Idempotency-Key"
+	defaultIdempotencyTTL        = 24 * time.Hour
+	defaultIdempotencyLockTTL    = time.Minute
+	idempotencyReplayedHeader    = "Idempotency-Replayed"
+	idempotencyCacheKeyPrefix    = "gf.ghttp.idempotency:"
+	idempotencyRecordProcessing  = 0
+	idempotencyMaxRequestKeySize = 255
+)
+
+// IdempotencyOption is the option for MiddlewareIdempotency.
+type IdempotencyOption struct {
+	Header  string        // Request header of the idempotency key, which is "Idempotency-Key" in default.
+	TTL     time.Duration // Duration of the response stored for replaying, which is 24 hours in default.
+	LockTTL time.Duration // Max duration of handling the first request, which is 1 minute in default.
+	Methods []string      // Request methods using idempotency key, which are POST and PATCH in default.
+	Routes  []string      // Route patterns, paths or path prefixes like "/api/*" using idempotency key, all routes if empty.
+
+	// KeyScope returns the scope of the idempotency key for the request, like the id of the authenticated user,
+	// so that the same key from different clients does not replay the response to each other.
+	// It is the Authorization header in default, which should be customized if other authentication is used.
+	KeyScope func(r *Request) string
+
+	// Cache stores the responses, which is an in-memory cache in default.
+	// It should use a shared cache like gcache.NewAdapterRedis for multiple server nodes.
+	Cache *gcache.Cache
+}
+
+// idempotencyRecord is the stored response of the first request using the idempotency key.
+type idempotencyRecord struct {
+	Fingerprint string      `json:"fingerprint"` // Fingerprint of the request body.
+	Status      int         `json:"status"`      // Response status, which is 0 if the request is in handling.
+	Header      http.Header `json:"header"`      // Response header.
+	Body        []byte      `json:"body"`        // Response body.
+}
+
+// MiddlewareIdempotency returns a middleware that handles the requests with the same idempotency key only once,
+// which stores the first response and replays it for the retried requests within the TTL, eg:
+//
+//	group.Middleware(ghttp.MiddlewareIdempotency(ghttp.IdempotencyOption{
+//	    Cache: gcache.NewWithAdapter(gcache.NewAdapterRedis(g.Redis())),
+//	}))
+//
+// The replayed response has header "Idempotency-Replayed: true". The retried request is responded with
+// http.StatusConflict if the first one is still in handling, and http.StatusUnprocessableEntity if its body
+// differs from the first one. The responses of 5XX status are not stored, so that the requests can be retried.
+//
+// It should be bound before MiddlewareHandlerResponse, so that the written response is stored.
+func MiddlewareIdempotency(option ...IdempotencyOption) HandlerFunc {
+	var opt IdempotencyOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Header == "" {
+		opt.Header = defaultIdempotencyHeader
+	}
+	if opt.TTL <= 0 {
+		opt.TTL = defaultIdempotencyTTL
+	}
+	if opt.LockTTL <= 0 {
+		opt.LockTTL = defaultIdempotencyLockTTL
+	}
+	if len(opt.Methods) == 0 {
+		opt.Methods = []string{http.MethodPost, http.MethodPatch}
+	}
+	if opt.Cache == nil {
+		opt.Cache = gcache.New()
+	}
+	if opt.KeyScope == nil {
+		opt.KeyScope = func(r *Request) string {
+			return r.Header.Get("Authorization")
+		}
+	}
+	return func(r *Request) {
+		key := r.Header.Get(opt.Header)
+		if key == "" || !gstr.InArray(opt.Methods, r.Method) || (len(opt.Routes) > 0 && !r.matchRoutes(opt.Routes)) {
+			r.Middleware.Next()
+			return
+		}
+		if len(key) > idempotencyMaxRequestKeySize {
+			r.Response.WriteStatus(http.StatusBadRequest, `idempotency key is too long`)
+			return
+		}
+		var (
+			ctx         = r.Context()
+			scope       = gsha1.Encrypt(opt.KeyScope(r))
+			cacheKey    = idempotencyCacheKeyPrefix + scope + ":" + r.Method + ":" + r.URL.Path + ":" + key
+			fingerprint = gsha1.Encrypt(r.GetBody())
+			processing  = idempotencyRecord{Fingerprint: fingerprint, Status: idempotencyRecordProcessing}
+		)
+		ok, err := opt.Cache.SetIfNotExist(ctx, cacheKey, idempotencyEncode(processing), opt.LockTTL)
+		if err != nil {
+			r.SetError(err)
+			r.Response.WriteStatus(http.StatusInternalServerError)
+			return
+		}
+		if !ok {
+			replayIdempotencyRecord(r, opt, cacheKey, fingerprint)
+			return
+		}
+
+		r.Middleware.Next()
+
+		// The streamed or server error responses are not stored.
+		status := r.Response.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		if r.Response.BytesWritten() > 0 || status >= http.StatusInternalServerError || r.GetError() != nil {
+			_, _ = opt.Cache.Remove(ctx, cacheKey)
+			return
+		}
+		record := idempotencyRecord{
+			Fingerprint: fingerprint,
+			Status:      status,
+			Header:      r.Response.Header().Clone(),
+			Body:        r.Response.Buffer(),
+		}
+		// The replayed response uses the trace id and cookies of the retried request,
+		// as the cookies like session id should not be shared with other clients.
+		record.Header.Del(responseHeaderTraceID)
+		record.Header.Del("Set-Cookie")
+		if err = opt.Cache.Set(ctx, cacheKey, idempotencyEncode(record), opt.TTL); err != nil {
+			r.SetError(err)
+		}
+	}
+}
+
+// replayIdempotencyRecord responds the stored response of `cacheKey`.
+func replayIdempotencyRecord(r *Request, opt IdempotencyOption, cacheKey, fingerprint string) {
+	v, err := opt.Cache.Get(r.Context(), cacheKey)
+	if err != nil {
+		r.SetError(err)
+		r.Response.WriteStatus(http.StatusInternalServerError)
+		return
+	}
+	var record idempotencyRecord
+	if v.IsNil() || json.Unmarshal(v.Bytes(), &record) != nil {
+		// It is expired just now, which is handled as the first request in next retry.
+		r.Response.WriteStatus(http.StatusConflict, `request with the same idempotency key is in handling`)
+		return
+	}
+	if record.Fingerprint != fingerprint {
+		r.Response.WriteStatus(
+			http.StatusUnprocessableEntity, `idempotency key is already used by request with different body`,
+		)
+		return
+	}
+	if record.Status == idempotencyRecordProcessing {
+		r.Response.WriteStatus(http.StatusConflict, `request with the same idempotency key is in handling`)
+		return
+	}
+	header := r.Response.Header()
+	for k, values := range record.Header {
+		header[k] = values
+	}
+	header.Set(idempotencyReplayedHeader, "true")
+	r.Response.WriteHeader(record.Status)
+	r.Response.Write(record.Body)
+}
+
+func idempotencyEncode(record idempotencyRecord) string {
+	b, _ := json.Marshal(record)
+	return string(b)
+}
@@ -0,0 +1,213 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/ghttp/internal/response"
+)
+
+// TimeoutOption is the option for MiddlewareTimeout.
+type TimeoutOption struct {
+	Status  int         // Response status on timeout, which is http.StatusServiceUnavailable in default.
+	Content interface{} // Response content on timeout, which is the status text in default.
+	Handler HandlerFunc // Custom handler writing the timeout response, which overrides Status and Content.
+}
+
+// timeoutWriter is the underlying http.ResponseWriter of the handling with timeout,
+// which records the flushed response instead of sending it to client,
+// so that the response can be discarded on timeout.
+type timeoutWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+// MiddlewareTimeout returns a middleware that responds with the timeout response
+// if the handling exceeds `timeout`, eg:
+//
+//	group.Middleware(ghttp.MiddlewareTimeout(3*time.Second, ghttp.TimeoutOption{
+//	    Status: http.StatusGatewayTimeout,
+//	}))
+//
+// The following middlewares and handler are running in another goroutine with a copy of the request,
+// whose response is buffered and copied to the request only if the handling completes in time.
+// On timeout, the request context passed to the handler is cancelled and the timeout response is sent
+// without waiting for the handler, whose response is discarded. So the handler should pass r.Context()
+// to the operations like gdb and gclient calls, and stop as soon as the context is done.
+//
+// Note that the content flushed by the handler is sent to client only after the handling completes,
+// so it does not work for streaming responses like Server-Sent Events and WebSocket.
+//
+// It should be bound before MiddlewareHandlerResponse, so that the timeout response overwrites the error response.
+func MiddlewareTimeout(timeout time.Duration, option ...TimeoutOption) HandlerFunc {
+	var opt TimeoutOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Status == 0 {
+		opt.Status = http.StatusServiceUnavailable
+	}
+	return func(r *Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		var (
+			writer    = &timeoutWriter{header: r.Response.Header().Clone()}
+			request   = r.newTimeoutRequest(ctx, writer)
+			done      = make(chan struct{})
+			exception interface{}
+		)
+		go func() {
+			defer func() {
+				exception = recover()
+				close(done)
+			}()
+			request.Middleware.Next()
+		}()
+		select {
+		case <-done:
+			if exception != nil {
+				panic(exception)
+			}
+			r.mergeTimeoutRequest(request, writer)
+			return
+
+		case <-ctx.Done():
+		}
+		if ctx.Err() != context.DeadlineExceeded || r.Response.BytesWritten() > 0 {
+			return
+		}
+		r.SetError(gerror.NewCodef(
+			gcode.CodeServerBusy, `request "%s %s" exceeds timeout %s`, r.Method, r.URL.Path, timeout,
+		))
+		r.Response.ClearBuffer()
+		if opt.Handler != nil {
+			opt.Handler(r)
+			return
+		}
+		if opt.Content != nil {
+			r.Response.WriteStatus(opt.Status, opt.Content)
+		} else {
+			r.Response.WriteStatus(opt.Status)
+		}
+	}
+}
+
+// newTimeoutRequest creates and returns a copy of the request with context `ctx` for handling with timeout,
+// which has its own response writing to `writer`, middleware manager, cookie and parameters,
+// so that it does not change the request if it is still running after timeout.
+func (r *Request) newTimeoutRequest(ctx context.Context, writer *timeoutWriter) *Request {
+	request := new(Request)
+	*request = *r
+	request.Request = r.Request.WithContext(ctx)
+	request.Response = &Response{
+		BufferWriter: response.NewBufferWriter(writer),
+		Server:       r.Server,
+		Request:      request,
+		bandwidth:    r.Response.bandwidth,
+	}
+	request.Response.Status = r.Response.Status
+	request.Response.SetBuffer(r.Response.Buffer())
+	request.Middleware = &middleware{
+		served:         r.Middleware.served,
+		request:        request,
+		handlerIndex:   r.Middleware.handlerIndex,
+		handlerMDIndex: r.Middleware.handlerMDIndex,
+	}
+	request.Cookie = &Cookie{
+		server:  r.Server,
+		request: request,
+	}
+	if r.Cookie != nil && r.Cookie.data != nil {
+		request.Cookie.data = make(map[string]*cookieItem, len(r.Cookie.data))
+		for k, v := range r.Cookie.data {
+			request.Cookie.data[k] = v
+		}
+		request.Cookie.response = request.Response
+	}
+	if r.paramsMap != nil {
+		request.paramsMap = make(map[string]interface{}, len(r.paramsMap))
+		for k, v := range r.paramsMap {
+			request.paramsMap[k] = v
+		}
+	}
+	if r.viewParams != nil {
+		request.viewParams = make(map[string]interface{}, len(r.viewParams))
+		for k, v := range r.viewParams {
+			request.viewParams[k] = v
+		}
+	}
+	return request
+}
+
+// mergeTimeoutRequest merges the state of `request` created by newTimeoutRequest back to the request,
+// after its handling completes in time.
+func (r *Request) mergeTimeoutRequest(request *Request, writer *timeoutWriter) {
+	var (
+		httpRequest = r.Request
+		resp        = r.Response
+		middleware  = r.Middleware
+		cookie      = r.Cookie
+	)
+	// The underlying http.Request is kept, as it is also referred by the server.
+	*httpRequest = *request.Request
+	*r = *request
+	r.Request, r.Response, r.Middleware, r.Cookie = httpRequest, resp, middleware, cookie
+
+	middleware.served = request.Middleware.served
+	middleware.handlerIndex = request.Middleware.handlerIndex
+	middleware.handlerMDIndex = request.Middleware.handlerMDIndex
+	if cookie != nil && request.Cookie.data != nil {
+		cookie.data = request.Cookie.data
+		cookie.response = resp
+	}
+
+	header := resp.Header()
+	for k := range header {
+		delete(header, k)
+	}
+	for k, v := range writer.header {
+		header[k] = v
+	}
+	if writer.status != 0 {
+		resp.BufferWriter.Writer.WriteHeader(writer.status)
+		if writer.body.Len() > 0 {
+			_, _ = resp.BufferWriter.Writer.Write(writer.body.Bytes())
+		}
+	}
+	resp.Status = request.Response.Status
+	resp.SetBuffer(request.Response.Buffer())
+	resp.bandwidth = request.Response.bandwidth
+}
+
+// Header implements the interface function of http.ResponseWriter.Header.
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+// Write implements the interface function of http.ResponseWriter.Write.
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(data)
+}
+
+// WriteHeader implements the interface function of http.ResponseWriter.WriteHeader.
+// The informational status like http.StatusEarlyHints is ignored.
+func (w *timeoutWriter) WriteHeader(status int) {
+	if w.status == 0 && status >= 200 {
+		w.status = status
+	}
+}
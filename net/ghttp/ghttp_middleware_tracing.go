@@ -7,9 +7,12 @@
 package ghttp
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"net/http"
+	"strings"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -18,6 +21,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/encoding/gcompress"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/internal/httputil"
 	"github.com/gogf/gf/v2/internal/utils"
@@ -36,9 +40,13 @@ const (
 	tracingEventHttpResponseHeaders             = "http.response.headers"
 	tracingEventHttpResponseBody                = "http.response.body"
 	tracingEventHttpRequestUrl                  = "http.request.url"
+	tracingAttrKeyHttpUrl                       = "http.url"
 	tracingMiddlewareHandled        gctx.StrKey = `MiddlewareServerTracingHandled`
 )
 
+// SpanNameFunc is the function naming the server tracing span of request.
+type SpanNameFunc func(r *Request) string
+
 // internalMiddlewareServerTracing is a serer middleware that enables tracing feature using standards of OpenTelemetry.
 func internalMiddlewareServerTracing(r *Request) {
 	var (
@@ -46,7 +54,7 @@ func internalMiddlewareServerTracing(r *Request) {
 	)
 	// Mark this request is handled by server tracing middleware,
 	// to avoid repeated handling by the same middleware.
-	if ctx.Value(tracingMiddlewareHandled) != nil {
+	if ctx.Value(tracingMiddlewareHandled) != nil || isTracingRouteMatched(r, r.Server.config.TracingExcludeRoutes) {
 		r.Middleware.Next()
 		return
 	}
@@ -60,16 +68,17 @@ func internalMiddlewareServerTracing(r *Request) {
 		)
 	)
 	ctx, span = tr.Start(
-		otel.GetTextMapPropagator().Extract(
+		r.Server.getPropagator().Extract(
 			ctx,
 			propagation.HeaderCarrier(r.Header),
 		),
-		r.URL.Path,
+		r.Server.getSpanName(r),
 		trace.WithSpanKind(trace.SpanKindServer),
 	)
 	defer span.End()
 
 	span.SetAttributes(gtrace.CommonLabels()...)
+	span.SetAttributes(attribute.String(tracingAttrKeyHttpUrl, gtrace.RedactContent(ctx, r.GetUrl())))
 
 	// Inject tracing context.
 	r.SetCtx(ctx)
@@ -80,32 +89,49 @@ func internalMiddlewareServerTracing(r *Request) {
 		return
 	}
 
+	var (
+		err          error
+		config       = r.Server.config
+		bodyExcluded = isTracingRouteMatched(r, config.TracingBodyExcludeRoutes)
+		reqAttrs     = []attribute.KeyValue{
+			attribute.String(tracingEventHttpRequestUrl, gtrace.RedactContent(ctx, r.URL.String())),
+			attribute.String(tracingEventHttpRequestHeaders, r.Server.tracingHeaderContent(ctx, r.Header)),
+			attribute.String(tracingEventHttpRequestBaggage, gtrace.GetBaggageMap(ctx).String()),
+		}
+	)
+
 	// Request content logging.
-	reqBodyContentBytes, err := io.ReadAll(r.Body)
-	if err != nil {
-		r.SetError(gerror.Wrap(err, `read request body failed`))
-		span.SetStatus(codes.Error, fmt.Sprintf(`%+v`, err))
-		return
-	}
-	r.Body = utils.NewReadCloser(reqBodyContentBytes, false)
-	reqBodyContent, err := gtrace.SafeContentForHttp(reqBodyContentBytes, r.Header)
-	if err != nil {
-		span.SetStatus(codes.Error, fmt.Sprintf(`converting safe content failed: %s`, err.Error()))
+	if !bodyExcluded {
+		reqBodyContentBytes, truncated, err := readTracingRequestBody(r, config.TracingMaxRequestBodySize)
+		if err != nil {
+			r.SetError(gerror.Wrap(err, `read request body failed`))
+			span.SetStatus(codes.Error, fmt.Sprintf(`%+v`, err))
+			return
+		}
+		reqBodyContent, err := tracingBodyContent(
+			reqBodyContentBytes, r.Header, config.TracingMaxRequestBodySize, truncated,
+		)
+		if err != nil {
+			span.SetStatus(codes.Error, fmt.Sprintf(`converting safe content failed: %s`, err.Error()))
+		}
+		reqAttrs = append(reqAttrs, attribute.String(tracingEventHttpRequestBody, gtrace.RedactContent(ctx, reqBodyContent)))
 	}
-
-	span.AddEvent(tracingEventHttpRequest, trace.WithAttributes(
-		attribute.String(tracingEventHttpRequestUrl, r.URL.String()),
-		attribute.String(tracingEventHttpRequestHeaders, gconv.String(httputil.HeaderToMap(r.Header))),
-		attribute.String(tracingEventHttpRequestBaggage, gtrace.GetBaggageMap(ctx).String()),
-		attribute.String(tracingEventHttpRequestBody, reqBodyContent),
-	))
+	span.AddEvent(tracingEventHttpRequest, trace.WithAttributes(reqAttrs...))
 
 	// Continue executing.
 	r.Middleware.Next()
 
-	// parse after set route as span name
+	// The custom span name function may use the request attributes set by handler.
+	if r.Server.spanNameFunc != nil {
+		span.SetName(r.Server.getSpanName(r))
+	}
 	if handler := r.GetServeHandler(); handler != nil && handler.Handler.Router != nil {
-		span.SetName(handler.Handler.Router.Uri)
+		span.SetAttributes(attribute.String(metricAttrKeyHttpRoute, handler.Handler.Router.Uri))
+	}
+	// The attributes share the same keys with server metrics.
+	span.SetAttributes(attribute.String(metricAttrKeyHttpRequestMethod, r.Method))
+	if r.Response.Status != 0 {
+		span.SetAttributes(attribute.Int(metricAttrKeyHttpResponseStatusCode, r.Response.Status))
 	}
 
 	// Error logging.
@@ -114,13 +140,141 @@ func internalMiddlewareServerTracing(r *Request) {
 	}
 
 	// Response content logging.
-	resBodyContent, err := gtrace.SafeContentForHttp(r.Response.Buffer(), r.Response.Header())
-	if err != nil {
-		span.SetStatus(codes.Error, fmt.Sprintf(`converting safe content failed: %s`, err.Error()))
+	resAttrs := []attribute.KeyValue{
+		attribute.String(tracingEventHttpResponseHeaders, r.Server.tracingHeaderContent(ctx, r.Response.Header())),
 	}
+	if !bodyExcluded {
+		resBodyContent, err := tracingBodyContent(
+			r.Response.Buffer(), r.Response.Header(), config.TracingMaxResponseBodySize, false,
+		)
+		if err != nil {
+			span.SetStatus(codes.Error, fmt.Sprintf(`converting safe content failed: %s`, err.Error()))
+		}
+		resAttrs = append(resAttrs, attribute.String(tracingEventHttpResponseBody, gtrace.RedactContent(ctx, resBodyContent)))
+	}
+	span.AddEvent(tracingEventHttpResponse, trace.WithAttributes(resAttrs...))
+}
 
-	span.AddEvent(tracingEventHttpResponse, trace.WithAttributes(
-		attribute.String(tracingEventHttpResponseHeaders, gconv.String(httputil.HeaderToMap(r.Response.Header()))),
-		attribute.String(tracingEventHttpResponseBody, resBodyContent),
-	))
+// getSpanName returns the server span name of `r` using the custom SpanNameFunc,
+// or else the request method and matched route pattern like "GET /user/{id}", which keeps the low cardinality
+// of span names. It is only the request method if no route matches, eg: static files.
+func (s *Server) getSpanName(r *Request) string {
+	if s.spanNameFunc != nil {
+		if name := s.spanNameFunc(r); name != "" {
+			return name
+		}
+	}
+	if handler := r.GetServeHandler(); handler != nil && handler.Handler.Router != nil {
+		return r.Method + " " + handler.Handler.Router.Uri
+	}
+	return r.Method
+}
+
+// getPropagator returns the propagator of TracingPropagators, or the global propagator if not configured.
+func (s *Server) getPropagator() propagation.TextMapPropagator {
+	if s.propagator != nil {
+		return s.propagator
+	}
+	return otel.GetTextMapPropagator()
+}
+
+// tracingBodyReader is the request body whose head is read ahead for tracing.
+type tracingBodyReader struct {
+	io.Reader
+	io.Closer
+}
+
+// readTracingRequestBody reads the request body recorded in tracing, and resets the request body for handler.
+// It reads only `maxSize` bytes ahead if `maxSize` is greater than 0, and `truncated` is true if the body is longer.
+func readTracingRequestBody(r *Request, maxSize int64) (data []byte, truncated bool, err error) {
+	if maxSize <= 0 {
+		if data, err = io.ReadAll(r.Body); err != nil {
+			return nil, false, err
+		}
+		r.Body = utils.NewReadCloser(data, false)
+		return data, false, nil
+	}
+	if data, err = io.ReadAll(io.LimitReader(r.Body, maxSize+1)); err != nil {
+		return nil, false, err
+	}
+	if int64(len(data)) <= maxSize {
+		r.Body = utils.NewReadCloser(data, false)
+		return data, false, nil
+	}
+	r.Body = &tracingBodyReader{
+		Reader: io.MultiReader(bytes.NewReader(data), r.Body),
+		Closer: r.Body,
+	}
+	return data[:maxSize], true, nil
+}
+
+// tracingBodyContent returns the body content recorded in tracing, which is cut by `maxSize` if it is greater than 0,
+// or else by gtrace.MaxContentLogSize. The encoded content like compressed by MiddlewareCompression is skipped
+// except gzip, which is decoded.
+func tracingBodyContent(data []byte, header http.Header, maxSize int64, truncated bool) (string, error) {
+	encoding := header.Get(headerContentEncoding)
+	if encoding != "" && (encoding != CompressionGzip || truncated) {
+		if truncated {
+			return fmt.Sprintf(`[%s encoded content, more than %d bytes]`, encoding, len(data)), nil
+		}
+		return fmt.Sprintf(`[%s encoded content, %d bytes]`, encoding, len(data)), nil
+	}
+	if maxSize <= 0 {
+		return gtrace.SafeContentForHttp(data, header)
+	}
+	var err error
+	if encoding == CompressionGzip {
+		if data, err = gcompress.UnGzip(data); err != nil {
+			return string(data), err
+		}
+	}
+	if int64(len(data)) > maxSize {
+		data, truncated = data[:maxSize], true
+	}
+	content := strings.ToValidUTF8(string(data), "")
+	if truncated {
+		content += "..."
+	}
+	return content, nil
+}
+
+// tracingHeaderContent returns the header content recorded in tracing,
+// which is filtered by TracingHeaderAllowList and TracingHeaderDenyList, and redacted by gtrace.
+func (s *Server) tracingHeaderContent(ctx context.Context, header http.Header) string {
+	m := httputil.HeaderToMap(header)
+	if len(s.config.TracingHeaderAllowList) > 0 {
+		allowed := make(map[string]interface{}, len(s.config.TracingHeaderAllowList))
+		for _, key := range s.config.TracingHeaderAllowList {
+			key = http.CanonicalHeaderKey(key)
+			if v, ok := m[key]; ok {
+				allowed[key] = v
+			}
+		}
+		m = allowed
+	}
+	for _, key := range s.config.TracingHeaderDenyList {
+		delete(m, http.CanonicalHeaderKey(key))
+	}
+	return gconv.String(gtrace.RedactHeader(ctx, m))
+}
+
+// isTracingRouteMatched checks whether the route of `r` matches `routes`,
+// in which the pattern ending with "/*" matches the request paths with its prefix.
+func isTracingRouteMatched(r *Request, routes []string) bool {
+	if len(routes) == 0 {
+		return false
+	}
+	var httpRoute string
+	if handler := r.GetServeHandler(); handler != nil && handler.Handler.Router != nil {
+		httpRoute = handler.Handler.Router.Uri
+	}
+	for _, route := range routes {
+		if route == r.URL.Path || (httpRoute != "" && route == httpRoute) {
+			return true
+		}
+		if strings.HasSuffix(route, "/*") && strings.HasPrefix(r.URL.Path, route[:len(route)-1]) {
+			return true
+		}
+	}
+	return false
 }
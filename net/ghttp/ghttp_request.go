@@ -9,9 +9,12 @@ package ghttp
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/gorilla/websocket"
+
 	"github.com/gogf/gf/v2/internal/intlog"
 	"github.com/gogf/gf/v2/os/gres"
 	"github.com/gogf/gf/v2/os/gsession"
@@ -58,6 +61,9 @@ type Request struct {
 	clientIp        string                 // The parsed client ip for current host used by GetClientIp function.
 	bodyContent     []byte                 // Request body content.
 	isFileRequest   bool                   // A bool marking whether current request is file serving.
+	certVerified    bool                   // A bool marking whether the client certificate is verified by MiddlewareClientCert.
+	upstream        string                 // The upstream that ReverseProxy forwarded the request to, for access logging.
+	bodyLimiter     *bodyLimitReader       // Body size limiter of the serving route, which is nil if the route has no limit.
 	viewObject      *gview.View            // Custom template view engine object for this response.
 	viewParams      gview.Params           // Custom template view variables for this response.
 	originUrlPath   string                 // Original URL path that passed from client.
@@ -118,15 +124,38 @@ func newRequest(s *Server, r *http.Request, w http.ResponseWriter) *Request {
 // It returns a new WebSocket object if success, or the error if failure.
 // Note that the request should be a websocket request, or it will surely fail upgrading.
 //
+// The optional parameter `option` specifies the subprotocols and permessage-deflate compression,
+// which are negotiated with the client in upgrading.
+//
 // Deprecated: will be removed in the future, please use third-party websocket library instead.
-func (r *Request) WebSocket() (*WebSocket, error) {
-	if conn, err := wsUpGrader.Upgrade(r.Response.Writer, r.Request, nil); err == nil {
-		return &WebSocket{
-			conn,
-		}, nil
-	} else {
+func (r *Request) WebSocket(option ...WebSocketOption) (*WebSocket, error) {
+	upgrader := &wsUpGrader
+	if len(option) > 0 {
+		upgrader = &websocket.Upgrader{
+			Subprotocols:      option[0].Subprotocols,
+			EnableCompression: option[0].Compression,
+			CheckOrigin:       option[0].CheckOrigin,
+		}
+		if upgrader.CheckOrigin == nil {
+			upgrader.CheckOrigin = wsUpGrader.CheckOrigin
+		}
+	}
+	conn, err := upgrader.Upgrade(r.Response.Writer, r.Request, nil)
+	if err != nil {
 		return nil, err
 	}
+	if len(option) > 0 && option[0].Compression {
+		conn.EnableWriteCompression(true)
+		if option[0].CompressionLevel != 0 {
+			if err = conn.SetCompressionLevel(option[0].CompressionLevel); err != nil {
+				_ = conn.Close()
+				return nil, err
+			}
+		}
+	}
+	return &WebSocket{
+		conn,
+	}, nil
 }
 
 // Exit exits executing of current HTTP handler.
@@ -178,6 +207,54 @@ func (r *Request) IsAjaxRequest() bool {
 	return strings.EqualFold(r.Header.Get("X-Requested-With"), "XMLHttpRequest")
 }
 
+// IsMsgPackRequest checks and returns whether current request posts MessagePack content,
+// the Content-Type of which is like "application/msgpack" or "application/x-msgpack".
+func (r *Request) IsMsgPackRequest() bool {
+	return isMsgPackMediaType(r.Header.Get("Content-Type"))
+}
+
+// IsMsgPackAccepted checks and returns whether the client prefers MessagePack to JSON
+// for the response according to the "Accept" header.
+// If both are accepted with the same quality, the one listed first is preferred.
+func (r *Request) IsMsgPackAccepted() bool {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return false
+	}
+	var (
+		msgPackQuality, jsonQuality float64
+		msgPackIndex, jsonIndex     int
+	)
+	for index, item := range strings.Split(accept, ",") {
+		var (
+			parts     = strings.Split(item, ";")
+			mediaType = strings.ToLower(strings.TrimSpace(parts[0]))
+			quality   = 1.0
+		)
+		for _, param := range parts[1:] {
+			if param = strings.TrimSpace(param); strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = v
+				}
+			}
+		}
+		switch {
+		case isMsgPackMediaType(mediaType):
+			if quality > msgPackQuality {
+				msgPackQuality, msgPackIndex = quality, index
+			}
+		case mediaType == contentTypeJson || mediaType == "application/*" || mediaType == "*/*":
+			if quality > jsonQuality {
+				jsonQuality, jsonIndex = quality, index
+			}
+		}
+	}
+	if msgPackQuality == jsonQuality {
+		return msgPackQuality > 0 && msgPackIndex < jsonIndex
+	}
+	return msgPackQuality > jsonQuality
+}
+
 // GetClientIp returns the client ip of this request without port.
 // Note that this ip address might be modified by client header.
 func (r *Request) GetClientIp() string {
@@ -288,3 +365,21 @@ func (r *Request) GetHandlerResponse() interface{} {
 func (r *Request) GetServeHandler() *HandlerItemParsed {
 	return r.serveHandler
 }
+
+// matchRoutes checks whether the request matches any of `routes`, which are request paths,
+// registered route patterns, or path prefixes ending with "/*", eg: "/api/*".
+func (r *Request) matchRoutes(routes []string) bool {
+	var httpRoute string
+	if handler := r.GetServeHandler(); handler != nil && handler.Handler.Router != nil {
+		httpRoute = handler.Handler.Router.Uri
+	}
+	for _, route := range routes {
+		if route == r.URL.Path || (httpRoute != "" && route == httpRoute) {
+			return true
+		}
+		if gstr.HasSuffix(route, "/*") && gstr.HasPrefix(r.URL.Path, route[:len(route)-1]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"io"
+	"net/http"
+)
+
+// bodyLimitReader limits the request body size of the route,
+// which marks the request exceeded once the body streams in over the limit.
+type bodyLimitReader struct {
+	io.ReadCloser
+	limit    int64 // Max body size.
+	read     int64 // Size of body read.
+	exceeded bool  // Whether the body size exceeds the limit.
+}
+
+// Read implements the interface io.Reader.
+func (l *bodyLimitReader) Read(p []byte) (n int, err error) {
+	n, err = l.ReadCloser.Read(p)
+	l.read += int64(n)
+	if err != nil && err != io.EOF && l.read >= l.limit {
+		l.exceeded = true
+	}
+	return
+}
+
+// limitRequestBody limits the body size of `request` using server ClientMaxBodySize,
+// or the limit of the serving route if it has one.
+// It returns false if the request should be rejected directly as its Content-Length exceeds the route limit.
+func (s *Server) limitRequestBody(w http.ResponseWriter, request *Request) bool {
+	var limit int64
+	if request.serveHandler != nil {
+		limit = request.serveHandler.Handler.BodyLimit
+	}
+	if limit == 0 {
+		if s.config.ClientMaxBodySize > 0 {
+			request.Body = http.MaxBytesReader(w, request.Body, s.config.ClientMaxBodySize)
+		}
+		return true
+	}
+	if limit < 0 {
+		return true
+	}
+	if request.ContentLength > limit {
+		return false
+	}
+	request.bodyLimiter = &bodyLimitReader{
+		ReadCloser: http.MaxBytesReader(w, request.Body, limit),
+		limit:      limit,
+	}
+	request.Body = request.bodyLimiter
+	return true
+}
+
+// isBodyLimitExceeded checks whether the request body exceeds the limit of the serving route.
+func (r *Request) isBodyLimitExceeded() bool {
+	return r.bodyLimiter != nil && r.bodyLimiter.exceeded
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/gogf/gf/v2/container/gvar"
 	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/encoding/gmsgpack"
 	"github.com/gogf/gf/v2/encoding/gurl"
 	"github.com/gogf/gf/v2/encoding/gxml"
 	"github.com/gogf/gf/v2/errors/gcode"
@@ -120,9 +121,17 @@ func (r *Request) doParse(pointer interface{}, requestType int) error {
 	// Multiple struct, it only supports JSON type post content like:
 	// [{"id":1, "name":"john"}, {"id":, "name":"smith"}]
 	case reflect.Array, reflect.Slice:
-		// If struct slice conversion, it might post JSON/XML/... content,
+		// If struct slice conversion, it might post JSON/XML/MessagePack/... content,
 		// so it uses `gjson` for the conversion.
-		j, err := gjson.LoadContent(r.GetBody())
+		var (
+			j   *gjson.Json
+			err error
+		)
+		if r.IsMsgPackRequest() {
+			j, err = gjson.LoadMsgPack(r.GetBody())
+		} else {
+			j, err = gjson.LoadContent(r.GetBody())
+		}
 		if err != nil {
 			return err
 		}
@@ -235,6 +244,13 @@ func (r *Request) parseBody() {
 		return
 	}
 	if body := r.GetBody(); len(body) > 0 {
+		// MessagePack format, which is binary content that cannot be trimmed.
+		if r.IsMsgPackRequest() {
+			if result, err := gmsgpack.Decode(body); err == nil {
+				r.bodyMap, _ = result.(map[string]interface{})
+			}
+			return
+		}
 		// Trim space/new line characters.
 		body = bytes.TrimSpace(body)
 		// JSON format checks.
@@ -0,0 +1,168 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"io"
+	"mime/multipart"
+	"strconv"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gtime"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// MultipartOption is the option for Request.GetMultipartReader.
+type MultipartOption struct {
+	MaxPartSize int64                                 // Max size in bytes of each part, no limit if not greater than 0.
+	MaxParts    int                                   // Max count of parts, no limit if not greater than 0.
+	Progress    func(part *MultipartPart, read int64) // Called after each read of part data with the total bytes read of the part.
+}
+
+// MultipartReader reads the parts of multipart request body one by one in streaming way,
+// which does not buffer the whole body in memory or temporary files.
+type MultipartReader struct {
+	ctx    context.Context
+	reader *multipart.Reader
+	option MultipartOption
+	count  int
+}
+
+// MultipartPart is a single part of multipart body, which is an uploading file or a form field.
+type MultipartPart struct {
+	*multipart.Part
+	ctx    context.Context
+	option *MultipartOption
+	read   int64
+}
+
+// GetMultipartReader returns a MultipartReader reading the multipart request body in streaming way,
+// which is used for uploading big files that are piped straight to storage, eg:
+//
+//	reader, err := r.GetMultipartReader(ghttp.MultipartOption{MaxPartSize: 10 << 30})
+//	for {
+//	    part, err := reader.NextPart()
+//	    if err == io.EOF {
+//	        break
+//	    }
+//	    // Reads or saves part.
+//	}
+//
+// Note that the request body is consumed by the reader, so the form parameters and uploading files
+// are not available by other functions like GetForm or GetUploadFiles, they should be read from the parts.
+// The whole body size is still limited by server configuration ClientMaxBodySize,
+// which should be enlarged for big files.
+func (r *Request) GetMultipartReader(option ...MultipartOption) (*MultipartReader, error) {
+	if r.parsedForm || r.bodyContent != nil {
+		return nil, gerror.NewCode(
+			gcode.CodeInvalidOperation,
+			`request body is already read, the multipart reader should be retrieved before parsing form`,
+		)
+	}
+	reader, err := r.Request.MultipartReader()
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidRequest, err, `r.MultipartReader failed`)
+	}
+	// Marks form parsed to avoid parsing the consumed body again.
+	r.parsedForm = true
+	m := &MultipartReader{
+		ctx:    r.Context(),
+		reader: reader,
+	}
+	if len(option) > 0 {
+		m.option = option[0]
+	}
+	return m, nil
+}
+
+// NextPart returns the next part of multipart body, or io.EOF if there are no more parts.
+// The previous part is discarded if it is not read completely.
+func (m *MultipartReader) NextPart() (*MultipartPart, error) {
+	part, err := m.reader.NextPart()
+	if err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, gerror.WrapCode(gcode.CodeInvalidRequest, err, `read next multipart part failed`)
+	}
+	m.count++
+	if m.option.MaxParts > 0 && m.count > m.option.MaxParts {
+		_ = part.Close()
+		return nil, gerror.NewCodef(gcode.CodeInvalidRequest, `multipart parts exceed max count %d`, m.option.MaxParts)
+	}
+	return &MultipartPart{
+		Part:   part,
+		ctx:    m.ctx,
+		option: &m.option,
+	}, nil
+}
+
+// IsFile checks and returns whether the part is an uploading file.
+func (p *MultipartPart) IsFile() bool {
+	return p.FileName() != ""
+}
+
+// Size returns the bytes read of the part.
+func (p *MultipartPart) Size() int64 {
+	return p.read
+}
+
+// Read implements the interface io.Reader, which checks the max part size and calls progress callback.
+func (p *MultipartPart) Read(b []byte) (n int, err error) {
+	n, err = p.Part.Read(b)
+	p.read += int64(n)
+	if maxSize := p.option.MaxPartSize; maxSize > 0 && p.read > maxSize {
+		return n, gerror.NewCodef(
+			gcode.CodeInvalidRequest, `multipart part "%s" exceeds max size %d`, p.FormName(), maxSize,
+		)
+	}
+	if n > 0 && p.option.Progress != nil {
+		p.option.Progress(p, p.read)
+	}
+	return n, err
+}
+
+// Save saves the part to directory path and returns the saved file name.
+// It uses the file name of the part, or random name if `randomlyRename` is true.
+//
+// Note that it will OVERWRITE the target file if there's already a same name file exist.
+func (p *MultipartPart) Save(dirPath string, randomlyRename ...bool) (filename string, err error) {
+	if !p.IsFile() {
+		return "", gerror.NewCodef(gcode.CodeInvalidParameter, `multipart part "%s" is not a file`, p.FormName())
+	}
+	if !gfile.Exists(dirPath) {
+		if err = gfile.Mkdir(dirPath); err != nil {
+			return
+		}
+	} else if !gfile.IsDir(dirPath) {
+		return "", gerror.NewCode(gcode.CodeInvalidParameter, `parameter "dirPath" should be a directory path`)
+	}
+	name := gfile.Basename(p.FileName())
+	if len(randomlyRename) > 0 && randomlyRename[0] {
+		name = strings.ToLower(strconv.FormatInt(gtime.TimestampNano(), 36) + grand.S(6))
+		name = name + gfile.Ext(p.FileName())
+	}
+	filePath := gfile.Join(dirPath, name)
+	newFile, err := gfile.Create(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer newFile.Close()
+	intlog.Printf(p.ctx, `save multipart part: %s`, filePath)
+	if _, err = io.Copy(newFile, p); err != nil {
+		_ = newFile.Close()
+		_ = gfile.Remove(filePath)
+		err = gerror.Wrapf(err, `io.Copy failed from "%s" to "%s"`, p.FileName(), filePath)
+		return "", err
+	}
+	return gfile.Basename(filePath), nil
+}
@@ -0,0 +1,65 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"time"
+)
+
+// ClientTLSInfo is the information of the client certificate of HTTPS request.
+type ClientTLSInfo struct {
+	Subject        string            `json:"subject"`        // Distinguished name of subject.
+	CommonName     string            `json:"commonName"`     // Common name of subject.
+	Issuer         string            `json:"issuer"`         // Distinguished name of issuer.
+	SerialNumber   string            `json:"serialNumber"`   // Serial number in hexadecimal.
+	DNSNames       []string          `json:"dnsNames"`       // DNS names of subject alternative names.
+	EmailAddresses []string          `json:"emailAddresses"` // Email addresses of subject alternative names.
+	IPAddresses    []string          `json:"ipAddresses"`    // IP addresses of subject alternative names.
+	URIs           []string          `json:"uris"`           // URIs of subject alternative names, like SPIFFE ids.
+	Fingerprint    string            `json:"fingerprint"`    // SHA-256 fingerprint of certificate in hexadecimal.
+	NotBefore      time.Time         `json:"notBefore"`      // Validity start time.
+	NotAfter       time.Time         `json:"notAfter"`       // Validity end time.
+	Verified       bool              `json:"verified"`       // Whether the certificate chain is verified by server or MiddlewareClientCert.
+	Certificate    *x509.Certificate `json:"-"`              // Raw certificate.
+}
+
+// GetClientTLSInfo returns the information of the client certificate,
+// which is nil if the request is not HTTPS or no client certificate is given.
+//
+// Note that the certificate is not trusted unless Verified is true, see ServerConfig.HTTPSClientAuth.
+func (r *Request) GetClientTLSInfo() *ClientTLSInfo {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return nil
+	}
+	var (
+		cert = r.TLS.PeerCertificates[0]
+		sum  = sha256.Sum256(cert.Raw)
+		info = &ClientTLSInfo{
+			Subject:        cert.Subject.String(),
+			CommonName:     cert.Subject.CommonName,
+			Issuer:         cert.Issuer.String(),
+			SerialNumber:   cert.SerialNumber.Text(16),
+			DNSNames:       cert.DNSNames,
+			EmailAddresses: cert.EmailAddresses,
+			Fingerprint:    hex.EncodeToString(sum[:]),
+			NotBefore:      cert.NotBefore,
+			NotAfter:       cert.NotAfter,
+			Verified:       len(r.TLS.VerifiedChains) > 0 || r.certVerified,
+			Certificate:    cert,
+		}
+	)
+	for _, ip := range cert.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		info.URIs = append(info.URIs, uri.String())
+	}
+	return info
+}
@@ -23,9 +23,11 @@ import (
 // Response is the http response manager.
 // Note that it implements the http.ResponseWriter interface with buffering feature.
 type Response struct {
-	*response.BufferWriter          // Underlying ResponseWriter.
-	Server                 *Server  // Parent server.
-	Request                *Request // According request.
+	*response.BufferWriter            // Underlying ResponseWriter.
+	Server                 *Server    // Parent server.
+	Request                *Request   // According request.
+	sseStream              *SSEStream // Server-Sent Events stream, which is nil if not streaming.
+	bandwidth              int64      // Max bytes per second serving content, which overwrites server StaticBandwidth.
 }
 
 // newResponse creates and returns a new Response object.
@@ -118,11 +120,18 @@ func (r *Response) RedirectBack(code ...int) {
 // provided ReadSeeker. The main benefit of ServeContent over io.Copy
 // is that it handles Range requests properly, sets the MIME type, and
 // handles If-Match, If-Unmodified-Since, If-None-Match, If-Modified-Since,
-// and If-Range requests.
+// and If-Range requests. The single and multiple byte ranges are responded with
+// http.StatusPartialContent, so that the downloading can be resumed.
+//
+// The content is sent limited by the bandwidth of SetBandwidth or server StaticBandwidth.
 //
 // See http.ServeContent
 func (r *Response) ServeContent(name string, modTime time.Time, content io.ReadSeeker) {
-	http.ServeContent(r.RawWriter(), r.Request.Request, name, modTime, content)
+	var writer = r.RawWriter()
+	if limit := r.getBandwidth(); limit > 0 {
+		writer = newBandwidthWriter(r.Request.Context(), writer, limit)
+	}
+	http.ServeContent(writer, r.Request.Request, name, modTime, content)
 }
 
 // Flush outputs the buffer content to the client and clears the buffer.
@@ -131,5 +140,9 @@ func (r *Response) Flush() {
 	if r.Server.config.ServerAgent != "" {
 		r.Header().Set("Server", r.Server.config.ServerAgent)
 	}
+	// Advertising HTTP/3 for requests that are not served over HTTP/3.
+	if altSvc := r.Server.http3AltSvc.Val(); altSvc != "" && r.Request.ProtoMajor < 3 {
+		r.Header().Set("Alt-Svc", altSvc)
+	}
 	r.BufferWriter.Flush()
 }
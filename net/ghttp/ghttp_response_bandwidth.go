@@ -0,0 +1,90 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+const (
+	// minBandwidthChunkSize is the min size of each write of bandwidthWriter.
+	minBandwidthChunkSize = 512
+)
+
+// bandwidthWriter limits the bytes per second written to the underlying writer,
+// which writes in chunks and sleeps if the writing is faster than the limit.
+type bandwidthWriter struct {
+	http.ResponseWriter
+	ctx       context.Context
+	limit     int64     // Max bytes per second.
+	chunkSize int       // Size of each write, which is about 1/10 of limit.
+	written   int64     // Bytes written.
+	startTime time.Time // Time of the first write.
+}
+
+// SetBandwidth sets the max bytes per second of current response serving files or content,
+// which overwrites the server configuration StaticBandwidth. It disables the limit if `bytesPerSecond`
+// is negative, eg: the downloading of paid users are not limited.
+func (r *Response) SetBandwidth(bytesPerSecond int64) {
+	r.bandwidth = bytesPerSecond
+}
+
+// getBandwidth returns the max bytes per second of current response, no limit if not greater than 0.
+func (r *Response) getBandwidth() int64 {
+	if r.bandwidth != 0 {
+		return r.bandwidth
+	}
+	return r.Server.config.StaticBandwidth
+}
+
+func newBandwidthWriter(ctx context.Context, w http.ResponseWriter, limit int64) *bandwidthWriter {
+	chunkSize := int(limit / 10)
+	if chunkSize < minBandwidthChunkSize {
+		chunkSize = minBandwidthChunkSize
+	}
+	return &bandwidthWriter{
+		ResponseWriter: w,
+		ctx:            ctx,
+		limit:          limit,
+		chunkSize:      chunkSize,
+	}
+}
+
+// Write implements the interface io.Writer.
+// It stops writing if the request context is done, eg: the client closes the connection.
+func (w *bandwidthWriter) Write(p []byte) (n int, err error) {
+	if w.startTime.IsZero() {
+		w.startTime = time.Now()
+	}
+	for len(p) > 0 {
+		size := len(p)
+		if size > w.chunkSize {
+			size = w.chunkSize
+		}
+		var written int
+		written, err = w.ResponseWriter.Write(p[:size])
+		n += written
+		w.written += int64(written)
+		if err != nil {
+			return n, err
+		}
+		p = p[size:]
+		expected := time.Duration(float64(w.written) / float64(w.limit) * float64(time.Second))
+		if delay := expected - time.Since(w.startTime); delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-w.ctx.Done():
+				timer.Stop()
+				return n, w.ctx.Err()
+			}
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,72 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+// WriteEarlyHints sends the informational response of status http.StatusEarlyHints with `links` as header Link,
+// so that the client can preload the critical resources before the handler finishes rendering, eg:
+//
+//	r.Response.WriteEarlyHints(
+//	    "</css/main.css>; rel=preload; as=style",
+//	    "</js/main.js>; rel=preload; as=script",
+//	)
+//
+// The `links` are also kept in the header of the final response. It does nothing for HTTP/1.0 clients
+// or if the response header is already sent, and the informational response is not sent with go < 1.19.
+func (r *Response) WriteEarlyHints(links ...string) {
+	if len(links) == 0 || r.IsHeaderWrote() || r.IsHijacked() || !r.Request.ProtoAtLeast(1, 1) {
+		return
+	}
+	header := r.Header()
+	for _, link := range links {
+		header.Add("Link", link)
+	}
+	writeEarlyHints(r.RawWriter())
+}
+
+// PushResources initiates HTTP/2 server push of `targets` like "/css/main.css" if the client supports it,
+// or else it sends them as early hints of preloading. The Response also implements http.Pusher for custom
+// push options.
+//
+// Note that it should be called before writing the response, and many browsers have dropped the support of
+// HTTP/2 server push, which use early hints instead.
+func (r *Response) PushResources(targets ...string) {
+	var links = make([]string, 0, len(targets))
+	for _, target := range targets {
+		err := r.Push(target, &http.PushOptions{
+			Header: http.Header{"Accept-Encoding": r.Request.Header.Values("Accept-Encoding")},
+		})
+		if err != nil {
+			links = append(links, "<"+target+">; rel=preload"+preloadLinkAs(target))
+		}
+	}
+	r.WriteEarlyHints(links...)
+}
+
+// preloadLinkAs returns the "as" attribute of preload link for `target` by its file extension.
+func preloadLinkAs(target string) string {
+	if i := strings.IndexAny(target, "?#"); i != -1 {
+		target = target[:i]
+	}
+	switch strings.ToLower(path.Ext(target)) {
+	case ".css":
+		return "; as=style"
+	case ".js", ".mjs":
+		return "; as=script"
+	case ".woff", ".woff2", ".ttf", ".otf":
+		return "; as=font; crossorigin"
+	case ".png", ".jpg", ".jpeg", ".gif", ".webp", ".svg", ".avif", ".ico":
+		return "; as=image"
+	default:
+		return ""
+	}
+}
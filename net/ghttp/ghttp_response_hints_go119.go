@@ -0,0 +1,19 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+//go:build go1.19
+// +build go1.19
+
+package ghttp
+
+import (
+	"net/http"
+)
+
+// writeEarlyHints sends the informational response of status http.StatusEarlyHints with current header of `w`.
+func writeEarlyHints(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusEarlyHints)
+}
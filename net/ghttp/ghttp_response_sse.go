@@ -0,0 +1,164 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// SSEStream is the writer of Server-Sent Events, which writes and flushes the events
+// to client directly without the response buffer.
+type SSEStream struct {
+	mu       sync.Mutex
+	response *Response
+	closed   bool
+	stopChan chan struct{} // Stops the heartbeat.
+}
+
+// SSEStream starts the Server-Sent Events stream for current response, which sets the
+// stream headers and sends them with the buffered content to client immediately.
+// It returns the same stream if it's called more than once.
+//
+// The optional parameter `heartbeat` specifies the interval sending comment as heartbeat,
+// which keeps the connection alive through proxies. The stream is closed automatically
+// when the request is done, so the handler should block until the streaming finishes, eg:
+//
+//	stream := r.Response.SSEStream(15 * time.Second)
+//	for {
+//	    select {
+//	    case <-r.Context().Done():
+//	        return
+//	    case msg := <-messages:
+//	        if err := stream.SendEvent(msg.Id, "message", msg); err != nil {
+//	            return
+//	        }
+//	    }
+//	}
+func (r *Response) SSEStream(heartbeat ...time.Duration) *SSEStream {
+	if r.sseStream != nil {
+		return r.sseStream
+	}
+	header := r.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	// Disables response buffering of nginx.
+	header.Set("X-Accel-Buffering", "no")
+	r.WriteHeader(http.StatusOK)
+	r.Flush()
+	r.Writer.Flush()
+	r.sseStream = &SSEStream{
+		response: r,
+		stopChan: make(chan struct{}),
+	}
+	if len(heartbeat) > 0 && heartbeat[0] > 0 {
+		go r.sseStream.doHeartbeat(heartbeat[0])
+	}
+	return r.sseStream
+}
+
+// SendEvent sends an event with `id`, `event` type and `data` to client.
+// The `id` and `event` are omitted if they are empty. The `data` is sent as it is if it's
+// string or []byte, or else it's encoded as JSON.
+func (s *SSEStream) SendEvent(id, event string, data interface{}) error {
+	var content string
+	switch v := data.(type) {
+	case nil:
+	case string:
+		content = v
+	case []byte:
+		content = string(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return gerror.WrapCode(gcode.CodeInvalidParameter, err, `encode SSE data failed`)
+		}
+		content = string(b)
+	}
+	var buffer bytes.Buffer
+	if id != "" {
+		buffer.WriteString("id: " + id + "\n")
+	}
+	if event != "" {
+		buffer.WriteString("event: " + event + "\n")
+	}
+	// Each line of data should be prefixed with "data:".
+	for _, line := range strings.Split(content, "\n") {
+		buffer.WriteString("data: " + strings.TrimSuffix(line, "\r") + "\n")
+	}
+	buffer.WriteString("\n")
+	return s.write(buffer.Bytes())
+}
+
+// SendData sends an event with only `data` to client.
+func (s *SSEStream) SendData(data interface{}) error {
+	return s.SendEvent("", "", data)
+}
+
+// SendRetry tells the client the reconnection time after the connection is lost.
+func (s *SSEStream) SendRetry(retry time.Duration) error {
+	return s.write([]byte("retry: " + strconv.FormatInt(retry.Milliseconds(), 10) + "\n\n"))
+}
+
+// SendComment sends a comment, which is ignored by client and commonly used as heartbeat.
+func (s *SSEStream) SendComment(comment string) error {
+	return s.write([]byte(": " + strings.ReplaceAll(comment, "\n", " ") + "\n\n"))
+}
+
+// Close closes the stream, after which the sending returns error.
+// It does not close the connection, which is closed after the request is done.
+func (s *SSEStream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.stopChan)
+	}
+}
+
+// write writes `data` to client and flushes it immediately.
+func (s *SSEStream) write(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `SSE stream is closed`)
+	}
+	if _, err := s.response.Writer.Write(data); err != nil {
+		return gerror.Wrap(err, `write SSE stream failed`)
+	}
+	s.response.Writer.Flush()
+	return nil
+}
+
+// doHeartbeat sends heartbeat every `interval` until the stream is closed or client is gone.
+func (s *SSEStream) doHeartbeat(interval time.Duration) {
+	var (
+		ticker = time.NewTicker(interval)
+		done   = s.response.Request.Context().Done()
+	)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stopChan:
+			return
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := s.SendComment("heartbeat"); err != nil {
+				return
+			}
+		}
+	}
+}
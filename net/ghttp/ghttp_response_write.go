@@ -12,6 +12,7 @@ import (
 	"net/http"
 
 	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/encoding/gmsgpack"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/internal/json"
 	"github.com/gogf/gf/v2/util/gconv"
@@ -189,6 +190,29 @@ func (r *Response) WriteXmlExit(content interface{}, rootTag ...string) {
 	r.Request.Exit()
 }
 
+// WriteMsgPack writes `content` to the response with MessagePack format.
+// If given []byte, it is considered as encoded MessagePack content and responded directly.
+func (r *Response) WriteMsgPack(content interface{}) {
+	r.Header().Set("Content-Type", contentTypeMsgPack)
+	if b, ok := content.([]byte); ok {
+		r.Write(b)
+		return
+	}
+	if b, err := gmsgpack.Encode(content); err != nil {
+		panic(gerror.Wrap(err, `WriteMsgPack failed`))
+	} else {
+		r.Write(b)
+	}
+}
+
+// WriteMsgPackExit writes `content` to the response with MessagePack format and exits executing
+// of current handler if success. The "Exit" feature is commonly used to replace usage of
+// return statements in the handler, for convenience.
+func (r *Response) WriteMsgPackExit(content interface{}) {
+	r.WriteMsgPack(content)
+	r.Request.Exit()
+}
+
 // WriteStatus writes HTTP `status` and `content` to the response.
 // Note that it does not set a Content-Type header here.
 func (r *Response) WriteStatus(status int, content ...interface{}) {
@@ -29,6 +29,7 @@ import (
 	"github.com/gogf/gf/v2/net/ghttp/internal/swaggerui"
 	"github.com/gogf/gf/v2/net/goai"
 	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/net/gtrace"
 	"github.com/gogf/gf/v2/os/gcache"
 	"github.com/gogf/gf/v2/os/gctx"
 	"github.com/gogf/gf/v2/os/genv"
@@ -98,6 +99,8 @@ func GetServer(name ...interface{}) *Server {
 			instance:         serverName,
 			plugins:          make([]Plugin, 0),
 			servers:          make([]*gracefulServer, 0),
+			http3Servers:     make([]*http3Server, 0),
+			http3AltSvc:      gtype.NewString(),
 			closeChan:        make(chan struct{}, 10000),
 			serverCount:      gtype.NewInt(),
 			statusHandlerMap: make(map[string][]HandlerFunc),
@@ -106,6 +109,8 @@ func GetServer(name ...interface{}) *Server {
 			routesMap:        make(map[string][]*HandlerItem),
 			openapi:          goai.New(),
 			registrar:        gsvc.GetRegistry(),
+			inflight:         gtype.NewInt(),
+			draining:         gtype.NewBool(),
 		}
 		// Initialize the server using default configurations.
 		if err := s.SetConfig(NewConfig()); err != nil {
@@ -123,6 +128,9 @@ func GetServer(name ...interface{}) *Server {
 func (s *Server) Start() error {
 	var ctx = gctx.GetInitCtx()
 
+	// It serves requests again if it was shut down.
+	s.draining.Set(false)
+
 	// Swagger UI.
 	if s.config.SwaggerPath != "" {
 		swaggerui.Init()
@@ -135,12 +143,44 @@ func (s *Server) Start() error {
 		s.BindHandler(s.config.OpenApiPath, s.openapiSpec)
 	}
 
+	// Metrics exporting handler.
+	if s.config.MetricPath != "" {
+		if metricHandler != nil {
+			s.BindHandler(s.config.MetricPath, metricHandler)
+		} else {
+			s.Logger().Warningf(
+				ctx,
+				`metric path "%s" is configured but no metric handler is registered`,
+				s.config.MetricPath,
+			)
+		}
+	}
+
+	// Tracing propagators from configuration.
+	if len(s.config.TracingPropagators) > 0 {
+		propagator, err := gtrace.NewPropagator(s.config.TracingPropagators...)
+		if err != nil {
+			return err
+		}
+		s.propagator = propagator
+	}
+
+	// Gateway routes from configuration.
+	if len(s.config.Gateway.Routes) > 0 {
+		if err := s.BindGateway(s.config.Gateway); err != nil {
+			return err
+		}
+	}
+
 	// Register group routes.
 	s.handlePreBindItems(ctx)
 
 	// Server process initialization, which can only be initialized once.
 	serverProcessInit()
 
+	// Server metrics initialization, which can only be initialized once.
+	s.initMetricManager()
+
 	// Server can only be run once.
 	if s.Status() == ServerStatusRunning {
 		return gerror.NewCode(gcode.CodeInvalidOperation, "server is already running")
@@ -492,6 +532,10 @@ func (s *Server) startServer(fdMap listenerFdMap) {
 		ctx          = context.TODO()
 		httpsEnabled bool
 	)
+	// Automatic certificates, which sets the TLS configuration for HTTPS.
+	if len(s.config.AutoCertDomains) > 0 {
+		s.initAutoCert(ctx)
+	}
 	// HTTPS
 	if s.config.TLSConfig != nil || (s.config.HTTPSCertPath != "" && s.config.HTTPSKeyPath != "") {
 		if len(s.config.HTTPSAddr) == 0 {
@@ -503,6 +547,12 @@ func (s *Server) startServer(fdMap listenerFdMap) {
 			}
 		}
 		httpsEnabled = len(s.config.HTTPSAddr) > 0
+		// Client certificate authentication.
+		if s.config.HTTPSClientAuth != "" {
+			if err := s.initHTTPSClientAuth(); err != nil {
+				s.Logger().Fatalf(ctx, `%+v`, err)
+			}
+		}
 		var array []string
 		if v, ok := fdMap["https"]; ok && len(v) > 0 {
 			array = strings.Split(v, ",")
@@ -566,6 +616,11 @@ func (s *Server) startServer(fdMap listenerFdMap) {
 		} else {
 			s.servers = append(s.servers, s.newGracefulServer(itemFunc))
 		}
+		// The HTTP servers answer the HTTP-01 challenges of automatic certificates.
+		if s.autoCertManager != nil {
+			httpServer := s.servers[len(s.servers)-1].httpServer
+			httpServer.Handler = s.autoCertManager.HTTPHandler(httpServer.Handler)
+		}
 	}
 	// Start listening asynchronously.
 	serverRunning.Add(1)
@@ -575,6 +630,10 @@ func (s *Server) startServer(fdMap listenerFdMap) {
 		go s.startGracefulServer(ctx, wg, gs)
 	}
 	wg.Wait()
+	// HTTP/3 listening after HTTPS addresses are determined.
+	if s.config.HTTP3Enabled {
+		s.startHTTP3Servers(ctx)
+	}
 }
 
 func (s *Server) startGracefulServer(ctx context.Context, wg *sync.WaitGroup, server *gracefulServer) {
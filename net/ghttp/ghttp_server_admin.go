@@ -83,13 +83,19 @@ func (s *Server) EnableAdmin(pattern ...string) {
 }
 
 // Shutdown shuts down current server.
+// It calls the hooks registered by OnShutdown, and waits for the in-flight requests
+// if ShutdownDrainTimeout is configured, before closing the underlying servers.
 func (s *Server) Shutdown() error {
 	var ctx = context.TODO()
 	s.doServiceDeregister()
+	s.drain(ctx)
 	// Only shut down current servers.
 	// It may have multiple underlying http servers.
 	for _, v := range s.servers {
 		v.close(ctx)
 	}
+	for _, v := range s.http3Servers {
+		v.close(ctx)
+	}
 	return nil
 }
@@ -260,9 +260,13 @@ func shutdownWebServersGracefully(ctx context.Context, signal os.Signal) {
 		for _, v := range m {
 			server := v.(*Server)
 			server.doServiceDeregister()
+			server.drain(ctx)
 			for _, s := range server.servers {
 				s.shutdown(ctx)
 			}
+			for _, s := range server.http3Servers {
+				s.shutdown(ctx)
+			}
 		}
 	})
 }
@@ -274,6 +278,9 @@ func forceCloseWebServers(ctx context.Context) {
 			for _, s := range v.(*Server).servers {
 				s.close(ctx)
 			}
+			for _, s := range v.(*Server).http3Servers {
+				s.close(ctx)
+			}
 		}
 	})
 }
@@ -0,0 +1,101 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// AutoCertConfig is the configuration for creating AutoCertManager,
+// which is from the AutoCert configurations of ServerConfig.
+type AutoCertConfig struct {
+	Domains      []string // Domains allowed acquiring certificates.
+	Email        string   // Contact email of the ACME account.
+	CacheDir     string   // Directory caching certificates, used if CacheRedis is empty.
+	CacheRedis   string   // Redis configuration group name for caching certificates.
+	DirectoryURL string   // ACME directory URL, the adapter uses Let's Encrypt production if empty.
+}
+
+// AutoCertManager is the interface acquiring and renewing certificates automatically using ACME.
+// As the ACME client is not in the standard library, it is implemented by an adapter, eg:
+// github.com/gogf/gf/contrib/net/autocert/v2, which registers itself using RegisterAutoCertManagerCreator.
+type AutoCertManager interface {
+	// TLSConfig returns the TLS configuration, which acquires and renews certificates on demand
+	// in TLS handshakes, and answers the TLS-ALPN-01 challenges.
+	TLSConfig() *tls.Config
+
+	// HTTPHandler returns the handler answering the HTTP-01 challenges,
+	// which passes other requests to `fallback`.
+	HTTPHandler(fallback http.Handler) http.Handler
+}
+
+// AutoCertManagerCreator creates and returns an AutoCertManager with `config`.
+type AutoCertManagerCreator func(ctx context.Context, config AutoCertConfig) (AutoCertManager, error)
+
+// autoCertManagerCreator is the registered creator for AutoCertManager.
+var autoCertManagerCreator AutoCertManagerCreator
+
+// RegisterAutoCertManagerCreator registers the creator for AutoCertManager,
+// which is commonly called by the adapter in its package initialization.
+func RegisterAutoCertManagerCreator(creator AutoCertManagerCreator) {
+	autoCertManagerCreator = creator
+}
+
+// initAutoCert creates the AutoCertManager, and sets its certificate getter into the TLS configuration,
+// which enables HTTPS on HTTPSAddr. The HTTP servers on Address answer the HTTP-01 challenges,
+// and the HTTPS servers answer the TLS-ALPN-01 challenges.
+func (s *Server) initAutoCert(ctx context.Context) {
+	if autoCertManagerCreator == nil {
+		s.Logger().Fatal(
+			ctx,
+			`automatic certificate enabled but no ACME adapter registered, `+
+				`import the adapter like: _ "github.com/gogf/gf/contrib/net/autocert/v2"`,
+		)
+		return
+	}
+	config := AutoCertConfig{
+		Domains:      s.config.AutoCertDomains,
+		Email:        s.config.AutoCertEmail,
+		CacheDir:     s.config.AutoCertCacheDir,
+		CacheRedis:   s.config.AutoCertCacheRedis,
+		DirectoryURL: s.config.AutoCertDirectoryURL,
+	}
+	if config.CacheDir == "" && config.CacheRedis == "" {
+		config.CacheDir = gfile.Join(gfile.Temp(), "autocert")
+	}
+	manager, err := autoCertManagerCreator(ctx, config)
+	if err != nil {
+		s.Logger().Fatalf(ctx, `create automatic certificate manager failed: %+v`, err)
+		return
+	}
+	s.autoCertManager = manager
+
+	// The custom TLS configuration is kept, only the certificate getter and protocols are set.
+	var (
+		autoCertTLSConfig = manager.TLSConfig()
+		tlsConfig         = &tls.Config{}
+	)
+	if s.config.TLSConfig != nil {
+		tlsConfig = s.config.TLSConfig.Clone()
+	}
+	tlsConfig.GetCertificate = autoCertTLSConfig.GetCertificate
+	tlsConfig.Certificates = nil
+	if len(tlsConfig.NextProtos) == 0 {
+		tlsConfig.NextProtos = []string{"http/1.1"}
+	}
+	for _, proto := range autoCertTLSConfig.NextProtos {
+		if !gstr.InArray(tlsConfig.NextProtos, proto) {
+			tlsConfig.NextProtos = append(tlsConfig.NextProtos, proto)
+		}
+	}
+	s.config.TLSConfig = tlsConfig
+}
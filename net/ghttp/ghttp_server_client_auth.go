@@ -0,0 +1,80 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/os/gres"
+)
+
+// Policies of client certificate authentication for HTTPS.
+const (
+	ClientAuthNone             = "none"               // No client certificate is requested.
+	ClientAuthRequest          = "request"            // Client certificate is requested but not required or verified.
+	ClientAuthRequire          = "require"            // Client certificate is required but not verified.
+	ClientAuthVerifyIfGiven    = "verify-if-given"    // Client certificate is verified if given, which suits per route group authentication.
+	ClientAuthRequireAndVerify = "require-and-verify" // Client certificate is required and verified.
+)
+
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	ClientAuthNone:             tls.NoClientCert,
+	ClientAuthRequest:          tls.RequestClientCert,
+	ClientAuthRequire:          tls.RequireAnyClientCert,
+	ClientAuthVerifyIfGiven:    tls.VerifyClientCertIfGiven,
+	ClientAuthRequireAndVerify: tls.RequireAndVerifyClientCert,
+}
+
+// initHTTPSClientAuth sets the client certificate authentication into the TLS configuration.
+func (s *Server) initHTTPSClientAuth() error {
+	clientAuth, ok := clientAuthTypes[s.config.HTTPSClientAuth]
+	if !ok {
+		return gerror.NewCodef(
+			gcode.CodeInvalidConfiguration, `invalid HTTPS client auth policy "%s"`, s.config.HTTPSClientAuth,
+		)
+	}
+	tlsConfig := &tls.Config{}
+	if s.config.TLSConfig != nil {
+		tlsConfig = s.config.TLSConfig.Clone()
+	}
+	tlsConfig.ClientAuth = clientAuth
+	if len(s.config.HTTPSClientCAPaths) > 0 {
+		pool, err := LoadCertPool(s.config.HTTPSClientCAPaths...)
+		if err != nil {
+			return err
+		}
+		tlsConfig.ClientCAs = pool
+	}
+	s.config.TLSConfig = tlsConfig
+	return nil
+}
+
+// LoadCertPool loads and returns the certificate pool from PEM certificate files `paths`,
+// which can also be resource files.
+func LoadCertPool(paths ...string) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	for _, path := range paths {
+		var content []byte
+		if gres.Contains(path) {
+			content = gres.GetContent(path)
+		} else {
+			realPath := gfile.RealPath(path)
+			if realPath == "" {
+				return nil, gerror.NewCodef(gcode.CodeInvalidConfiguration, `certificate file "%s" does not exist`, path)
+			}
+			content = gfile.GetBytes(realPath)
+		}
+		if !pool.AppendCertsFromPEM(content) {
+			return nil, gerror.NewCodef(gcode.CodeInvalidConfiguration, `no certificate found in "%s"`, path)
+		}
+	}
+	return pool, nil
+}
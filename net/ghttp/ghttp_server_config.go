@@ -65,6 +65,10 @@ type ServerConfig struct {
 	// Endpoints are custom endpoints for service register, it uses Address if empty.
 	Endpoints []string `json:"endpoints"`
 
+	// ServiceMetadata specifies custom metadata for service register,
+	// which overwrites the default metadata like protocol if they have the same keys.
+	ServiceMetadata map[string]interface{} `json:"serviceMetadata"`
+
 	// HTTPSCertPath specifies certification file path for HTTPS service.
 	HTTPSCertPath string `json:"httpsCertPath"`
 
@@ -80,6 +84,45 @@ type ServerConfig struct {
 	// instead.
 	TLSConfig *tls.Config `json:"tlsConfig"`
 
+	// HTTPSClientAuth specifies the policy of client certificate authentication for HTTPS, which is one of
+	// ClientAuthNone, ClientAuthRequest, ClientAuthRequire, ClientAuthVerifyIfGiven and ClientAuthRequireAndVerify.
+	// The client certificates are verified using the CA certificates of HTTPSClientCAPaths.
+	HTTPSClientAuth string `json:"httpsClientAuth"`
+
+	// HTTPSClientCAPaths specifies the CA certificate file paths for verifying client certificates.
+	HTTPSClientCAPaths []string `json:"httpsClientCAPaths"`
+
+	// HTTP3Enabled enables serving HTTP/3 over QUIC alongside HTTPS, which requires the HTTPS
+	// configurations and an HTTP/3 server adapter registered by RegisterHTTP3ServerCreator.
+	HTTP3Enabled bool `json:"http3Enabled"`
+
+	// HTTP3Addr specifies the UDP addresses for HTTP/3, multiple addresses joined using char ','.
+	// It uses the same addresses as HTTPSAddr if empty.
+	HTTP3Addr string `json:"http3Addr"`
+
+	// HTTP3AltSvcMaxAge specifies the max age of HTTP/3 advertisement in response header "Alt-Svc".
+	// It's 24 hours in default.
+	HTTP3AltSvcMaxAge time.Duration `json:"http3AltSvcMaxAge"`
+
+	// AutoCertDomains specifies the domains acquiring and renewing certificates automatically using ACME,
+	// like Let's Encrypt, which enables HTTPS without provisioning certificates manually.
+	// It requires an ACME adapter registered by RegisterAutoCertManagerCreator.
+	AutoCertDomains []string `json:"autoCertDomains"`
+
+	// AutoCertEmail specifies the contact email of the ACME account, which is optional.
+	AutoCertEmail string `json:"autoCertEmail"`
+
+	// AutoCertCacheDir specifies the directory caching the certificates and ACME account key.
+	// It's "autocert" under the temporary directory in default.
+	AutoCertCacheDir string `json:"autoCertCacheDir"`
+
+	// AutoCertCacheRedis specifies the redis configuration group name for caching certificates,
+	// which is used instead of AutoCertCacheDir for servers in cluster sharing the certificates.
+	AutoCertCacheRedis string `json:"autoCertCacheRedis"`
+
+	// AutoCertDirectoryURL specifies the ACME directory URL, which is Let's Encrypt production in default.
+	AutoCertDirectoryURL string `json:"autoCertDirectoryURL"`
+
 	// Handler the handler for HTTP request.
 	Handler func(w http.ResponseWriter, r *http.Request) `json:"-"`
 
@@ -150,6 +193,19 @@ type ServerConfig struct {
 	// It is automatically set enabled if any static path is set.
 	FileServerEnabled bool `json:"fileServerEnabled"`
 
+	// StaticETag specifies the ETag generating mode for static files, which is StaticETagModTime in default.
+	// The conditional requests with If-None-Match or If-Modified-Since are responded with 304 if not modified.
+	StaticETag string `json:"staticETag"`
+
+	// StaticCacheControl specifies the Cache-Control header values for static files by path patterns, eg:
+	// {"*.html": "no-cache", "/assets/": "public, max-age=31536000, immutable"}.
+	// See Server.SetStaticCacheControl for pattern matching.
+	StaticCacheControl map[string]string `json:"staticCacheControl"`
+
+	// StaticBandwidth specifies the max bytes per second of each connection serving files,
+	// which limits the static service, Response.ServeFile and Response.ServeFileDownload. No limit if 0.
+	StaticBandwidth int64 `json:"staticBandwidth"`
+
 	// ======================================================================================================
 	// Cookie.
 	// ======================================================================================================
@@ -215,6 +271,17 @@ type ServerConfig struct {
 	AccessLogEnabled bool         `json:"accessLogEnabled"` // AccessLogEnabled enables access logging content to files.
 	AccessLogPattern string       `json:"accessLogPattern"` // AccessLogPattern specifies the error log file pattern like: access-{Ymd}.log
 
+	// AccessLogFormat specifies the access log format, which is AccessLogFormatText in default,
+	// AccessLogFormatJson, or a template with field placeholders like "{method} {uri} {status} {latency}".
+	// The structured access log is printed without the logging header like time and level.
+	AccessLogFormat string `json:"accessLogFormat"`
+
+	// AccessLogFields specifies the fields of JSON access log, like: ["time", "status", "route", "latency"].
+	AccessLogFields []string `json:"accessLogFields"`
+
+	// AccessLogFormatter specifies the custom formatter of access log, which overrides AccessLogFormat.
+	AccessLogFormatter AccessLogFormatter `json:"-"`
+
 	// ======================================================================================================
 	// PProf.
 	// ======================================================================================================
@@ -230,13 +297,79 @@ type ServerConfig struct {
 	SwaggerPath       string `json:"swaggerPath"`       // SwaggerPath specifies the swagger UI path for route registering.
 	SwaggerUITemplate string `json:"swaggerUITemplate"` // SwaggerUITemplate specifies the swagger UI custom template
 
+	// ======================================================================================================
+	// Metric.
+	// ======================================================================================================
+
+	// MetricExcludeRoutes specifies the route patterns or request paths that are not recorded
+	// by the server metrics, eg: "/metrics", "/healthz".
+	MetricExcludeRoutes []string `json:"metricExcludeRoutes"`
+
+	// MetricDurationBuckets specifies the buckets for request duration histogram in milliseconds.
+	// Note that the histogram is shared by all servers of current process, so it takes effect only
+	// for the first started server.
+	MetricDurationBuckets []float64 `json:"metricDurationBuckets"`
+
+	// MetricSizeBuckets specifies the buckets for request and response size histograms in bytes,
+	// which is shared by all servers of current process like MetricDurationBuckets.
+	MetricSizeBuckets []float64 `json:"metricSizeBuckets"`
+
+	// MetricPath specifies the route path exporting metrics like "/metrics", which is served by
+	// the handler registered using RegisterMetricHandler, eg: importing contrib/metric/otelmetric.
+	// The requests to this path are not recorded by server metrics.
+	MetricPath string `json:"metricPath"`
+
+	// ======================================================================================================
+	// Tracing.
+	// ======================================================================================================
+
+	// TracingExcludeRoutes specifies the route patterns or request paths that are not traced,
+	// eg: "/healthz". The pattern ending with "/*" matches the request paths with its prefix, like "/static/*".
+	TracingExcludeRoutes []string `json:"tracingExcludeRoutes"`
+
+	// TracingBodyExcludeRoutes specifies the route patterns or request paths whose request and response
+	// bodies are not recorded in tracing, eg: file uploading routes. It matches routes like TracingExcludeRoutes.
+	TracingBodyExcludeRoutes []string `json:"tracingBodyExcludeRoutes"`
+
+	// TracingHeaderAllowList specifies the request and response headers recorded in tracing,
+	// all headers are recorded if empty.
+	TracingHeaderAllowList []string `json:"tracingHeaderAllowList"`
+
+	// TracingHeaderDenyList specifies the request and response headers not recorded in tracing,
+	// eg: "Authorization", "Cookie".
+	TracingHeaderDenyList []string `json:"tracingHeaderDenyList"`
+
+	// TracingMaxRequestBodySize specifies the max size in bytes of request body recorded in tracing,
+	// which is gtrace.MaxContentLogSize if 0. Only the recorded part of request body is read ahead of handler.
+	// It can be configured in configuration file using string like: 1m, 10m, 500kb etc.
+	TracingMaxRequestBodySize int64 `json:"tracingMaxRequestBodySize"`
+
+	// TracingMaxResponseBodySize specifies the max size in bytes of response body recorded in tracing,
+	// which is gtrace.MaxContentLogSize if 0.
+	// It can be configured in configuration file using string like: 1m, 10m, 500kb etc.
+	TracingMaxResponseBodySize int64 `json:"tracingMaxResponseBodySize"`
+
+	// TracingPropagators specifies the propagator names extracting tracing context from request headers,
+	// which are also used injecting tracing context for reverse proxy, eg: ["tracecontext", "b3", "jaeger"].
+	// The name like "header:X-Request-Id" propagates trace id using the custom header.
+	// It uses the global propagator of gtrace if empty.
+	TracingPropagators []string `json:"tracingPropagators"`
+
+	// ======================================================================================================
+	// Gateway.
+	// ======================================================================================================
+
+	// Gateway specifies the request/response transformation and forwarding routes,
+	// which are bound as global middleware when server starts.
+	Gateway GatewayConfig `json:"gateway"`
+
 	// ======================================================================================================
 	// Other.
 	// ======================================================================================================
 
 	// ClientMaxBodySize specifies the max body size limit in bytes for client request.
 	// It can be configured in configuration file using string like: 1m, 10m, 500kb etc.
-	// It's `8MB` in default.
+	// It's `8MB` in default, and can be overridden for route groups using RouterGroup.ClientMaxBodySize.
 	ClientMaxBodySize int64 `json:"clientMaxBodySize"`
 
 	// FormParsingMemory specifies max memory buffer size in bytes which can be used for
@@ -263,6 +396,11 @@ type ServerConfig struct {
 
 	// GracefulShutdownTimeout set the maximum survival time (seconds) before stopping the server.
 	GracefulShutdownTimeout uint8 `json:"gracefulShutdownTimeout"`
+
+	// ShutdownDrainTimeout specifies the maximum duration waiting for the in-flight requests in shutting down,
+	// during which the new requests are responded with 503 and "Connection: close".
+	// It does not wait for the in-flight requests if it is 0.
+	ShutdownDrainTimeout time.Duration `json:"shutdownDrainTimeout"`
 }
 
 // NewConfig creates and returns a ServerConfig object with default configurations.
@@ -273,6 +411,7 @@ func NewConfig() ServerConfig {
 		Name:                    DefaultServerName,
 		Address:                 ":0",
 		HTTPSAddr:               "",
+		HTTP3AltSvcMaxAge:       time.Hour * 24,
 		Listeners:               nil,
 		Handler:                 nil,
 		ReadTimeout:             60 * time.Second,
@@ -339,6 +478,12 @@ func (s *Server) SetConfigWithMap(m map[string]interface{}) error {
 	if k, v := gutil.MapPossibleItemByKey(m, "FormParsingMemory"); k != "" {
 		m[k] = gfile.StrToSize(gconv.String(v))
 	}
+	if k, v := gutil.MapPossibleItemByKey(m, "TracingMaxRequestBodySize"); k != "" {
+		m[k] = gfile.StrToSize(gconv.String(v))
+	}
+	if k, v := gutil.MapPossibleItemByKey(m, "TracingMaxResponseBodySize"); k != "" {
+		m[k] = gfile.StrToSize(gconv.String(v))
+	}
 	// Update the current configuration object.
 	// It only updates the configured keys not all the object.
 	if err := gconv.Struct(m, &s.config); err != nil {
@@ -485,6 +630,35 @@ func (s *Server) SetTLSConfig(tlsConfig *tls.Config) {
 	s.config.TLSConfig = tlsConfig
 }
 
+// EnableAutoCert enables HTTPS with certificates acquired and renewed automatically using ACME for `domains`.
+// See ServerConfig.AutoCertDomains.
+func (s *Server) EnableAutoCert(domains ...string) {
+	s.config.AutoCertDomains = domains
+}
+
+// SetHTTPSClientAuth sets the policy of client certificate authentication for HTTPS,
+// with the CA certificate file paths `caPaths` for verifying client certificates.
+// See ServerConfig.HTTPSClientAuth.
+func (s *Server) SetHTTPSClientAuth(policy string, caPaths ...string) {
+	s.config.HTTPSClientAuth = policy
+	s.config.HTTPSClientCAPaths = caPaths
+}
+
+// SetHTTP3Enabled enables or disables serving HTTP/3 over QUIC for the server.
+func (s *Server) SetHTTP3Enabled(enabled bool) {
+	s.config.HTTP3Enabled = enabled
+}
+
+// SetHTTP3Addr sets the HTTP/3 UDP listening addresses for the server.
+func (s *Server) SetHTTP3Addr(address string) {
+	s.config.HTTP3Addr = address
+}
+
+// SetShutdownDrainTimeout sets the ShutdownDrainTimeout for the server.
+func (s *Server) SetShutdownDrainTimeout(timeout time.Duration) {
+	s.config.ShutdownDrainTimeout = timeout
+}
+
 // SetReadTimeout sets the ReadTimeout for the server.
 func (s *Server) SetReadTimeout(t time.Duration) {
 	s.config.ReadTimeout = t
@@ -535,6 +709,11 @@ func (s *Server) SetEndpoints(endpoints []string) {
 	s.config.Endpoints = endpoints
 }
 
+// SetServiceMetadata sets the custom metadata for service register of the server.
+func (s *Server) SetServiceMetadata(metadata map[string]interface{}) {
+	s.config.ServiceMetadata = metadata
+}
+
 // SetHandler sets the request handler for server.
 func (s *Server) SetHandler(h func(w http.ResponseWriter, r *http.Request)) {
 	s.config.Handler = h
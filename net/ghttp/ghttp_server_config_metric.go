@@ -0,0 +1,35 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+// SetMetricExcludeRoutes sets the MetricExcludeRoutes for server.
+// The requests matching these route patterns or paths are not recorded by server metrics.
+func (s *Server) SetMetricExcludeRoutes(routes ...string) {
+	s.config.MetricExcludeRoutes = routes
+}
+
+// SetMetricDurationBuckets sets the MetricDurationBuckets for server.
+func (s *Server) SetMetricDurationBuckets(buckets []float64) {
+	s.config.MetricDurationBuckets = buckets
+}
+
+// SetMetricSizeBuckets sets the MetricSizeBuckets for server.
+func (s *Server) SetMetricSizeBuckets(buckets []float64) {
+	s.config.MetricSizeBuckets = buckets
+}
+
+// SetMetricPath sets the MetricPath for server.
+func (s *Server) SetMetricPath(path string) {
+	s.config.MetricPath = path
+}
+
+// AddMetricHook adds hook called after each request is done, which is used for recording custom metrics
+// with the same attributes as server metrics, eg: the route pattern, method and status of the request.
+// Note that the hook is not called for the requests excluded by MetricExcludeRoutes.
+func (s *Server) AddMetricHook(hook MetricHook) {
+	s.metricHooks = append(s.metricHooks, hook)
+}
@@ -47,6 +47,30 @@ func (s *Server) SetFileServerEnabled(enabled bool) {
 	s.config.FileServerEnabled = enabled
 }
 
+// SetStaticETag sets the ETag generating mode for static files,
+// which is one of StaticETagModTime, StaticETagHash and StaticETagOff.
+func (s *Server) SetStaticETag(mode string) {
+	s.config.StaticETag = mode
+}
+
+// SetStaticCacheControl sets the Cache-Control header `value` for static files matching `pattern`.
+//
+// The `pattern` ending with "/" matches the URI prefix, like "/assets/".
+// The `pattern` starting with "/" matches the URI using path.Match, like "/js/*.js".
+// The other `pattern` matches the file name using path.Match, like "*.html".
+// The longest matched pattern is used if multiple patterns match.
+func (s *Server) SetStaticCacheControl(pattern string, value string) {
+	if s.config.StaticCacheControl == nil {
+		s.config.StaticCacheControl = make(map[string]string)
+	}
+	s.config.StaticCacheControl[pattern] = value
+}
+
+// SetStaticBandwidth sets the max bytes per second of each connection serving files, no limit if 0.
+func (s *Server) SetStaticBandwidth(bytesPerSecond int64) {
+	s.config.StaticBandwidth = bytesPerSecond
+}
+
 // SetServerRoot sets the document root for static service.
 func (s *Server) SetServerRoot(root string) {
 	var (
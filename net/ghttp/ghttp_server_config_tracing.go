@@ -0,0 +1,46 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+// SetTracingExcludeRoutes sets the TracingExcludeRoutes for server.
+// The requests matching these route patterns or paths are not traced.
+func (s *Server) SetTracingExcludeRoutes(routes ...string) {
+	s.config.TracingExcludeRoutes = routes
+}
+
+// SetTracingBodyExcludeRoutes sets the TracingBodyExcludeRoutes for server.
+// The request and response bodies of requests matching these route patterns or paths are not recorded.
+func (s *Server) SetTracingBodyExcludeRoutes(routes ...string) {
+	s.config.TracingBodyExcludeRoutes = routes
+}
+
+// SetTracingHeaderAllowList sets the TracingHeaderAllowList for server.
+func (s *Server) SetTracingHeaderAllowList(headers ...string) {
+	s.config.TracingHeaderAllowList = headers
+}
+
+// SetTracingHeaderDenyList sets the TracingHeaderDenyList for server.
+func (s *Server) SetTracingHeaderDenyList(headers ...string) {
+	s.config.TracingHeaderDenyList = headers
+}
+
+// SetTracingMaxBodySize sets the TracingMaxRequestBodySize and TracingMaxResponseBodySize for server.
+func (s *Server) SetTracingMaxBodySize(requestSize, responseSize int64) {
+	s.config.TracingMaxRequestBodySize = requestSize
+	s.config.TracingMaxResponseBodySize = responseSize
+}
+
+// SetTracingSpanNameFunc sets the custom function naming the server tracing span of request,
+// which replaces the default name like "GET /user/{id}" using the route pattern.
+func (s *Server) SetTracingSpanNameFunc(f SpanNameFunc) {
+	s.spanNameFunc = f
+}
+
+// SetTracingPropagators sets the TracingPropagators for server, which takes effect when server starts.
+func (s *Server) SetTracingPropagators(names ...string) {
+	s.config.TracingPropagators = names
+}
@@ -43,6 +43,7 @@ func (d *Domain) doBindHandler(ctx context.Context, in doBindHandlerInput) {
 			Pattern:    in.Pattern + "@" + domain,
 			FuncInfo:   in.FuncInfo,
 			Middleware: in.Middleware,
+			BodyLimit:  in.BodyLimit,
 			Source:     in.Source,
 		})
 	}
@@ -63,6 +64,7 @@ func (d *Domain) doBindObject(ctx context.Context, in doBindObjectInput) {
 			Object:     in.Object,
 			Method:     in.Method,
 			Middleware: in.Middleware,
+			BodyLimit:  in.BodyLimit,
 			Source:     in.Source,
 		})
 	}
@@ -83,6 +85,7 @@ func (d *Domain) doBindObjectMethod(ctx context.Context, in doBindObjectMethodIn
 			Object:     in.Object,
 			Method:     in.Method,
 			Middleware: in.Middleware,
+			BodyLimit:  in.BodyLimit,
 			Source:     in.Source,
 		})
 	}
@@ -103,6 +106,7 @@ func (d *Domain) doBindObjectRest(ctx context.Context, in doBindObjectInput) {
 			Object:     in.Object,
 			Method:     in.Method,
 			Middleware: in.Middleware,
+			BodyLimit:  in.BodyLimit,
 			Source:     in.Source,
 		})
 	}
@@ -166,3 +170,11 @@ func (d *Domain) BindMiddlewareDefault(handlers ...HandlerFunc) {
 func (d *Domain) Use(handlers ...HandlerFunc) {
 	d.BindMiddlewareDefault(handlers...)
 }
+
+// UseWithPriority adds middleware to the domain with `priority`.
+// See Server.BindMiddlewareWithPriority.
+func (d *Domain) UseWithPriority(priority int, handlers ...HandlerFunc) {
+	for domain := range d.domains {
+		d.server.BindMiddlewareWithPriority(defaultMiddlewarePattern+"@"+domain, priority, handlers...)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// GatewayConfig is the configuration of Gateway, which is commonly configured in configuration file
+// under the server configuration, eg:
+//
+//	server:
+//	  gateway:
+//	    routes:
+//	      - prefix:   "/api/user"
+//	        rewrite:  "/v1/user"
+//	        upstream: "http://user-service:8000"
+//	        requestHeaders:
+//	          set:    { "Authorization": "Bearer ${USER_SERVICE_TOKEN}" }
+//	          remove: [ "Cookie" ]
+//	        responseHeaders:
+//	          rename: { "X-Upstream-Id": "X-Request-Id" }
+//	        statusMap: { 502: 503 }
+//	      - prefix:   "/api/order"
+//	        balance:  "least-conn"
+//	        retries:  1
+//	        upstreams:
+//	          - url: "http://order-service-1:8000"
+//	          - url: "http://order-service-2:8000"
+type GatewayConfig struct {
+	Routes []GatewayRoute `json:"routes"` // Routes matched by the longest path prefix.
+}
+
+// GatewayRoute is the transformation and forwarding rule for requests matching Prefix.
+type GatewayRoute struct {
+	Prefix          string                 `json:"prefix"`          // URL path prefix of matched requests, like "/api/user".
+	Rewrite         string                 `json:"rewrite"`         // Replacement of Prefix in path for forwarding to upstream.
+	Upstream        string                 `json:"upstream"`        // Upstream URL to forward, the request is handled by the server if no upstream.
+	Upstreams       []ReverseProxyUpstream `json:"upstreams"`       // Multiple upstreams to forward with load balancing, see ReverseProxy.
+	Balance         string                 `json:"balance"`         // Load balancing policy of Upstreams, like ProxyBalanceRoundRobin.
+	Retries         int                    `json:"retries"`         // Max retries with another upstream on connection failures.
+	RequestHeaders  GatewayHeaderTransform `json:"requestHeaders"`  // Transformation of request headers.
+	ResponseHeaders GatewayHeaderTransform `json:"responseHeaders"` // Transformation of response headers.
+	StatusMap       map[int]int            `json:"statusMap"`       // Maps response status to another one, like 502 to 503.
+}
+
+// GatewayHeaderTransform transforms headers in order: Rename, Remove, Set and Add.
+// The values of Set and Add support environment variables like "${TOKEN}",
+// which is commonly used for injecting credentials without putting them in configuration.
+type GatewayHeaderTransform struct {
+	Rename map[string]string `json:"rename"` // Renames headers from key to value.
+	Remove []string          `json:"remove"` // Removes headers.
+	Set    map[string]string `json:"set"`    // Sets headers, overwriting the existing ones.
+	Add    map[string]string `json:"add"`    // Adds header values.
+}
+
+// Gateway transforms requests and responses, and forwards requests to upstreams by routes.
+type Gateway struct {
+	routes []*gatewayRoute // Sorted by prefix length in descending order.
+}
+
+type gatewayRoute struct {
+	GatewayRoute
+	proxy *ReverseProxy // Nil if no upstream.
+}
+
+// NewGateway creates and returns a Gateway with `config`.
+func NewGateway(config GatewayConfig) (*Gateway, error) {
+	gateway := &Gateway{}
+	for _, route := range config.Routes {
+		if route.Prefix == "" {
+			return nil, gerror.NewCode(gcode.CodeInvalidConfiguration, `gateway route prefix cannot be empty`)
+		}
+		item := &gatewayRoute{GatewayRoute: route}
+		upstreams := route.Upstreams
+		if route.Upstream != "" {
+			upstreams = append([]ReverseProxyUpstream{{URL: route.Upstream}}, upstreams...)
+		}
+		if len(upstreams) > 0 {
+			proxy, err := NewReverseProxy(ReverseProxyConfig{
+				Upstreams: upstreams,
+				Balance:   route.Balance,
+				Retries:   route.Retries,
+			})
+			if err != nil {
+				return nil, gerror.WrapCodef(
+					gcode.CodeInvalidConfiguration, err, `invalid gateway upstream for prefix "%s"`, route.Prefix,
+				)
+			}
+			item.proxy = proxy
+		}
+		gateway.routes = append(gateway.routes, item)
+	}
+	sort.SliceStable(gateway.routes, func(i, j int) bool {
+		return len(gateway.routes[i].Prefix) > len(gateway.routes[j].Prefix)
+	})
+	return gateway, nil
+}
+
+// BindGateway creates Gateway with `config` and binds its middleware globally.
+// It is called automatically in Start if the gateway routes are configured in server configuration.
+func (s *Server) BindGateway(config GatewayConfig) error {
+	gateway, err := NewGateway(config)
+	if err != nil {
+		return err
+	}
+	s.BindMiddlewareDefault(gateway.Middleware)
+	return nil
+}
+
+// Middleware is the middleware handler of Gateway.
+// The requests not matching any route are passed through untouched.
+func (g *Gateway) Middleware(r *Request) {
+	route := g.match(r.URL.Path)
+	if route == nil {
+		r.Middleware.Next()
+		return
+	}
+	route.RequestHeaders.apply(r.Header)
+	if route.proxy != nil {
+		route.proxy.serve(r, route.rewritePath(r.URL.Path))
+	} else {
+		r.Middleware.Next()
+	}
+	route.ResponseHeaders.apply(r.Response.Header())
+	if status, ok := route.StatusMap[r.Response.Status]; ok && status > 0 {
+		r.Response.WriteHeader(status)
+	}
+}
+
+// match returns the route with the longest prefix matching `path`.
+func (g *Gateway) match(path string) *gatewayRoute {
+	for _, route := range g.routes {
+		if path == route.Prefix || strings.HasPrefix(path, strings.TrimSuffix(route.Prefix, "/")+"/") {
+			return route
+		}
+	}
+	return nil
+}
+
+// rewritePath replaces the prefix of `path` with Rewrite if configured.
+func (r *gatewayRoute) rewritePath(path string) string {
+	if r.Rewrite == "" {
+		return path
+	}
+	path = r.Rewrite + strings.TrimPrefix(path, r.Prefix)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path
+}
+
+// apply transforms `header` in order of Rename, Remove, Set and Add.
+func (t GatewayHeaderTransform) apply(header http.Header) {
+	for from, to := range t.Rename {
+		if values := header.Values(from); len(values) > 0 {
+			header.Del(from)
+			header[http.CanonicalHeaderKey(to)] = values
+		}
+	}
+	for _, key := range t.Remove {
+		header.Del(key)
+	}
+	for key, value := range t.Set {
+		header.Set(key, os.ExpandEnv(value))
+	}
+	for key, value := range t.Add {
+		header.Add(key, os.ExpandEnv(value))
+	}
+}
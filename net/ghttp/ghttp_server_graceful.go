@@ -123,20 +123,8 @@ func (s *gracefulServer) CreateListenerTLS(certFile, keyFile string, tlsConfig .
 	if config.NextProtos == nil {
 		config.NextProtos = []string{"http/1.1"}
 	}
-	var err error
-	if len(config.Certificates) == 0 {
-		config.Certificates = make([]tls.Certificate, 1)
-		if gres.Contains(certFile) {
-			config.Certificates[0], err = tls.X509KeyPair(
-				gres.GetContent(certFile),
-				gres.GetContent(keyFile),
-			)
-		} else {
-			config.Certificates[0], err = tls.LoadX509KeyPair(certFile, keyFile)
-		}
-	}
-	if err != nil {
-		return gerror.Wrapf(err, `open certFile "%s" and keyFile "%s" failed`, certFile, keyFile)
+	if err := loadTLSCertificates(config, certFile, keyFile); err != nil {
+		return err
 	}
 	ln, err := s.getNetListener()
 	if err != nil {
@@ -148,6 +136,31 @@ func (s *gracefulServer) CreateListenerTLS(certFile, keyFile string, tlsConfig .
 	return nil
 }
 
+// loadTLSCertificates loads the certification from `certFile` and `keyFile` into `config`
+// if it has no certification or certificate getter.
+func loadTLSCertificates(config *tls.Config, certFile, keyFile string) error {
+	if len(config.Certificates) > 0 || config.GetCertificate != nil {
+		return nil
+	}
+	var (
+		err         error
+		certificate tls.Certificate
+	)
+	if gres.Contains(certFile) {
+		certificate, err = tls.X509KeyPair(
+			gres.GetContent(certFile),
+			gres.GetContent(keyFile),
+		)
+	} else {
+		certificate, err = tls.LoadX509KeyPair(certFile, keyFile)
+	}
+	if err != nil {
+		return gerror.Wrapf(err, `open certFile "%s" and keyFile "%s" failed`, certFile, keyFile)
+	}
+	config.Certificates = []tls.Certificate{certificate}
+	return nil
+}
+
 // Serve starts the serving with blocking way.
 func (s *gracefulServer) Serve(ctx context.Context) error {
 	if s.rawListener == nil {
@@ -0,0 +1,418 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/net/gtrace"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gmode"
+)
+
+// GraphQLSchema is the executable GraphQL schema, which is commonly implemented by
+// the adapter of any GraphQL library.
+//
+// The context passed to Execute is the context of the http request,
+// from which the Request can be retrieved using RequestFromCtx.
+type GraphQLSchema interface {
+	Execute(ctx context.Context, req *GraphQLRequest) *GraphQLResponse
+}
+
+// GraphQLSchemaFunc is the function adapter of GraphQLSchema.
+type GraphQLSchemaFunc func(ctx context.Context, req *GraphQLRequest) *GraphQLResponse
+
+// GraphQLRequest is the GraphQL request from client.
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	Extensions    map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// GraphQLResponse is the GraphQL response to client.
+type GraphQLResponse struct {
+	Data       interface{}            `json:"data,omitempty"`
+	Errors     []*GraphQLError        `json:"errors,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// GraphQLError is the error item of GraphQLResponse.
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// GraphQLOption is the option for GraphQL handler.
+type GraphQLOption struct {
+	// PlaygroundPattern is the route pattern of GraphiQL playground page, eg: /graphql/playground.
+	// The playground is served only in develop mode of gmode, and it is disabled if empty.
+	PlaygroundPattern string
+	// PersistedQueries is the registered persisted queries, which are query sha256 hashes to queries.
+	PersistedQueries map[string]string
+	// PersistedQueryCacheSize is the max count of automatic persisted queries that cached in memory.
+	// The automatic persisted queries are disabled if it is negative, the default size is 1000.
+	PersistedQueryCacheSize int
+}
+
+const (
+	defaultGraphQLPersistedQueryCacheSize = 1000
+	graphQLErrorPersistedQueryNotFound    = "PersistedQueryNotFound"
+	graphQLErrorPersistedQueryNotMatch    = "provided sha does not match query"
+	tracingInstrumentGraphQL              = "github.com/gogf/gf/v2/net/ghttp.GraphQL"
+	tracingAttrGraphQLOperationName       = "graphql.operation.name"
+	tracingAttrGraphQLResolverField       = "graphql.resolver.field"
+	graphQLPlaygroundURLPlaceHolder       = `{GraphQLUrl}`
+	graphQLPlaygroundTemplate             = `
+<!DOCTYPE html>
+<html>
+	<head>
+	<title>GraphQL Playground</title>
+	<meta charset="utf-8"/>
+	<link rel="stylesheet" href="https://unpkg.com/graphiql/graphiql.min.css"/>
+	<style>
+		body {
+			margin:  0;
+			height:  100vh;
+		}
+		#graphiql {
+			height: 100vh;
+		}
+	</style>
+	</head>
+	<body>
+		<div id="graphiql"></div>
+		<script src="https://unpkg.com/react/umd/react.production.min.js"></script>
+		<script src="https://unpkg.com/react-dom/umd/react-dom.production.min.js"></script>
+		<script src="https://unpkg.com/graphiql/graphiql.min.js"></script>
+		<script>
+			ReactDOM.render(
+				React.createElement(GraphiQL, {
+					fetcher: GraphiQL.createFetcher({url: "{GraphQLUrl}"}),
+				}),
+				document.getElementById("graphiql"),
+			);
+		</script>
+	</body>
+</html>
+`
+)
+
+// Execute implements the interface GraphQLSchema.
+func (f GraphQLSchemaFunc) Execute(ctx context.Context, req *GraphQLRequest) *GraphQLResponse {
+	return f(ctx, req)
+}
+
+// graphQLHandler is the http handler serving GraphQL requests.
+type graphQLHandler struct {
+	schema           GraphQLSchema
+	persistedQueries map[string]string
+	persistedCache   *gcache.Cache // Automatic persisted queries, nil if disabled.
+}
+
+// BindGraphQL registers GraphQL `schema` to `pattern`, eg: /graphql.
+// It handles the queries using GET and POST methods, and mutations using POST method only.
+//
+// The GraphQL requests are handled like any other http requests,
+// which means all the middlewares bound to the `pattern` also work for GraphQL.
+func (s *Server) BindGraphQL(pattern string, schema GraphQLSchema, option ...GraphQLOption) {
+	s.Domain(DefaultDomainName).BindGraphQL(pattern, schema, option...)
+}
+
+// BindGraphQL registers GraphQL `schema` to `pattern` for server of specified domain.
+// See Server.BindGraphQL.
+func (d *Domain) BindGraphQL(pattern string, schema GraphQLSchema, option ...GraphQLOption) {
+	var opt GraphQLOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	h := &graphQLHandler{
+		schema:           schema,
+		persistedQueries: opt.PersistedQueries,
+	}
+	switch {
+	case opt.PersistedQueryCacheSize == 0:
+		h.persistedCache = gcache.New(defaultGraphQLPersistedQueryCacheSize)
+	case opt.PersistedQueryCacheSize > 0:
+		h.persistedCache = gcache.New(opt.PersistedQueryCacheSize)
+	}
+	d.BindHandler(pattern, h.Serve)
+	if opt.PlaygroundPattern != "" && gmode.IsDevelop() {
+		_, _, uri, _ := d.server.parsePattern(pattern)
+		content := gstr.Replace(graphQLPlaygroundTemplate, graphQLPlaygroundURLPlaceHolder, uri)
+		d.BindHandler("GET:"+opt.PlaygroundPattern, func(r *Request) {
+			r.Response.Write(content)
+		})
+	}
+}
+
+// Serve handles the GraphQL request.
+func (h *graphQLHandler) Serve(r *Request) {
+	req, err := h.parseRequest(r)
+	if err != nil {
+		h.writeError(r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if res := h.resolvePersistedQuery(r.Context(), req); res != nil {
+		r.Response.WriteJson(res)
+		return
+	}
+	// The mutation is checked after the persisted query resolved, as its query can be empty in request.
+	if r.Method == http.MethodGet && graphQLOperationType(req.Query, req.OperationName) == "mutation" {
+		h.writeError(r, http.StatusMethodNotAllowed, "mutations are not allowed using GET method")
+		return
+	}
+	r.Response.WriteJson(h.execute(r.Context(), req))
+}
+
+// writeError writes the GraphQL response with error `message` and http `status`.
+func (h *graphQLHandler) writeError(r *Request, status int, message string) {
+	r.Response.WriteHeader(status)
+	r.Response.WriteJson(&GraphQLResponse{
+		Errors: []*GraphQLError{{Message: message}},
+	})
+}
+
+// parseRequest parses GraphQL request from query parameters for GET method, or from body for POST method.
+func (h *graphQLHandler) parseRequest(r *Request) (*GraphQLRequest, error) {
+	req := &GraphQLRequest{}
+	switch r.Method {
+	case http.MethodGet:
+		req.Query = r.GetQuery("query").String()
+		req.OperationName = r.GetQuery("operationName").String()
+		for name, pointer := range map[string]*map[string]interface{}{
+			"variables":  &req.Variables,
+			"extensions": &req.Extensions,
+		} {
+			if value := r.GetQuery(name).String(); value != "" {
+				if err := gjson.DecodeTo(value, pointer); err != nil {
+					return nil, gerror.WrapCodef(gcode.CodeInvalidParameter, err, `invalid GraphQL %s`, name)
+				}
+			}
+		}
+
+	case http.MethodPost:
+		body := r.GetBody()
+		if gstr.Contains(r.Header.Get("Content-Type"), "application/graphql") {
+			req.Query = string(body)
+		} else if err := gjson.DecodeTo(body, req); err != nil {
+			return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `invalid GraphQL request body`)
+		}
+
+	default:
+		return nil, gerror.NewCodef(gcode.CodeInvalidRequest, `method "%s" is not supported for GraphQL`, r.Method)
+	}
+	return req, nil
+}
+
+// resolvePersistedQuery resolves the query of `req` according to its persisted query extension.
+// It returns the response directly if the persisted query cannot be resolved.
+func (h *graphQLHandler) resolvePersistedQuery(ctx context.Context, req *GraphQLRequest) *GraphQLResponse {
+	persistedQuery := gconv.Map(req.Extensions["persistedQuery"])
+	if len(persistedQuery) == 0 {
+		return nil
+	}
+	hash := gconv.String(persistedQuery["sha256Hash"])
+	if req.Query == "" {
+		if query, ok := h.persistedQueries[hash]; ok {
+			req.Query = query
+			return nil
+		}
+		if h.persistedCache != nil {
+			if v, _ := h.persistedCache.Get(ctx, hash); !v.IsNil() {
+				req.Query = v.String()
+				return nil
+			}
+		}
+		return &GraphQLResponse{Errors: []*GraphQLError{{
+			Message:    graphQLErrorPersistedQueryNotFound,
+			Extensions: map[string]interface{}{"code": "PERSISTED_QUERY_NOT_FOUND"},
+		}}}
+	}
+	sum := sha256.Sum256([]byte(req.Query))
+	if hex.EncodeToString(sum[:]) != hash {
+		return &GraphQLResponse{Errors: []*GraphQLError{{Message: graphQLErrorPersistedQueryNotMatch}}}
+	}
+	if h.persistedCache != nil {
+		if err := h.persistedCache.Set(ctx, hash, req.Query, 0); err != nil {
+			intlog.Errorf(ctx, `%+v`, err)
+		}
+	}
+	return nil
+}
+
+// execute executes `req` with tracing span.
+func (h *graphQLHandler) execute(ctx context.Context, req *GraphQLRequest) *GraphQLResponse {
+	spanName := "graphql"
+	if req.OperationName != "" {
+		spanName += "." + req.OperationName
+	}
+	ctx, span := graphQLTracer().Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+	span.SetAttributes(gtrace.CommonLabels()...)
+	span.SetAttributes(attribute.String(tracingAttrGraphQLOperationName, req.OperationName))
+
+	res := h.schema.Execute(ctx, req)
+	if res == nil {
+		res = &GraphQLResponse{}
+	}
+	if len(res.Errors) > 0 {
+		span.SetStatus(codes.Error, res.Errors[0].Message)
+	}
+	return res
+}
+
+// TraceGraphQLResolver calls `resolver` of GraphQL field `field` with a tracing span,
+// which is commonly used by the adapters of GraphQL libraries for each field resolving.
+func TraceGraphQLResolver(
+	ctx context.Context, field string, resolver func(ctx context.Context) (interface{}, error),
+) (interface{}, error) {
+	ctx, span := graphQLTracer().Start(ctx, "graphql.resolve."+field, trace.WithSpanKind(trace.SpanKindInternal))
+	defer span.End()
+	span.SetAttributes(attribute.String(tracingAttrGraphQLResolverField, field))
+	result, err := resolver(ctx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func graphQLTracer() trace.Tracer {
+	return otel.GetTracerProvider().Tracer(
+		tracingInstrumentGraphQL,
+		trace.WithInstrumentationVersion(gf.VERSION),
+	)
+}
+
+// graphQLOperation is the operation definition in GraphQL query document.
+type graphQLOperation struct {
+	Type string // Operation type: query, mutation or subscription.
+	Name string // Operation name, which is empty for anonymous operation.
+}
+
+// graphQLOperationType returns the type of the operation selected by `operationName` in `query`,
+// or the only operation if `operationName` is empty.
+// It returns empty string if the operation cannot be selected, which is reported by the schema in executing.
+//
+// It scans the top level definitions of the document only, as the query is not fully parsed by ghttp.
+func graphQLOperationType(query, operationName string) string {
+	operations := parseGraphQLOperations(query)
+	if operationName == "" {
+		if len(operations) == 1 {
+			return operations[0].Type
+		}
+		return ""
+	}
+	for _, operation := range operations {
+		if operation.Name == operationName {
+			return operation.Type
+		}
+	}
+	return ""
+}
+
+// parseGraphQLOperations parses and returns the operation definitions of GraphQL `query`,
+// in which the comments, strings and nested selections are skipped.
+// The shorthand operation like "{ hello }" is a query operation without name.
+func parseGraphQLOperations(query string) []graphQLOperation {
+	var (
+		operations []graphQLOperation
+		keyword    string // Keyword of current definition: query, mutation, subscription or fragment.
+		name       string // Name of current definition.
+		directive  bool   // Whether the next name is a directive name, eg: @include.
+		depth      int    // Depth of the nested braces, parentheses and brackets.
+	)
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '#':
+			for i < len(query) && query[i] != '\n' && query[i] != '\r' {
+				i++
+			}
+
+		case c == '"':
+			if strings.HasPrefix(query[i:], `"""`) {
+				// Block string, in which only \""" is escaped.
+				i += 3
+				for i < len(query) && !strings.HasPrefix(query[i:], `"""`) {
+					if strings.HasPrefix(query[i:], `\"""`) {
+						i += 3
+					}
+					i++
+				}
+				i += 2
+				break
+			}
+			for i++; i < len(query) && query[i] != '"' && query[i] != '\n'; i++ {
+				if query[i] == '\\' {
+					i++
+				}
+			}
+
+		case c == '{' || c == '(' || c == '[':
+			if depth == 0 && c == '{' {
+				switch keyword {
+				case "":
+					operations = append(operations, graphQLOperation{Type: "query"})
+				case "fragment":
+				default:
+					operations = append(operations, graphQLOperation{Type: keyword, Name: name})
+				}
+				keyword, name = "", ""
+			}
+			depth++
+
+		case c == '}' || c == ')' || c == ']':
+			if depth > 0 {
+				depth--
+			}
+
+		case c == '@':
+			directive = true
+
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			start := i
+			for i+1 < len(query) && isGraphQLNameChar(query[i+1]) {
+				i++
+			}
+			if depth > 0 || directive {
+				directive = false
+				break
+			}
+			token := query[start : i+1]
+			switch {
+			case keyword == "":
+				keyword = token
+			case name == "":
+				name = token
+			}
+		}
+	}
+	return operations
+}
+
+// isGraphQLNameChar checks whether `c` can be a character of GraphQL name.
+func isGraphQLNameChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
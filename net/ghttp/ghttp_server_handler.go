@@ -29,9 +29,14 @@ import (
 //
 // This function also makes serve implementing the interface of http.Handler.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// Max body size limit.
-	if s.config.ClientMaxBodySize > 0 {
-		r.Body = http.MaxBytesReader(w, r.Body, s.config.ClientMaxBodySize)
+	s.inflight.Add(1)
+	defer s.inflight.Add(-1)
+	// It rejects the new requests when draining in shutting down,
+	// and closes the connection to make the client retry on other servers.
+	if s.draining.Val() {
+		w.Header().Set("Connection", "close")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
 	}
 	// Rewrite feature checks.
 	if len(s.config.Rewrites) > 0 {
@@ -71,6 +76,12 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		request.isFileRequest = false
 	}
 
+	// Max body size limit, which is checked after the serving route is searched as it might have its own limit.
+	if !s.limitRequestBody(w, request) {
+		request.Response.WriteHeader(http.StatusRequestEntityTooLarge)
+		request.exitAll = true
+	}
+
 	// Metrics.
 	s.handleMetricsBeforeRequest(request)
 
@@ -101,6 +112,12 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// The body streams in over the limit of the serving route.
+	if request.isBodyLimitExceeded() && request.Response.BytesWritten() == 0 {
+		request.Response.ClearBuffer()
+		request.Response.WriteHeader(http.StatusRequestEntityTooLarge)
+	}
+
 	// HOOK - AfterServe
 	if !request.IsExited() {
 		s.callHookHandler(HookAfterServe, request)
@@ -171,6 +188,10 @@ func (s *Server) handleResponse(request *Request, sessionId string) {
 
 func (s *Server) handleAfterRequestDone(request *Request) {
 	request.LeaveTime = gtime.Now()
+	// It stops the SSE stream as the response cannot be written after the request is done.
+	if request.Response.sseStream != nil {
+		request.Response.sseStream.Close()
+	}
 	// error log handling.
 	if request.error != nil {
 		s.handleErrorLog(request.error, request)
@@ -295,6 +316,7 @@ func (s *Server) serveFile(r *Request, f *staticFile, allowIndex ...bool) {
 			}
 		} else {
 			info := f.File.FileInfo()
+			s.setStaticCacheHeaders(r, f.File.Name(), info, f.File)
 			r.Response.ServeContent(info.Name(), info.ModTime(), f.File)
 		}
 		return
@@ -321,6 +343,7 @@ func (s *Server) serveFile(r *Request, f *staticFile, allowIndex ...bool) {
 			r.Response.WriteStatus(http.StatusForbidden)
 		}
 	} else {
+		s.setStaticCacheHeaders(r, f.Path, info, file)
 		r.Response.ServeContent(info.Name(), info.ModTime(), file)
 	}
 }
@@ -0,0 +1,182 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/os/gproc"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// HTTP3Server is the interface of the server serving HTTP/3 over QUIC.
+// As QUIC is not supported by the standard library, it is implemented by an adapter, eg:
+// github.com/gogf/gf/contrib/net/http3/v2, which registers itself using RegisterHTTP3ServerCreator.
+type HTTP3Server interface {
+	// Serve serves HTTP/3 requests on `conn` in blocking way until the server is closed.
+	Serve(conn net.PacketConn) error
+
+	// Shutdown shuts down the server gracefully, which stops accepting new requests
+	// and waits the active requests done until `ctx` is done.
+	Shutdown(ctx context.Context) error
+
+	// Close closes the server and all its connections immediately.
+	Close() error
+}
+
+// HTTP3ServerCreator creates and returns a HTTP3Server serving requests with `handler`.
+// The `tlsConfig` has the certificates loaded, which is cloned from the server configuration.
+type HTTP3ServerCreator func(tlsConfig *tls.Config, handler http.Handler) HTTP3Server
+
+// http3Server wraps the HTTP3Server with its listening UDP connection.
+type http3Server struct {
+	server      *Server        // Belonged server.
+	address     string         // Listening address like: ":443".
+	conn        net.PacketConn // Underlying UDP connection.
+	http3Server HTTP3Server    // Underlying HTTP/3 server.
+	status      *gtype.Int     // Status of current server.
+}
+
+// http3ServerCreator is the registered creator for HTTP/3 server.
+var http3ServerCreator HTTP3ServerCreator
+
+// RegisterHTTP3ServerCreator registers the creator for HTTP/3 server,
+// which is commonly called by the adapter in its package initialization.
+func RegisterHTTP3ServerCreator(creator HTTP3ServerCreator) {
+	http3ServerCreator = creator
+}
+
+// startHTTP3Servers starts listening and serving HTTP/3 on configured UDP addresses,
+// and then advertises the listening ports using response header "Alt-Svc".
+//
+// Note that the UDP listeners are not passed to the child process in graceful reloading,
+// the child process fails listening them if the parent process still holds the ports,
+// in which case the clients fall back to HTTPS.
+func (s *Server) startHTTP3Servers(ctx context.Context) {
+	if http3ServerCreator == nil {
+		s.Logger().Fatal(
+			ctx,
+			`HTTP/3 enabled but no HTTP/3 server adapter registered, `+
+				`import the adapter like: _ "github.com/gogf/gf/contrib/net/http3/v2"`,
+		)
+		return
+	}
+	if s.config.HTTPSAddr == "" {
+		s.Logger().Fatal(ctx, `HTTP/3 requires HTTPS, configure the certification using EnableHTTPS or SetTLSConfig`)
+		return
+	}
+	var tlsConfig *tls.Config
+	if s.config.TLSConfig != nil {
+		tlsConfig = s.config.TLSConfig.Clone()
+	} else {
+		tlsConfig = &tls.Config{}
+	}
+	if err := loadTLSCertificates(tlsConfig, s.config.HTTPSCertPath, s.config.HTTPSKeyPath); err != nil {
+		s.Logger().Fatalf(ctx, `%+v`, err)
+		return
+	}
+	var (
+		ports     []string
+		addresses = gstr.SplitAndTrim(s.config.HTTP3Addr, ",")
+	)
+	if len(addresses) == 0 {
+		// Using the same ports as HTTPS, which are listened already.
+		for _, gs := range s.servers {
+			if gs.isHttps {
+				addresses = append(addresses, gs.GetListenedAddress())
+			}
+		}
+	}
+	for _, address := range addresses {
+		if gstr.IsNumeric(address) {
+			address = ":" + address
+		}
+		conn, err := net.ListenPacket("udp", address)
+		if err != nil {
+			s.Logger().Errorf(ctx, `HTTP/3 listening UDP address "%s" failed: %+v`, address, err)
+			continue
+		}
+		hs := &http3Server{
+			server:      s,
+			address:     address,
+			conn:        conn,
+			http3Server: http3ServerCreator(tlsConfig, http.HandlerFunc(s.config.Handler)),
+			status:      gtype.NewInt(ServerStatusRunning),
+		}
+		s.http3Servers = append(s.http3Servers, hs)
+		ports = append(ports, fmt.Sprintf(`h3=":%d"; ma=%d`, hs.GetListenedPort(), int(s.config.HTTP3AltSvcMaxAge/time.Second)))
+		go hs.serve(ctx)
+	}
+	s.http3AltSvc.Set(strings.Join(ports, ", "))
+}
+
+// serve starts serving HTTP/3 requests in blocking way.
+func (s *http3Server) serve(ctx context.Context) {
+	s.server.Logger().Infof(
+		ctx,
+		`pid[%d]: http3 server started listening on [%s]`,
+		gproc.Pid(), s.conn.LocalAddr().String(),
+	)
+	err := s.http3Server.Serve(s.conn)
+	// The error is ignored if the server is closed by shutdown or close.
+	if s.status.Set(ServerStatusStopped) == ServerStatusRunning && err != nil && !errors.Is(err, http.ErrServerClosed) {
+		s.server.Logger().Errorf(ctx, `%d: http3 server [%s] serving error: %+v`, gproc.Pid(), s.address, err)
+	}
+}
+
+// GetListenedPort retrieves and returns the UDP port which is listened by current server.
+func (s *http3Server) GetListenedPort() int {
+	if addr, ok := s.conn.LocalAddr().(*net.UDPAddr); ok {
+		return addr.Port
+	}
+	return -1
+}
+
+// shutdown shuts down the server gracefully.
+func (s *http3Server) shutdown(ctx context.Context) {
+	if s.status.Set(ServerStatusStopped) == ServerStatusStopped {
+		return
+	}
+	s.server.http3AltSvc.Set("")
+	timeoutCtx, cancelFunc := context.WithTimeout(
+		ctx,
+		time.Duration(s.server.config.GracefulShutdownTimeout)*time.Second,
+	)
+	defer cancelFunc()
+	if err := s.http3Server.Shutdown(timeoutCtx); err != nil {
+		s.server.Logger().Errorf(
+			ctx,
+			"%d: http3 server [%s] shutdown error: %v",
+			gproc.Pid(), s.address, err,
+		)
+	}
+	_ = s.conn.Close()
+}
+
+// close shuts down the server forcibly.
+func (s *http3Server) close(ctx context.Context) {
+	if s.status.Set(ServerStatusStopped) == ServerStatusStopped {
+		return
+	}
+	s.server.http3AltSvc.Set("")
+	if err := s.http3Server.Close(); err != nil {
+		s.server.Logger().Errorf(
+			ctx,
+			"%d: http3 server [%s] closed error: %v",
+			gproc.Pid(), s.address, err,
+		)
+	}
+	_ = s.conn.Close()
+}
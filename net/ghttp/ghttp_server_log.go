@@ -7,6 +7,7 @@
 package ghttp
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -21,22 +22,24 @@ func (s *Server) handleAccessLog(r *Request) {
 		return
 	}
 	var (
-		scheme            = r.GetSchema()
+		content           = s.formatAccessLog(r)
 		loggerInstanceKey = fmt.Sprintf(`Acccess Logger Of Server:%s`, s.instance)
 	)
-	content := fmt.Sprintf(
-		`%d "%s %s %s %s %s" %.3f, %s, "%s", "%s"`,
-		r.Response.Status, r.Method, scheme, r.Host, r.URL.String(), r.Proto,
-		float64(r.LeaveTime.Sub(r.EnterTime).Milliseconds())/1000,
-		r.GetClientIp(), r.Referer(), r.UserAgent(),
-	)
 	logger := instance.GetOrSetFuncLock(loggerInstanceKey, func() interface{} {
 		l := s.Logger().Clone()
 		l.SetFile(s.config.AccessLogPattern)
 		l.SetStdoutPrint(s.config.LogStdout)
 		l.SetLevelPrint(false)
+		if s.isAccessLogStructured() {
+			l.SetHeaderPrint(false)
+		}
 		return l
 	}).(*glog.Logger)
+	if s.isAccessLogStructured() {
+		// The trace id is a field of the structured content, which is not printed by logger.
+		logger.Print(context.Background(), content)
+		return
+	}
 	logger.Print(r.Context(), content)
 }
 
@@ -0,0 +1,255 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/net/gtrace"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// AccessLogEntry is the information of a request for access logging.
+type AccessLogEntry struct {
+	Time      time.Time     // Time the request entered.
+	Status    int           // Response status code.
+	Method    string        // Request method.
+	Scheme    string        // Request scheme, http or https.
+	Host      string        // Request host.
+	URI       string        // Request URI with query string.
+	Path      string        // Request URL path.
+	Route     string        // Matched route pattern, which is empty if no route matched.
+	Proto     string        // Request protocol like HTTP/1.1.
+	Latency   time.Duration // Duration serving the request.
+	ClientIp  string        // Client IP address.
+	Referer   string        // Referer header.
+	UserAgent string        // User-Agent header.
+	TraceId   string        // Trace ID of the request.
+	Bytes     int64         // Bytes of response body written.
+	Upstream  string        // Upstream the request was forwarded to by ReverseProxy.
+}
+
+// AccessLogFormatter formats and returns the access log content of `entry`, which is for request `r`.
+type AccessLogFormatter func(r *Request, entry *AccessLogEntry) string
+
+const (
+	AccessLogFormatText = "text" // The default text format.
+	AccessLogFormatJson = "json" // JSON object of the fields in AccessLogFields per line.
+)
+
+// Fields of the access log, which are used as JSON keys or template placeholders like "{status}".
+const (
+	AccessLogFieldTime      = "time"
+	AccessLogFieldStatus    = "status"
+	AccessLogFieldMethod    = "method"
+	AccessLogFieldScheme    = "scheme"
+	AccessLogFieldHost      = "host"
+	AccessLogFieldUri       = "uri"
+	AccessLogFieldPath      = "path"
+	AccessLogFieldRoute     = "route"
+	AccessLogFieldProto     = "proto"
+	AccessLogFieldLatency   = "latency" // Latency in seconds.
+	AccessLogFieldClientIp  = "clientIp"
+	AccessLogFieldReferer   = "referer"
+	AccessLogFieldUserAgent = "userAgent"
+	AccessLogFieldTraceId   = "traceId"
+	AccessLogFieldBytes     = "bytes"
+	AccessLogFieldUpstream  = "upstream"
+)
+
+// defaultAccessLogFields are the fields of JSON access log if AccessLogFields is not configured.
+var defaultAccessLogFields = []string{
+	AccessLogFieldTime,
+	AccessLogFieldStatus,
+	AccessLogFieldMethod,
+	AccessLogFieldScheme,
+	AccessLogFieldHost,
+	AccessLogFieldUri,
+	AccessLogFieldRoute,
+	AccessLogFieldProto,
+	AccessLogFieldLatency,
+	AccessLogFieldClientIp,
+	AccessLogFieldReferer,
+	AccessLogFieldUserAgent,
+	AccessLogFieldTraceId,
+	AccessLogFieldBytes,
+}
+
+// SetAccessLogFormat sets the format of access log, which is AccessLogFormatText, AccessLogFormatJson
+// or a template with field placeholders like "{method} {uri} {status} {latency}".
+// The optional parameter `fields` specifies the fields of JSON format.
+func (s *Server) SetAccessLogFormat(format string, fields ...string) {
+	s.config.AccessLogFormat = format
+	if len(fields) > 0 {
+		s.config.AccessLogFields = fields
+	}
+}
+
+// SetAccessLogFormatter sets the custom formatter of access log, which overrides AccessLogFormat.
+func (s *Server) SetAccessLogFormatter(formatter AccessLogFormatter) {
+	s.config.AccessLogFormatter = formatter
+}
+
+// isAccessLogStructured checks whether the access log is not the default text format,
+// which should be printed without logging header.
+func (s *Server) isAccessLogStructured() bool {
+	if s.config.AccessLogFormatter != nil {
+		return true
+	}
+	return s.config.AccessLogFormat != "" && s.config.AccessLogFormat != AccessLogFormatText
+}
+
+// newAccessLogEntry creates and returns the access log entry of request `r`.
+func newAccessLogEntry(r *Request) *AccessLogEntry {
+	entry := &AccessLogEntry{
+		Time:      r.EnterTime.Time,
+		Status:    r.Response.Status,
+		Method:    r.Method,
+		Scheme:    r.GetSchema(),
+		Host:      r.Host,
+		URI:       r.URL.String(),
+		Path:      r.URL.Path,
+		Proto:     r.Proto,
+		Latency:   r.LeaveTime.Sub(r.EnterTime),
+		ClientIp:  r.GetClientIp(),
+		Referer:   r.Referer(),
+		UserAgent: r.UserAgent(),
+		TraceId:   gtrace.GetTraceID(r.Context()),
+		Bytes:     r.Response.BytesWritten(),
+		Upstream:  r.upstream,
+	}
+	if r.Router != nil {
+		entry.Route = r.Router.Uri
+	}
+	return entry
+}
+
+// Value returns the value of `field`, or nil if `field` is unknown.
+func (e *AccessLogEntry) Value(field string) interface{} {
+	switch field {
+	case AccessLogFieldTime:
+		return e.Time.Format(time.RFC3339Nano)
+	case AccessLogFieldStatus:
+		return e.Status
+	case AccessLogFieldMethod:
+		return e.Method
+	case AccessLogFieldScheme:
+		return e.Scheme
+	case AccessLogFieldHost:
+		return e.Host
+	case AccessLogFieldUri:
+		return e.URI
+	case AccessLogFieldPath:
+		return e.Path
+	case AccessLogFieldRoute:
+		return e.Route
+	case AccessLogFieldProto:
+		return e.Proto
+	case AccessLogFieldLatency:
+		return e.Latency.Seconds()
+	case AccessLogFieldClientIp:
+		return e.ClientIp
+	case AccessLogFieldReferer:
+		return e.Referer
+	case AccessLogFieldUserAgent:
+		return e.UserAgent
+	case AccessLogFieldTraceId:
+		return e.TraceId
+	case AccessLogFieldBytes:
+		return e.Bytes
+	case AccessLogFieldUpstream:
+		return e.Upstream
+	}
+	return nil
+}
+
+// formatAccessLog formats and returns the access log content of request `r` using the configured format.
+func (s *Server) formatAccessLog(r *Request) string {
+	if s.config.AccessLogFormatter == nil &&
+		(s.config.AccessLogFormat == "" || s.config.AccessLogFormat == AccessLogFormatText) {
+		return fmt.Sprintf(
+			`%d "%s %s %s %s %s" %.3f, %s, "%s", "%s"`,
+			r.Response.Status, r.Method, r.GetSchema(), r.Host, r.URL.String(), r.Proto,
+			float64(r.LeaveTime.Sub(r.EnterTime).Milliseconds())/1000,
+			r.GetClientIp(), r.Referer(), r.UserAgent(),
+		)
+	}
+	entry := newAccessLogEntry(r)
+	if s.config.AccessLogFormatter != nil {
+		return s.config.AccessLogFormatter(r, entry)
+	}
+	if s.config.AccessLogFormat == AccessLogFormatJson {
+		fields := s.config.AccessLogFields
+		if len(fields) == 0 {
+			fields = defaultAccessLogFields
+		}
+		return entry.formatJson(fields)
+	}
+	return entry.formatTemplate(s.config.AccessLogFormat)
+}
+
+// formatJson formats `fields` of entry as JSON object, keeping the order of `fields`.
+func (e *AccessLogEntry) formatJson(fields []string) string {
+	var buffer = bytes.NewBuffer(nil)
+	buffer.WriteByte('{')
+	for i, field := range fields {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		key, _ := json.Marshal(field)
+		value, err := json.Marshal(e.Value(field))
+		if err != nil {
+			value = []byte(`null`)
+		}
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+	return buffer.String()
+}
+
+// formatTemplate replaces the field placeholders in `template` with their values.
+func (e *AccessLogEntry) formatTemplate(template string) string {
+	replaces := make(map[string]string)
+	for _, field := range accessLogTemplateFields(template) {
+		var value string
+		switch field {
+		case AccessLogFieldLatency:
+			value = fmt.Sprintf(`%.3f`, e.Latency.Seconds())
+		default:
+			if v := e.Value(field); v != nil {
+				value = fmt.Sprint(v)
+			} else {
+				continue
+			}
+		}
+		replaces[`{`+field+`}`] = value
+	}
+	return gstr.ReplaceByMap(template, replaces)
+}
+
+// accessLogTemplateFields returns the field names of the placeholders in `template`.
+func accessLogTemplateFields(template string) []string {
+	var fields []string
+	for {
+		start := gstr.Pos(template, `{`)
+		if start < 0 {
+			break
+		}
+		end := gstr.Pos(template[start:], `}`)
+		if end < 0 {
+			break
+		}
+		fields = append(fields, template[start+1:start+end])
+		template = template[start+end+1:]
+	}
+	return fields
+}
@@ -9,6 +9,7 @@ package ghttp
 import (
 	"net"
 	"net/http"
+	"sync"
 
 	"github.com/gogf/gf/v2"
 	"github.com/gogf/gf/v2/errors/gerror"
@@ -23,8 +24,14 @@ type localMetricManager struct {
 	HttpServerRequestDurationTotal gmetric.Counter
 	HttpServerRequestBodySize      gmetric.Counter
 	HttpServerResponseBodySize     gmetric.Counter
+	HttpServerRequestSize          gmetric.Histogram
+	HttpServerResponseSize         gmetric.Histogram
 }
 
+// MetricHook is the hook called after each request is done with the metric attributes of the request,
+// which is used for recording custom metrics sharing the attributes of server metrics.
+type MetricHook func(r *Request, attrMap gmetric.AttributeMap)
+
 const (
 	metricAttrKeyServerAddress          = "server.address"
 	metricAttrKeyServerPort             = "server.port"
@@ -38,10 +45,70 @@ const (
 
 var (
 	// metricManager for http server metrics.
-	metricManager = newMetricManager()
+	// It is lazily created as the buckets of duration histogram can be customized by server configuration.
+	metricManager     *localMetricManager
+	metricManagerOnce sync.Once
+
+	// defaultMetricDurationBuckets is the default buckets for request duration histogram.
+	defaultMetricDurationBuckets = []float64{
+		1,
+		5,
+		10,
+		25,
+		50,
+		75,
+		100,
+		250,
+		500,
+		750,
+		1000,
+		2500,
+		5000,
+		7500,
+		10000,
+		30000,
+		60000,
+	}
+
+	// defaultMetricSizeBuckets is the default buckets for request and response size histograms.
+	defaultMetricSizeBuckets = []float64{
+		100,
+		1000,
+		10000,
+		100000,
+		1000000,
+		10000000,
+		100000000,
+	}
+
+	// metricHandler is the handler exporting metrics, which is registered by metric implementation.
+	metricHandler HandlerFunc
 )
 
-func newMetricManager() *localMetricManager {
+// RegisterMetricHandler registers the handler exporting metrics, which is served at server MetricPath.
+// It is commonly called by metric implementation, eg: the prometheus exporting of contrib/metric/otelmetric.
+func RegisterMetricHandler(handler HandlerFunc) {
+	metricHandler = handler
+}
+
+// initMetricManager creates the metricManager using duration buckets of current server if it is not created.
+func (s *Server) initMetricManager() {
+	metricManagerOnce.Do(func() {
+		var (
+			durationBuckets = s.config.MetricDurationBuckets
+			sizeBuckets     = s.config.MetricSizeBuckets
+		)
+		if len(durationBuckets) == 0 {
+			durationBuckets = defaultMetricDurationBuckets
+		}
+		if len(sizeBuckets) == 0 {
+			sizeBuckets = defaultMetricSizeBuckets
+		}
+		metricManager = newMetricManager(durationBuckets, sizeBuckets)
+	})
+}
+
+func newMetricManager(durationBuckets, sizeBuckets []float64) *localMetricManager {
 	meter := gmetric.GetGlobalProvider().Meter(gmetric.MeterOption{
 		Instrument:        instrumentName,
 		InstrumentVersion: gf.VERSION,
@@ -53,25 +120,7 @@ func newMetricManager() *localMetricManager {
 				Help:       "Measures the duration of inbound request.",
 				Unit:       "ms",
 				Attributes: gmetric.Attributes{},
-				Buckets: []float64{
-					1,
-					5,
-					10,
-					25,
-					50,
-					75,
-					100,
-					250,
-					500,
-					750,
-					1000,
-					2500,
-					5000,
-					7500,
-					10000,
-					30000,
-					60000,
-				},
+				Buckets:    durationBuckets,
 			},
 		),
 		HttpServerRequestTotal: meter.MustCounter(
@@ -114,6 +163,24 @@ func newMetricManager() *localMetricManager {
 				Attributes: gmetric.Attributes{},
 			},
 		),
+		HttpServerRequestSize: meter.MustHistogram(
+			"http.server.request.size",
+			gmetric.MetricOption{
+				Help:       "Measures the size of inbound request body.",
+				Unit:       "bytes",
+				Attributes: gmetric.Attributes{},
+				Buckets:    sizeBuckets,
+			},
+		),
+		HttpServerResponseSize: meter.MustHistogram(
+			"http.server.response.size",
+			gmetric.MetricOption{
+				Help:       "Measures the size of response body.",
+				Unit:       "bytes",
+				Attributes: gmetric.Attributes{},
+				Buckets:    sizeBuckets,
+			},
+		),
 	}
 	return mm
 }
@@ -123,6 +190,9 @@ func (m *localMetricManager) GetMetricOptionForRequestDurationByMap(attrMap gmet
 		Attributes: attrMap.Pick(
 			metricAttrKeyServerAddress,
 			metricAttrKeyServerPort,
+			metricAttrKeyHttpRoute,
+			metricAttrKeyHttpRequestMethod,
+			metricAttrKeyHttpResponseStatusCode,
 		),
 	}
 }
@@ -203,10 +273,31 @@ func (m *localMetricManager) GetMetricAttributeMap(r *Request) gmetric.Attribute
 	return attrMap
 }
 
+// isMetricExcluded checks and returns whether the request should not be recorded by server metrics.
+func (s *Server) isMetricExcluded(r *Request) bool {
+	if len(s.config.MetricExcludeRoutes) == 0 && s.config.MetricPath == "" {
+		return false
+	}
+	if s.config.MetricPath != "" && r.URL.Path == s.config.MetricPath {
+		return true
+	}
+	var httpRoute string
+	if handler := r.GetServeHandler(); handler != nil && handler.Handler.Router != nil {
+		httpRoute = handler.Handler.Router.Uri
+	}
+	for _, route := range s.config.MetricExcludeRoutes {
+		if route == r.URL.Path || (httpRoute != "" && route == httpRoute) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Server) handleMetricsBeforeRequest(r *Request) {
-	if !gmetric.IsEnabled() {
+	if !gmetric.IsEnabled() || s.isMetricExcluded(r) {
 		return
 	}
+	s.initMetricManager()
 	var (
 		ctx           = r.Context()
 		attrMap       = metricManager.GetMetricAttributeMap(r)
@@ -224,12 +315,22 @@ func (s *Server) handleMetricsBeforeRequest(r *Request) {
 }
 
 func (s *Server) handleMetricsAfterRequestDone(r *Request) {
-	if !gmetric.IsEnabled() {
+	if (!gmetric.IsEnabled() && len(s.metricHooks) == 0) || s.isMetricExcluded(r) {
 		return
 	}
+	s.initMetricManager()
+	attrMap := metricManager.GetMetricAttributeMap(r)
+	if gmetric.IsEnabled() {
+		s.recordMetricsAfterRequestDone(r, attrMap)
+	}
+	for _, hook := range s.metricHooks {
+		hook(r, attrMap)
+	}
+}
+
+func (s *Server) recordMetricsAfterRequestDone(r *Request, attrMap gmetric.AttributeMap) {
 	var (
 		ctx             = r.Context()
-		attrMap         = metricManager.GetMetricAttributeMap(r)
 		durationMilli   = float64(r.LeaveTime.Sub(r.EnterTime).Milliseconds())
 		responseOption  = metricManager.GetMetricOptionForResponseByMap(attrMap)
 		histogramOption = metricManager.GetMetricOptionForRequestDurationByMap(attrMap)
@@ -253,4 +354,14 @@ func (s *Server) handleMetricsAfterRequestDone(r *Request) {
 		durationMilli,
 		histogramOption,
 	)
+	if r.ContentLength >= 0 {
+		metricManager.HttpServerRequestSize.Record(
+			float64(r.ContentLength),
+			histogramOption,
+		)
+	}
+	metricManager.HttpServerResponseSize.Record(
+		float64(r.Response.BytesWritten()),
+		histogramOption,
+	)
 }
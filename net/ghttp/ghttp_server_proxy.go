@@ -0,0 +1,296 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gsel"
+	"github.com/gogf/gf/v2/net/gsvc"
+)
+
+// Load balancing policies of ReverseProxy.
+const (
+	ProxyBalanceRoundRobin = "round-robin" // Picks upstreams in turn, which is the default policy.
+	ProxyBalanceWeight     = "weighted"    // Picks upstreams randomly in proportion to their weights.
+	ProxyBalanceLeastConn  = "least-conn"  // Picks the upstream with the least in-flight requests.
+)
+
+// ReverseProxyConfig is the configuration of ReverseProxy.
+type ReverseProxyConfig struct {
+	Upstreams       []ReverseProxyUpstream `json:"upstreams"`       // Upstreams to forward requests to.
+	Balance         string                 `json:"balance"`         // Load balancing policy, like ProxyBalanceRoundRobin.
+	Retries         int                    `json:"retries"`         // Max retries with another picked upstream on connection failures.
+	StripPrefix     string                 `json:"stripPrefix"`     // Prefix removed from request path before forwarding.
+	RequestHeaders  GatewayHeaderTransform `json:"requestHeaders"`  // Transformation of request headers.
+	ResponseHeaders GatewayHeaderTransform `json:"responseHeaders"` // Transformation of response headers.
+	Transport       http.RoundTripper      `json:"-"`               // Transport for upstream requests, http.DefaultTransport in default.
+}
+
+// ReverseProxyUpstream is an upstream of ReverseProxy.
+type ReverseProxyUpstream struct {
+	URL    string `json:"url"`    // Upstream URL like "http://127.0.0.1:8000/prefix".
+	Weight int    `json:"weight"` // Weight for ProxyBalanceWeight, which is 1 if not set.
+}
+
+// ReverseProxy forwards requests to multiple upstreams with load balancing.
+// It propagates the tracing context of the request to upstreams, so that the
+// upstream spans join the trace started by the server tracing middleware.
+type ReverseProxy struct {
+	config   ReverseProxyConfig
+	selector gsel.Selector
+	proxy    *httputil.ReverseProxy
+}
+
+// proxyNode implements gsel.Node for upstream.
+type proxyNode struct {
+	service gsvc.Service
+	target  *url.URL
+}
+
+// proxyTransport picks upstream for each attempt, and retries on connection failures.
+type proxyTransport struct {
+	proxy *ReverseProxy
+	next  http.RoundTripper
+}
+
+// proxyBody calls done when the upstream response body is closed,
+// which is when the request is really finished for ProxyBalanceLeastConn.
+type proxyBody struct {
+	io.ReadCloser
+	once sync.Once
+	done func()
+}
+
+// NewReverseProxy creates and returns a ReverseProxy with `config`.
+//
+// The request body is replayed in retries only if it can be retrieved again,
+// which is always true for ReverseProxy.Handler as the body of Request is cached.
+// For ReverseProxy.ServeHTTP, the requests with body that cannot be replayed are not retried.
+func NewReverseProxy(config ReverseProxyConfig) (*ReverseProxy, error) {
+	if len(config.Upstreams) == 0 {
+		return nil, gerror.NewCode(gcode.CodeInvalidConfiguration, `reverse proxy upstreams cannot be empty`)
+	}
+	var selector gsel.Selector
+	switch config.Balance {
+	case "", ProxyBalanceRoundRobin:
+		selector = gsel.NewSelectorRoundRobin()
+	case ProxyBalanceWeight:
+		selector = gsel.NewSelectorWeight()
+	case ProxyBalanceLeastConn:
+		selector = gsel.NewSelectorLeastConnection()
+	default:
+		return nil, gerror.NewCodef(gcode.CodeInvalidConfiguration, `unsupported reverse proxy balance "%s"`, config.Balance)
+	}
+	nodes := make(gsel.Nodes, 0, len(config.Upstreams))
+	for _, upstream := range config.Upstreams {
+		target, err := url.Parse(upstream.URL)
+		if err != nil || target.Scheme == "" || target.Host == "" {
+			return nil, gerror.NewCodef(gcode.CodeInvalidConfiguration, `invalid reverse proxy upstream "%s"`, upstream.URL)
+		}
+		service := &gsvc.LocalService{
+			Name:     target.Host,
+			Metadata: make(gsvc.Metadata),
+		}
+		if upstream.Weight > 0 {
+			service.Metadata.Set(gsvc.MDWeight, upstream.Weight)
+		}
+		nodes = append(nodes, &proxyNode{service: service, target: target})
+	}
+	if err := selector.Update(context.Background(), nodes); err != nil {
+		return nil, err
+	}
+	if config.Transport == nil {
+		config.Transport = http.DefaultTransport
+	}
+	p := &ReverseProxy{
+		config:   config,
+		selector: selector,
+	}
+	p.proxy = &httputil.ReverseProxy{
+		Director:       p.director,
+		Transport:      &proxyTransport{proxy: p, next: config.Transport},
+		ModifyResponse: p.modifyResponse,
+		ErrorHandler:   p.errorHandler,
+	}
+	return p, nil
+}
+
+// ServeHTTP implements the interface http.Handler.
+func (p *ReverseProxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	p.proxy.ServeHTTP(w, req)
+}
+
+// Handler is the handler of ReverseProxy for binding to the server, eg:
+//
+//	s.BindHandler("/api/*", proxy.Handler)
+func (p *ReverseProxy) Handler(r *Request) {
+	p.serve(r, r.URL.Path)
+}
+
+// serve forwards `r` with request path `path`.
+// The context of `r` is used for the upstream request, which carries the tracing span.
+func (p *ReverseProxy) serve(r *Request, path string) {
+	var (
+		body   = r.GetBody()
+		outReq = r.Request.Clone(r.Context())
+	)
+	outReq.URL.Path = path
+	outReq.URL.RawPath = ""
+	outReq.RequestURI = ""
+	outReq.ContentLength = int64(len(body))
+	outReq.Body = io.NopCloser(bytes.NewReader(body))
+	outReq.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	if len(body) == 0 {
+		outReq.Body = http.NoBody
+	}
+	p.proxy.ServeHTTP(r.Response.BufferWriter, outReq)
+}
+
+func (p *ReverseProxy) director(req *http.Request) {
+	if p.config.StripPrefix != "" {
+		// The prefix is stripped only at path segment boundary,
+		// eg: prefix "/api" strips "/api" and "/api/x", but not "/apiv2/x".
+		prefix := strings.TrimSuffix(p.config.StripPrefix, "/")
+		if req.URL.Path == prefix || strings.HasPrefix(req.URL.Path, prefix+"/") {
+			req.URL.Path = strings.TrimPrefix(req.URL.Path, prefix)
+			if req.URL.Path == "" {
+				req.URL.Path = "/"
+			}
+			req.URL.RawPath = ""
+		}
+	}
+	p.config.RequestHeaders.apply(req.Header)
+}
+
+func (p *ReverseProxy) modifyResponse(resp *http.Response) error {
+	p.config.ResponseHeaders.apply(resp.Header)
+	return nil
+}
+
+func (p *ReverseProxy) errorHandler(w http.ResponseWriter, req *http.Request, err error) {
+	if r := RequestFromCtx(req.Context()); r != nil {
+		r.Server.Logger().Errorf(req.Context(), `reverse proxy forwarding "%s" failed: %+v`, req.URL.Path, err)
+	}
+	if gerror.Code(err) == gcode.CodeServerBusy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusBadGateway)
+}
+
+// RoundTrip implements the interface http.RoundTripper.
+func (t *proxyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var (
+		ctx      = req.Context()
+		attempts = t.proxy.config.Retries + 1
+	)
+	for attempt := 1; ; attempt++ {
+		node, done, err := t.proxy.selector.Pick(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			return nil, gerror.NewCode(gcode.CodeServerBusy, `no available upstream`)
+		}
+		outReq := req.Clone(ctx)
+		if attempt > 1 && req.GetBody != nil {
+			if outReq.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		node.(*proxyNode).rewrite(outReq)
+		if r := RequestFromCtx(ctx); r != nil {
+			r.upstream = outReq.URL.Scheme + "://" + outReq.URL.Host
+			r.Server.getPropagator().Inject(ctx, propagation.HeaderCarrier(outReq.Header))
+		} else {
+			otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(outReq.Header))
+		}
+
+		resp, err := t.next.RoundTrip(outReq)
+		if err != nil {
+			if done != nil {
+				done(ctx, gsel.DoneInfo{Err: err})
+			}
+			if attempt < attempts && isProxyConnectionError(err) && isProxyBodyReplayable(req) {
+				continue
+			}
+			return nil, err
+		}
+		if done != nil {
+			resp.Body = &proxyBody{
+				ReadCloser: resp.Body,
+				done: func() {
+					done(ctx, gsel.DoneInfo{BytesSent: true, BytesReceived: true})
+				},
+			}
+		}
+		return resp, nil
+	}
+}
+
+// Close implements the interface io.Closer.
+func (b *proxyBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.done)
+	return err
+}
+
+// Service implements the interface gsel.Node.
+func (n *proxyNode) Service() gsvc.Service {
+	return n.service
+}
+
+// Address implements the interface gsel.Node.
+func (n *proxyNode) Address() string {
+	return n.target.Host
+}
+
+// rewrite points `req` to the upstream, which joins the path and query of upstream and the request.
+func (n *proxyNode) rewrite(req *http.Request) {
+	target := n.target
+	req.URL.Scheme = target.Scheme
+	req.URL.Host = target.Host
+	req.URL.Path = strings.TrimSuffix(target.Path, "/") + req.URL.Path
+	req.URL.RawPath = ""
+	if target.RawQuery != "" {
+		if req.URL.RawQuery == "" {
+			req.URL.RawQuery = target.RawQuery
+		} else {
+			req.URL.RawQuery = target.RawQuery + "&" + req.URL.RawQuery
+		}
+	}
+	req.Host = target.Host
+}
+
+// isProxyConnectionError checks whether `err` is failure of connecting upstream,
+// in which case the request is not sent and can be retried safely.
+func isProxyConnectionError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// isProxyBodyReplayable checks whether the body of `req` can be sent again.
+func isProxyBodyReplayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
@@ -38,6 +38,7 @@ func (s *Server) doServiceRegister() {
 		gsvc.MDProtocol: protocol,
 		gsvc.MDInsecure: insecure,
 	}
+	metadata.Sets(s.config.ServiceMetadata)
 	s.service = &gsvc.LocalService{
 		Name:      s.GetName(),
 		Endpoints: s.calculateListenedEndpoints(ctx),
@@ -26,6 +26,8 @@ type (
 		domain     *Domain       // Domain.
 		prefix     string        // Prefix for sub-route.
 		middleware []HandlerFunc // Middleware array.
+		priorities []int         // Priorities of the middleware array, in descending order.
+		bodyLimit  int64         // Max request body size of the routes, which is inherited by subgroups.
 	}
 
 	// preBindItem is item for lazy registering feature of router group. preBindItem is not really registered
@@ -120,14 +122,17 @@ func (g *RouterGroup) Group(prefix string, groups ...func(group *RouterGroup)) *
 		prefix = ""
 	}
 	group := &RouterGroup{
-		parent: g,
-		server: g.server,
-		domain: g.domain,
-		prefix: prefix,
+		parent:    g,
+		server:    g.server,
+		domain:    g.domain,
+		prefix:    prefix,
+		bodyLimit: g.bodyLimit,
 	}
 	if len(g.middleware) > 0 {
 		group.middleware = make([]HandlerFunc, len(g.middleware))
+		group.priorities = make([]int, len(g.priorities))
 		copy(group.middleware, g.middleware)
+		copy(group.priorities, g.priorities)
 	}
 	if len(groups) > 0 {
 		for _, v := range groups {
@@ -145,8 +150,11 @@ func (g *RouterGroup) Clone() *RouterGroup {
 		domain:     g.domain,
 		prefix:     g.prefix,
 		middleware: make([]HandlerFunc, len(g.middleware)),
+		priorities: make([]int, len(g.priorities)),
+		bodyLimit:  g.bodyLimit,
 	}
 	copy(newGroup.middleware, g.middleware)
+	copy(newGroup.priorities, g.priorities)
 	return newGroup
 }
 
@@ -261,7 +269,45 @@ func (g *RouterGroup) Hook(pattern string, hook HookName, handler HandlerFunc) *
 
 // Middleware binds one or more middleware to the router group.
 func (g *RouterGroup) Middleware(handlers ...HandlerFunc) *RouterGroup {
-	g.middleware = append(g.middleware, handlers...)
+	return g.MiddlewareWithPriority(MiddlewarePriorityDefault, handlers...)
+}
+
+// MiddlewareWithPriority binds one or more middleware to the router group with `priority`.
+// The middleware of higher priority executes before the lower ones, including the ones bound
+// by its parent groups, and the ones of the same priority execute in their binding order.
+func (g *RouterGroup) MiddlewareWithPriority(priority int, handlers ...HandlerFunc) *RouterGroup {
+	// It inserts the handlers after the middleware of higher or equal priority.
+	index := len(g.priorities)
+	for i, v := range g.priorities {
+		if v < priority {
+			index = i
+			break
+		}
+	}
+	var (
+		middleware = make([]HandlerFunc, 0, len(g.middleware)+len(handlers))
+		priorities = make([]int, 0, len(g.priorities)+len(handlers))
+	)
+	middleware = append(middleware, g.middleware[:index]...)
+	middleware = append(middleware, handlers...)
+	middleware = append(middleware, g.middleware[index:]...)
+	priorities = append(priorities, g.priorities[:index]...)
+	for range handlers {
+		priorities = append(priorities, priority)
+	}
+	priorities = append(priorities, g.priorities[index:]...)
+	g.middleware, g.priorities = middleware, priorities
+	return g
+}
+
+// ClientMaxBodySize sets the max request body size in bytes for the routes of the group,
+// which overrides server ClientMaxBodySize, eg: a large limit for uploading routes only.
+// The request whose body exceeds the limit is rejected with http.StatusRequestEntityTooLarge
+// as the body streams in, and a negative `maxSize` means no limit.
+//
+// The subgroups created after it inherit the limit.
+func (g *RouterGroup) ClientMaxBodySize(maxSize int64) *RouterGroup {
+	g.bodyLimit = maxSize
 	return g
 }
 
@@ -341,6 +387,7 @@ func (g *RouterGroup) doBindRoutersToServer(ctx context.Context, item *preBindIt
 				Pattern:    pattern,
 				FuncInfo:   funcInfo,
 				Middleware: g.middleware,
+				BodyLimit:  g.bodyLimit,
 				Source:     source,
 			}
 			if g.domain != nil {
@@ -357,6 +404,7 @@ func (g *RouterGroup) doBindRoutersToServer(ctx context.Context, item *preBindIt
 						Object:     object,
 						Method:     extras[0],
 						Middleware: g.middleware,
+						BodyLimit:  g.bodyLimit,
 						Source:     source,
 					}
 					if g.domain != nil {
@@ -371,6 +419,7 @@ func (g *RouterGroup) doBindRoutersToServer(ctx context.Context, item *preBindIt
 						Object:     object,
 						Method:     extras[0],
 						Middleware: g.middleware,
+						BodyLimit:  g.bodyLimit,
 						Source:     source,
 					}
 					if g.domain != nil {
@@ -386,6 +435,7 @@ func (g *RouterGroup) doBindRoutersToServer(ctx context.Context, item *preBindIt
 					Object:     object,
 					Method:     "",
 					Middleware: g.middleware,
+					BodyLimit:  g.bodyLimit,
 					Source:     source,
 				}
 				// Finally, it treats the `object` as the Object registering type.
@@ -404,6 +454,7 @@ func (g *RouterGroup) doBindRoutersToServer(ctx context.Context, item *preBindIt
 			Object:     object,
 			Method:     "",
 			Middleware: g.middleware,
+			BodyLimit:  g.bodyLimit,
 			Source:     source,
 		}
 		if g.domain != nil {
@@ -18,11 +18,26 @@ const (
 	defaultMiddlewarePattern = "/*"
 )
 
+const (
+	// MiddlewarePriorityDefault is the priority of middleware registered without priority,
+	// including the internal tracing middleware. The middleware of greater priority executes
+	// before them, and the middleware of less priority executes after them.
+	MiddlewarePriorityDefault = 0
+)
+
 // BindMiddleware registers one or more global middleware to the server.
 // Global middleware can be used standalone without service handler, which intercepts all dynamic requests
 // before or after service handler. The parameter `pattern` specifies what route pattern the middleware intercepts,
 // which is usually a "fuzzy" pattern like "/:name", "/*any" or "/{field}".
 func (s *Server) BindMiddleware(pattern string, handlers ...HandlerFunc) {
+	s.BindMiddlewareWithPriority(pattern, MiddlewarePriorityDefault, handlers...)
+}
+
+// BindMiddlewareWithPriority registers one or more global middleware to the server with `priority`.
+// The global middleware of higher priority executes before the lower ones, no matter of their registering order,
+// and the ones of the same priority execute in their registering order.
+// See BindMiddleware.
+func (s *Server) BindMiddlewareWithPriority(pattern string, priority int, handlers ...HandlerFunc) {
 	var (
 		ctx = context.TODO()
 	)
@@ -31,8 +46,9 @@ func (s *Server) BindMiddleware(pattern string, handlers ...HandlerFunc) {
 			Prefix:  "",
 			Pattern: pattern,
 			HandlerItem: &HandlerItem{
-				Type: HandlerTypeMiddleware,
-				Name: gdebug.FuncPath(handler),
+				Type:     HandlerTypeMiddleware,
+				Name:     gdebug.FuncPath(handler),
+				Priority: priority,
 				Info: handlerFuncInfo{
 					Func: handler,
 					Type: reflect.TypeOf(handler),
@@ -46,23 +62,7 @@ func (s *Server) BindMiddleware(pattern string, handlers ...HandlerFunc) {
 // Global middleware can be used standalone without service handler, which intercepts all dynamic requests
 // before or after service handler.
 func (s *Server) BindMiddlewareDefault(handlers ...HandlerFunc) {
-	var (
-		ctx = context.TODO()
-	)
-	for _, handler := range handlers {
-		s.setHandler(ctx, setHandlerInput{
-			Prefix:  "",
-			Pattern: defaultMiddlewarePattern,
-			HandlerItem: &HandlerItem{
-				Type: HandlerTypeMiddleware,
-				Name: gdebug.FuncPath(handler),
-				Info: handlerFuncInfo{
-					Func: handler,
-					Type: reflect.TypeOf(handler),
-				},
-			},
-		})
-	}
+	s.BindMiddlewareWithPriority(defaultMiddlewarePattern, MiddlewarePriorityDefault, handlers...)
 }
 
 // Use is the alias of BindMiddlewareDefault.
@@ -70,3 +70,9 @@ func (s *Server) BindMiddlewareDefault(handlers ...HandlerFunc) {
 func (s *Server) Use(handlers ...HandlerFunc) {
 	s.BindMiddlewareDefault(handlers...)
 }
+
+// UseWithPriority registers one or more global middleware to the server using default pattern "/*"
+// with `priority`. See BindMiddlewareWithPriority.
+func (s *Server) UseWithPriority(priority int, handlers ...HandlerFunc) {
+	s.BindMiddlewareWithPriority(defaultMiddlewarePattern, priority, handlers...)
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/gogf/gf/v2/container/glist"
@@ -241,12 +242,23 @@ func (s *Server) searchHandlers(method, path, domain string) (parsedItems []*Han
 		}
 	}
 	if parsedItemList.Len() > 0 {
-		var index = 0
+		var (
+			index           = 0
+			middlewareCount = 0
+		)
 		parsedItems = make([]*HandlerItemParsed, parsedItemList.Len())
 		for e := parsedItemList.Front(); e != nil; e = e.Next() {
 			parsedItems[index] = e.Value.(*HandlerItemParsed)
+			if parsedItems[index].Handler.Type == HandlerTypeMiddleware {
+				middlewareCount++
+			}
 			index++
 		}
+		// The middleware are in the front of the array,
+		// which are sorted by their priorities, keeping the registering order of the same priority.
+		sort.SliceStable(parsedItems[:middlewareCount], func(i, j int) bool {
+			return parsedItems[i].Handler.Priority > parsedItems[j].Handler.Priority
+		})
 	}
 	return
 }
@@ -43,6 +43,7 @@ type doBindHandlerInput struct {
 	Pattern    string
 	FuncInfo   handlerFuncInfo
 	Middleware []HandlerFunc
+	BodyLimit  int64
 	Source     string
 }
 
@@ -58,6 +59,7 @@ func (s *Server) doBindHandler(ctx context.Context, in doBindHandlerInput) {
 			Type:       HandlerTypeHandler,
 			Info:       in.FuncInfo,
 			Middleware: in.Middleware,
+			BodyLimit:  in.BodyLimit,
 			Source:     in.Source,
 		},
 	})
@@ -69,6 +69,7 @@ type doBindObjectInput struct {
 	Object     interface{}
 	Method     string
 	Middleware []HandlerFunc
+	BodyLimit  int64
 	Source     string
 }
 
@@ -142,6 +143,7 @@ func (s *Server) doBindObject(ctx context.Context, in doBindObjectInput) {
 			InitFunc:   initFunc,
 			ShutFunc:   shutFunc,
 			Middleware: in.Middleware,
+			BodyLimit:  in.BodyLimit,
 			Source:     in.Source,
 		}
 		// If there's "Index" method, then an additional route is automatically added
@@ -169,6 +171,7 @@ func (s *Server) doBindObject(ctx context.Context, in doBindObjectInput) {
 				InitFunc:   initFunc,
 				ShutFunc:   shutFunc,
 				Middleware: in.Middleware,
+				BodyLimit:  in.BodyLimit,
 				Source:     in.Source,
 			}
 		}
@@ -182,6 +185,7 @@ type doBindObjectMethodInput struct {
 	Object     interface{}
 	Method     string
 	Middleware []HandlerFunc
+	BodyLimit  int64
 	Source     string
 }
 
@@ -238,6 +242,7 @@ func (s *Server) doBindObjectMethod(ctx context.Context, in doBindObjectMethodIn
 		InitFunc:   initFunc,
 		ShutFunc:   shutFunc,
 		Middleware: in.Middleware,
+		BodyLimit:  in.BodyLimit,
 		Source:     in.Source,
 	}
 
@@ -297,6 +302,7 @@ func (s *Server) doBindObjectRest(ctx context.Context, in doBindObjectInput) {
 			InitFunc:   initFunc,
 			ShutFunc:   shutFunc,
 			Middleware: in.Middleware,
+			BodyLimit:  in.BodyLimit,
 			Source:     in.Source,
 		}
 	}
@@ -0,0 +1,72 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gproc"
+)
+
+const (
+	// shutdownDrainCheckInterval is the interval checking the in-flight requests in draining.
+	shutdownDrainCheckInterval = 10 * time.Millisecond
+)
+
+// OnShutdown registers hook `f`, which is called when the server starts shutting down,
+// before draining the in-flight requests. The `ctx` passed to `f` is done when the drain timeout exceeds.
+//
+// It is commonly used for marking the instance unhealthy, or stopping the background jobs.
+func (s *Server) OnShutdown(f func(ctx context.Context)) {
+	s.shutdownHooks = append(s.shutdownHooks, f)
+}
+
+// GetInflightCount returns the number of the requests in serving.
+func (s *Server) GetInflightCount() int {
+	return s.inflight.Val()
+}
+
+// drain calls the shutdown hooks, and waits for the in-flight requests done or the drain timeout exceeds.
+// The new requests are rejected with 503 once it starts, and the keep-alive connections are closed
+// after their current requests. It only takes effect once for the server.
+func (s *Server) drain(ctx context.Context) {
+	if !s.draining.Cas(false, true) {
+		return
+	}
+	var cancel context.CancelFunc
+	if s.config.ShutdownDrainTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, s.config.ShutdownDrainTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	for _, hook := range s.shutdownHooks {
+		hook(ctx)
+	}
+	for _, server := range s.servers {
+		server.httpServer.SetKeepAlivesEnabled(false)
+	}
+	if s.config.ShutdownDrainTimeout <= 0 {
+		return
+	}
+	ticker := time.NewTicker(shutdownDrainCheckInterval)
+	defer ticker.Stop()
+	for s.inflight.Val() > 0 {
+		select {
+		case <-ctx.Done():
+			s.Logger().Warningf(
+				ctx,
+				`%d: server "%s" drain timeout exceeded with %d in-flight requests`,
+				gproc.Pid(), s.GetName(), s.inflight.Val(),
+			)
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+// ETag generating modes of static files.
+const (
+	StaticETagModTime = "modtime" // ETag of modification time and size, which is cheap and the default mode.
+	StaticETagHash    = "hash"    // ETag of content hash, which is stable across deployments but reads file once.
+	StaticETagOff     = "off"     // No ETag, only Last-Modified is used for conditional requests.
+)
+
+const (
+	staticETagCacheKeyPrefix = "StaticETag:"
+	staticETagCacheDuration  = time.Hour
+)
+
+// setStaticCacheHeaders sets the ETag and Cache-Control headers for static file serving,
+// which are used by http.ServeContent handling the conditional requests.
+func (s *Server) setStaticCacheHeaders(r *Request, name string, info os.FileInfo, content io.ReadSeeker) {
+	header := r.Response.Header()
+	if header.Get("Cache-Control") == "" {
+		if value := s.matchStaticCacheControl(r.URL.Path, info.Name()); value != "" {
+			header.Set("Cache-Control", value)
+		}
+	}
+	if header.Get("ETag") != "" {
+		return
+	}
+	switch s.config.StaticETag {
+	case "", StaticETagModTime:
+		header.Set("ETag", fmt.Sprintf(`"%x-%x"`, info.ModTime().Unix(), info.Size()))
+
+	case StaticETagHash:
+		etag, err := s.getStaticETagHash(r.Context(), name, info, content)
+		if err != nil {
+			intlog.Errorf(r.Context(), `%+v`, err)
+			return
+		}
+		header.Set("ETag", etag)
+	}
+}
+
+// getStaticETagHash returns the content hash ETag, which is cached by file name, modification time and size.
+func (s *Server) getStaticETagHash(ctx context.Context, name string, info os.FileInfo, content io.ReadSeeker) (string, error) {
+	cacheKey := fmt.Sprintf(`%s%s:%d:%d`, staticETagCacheKeyPrefix, name, info.ModTime().UnixNano(), info.Size())
+	value, err := s.serveCache.GetOrSetFunc(ctx, cacheKey, func(ctx context.Context) (interface{}, error) {
+		hash := sha256.New()
+		if _, err := io.Copy(hash, content); err != nil {
+			return nil, gerror.Wrapf(err, `hash static file "%s" failed`, name)
+		}
+		return `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`, nil
+	}, staticETagCacheDuration)
+	if err != nil {
+		return "", err
+	}
+	if _, err = content.Seek(0, io.SeekStart); err != nil {
+		return "", gerror.Wrapf(err, `seek static file "%s" failed`, name)
+	}
+	return value.String(), nil
+}
+
+// matchStaticCacheControl returns the Cache-Control value of the longest pattern matching `uri` or `fileName`,
+// the patterns of the same length are compared in lexical order for deterministic result.
+func (s *Server) matchStaticCacheControl(uri, fileName string) string {
+	var (
+		matchedPattern string
+		matchedValue   string
+	)
+	for pattern, value := range s.config.StaticCacheControl {
+		if matchedPattern != "" && (len(pattern) < len(matchedPattern) ||
+			(len(pattern) == len(matchedPattern) && pattern > matchedPattern)) {
+			continue
+		}
+		var matched bool
+		switch {
+		case strings.HasSuffix(pattern, "/"):
+			matched = strings.HasPrefix(uri, pattern)
+		case strings.HasPrefix(pattern, "/"):
+			matched, _ = path.Match(pattern, uri)
+		default:
+			matched, _ = path.Match(pattern, fileName)
+		}
+		if matched {
+			matchedPattern, matchedValue = pattern, value
+		}
+	}
+	return matchedValue
+}
@@ -6,7 +6,11 @@
 
 package ghttp
 
-import "github.com/gorilla/websocket"
+import (
+	"net/http"
+
+	"github.com/gorilla/websocket"
+)
 
 // WebSocket wraps the underlying websocket connection
 // and provides convenient functions.
@@ -16,6 +20,14 @@ type WebSocket struct {
 	*websocket.Conn
 }
 
+// WebSocketOption is the option for upgrading request as websocket.
+type WebSocketOption struct {
+	Subprotocols     []string                   // Subprotocols supported by server, in order of preference.
+	Compression      bool                       // Negotiates permessage-deflate compression with client.
+	CompressionLevel int                        // Flate compression level, the default level is used if 0.
+	CheckOrigin      func(r *http.Request) bool // Checks the request origin, all origins are allowed if nil.
+}
+
 const (
 	// WsMsgText TextMessage denotes a text data message.
 	// The text message payload is interpreted as UTF-8 encoded text data.
@@ -0,0 +1,101 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// testAutoCertManager is a fake AutoCertManager serving a fixed certificate.
+type testAutoCertManager struct {
+	config      ghttp.AutoCertConfig
+	certificate tls.Certificate
+}
+
+func (m *testAutoCertManager) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return &m.certificate, nil
+		},
+		NextProtos: []string{"acme-tls/1"},
+	}
+}
+
+func (m *testAutoCertManager) HTTPHandler(fallback http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/.well-known/acme-challenge/") {
+			_, _ = w.Write([]byte("challenge"))
+			return
+		}
+		fallback.ServeHTTP(w, r)
+	})
+}
+
+func Test_AutoCert(t *testing.T) {
+	certificate, err := tls.LoadX509KeyPair(
+		gtest.DataPath("https", "files", "server.crt"),
+		gtest.DataPath("https", "files", "server.key"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manager *testAutoCertManager
+	ghttp.RegisterAutoCertManagerCreator(func(ctx context.Context, config ghttp.AutoCertConfig) (ghttp.AutoCertManager, error) {
+		manager = &testAutoCertManager{config: config, certificate: certificate}
+		return manager, nil
+	})
+	defer ghttp.RegisterAutoCertManagerCreator(nil)
+
+	s := g.Server(guid.S())
+	s.BindHandler("/test", func(r *ghttp.Request) {
+		r.Response.Write("test")
+	})
+	err = s.SetConfigWithMap(g.Map{
+		"address":         ":0",
+		"httpsAddr":       ":0",
+		"autoCertDomains": g.Slice{"example.com"},
+		"autoCertEmail":   "admin@example.com",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNE(manager, nil)
+		t.Assert(manager.config.Domains, g.Slice{"example.com"})
+		t.Assert(manager.config.Email, "admin@example.com")
+		t.AssertNE(manager.config.CacheDir, "")
+
+		ports := s.GetListenedPorts()
+		t.Assert(len(ports), 2)
+		// HTTPS with the certificate from manager.
+		c := g.Client()
+		c.SetPrefix(fmt.Sprintf("https://127.0.0.1:%d", ports[0]))
+		t.Assert(c.GetContent(ctx, "/test"), "test")
+
+		// HTTP answering challenges.
+		c = g.Client()
+		c.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", ports[1]))
+		t.Assert(c.GetContent(ctx, "/.well-known/acme-challenge/token"), "challenge")
+		t.Assert(c.GetContent(ctx, "/test"), "test")
+	})
+}
@@ -0,0 +1,185 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gfile"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCert creates a certificate signed by `parent`, or a self-signed CA if `parent` is nil.
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{name},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	var (
+		signerCert = template
+		signerKey  = key
+	)
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCert{cert: cert, key: key}
+}
+
+func (c *testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.cert.Raw}, PrivateKey: c.key, Leaf: c.cert}
+}
+
+func newTestClientCertClient(port int, cert *testCert) *gclient.Client {
+	client := g.Client()
+	client.SetPrefix(fmt.Sprintf("https://127.0.0.1:%d", port))
+	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+	if cert != nil {
+		// It always sends the certificate, even if it is not signed by the CAs the server requests.
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			certificate := cert.tlsCertificate()
+			return &certificate, nil
+		}
+	}
+	_ = client.SetTLSConfig(tlsConfig)
+	return client
+}
+
+func Test_ClientCert(t *testing.T) {
+	var (
+		ca         = newTestCert(t, "ca", nil)
+		otherCA    = newTestCert(t, "other-ca", nil)
+		serverCert = newTestCert(t, "server", ca)
+		clientA    = newTestCert(t, "svc-a", ca)
+		clientB    = newTestCert(t, "svc-b", ca)
+		rogue      = newTestCert(t, "svc-a", otherCA)
+		caPath     = gfile.Temp(guid.S(), "ca.pem")
+	)
+	defer gfile.Remove(gfile.Dir(caPath))
+	if err := gfile.PutBytes(caPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})); err != nil {
+		t.Fatal(err)
+	}
+	caPool, err := ghttp.LoadCertPool(caPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := func(r *ghttp.Request) {
+		info := r.GetClientTLSInfo()
+		if info == nil {
+			r.Response.Write("anonymous")
+			return
+		}
+		r.Response.Writef("%s|%v|%s|%d", info.CommonName, info.Verified, info.DNSNames[0], len(info.Fingerprint))
+	}
+
+	// The certificates are verified in handshake by server, and authenticated by route group.
+	gtest.C(t, func(t *gtest.T) {
+		s := g.Server(guid.S())
+		s.SetTLSConfig(&tls.Config{Certificates: []tls.Certificate{serverCert.tlsCertificate()}})
+		s.SetHTTPSClientAuth(ghttp.ClientAuthVerifyIfGiven, caPath)
+		s.Group("/", func(group *ghttp.RouterGroup) {
+			group.GET("/public", handler)
+			group.Group("/internal", func(group *ghttp.RouterGroup) {
+				group.Middleware(ghttp.MiddlewareClientCert(ghttp.ClientCertOption{AllowedNames: []string{"svc-a"}}))
+				group.GET("/", handler)
+			})
+		})
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+
+		port := s.GetListenedPort()
+		t.Assert(newTestClientCertClient(port, nil).GetContent(ctx, "/public"), "anonymous")
+		t.Assert(newTestClientCertClient(port, clientA).GetContent(ctx, "/public"), "svc-a|true|svc-a|64")
+
+		resp, err := newTestClientCertClient(port, nil).Get(ctx, "/internal")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 401)
+		resp.Close()
+
+		t.Assert(newTestClientCertClient(port, clientA).GetContent(ctx, "/internal"), "svc-a|true|svc-a|64")
+
+		resp, err = newTestClientCertClient(port, clientB).Get(ctx, "/internal")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 403)
+		resp.Close()
+
+		// The certificate of other CA fails in handshake.
+		_, err = newTestClientCertClient(port, rogue).Get(ctx, "/public")
+		t.AssertNE(err, nil)
+	})
+
+	// The certificates are verified by route group with its own CA.
+	gtest.C(t, func(t *gtest.T) {
+		s := g.Server(guid.S())
+		s.SetTLSConfig(&tls.Config{Certificates: []tls.Certificate{serverCert.tlsCertificate()}})
+		s.SetHTTPSClientAuth(ghttp.ClientAuthRequest)
+		s.Group("/internal", func(group *ghttp.RouterGroup) {
+			group.Middleware(ghttp.MiddlewareClientCert(ghttp.ClientCertOption{CAs: caPool}))
+			group.GET("/", handler)
+		})
+		s.SetDumpRouterMap(false)
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+
+		port := s.GetListenedPort()
+		t.Assert(newTestClientCertClient(port, clientB).GetContent(ctx, "/internal"), "svc-b|true|svc-b|64")
+
+		resp, err := newTestClientCertClient(port, rogue).Get(ctx, "/internal")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 403)
+		resp.Close()
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		_, err := ghttp.LoadCertPool(gfile.Temp(guid.S()))
+		t.AssertNE(err, nil)
+	})
+}
@@ -8,6 +8,8 @@ package ghttp_test
 
 import (
 	"fmt"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
 	"time"
@@ -163,3 +165,83 @@ func Test_ClientMaxBodySize_File(t *testing.T) {
 		)
 	})
 }
+
+func Test_ClientMaxBodySize_Route(t *testing.T) {
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		handler := func(r *ghttp.Request) {
+			r.Response.Write(len(r.GetBody()))
+		}
+		group.POST("/default", handler)
+		group.Group("/small", func(group *ghttp.RouterGroup) {
+			group.ClientMaxBodySize(16)
+			group.POST("/", handler)
+			group.Group("/nolimit", func(group *ghttp.RouterGroup) {
+				group.ClientMaxBodySize(-1)
+				group.POST("/", handler)
+			})
+		})
+		group.Group("/upload", func(group *ghttp.RouterGroup) {
+			group.ClientMaxBodySize(4096)
+			group.POST("/", handler)
+		})
+	})
+	m := g.Map{
+		"ErrorLogEnabled":   false,
+		"ClientMaxBodySize": "1k",
+	}
+	gtest.Assert(s.SetConfigWithMap(m), nil)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+
+	var (
+		prefix = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+		data   = strings.Repeat("a", 2048)
+	)
+	// The body of unknown size is sent in chunks.
+	post := func(t *gtest.T, uri string, body string, chunked bool) (int, string) {
+		var reader io.Reader = strings.NewReader(body)
+		if chunked {
+			reader = io.MultiReader(reader)
+		}
+		resp, err := http.Post(prefix+uri, "text/plain", reader)
+		t.AssertNil(err)
+		defer resp.Body.Close()
+		content, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(content)
+	}
+
+	gtest.C(t, func(t *gtest.T) {
+		status, content := post(t, "/small", "hello", false)
+		t.Assert(status, http.StatusOK)
+		t.Assert(content, "5")
+
+		status, _ = post(t, "/small", data[:17], false)
+		t.Assert(status, http.StatusRequestEntityTooLarge)
+
+		status, _ = post(t, "/small", data[:17], true)
+		t.Assert(status, http.StatusRequestEntityTooLarge)
+
+		status, content = post(t, "/small/nolimit", data, true)
+		t.Assert(status, http.StatusOK)
+		t.Assert(content, "2048")
+	})
+
+	// The route limit is greater than the server one.
+	gtest.C(t, func(t *gtest.T) {
+		status, content := post(t, "/upload", data, true)
+		t.Assert(status, http.StatusOK)
+		t.Assert(content, "2048")
+
+		status, _ = post(t, "/upload", data+data+data, true)
+		t.Assert(status, http.StatusRequestEntityTooLarge)
+
+		// The server limit is still applied to other routes.
+		status, content = post(t, "/default", data, false)
+		t.Assert(status, http.StatusInternalServerError)
+		t.Assert(gstr.Contains(content, "http: request body too large"), true)
+	})
+}
@@ -0,0 +1,128 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Gateway_Config(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Upstream-Id", "u1")
+		w.Header().Set("X-Internal", "secret")
+		if r.URL.Path == "/v1/user/fail" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = fmt.Fprintf(w, "%s|%s|%s|%s", r.URL.Path, r.URL.RawQuery, r.Header.Get("Authorization"), r.Header.Get("Cookie"))
+	}))
+	defer upstream.Close()
+	t.Setenv("GATEWAY_TEST_TOKEN", "token")
+
+	s := g.Server(guid.S())
+	err := s.SetConfigWithMap(g.Map{
+		"gateway": g.Map{
+			"routes": g.Slice{
+				g.Map{
+					"prefix":   "/api/user",
+					"rewrite":  "/v1/user",
+					"upstream": upstream.URL,
+					"requestHeaders": g.Map{
+						"set":    g.Map{"Authorization": "Bearer ${GATEWAY_TEST_TOKEN}"},
+						"remove": g.Slice{"Cookie"},
+					},
+					"responseHeaders": g.Map{
+						"rename": g.Map{"X-Upstream-Id": "X-Request-Id"},
+						"remove": g.Slice{"X-Internal"},
+					},
+					"statusMap": g.Map{"502": 503},
+				},
+				g.Map{
+					"prefix": "/local",
+					"responseHeaders": g.Map{
+						"add": g.Map{"X-Gateway": "local"},
+					},
+					"statusMap": g.Map{"404": 410},
+				},
+				g.Map{
+					"prefix":   "/down",
+					"upstream": "http://127.0.0.1:1",
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.BindHandler("/local/hello", func(r *ghttp.Request) {
+		r.Response.Write("hello")
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		// Forwarding with path rewriting and header transformation.
+		resp, err := client.Cookie(g.MapStrStr{"session": "1"}).Get(ctx, "/api/user/info?id=1")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.ReadAllString(), "/v1/user/info|id=1|Bearer token|")
+		t.Assert(resp.Header.Get("X-Request-Id"), "u1")
+		t.Assert(resp.Header.Get("X-Upstream-Id"), "")
+		t.Assert(resp.Header.Get("X-Internal"), "")
+		resp.Close()
+
+		// Status mapping of upstream.
+		resp, err = client.Get(ctx, "/api/user/fail")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		resp.Close()
+
+		// Local handling.
+		resp, err = client.Get(ctx, "/local/hello")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "hello")
+		t.Assert(resp.Header.Get("X-Gateway"), "local")
+		resp.Close()
+
+		resp, err = client.Get(ctx, "/local/none")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusGone)
+		resp.Close()
+
+		// Upstream unavailable.
+		resp, err = client.Get(ctx, "/down/test")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusBadGateway)
+		resp.Close()
+
+		// Not matched.
+		t.Assert(client.GetContent(ctx, "/api/users"), "Not Found")
+	})
+}
+
+func Test_Gateway_InvalidConfig(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		_, err := ghttp.NewGateway(ghttp.GatewayConfig{Routes: []ghttp.GatewayRoute{{Prefix: ""}}})
+		t.AssertNE(err, nil)
+		_, err = ghttp.NewGateway(ghttp.GatewayConfig{Routes: []ghttp.GatewayRoute{{Prefix: "/a", Upstream: "127.0.0.1"}}})
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,149 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_GraphQL(t *testing.T) {
+	var (
+		s      = g.Server(guid.S())
+		schema = ghttp.GraphQLSchemaFunc(func(ctx context.Context, req *ghttp.GraphQLRequest) *ghttp.GraphQLResponse {
+			name, err := ghttp.TraceGraphQLResolver(ctx, "hello", func(ctx context.Context) (interface{}, error) {
+				// The request context is propagated to the resolvers.
+				return ghttp.RequestFromCtx(ctx).Header.Get("X-Name"), nil
+			})
+			if err != nil {
+				return &ghttp.GraphQLResponse{Errors: []*ghttp.GraphQLError{{Message: err.Error()}}}
+			}
+			return &ghttp.GraphQLResponse{Data: g.Map{
+				"query":     req.Query,
+				"variables": req.Variables,
+				"name":      name,
+			}}
+		})
+	)
+	s.Use(func(r *ghttp.Request) {
+		r.Response.Header().Set("X-Middleware", "1")
+		r.Middleware.Next()
+	})
+	s.BindGraphQL("/graphql", schema, ghttp.GraphQLOption{
+		PlaygroundPattern: "/graphql/playground",
+		PersistedQueries:  map[string]string{"registered": "{ registered }", "add": "mutation { add }"},
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+	// Query using POST.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().SetPrefix(prefix).ContentJson().SetHeader("X-Name", "john")
+		content := client.PostContent(ctx, "/graphql", g.Map{
+			"query":     "{ hello }",
+			"variables": g.Map{"id": 1},
+		})
+		t.Assert(content, `{"data":{"name":"john","query":"{ hello }","variables":{"id":1}}}`)
+	})
+	// Query using GET and application/graphql body.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().SetPrefix(prefix)
+		t.Assert(
+			client.GetContent(ctx, "/graphql?query="+url.QueryEscape("{ hello }")+"&variables="+url.QueryEscape(`{"id":2}`)),
+			`{"data":{"name":"","query":"{ hello }","variables":{"id":2}}}`,
+		)
+		t.Assert(
+			client.ContentType("application/graphql").PostContent(ctx, "/graphql", "{ hello }"),
+			`{"data":{"name":"","query":"{ hello }","variables":null}}`,
+		)
+	})
+	// Mutation is not allowed using GET.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().SetPrefix(prefix)
+		res, err := client.Get(ctx, "/graphql?query="+url.QueryEscape("mutation { add }"))
+		t.AssertNil(err)
+		defer res.Close()
+		t.Assert(res.StatusCode, 405)
+		t.Assert(res.ReadAllString(), `{"errors":[{"message":"mutations are not allowed using GET method"}]}`)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().SetPrefix(prefix)
+		get := func(query, operationName string, extensions string) int {
+			res, err := client.Get(ctx, "/graphql?query="+url.QueryEscape(query)+
+				"&operationName="+url.QueryEscape(operationName)+"&extensions="+url.QueryEscape(extensions))
+			t.AssertNil(err)
+			defer res.Close()
+			return res.StatusCode
+		}
+		t.Assert(get("# comment\n  mutation Add { add }", "", ""), 405)
+		t.Assert(get("query Get { hello } mutation Add($id: Int) @tag(name: \"a\") { add(id: $id) }", "Add", ""), 405)
+		t.Assert(get("query Get { hello } mutation Add { add }", "Get", ""), 200)
+		t.Assert(get(`{ hello(text: "mutation { add }") } # mutation`, "", ""), 200)
+		t.Assert(get(`query @tag(name: """ mutation""") { hello }`, "", ""), 200)
+		t.Assert(get("", "", `{"persistedQuery":{"version":1,"sha256Hash":"add"}}`), 405)
+	})
+	// Invalid request.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().SetPrefix(prefix)
+		res, err := client.ContentJson().Post(ctx, "/graphql", "{invalid")
+		t.AssertNil(err)
+		defer res.Close()
+		t.Assert(res.StatusCode, 400)
+		t.Assert(res.Header.Get("X-Middleware"), "1")
+	})
+	// Persisted queries.
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client = g.Client().SetPrefix(prefix).ContentJson()
+			query  = "{ persisted }"
+			sum    = sha256.Sum256([]byte(query))
+			hash   = hex.EncodeToString(sum[:])
+		)
+		extensions := g.Map{"persistedQuery": g.Map{"version": 1, "sha256Hash": hash}}
+		t.Assert(
+			client.PostContent(ctx, "/graphql", g.Map{"extensions": extensions}),
+			`{"errors":[{"message":"PersistedQueryNotFound","extensions":{"code":"PERSISTED_QUERY_NOT_FOUND"}}]}`,
+		)
+		t.Assert(
+			client.PostContent(ctx, "/graphql", g.Map{"query": "{ other }", "extensions": extensions}),
+			`{"errors":[{"message":"provided sha does not match query"}]}`,
+		)
+		t.Assert(
+			client.PostContent(ctx, "/graphql", g.Map{"query": query, "extensions": extensions}),
+			`{"data":{"name":"","query":"{ persisted }","variables":null}}`,
+		)
+		t.Assert(
+			client.PostContent(ctx, "/graphql", g.Map{"extensions": extensions}),
+			`{"data":{"name":"","query":"{ persisted }","variables":null}}`,
+		)
+		t.Assert(
+			client.PostContent(ctx, "/graphql", g.Map{
+				"extensions": g.Map{"persistedQuery": g.Map{"version": 1, "sha256Hash": "registered"}},
+			}),
+			`{"data":{"name":"","query":"{ registered }","variables":null}}`,
+		)
+	})
+	// Playground.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().SetPrefix(prefix)
+		t.Assert(gstr.Contains(client.GetContent(ctx, "/graphql/playground"), `{url: "/graphql"}`), true)
+	})
+}
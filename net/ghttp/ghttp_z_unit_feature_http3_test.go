@@ -0,0 +1,90 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// testHTTP3Server is a fake HTTP3Server that only holds the UDP connection.
+type testHTTP3Server struct {
+	closed *gtype.Bool
+	done   chan struct{}
+}
+
+func (s *testHTTP3Server) Serve(conn net.PacketConn) error {
+	<-s.done
+	return http.ErrServerClosed
+}
+
+func (s *testHTTP3Server) Shutdown(ctx context.Context) error {
+	return s.Close()
+}
+
+func (s *testHTTP3Server) Close() error {
+	if !s.closed.Cas(false, true) {
+		return nil
+	}
+	close(s.done)
+	return nil
+}
+
+func Test_HTTP3_AltSvc(t *testing.T) {
+	var created *testHTTP3Server
+	ghttp.RegisterHTTP3ServerCreator(func(tlsConfig *tls.Config, handler http.Handler) ghttp.HTTP3Server {
+		created = &testHTTP3Server{
+			closed: gtype.NewBool(),
+			done:   make(chan struct{}),
+		}
+		return created
+	})
+	defer ghttp.RegisterHTTP3ServerCreator(nil)
+
+	s := g.Server(guid.S())
+	s.BindHandler("/test", func(r *ghttp.Request) {
+		r.Response.Write("test")
+	})
+	s.EnableHTTPS(
+		gtest.DataPath("https", "files", "server.crt"),
+		gtest.DataPath("https", "files", "server.key"),
+	)
+	s.SetHTTP3Enabled(true)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNE(created, nil)
+
+		c := g.Client()
+		c.SetPrefix(fmt.Sprintf("https://127.0.0.1:%d", s.GetListenedPort()))
+		resp, err := c.Get(ctx, "/test")
+		t.AssertNil(err)
+		t.Assert(resp.ReadAllString(), "test")
+		t.Assert(resp.Header.Get("Alt-Svc"), fmt.Sprintf(`h3=":%d"; ma=86400`, s.GetListenedPort()))
+		resp.Close()
+
+		// The UDP port is listened.
+		_, err = net.ListenPacket("udp", fmt.Sprintf(":%d", s.GetListenedPort()))
+		t.AssertNE(err, nil)
+
+		t.AssertNil(s.Shutdown())
+		t.Assert(created.closed.Val(), true)
+	})
+}
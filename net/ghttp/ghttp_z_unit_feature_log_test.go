@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gogf/gf/v2/encoding/gjson"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/gogf/gf/v2/os/gfile"
@@ -62,3 +63,77 @@ func Test_Log(t *testing.T) {
 		t.Assert(gstr.Contains(gfile.GetContents(logPath3), "custom error"), true)
 	})
 }
+
+func Test_Log_AccessLogFormat(t *testing.T) {
+	newServer := func(logDir string) *ghttp.Server {
+		s := g.Server(guid.S())
+		s.BindHandler("/user/{id}", func(r *ghttp.Request) {
+			r.Response.Write("hello")
+		})
+		s.SetLogPath(logDir)
+		s.SetLogStdout(false)
+		s.SetDumpRouterMap(false)
+		return s
+	}
+	accessLogLines := func(logDir string) []string {
+		content := gfile.GetContents(gfile.Join(logDir, "access-"+gtime.Now().Format("Ymd")+".log"))
+		return gstr.SplitAndTrim(content, "\n")
+	}
+
+	// JSON.
+	gtest.C(t, func(t *gtest.T) {
+		logDir := gfile.Temp(guid.S())
+		defer gfile.Remove(logDir)
+		s := newServer(logDir)
+		s.SetAccessLogFormat(ghttp.AccessLogFormatJson, "status", "method", "route", "path", "bytes", "latency", "traceId")
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(client.GetContent(ctx, "/user/1?name=john"), "hello")
+
+		lines := accessLogLines(logDir)
+		t.Assert(len(lines), 1)
+		t.Assert(gstr.HasPrefix(lines[0], `{"status":200,"method":"GET","route":"/user/{id}","path":"/user/1","bytes":5,"latency":`), true)
+		j, err := gjson.DecodeToJson(lines[0])
+		t.AssertNil(err)
+		t.Assert(j.Get("traceId").String() != "", true)
+		t.Assert(j.Get("latency").Float64() >= 0, true)
+	})
+
+	// Template.
+	gtest.C(t, func(t *gtest.T) {
+		logDir := gfile.Temp(guid.S())
+		defer gfile.Remove(logDir)
+		s := newServer(logDir)
+		s.SetAccessLogFormat("{method} {uri} {status} {unknown}")
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(client.GetContent(ctx, "/user/1?name=john"), "hello")
+		t.Assert(accessLogLines(logDir), g.SliceStr{"GET /user/1?name=john 200 {unknown}"})
+	})
+
+	// Formatter.
+	gtest.C(t, func(t *gtest.T) {
+		logDir := gfile.Temp(guid.S())
+		defer gfile.Remove(logDir)
+		s := newServer(logDir)
+		s.SetAccessLogFormatter(func(r *ghttp.Request, entry *ghttp.AccessLogEntry) string {
+			return fmt.Sprintf("%s|%s|%d", entry.Route, r.Get("name"), entry.Status)
+		})
+		s.Start()
+		defer s.Shutdown()
+		time.Sleep(100 * time.Millisecond)
+
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(client.GetContent(ctx, "/user/1?name=john"), "hello")
+		t.Assert(accessLogLines(logDir), g.SliceStr{"/user/{id}|john|200"})
+	})
+}
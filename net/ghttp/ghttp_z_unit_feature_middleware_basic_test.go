@@ -779,3 +779,51 @@ var _ trace.TracerProvider = &testTracerProvider{}
 func (*testTracerProvider) Tracer(_ string, _ ...trace.TracerOption) trace.Tracer {
 	return trace.NewNoopTracerProvider().Tracer("")
 }
+
+func Test_Middleware_Priority(t *testing.T) {
+	s := g.Server(guid.S())
+	newMiddleware := func(name string) ghttp.HandlerFunc {
+		return func(r *ghttp.Request) {
+			r.Response.Write(name)
+			r.Middleware.Next()
+		}
+	}
+	s.Use(newMiddleware("a"))
+	s.UseWithPriority(-10, newMiddleware("b"))
+	s.UseWithPriority(10, newMiddleware("c"))
+	s.UseWithPriority(10, func(r *ghttp.Request) {
+		// It runs before the tracing middleware, which has no span yet.
+		if trace.SpanFromContext(r.Context()).SpanContext().IsValid() {
+			r.Response.Write("traced")
+		} else {
+			r.Response.Write("d")
+		}
+		r.Middleware.Next()
+	})
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(newMiddleware("1"))
+		group.MiddlewareWithPriority(5, newMiddleware("2"))
+		group.Group("/sub", func(group *ghttp.RouterGroup) {
+			group.Middleware(newMiddleware("3"))
+			group.MiddlewareWithPriority(5, newMiddleware("4"))
+			group.MiddlewareWithPriority(10, newMiddleware("5"))
+			group.GET("/", func(r *ghttp.Request) {
+				r.Response.Write("|")
+			})
+		})
+		group.GET("/", func(r *ghttp.Request) {
+			r.Response.Write("|")
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		t.Assert(client.GetContent(ctx, "/"), "cdab21|")
+		t.Assert(client.GetContent(ctx, "/sub"), "cdab52413|")
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_CircuitBreaker(t *testing.T) {
+	var (
+		failing = gtype.NewBool(true)
+		changes = garray.NewStrArray(true)
+		s       = g.Server(guid.S())
+	)
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCircuitBreaker(ghttp.CircuitBreakerOption{
+			Latency:      100 * time.Millisecond,
+			MinRequests:  2,
+			OpenDuration: 200 * time.Millisecond,
+			Fallback: func(r *ghttp.Request) {
+				r.Response.WriteStatus(http.StatusServiceUnavailable, "fallback")
+			},
+			OnStateChange: func(ctx context.Context, route string, from, to ghttp.CircuitBreakerState) {
+				changes.Append(fmt.Sprintf("%s %s>%s", route, from, to))
+			},
+		}))
+		group.GET("/api", func(r *ghttp.Request) {
+			if failing.Val() {
+				r.Response.WriteStatus(http.StatusInternalServerError, "error")
+				return
+			}
+			r.Response.Write("ok")
+		})
+		group.GET("/slow", func(r *ghttp.Request) {
+			if failing.Val() {
+				time.Sleep(150 * time.Millisecond)
+			}
+			r.Response.Write("slow")
+		})
+		group.GET("/other", func(r *ghttp.Request) {
+			r.Response.Write("other")
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	client := g.Client()
+	client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(client.GetContent(ctx, "/api"), "error")
+		t.Assert(client.GetContent(ctx, "/api"), "error")
+		t.Assert(changes.Slice(), []string{"GET:/api closed>open"})
+
+		// It is rejected in open state, and other routes are not affected.
+		resp, err := client.Get(ctx, "/api")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		t.Assert(resp.ReadAllString(), "fallback")
+		resp.Close()
+		t.Assert(client.GetContent(ctx, "/other"), "other")
+
+		// The failed probe opens the breaker again.
+		time.Sleep(250 * time.Millisecond)
+		t.Assert(client.GetContent(ctx, "/api"), "error")
+		t.Assert(client.GetContent(ctx, "/api"), "fallback")
+
+		// The successful probe closes the breaker.
+		failing.Set(false)
+		time.Sleep(250 * time.Millisecond)
+		t.Assert(client.GetContent(ctx, "/api"), "ok")
+		t.Assert(client.GetContent(ctx, "/api"), "ok")
+		t.Assert(changes.Slice(), []string{
+			"GET:/api closed>open",
+			"GET:/api open>half-open",
+			"GET:/api half-open>open",
+			"GET:/api open>half-open",
+			"GET:/api half-open>closed",
+		})
+	})
+
+	// The slow requests are counted as failures.
+	gtest.C(t, func(t *gtest.T) {
+		failing.Set(true)
+		changes.Clear()
+		t.Assert(client.GetContent(ctx, "/slow"), "slow")
+		t.Assert(client.GetContent(ctx, "/slow"), "slow")
+		t.Assert(client.GetContent(ctx, "/slow"), "fallback")
+		t.Assert(changes.Slice(), []string{"GET:/slow closed>open"})
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gcompress"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_Compression(t *testing.T) {
+	var (
+		s       = g.Server(guid.S())
+		content = gstr.Repeat("goframe compression ", 100)
+	)
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCompression())
+		group.GET("/text", func(r *ghttp.Request) {
+			r.Response.Write(content)
+		})
+		group.GET("/json", func(r *ghttp.Request) {
+			r.Response.WriteJson(g.Map{"content": content})
+		})
+		group.GET("/small", func(r *ghttp.Request) {
+			r.Response.Write("small")
+		})
+		group.GET("/binary", func(r *ghttp.Request) {
+			r.Response.Header().Set("Content-Type", "image/png")
+			r.Response.Write(content)
+		})
+		group.GET("/encoded", func(r *ghttp.Request) {
+			r.Response.Header().Set("Content-Encoding", "identity")
+			r.Response.Write(content)
+		})
+		group.GET("/flushed", func(r *ghttp.Request) {
+			r.Response.Write(content)
+			r.Response.Flush()
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	get := func(t *gtest.T, uri string, acceptEncoding string) (encoding string, body []byte) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		if acceptEncoding != "" {
+			client.SetHeader("Accept-Encoding", acceptEncoding)
+		}
+		resp, err := client.Get(ctx, uri)
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.Header.Get("Vary"), "Accept-Encoding")
+		return resp.Header.Get("Content-Encoding"), resp.ReadAll()
+	}
+
+	gtest.C(t, func(t *gtest.T) {
+		// The br and zstd encodings are not available without their adapters.
+		encoding, body := get(t, "/text", "gzip, deflate, br, zstd")
+		t.Assert(encoding, "gzip")
+		data, err := gcompress.UnGzip(body)
+		t.AssertNil(err)
+		t.Assert(data, content)
+
+		encoding, body = get(t, "/text", "gzip;q=0.8, zstd")
+		t.Assert(encoding, "gzip")
+		data, err = gcompress.UnGzip(body)
+		t.AssertNil(err)
+		t.Assert(data, content)
+
+		encoding, body = get(t, "/json", "gzip, br;q=0")
+		t.Assert(encoding, "gzip")
+		data, err = gcompress.UnGzip(body)
+		t.AssertNil(err)
+		t.Assert(data, fmt.Sprintf(`{"content":"%s"}`, content))
+
+		encoding, _ = get(t, "/text", "*")
+		t.Assert(encoding, "gzip")
+	})
+
+	// Not compressed.
+	gtest.C(t, func(t *gtest.T) {
+		encoding, body := get(t, "/text", "identity")
+		t.Assert(encoding, "")
+		t.Assert(body, content)
+
+		encoding, body = get(t, "/small", "gzip")
+		t.Assert(encoding, "")
+		t.Assert(body, "small")
+
+		encoding, body = get(t, "/binary", "gzip")
+		t.Assert(encoding, "")
+		t.Assert(body, content)
+
+		encoding, body = get(t, "/encoded", "gzip")
+		t.Assert(encoding, "identity")
+		t.Assert(body, content)
+
+		encoding, body = get(t, "/flushed", "gzip")
+		t.Assert(encoding, "")
+		t.Assert(body, content)
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gregex"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_CSRF(t *testing.T) {
+	bind := func(group *ghttp.RouterGroup) {
+		group.GET("/form", func(r *ghttp.Request) {
+			_ = r.Response.WriteTplContent(`<input type="hidden" name="{{.CSRFField}}" value="{{.CSRFToken}}">`)
+		})
+		group.POST("/submit", func(r *ghttp.Request) {
+			r.Response.Write("ok:" + r.GetCSRFToken())
+		})
+		group.POST("/api/submit", func(r *ghttp.Request) {
+			r.Response.Write("api")
+		})
+	}
+	s := g.Server(guid.S())
+	s.Group("/cookie", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCSRF(ghttp.CSRFOption{
+			ExcludeRoutes: []string{"/cookie/api/*"},
+		}))
+		bind(group)
+	})
+	s.Group("/session", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareCSRF(ghttp.CSRFOption{Mode: ghttp.CSRFModeSession}))
+		bind(group)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+
+	// Double-submit cookie.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(prefix)
+		resp, err := client.Get(ctx, "/cookie/form")
+		t.AssertNil(err)
+		var (
+			token   = resp.GetCookie("_csrf")
+			content = resp.ReadAllString()
+		)
+		resp.Close()
+		t.Assert(len(token), 64)
+		t.Assert(content, fmt.Sprintf(`<input type="hidden" name="_csrf" value="%s">`, token))
+
+		resp, err = client.Post(ctx, "/cookie/submit")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusForbidden)
+		resp.Close()
+
+		// The cookie only is not enough.
+		client.SetCookie("_csrf", token)
+		resp, err = client.Post(ctx, "/cookie/submit")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusForbidden)
+		resp.Close()
+
+		t.Assert(client.PostContent(ctx, "/cookie/submit", "_csrf="+token), "ok:"+token)
+		t.Assert(client.Header(g.MapStrStr{"X-CSRF-Token": token}).PostContent(ctx, "/cookie/submit"), "ok:"+token)
+		t.Assert(client.PostContent(ctx, "/cookie/submit", "_csrf=invalid"), "invalid CSRF token")
+
+		// Excluded routes.
+		t.Assert(g.Client().PostContent(ctx, prefix+"/cookie/api/submit"), "api")
+	})
+
+	// Synchronizer token in session.
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(prefix)
+		resp, err := client.Get(ctx, "/session/form")
+		t.AssertNil(err)
+		var (
+			sessionId = resp.GetCookie(s.GetSessionIdName())
+			content   = resp.ReadAllString()
+		)
+		resp.Close()
+		t.AssertNE(sessionId, "")
+		t.Assert(resp.GetCookie("_csrf"), "")
+		match, _ := gregex.MatchString(`value="(\w+)"`, content)
+		t.Assert(len(match), 2)
+		token := match[1]
+		t.Assert(len(token), 64)
+
+		client.SetCookie(s.GetSessionIdName(), sessionId)
+		t.Assert(client.PostContent(ctx, "/session/submit", "_csrf="+token), "ok:"+token)
+
+		// The token of other session is invalid.
+		t.Assert(g.Client().PostContent(ctx, prefix+"/session/submit", "_csrf="+token), "invalid CSRF token")
+	})
+}
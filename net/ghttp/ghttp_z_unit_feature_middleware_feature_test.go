@@ -0,0 +1,63 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/os/gfeature"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_Feature(t *testing.T) {
+	gfeature.SetProvider(gfeature.NewProviderRemote(func(ctx context.Context) (map[string]*gfeature.Flag, error) {
+		return gfeature.ParseFlags(g.Map{
+			"search":   g.Map{"enabled": true},
+			"checkout": g.Map{"enabled": true, "percentage": 0, "includes": g.Map{"tenant": g.Slice{"beta"}}},
+			"disabled": g.Map{"enabled": false},
+		})
+	}))
+	defer gfeature.SetProvider(gfeature.NewProviderConfig())
+
+	s := g.Server(guid.S())
+	s.Use(func(r *ghttp.Request) {
+		if tenant := r.Header.Get("X-Tenant"); tenant != "" {
+			r.SetCtx(gfeature.WithTenant(r.Context(), tenant))
+		}
+		r.Middleware.Next()
+	}, ghttp.MiddlewareFeature)
+	s.BindHandler("/checkout", func(r *ghttp.Request) {
+		r.Response.Write(gfeature.Enabled(r.Context(), "checkout"))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		resp, err := client.Get(ctx, "/checkout")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get(ghttp.HeaderFeatureFlags), "search")
+		t.Assert(resp.ReadAllString(), "false")
+		resp.Close()
+
+		resp, err = client.Header(g.MapStrStr{"X-Tenant": "beta"}).Get(ctx, "/checkout")
+		t.AssertNil(err)
+		t.Assert(resp.Header.Get(ghttp.HeaderFeatureFlags), "checkout,search")
+		t.Assert(resp.ReadAllString(), "true")
+		resp.Close()
+	})
+}
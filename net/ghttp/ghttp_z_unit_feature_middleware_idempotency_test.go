@@ -0,0 +1,154 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gclient"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_Idempotency(t *testing.T) {
+	var (
+		orders = gtype.NewInt()
+		s      = g.Server(guid.S())
+	)
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareIdempotency(ghttp.IdempotencyOption{
+			TTL:    time.Second,
+			Routes: []string{"/order", "/slow", "/fail", "/pay/*"},
+		}))
+		group.POST("/order", func(r *ghttp.Request) {
+			r.Response.Header().Set("X-Order-Id", fmt.Sprint(orders.Add(1)))
+			r.Response.Header().Set("Set-Cookie", "session=first")
+			r.Response.WriteStatus(http.StatusCreated, fmt.Sprintf("order %d", orders.Val()))
+		})
+		group.POST("/slow", func(r *ghttp.Request) {
+			time.Sleep(300 * time.Millisecond)
+			r.Response.Write("slow")
+		})
+		group.POST("/fail", func(r *ghttp.Request) {
+			r.Response.WriteStatus(http.StatusInternalServerError, fmt.Sprintf("fail %d", orders.Add(1)))
+		})
+		group.POST("/pay/card", func(r *ghttp.Request) {
+			r.Response.Write(fmt.Sprintf("pay %d", orders.Add(1)))
+		})
+		group.POST("/other", func(r *ghttp.Request) {
+			r.Response.Write(fmt.Sprintf("other %d", orders.Add(1)))
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	post := func(t *gtest.T, uri, key, body string) *gclient.Response {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		if key != "" {
+			client.SetHeader("Idempotency-Key", key)
+		}
+		resp, err := client.Post(ctx, uri, body)
+		t.AssertNil(err)
+		return resp
+	}
+
+	gtest.C(t, func(t *gtest.T) {
+		orders.Set(0)
+		resp := post(t, "/order", "k1", "amount=1")
+		t.Assert(resp.StatusCode, http.StatusCreated)
+		t.Assert(resp.ReadAllString(), "order 1")
+		t.Assert(resp.Header.Get("Idempotency-Replayed"), "")
+		resp.Close()
+
+		// Replayed.
+		resp = post(t, "/order", "k1", "amount=1")
+		t.Assert(resp.StatusCode, http.StatusCreated)
+		t.Assert(resp.ReadAllString(), "order 1")
+		t.Assert(resp.Header.Get("X-Order-Id"), "1")
+		t.Assert(resp.Header.Get("Set-Cookie"), "")
+		t.Assert(resp.Header.Get("Idempotency-Replayed"), "true")
+		resp.Close()
+
+		// Different body with the same key.
+		resp = post(t, "/order", "k1", "amount=2")
+		t.Assert(resp.StatusCode, http.StatusUnprocessableEntity)
+		resp.Close()
+
+		// Different key, no key, or not configured route.
+		resp = post(t, "/order", "k2", "amount=1")
+		t.Assert(resp.ReadAllString(), "order 2")
+		resp.Close()
+		resp = post(t, "/order", "", "amount=1")
+		t.Assert(resp.ReadAllString(), "order 3")
+		resp.Close()
+		resp = post(t, "/other", "k1", "")
+		t.Assert(resp.ReadAllString(), "other 4")
+		resp.Close()
+		resp = post(t, "/other", "k1", "")
+		t.Assert(resp.ReadAllString(), "other 5")
+		resp.Close()
+
+		// Expired.
+		time.Sleep(1100 * time.Millisecond)
+		resp = post(t, "/order", "k1", "amount=2")
+		t.Assert(resp.ReadAllString(), "order 6")
+		resp.Close()
+	})
+
+	// The server errors are not stored.
+	gtest.C(t, func(t *gtest.T) {
+		orders.Set(0)
+		resp := post(t, "/fail", "k1", "")
+		t.Assert(resp.ReadAllString(), "fail 1")
+		resp.Close()
+		resp = post(t, "/fail", "k1", "")
+		t.Assert(resp.ReadAllString(), "fail 2")
+		resp.Close()
+	})
+
+	// Concurrent request with the same key in handling.
+	gtest.C(t, func(t *gtest.T) {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			resp := post(t, "/slow", "k1", "")
+			t.Assert(resp.ReadAllString(), "slow")
+			resp.Close()
+		}()
+		time.Sleep(100 * time.Millisecond)
+		resp := post(t, "/slow", "k1", "")
+		t.Assert(resp.StatusCode, http.StatusConflict)
+		resp.Close()
+		<-done
+	})
+
+	// The keys are scoped by the Authorization header, and the route prefix is matched.
+	gtest.C(t, func(t *gtest.T) {
+		orders.Set(0)
+		post := func(uri, authorization string) string {
+			client := g.Client()
+			client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+			client.SetHeader("Idempotency-Key", "k3")
+			client.SetHeader("Authorization", authorization)
+			return client.PostContent(ctx, uri, "amount=1")
+		}
+		t.Assert(post("/order", "Bearer a"), "order 1")
+		t.Assert(post("/order", "Bearer b"), "order 2")
+		t.Assert(post("/order", "Bearer a"), "order 1")
+		t.Assert(post("/pay/card", "Bearer a"), "pay 3")
+		t.Assert(post("/pay/card", "Bearer a"), "pay 3")
+	})
+}
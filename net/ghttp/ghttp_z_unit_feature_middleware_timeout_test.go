@@ -0,0 +1,113 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_Timeout(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(3 * time.Second):
+		case <-r.Context().Done():
+		}
+		_, _ = w.Write([]byte("upstream"))
+	}))
+	defer upstream.Close()
+
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Group("/slow", func(group *ghttp.RouterGroup) {
+			group.Middleware(ghttp.MiddlewareTimeout(200 * time.Millisecond))
+			group.GET("/client", func(r *ghttp.Request) {
+				// The client call is cancelled with the request context.
+				content, err := g.Client().Get(r.Context(), upstream.URL)
+				if err != nil {
+					r.SetError(err)
+					r.Response.Write("error")
+					return
+				}
+				defer content.Close()
+				r.Response.Write(content.ReadAllString())
+			})
+			group.GET("/fast", func(r *ghttp.Request) {
+				r.Cookie.Set("fast", "1")
+				r.Response.Header().Set("X-Fast", "1")
+				r.Response.Write("fast")
+			})
+			group.GET("/blocking", func(r *ghttp.Request) {
+				// The handler ignoring the request context does not block the timeout response.
+				time.Sleep(time.Second)
+				r.Response.Header().Set("X-Blocking", "1")
+				r.Response.Write("blocking")
+			})
+		})
+		group.Group("/custom", func(group *ghttp.RouterGroup) {
+			group.Middleware(
+				ghttp.MiddlewareTimeout(100*time.Millisecond, ghttp.TimeoutOption{
+					Status:  http.StatusGatewayTimeout,
+					Content: "timeout",
+				}),
+				ghttp.MiddlewareHandlerResponse,
+			)
+			group.GET("/", func(r *ghttp.Request) {
+				<-r.Context().Done()
+				r.SetError(r.Context().Err())
+			})
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		start := time.Now()
+		resp, err := client.Get(ctx, "/slow/client")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		t.Assert(resp.ReadAllString(), http.StatusText(http.StatusServiceUnavailable))
+		t.Assert(time.Since(start) < time.Second, true)
+		resp.Close()
+
+		resp, err = client.Get(ctx, "/slow/fast")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.Header.Get("X-Fast"), "1")
+		t.Assert(resp.GetCookie("fast"), "1")
+		t.Assert(resp.ReadAllString(), "fast")
+		resp.Close()
+
+		start = time.Now()
+		resp, err = client.Get(ctx, "/slow/blocking")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusServiceUnavailable)
+		t.Assert(resp.Header.Get("X-Blocking"), "")
+		t.Assert(resp.ReadAllString(), http.StatusText(http.StatusServiceUnavailable))
+		t.Assert(time.Since(start) < 800*time.Millisecond, true)
+		resp.Close()
+
+		resp, err = client.Get(ctx, "/custom")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, http.StatusGatewayTimeout)
+		t.Assert(resp.ReadAllString(), "timeout")
+		resp.Close()
+	})
+}
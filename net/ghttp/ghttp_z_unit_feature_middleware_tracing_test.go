@@ -0,0 +1,165 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Middleware_Tracing_Filter(t *testing.T) {
+	var (
+		recorder    = tracetest.NewSpanRecorder()
+		oldProvider = otel.GetTracerProvider()
+	)
+	otel.SetTracerProvider(sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(oldProvider)
+
+	s := g.Server(guid.S())
+	s.BindHandler("/healthz", func(r *ghttp.Request) {
+		r.Response.Write("ok")
+	})
+	s.BindHandler("/upload", func(r *ghttp.Request) {
+		r.Response.Write(len(r.GetBody()))
+	})
+	s.BindHandler("/echo", func(r *ghttp.Request) {
+		r.Response.Header().Set("Set-Cookie", "session=secret")
+		r.Response.Write(r.GetBodyString())
+	})
+	s.SetTracingExcludeRoutes("/healthz")
+	s.SetTracingBodyExcludeRoutes("/upload")
+	s.SetTracingHeaderDenyList("authorization", "Set-Cookie")
+	s.SetTracingMaxBodySize(10, 5)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		client.SetHeader("Authorization", "Bearer secret")
+		client.SetHeader("X-Source", "test")
+
+		eventAttrs := func(name string) map[string]string {
+			attrs := make(map[string]string)
+			for _, span := range recorder.Ended() {
+				if span.Name() != name {
+					continue
+				}
+				for _, event := range span.Events() {
+					for _, attr := range event.Attributes {
+						attrs[string(attr.Key)] = attr.Value.Emit()
+					}
+				}
+			}
+			return attrs
+		}
+
+		t.Assert(client.GetContent(ctx, "/healthz"), "ok")
+		t.Assert(len(eventAttrs("GET /healthz")), 0)
+
+		content := strings.Repeat("a", 100)
+		t.Assert(client.PostContent(ctx, "/upload", content), "100")
+		attrs := eventAttrs("POST /upload")
+		t.AssertNE(attrs["http.request.url"], "")
+		_, ok := attrs["http.request.body"]
+		t.Assert(ok, false)
+		_, ok = attrs["http.response.body"]
+		t.Assert(ok, false)
+
+		// The request body is read partly ahead for tracing and completely by handler.
+		t.Assert(client.PostContent(ctx, "/echo", content), content)
+		attrs = eventAttrs("POST /echo")
+		t.Assert(attrs["http.request.body"], "aaaaaaaaaa...")
+		t.Assert(attrs["http.response.body"], "aaaaa...")
+		t.Assert(strings.Contains(attrs["http.request.headers"], "Bearer"), false)
+		t.Assert(strings.Contains(attrs["http.request.headers"], "User-Agent"), true)
+		t.Assert(strings.Contains(attrs["http.response.headers"], "secret"), false)
+
+		// The attributes of the latest span overwrite the earlier ones.
+		s.SetTracingHeaderAllowList("x-source", "Authorization")
+		t.Assert(client.PostContent(ctx, "/echo", "b"), "b")
+		attrs = eventAttrs("POST /echo")
+		t.Assert(attrs["http.request.body"], "b")
+		t.Assert(attrs["http.request.headers"], `{"X-Source":"test"}`)
+	})
+}
+
+func Test_Middleware_Tracing_SpanName(t *testing.T) {
+	var (
+		recorder    = tracetest.NewSpanRecorder()
+		oldProvider = otel.GetTracerProvider()
+	)
+	otel.SetTracerProvider(sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(oldProvider)
+
+	s := g.Server(guid.S())
+	s.BindHandler("/user/{id}", func(r *ghttp.Request) {
+		r.Response.Write(r.Get("id"))
+	})
+	s.BindHandler("/order/{id}", func(r *ghttp.Request) {
+		r.SetCtxVar("tenant", "t1")
+		r.Response.Write(r.Get("id"))
+	})
+	s.SetTracingSpanNameFunc(func(r *ghttp.Request) string {
+		if tenant := r.GetCtxVar("tenant").String(); tenant != "" {
+			return "tenant " + tenant
+		}
+		return ""
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+		client := g.Client()
+		client.SetPrefix(prefix)
+
+		t.Assert(client.GetContent(ctx, "/user/1?name=john"), "1")
+		t.Assert(client.GetContent(ctx, "/user/2"), "2")
+		t.Assert(client.GetContent(ctx, "/order/3"), "3")
+		t.Assert(client.GetContent(ctx, "/none/4"), "Not Found")
+
+		var (
+			names = make([]string, 0)
+			urls  = make([]string, 0)
+		)
+		for _, span := range recorder.Ended() {
+			if span.SpanKind() != trace.SpanKindServer {
+				continue
+			}
+			names = append(names, span.Name())
+			for _, attr := range span.Attributes() {
+				if attr.Key == "http.url" {
+					urls = append(urls, attr.Value.Emit())
+				}
+			}
+		}
+		t.Assert(names, []string{"GET /user/{id}", "GET /user/{id}", "tenant t1", "GET"})
+		t.Assert(urls, []string{
+			prefix + "/user/1?name=john",
+			prefix + "/user/2",
+			prefix + "/order/3",
+			prefix + "/none/4",
+		})
+	})
+}
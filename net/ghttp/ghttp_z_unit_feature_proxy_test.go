@@ -0,0 +1,183 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func newProxyUpstream(name string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Internal", "secret")
+		_, _ = fmt.Fprintf(
+			w, "%s|%s|%s|%s|%s",
+			name, r.URL.Path, body, r.Header.Get("X-Gateway"), r.Header.Get("Traceparent"),
+		)
+	}))
+}
+
+func Test_ReverseProxy_RoundRobin(t *testing.T) {
+	var (
+		upstream1 = newProxyUpstream("u1")
+		upstream2 = newProxyUpstream("u2")
+	)
+	defer upstream1.Close()
+	defer upstream2.Close()
+
+	proxy, err := ghttp.NewReverseProxy(ghttp.ReverseProxyConfig{
+		Upstreams: []ghttp.ReverseProxyUpstream{
+			{URL: upstream1.URL},
+			{URL: upstream2.URL + "/v1"},
+			{URL: "http://127.0.0.1:1"},
+		},
+		Retries:         2,
+		StripPrefix:     "/api",
+		RequestHeaders:  ghttp.GatewayHeaderTransform{Set: map[string]string{"X-Gateway": "gf"}},
+		ResponseHeaders: ghttp.GatewayHeaderTransform{Remove: []string{"X-Internal"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := g.Server(guid.S())
+	s.BindHandler("/api/*", proxy.Handler)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			client = g.Client()
+			prefix = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+		)
+		client.SetPrefix(prefix)
+
+		// The failed upstream is retried with the next one.
+		names := make(map[string]int)
+		for i := 0; i < 6; i++ {
+			resp, err := client.Post(ctx, "/api/user", "body")
+			t.AssertNil(err)
+			t.Assert(resp.StatusCode, 200)
+			t.Assert(resp.Header.Get("X-Internal"), "")
+			array := strings.Split(resp.ReadAllString(), "|")
+			resp.Close()
+			t.Assert(len(array), 5)
+			names[array[0]]++
+			switch array[0] {
+			case "u1":
+				t.Assert(array[1], "/user")
+			case "u2":
+				t.Assert(array[1], "/v1/user")
+			}
+			t.Assert(array[2], "body")
+			t.Assert(array[3], "gf")
+		}
+		t.Assert(names["u1"]+names["u2"], 6)
+		t.AssertGT(names["u1"], 0)
+		t.AssertGT(names["u2"], 0)
+
+		// Tracing context is propagated to upstream through the proxy.
+		traceId := "4bf92f3577b34da6a3ce929d0e0e4736"
+		req, err := http.NewRequest(http.MethodGet, prefix+"/api/trace", nil)
+		t.AssertNil(err)
+		req.Header.Set("Traceparent", fmt.Sprintf("00-%s-00f067aa0ba902b7-01", traceId))
+		resp, err := http.DefaultClient.Do(req)
+		t.AssertNil(err)
+		content, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		t.Assert(strings.Contains(string(content), traceId), true)
+	})
+}
+
+func Test_ReverseProxy_StripPrefix(t *testing.T) {
+	upstream := newProxyUpstream("u1")
+	defer upstream.Close()
+
+	proxy, err := ghttp.NewReverseProxy(ghttp.ReverseProxyConfig{
+		Upstreams:   []ghttp.ReverseProxyUpstream{{URL: upstream.URL}},
+		StripPrefix: "/api/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := g.Server(guid.S())
+	s.BindHandler("/*", proxy.Handler)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		for path, expect := range map[string]string{
+			"/api":      "/",
+			"/api/":     "/",
+			"/api/x":    "/x",
+			"/apiv2/x":  "/apiv2/x",
+			"/v1/api/x": "/v1/api/x",
+		} {
+			array := strings.Split(client.GetContent(ctx, path), "|")
+			t.Assert(len(array), 5)
+			t.Assert(array[1], expect)
+		}
+	})
+}
+
+func Test_ReverseProxy_Balance(t *testing.T) {
+	upstream := newProxyUpstream("u1")
+	defer upstream.Close()
+
+	gtest.C(t, func(t *gtest.T) {
+		for _, balance := range []string{ghttp.ProxyBalanceWeight, ghttp.ProxyBalanceLeastConn} {
+			proxy, err := ghttp.NewReverseProxy(ghttp.ReverseProxyConfig{
+				Balance:   balance,
+				Upstreams: []ghttp.ReverseProxyUpstream{{URL: upstream.URL, Weight: 2}},
+			})
+			t.AssertNil(err)
+			for i := 0; i < 3; i++ {
+				w := httptest.NewRecorder()
+				proxy.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/hello", nil))
+				t.Assert(w.Code, 200)
+				t.Assert(strings.HasPrefix(w.Body.String(), "u1|/hello|"), true)
+			}
+		}
+
+		// Connection failure without retries.
+		proxy, err := ghttp.NewReverseProxy(ghttp.ReverseProxyConfig{
+			Upstreams: []ghttp.ReverseProxyUpstream{{URL: "http://127.0.0.1:1"}},
+		})
+		t.AssertNil(err)
+		w := httptest.NewRecorder()
+		proxy.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/hello", nil))
+		t.Assert(w.Code, http.StatusBadGateway)
+
+		_, err = ghttp.NewReverseProxy(ghttp.ReverseProxyConfig{})
+		t.AssertNE(err, nil)
+		_, err = ghttp.NewReverseProxy(ghttp.ReverseProxyConfig{
+			Balance:   "unknown",
+			Upstreams: []ghttp.ReverseProxyUpstream{{URL: upstream.URL}},
+		})
+		t.AssertNE(err, nil)
+		_, err = ghttp.NewReverseProxy(ghttp.ReverseProxyConfig{
+			Upstreams: []ghttp.ReverseProxyUpstream{{URL: "invalid"}},
+		})
+		t.AssertNE(err, nil)
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/gsvc"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// testRegistrar records the registered services in memory.
+type testRegistrar struct {
+	mu       sync.Mutex
+	services map[string]gsvc.Service
+}
+
+func (r *testRegistrar) Register(ctx context.Context, service gsvc.Service) (gsvc.Service, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.services[service.GetName()] = service
+	return service, nil
+}
+
+func (r *testRegistrar) Deregister(ctx context.Context, service gsvc.Service) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.services, service.GetName())
+	return nil
+}
+
+func (r *testRegistrar) get(name string) gsvc.Service {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.services[name]
+}
+
+func Test_Server_Registrar(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			name      = guid.S()
+			registrar = &testRegistrar{services: make(map[string]gsvc.Service)}
+			s         = g.Server(name)
+		)
+		s.SetName(name)
+		s.SetAddr("127.0.0.1:0")
+		s.SetEndpoints([]string{"10.0.0.1:8000"})
+		s.SetRegistrar(registrar)
+		s.SetServiceMetadata(g.Map{"zone": "a", gsvc.MDWeight: 10})
+		s.SetDumpRouterMap(false)
+		t.AssertNil(s.Start())
+		time.Sleep(100 * time.Millisecond)
+
+		service := registrar.get(name)
+		t.AssertNE(service, nil)
+		t.Assert(service.GetEndpoints().String(), "10.0.0.1:8000")
+		t.Assert(service.GetMetadata(), g.Map{
+			gsvc.MDProtocol: "http",
+			gsvc.MDInsecure: true,
+			gsvc.MDWeight:   10,
+			"zone":          "a",
+		})
+
+		t.AssertNil(s.Shutdown())
+		t.Assert(registrar.get(name), nil)
+	})
+}
@@ -9,6 +9,7 @@ package ghttp_test
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 	"time"
@@ -415,3 +416,74 @@ func Test_Params_Strict_Route_File_Batch_Up_Multiple(t *testing.T) {
 		t.Assert(gfile.GetContents(dstPath2), gfile.GetContents(srcPath2))
 	})
 }
+
+func Test_Params_File_MultipartReader(t *testing.T) {
+	dstDirPath := gfile.Temp(gtime.TimestampNanoStr())
+	defer gfile.Remove(dstDirPath)
+	s := g.Server(guid.S())
+	s.BindHandler("/upload/stream", func(r *ghttp.Request) {
+		var progress int64
+		reader, err := r.GetMultipartReader(ghttp.MultipartOption{
+			MaxPartSize: r.GetQuery("max", 1024).Int64(),
+			Progress: func(part *ghttp.MultipartPart, read int64) {
+				progress = read
+			},
+		})
+		if err != nil {
+			r.Response.WriteExit(err.Error())
+		}
+		var result []string
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				r.Response.WriteExit(err.Error())
+			}
+			if !part.IsFile() {
+				value, err := io.ReadAll(part)
+				if err != nil {
+					r.Response.WriteExit(err.Error())
+				}
+				result = append(result, part.FormName()+"="+string(value))
+				continue
+			}
+			name, err := part.Save(dstDirPath)
+			if err != nil {
+				r.Response.WriteExit(err.Error())
+			}
+			result = append(result, fmt.Sprintf("%s:%d:%d", name, part.Size(), progress))
+		}
+		// The form is not available as body is consumed.
+		result = append(result, r.GetForm("name").String())
+		r.Response.Write(strings.Join(result, ","))
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		srcPath := gtest.DataPath("upload", "file1.txt")
+		size := gfile.Size(srcPath)
+		content := client.PostContent(ctx, "/upload/stream", g.Map{
+			"name": "john",
+			"file": "@file:" + srcPath,
+		})
+		t.Assert(strings.Contains(content, fmt.Sprintf("file1.txt:%d:%d", size, size)), true)
+		t.Assert(strings.Contains(content, "name=john"), true)
+		t.Assert(gfile.GetContents(gfile.Join(dstDirPath, "file1.txt")), gfile.GetContents(srcPath))
+
+		content = client.PostContent(ctx, "/upload/stream?max=1", g.Map{
+			"file": "@file:" + srcPath,
+		})
+		t.Assert(strings.Contains(content, "exceeds max size 1"), true)
+
+		content = client.PostContent(ctx, "/upload/stream", "name=john")
+		t.Assert(strings.Contains(content, "r.MultipartReader failed"), true)
+	})
+}
@@ -0,0 +1,127 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gmsgpack"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Params_MsgPack_Request(t *testing.T) {
+	type User struct {
+		Id    int
+		Name  string
+		Pass1 string `p:"password1"`
+		Pass2 string `p:"password2" v:"required|same:password1#|Passwords mismatch"`
+	}
+	type Item struct {
+		Id   int
+		Name string `v:"required"`
+	}
+	s := g.Server(guid.S())
+	s.BindHandler("/map", func(r *ghttp.Request) {
+		if m := r.GetMap(); len(m) > 0 {
+			r.Response.WriteExit(m["id"], m["name"], m["password1"], m["password2"])
+		}
+	})
+	s.BindHandler("/parse", func(r *ghttp.Request) {
+		var user *User
+		if err := r.Parse(&user); err != nil {
+			r.Response.WriteExit(err)
+		}
+		r.Response.WriteMsgPackExit(user)
+	})
+	s.BindHandler("/parse-slice", func(r *ghttp.Request) {
+		var items []Item
+		if err := r.Parse(&items); err != nil {
+			r.Response.WriteExit(err)
+		}
+		r.Response.WriteExit(len(items), items[1].Name)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client().ContentType("application/msgpack")
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		content1, _ := gmsgpack.Encode(g.Map{"id": 1, "name": "john", "password1": "123456", "password2": "123456"})
+		content2, _ := gmsgpack.Encode(g.Map{"id": 1, "name": "john", "password1": "123456", "password2": "654321"})
+		content3, _ := gmsgpack.Encode(g.Slice{g.Map{"id": 1, "name": "john"}, g.Map{"id": 2, "name": "smith"}})
+		t.Assert(client.PostContent(ctx, "/map", content1), `1john123456123456`)
+		t.Assert(client.PostContent(ctx, "/parse", content2), `Passwords mismatch`)
+		t.Assert(client.PostContent(ctx, "/parse-slice", content3), `2smith`)
+
+		resp, err := client.Post(ctx, "/parse", content1)
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.Header.Get("Content-Type"), "application/msgpack")
+		var user *User
+		t.AssertNil(gmsgpack.DecodeTo(resp.ReadAll(), &user))
+		t.Assert(user, &User{Id: 1, Name: "john", Pass1: "123456", Pass2: "123456"})
+	})
+}
+
+func Test_MsgPack_Accept(t *testing.T) {
+	type Req struct {
+		g.Meta `path:"/user" method:"get"`
+		Id     int
+	}
+	type Res struct {
+		Id   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	s := g.Server(guid.S())
+	s.Use(ghttp.MiddlewareHandlerResponse)
+	s.BindHandler("/user", func(ctx context.Context, req *Req) (res *Res, err error) {
+		return &Res{Id: req.Id, Name: "john"}, nil
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		prefix := fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+		t.Assert(
+			g.Client().SetPrefix(prefix).GetContent(ctx, "/user?id=1"),
+			`{"code":0,"message":"","data":{"id":1,"name":"john"}}`,
+		)
+		for accept, isMsgPack := range map[string]bool{
+			"application/msgpack":                           true,
+			"application/x-msgpack, application/json":       true,
+			"application/json, application/msgpack":         false,
+			"application/json;q=0.5, application/msgpack":   true,
+			"application/msgpack;q=0.1, */*":                false,
+			"text/html, application/msgpack;q=0.9, */*;q=0": true,
+		} {
+			client := g.Client().SetPrefix(prefix).Header(g.MapStrStr{"Accept": accept})
+			content := client.GetBytes(ctx, "/user?id=1")
+			if !isMsgPack {
+				t.Assert(content, `{"code":0,"message":"","data":{"id":1,"name":"john"}}`)
+				continue
+			}
+			result, err := gmsgpack.Decode(content)
+			t.AssertNil(err)
+			t.Assert(result, g.Map{
+				"code":    0,
+				"message": "",
+				"data":    g.Map{"id": 1, "name": "john"},
+			})
+		}
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+//go:build go1.19
+// +build go1.19
+
+package ghttp_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Response_WriteEarlyHints(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/hints", func(r *ghttp.Request) {
+		r.Response.WriteEarlyHints("</main.css>; rel=preload; as=style")
+		r.Response.Write("hints")
+	})
+	s.BindHandler("/push", func(r *ghttp.Request) {
+		r.Response.PushResources("/main.css", "/main.js?v=1")
+		r.Response.Write(r.Response.Push("/main.css", nil) == http.ErrNotSupported)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		get := func(uri string) (hints []textproto.MIMEHeader, resp *http.Response, body string) {
+			trace := &httptrace.ClientTrace{
+				Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+					if code == http.StatusEarlyHints {
+						hints = append(hints, header)
+					}
+					return nil
+				},
+			}
+			url := fmt.Sprintf("http://127.0.0.1:%d%s", s.GetListenedPort(), uri)
+			req, err := http.NewRequestWithContext(httptrace.WithClientTrace(ctx, trace), http.MethodGet, url, nil)
+			t.AssertNil(err)
+			resp, err = http.DefaultClient.Do(req)
+			t.AssertNil(err)
+			defer resp.Body.Close()
+			content, err := io.ReadAll(resp.Body)
+			t.AssertNil(err)
+			return hints, resp, string(content)
+		}
+		hints, resp, body := get("/hints")
+		t.Assert(len(hints), 1)
+		t.Assert(hints[0].Values("Link"), []string{"</main.css>; rel=preload; as=style"})
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(resp.Header.Get("Link"), "</main.css>; rel=preload; as=style")
+		t.Assert(body, "hints")
+
+		// Server push is not supported in HTTP/1.1, which uses early hints instead.
+		hints, resp, body = get("/push")
+		t.Assert(len(hints), 1)
+		t.Assert(hints[0].Values("Link"), []string{
+			"</main.css>; rel=preload; as=style",
+			"</main.js?v=1>; rel=preload; as=script",
+		})
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(body, "true")
+	})
+}
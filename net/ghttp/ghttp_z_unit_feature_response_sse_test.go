@@ -0,0 +1,64 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Response_SSEStream(t *testing.T) {
+	var (
+		sameStream = gtype.NewBool()
+		closedErr  = gtype.NewInterface()
+	)
+	s := g.Server(guid.S())
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(ghttp.MiddlewareHandlerResponse)
+		group.GET("/sse", func(r *ghttp.Request) {
+			stream := r.Response.SSEStream(30 * time.Millisecond)
+			sameStream.Set(r.Response.SSEStream() == stream)
+			_ = stream.SendRetry(3 * time.Second)
+			_ = stream.SendEvent("1", "message", "hello\nworld")
+			_ = stream.SendData(g.Map{"id": 2})
+			time.Sleep(50 * time.Millisecond)
+			stream.Close()
+			closedErr.Set(stream.SendData("closed"))
+		})
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		resp, err := client.Get(ctx, "/sse")
+		t.AssertNil(err)
+		defer resp.Close()
+		t.Assert(resp.Header.Get("Content-Type"), "text/event-stream")
+		t.Assert(resp.Header.Get("Cache-Control"), "no-cache")
+
+		content := resp.ReadAllString()
+		t.Assert(gstr.HasPrefix(content, "retry: 3000\n\nid: 1\nevent: message\ndata: hello\ndata: world\n\ndata: {\"id\":2}\n\n"), true)
+		t.Assert(gstr.Contains(content, ": heartbeat\n\n"), true)
+		// No handler response appended.
+		t.Assert(gstr.Contains(content, `"code"`), false)
+		t.Assert(sameStream.Val(), true)
+		t.AssertNE(closedErr.Val(), nil)
+	})
+}
@@ -8,6 +8,7 @@ package ghttp_test
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"testing"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/gogf/gf/v2/encoding/gxml"
 	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/os/gview"
 
 	"github.com/gogf/gf/v2/frame/g"
@@ -85,6 +87,109 @@ func Test_Response_ServeFileDownload(t *testing.T) {
 	})
 }
 
+func Test_Response_ServeFile_Range(t *testing.T) {
+	var (
+		filePath = gfile.Temp(guid.S())
+		content  = strings.Repeat("0123456789", 10)
+	)
+	gtest.AssertNil(gfile.PutContents(filePath, content))
+	defer gfile.Remove(filePath)
+
+	s := g.Server(guid.S())
+	s.BindHandler("/ServeFileDownload", func(r *ghttp.Request) {
+		r.Response.ServeFileDownload(filePath)
+	})
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		url := fmt.Sprintf("http://127.0.0.1:%d/ServeFileDownload", s.GetListenedPort())
+		get := func(header map[string]string) (*http.Response, string) {
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			t.AssertNil(err)
+			for k, v := range header {
+				req.Header.Set(k, v)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			t.AssertNil(err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			t.AssertNil(err)
+			return resp, string(body)
+		}
+		// Single range.
+		resp, body := get(map[string]string{"Range": "bytes=10-19"})
+		t.Assert(resp.StatusCode, http.StatusPartialContent)
+		t.Assert(resp.Header.Get("Accept-Ranges"), "bytes")
+		t.Assert(resp.Header.Get("Content-Range"), "bytes 10-19/100")
+		t.Assert(body, "0123456789")
+
+		// Suffix range resuming the downloading.
+		resp, body = get(map[string]string{"Range": "bytes=-5"})
+		t.Assert(resp.StatusCode, http.StatusPartialContent)
+		t.Assert(body, "56789")
+
+		// Multiple ranges.
+		resp, body = get(map[string]string{"Range": "bytes=0-1,20-21"})
+		t.Assert(resp.StatusCode, http.StatusPartialContent)
+		t.Assert(strings.HasPrefix(resp.Header.Get("Content-Type"), "multipart/byteranges"), true)
+		t.Assert(strings.Contains(body, "Content-Range: bytes 0-1/100"), true)
+		t.Assert(strings.Contains(body, "Content-Range: bytes 20-21/100"), true)
+
+		// Unsatisfiable range.
+		resp, _ = get(map[string]string{"Range": "bytes=200-300"})
+		t.Assert(resp.StatusCode, http.StatusRequestedRangeNotSatisfiable)
+
+		// The range is ignored if the file is changed.
+		resp, body = get(map[string]string{"Range": "bytes=0-1", "If-Range": `"changed"`})
+		t.Assert(resp.StatusCode, http.StatusOK)
+		t.Assert(body, content)
+
+		resp, _ = get(nil)
+		resp, body = get(map[string]string{"Range": "bytes=0-1", "If-Range": resp.Header.Get("ETag")})
+		t.Assert(resp.StatusCode, http.StatusPartialContent)
+		t.Assert(body, "01")
+	})
+}
+
+func Test_Response_ServeFile_Bandwidth(t *testing.T) {
+	var (
+		filePath = gfile.Temp(guid.S())
+		content  = strings.Repeat("0123456789", 5000)
+	)
+	gtest.AssertNil(gfile.PutContents(filePath, content))
+	defer gfile.Remove(filePath)
+
+	s := g.Server(guid.S())
+	s.BindHandler("/ServeFile", func(r *ghttp.Request) {
+		r.Response.ServeFile(filePath)
+	})
+	s.BindHandler("/Unlimited", func(r *ghttp.Request) {
+		r.Response.SetBandwidth(-1)
+		r.Response.ServeFile(filePath)
+	})
+	s.SetStaticBandwidth(100 * 1024)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+		startTime := time.Now()
+		t.Assert(client.GetContent(ctx, "/ServeFile"), content)
+		t.Assert(time.Since(startTime) > 400*time.Millisecond, true)
+
+		startTime = time.Now()
+		t.Assert(client.GetContent(ctx, "/Unlimited"), content)
+		t.Assert(time.Since(startTime) < 400*time.Millisecond, true)
+	})
+}
+
 func Test_Response_Redirect(t *testing.T) {
 	s := g.Server(guid.S())
 	s.BindHandler("/", func(r *ghttp.Request) {
@@ -0,0 +1,102 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package ghttp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_Shutdown_Drain(t *testing.T) {
+	var (
+		events = garray.NewStrArray(true)
+		s      = g.Server(guid.S())
+	)
+	s.BindHandler("/slow", func(r *ghttp.Request) {
+		time.Sleep(500 * time.Millisecond)
+		events.Append("slow")
+		r.Response.Write("slow")
+	})
+	s.BindHandler("/fast", func(r *ghttp.Request) {
+		r.Response.Write("fast")
+	})
+	s.OnShutdown(func(ctx context.Context) {
+		_, ok := ctx.Deadline()
+		events.Append(fmt.Sprintf("hook:%v", ok))
+	})
+	s.SetShutdownDrainTimeout(3 * time.Second)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			prefix = fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort())
+			done   = make(chan struct{})
+		)
+		go func() {
+			defer close(done)
+			client := g.Client()
+			client.SetPrefix(prefix)
+			t.Assert(client.GetContent(ctx, "/slow"), "slow")
+		}()
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(s.GetInflightCount(), 1)
+
+		start := time.Now()
+		go func() {
+			_ = s.Shutdown()
+			events.Append("shutdown")
+		}()
+		time.Sleep(100 * time.Millisecond)
+
+		// The new requests are rejected in draining.
+		client := g.Client()
+		client.SetPrefix(prefix)
+		resp, err := client.Get(ctx, "/fast")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 503)
+		t.Assert(resp.Response.Close, true)
+		resp.Close()
+
+		<-done
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(events.Slice(), g.SliceStr{"hook:true", "slow", "shutdown"})
+		t.Assert(time.Since(start) < 3*time.Second, true)
+	})
+}
+
+func Test_Shutdown_DrainTimeout(t *testing.T) {
+	s := g.Server(guid.S())
+	s.BindHandler("/slow", func(r *ghttp.Request) {
+		time.Sleep(time.Second)
+		r.Response.Write("slow")
+	})
+	s.SetShutdownDrainTimeout(200 * time.Millisecond)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+		go client.GetContent(ctx, "/slow")
+		time.Sleep(100 * time.Millisecond)
+
+		start := time.Now()
+		t.AssertNil(s.Shutdown())
+		t.Assert(time.Since(start) < 500*time.Millisecond, true)
+	})
+}
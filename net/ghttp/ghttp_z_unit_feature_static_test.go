@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/test/gtest"
 	"github.com/gogf/gf/v2/text/gstr"
@@ -272,3 +273,65 @@ func Test_Static_Rewrite(t *testing.T) {
 		t.Assert(client.GetContent(ctx, "/my-test2"), "test2")
 	})
 }
+
+func Test_Static_ConditionalRequest(t *testing.T) {
+	for _, mode := range []string{ghttp.StaticETagModTime, ghttp.StaticETagHash} {
+		gtest.C(t, func(t *gtest.T) {
+			s := g.Server(guid.S())
+			path := fmt.Sprintf(`%s/ghttp/static/etag/%s`, gfile.Temp(), guid.S())
+			defer gfile.Remove(path)
+			t.AssertNil(gfile.PutContents(path+"/index.html", "index"))
+			t.AssertNil(gfile.PutContents(path+"/assets/app.js", "app"))
+			s.SetServerRoot(path)
+			s.SetStaticETag(mode)
+			s.SetStaticCacheControl("*.html", "no-cache")
+			s.SetStaticCacheControl("/assets/", "public, max-age=31536000")
+			s.SetDumpRouterMap(false)
+			s.Start()
+			defer s.Shutdown()
+			time.Sleep(100 * time.Millisecond)
+			client := g.Client()
+			client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", s.GetListenedPort()))
+
+			resp, err := client.Get(ctx, "/assets/app.js")
+			t.AssertNil(err)
+			var (
+				etag         = resp.Header.Get("ETag")
+				lastModified = resp.Header.Get("Last-Modified")
+			)
+			t.Assert(resp.StatusCode, 200)
+			t.Assert(resp.ReadAllString(), "app")
+			t.Assert(resp.Header.Get("Cache-Control"), "public, max-age=31536000")
+			t.AssertNE(etag, "")
+			t.AssertNE(lastModified, "")
+			resp.Close()
+
+			resp, err = client.Header(g.MapStrStr{"If-None-Match": etag}).Get(ctx, "/assets/app.js")
+			t.AssertNil(err)
+			t.Assert(resp.StatusCode, 304)
+			t.Assert(resp.ReadAllString(), "")
+			resp.Close()
+
+			resp, err = client.Header(g.MapStrStr{"If-Modified-Since": lastModified}).Get(ctx, "/assets/app.js")
+			t.AssertNil(err)
+			t.Assert(resp.StatusCode, 304)
+			resp.Close()
+
+			resp, err = client.Header(g.MapStrStr{"If-None-Match": `"other"`}).Get(ctx, "/index.html")
+			t.AssertNil(err)
+			t.Assert(resp.StatusCode, 200)
+			t.Assert(resp.Header.Get("Cache-Control"), "no-cache")
+			resp.Close()
+
+			// ETag changes with content.
+			time.Sleep(1100 * time.Millisecond)
+			t.AssertNil(gfile.PutContents(path+"/assets/app.js", "app2"))
+			resp, err = client.Header(g.MapStrStr{"If-None-Match": etag}).Get(ctx, "/assets/app.js")
+			t.AssertNil(err)
+			t.Assert(resp.StatusCode, 200)
+			t.Assert(resp.ReadAllString(), "app2")
+			t.AssertNE(resp.Header.Get("ETag"), etag)
+			resp.Close()
+		})
+	}
+}
@@ -28,12 +28,16 @@ func NewWriter(writer http.ResponseWriter) *Writer {
 }
 
 // WriteHeader implements the interface of http.ResponseWriter.WriteHeader.
-// Note that the underlying `WriteHeader` can only be called once in a http response.
+// Note that the underlying `WriteHeader` can only be called once in a http response,
+// except the informational 1xx status like http.StatusEarlyHints, which is sent immediately.
 func (w *Writer) WriteHeader(status int) {
 	if w.wroteHeader {
 		return
 	}
 	w.ResponseWriter.WriteHeader(status)
+	if status >= 100 && status < 200 && status != http.StatusSwitchingProtocols {
+		return
+	}
 	w.wroteHeader = true
 }
 
@@ -57,6 +61,15 @@ func (w *Writer) Hijack() (conn net.Conn, writer *bufio.ReadWriter, err error) {
 	return
 }
 
+// Push implements the interface function of http.Pusher.Push.
+// It returns http.ErrNotSupported if the underlying ResponseWriter does not support HTTP/2 server push.
+func (w *Writer) Push(target string, opts *http.PushOptions) error {
+	if pusher, ok := w.ResponseWriter.(http.Pusher); ok {
+		return pusher.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
 // IsHeaderWrote returns if the header status is written.
 func (w *Writer) IsHeaderWrote() bool {
 	return w.wroteHeader
@@ -0,0 +1,54 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gjwt implements issuing, refreshing and validating of access and refresh tokens,
+// with ghttp middleware and publishing of the verification keys as JSON Web Key Set.
+//
+// The token signing, verification and key sets are provided by package crypto/gjwt,
+// which supports algorithms of HMAC, RSA, ECDSA and Ed25519.
+package gjwt
+
+import (
+	"context"
+
+	jwt "github.com/gogf/gf/v2/crypto/gjwt"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Claims is the claims set of token.
+type Claims = jwt.Claims
+
+// ClaimTokenUse is the private claim distinguishing access token from refresh token.
+const ClaimTokenUse = "token_use"
+
+// Values of ClaimTokenUse.
+const (
+	TokenUseAccess  = "access"
+	TokenUseRefresh = "refresh"
+)
+
+type ctxKey string
+
+const ctxKeyClaims ctxKey = "GJwtClaims"
+
+// GetTokenUse returns the usage of token with `claims`, TokenUseAccess or TokenUseRefresh.
+func GetTokenUse(claims Claims) string {
+	return gconv.String(claims[ClaimTokenUse])
+}
+
+// WithClaims returns a new context with `claims`.
+func WithClaims(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, ctxKeyClaims, claims)
+}
+
+// ClaimsFromCtx retrieves and returns the claims from `ctx`, which is set by Middleware.
+// It returns nil if there's no claims in context.
+func ClaimsFromCtx(ctx context.Context) Claims {
+	if v, ok := ctx.Value(ctxKeyClaims).(Claims); ok {
+		return v
+	}
+	return nil
+}
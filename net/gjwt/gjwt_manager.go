@@ -0,0 +1,214 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjwt
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	jwt "github.com/gogf/gf/v2/crypto/gjwt"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// Config is the configuration for Manager.
+type Config struct {
+	Issuer            string        // Issuer of tokens, which is also verified if not empty.
+	Audience          string        // Audience of tokens, which is also verified if not empty.
+	Expiration        time.Duration // Expiration of access token, which is 1 hour in default.
+	RefreshExpiration time.Duration // Expiration of refresh token, which is 7 days in default.
+	Leeway            time.Duration // Tolerance of clock skew in validating time claims.
+	SigningKey        *jwt.Key      // Key for signing tokens, which is needed for issuing.
+	KeySet            jwt.KeySet    // Keys for verifying like jwt.NewJWKS, which uses the keys of Manager if not set.
+}
+
+// Manager issues, refreshes and validates tokens.
+type Manager struct {
+	mu     sync.RWMutex
+	config Config
+	keys   []jwt.Key // Signing key and the retired keys for verifying tokens issued before rotation.
+}
+
+// TokenPair is the access token with refresh token.
+type TokenPair struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int64  `json:"expiresIn"` // Expiration seconds of the access token.
+}
+
+var errRefreshTokenForAccess = gerror.NewCode(gcode.CodeNotAuthorized, `refresh token cannot be used for access`)
+
+const (
+	defaultExpiration        = time.Hour
+	defaultRefreshExpiration = 7 * 24 * time.Hour
+)
+
+// New creates and returns a token manager with `config`.
+func New(config Config) *Manager {
+	if config.Expiration <= 0 {
+		config.Expiration = defaultExpiration
+	}
+	if config.RefreshExpiration <= 0 {
+		config.RefreshExpiration = defaultRefreshExpiration
+	}
+	m := &Manager{
+		config: config,
+	}
+	if config.SigningKey != nil {
+		m.keys = []jwt.Key{*config.SigningKey}
+	}
+	return m
+}
+
+// RotateKey uses `key` for signing new tokens, and retains the previous keys for verifying
+// the tokens issued before, until they are removed by RemoveKey.
+func (m *Manager) RotateKey(key jwt.Key) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.config.SigningKey = &key
+	m.keys = append([]jwt.Key{key}, m.keys...)
+}
+
+// RemoveKey removes the retired key with id `kid`, after which the tokens signed by it are invalid.
+// The current signing key cannot be removed.
+func (m *Manager) RemoveKey(kid string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := make([]jwt.Key, 0, len(m.keys))
+	for i, key := range m.keys {
+		if i == 0 || key.ID != kid {
+			keys = append(keys, key)
+		}
+	}
+	m.keys = keys
+}
+
+// Keys implements the interface jwt.KeySet with the signing key and retired keys.
+// It returns the keys having id `kid` and the keys without id, or all keys if `kid` is empty.
+func (m *Manager) Keys(ctx context.Context, kid string) ([]jwt.Key, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	keys := make([]jwt.Key, 0, len(m.keys))
+	for _, key := range m.keys {
+		if kid == "" || key.ID == "" || key.ID == kid {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// JWKS returns the public keys of the signing key and retired keys as JSON Web Key Set,
+// which are published for other services verifying the tokens.
+// The HMAC keys are never published.
+func (m *Manager) JWKS() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return jwt.EncodeJWKS(m.keys...)
+}
+
+// Issue issues and returns an access token for `subject` with custom `claims`.
+func (m *Manager) Issue(ctx context.Context, subject string, claims ...Claims) (string, error) {
+	return m.issue(subject, TokenUseAccess, m.config.Expiration, claims...)
+}
+
+// IssuePair issues and returns an access token with refresh token for `subject` with custom `claims`.
+func (m *Manager) IssuePair(ctx context.Context, subject string, claims ...Claims) (*TokenPair, error) {
+	accessToken, err := m.issue(subject, TokenUseAccess, m.config.Expiration, claims...)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := m.issue(subject, TokenUseRefresh, m.config.RefreshExpiration, claims...)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(m.config.Expiration / time.Second),
+	}, nil
+}
+
+// Refresh validates `refreshToken` and issues a new token pair with its subject and custom claims.
+func (m *Manager) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	claims, err := m.Parse(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if GetTokenUse(claims) != TokenUseRefresh {
+		return nil, gerror.NewCode(gcode.CodeNotAuthorized, `token is not refresh token`)
+	}
+	custom := make(Claims)
+	for k, v := range claims {
+		if !isReservedClaim(k) {
+			custom[k] = v
+		}
+	}
+	return m.IssuePair(ctx, claims.Subject(), custom)
+}
+
+// Parse verifies `token` and validates its claims, and then returns the claims.
+func (m *Manager) Parse(ctx context.Context, token string) (Claims, error) {
+	var keySet jwt.KeySet = m
+	if m.config.KeySet != nil {
+		keySet = m.config.KeySet
+	}
+	t, err := jwt.Verify(ctx, token, jwt.VerifyOption{
+		KeySet:            keySet,
+		Issuer:            m.config.Issuer,
+		Audience:          m.config.Audience,
+		Leeway:            m.config.Leeway,
+		RequireExpiration: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t.Claims, nil
+}
+
+// issue signs and returns a token.
+func (m *Manager) issue(subject, tokenUse string, expiration time.Duration, claims ...Claims) (string, error) {
+	m.mu.RLock()
+	key := m.config.SigningKey
+	m.mu.RUnlock()
+	if key == nil {
+		return "", gerror.NewCode(gcode.CodeInvalidConfiguration, `signing key is not configured`)
+	}
+	var (
+		now     = time.Now()
+		payload = make(Claims)
+	)
+	for _, c := range claims {
+		for k, v := range c {
+			payload[k] = v
+		}
+	}
+	payload[jwt.ClaimSubject] = subject
+	payload[jwt.ClaimIssuedAt] = now.Unix()
+	payload[jwt.ClaimNotBefore] = now.Unix()
+	payload[jwt.ClaimExpiresAt] = now.Add(expiration).Unix()
+	payload[jwt.ClaimID] = guid.S()
+	payload[ClaimTokenUse] = tokenUse
+	if m.config.Issuer != "" {
+		payload[jwt.ClaimIssuer] = m.config.Issuer
+	}
+	if m.config.Audience != "" {
+		payload[jwt.ClaimAudience] = m.config.Audience
+	}
+	return jwt.Sign(payload, *key)
+}
+
+// isReservedClaim checks whether `name` is the claim set by Manager in issuing.
+func isReservedClaim(name string) bool {
+	switch name {
+	case jwt.ClaimIssuer, jwt.ClaimSubject, jwt.ClaimAudience, jwt.ClaimExpiresAt,
+		jwt.ClaimNotBefore, jwt.ClaimIssuedAt, jwt.ClaimID, ClaimTokenUse:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjwt
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gogf/gf/v2/net/ghttp"
+)
+
+// Middleware is the ghttp middleware authenticating requests with the access token in header
+// "Authorization: Bearer <token>". It responds 401 for invalid tokens, or else it populates
+// the request context with the claims, which can be retrieved by ClaimsFromCtx.
+func (m *Manager) Middleware(r *ghttp.Request) {
+	var (
+		ctx           = r.Context()
+		authorization = r.Header.Get("Authorization")
+	)
+	if len(authorization) < 7 || !strings.EqualFold(authorization[:7], "Bearer ") {
+		r.Response.Header().Set("WWW-Authenticate", `Bearer`)
+		r.Response.WriteStatus(http.StatusUnauthorized)
+		return
+	}
+	claims, err := m.Parse(ctx, strings.TrimSpace(authorization[7:]))
+	if err == nil && GetTokenUse(claims) == TokenUseRefresh {
+		err = errRefreshTokenForAccess
+	}
+	if err != nil {
+		r.Response.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		r.Response.WriteStatus(http.StatusUnauthorized)
+		return
+	}
+	r.SetCtx(WithClaims(ctx, claims))
+	r.Middleware.Next()
+}
+
+// JWKSHandler is the ghttp handler publishing the public keys of Manager as JSON Web Key Set,
+// which is commonly bound to "/.well-known/jwks.json".
+func (m *Manager) JWKSHandler(r *ghttp.Request) {
+	data, err := m.JWKS()
+	if err != nil {
+		r.SetError(err)
+		r.Response.WriteStatus(http.StatusInternalServerError)
+		return
+	}
+	r.Response.Header().Set("Content-Type", "application/json")
+	r.Response.Write(data)
+}
@@ -0,0 +1,228 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	jwt "github.com/gogf/gf/v2/crypto/gjwt"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/net/ghttp"
+	"github.com/gogf/gf/v2/net/gjwt"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+var ctx = gctx.New()
+
+func newHMACKey(id string) *jwt.Key {
+	return &jwt.Key{ID: id, Algorithm: jwt.HS256, Key: []byte("secret")}
+}
+
+func newRSAKey(t *gtest.T, id string) *jwt.Key {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	t.AssertNil(err)
+	return &jwt.Key{ID: id, Algorithm: jwt.RS256, Key: privateKey}
+}
+
+func newECDSAKey(t *gtest.T, id string) *jwt.Key {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	t.AssertNil(err)
+	return &jwt.Key{ID: id, Algorithm: jwt.ES256, Key: privateKey}
+}
+
+func Test_Manager_Algorithms(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		keys := []*jwt.Key{
+			newHMACKey("hmac"),
+			newRSAKey(t, "rsa"),
+			newECDSAKey(t, "ecdsa"),
+		}
+		for _, key := range keys {
+			manager := gjwt.New(gjwt.Config{
+				Issuer:     "gf",
+				Audience:   "api",
+				SigningKey: key,
+			})
+			token, err := manager.Issue(ctx, "10000", gjwt.Claims{"role": "admin"})
+			t.AssertNil(err)
+
+			claims, err := manager.Parse(ctx, token)
+			t.AssertNil(err)
+			t.Assert(claims.Subject(), "10000")
+			t.Assert(claims.Issuer(), "gf")
+			t.Assert(claims.Audience(), []string{"api"})
+			t.Assert(gjwt.GetTokenUse(claims), gjwt.TokenUseAccess)
+			t.Assert(claims["role"], "admin")
+			t.AssertNE(claims.ID(), "")
+			expiresAt, ok := claims.ExpiresAt()
+			t.Assert(ok, true)
+			t.AssertGT(expiresAt.Unix(), time.Now().Unix())
+
+			// Tampered payload.
+			parts := strings.Split(token, ".")
+			_, err = manager.Parse(ctx, parts[0]+"."+parts[0]+"."+parts[2])
+			t.AssertNE(err, nil)
+		}
+	})
+}
+
+func Test_Manager_Validate(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		key := newHMACKey("k1")
+		expired := gjwt.New(gjwt.Config{SigningKey: key, Expiration: time.Millisecond})
+		token, err := expired.Issue(ctx, "1")
+		t.AssertNil(err)
+		time.Sleep(1100 * time.Millisecond)
+		_, err = expired.Parse(ctx, token)
+		t.AssertNE(err, nil)
+
+		// Leeway for clock skew.
+		tolerant := gjwt.New(gjwt.Config{SigningKey: key, Leeway: time.Minute})
+		_, err = tolerant.Parse(ctx, token)
+		t.AssertNil(err)
+
+		// Issuer and audience.
+		other := gjwt.New(gjwt.Config{SigningKey: key, Issuer: "other", Audience: "web"})
+		token, err = gjwt.New(gjwt.Config{SigningKey: key, Issuer: "gf"}).Issue(ctx, "1")
+		t.AssertNil(err)
+		_, err = other.Parse(ctx, token)
+		t.AssertNE(err, nil)
+
+		// Algorithm confusion.
+		rsaManager := gjwt.New(gjwt.Config{SigningKey: newRSAKey(t, "k1")})
+		_, err = rsaManager.Parse(ctx, token)
+		t.AssertNE(err, nil)
+
+		_, err = tolerant.Parse(ctx, "invalid")
+		t.AssertNE(err, nil)
+		_, err = gjwt.New(gjwt.Config{}).Issue(ctx, "1")
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Manager_Refresh(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		manager := gjwt.New(gjwt.Config{SigningKey: newHMACKey("k1")})
+		pair, err := manager.IssuePair(ctx, "10000", gjwt.Claims{"tenant": "t1"})
+		t.AssertNil(err)
+		t.Assert(pair.ExpiresIn, 3600)
+
+		_, err = manager.Refresh(ctx, pair.AccessToken)
+		t.AssertNE(err, nil)
+
+		refreshed, err := manager.Refresh(ctx, pair.RefreshToken)
+		t.AssertNil(err)
+		claims, err := manager.Parse(ctx, refreshed.AccessToken)
+		t.AssertNil(err)
+		t.Assert(claims.Subject(), "10000")
+		t.Assert(claims["tenant"], "t1")
+		t.Assert(gjwt.GetTokenUse(claims), gjwt.TokenUseAccess)
+	})
+}
+
+func Test_Manager_RotateKey(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			key1    = newECDSAKey(t, "k1")
+			key2    = newRSAKey(t, "k2")
+			manager = gjwt.New(gjwt.Config{SigningKey: key1})
+		)
+		token1, err := manager.Issue(ctx, "1")
+		t.AssertNil(err)
+
+		manager.RotateKey(*key2)
+		token2, err := manager.Issue(ctx, "2")
+		t.AssertNil(err)
+		_, err = manager.Parse(ctx, token1)
+		t.AssertNil(err)
+		_, err = manager.Parse(ctx, token2)
+		t.AssertNil(err)
+		t.Assert(len(mustJWKS(t, manager)), 2)
+
+		manager.RemoveKey("k1")
+		_, err = manager.Parse(ctx, token1)
+		t.AssertNE(err, nil)
+		_, err = manager.Parse(ctx, token2)
+		t.AssertNil(err)
+		// The signing key cannot be removed.
+		manager.RemoveKey("k2")
+		t.Assert(len(mustJWKS(t, manager)), 1)
+	})
+}
+
+func Test_Middleware_And_JWKS(t *testing.T) {
+	issuer := gjwt.New(gjwt.Config{SigningKey: &jwt.Key{ID: "k1", Algorithm: jwt.RS256, Key: mustRSAKey()}})
+	s := g.Server(guid.S())
+	s.BindHandler("/.well-known/jwks.json", issuer.JWKSHandler)
+	s.SetDumpRouterMap(false)
+	s.Start()
+	defer s.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+	jwksURL := fmt.Sprintf("http://127.0.0.1:%d/.well-known/jwks.json", s.GetListenedPort())
+
+	// Another service verifying tokens with the published keys.
+	verifier := gjwt.New(gjwt.Config{KeySet: jwt.NewJWKS(jwksURL)})
+	api := g.Server(guid.S())
+	api.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(verifier.Middleware)
+		group.GET("/me", func(r *ghttp.Request) {
+			r.Response.Write(gjwt.ClaimsFromCtx(r.Context()).Subject())
+		})
+	})
+	api.SetDumpRouterMap(false)
+	api.Start()
+	defer api.Shutdown()
+	time.Sleep(100 * time.Millisecond)
+
+	gtest.C(t, func(t *gtest.T) {
+		client := g.Client()
+		client.SetPrefix(fmt.Sprintf("http://127.0.0.1:%d", api.GetListenedPort()))
+
+		resp, err := client.Get(ctx, "/me")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 401)
+		resp.Close()
+
+		pair, err := issuer.IssuePair(ctx, "10000")
+		t.AssertNil(err)
+		t.Assert(client.Header(bearer(pair.AccessToken)).GetContent(ctx, "/me"), "10000")
+
+		resp, err = client.Header(bearer(pair.RefreshToken)).Get(ctx, "/me")
+		t.AssertNil(err)
+		t.Assert(resp.StatusCode, 401)
+		resp.Close()
+	})
+}
+
+func mustJWKS(t *gtest.T, manager *gjwt.Manager) []jwt.Key {
+	data, err := manager.JWKS()
+	t.AssertNil(err)
+	keys, err := jwt.ParseJWKS(data)
+	t.AssertNil(err)
+	return keys
+}
+
+func bearer(token string) map[string]string {
+	return map[string]string{"Authorization": "Bearer " + token}
+}
+
+func mustRSAKey() *rsa.PrivateKey {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	return privateKey
+}
@@ -0,0 +1,77 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gmq provides message queue producer and consumer definition.
+//
+// The brokers like Kafka, RabbitMQ and NATS are implemented as adapters in contrib,
+// and there's an in-memory broker in this package for local development and testing.
+package gmq
+
+import (
+	"context"
+	"time"
+)
+
+// Producer interface for publishing messages.
+type Producer interface {
+	// Publish publishes `messages` to their topics.
+	// It returns after all messages are accepted by the broker.
+	Publish(ctx context.Context, messages ...*Message) error
+
+	// Close flushes the pending messages and closes the producer.
+	Close(ctx context.Context) error
+}
+
+// Consumer interface for consuming messages.
+type Consumer interface {
+	// Subscribe consumes messages of `topic` in background as a member of consumer `group`.
+	//
+	// Each message is delivered to one member of every group at least once:
+	// the message is acknowledged if `handler` returns nil, or else it is redelivered later.
+	// The subscription stops if `ctx` is done or the consumer is closed.
+	Subscribe(ctx context.Context, topic, group string, handler Handler) error
+
+	// Close stops consuming and waits for the messages being handled done, or `ctx` done.
+	Close(ctx context.Context) error
+}
+
+// Broker interface for both producing and consuming messages.
+type Broker interface {
+	Producer
+	Consumer
+}
+
+// Handler handles the consumed message.
+type Handler func(ctx context.Context, message *Message) error
+
+// Middleware wraps Handler with extra features, like tracing, logging and retrying.
+type Middleware func(next Handler) Handler
+
+// Message is the message of queue.
+type Message struct {
+	Id        string            // Unique id of the message, which is generated when publishing if empty.
+	Topic     string            // Topic of the message.
+	Key       string            // Key of the message, which is used for partitioning or routing by broker.
+	Header    map[string]string // Header of the message, which also carries tracing context.
+	Body      []byte            // Body of the message.
+	Timestamp time.Time         // Creating time of the message, which is set when publishing if zero.
+	Attempt   int               // Delivery attempt of the message starting from 1, which is 0 if broker does not support.
+}
+
+const (
+	HeaderError         = "x-gmq-error"          // HeaderError is the header of the last error for dead letter message.
+	HeaderOriginalTopic = "x-gmq-original-topic" // HeaderOriginalTopic is the header of the original topic for dead letter message.
+	DeadLetterSuffix    = ".dlq"                 // DeadLetterSuffix is the suffix of default dead letter topic.
+)
+
+// Chain wraps `handler` with `middlewares`.
+// The first middleware is the outermost, which is executed first.
+func Chain(handler Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
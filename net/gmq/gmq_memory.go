@@ -0,0 +1,168 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmq
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/glist"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/util/guid"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+// Memory is the in-memory Broker, which is used for local development and testing.
+//
+// The messages are only delivered to the consumer groups that subscribed before publishing,
+// and they are lost when the process exits.
+type Memory struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	closed  bool
+	closing chan struct{}                     // Closed when the broker is closed.
+	groups  map[string]map[string]*glist.List // Topic => group name => pending messages.
+	running sync.WaitGroup                    // Running consuming goroutines.
+}
+
+const (
+	// memoryRedeliveryDelay is the delay for redelivering failed messages.
+	memoryRedeliveryDelay = 100 * time.Millisecond
+)
+
+var (
+	_ Broker = &Memory{}
+)
+
+// NewMemory creates and returns an in-memory Broker.
+func NewMemory() *Memory {
+	m := &Memory{
+		groups:  make(map[string]map[string]*glist.List),
+		closing: make(chan struct{}),
+	}
+	m.cond = sync.NewCond(&m.mu)
+	return m
+}
+
+// Publish implements the interface Producer.
+func (m *Memory) Publish(ctx context.Context, messages ...*Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `memory broker is closed`)
+	}
+	for _, message := range messages {
+		if message.Id == "" {
+			message.Id = guid.S()
+		}
+		if message.Timestamp.IsZero() {
+			message.Timestamp = time.Now()
+		}
+		for _, list := range m.groups[message.Topic] {
+			delivery := message.Clone()
+			delivery.Attempt = 1
+			list.PushBack(delivery)
+		}
+	}
+	m.cond.Broadcast()
+	return nil
+}
+
+// Subscribe implements the interface Consumer.
+func (m *Memory) Subscribe(ctx context.Context, topic, group string, handler Handler) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `memory broker is closed`)
+	}
+	if m.groups[topic] == nil {
+		m.groups[topic] = make(map[string]*glist.List)
+	}
+	list := m.groups[topic][group]
+	if list == nil {
+		list = glist.New()
+		m.groups[topic][group] = list
+	}
+	m.running.Add(1)
+	go m.consume(ctx, list, handler)
+	// Wake up the consuming goroutine if the subscription is canceled.
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-m.closing:
+		}
+		m.mu.Lock()
+		m.cond.Broadcast()
+		m.mu.Unlock()
+	}()
+	return nil
+}
+
+// consume handles messages from `list` until `ctx` is done or the broker is closed.
+func (m *Memory) consume(ctx context.Context, list *glist.List, handler Handler) {
+	defer m.running.Done()
+	for {
+		m.mu.Lock()
+		for list.Len() == 0 && !m.closed && ctx.Err() == nil {
+			m.cond.Wait()
+		}
+		if m.closed || ctx.Err() != nil {
+			m.mu.Unlock()
+			return
+		}
+		message := list.PopFront().(*Message)
+		m.mu.Unlock()
+
+		var err error
+		gutil.TryCatch(ctx, func(ctx context.Context) {
+			err = handler(ctx, message)
+		}, func(ctx context.Context, exception error) {
+			err = exception
+		})
+		if err == nil {
+			continue
+		}
+		intlog.Errorf(ctx, `handling message "%s" of topic "%s" failed: %+v`, message.Id, message.Topic, err)
+		// Redeliver the message to the same group after a while.
+		message.Attempt++
+		time.AfterFunc(memoryRedeliveryDelay, func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			if !m.closed {
+				list.PushBack(message)
+				m.cond.Broadcast()
+			}
+		})
+	}
+}
+
+// Close implements the interface Producer and Consumer.
+// It stops all subscriptions and waits for the messages being handled done.
+func (m *Memory) Close(ctx context.Context) error {
+	m.mu.Lock()
+	if !m.closed {
+		m.closed = true
+		close(m.closing)
+		m.cond.Broadcast()
+	}
+	m.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return gerror.WrapCode(gcode.CodeOperationFailed, ctx.Err(), `waiting for handling messages done failed`)
+	}
+}
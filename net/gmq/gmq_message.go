@@ -0,0 +1,108 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmq
+
+import (
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// Codec encodes and decodes the body of message.
+type Codec interface {
+	// Marshal encodes `value` to message body.
+	Marshal(value interface{}) ([]byte, error)
+
+	// Unmarshal decodes message body `data` to `pointer`.
+	Unmarshal(data []byte, pointer interface{}) error
+}
+
+// codecJson is the default Codec using json.
+type codecJson struct{}
+
+var defaultCodec Codec = codecJson{}
+
+// SetCodec sets the default Codec for message body, which is json in default.
+func SetCodec(codec Codec) {
+	if codec == nil {
+		panic(gerror.New(`invalid Codec value "nil" given`))
+	}
+	defaultCodec = codec
+}
+
+// GetCodec returns the default Codec for message body.
+func GetCodec() Codec {
+	return defaultCodec
+}
+
+// NewMessage creates and returns a message of `topic` with `value` as its body.
+// The `value` of type []byte or string is used as the body directly,
+// or else it is encoded by default Codec.
+func NewMessage(topic string, value interface{}) (*Message, error) {
+	message := &Message{
+		Id:        guid.S(),
+		Topic:     topic,
+		Header:    make(map[string]string),
+		Timestamp: time.Now(),
+	}
+	switch v := value.(type) {
+	case []byte:
+		message.Body = v
+	case string:
+		message.Body = []byte(v)
+	default:
+		body, err := defaultCodec.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		message.Body = body
+	}
+	return message, nil
+}
+
+// Scan decodes the body of message to `pointer` using default Codec.
+// The `pointer` of type *[]byte or *string receives the body directly.
+func (m *Message) Scan(pointer interface{}) error {
+	switch p := pointer.(type) {
+	case *[]byte:
+		*p = m.Body
+		return nil
+	case *string:
+		*p = string(m.Body)
+		return nil
+	}
+	return defaultCodec.Unmarshal(m.Body, pointer)
+}
+
+// Clone returns a copy of the message.
+func (m *Message) Clone() *Message {
+	message := *m
+	message.Header = make(map[string]string, len(m.Header))
+	for k, v := range m.Header {
+		message.Header[k] = v
+	}
+	return &message
+}
+
+// Marshal implements the interface Codec.
+func (codecJson) Marshal(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// Unmarshal implements the interface Codec.
+// It decodes the json into generic value first and then converts it to `pointer` using gconv,
+// which makes the loose type conversion between the fields of producer and consumer.
+func (codecJson) Unmarshal(data []byte, pointer interface{}) error {
+	var value interface{}
+	if err := json.UnmarshalUseNumber(data, &value); err != nil {
+		return err
+	}
+	return gconv.Scan(value, pointer)
+}
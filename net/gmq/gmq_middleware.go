@@ -0,0 +1,189 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmq
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/net/gtrace"
+	"github.com/gogf/gf/v2/os/glog"
+)
+
+const (
+	instrumentName          = "github.com/gogf/gf/v2/net/gmq"
+	tracingAttrMessageId    = "messaging.message.id"
+	tracingAttrMessageTopic = "messaging.destination.name"
+	tracingAttrMessageKey   = "messaging.message.key"
+	tracingAttrMessageRetry = "messaging.message.attempt"
+	defaultRetryCount       = 3
+	defaultRetryInterval    = time.Second
+	defaultRetryMaxInterval = time.Minute
+)
+
+// RetryOption is the option for MiddlewareRetry.
+type RetryOption struct {
+	// Count is the max retry count after the first failure, which is 3 in default.
+	Count int
+	// Interval is the base interval between retries, which is doubled for each retry and 1 second in default.
+	Interval time.Duration
+	// DeadLetter is the producer for dead letter messages.
+	// The message is redelivered by the broker if it is nil after all retries failed,
+	// or else it is published to the dead letter topic and acknowledged.
+	DeadLetter Producer
+	// DeadLetterTopic returns the dead letter topic of `topic`, which is `topic` + DeadLetterSuffix in default.
+	DeadLetterTopic func(topic string) string
+}
+
+// MiddlewareTracing is a middleware that enables tracing feature for consuming messages,
+// which continues the tracing context carried by message header from producer.
+// See NewProducerTracing for tracing producer.
+func MiddlewareTracing(next Handler) Handler {
+	return func(ctx context.Context, message *Message) error {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(message.Header))
+		ctx, span := tracer().Start(
+			ctx, message.Topic+" process", trace.WithSpanKind(trace.SpanKindConsumer),
+		)
+		defer span.End()
+		span.SetAttributes(gtrace.CommonLabels()...)
+		span.SetAttributes(messageAttributes(message)...)
+		err := next(ctx, message)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return err
+	}
+}
+
+// MiddlewareLogging returns a middleware that logs each handled message using `logger`.
+// The failed messages are logged in error level, and others in debug level.
+func MiddlewareLogging(logger glog.ILogger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, message *Message) error {
+			var (
+				start    = time.Now()
+				err      = next(ctx, message)
+				duration = time.Since(start)
+			)
+			if err != nil {
+				logger.Errorf(
+					ctx, `%s, %s, %d, %.3fms, %+v`,
+					message.Topic, message.Id, message.Attempt, float64(duration)/1e6, err,
+				)
+			} else {
+				logger.Debugf(
+					ctx, `%s, %s, %d, %.3fms`,
+					message.Topic, message.Id, message.Attempt, float64(duration)/1e6,
+				)
+			}
+			return err
+		}
+	}
+}
+
+// MiddlewareRetry returns a middleware that retries handling failed messages in place,
+// and publishes the messages that still fail to dead letter topic if RetryOption.DeadLetter is set.
+func MiddlewareRetry(option ...RetryOption) Middleware {
+	var opt RetryOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Count <= 0 {
+		opt.Count = defaultRetryCount
+	}
+	if opt.Interval <= 0 {
+		opt.Interval = defaultRetryInterval
+	}
+	if opt.DeadLetterTopic == nil {
+		opt.DeadLetterTopic = func(topic string) string {
+			return topic + DeadLetterSuffix
+		}
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, message *Message) (err error) {
+			interval := opt.Interval
+			for i := 0; ; i++ {
+				if err = next(ctx, message); err == nil || i >= opt.Count {
+					break
+				}
+				select {
+				case <-ctx.Done():
+					return err
+				case <-time.After(interval):
+				}
+				if interval *= 2; interval > defaultRetryMaxInterval {
+					interval = defaultRetryMaxInterval
+				}
+			}
+			if err == nil || opt.DeadLetter == nil {
+				return err
+			}
+			deadLetter := message.Clone()
+			deadLetter.Topic = opt.DeadLetterTopic(message.Topic)
+			deadLetter.Header[HeaderError] = err.Error()
+			deadLetter.Header[HeaderOriginalTopic] = message.Topic
+			// The message is redelivered if it fails publishing to dead letter topic.
+			return opt.DeadLetter.Publish(ctx, deadLetter)
+		}
+	}
+}
+
+// producerTracing is the Producer wrapper for tracing feature.
+type producerTracing struct {
+	Producer
+}
+
+// NewProducerTracing returns a Producer that enables tracing feature for `producer`,
+// which carries the tracing context in message header to consumers.
+func NewProducerTracing(producer Producer) Producer {
+	return &producerTracing{Producer: producer}
+}
+
+// Publish implements the interface Producer.
+func (p *producerTracing) Publish(ctx context.Context, messages ...*Message) error {
+	var spans = make([]trace.Span, 0, len(messages))
+	for _, message := range messages {
+		spanCtx, span := tracer().Start(
+			ctx, message.Topic+" publish", trace.WithSpanKind(trace.SpanKindProducer),
+		)
+		span.SetAttributes(gtrace.CommonLabels()...)
+		span.SetAttributes(messageAttributes(message)...)
+		if message.Header == nil {
+			message.Header = make(map[string]string)
+		}
+		otel.GetTextMapPropagator().Inject(spanCtx, propagation.MapCarrier(message.Header))
+		spans = append(spans, span)
+	}
+	err := p.Producer.Publish(ctx, messages...)
+	for _, span := range spans {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+	return err
+}
+
+func tracer() trace.Tracer {
+	return otel.GetTracerProvider().Tracer(instrumentName, trace.WithInstrumentationVersion(gf.VERSION))
+}
+
+func messageAttributes(message *Message) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String(tracingAttrMessageId, message.Id),
+		attribute.String(tracingAttrMessageTopic, message.Topic),
+		attribute.String(tracingAttrMessageKey, message.Key),
+		attribute.Int(tracingAttrMessageRetry, message.Attempt),
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gmq_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/net/gmq"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+type testOrder struct {
+	Id    int
+	Price float64
+	Items []string
+}
+
+func Test_Message_Codec(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		message, err := gmq.NewMessage("order", testOrder{Id: 1, Price: 9.9, Items: []string{"a"}})
+		t.AssertNil(err)
+		t.AssertNE(message.Id, "")
+		t.Assert(message.Topic, "order")
+		t.Assert(message.Body, `{"Id":1,"Price":9.9,"Items":["a"]}`)
+
+		var order *testOrder
+		t.AssertNil(message.Scan(&order))
+		t.Assert(order, testOrder{Id: 1, Price: 9.9, Items: []string{"a"}})
+
+		// Loose type conversion by gconv.
+		message.Body = []byte(`{"id":"2","price":"1.5"}`)
+		t.AssertNil(message.Scan(&order))
+		t.Assert(order.Id, 2)
+		t.Assert(order.Price, 1.5)
+
+		var s string
+		message, err = gmq.NewMessage("text", "hello")
+		t.AssertNil(err)
+		t.AssertNil(message.Scan(&s))
+		t.Assert(s, "hello")
+	})
+}
+
+func Test_Memory_ConsumerGroup(t *testing.T) {
+	var (
+		ctx    = gctx.New()
+		broker = gmq.NewMemory()
+		group1 = garray.NewStrArray(true)
+		group2 = garray.NewStrArray(true)
+	)
+	gtest.C(t, func(t *gtest.T) {
+		for i := 0; i < 2; i++ {
+			t.AssertNil(broker.Subscribe(ctx, "topic", "group1", func(ctx context.Context, message *gmq.Message) error {
+				group1.Append(string(message.Body))
+				return nil
+			}))
+		}
+		t.AssertNil(broker.Subscribe(ctx, "topic", "group2", func(ctx context.Context, message *gmq.Message) error {
+			group2.Append(string(message.Body))
+			return nil
+		}))
+		for _, body := range []string{"1", "2", "3"} {
+			message, _ := gmq.NewMessage("topic", body)
+			t.AssertNil(broker.Publish(ctx, message))
+		}
+		time.Sleep(100 * time.Millisecond)
+		t.AssertNil(broker.Close(ctx))
+		t.Assert(group1.Sort().Slice(), []string{"1", "2", "3"})
+		t.Assert(group2.Sort().Slice(), []string{"1", "2", "3"})
+
+		message, _ := gmq.NewMessage("topic", "4")
+		t.AssertNE(broker.Publish(ctx, message), nil)
+	})
+}
+
+func Test_Memory_Redelivery(t *testing.T) {
+	var (
+		ctx      = gctx.New()
+		broker   = gmq.NewMemory()
+		attempts = garray.NewIntArray(true)
+	)
+	defer broker.Close(ctx)
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(broker.Subscribe(ctx, "topic", "group", func(ctx context.Context, message *gmq.Message) error {
+			attempts.Append(message.Attempt)
+			if message.Attempt < 3 {
+				return errors.New("failed")
+			}
+			return nil
+		}))
+		message, _ := gmq.NewMessage("topic", "body")
+		t.AssertNil(broker.Publish(ctx, message))
+		time.Sleep(500 * time.Millisecond)
+		t.Assert(attempts.Slice(), []int{1, 2, 3})
+	})
+}
+
+func Test_Memory_GracefulShutdown(t *testing.T) {
+	var (
+		ctx     = gctx.New()
+		broker  = gmq.NewMemory()
+		handled = gtype.NewBool()
+		started = make(chan struct{})
+	)
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(broker.Subscribe(ctx, "topic", "group", func(ctx context.Context, message *gmq.Message) error {
+			close(started)
+			time.Sleep(200 * time.Millisecond)
+			handled.Set(true)
+			return nil
+		}))
+		message, _ := gmq.NewMessage("topic", "body")
+		t.AssertNil(broker.Publish(ctx, message))
+		<-started
+		t.AssertNil(broker.Close(ctx))
+		t.Assert(handled.Val(), true)
+	})
+}
+
+func Test_Middleware_RetryAndDeadLetter(t *testing.T) {
+	var (
+		ctx         = gctx.New()
+		broker      = gmq.NewMemory()
+		calls       = gtype.NewInt()
+		deadLetters = make(chan *gmq.Message, 1)
+	)
+	defer broker.Close(ctx)
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(broker.Subscribe(ctx, "topic.dlq", "group", func(ctx context.Context, message *gmq.Message) error {
+			deadLetters <- message
+			return nil
+		}))
+		handler := gmq.Chain(
+			func(ctx context.Context, message *gmq.Message) error {
+				calls.Add(1)
+				return errors.New("always failed")
+			},
+			gmq.MiddlewareTracing,
+			gmq.MiddlewareLogging(glog.New()),
+			gmq.MiddlewareRetry(gmq.RetryOption{
+				Count:      2,
+				Interval:   time.Millisecond,
+				DeadLetter: broker,
+			}),
+		)
+		t.AssertNil(broker.Subscribe(ctx, "topic", "group", handler))
+		message, _ := gmq.NewMessage("topic", "body")
+		t.AssertNil(gmq.NewProducerTracing(broker).Publish(ctx, message))
+		select {
+		case deadLetter := <-deadLetters:
+			t.Assert(calls.Val(), 3)
+			t.Assert(deadLetter.Id, message.Id)
+			t.Assert(deadLetter.Body, "body")
+			t.Assert(deadLetter.Header[gmq.HeaderError], "always failed")
+			t.Assert(deadLetter.Header[gmq.HeaderOriginalTopic], "topic")
+		case <-time.After(time.Second):
+			t.Error("dead letter timeout")
+		}
+	})
+}
+
+func Test_Chain(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			mu    sync.Mutex
+			order []string
+			mark  = func(name string) gmq.Middleware {
+				return func(next gmq.Handler) gmq.Handler {
+					return func(ctx context.Context, message *gmq.Message) error {
+						mu.Lock()
+						order = append(order, name)
+						mu.Unlock()
+						return next(ctx, message)
+					}
+				}
+			}
+		)
+		handler := gmq.Chain(func(ctx context.Context, message *gmq.Message) error {
+			order = append(order, "handler")
+			return nil
+		}, mark("1"), mark("2"))
+		t.AssertNil(handler(context.Background(), &gmq.Message{}))
+		t.Assert(order, []string{"1", "2", "handler"})
+	})
+}
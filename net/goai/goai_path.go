@@ -96,8 +96,11 @@ func (oai *OpenApiV3) addPath(in addPathInput) error {
 			Responses:   map[string]ResponseRef{},
 			XExtensions: make(XExtensions),
 		}
-		seRequirement = SecurityRequirement{}
+		responseOkStatus = gmeta.Get(outputObject.Interface(), gtag.Status).String()
 	)
+	if responseOkStatus == "" {
+		responseOkStatus = responseOkKey
+	}
 	// Path check.
 	if in.Path == "" {
 		in.Path = gmeta.Get(inputObject.Interface(), gtag.Path).String()
@@ -158,16 +161,13 @@ func (oai *OpenApiV3) addPath(in addPathInput) error {
 		}
 	}
 
-	// path security
-	// note: the security schema type only support http and apiKey;not support oauth2 and openIdConnect.
-	// multi schema separate with comma, e.g. `security: apiKey1,apiKey2`
-	TagNameSecurity := gmeta.Get(inputObject.Interface(), gtag.Security).String()
-	securities := gstr.SplitAndTrim(TagNameSecurity, ",")
-	for _, sec := range securities {
-		seRequirement[sec] = []string{}
-	}
-	if len(securities) > 0 {
-		operation.Security = &SecurityRequirements{seRequirement}
+	// Path security, which refers the security schemes in components.
+	// Multiple schemes separate with comma, and alternatives separate with '|',
+	// e.g. `security: apiKey1,apiKey2`, `security: bearer|oauth:read write`.
+	if securities := oai.tagValueToSecurityRequirements(
+		gmeta.Get(inputObject.Interface(), gtag.Security).String(),
+	); len(securities) > 0 {
+		operation.Security = &securities
 	}
 
 	// =================================================================================================================
@@ -223,9 +223,11 @@ func (oai *OpenApiV3) addPath(in addPathInput) error {
 				if err != nil {
 					return err
 				}
-				requestBody.Content[v] = MediaType{
+				mediaType := MediaType{
 					Schema: schemaRef,
 				}
+				oai.tagMapToMediaTypeExamples(oai.fillMapWithShortTags(inputMetaMap), &mediaType)
+				requestBody.Content[v] = mediaType
 			}
 		}
 		operation.RequestBody = &RequestBodyRef{
@@ -236,7 +238,7 @@ func (oai *OpenApiV3) addPath(in addPathInput) error {
 	// =================================================================================================================
 	// Response.
 	// =================================================================================================================
-	if _, ok := operation.Responses[responseOkKey]; !ok {
+	if _, ok := operation.Responses[responseOkStatus]; !ok {
 		var (
 			response = Response{
 				Content:     map[string]MediaType{},
@@ -271,11 +273,19 @@ func (oai *OpenApiV3) addPath(in addPathInput) error {
 			if err != nil {
 				return err
 			}
-			response.Content[v] = MediaType{
+			mediaType := MediaType{
 				Schema: schemaRef,
 			}
+			oai.tagMapToMediaTypeExamples(oai.fillMapWithShortTags(outputMetaMap), &mediaType)
+			response.Content[v] = mediaType
+		}
+		operation.Responses[responseOkStatus] = ResponseRef{Value: &response}
+	}
+	// Responses of other status codes declared by output struct.
+	if statusMap := oai.getEnhancedResponseStatus(outputObject); len(statusMap) > 0 {
+		if err := oai.addEnhancedResponses(statusMap, oai.Config.ReadContentTypes, &operation); err != nil {
+			return err
 		}
-		operation.Responses[responseOkKey] = ResponseRef{Value: &response}
 	}
 
 	// Remove operation body duplicated properties.
@@ -0,0 +1,124 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package goai
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/util/gconv"
+	"github.com/gogf/gf/v2/util/gtag"
+)
+
+// EnhancedStatusCode is the HTTP status code of declared response.
+type EnhancedStatusCode = int
+
+// EnhancedStatusType is the declared response of certain status code.
+type EnhancedStatusType struct {
+	Response    interface{} // Response object, whose type is added as schema. The response has no content if nil.
+	Description string      // Description of the response, which is the status text if empty.
+	Example     interface{} // Example of the response content.
+}
+
+// IEnhanceResponseStatus is the interface that the output struct of handler implements,
+// which declares the responses of status codes other than the success one, eg:
+//
+//	func (r *CreateRes) EnhanceResponseStatus() map[goai.EnhancedStatusCode]goai.EnhancedStatusType {
+//		return map[goai.EnhancedStatusCode]goai.EnhancedStatusType{
+//			404: {Response: NotFoundRes{}, Description: "user not found"},
+//			409: {Response: ConflictRes{}, Example: g.Map{"code": 409, "message": "user exists"}},
+//		}
+//	}
+type IEnhanceResponseStatus interface {
+	EnhanceResponseStatus() map[EnhancedStatusCode]EnhancedStatusType
+}
+
+// getEnhancedResponseStatus returns the declared responses of `object` if it implements IEnhanceResponseStatus.
+func (oai *OpenApiV3) getEnhancedResponseStatus(object reflect.Value) map[EnhancedStatusCode]EnhancedStatusType {
+	var target interface{}
+	if object.CanAddr() {
+		target = object.Addr().Interface()
+	} else {
+		target = object.Interface()
+	}
+	if v, ok := target.(IEnhanceResponseStatus); ok {
+		return v.EnhanceResponseStatus()
+	}
+	return nil
+}
+
+// addEnhancedResponses adds the declared responses of `statusMap` into `operation`,
+// which do not override the responses existing.
+func (oai *OpenApiV3) addEnhancedResponses(
+	statusMap map[EnhancedStatusCode]EnhancedStatusType, contentTypes []string, operation *Operation,
+) error {
+	codes := make([]int, 0, len(statusMap))
+	for code := range statusMap {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		var (
+			key    = gconv.String(code)
+			status = statusMap[code]
+		)
+		if _, ok := operation.Responses[key]; ok {
+			continue
+		}
+		response := Response{
+			Description: status.Description,
+			XExtensions: make(XExtensions),
+		}
+		if response.Description == "" {
+			response.Description = http.StatusText(code)
+		}
+		if status.Response != nil {
+			if err := oai.addSchema(status.Response); err != nil {
+				return err
+			}
+			response.Content = map[string]MediaType{}
+			schemaName := oai.golangTypeToSchemaName(reflect.TypeOf(status.Response))
+			for _, contentType := range contentTypes {
+				response.Content[contentType] = MediaType{
+					Schema:  &SchemaRef{Ref: schemaName},
+					Example: status.Example,
+				}
+			}
+		}
+		operation.Responses[key] = ResponseRef{Value: &response}
+	}
+	return nil
+}
+
+// tagMapToMediaTypeExamples sets the examples in `tagMap` into `mediaType`.
+// The `example` tag value is a single example, and the `examples` tag value is a JSON object of named examples.
+// The JSON tag value is decoded, or else it is used as string.
+func (oai *OpenApiV3) tagMapToMediaTypeExamples(tagMap map[string]string, mediaType *MediaType) {
+	if v := tagMap[gtag.Example]; v != "" {
+		mediaType.Example = oai.tagValueToExample(v)
+	}
+	if v := tagMap[gtag.Examples]; v != "" {
+		var examples map[string]interface{}
+		if err := json.UnmarshalUseNumber([]byte(v), &examples); err != nil {
+			return
+		}
+		mediaType.Examples = make(Examples)
+		for name, value := range examples {
+			mediaType.Examples[name] = &ExampleRef{Value: &Example{Value: value}}
+		}
+	}
+}
+
+func (oai *OpenApiV3) tagValueToExample(value string) interface{} {
+	var example interface{}
+	if err := json.UnmarshalUseNumber([]byte(value), &example); err != nil {
+		return value
+	}
+	return example
+}
@@ -8,6 +8,14 @@ package goai
 
 import (
 	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+const (
+	SecuritySchemeTypeHTTP          = `http`
+	SecuritySchemeTypeApiKey        = `apiKey`
+	SecuritySchemeTypeOAuth2        = `oauth2`
+	SecuritySchemeTypeOpenIdConnect = `openIdConnect`
 )
 
 type SecurityScheme struct {
@@ -46,6 +54,40 @@ type OAuthFlow struct {
 	Scopes           map[string]string `json:"scopes"`
 }
 
+// AddSecurityScheme adds security scheme `scheme` with `name` into components,
+// which is referred by the `security` tag in Meta of request struct.
+func (oai *OpenApiV3) AddSecurityScheme(name string, scheme SecurityScheme) {
+	if oai.Components.SecuritySchemes == nil {
+		oai.Components.SecuritySchemes = make(SecuritySchemes)
+	}
+	oai.Components.SecuritySchemes[name] = SecuritySchemeRef{Value: &scheme}
+}
+
+// tagValueToSecurityRequirements parses and returns the security requirements from `security` tag value.
+// The alternative requirements are separated by char '|', and the schemes required together are
+// separated by char ','. The scopes of scheme follow its name after char ':', separated by space, eg:
+// `security:"bearer"`, `security:"apiKey,appId"`, `security:"bearer|oauth:read write"`.
+func (oai *OpenApiV3) tagValueToSecurityRequirements(value string) SecurityRequirements {
+	var requirements SecurityRequirements
+	for _, alternative := range gstr.SplitAndTrim(value, "|") {
+		requirement := SecurityRequirement{}
+		for _, item := range gstr.SplitAndTrim(alternative, ",") {
+			var (
+				array  = gstr.SplitAndTrim(item, ":")
+				scopes = make([]string, 0)
+			)
+			if len(array) > 1 {
+				scopes = gstr.SplitAndTrim(array[1], " ")
+			}
+			requirement[array[0]] = scopes
+		}
+		if len(requirement) > 0 {
+			requirements = append(requirements, requirement)
+		}
+	}
+	return requirements
+}
+
 func (r SecuritySchemeRef) MarshalJSON() ([]byte, error) {
 	if r.Ref != "" {
 		return formatRefToBytes(r.Ref), nil
@@ -1223,3 +1223,83 @@ func Test_XExtension(t *testing.T) {
 		t.Assert(oai.String(), `{"openapi":"3.0.0","components":{"schemas":{"github.com.gogf.gf.v2.net.goai_test.GetListReq":{"properties":{"Page":{"default":1,"description":"Page number","format":"int","type":"integer","x-sort":"1"},"Size":{"default":10,"description":"Size for per page.","format":"int","type":"integer","x-sort":"2"}},"type":"object","x-group":"User/Info"}}},"info":{"title":"","version":""},"paths":null}`)
 	})
 }
+
+type testEnhancedStatusRes struct {
+	gmeta.Meta `status:"201" example:"{\"id\":1}"`
+	Id         int `json:"id"`
+}
+
+type testEnhancedStatusError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (r *testEnhancedStatusRes) EnhanceResponseStatus() map[goai.EnhancedStatusCode]goai.EnhancedStatusType {
+	return map[goai.EnhancedStatusCode]goai.EnhancedStatusType{
+		404: {Response: testEnhancedStatusError{}, Description: "user not found"},
+		409: {Response: testEnhancedStatusError{}, Example: g.Map{"code": 409, "message": "user exists"}},
+		500: {},
+	}
+}
+
+func Test_EnhancedResponse(t *testing.T) {
+	type Req struct {
+		gmeta.Meta `method:"POST" deprecated:"true" security:"bearer|oauth:read write,apiKey" examples:"{\"john\":{\"name\":\"john\"}}"`
+		Name       string `json:"name"`
+	}
+	f := func(ctx context.Context, req *Req) (res *testEnhancedStatusRes, err error) {
+		return
+	}
+
+	gtest.C(t, func(t *gtest.T) {
+		oai := goai.New()
+		oai.AddSecurityScheme("bearer", goai.SecurityScheme{
+			Type:         goai.SecuritySchemeTypeHTTP,
+			Scheme:       "bearer",
+			BearerFormat: "JWT",
+		})
+		oai.AddSecurityScheme("oauth", goai.SecurityScheme{
+			Type: goai.SecuritySchemeTypeOAuth2,
+			Flows: &goai.OAuthFlows{ClientCredentials: &goai.OAuthFlow{
+				TokenURL: "https://example.com/token",
+				Scopes:   map[string]string{"read": "Read", "write": "Write"},
+			}},
+		})
+		err := oai.Add(goai.AddInput{
+			Path:   "/user",
+			Object: f,
+		})
+		t.AssertNil(err)
+
+		operation := oai.Paths["/user"].Post
+		t.Assert(operation.Deprecated, true)
+		t.Assert(*operation.Security, goai.SecurityRequirements{
+			{"bearer": {}},
+			{"oauth": {"read", "write"}, "apiKey": {}},
+		})
+		t.Assert(len(oai.Components.SecuritySchemes), 2)
+
+		// Request examples.
+		requestContent := operation.RequestBody.Value.Content["application/json"]
+		t.Assert(requestContent.Examples["john"].Value.Value, g.Map{"name": "john"})
+
+		// Responses of status codes.
+		t.Assert(len(operation.Responses), 4)
+		_, ok := operation.Responses["200"]
+		t.Assert(ok, false)
+		t.Assert(operation.Responses["201"].Value.Content["application/json"].Example, g.Map{"id": 1})
+		t.Assert(operation.Responses["404"].Value.Description, "user not found")
+		t.Assert(
+			operation.Responses["404"].Value.Content["application/json"].Schema.Ref,
+			"github.com.gogf.gf.v2.net.goai_test.testEnhancedStatusError",
+		)
+		t.Assert(operation.Responses["409"].Value.Description, "Conflict")
+		t.Assert(operation.Responses["409"].Value.Content["application/json"].Example, g.Map{"code": 409, "message": "user exists"})
+		t.Assert(operation.Responses["500"].Value.Description, "Internal Server Error")
+		t.Assert(operation.Responses["500"].Value.Content, nil)
+		t.AssertNE(oai.Components.Schemas.Get("github.com.gogf.gf.v2.net.goai_test.testEnhancedStatusError"), nil)
+
+		var data g.Map
+		t.AssertNil(json.Unmarshal([]byte(oai.String()), &data))
+	})
+}
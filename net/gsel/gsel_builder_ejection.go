@@ -0,0 +1,34 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel
+
+import "fmt"
+
+type builderEjection struct {
+	builder Builder
+	config  []EjectionConfig
+}
+
+// NewBuilderEjection creates and returns a builder, which builds selectors of `builder`
+// that eject unhealthy nodes.
+//
+// It should be the outermost builder if it is used with NewBuilderSubset,
+// so that the ejected nodes are replaced by the other nodes in the subset.
+func NewBuilderEjection(builder Builder, config ...EjectionConfig) Builder {
+	return &builderEjection{
+		builder: builder,
+		config:  config,
+	}
+}
+
+func (b *builderEjection) Name() string {
+	return fmt.Sprintf("BalancerEjection(%s)", b.builder.Name())
+}
+
+func (b *builderEjection) Build() Selector {
+	return NewSelectorEjection(b.builder.Build(), b.config...)
+}
@@ -0,0 +1,31 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel
+
+import "fmt"
+
+type builderSubset struct {
+	builder Builder
+	size    int
+}
+
+// NewBuilderSubset creates and returns a builder, which builds selectors of `builder`
+// that select among at most `size` nodes.
+func NewBuilderSubset(builder Builder, size int) Builder {
+	return &builderSubset{
+		builder: builder,
+		size:    size,
+	}
+}
+
+func (b *builderSubset) Name() string {
+	return fmt.Sprintf("BalancerSubset(%s,%d)", b.builder.Name(), b.size)
+}
+
+func (b *builderSubset) Build() Selector {
+	return NewSelectorSubset(b.builder.Build(), b.size)
+}
@@ -0,0 +1,173 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+// EjectionConfig is the configuration for ejecting unhealthy nodes.
+type EjectionConfig struct {
+	// MaxFailures is the count of consecutive failures that ejects the node, which is 5 in default.
+	MaxFailures int
+	// BaseEjectionTime is the base duration of ejection, which is 30 seconds in default.
+	// The node is ejected for the duration that multiplied by its ejection count,
+	// and the ejection count is reset once the node succeeds.
+	BaseEjectionTime time.Duration
+	// MaxEjectionPercent is the max percent of nodes that can be ejected, which is 50 in default.
+	MaxEjectionPercent int
+}
+
+const (
+	defaultEjectionMaxFailures        = 5
+	defaultEjectionBaseEjectionTime   = 30 * time.Second
+	defaultEjectionMaxEjectionPercent = 50
+)
+
+// selectorEjection ejects the unhealthy nodes from the wrapped selector
+// according to the results of requests reported by DoneFunc.
+type selectorEjection struct {
+	mu       sync.Mutex
+	selector Selector
+	config   EjectionConfig
+	nodes    Nodes
+	states   map[string]*ejectionState // Node address => state.
+}
+
+type ejectionState struct {
+	failures     int       // Count of consecutive failures.
+	ejections    int       // Count of consecutive ejections.
+	ejectedUntil time.Time // Zero if the node is not ejected.
+}
+
+// NewSelectorEjection creates and returns a selector that ejects unhealthy nodes from `selector`.
+//
+// A node is considered unhealthy if it fails for EjectionConfig.MaxFailures times consecutively,
+// which is reported by the DoneFunc of Pick with DoneInfo.Err. It is ejected for a while and
+// then returned to `selector` automatically.
+func NewSelectorEjection(selector Selector, config ...EjectionConfig) Selector {
+	s := &selectorEjection{
+		selector: selector,
+		nodes:    make(Nodes, 0),
+		states:   make(map[string]*ejectionState),
+	}
+	if len(config) > 0 {
+		s.config = config[0]
+	}
+	if s.config.MaxFailures <= 0 {
+		s.config.MaxFailures = defaultEjectionMaxFailures
+	}
+	if s.config.BaseEjectionTime <= 0 {
+		s.config.BaseEjectionTime = defaultEjectionBaseEjectionTime
+	}
+	if s.config.MaxEjectionPercent <= 0 {
+		s.config.MaxEjectionPercent = defaultEjectionMaxEjectionPercent
+	}
+	return s
+}
+
+func (s *selectorEjection) Update(ctx context.Context, nodes Nodes) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	states := make(map[string]*ejectionState, len(nodes))
+	for _, node := range nodes {
+		if state, ok := s.states[node.Address()]; ok {
+			states[node.Address()] = state
+		} else {
+			states[node.Address()] = &ejectionState{}
+		}
+	}
+	s.nodes, s.states = nodes, states
+	return s.updateAvailableNodes(ctx)
+}
+
+func (s *selectorEjection) Pick(ctx context.Context) (node Node, done DoneFunc, err error) {
+	if err = s.restoreEjectedNodes(ctx); err != nil {
+		return nil, nil, err
+	}
+	node, innerDone, err := s.selector.Pick(ctx)
+	if err != nil || node == nil {
+		return node, innerDone, err
+	}
+	return node, func(ctx context.Context, di DoneInfo) {
+		if innerDone != nil {
+			innerDone(ctx, di)
+		}
+		s.report(ctx, node.Address(), di.Err)
+	}, nil
+}
+
+// restoreEjectedNodes returns the nodes whose ejection expire to the wrapped selector.
+func (s *selectorEjection) restoreEjectedNodes(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var (
+		now     = time.Now()
+		changed = false
+	)
+	for _, state := range s.states {
+		if !state.ejectedUntil.IsZero() && !now.Before(state.ejectedUntil) {
+			state.ejectedUntil = time.Time{}
+			state.failures = 0
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return s.updateAvailableNodes(ctx)
+}
+
+// report records the result of request to node `address`, and ejects the node if necessary.
+func (s *selectorEjection) report(ctx context.Context, address string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[address]
+	if !ok || !state.ejectedUntil.IsZero() {
+		return
+	}
+	if err == nil {
+		state.failures = 0
+		state.ejections = 0
+		return
+	}
+	state.failures++
+	if state.failures < s.config.MaxFailures {
+		return
+	}
+	ejected := 0
+	for _, v := range s.states {
+		if !v.ejectedUntil.IsZero() {
+			ejected++
+		}
+	}
+	if (ejected+1)*100 > len(s.nodes)*s.config.MaxEjectionPercent {
+		return
+	}
+	state.failures = 0
+	state.ejections++
+	state.ejectedUntil = time.Now().Add(s.config.BaseEjectionTime * time.Duration(state.ejections))
+	intlog.Printf(ctx, `Eject node "%s" until %s`, address, state.ejectedUntil)
+	if err = s.updateAvailableNodes(ctx); err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+	}
+}
+
+// updateAvailableNodes updates the nodes that are not ejected into the wrapped selector.
+func (s *selectorEjection) updateAvailableNodes(ctx context.Context) error {
+	available := make(Nodes, 0, len(s.nodes))
+	for _, node := range s.nodes {
+		if s.states[node.Address()].ejectedUntil.IsZero() {
+			available = append(available, node)
+		}
+	}
+	return s.selector.Update(ctx, available)
+}
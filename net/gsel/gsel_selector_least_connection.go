@@ -49,6 +49,9 @@ func (s *selectorLeastConnection) Update(ctx context.Context, nodes Nodes) error
 func (s *selectorLeastConnection) Pick(ctx context.Context) (node Node, done DoneFunc, err error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	if len(s.nodes) == 0 {
+		return nil, nil, nil
+	}
 	var pickedNode *leastConnectionNode
 	if len(s.nodes) == 1 {
 		pickedNode = s.nodes[0]
@@ -0,0 +1,61 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsel
+
+import (
+	"context"
+	"sort"
+
+	"github.com/gogf/gf/v2/encoding/ghash"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// selectorSubset limits the nodes of wrapped selector to a subset of all nodes,
+// which reduces the connections between clients and large scale services.
+type selectorSubset struct {
+	selector Selector
+	size     int
+	seed     uint64
+}
+
+// NewSelectorSubset creates and returns a selector that updates only `size` nodes into `selector`.
+//
+// The subset is chosen using rendezvous hashing with a random seed of the selector,
+// so that different selectors choose different subsets, and the subset changes minimally
+// when the nodes are updated.
+func NewSelectorSubset(selector Selector, size int) Selector {
+	return &selectorSubset{
+		selector: selector,
+		size:     size,
+		seed:     uint64(grand.N(0, 1<<31)),
+	}
+}
+
+func (s *selectorSubset) Update(ctx context.Context, nodes Nodes) error {
+	if s.size <= 0 || len(nodes) <= s.size {
+		return s.selector.Update(ctx, nodes)
+	}
+	var (
+		subset = make(Nodes, len(nodes))
+		scores = make(map[Node]uint64, len(nodes))
+	)
+	copy(subset, nodes)
+	for _, node := range nodes {
+		scores[node] = ghash.XXH64([]byte(node.Address()), s.seed)
+	}
+	sort.SliceStable(subset, func(i, j int) bool {
+		return scores[subset[i]] < scores[subset[j]]
+	})
+	subset = subset[:s.size]
+	intlog.Printf(ctx, `Update subset nodes: %s`, subset.String())
+	return s.selector.Update(ctx, subset)
+}
+
+func (s *selectorSubset) Pick(ctx context.Context) (node Node, done DoneFunc, err error) {
+	return s.selector.Pick(ctx)
+}
@@ -52,6 +52,11 @@ func (s *selectorWeight) Pick(ctx context.Context) (node Node, done DoneFunc, er
 	return node, nil, nil
 }
 
+// getWeight returns the weight of `node`, which is 1 if the weight is not set in metadata.
 func (s *selectorWeight) getWeight(node Node) int {
-	return node.Service().GetMetadata().Get(gsvc.MDWeight).Int()
+	weight := node.Service().GetMetadata().Get(gsvc.MDWeight)
+	if weight == nil {
+		return 1
+	}
+	return weight.Int()
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/internal/command"
+	"github.com/gogf/gf/v2/internal/intlog"
 	"github.com/gogf/gf/v2/net/gipv4"
 	"github.com/gogf/gf/v2/net/gtrace/internal/provider"
 	"github.com/gogf/gf/v2/text/gstr"
@@ -55,7 +56,23 @@ func init() {
 		tracingMaxContentLogSize = maxContentLogSize
 	}
 	// Default trace provider.
-	otel.SetTracerProvider(provider.New())
+	otel.SetTracerProvider(provider.New(GetSampler()))
+	// Propagators from command line or environment configuration.
+	if names := command.GetOptWithEnv(commandEnvKeyForPropagators); names != "" {
+		if err := SetPropagators(gstr.SplitAndTrim(names, ",")...); err != nil {
+			intlog.Errorf(context.Background(), `%+v`, err)
+		}
+	}
+	// Sampler from command line or environment configuration.
+	if name := command.GetOptWithEnv(commandEnvKeyForSampler); name != "" {
+		err := SetSamplerWithConfig(SamplerConfig{
+			Name:  name,
+			Ratio: gconv.Float64(command.GetOptWithEnv(commandEnvKeyForSamplerRatio)),
+		})
+		if err != nil {
+			intlog.Errorf(context.Background(), `%+v`, err)
+		}
+	}
 	CheckSetDefaultTextMapPropagator()
 }
 
@@ -0,0 +1,106 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// Builtin propagator names that can be used for propagator selection.
+const (
+	PropagatorTraceContext = "tracecontext" // W3C Trace Context.
+	PropagatorBaggage      = "baggage"      // W3C Baggage.
+	PropagatorB3           = "b3"           // B3 single header format.
+	PropagatorB3Multi      = "b3multi"      // B3 multiple headers format.
+	PropagatorJaeger       = "jaeger"       // Jaeger "uber-trace-id" header format.
+)
+
+const (
+	// PropagatorHeaderPrefix is the name prefix of the propagator using custom header,
+	// eg: "header:X-Request-Id", see NewHeaderPropagator.
+	PropagatorHeaderPrefix = "header:"
+)
+
+const (
+	commandEnvKeyForPropagators = "gf.gtrace.propagators" // Comma separated propagator names, eg: tracecontext,baggage,b3.
+)
+
+var (
+	// propagatorMap stores all registered propagators by their names.
+	propagatorMap = map[string]propagation.TextMapPropagator{
+		PropagatorTraceContext: propagation.TraceContext{},
+		PropagatorBaggage:      propagation.Baggage{},
+		PropagatorB3:           b3Propagator{singleHeader: true},
+		PropagatorB3Multi:      b3Propagator{singleHeader: false},
+		PropagatorJaeger:       jaegerPropagator{},
+	}
+	// propagatorMu is the mutex for propagatorMap.
+	propagatorMu sync.RWMutex
+)
+
+// RegisterPropagator registers custom propagator with given name,
+// which can be selected by name using SetPropagators or configuration.
+// It overwrites the propagator if the name is already registered.
+func RegisterPropagator(name string, propagator propagation.TextMapPropagator) {
+	propagatorMu.Lock()
+	defer propagatorMu.Unlock()
+	propagatorMap[gstr.ToLower(gstr.Trim(name))] = propagator
+}
+
+// GetPropagator retrieves and returns the registered propagator by name.
+// The name with prefix PropagatorHeaderPrefix like "header:X-Request-Id" returns the propagator
+// using the custom header. It returns nil if no propagator registered with given name.
+func GetPropagator(name string) propagation.TextMapPropagator {
+	name = gstr.Trim(name)
+	if len(name) > len(PropagatorHeaderPrefix) && gstr.HasPrefix(gstr.ToLower(name), PropagatorHeaderPrefix) {
+		return NewHeaderPropagator(gstr.Trim(name[len(PropagatorHeaderPrefix):]))
+	}
+	propagatorMu.RLock()
+	defer propagatorMu.RUnlock()
+	return propagatorMap[gstr.ToLower(gstr.Trim(name))]
+}
+
+// NewPropagator creates and returns a composite propagator combined with propagators of given names.
+// The order of names matters, as the latter propagator overwrites the extracted span context of the former one.
+func NewPropagator(names ...string) (propagation.TextMapPropagator, error) {
+	var propagators = make([]propagation.TextMapPropagator, 0, len(names))
+	for _, name := range names {
+		if name = gstr.Trim(name); name == "" {
+			continue
+		}
+		propagator := GetPropagator(name)
+		if propagator == nil {
+			return nil, gerror.NewCodef(
+				gcode.CodeInvalidParameter,
+				`propagator "%s" is not registered`,
+				name,
+			)
+		}
+		propagators = append(propagators, propagator)
+	}
+	return propagation.NewCompositeTextMapPropagator(propagators...), nil
+}
+
+// SetPropagators combines propagators of given names and sets it as the global TextMapPropagator.
+//
+// Example:
+// SetPropagators("tracecontext", "baggage", "b3").
+func SetPropagators(names ...string) error {
+	propagator, err := NewPropagator(names...)
+	if err != nil {
+		return err
+	}
+	otel.SetTextMapPropagator(propagator)
+	return nil
+}
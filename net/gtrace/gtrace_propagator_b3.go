@@ -0,0 +1,139 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	b3HeaderSingle       = "b3"
+	b3HeaderTraceID      = "x-b3-traceid"
+	b3HeaderSpanID       = "x-b3-spanid"
+	b3HeaderSampled      = "x-b3-sampled"
+	b3HeaderFlags        = "x-b3-flags"
+	b3HeaderParentSpanID = "x-b3-parentspanid"
+	b3SampledValue       = "1"
+	b3NotSampledValue    = "0"
+	b3DebugValue         = "d"
+)
+
+// b3Propagator propagates span context in B3 format, which is used by Zipkin.
+// It injects span context using single header "b3" if `singleHeader` is true,
+// or else using multiple "x-b3-*" headers. It extracts span context from both formats.
+//
+// See https://github.com/openzipkin/b3-propagation.
+type b3Propagator struct {
+	singleHeader bool
+}
+
+var _ propagation.TextMapPropagator = b3Propagator{}
+
+// Inject sets B3 headers from `ctx` into the `carrier`.
+func (p b3Propagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	sampled := b3NotSampledValue
+	if sc.IsSampled() {
+		sampled = b3SampledValue
+	}
+	if p.singleHeader {
+		carrier.Set(b3HeaderSingle, strings.Join([]string{
+			sc.TraceID().String(), sc.SpanID().String(), sampled,
+		}, "-"))
+		return
+	}
+	carrier.Set(b3HeaderTraceID, sc.TraceID().String())
+	carrier.Set(b3HeaderSpanID, sc.SpanID().String())
+	carrier.Set(b3HeaderSampled, sampled)
+}
+
+// Extract reads B3 headers from the `carrier` into a returned Context.
+// It returns `ctx` unchanged if no valid B3 headers found.
+func (p b3Propagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	var (
+		sc trace.SpanContext
+		ok bool
+	)
+	if sc, ok = p.extractSingle(carrier.Get(b3HeaderSingle)); !ok {
+		if sc, ok = p.extractMulti(carrier); !ok {
+			return ctx
+		}
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// Fields returns the keys whose values are set with Inject.
+func (p b3Propagator) Fields() []string {
+	if p.singleHeader {
+		return []string{b3HeaderSingle}
+	}
+	return []string{b3HeaderTraceID, b3HeaderSpanID, b3HeaderSampled}
+}
+
+// extractSingle parses the single header value in format:
+// {TraceId}-{SpanId}-{SamplingState}-{ParentSpanId}
+// in which the last two fields are optional.
+func (p b3Propagator) extractSingle(value string) (sc trace.SpanContext, ok bool) {
+	if value == "" {
+		return
+	}
+	var parts = strings.Split(value, "-")
+	if len(parts) < 2 || len(parts) > 4 {
+		return
+	}
+	var sampling string
+	if len(parts) > 2 {
+		sampling = parts[2]
+	}
+	return newRemoteSpanContext(parts[0], parts[1], sampling == b3SampledValue || sampling == b3DebugValue)
+}
+
+// extractMulti parses the multiple "x-b3-*" headers.
+func (p b3Propagator) extractMulti(carrier propagation.TextMapCarrier) (sc trace.SpanContext, ok bool) {
+	var (
+		sampled = carrier.Get(b3HeaderSampled)
+		flags   = carrier.Get(b3HeaderFlags)
+	)
+	return newRemoteSpanContext(
+		carrier.Get(b3HeaderTraceID),
+		carrier.Get(b3HeaderSpanID),
+		sampled == b3SampledValue || strings.EqualFold(sampled, "true") || flags == b3SampledValue,
+	)
+}
+
+// newRemoteSpanContext creates a remote span context from hex trace id and span id.
+// The 64 bits trace id or span id shorter than 16 characters are left padded with zeros.
+func newRemoteSpanContext(traceIDHex, spanIDHex string, sampled bool) (sc trace.SpanContext, ok bool) {
+	if traceIDHex == "" || spanIDHex == "" || len(traceIDHex) > 32 || len(spanIDHex) > 16 {
+		return
+	}
+	traceID, err := trace.TraceIDFromHex(strings.Repeat("0", 32-len(traceIDHex)) + traceIDHex)
+	if err != nil {
+		return
+	}
+	spanID, err := trace.SpanIDFromHex(strings.Repeat("0", 16-len(spanIDHex)) + spanIDHex)
+	if err != nil {
+		return
+	}
+	config := trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+		Remote:  true,
+	}
+	if sampled {
+		config.TraceFlags = trace.FlagsSampled
+	}
+	sc = trace.NewSpanContext(config)
+	return sc, sc.IsValid()
+}
@@ -0,0 +1,70 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+// headerPropagator propagates only the trace id using a custom header.
+type headerPropagator struct {
+	header string
+}
+
+var _ propagation.TextMapPropagator = headerPropagator{}
+
+// NewHeaderPropagator creates and returns a propagator that propagates trace id using custom `header`,
+// eg: "X-Request-Id". The header value should be a 32 characters hex string or an UUID string.
+//
+// As there's only trace id in the header, a random span id is generated as the remote parent span id
+// for extracting. It is usually registered using RegisterPropagator and combined with other propagators.
+func NewHeaderPropagator(header string) propagation.TextMapPropagator {
+	return headerPropagator{
+		header: header,
+	}
+}
+
+// Inject sets the trace id from `ctx` into the `carrier` using custom header.
+func (p headerPropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	carrier.Set(p.header, sc.TraceID().String())
+}
+
+// Extract reads the trace id of custom header from the `carrier` into a returned Context.
+// It returns `ctx` unchanged if no valid trace id found.
+func (p headerPropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	value := gstr.Replace(carrier.Get(p.header), "-", "")
+	if value == "" {
+		return ctx
+	}
+	traceID, err := trace.TraceIDFromHex(value)
+	if err != nil {
+		return ctx
+	}
+	var spanID trace.SpanID
+	copy(spanID[:], grand.B(len(spanID)))
+	return trace.ContextWithRemoteSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+}
+
+// Fields returns the keys whose values are set with Inject.
+func (p headerPropagator) Fields() []string {
+	return []string{p.header}
+}
@@ -0,0 +1,76 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	jaegerHeader      = "uber-trace-id"
+	jaegerFlagSampled = 0x01
+)
+
+// jaegerPropagator propagates span context in Jaeger format using header:
+// uber-trace-id: {trace-id}:{span-id}:{parent-span-id}:{flags}
+//
+// See https://www.jaegertracing.io/docs/client-libraries/#propagation-format.
+type jaegerPropagator struct{}
+
+var _ propagation.TextMapPropagator = jaegerPropagator{}
+
+// Inject sets Jaeger header from `ctx` into the `carrier`.
+func (p jaegerPropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	var flags = 0
+	if sc.IsSampled() {
+		flags |= jaegerFlagSampled
+	}
+	carrier.Set(jaegerHeader, fmt.Sprintf(
+		`%s:%s:0:%x`, sc.TraceID().String(), sc.SpanID().String(), flags,
+	))
+}
+
+// Extract reads Jaeger header from the `carrier` into a returned Context.
+// It returns `ctx` unchanged if no valid Jaeger header found.
+func (p jaegerPropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	value := carrier.Get(jaegerHeader)
+	if value == "" {
+		return ctx
+	}
+	if unescaped, err := url.QueryUnescape(value); err == nil {
+		value = unescaped
+	}
+	parts := strings.Split(value, ":")
+	if len(parts) != 4 {
+		return ctx
+	}
+	flags, err := strconv.ParseInt(parts[3], 16, 64)
+	if err != nil {
+		return ctx
+	}
+	sc, ok := newRemoteSpanContext(parts[0], parts[1], flags&jaegerFlagSampled == jaegerFlagSampled)
+	if !ok {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// Fields returns the keys whose values are set with Inject.
+func (p jaegerPropagator) Fields() []string {
+	return []string{jaegerHeader}
+}
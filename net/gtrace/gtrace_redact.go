@@ -0,0 +1,181 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// RedactMask is the mask replacing the sensitive data in tracing content.
+	RedactMask = "******"
+
+	// RedactPatternCardNumber is the pattern of bank card numbers for AddRedactPattern.
+	RedactPatternCardNumber = `\b(?:\d[ -]?){12,18}\d\b`
+)
+
+// RedactHook is the hook redacting the tracing content, which is called after the builtin redaction
+// of fields and patterns. The `content` is a header value, URL or body recorded by tracing middlewares.
+type RedactHook func(ctx context.Context, content string) string
+
+// redactor masks the sensitive data in tracing content.
+type redactor struct {
+	fields      map[string]struct{} // Lower case names of sensitive fields.
+	fieldsRegex *regexp.Regexp      // Matches values of fields in JSON, form and URL query content.
+	patterns    []*regexp.Regexp    // Patterns of sensitive data.
+	hooks       []RedactHook        // Custom redaction hooks.
+}
+
+var (
+	// currentRedactor is replaced but never modified, so that it is read without lock.
+	currentRedactor = &redactor{}
+	redactorMu      sync.RWMutex
+)
+
+// AddRedactFields adds names of sensitive fields, eg: "Authorization", "password", whose values are masked
+// in the tracing headers and the JSON, form or URL query content of tracing bodies. The names are case-insensitive.
+func AddRedactFields(names ...string) {
+	_ = updateRedactor(func(r *redactor) error {
+		for _, name := range names {
+			if name = strings.TrimSpace(name); name != "" {
+				r.fields[strings.ToLower(name)] = struct{}{}
+			}
+		}
+		if len(r.fields) == 0 {
+			return nil
+		}
+		quoted := make([]string, 0, len(r.fields))
+		for name := range r.fields {
+			quoted = append(quoted, regexp.QuoteMeta(name))
+		}
+		var (
+			names = `(?i:` + strings.Join(quoted, "|") + `)`
+			json  = `("` + names + `"\s*:\s*)(?:"(?:[^"\\]|\\.)*"|[^,}\]\s]+)`
+			form  = `((?:^|[?&;\s])` + names + `=)[^&;\s]*`
+		)
+		r.fieldsRegex = regexp.MustCompile(json + `|` + form)
+		return nil
+	})
+}
+
+// AddRedactPattern adds regular expression `pattern` of sensitive data, eg: RedactPatternCardNumber,
+// whose matched parts are masked in tracing headers and bodies.
+func AddRedactPattern(pattern string) error {
+	return updateRedactor(func(r *redactor) error {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return gerror.WrapCodef(gcode.CodeInvalidParameter, err, `invalid redaction pattern "%s"`, pattern)
+		}
+		r.patterns = append(r.patterns, re)
+		return nil
+	})
+}
+
+// AddRedactHook adds custom hook redacting tracing content.
+func AddRedactHook(hook RedactHook) {
+	_ = updateRedactor(func(r *redactor) error {
+		r.hooks = append(r.hooks, hook)
+		return nil
+	})
+}
+
+// ResetRedaction removes all redaction fields, patterns and hooks.
+func ResetRedaction() {
+	redactorMu.Lock()
+	defer redactorMu.Unlock()
+	currentRedactor = &redactor{}
+}
+
+// RedactHeader masks the values of sensitive fields in `header`, and the sensitive data in other values.
+// It modifies and returns `header`, which is converted from http.Header by tracing middlewares.
+func RedactHeader(ctx context.Context, header map[string]interface{}) map[string]interface{} {
+	r := getRedactor()
+	if r.isEmpty() {
+		return header
+	}
+	for k, v := range header {
+		if _, ok := r.fields[strings.ToLower(k)]; ok {
+			header[k] = RedactMask
+			continue
+		}
+		switch value := v.(type) {
+		case string:
+			header[k] = r.redact(ctx, value)
+		case []string:
+			values := make([]string, len(value))
+			for i, item := range value {
+				values[i] = r.redact(ctx, item)
+			}
+			header[k] = values
+		}
+	}
+	return header
+}
+
+// RedactContent masks the sensitive data in `content`, which is the URL or body of tracing.
+func RedactContent(ctx context.Context, content string) string {
+	r := getRedactor()
+	if r.isEmpty() || content == "" {
+		return content
+	}
+	return r.redact(ctx, content)
+}
+
+func getRedactor() *redactor {
+	redactorMu.RLock()
+	defer redactorMu.RUnlock()
+	return currentRedactor
+}
+
+// updateRedactor updates a copy of current redactor using `f` and replaces current redactor with the copy.
+func updateRedactor(f func(r *redactor) error) error {
+	redactorMu.Lock()
+	defer redactorMu.Unlock()
+	r := &redactor{
+		fields:      make(map[string]struct{}, len(currentRedactor.fields)),
+		fieldsRegex: currentRedactor.fieldsRegex,
+		patterns:    append([]*regexp.Regexp{}, currentRedactor.patterns...),
+		hooks:       append([]RedactHook{}, currentRedactor.hooks...),
+	}
+	for k := range currentRedactor.fields {
+		r.fields[k] = struct{}{}
+	}
+	if err := f(r); err != nil {
+		return err
+	}
+	currentRedactor = r
+	return nil
+}
+
+func (r *redactor) isEmpty() bool {
+	return r.fieldsRegex == nil && len(r.patterns) == 0 && len(r.hooks) == 0
+}
+
+func (r *redactor) redact(ctx context.Context, content string) string {
+	if r.fieldsRegex != nil {
+		content = r.fieldsRegex.ReplaceAllStringFunc(content, func(s string) string {
+			match := r.fieldsRegex.FindStringSubmatch(s)
+			if match[1] != "" {
+				return match[1] + `"` + RedactMask + `"`
+			}
+			return match[2] + RedactMask
+		})
+	}
+	for _, pattern := range r.patterns {
+		content = pattern.ReplaceAllString(content, RedactMask)
+	}
+	for _, hook := range r.hooks {
+		content = hook(ctx, content)
+	}
+	return content
+}
@@ -0,0 +1,234 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/gcfg"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+// Builtin sampler names, which are the same as the values of OTEL_TRACES_SAMPLER of OpenTelemetry.
+const (
+	SamplerAlwaysOn                = "always_on"                // Samples all spans.
+	SamplerAlwaysOff               = "always_off"               // Samples no span.
+	SamplerTraceIDRatio            = "traceidratio"             // Samples spans by ratio of trace id.
+	SamplerParentBasedAlwaysOn     = "parentbased_always_on"    // Follows parent, or else samples all root spans.
+	SamplerParentBasedAlwaysOff    = "parentbased_always_off"   // Follows parent, or else samples no root span.
+	SamplerParentBasedTraceIDRatio = "parentbased_traceidratio" // Follows parent, or else samples root spans by ratio.
+)
+
+const (
+	commandEnvKeyForSampler      = "gf.gtrace.sampler"       // Builtin sampler name, eg: parentbased_traceidratio.
+	commandEnvKeyForSamplerRatio = "gf.gtrace.sampler.ratio" // Sampling ratio for ratio based sampler, eg: 0.01.
+	configNodeNameSampler        = "tracing.sampler"         // Configuration node name of SamplerConfig.
+)
+
+// SamplerConfig is the sampler configuration, which can be read from configuration file, eg:
+//
+//	tracing:
+//	  sampler:
+//	    name:  "parentbased_traceidratio"
+//	    ratio: 0.01
+//	    rules:
+//	    - name:  "GET /healthz"
+//	      ratio: 0
+//	    - name:  "POST /order/*"
+//	      ratio: 1
+type SamplerConfig struct {
+	Name  string        `json:"name"`  // Builtin sampler name, which is SamplerParentBasedAlwaysOn in default.
+	Ratio float64       `json:"ratio"` // Sampling ratio from 0 to 1 for the ratio based samplers.
+	Rules []SamplerRule `json:"rules"` // Sampling rules by span names, which take precedence over the Name sampler.
+}
+
+// SamplerRule specifies the sampling ratio for the spans whose names match Name.
+type SamplerRule struct {
+	Name  string  `json:"name"`  // Span name pattern, in which "*" matches any characters, eg: "GET /user/*".
+	Ratio float64 `json:"ratio"` // Sampling ratio from 0 to 1.
+}
+
+// samplerHolder holds the sampler in atomic.Value, as it requires the same concrete type for all values.
+type samplerHolder struct {
+	sdkTrace.Sampler
+}
+
+// globalSampler is the sampler delegating to the sampler set by SetSampler.
+type globalSampler struct{}
+
+// ruleSampler samples spans using the sampler of the first rule matching the span name.
+type ruleSampler struct {
+	rules    []ruleSamplerItem
+	fallback sdkTrace.Sampler
+}
+
+type ruleSamplerItem struct {
+	pattern *regexp.Regexp
+	sampler sdkTrace.Sampler
+}
+
+var (
+	// currentSampler stores the sampler used by globalSampler,
+	// which is initialized before the package init function setting sampler from configuration.
+	currentSampler = newSamplerValue()
+	// samplerSet marks whether the sampler is set by SetSampler, other than the default one.
+	samplerSet = gtype.NewBool()
+)
+
+func newSamplerValue() *atomic.Value {
+	v := &atomic.Value{}
+	v.Store(samplerHolder{sdkTrace.ParentBased(sdkTrace.AlwaysSample())})
+	return v
+}
+
+// GetSampler returns the global sampler delegating to the sampler set by SetSampler,
+// which should be used for creating TracerProvider, so that the sampler can be changed at runtime, eg:
+//
+//	sdkTrace.NewTracerProvider(sdkTrace.WithSampler(gtrace.GetSampler()))
+//
+// It is parent based and samples all root spans in default.
+func GetSampler() sdkTrace.Sampler {
+	return globalSampler{}
+}
+
+// GetSamplerWithDefault returns the global sampler if it is configured using SetSampler, command line,
+// environment, or the configuration node "tracing.sampler" of gcfg, or else it returns `defaultSampler`.
+// It is commonly used by the tracing exporters creating TracerProvider, which have their own default sampler.
+func GetSamplerWithDefault(ctx context.Context, defaultSampler sdkTrace.Sampler) (sdkTrace.Sampler, error) {
+	if samplerSet.Val() {
+		return GetSampler(), nil
+	}
+	if cfg := gcfg.Instance(); cfg.Available(ctx) {
+		v, err := cfg.Get(ctx, configNodeNameSampler)
+		if err != nil {
+			return nil, err
+		}
+		if !v.IsEmpty() {
+			var config SamplerConfig
+			if err = v.Scan(&config); err != nil {
+				return nil, err
+			}
+			if err = SetSamplerWithConfig(config); err != nil {
+				return nil, err
+			}
+			return GetSampler(), nil
+		}
+	}
+	return defaultSampler, nil
+}
+
+// SetSampler sets the sampler for the global sampler.
+// It resets the global sampler to the default one if `sampler` is nil.
+func SetSampler(sampler sdkTrace.Sampler) {
+	samplerSet.Set(sampler != nil)
+	if sampler == nil {
+		sampler = sdkTrace.ParentBased(sdkTrace.AlwaysSample())
+	}
+	currentSampler.Store(samplerHolder{sampler})
+}
+
+// SetSamplerRatio sets the global sampler sampling root spans by `ratio` from 0 to 1,
+// and the other spans following their parents.
+func SetSamplerRatio(ratio float64) {
+	SetSampler(sdkTrace.ParentBased(sdkTrace.TraceIDRatioBased(ratio)))
+}
+
+// SetSamplerWithConfig creates sampler using `config` and sets it for the global sampler.
+func SetSamplerWithConfig(config SamplerConfig) error {
+	sampler, err := NewSampler(config)
+	if err != nil {
+		return err
+	}
+	SetSampler(sampler)
+	return nil
+}
+
+// NewSampler creates and returns a sampler using `config`.
+// The rules apply only to the root spans if the sampler of config Name is parent based.
+func NewSampler(config SamplerConfig) (sdkTrace.Sampler, error) {
+	var (
+		root        sdkTrace.Sampler
+		parentBased = true
+	)
+	switch gstr.ToLower(gstr.Trim(config.Name)) {
+	case "", SamplerParentBasedAlwaysOn:
+		root = sdkTrace.AlwaysSample()
+	case SamplerParentBasedAlwaysOff:
+		root = sdkTrace.NeverSample()
+	case SamplerParentBasedTraceIDRatio:
+		root = sdkTrace.TraceIDRatioBased(config.Ratio)
+	case SamplerAlwaysOn:
+		root, parentBased = sdkTrace.AlwaysSample(), false
+	case SamplerAlwaysOff:
+		root, parentBased = sdkTrace.NeverSample(), false
+	case SamplerTraceIDRatio:
+		root, parentBased = sdkTrace.TraceIDRatioBased(config.Ratio), false
+	default:
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `sampler "%s" is not supported`, config.Name)
+	}
+	if len(config.Rules) > 0 {
+		root = NewRuleSampler(config.Rules, root)
+	}
+	if parentBased {
+		return sdkTrace.ParentBased(root), nil
+	}
+	return root, nil
+}
+
+// NewRuleSampler creates and returns a sampler sampling spans using the ratio of the first rule matching
+// the span name, and using `fallback` if no rule matches. The span names of ghttp server are like "GET /user/{id}".
+func NewRuleSampler(rules []SamplerRule, fallback sdkTrace.Sampler) sdkTrace.Sampler {
+	if fallback == nil {
+		fallback = sdkTrace.AlwaysSample()
+	}
+	sampler := &ruleSampler{
+		rules:    make([]ruleSamplerItem, 0, len(rules)),
+		fallback: fallback,
+	}
+	for _, rule := range rules {
+		pattern := strings.ReplaceAll(regexp.QuoteMeta(rule.Name), `\*`, `.*`)
+		sampler.rules = append(sampler.rules, ruleSamplerItem{
+			pattern: regexp.MustCompile(`^` + pattern + `$`),
+			sampler: sdkTrace.TraceIDRatioBased(rule.Ratio),
+		})
+	}
+	return sampler
+}
+
+// ShouldSample implements the interface sdkTrace.Sampler.
+func (globalSampler) ShouldSample(p sdkTrace.SamplingParameters) sdkTrace.SamplingResult {
+	return currentSampler.Load().(samplerHolder).ShouldSample(p)
+}
+
+// Description implements the interface sdkTrace.Sampler.
+func (globalSampler) Description() string {
+	return currentSampler.Load().(samplerHolder).Description()
+}
+
+// ShouldSample implements the interface sdkTrace.Sampler.
+func (s *ruleSampler) ShouldSample(p sdkTrace.SamplingParameters) sdkTrace.SamplingResult {
+	for _, rule := range s.rules {
+		if rule.pattern.MatchString(p.Name) {
+			return rule.sampler.ShouldSample(p)
+		}
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+// Description implements the interface sdkTrace.Sampler.
+func (s *ruleSampler) Description() string {
+	return fmt.Sprintf(`RuleSampler{rules:%d,fallback:%s}`, len(s.rules), s.fallback.Description())
+}
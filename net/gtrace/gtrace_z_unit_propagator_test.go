@@ -0,0 +1,144 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2/net/gtrace"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func newSampledContext() context.Context {
+	return trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+}
+
+func Test_Propagator_B3(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		p, err := gtrace.NewPropagator(gtrace.PropagatorB3)
+		t.AssertNil(err)
+		carrier := propagation.MapCarrier{}
+		p.Inject(newSampledContext(), carrier)
+		t.Assert(carrier.Get("b3"), traceIDStr+"-"+spanIDStr+"-1")
+
+		sc := trace.SpanContextFromContext(p.Extract(context.Background(), carrier))
+		t.Assert(sc.TraceID().String(), traceIDStr)
+		t.Assert(sc.SpanID().String(), spanIDStr)
+		t.Assert(sc.IsSampled(), true)
+		t.Assert(sc.IsRemote(), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		p, err := gtrace.NewPropagator(gtrace.PropagatorB3Multi)
+		t.AssertNil(err)
+		carrier := propagation.MapCarrier{}
+		p.Inject(newSampledContext(), carrier)
+		t.Assert(carrier.Get("x-b3-traceid"), traceIDStr)
+		t.Assert(carrier.Get("x-b3-spanid"), spanIDStr)
+		t.Assert(carrier.Get("x-b3-sampled"), "1")
+
+		sc := trace.SpanContextFromContext(p.Extract(context.Background(), carrier))
+		t.Assert(sc.TraceID().String(), traceIDStr)
+		t.Assert(sc.SpanID().String(), spanIDStr)
+	})
+	// 64 bits trace id.
+	gtest.C(t, func(t *gtest.T) {
+		p, err := gtrace.NewPropagator(gtrace.PropagatorB3)
+		t.AssertNil(err)
+		carrier := propagation.MapCarrier{"b3": "a3ce929d0e0e4736-00f067aa0ba902b7-0"}
+		sc := trace.SpanContextFromContext(p.Extract(context.Background(), carrier))
+		t.Assert(sc.TraceID().String(), "0000000000000000a3ce929d0e0e4736")
+		t.Assert(sc.IsSampled(), false)
+	})
+	// Invalid.
+	gtest.C(t, func(t *gtest.T) {
+		p, err := gtrace.NewPropagator(gtrace.PropagatorB3)
+		t.AssertNil(err)
+		carrier := propagation.MapCarrier{"b3": "1"}
+		sc := trace.SpanContextFromContext(p.Extract(context.Background(), carrier))
+		t.Assert(sc.IsValid(), false)
+	})
+}
+
+func Test_Propagator_Jaeger(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		p, err := gtrace.NewPropagator(gtrace.PropagatorJaeger)
+		t.AssertNil(err)
+		carrier := propagation.MapCarrier{}
+		p.Inject(newSampledContext(), carrier)
+		t.Assert(carrier.Get("uber-trace-id"), traceIDStr+":"+spanIDStr+":0:1")
+
+		sc := trace.SpanContextFromContext(p.Extract(context.Background(), carrier))
+		t.Assert(sc.TraceID().String(), traceIDStr)
+		t.Assert(sc.SpanID().String(), spanIDStr)
+		t.Assert(sc.IsSampled(), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		p, err := gtrace.NewPropagator(gtrace.PropagatorJaeger)
+		t.AssertNil(err)
+		carrier := propagation.MapCarrier{"uber-trace-id": "a3ce929d0e0e4736%3A67aa0ba902b7%3A0%3A0"}
+		sc := trace.SpanContextFromContext(p.Extract(context.Background(), carrier))
+		t.Assert(sc.TraceID().String(), "0000000000000000a3ce929d0e0e4736")
+		t.Assert(sc.SpanID().String(), "000067aa0ba902b7")
+		t.Assert(sc.IsSampled(), false)
+	})
+}
+
+func Test_Propagator_Header(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		gtrace.RegisterPropagator("request-id", gtrace.NewHeaderPropagator("x-request-id"))
+		p, err := gtrace.NewPropagator(gtrace.PropagatorTraceContext, "request-id")
+		t.AssertNil(err)
+
+		carrier := propagation.MapCarrier{}
+		p.Inject(newSampledContext(), carrier)
+		t.Assert(carrier.Get("x-request-id"), traceIDStr)
+		t.AssertNE(carrier.Get("traceparent"), "")
+
+		carrier = propagation.MapCarrier{"x-request-id": "4bf92f35-77b3-4da6-a3ce-929d0e0e4736"}
+		sc := trace.SpanContextFromContext(p.Extract(context.Background(), carrier))
+		t.Assert(sc.TraceID().String(), traceIDStr)
+		t.Assert(sc.SpanID().IsValid(), true)
+	})
+	// The custom header propagator by name.
+	gtest.C(t, func(t *gtest.T) {
+		p, err := gtrace.NewPropagator(gtrace.PropagatorHeaderPrefix + "X-Trace-Id")
+		t.AssertNil(err)
+
+		carrier := propagation.MapCarrier{}
+		p.Inject(newSampledContext(), carrier)
+		t.Assert(carrier.Get("X-Trace-Id"), traceIDStr)
+
+		_, err = gtrace.NewPropagator(gtrace.PropagatorHeaderPrefix)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_SetPropagators(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		defer otel.SetTextMapPropagator(gtrace.GetDefaultTextMapPropagator())
+
+		t.AssertNE(gtrace.SetPropagators("tracecontext", "none"), nil)
+
+		t.AssertNil(gtrace.SetPropagators("tracecontext", "baggage", "b3", "jaeger"))
+		// The fields of composite propagator are deduplicated in random order.
+		fields := otel.GetTextMapPropagator().Fields()
+		sort.Strings(fields)
+		t.Assert(fields, []string{
+			"b3", "baggage", "traceparent", "tracestate", "uber-trace-id",
+		})
+	})
+}
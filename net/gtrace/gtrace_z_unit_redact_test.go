@@ -0,0 +1,63 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gogf/gf/v2/net/gtrace"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Redact(t *testing.T) {
+	var ctx = context.Background()
+	defer gtrace.ResetRedaction()
+
+	gtest.C(t, func(t *gtest.T) {
+		content := `{"name":"john","password":"123456"}`
+		t.Assert(gtrace.RedactContent(ctx, content), content)
+
+		gtrace.AddRedactFields("Authorization", "Password", "token", "session")
+		t.Assert(
+			gtrace.RedactContent(ctx, `{"name":"john","password": "12\"34","token":123,"list":[{"Token":null}]}`),
+			`{"name":"john","password": "******","token":"******","list":[{"Token":"******"}]}`,
+		)
+		t.Assert(
+			gtrace.RedactContent(ctx, `/login?name=john&password=123456&token=abc`),
+			`/login?name=john&password=******&token=******`,
+		)
+		t.Assert(gtrace.RedactContent(ctx, `password=123456&name=john`), `password=******&name=john`)
+
+		header := gtrace.RedactHeader(ctx, map[string]interface{}{
+			"Authorization": "Bearer secret",
+			"Cookie":        "lang=en; session=secret",
+			"Accept":        []string{"text/html", "application/json"},
+		})
+		t.Assert(header["Authorization"], gtrace.RedactMask)
+		t.Assert(header["Cookie"], "lang=en; session=******")
+		t.Assert(header["Accept"], []string{"text/html", "application/json"})
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNE(gtrace.AddRedactPattern(`(`), nil)
+		t.AssertNil(gtrace.AddRedactPattern(gtrace.RedactPatternCardNumber))
+		t.Assert(
+			gtrace.RedactContent(ctx, `{"card":"4111 1111 1111 1111","id":123}`),
+			`{"card":"******","id":123}`,
+		)
+
+		gtrace.AddRedactHook(func(ctx context.Context, content string) string {
+			return strings.ReplaceAll(content, "john", "j***")
+		})
+		t.Assert(gtrace.RedactContent(ctx, `{"name":"john"}`), `{"name":"j***"}`)
+
+		gtrace.ResetRedaction()
+		t.Assert(gtrace.RedactContent(ctx, `{"name":"john"}`), `{"name":"john"}`)
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gtrace_test
+
+import (
+	"context"
+	"testing"
+
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2/net/gtrace"
+	"github.com/gogf/gf/v2/os/gcfg"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Sampler(t *testing.T) {
+	var (
+		provider = sdkTrace.NewTracerProvider(sdkTrace.WithSampler(gtrace.GetSampler()))
+		tracer   = provider.Tracer("gtrace")
+		sampled  = func(ctx context.Context, name string) bool {
+			_, span := tracer.Start(ctx, name)
+			defer span.End()
+			return span.SpanContext().IsSampled()
+		}
+		unsampledCtx = trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(
+			trace.SpanContextConfig{TraceID: traceID, SpanID: spanID},
+		))
+	)
+	defer gtrace.SetSampler(nil)
+
+	gtest.C(t, func(t *gtest.T) {
+		// Parent based and always on in default.
+		t.Assert(sampled(context.Background(), "GET /user/{id}"), true)
+		t.Assert(sampled(newSampledContext(), "GET /user/{id}"), true)
+		t.Assert(sampled(unsampledCtx, "GET /user/{id}"), false)
+
+		// The sampler is changed at runtime.
+		gtrace.SetSamplerRatio(0)
+		t.Assert(sampled(context.Background(), "GET /user/{id}"), false)
+		t.Assert(sampled(newSampledContext(), "GET /user/{id}"), true)
+
+		gtrace.SetSampler(sdkTrace.AlwaysSample())
+		t.Assert(sampled(unsampledCtx, "GET /user/{id}"), true)
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNE(gtrace.SetSamplerWithConfig(gtrace.SamplerConfig{Name: "none"}), nil)
+
+		t.AssertNil(gtrace.SetSamplerWithConfig(gtrace.SamplerConfig{
+			Name:  gtrace.SamplerParentBasedTraceIDRatio,
+			Ratio: 1,
+			Rules: []gtrace.SamplerRule{
+				{Name: "GET /healthz", Ratio: 0},
+				{Name: "* /internal/*", Ratio: 0},
+			},
+		}))
+		t.Assert(sampled(context.Background(), "GET /user/{id}"), true)
+		t.Assert(sampled(context.Background(), "GET /healthz"), false)
+		t.Assert(sampled(context.Background(), "POST /internal/user/{id}"), false)
+		// The rules apply only to root spans for parent based sampler.
+		t.Assert(sampled(newSampledContext(), "GET /healthz"), true)
+
+		t.AssertNil(gtrace.SetSamplerWithConfig(gtrace.SamplerConfig{
+			Name:  gtrace.SamplerAlwaysOff,
+			Rules: []gtrace.SamplerRule{{Name: "POST /order", Ratio: 1}},
+		}))
+		t.Assert(sampled(newSampledContext(), "GET /user/{id}"), false)
+		t.Assert(sampled(unsampledCtx, "POST /order"), true)
+	})
+}
+
+func Test_GetSamplerWithDefault(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		config  = gcfg.Instance()
+		adapter = config.GetAdapter()
+	)
+	defer gtrace.SetSampler(nil)
+	defer config.SetAdapter(adapter)
+
+	gtest.C(t, func(t *gtest.T) {
+		// The default sampler of exporter is used if no sampler is configured.
+		content, err := gcfg.NewAdapterContent(`{"server": {"address": ":8000"}}`)
+		t.AssertNil(err)
+		config.SetAdapter(content)
+		sampler, err := gtrace.GetSamplerWithDefault(ctx, sdkTrace.AlwaysSample())
+		t.AssertNil(err)
+		t.Assert(sampler.Description(), sdkTrace.AlwaysSample().Description())
+
+		gtrace.SetSamplerRatio(0)
+		sampler, err = gtrace.GetSamplerWithDefault(ctx, sdkTrace.AlwaysSample())
+		t.AssertNil(err)
+		t.Assert(sampler, gtrace.GetSampler())
+	})
+	gtest.C(t, func(t *gtest.T) {
+		// The sampler is loaded from configuration.
+		gtrace.SetSampler(nil)
+		content, err := gcfg.NewAdapterContent(`{"tracing": {"sampler": {"name": "traceidratio", "ratio": 0.5}}}`)
+		t.AssertNil(err)
+		config.SetAdapter(content)
+		sampler, err := gtrace.GetSamplerWithDefault(ctx, sdkTrace.AlwaysSample())
+		t.AssertNil(err)
+		t.Assert(sampler, gtrace.GetSampler())
+		t.Assert(sampler.Description(), sdkTrace.TraceIDRatioBased(0.5).Description())
+
+		gtrace.SetSampler(nil)
+		content, err = gcfg.NewAdapterContent(`{"tracing": {"sampler": {"name": "none"}}}`)
+		t.AssertNil(err)
+		config.SetAdapter(content)
+		_, err = gtrace.GetSamplerWithDefault(ctx, sdkTrace.AlwaysSample())
+		t.AssertNE(err, nil)
+	})
+}
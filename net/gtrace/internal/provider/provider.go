@@ -17,16 +17,14 @@ type TracerProvider struct {
 // New returns a new and configured TracerProvider, which has no SpanProcessor.
 //
 // In default the returned TracerProvider is configured with:
-// - a ParentBased(AlwaysSample) Sampler;
+// - the given Sampler;
 // - a unix nano timestamp and random umber based IDGenerator;
 // - the resource.Default() Resource;
 // - the default SpanLimits.
-//
-// The passed opts are used to override these default values and configure the
-// returned TracerProvider appropriately.
-func New() *TracerProvider {
+func New(sampler sdkTrace.Sampler) *TracerProvider {
 	return &TracerProvider{
 		TracerProvider: sdkTrace.NewTracerProvider(
+			sdkTrace.WithSampler(sampler),
 			sdkTrace.WithIDGenerator(NewIDGenerator()),
 		),
 	}
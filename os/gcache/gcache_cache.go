@@ -9,6 +9,7 @@ package gcache
 import (
 	"context"
 
+	"github.com/gogf/gf/v2/container/gvar"
 	"github.com/gogf/gf/v2/util/gconv"
 )
 
@@ -49,6 +50,19 @@ func (c *Cache) GetAdapter() Adapter {
 	return c.localAdapter
 }
 
+// Get retrieves and returns the associated value of given `key`.
+// It returns nil if it does not exist, or its value is nil, or it's expired.
+// If you would like to check if the `key` exists in the cache, it's better using function Contains.
+//
+// It also records the hit/miss metrics of the cache if metric feature is enabled.
+func (c *Cache) Get(ctx context.Context, key interface{}) (*gvar.Var, error) {
+	value, err := c.localAdapter.Get(ctx, key)
+	if err == nil {
+		c.handleMetricsAfterGet(ctx, value != nil && !value.IsNil())
+	}
+	return value, err
+}
+
 // Removes deletes `keys` in the cache.
 func (c *Cache) Removes(ctx context.Context, keys []interface{}) error {
 	_, err := c.Remove(ctx, keys...)
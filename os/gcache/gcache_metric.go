@@ -0,0 +1,95 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcache
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/os/gmetric"
+)
+
+type localMetricManager struct {
+	CacheRequestTotal gmetric.Counter
+	CacheHitTotal     gmetric.Counter
+	CacheMissTotal    gmetric.Counter
+}
+
+const (
+	metricInstrumentName   = "github.com/gogf/gf/v2/os/gcache"
+	metricAttrKeyCacheType = "cache.adapter"
+)
+
+var (
+	// metricManager for cache metrics.
+	metricManager = newMetricManager()
+)
+
+func newMetricManager() *localMetricManager {
+	meter := gmetric.GetGlobalProvider().Meter(gmetric.MeterOption{
+		Instrument:        metricInstrumentName,
+		InstrumentVersion: gf.VERSION,
+	})
+	mm := &localMetricManager{
+		CacheRequestTotal: meter.MustCounter(
+			"cache.request.total",
+			gmetric.MetricOption{
+				Help:       "Total cache retrieving request number.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		CacheHitTotal: meter.MustCounter(
+			"cache.hit.total",
+			gmetric.MetricOption{
+				Help:       "Total cache retrieving request number that hits the cache.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		CacheMissTotal: meter.MustCounter(
+			"cache.miss.total",
+			gmetric.MetricOption{
+				Help:       "Total cache retrieving request number that misses the cache.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+	}
+	return mm
+}
+
+func (m *localMetricManager) GetMetricOption(c *Cache) gmetric.Option {
+	var adapterType string
+	switch c.localAdapter.(type) {
+	case *AdapterMemory:
+		adapterType = "memory"
+	case *AdapterRedis:
+		adapterType = "redis"
+	default:
+		adapterType = "custom"
+	}
+	return gmetric.Option{
+		Attributes: gmetric.Attributes{
+			gmetric.NewAttribute(metricAttrKeyCacheType, adapterType),
+		},
+	}
+}
+
+// handleMetricsAfterGet records the hit/miss metrics for cache retrieving if metric feature is enabled.
+func (c *Cache) handleMetricsAfterGet(ctx context.Context, hit bool) {
+	if !gmetric.IsEnabled() {
+		return
+	}
+	option := metricManager.GetMetricOption(c)
+	metricManager.CacheRequestTotal.Inc(ctx, option)
+	if hit {
+		metricManager.CacheHitTotal.Inc(ctx, option)
+	} else {
+		metricManager.CacheMissTotal.Inc(ctx, option)
+	}
+}
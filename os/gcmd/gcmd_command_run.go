@@ -48,6 +48,9 @@ func (c *Command) RunWithValue(ctx context.Context) (value interface{}) {
 		if code.Code() == gcode.CodeNotFound.Code() {
 			buffer.WriteString(fmt.Sprintf("ERROR: %s\n", gstr.Trim(err.Error())))
 			if lastCmd, ok := detail.(*Command); ok {
+				if suggestion := lastCmd.suggestCommandName(os.Args); suggestion != "" {
+					buffer.WriteString(fmt.Sprintf("Did you mean \"%s\"?\n", suggestion))
+				}
 				lastCmd.PrintTo(buffer)
 			} else {
 				c.PrintTo(buffer)
@@ -241,6 +244,33 @@ func (c *Command) searchCommand(
 	return c, nil, ctx
 }
 
+// suggestCommandName searches and returns the sub command name of `c` that is most similar
+// to any of given `args`, which is used for "did you mean" suggestion of not found command.
+// It returns an empty string if there's no similar one.
+func (c *Command) suggestCommandName(args []string) string {
+	const minSuggestionScore = 0.8
+	var (
+		names      = make([]string, 0, len(c.commands))
+		suggestion string
+		bestScore  float64
+	)
+	for _, cmd := range c.commands {
+		names = append(names, cmd.Name)
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	for _, arg := range args {
+		if arg == "" || arg[0] == '-' {
+			continue
+		}
+		if match, score := gstr.BestMatch(arg, names); score >= minSuggestionScore && score < 1 && score > bestScore {
+			suggestion, bestScore = match, score
+		}
+	}
+	return suggestion
+}
+
 func (c *Command) hasArgumentFromIndex() bool {
 	for _, arg := range c.Arguments {
 		if arg.IsArg {
@@ -0,0 +1,24 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gcmd
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+func Test_Command_SuggestCommandName(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		c := &Command{Name: "gf"}
+		t.AssertNil(c.AddCommand(&Command{Name: "build"}, &Command{Name: "run"}))
+		t.Assert(c.suggestCommandName([]string{"gf", "biuld", "--debug"}), "build")
+		t.Assert(c.suggestCommandName([]string{"gf", "xyz"}), "")
+		t.Assert(c.suggestCommandName([]string{"gf", "-biuld"}), "")
+		t.Assert((&Command{Name: "empty"}).suggestCommandName([]string{"build"}), "")
+	})
+}
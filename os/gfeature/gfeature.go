@@ -0,0 +1,175 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gfeature provides feature flags with boolean and percentage rollouts.
+//
+// The flags are evaluated using the attributes like user and tenant from context,
+// and they are provided by configuration in default, which takes effect without restarting
+// when the configuration file changes. Any remote flag service can be used by implementing Provider.
+package gfeature
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+)
+
+// Provider provides feature flags.
+type Provider interface {
+	// Flags returns all feature flags, the key of the result map is the flag name.
+	// It is called for each evaluation, so it should be cached by the provider if it is expensive.
+	Flags(ctx context.Context) (map[string]*Flag, error)
+}
+
+// Flag is the definition of a feature flag.
+//
+// A flag is evaluated in following order:
+// 1. It is disabled if Enabled is false;
+// 2. It is disabled if any attribute matches Excludes;
+// 3. It is enabled if any attribute matches Includes;
+// 4. It is enabled for the Percentage of values of attribute RolloutBy.
+type Flag struct {
+	Enabled    bool                `json:"enabled"`    // Whether the flag is on.
+	Percentage float64             `json:"percentage"` // Percentage of rollout from 0 to 100, which is 100 in default.
+	RolloutBy  string              `json:"rolloutBy"`  // Attribute key for percentage rollout, which is AttributeUser in default.
+	Includes   map[string][]string `json:"includes"`   // Attribute values for which the flag is always enabled.
+	Excludes   map[string][]string `json:"excludes"`   // Attribute values for which the flag is always disabled.
+}
+
+const (
+	AttributeUser   = "user"   // AttributeUser is the attribute key of user.
+	AttributeTenant = "tenant" // AttributeTenant is the attribute key of tenant.
+)
+
+const (
+	// percentageScale is the count of rollout buckets for each percent.
+	percentageScale = 100
+)
+
+var defaultProvider Provider = NewProviderConfig()
+
+// SetProvider sets the default Provider, which is the configuration Provider in default.
+func SetProvider(provider Provider) {
+	if provider == nil {
+		panic(gerror.New(`invalid Provider value "nil" given`))
+	}
+	defaultProvider = provider
+}
+
+// GetProvider returns the default Provider.
+func GetProvider() Provider {
+	return defaultProvider
+}
+
+// Enabled checks and returns whether feature flag `name` is enabled for the attributes in `ctx`.
+// It returns false if the flag does not exist or any error occurs.
+//
+// If the flags are already evaluated in `ctx` by WithEvaluated, it uses the evaluated result,
+// which keeps the flags consistent in the same request.
+func Enabled(ctx context.Context, name string) bool {
+	if evaluated := getEvaluated(ctx); evaluated != nil {
+		return evaluated[name]
+	}
+	flags, err := defaultProvider.Flags(ctx)
+	if err != nil {
+		intlog.Errorf(ctx, `%+v`, err)
+		return false
+	}
+	flag, ok := flags[name]
+	if !ok {
+		return false
+	}
+	enabled := flag.Evaluate(name, GetAttributes(ctx))
+	recordEvaluation(ctx, name, enabled)
+	return enabled
+}
+
+// Evaluate evaluates and returns all feature flags for the attributes in `ctx`.
+func Evaluate(ctx context.Context) (map[string]bool, error) {
+	if evaluated := getEvaluated(ctx); evaluated != nil {
+		return evaluated, nil
+	}
+	flags, err := defaultProvider.Flags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		attributes = GetAttributes(ctx)
+		result     = make(map[string]bool, len(flags))
+	)
+	for name, flag := range flags {
+		result[name] = flag.Evaluate(name, attributes)
+		recordEvaluation(ctx, name, result[name])
+	}
+	return result, nil
+}
+
+// EnabledNames returns the sorted names of enabled flags in `evaluated`.
+func EnabledNames(evaluated map[string]bool) []string {
+	names := make([]string, 0, len(evaluated))
+	for name, enabled := range evaluated {
+		if enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Evaluate evaluates flag `name` with `attributes`.
+// The `name` is used for percentage rollout, so that each flag has its own rollout users.
+func (f *Flag) Evaluate(name string, attributes map[string]string) bool {
+	if f == nil || !f.Enabled {
+		return false
+	}
+	if matchAttributes(f.Excludes, attributes) {
+		return false
+	}
+	if matchAttributes(f.Includes, attributes) {
+		return true
+	}
+	if f.Percentage >= 100 {
+		return true
+	}
+	if f.Percentage <= 0 {
+		return false
+	}
+	rolloutBy := f.RolloutBy
+	if rolloutBy == "" {
+		rolloutBy = AttributeUser
+	}
+	value, ok := attributes[rolloutBy]
+	if !ok || value == "" {
+		return false
+	}
+	return float64(bucket(name, value)) < f.Percentage*percentageScale
+}
+
+// matchAttributes checks whether any of `attributes` is in `values`.
+func matchAttributes(values map[string][]string, attributes map[string]string) bool {
+	for key, items := range values {
+		value, ok := attributes[key]
+		if !ok {
+			continue
+		}
+		for _, item := range items {
+			if item == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bucket returns the stable rollout bucket of `value` for flag `name`, in range [0, 100*percentageScale).
+func bucket(name, value string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name + ":" + value))
+	return h.Sum32() % (100 * percentageScale)
+}
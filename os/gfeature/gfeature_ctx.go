@@ -0,0 +1,66 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gfeature
+
+import (
+	"context"
+)
+
+type ctxKey string
+
+const (
+	ctxKeyAttributes ctxKey = "GFeatureAttributes"
+	ctxKeyEvaluated  ctxKey = "GFeatureEvaluated"
+)
+
+// WithAttributes returns a new context with `attributes` for evaluating flags,
+// which are merged with the attributes that already in `ctx`.
+func WithAttributes(ctx context.Context, attributes map[string]string) context.Context {
+	merged := make(map[string]string)
+	for k, v := range GetAttributes(ctx) {
+		merged[k] = v
+	}
+	for k, v := range attributes {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, ctxKeyAttributes, merged)
+}
+
+// WithUser returns a new context with user attribute for evaluating flags.
+func WithUser(ctx context.Context, user string) context.Context {
+	return WithAttributes(ctx, map[string]string{AttributeUser: user})
+}
+
+// WithTenant returns a new context with tenant attribute for evaluating flags.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return WithAttributes(ctx, map[string]string{AttributeTenant: tenant})
+}
+
+// GetAttributes returns the attributes in `ctx` for evaluating flags.
+func GetAttributes(ctx context.Context) map[string]string {
+	if v, ok := ctx.Value(ctxKeyAttributes).(map[string]string); ok {
+		return v
+	}
+	return nil
+}
+
+// WithEvaluated evaluates all flags and returns a new context with the evaluated result,
+// so that the following Enabled and Evaluate using the returned context use the same result.
+func WithEvaluated(ctx context.Context) (context.Context, error) {
+	evaluated, err := Evaluate(ctx)
+	if err != nil {
+		return ctx, err
+	}
+	return context.WithValue(ctx, ctxKeyEvaluated, evaluated), nil
+}
+
+func getEvaluated(ctx context.Context) map[string]bool {
+	if v, ok := ctx.Value(ctxKeyEvaluated).(map[string]bool); ok {
+		return v
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gfeature
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/os/gmetric"
+)
+
+const (
+	instrumentName     = "github.com/gogf/gf/v2/os/gfeature"
+	metricAttrFlag     = "feature.flag"
+	metricAttrEnabled  = "feature.enabled"
+	metricNameEvaluate = "feature.evaluation.total"
+)
+
+var (
+	metricOnce     sync.Once
+	metricEvaluate gmetric.Counter
+)
+
+// recordEvaluation increases the evaluation counter of flag `name` with its result.
+func recordEvaluation(ctx context.Context, name string, enabled bool) {
+	if !gmetric.IsEnabled() {
+		return
+	}
+	metricOnce.Do(func() {
+		meter := gmetric.GetGlobalProvider().Meter(gmetric.MeterOption{
+			Instrument:        instrumentName,
+			InstrumentVersion: gf.VERSION,
+		})
+		metricEvaluate = meter.MustCounter(
+			metricNameEvaluate,
+			gmetric.MetricOption{
+				Help:       "Total evaluation number of feature flags.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		)
+	})
+	metricEvaluate.Inc(ctx, gmetric.Option{
+		Attributes: gmetric.Attributes{
+			gmetric.NewAttribute(metricAttrFlag, name),
+			gmetric.NewAttribute(metricAttrEnabled, enabled),
+		},
+	})
+}
@@ -0,0 +1,151 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gfeature
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gcfg"
+	"github.com/gogf/gf/v2/os/gtimer"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// ProviderConfig provides flags from configuration.
+type ProviderConfig struct {
+	config  *gcfg.Config // Nil means using the default configuration instance.
+	pattern string
+}
+
+// ProviderRemote provides flags loaded by Loader from remote service periodically.
+type ProviderRemote struct {
+	mu       sync.RWMutex
+	once     sync.Once
+	loader   Loader
+	interval time.Duration
+	flags    map[string]*Flag
+	err      error // Error of the first loading, which is returned until loading succeeds.
+}
+
+// Loader loads all flags from remote service.
+type Loader func(ctx context.Context) (map[string]*Flag, error)
+
+const (
+	// DefaultConfigPattern is the default configuration pattern of flags.
+	DefaultConfigPattern = "features"
+	// DefaultRemoteInterval is the default interval of reloading remote flags.
+	DefaultRemoteInterval = 30 * time.Second
+)
+
+// NewProviderConfig creates and returns a Provider using configuration `config` with `pattern`,
+// which are the default configuration instance and DefaultConfigPattern if not given.
+//
+// As the flags are read from the configuration for each evaluation,
+// the changes of configuration file take effect without restarting. The configuration is like:
+//
+//	features:
+//	  new-checkout:
+//	    enabled: true
+//	    percentage: 20
+//	    includes:
+//	      tenant: ["beta"]
+func NewProviderConfig(config ...*gcfg.Config) *ProviderConfig {
+	p := &ProviderConfig{
+		pattern: DefaultConfigPattern,
+	}
+	if len(config) > 0 {
+		p.config = config[0]
+	}
+	return p
+}
+
+// SetPattern sets the configuration pattern of flags.
+func (p *ProviderConfig) SetPattern(pattern string) *ProviderConfig {
+	p.pattern = pattern
+	return p
+}
+
+// Flags implements the interface Provider.
+func (p *ProviderConfig) Flags(ctx context.Context) (map[string]*Flag, error) {
+	config := p.config
+	if config == nil {
+		config = gcfg.Instance()
+	}
+	if !config.Available(ctx) {
+		return map[string]*Flag{}, nil
+	}
+	v, err := config.Get(ctx, p.pattern)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFlags(v.Map())
+}
+
+// NewProviderRemote creates and returns a Provider that loads flags using `loader`,
+// which is commonly the adapter of remote feature flag service.
+//
+// The flags are loaded when they are used for the first time,
+// and then reloaded every `interval`, which is DefaultRemoteInterval if not given.
+// The last loaded flags are still used if the reloading fails.
+func NewProviderRemote(loader Loader, interval ...time.Duration) *ProviderRemote {
+	p := &ProviderRemote{
+		loader:   loader,
+		interval: DefaultRemoteInterval,
+	}
+	if len(interval) > 0 && interval[0] > 0 {
+		p.interval = interval[0]
+	}
+	return p
+}
+
+// Flags implements the interface Provider.
+func (p *ProviderRemote) Flags(ctx context.Context) (map[string]*Flag, error) {
+	p.once.Do(func() {
+		p.load(ctx)
+		gtimer.AddSingleton(context.Background(), p.interval, func(ctx context.Context) {
+			p.load(ctx)
+		})
+	})
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.flags == nil {
+		return nil, p.err
+	}
+	return p.flags, nil
+}
+
+// load loads flags using loader.
+func (p *ProviderRemote) load(ctx context.Context) {
+	flags, err := p.loader(ctx)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err != nil {
+		intlog.Errorf(ctx, `loading remote feature flags failed: %+v`, err)
+		if p.flags == nil {
+			p.err = gerror.Wrap(err, `loading remote feature flags failed`)
+		}
+		return
+	}
+	p.flags, p.err = flags, nil
+}
+
+// ParseFlags parses and returns flags from `data`, which is the map of flag name to its definition.
+// It is used for converting the flags from configuration or remote service.
+func ParseFlags(data map[string]interface{}) (map[string]*Flag, error) {
+	flags := make(map[string]*Flag, len(data))
+	for name, value := range data {
+		flag := &Flag{Percentage: 100}
+		if err := gconv.Struct(value, flag); err != nil {
+			return nil, gerror.Wrapf(err, `invalid feature flag "%s"`, name)
+		}
+		flags[name] = flag
+	}
+	return flags, nil
+}
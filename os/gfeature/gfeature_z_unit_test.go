@@ -0,0 +1,155 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gfeature_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/os/gcfg"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gfeature"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+const testConfigContent = `
+features:
+  on:
+    enabled: true
+  off:
+    enabled: false
+  beta:
+    enabled: true
+    percentage: 0
+    includes:
+      tenant: ["beta"]
+  rollout:
+    enabled: true
+    percentage: 30
+    excludes:
+      user: ["blocked"]
+`
+
+func Test_Flag_Evaluate(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var nilFlag *gfeature.Flag
+		t.Assert(nilFlag.Evaluate("f", nil), false)
+		t.Assert((&gfeature.Flag{Enabled: true, Percentage: 100}).Evaluate("f", nil), true)
+		t.Assert((&gfeature.Flag{Enabled: false, Percentage: 100}).Evaluate("f", nil), false)
+
+		// No attribute for percentage rollout.
+		flag := &gfeature.Flag{Enabled: true, Percentage: 50}
+		t.Assert(flag.Evaluate("f", nil), false)
+
+		// Stable and approximate percentage rollout.
+		enabled := 0
+		for i := 0; i < 10000; i++ {
+			attributes := map[string]string{gfeature.AttributeUser: fmt.Sprint(i)}
+			if flag.Evaluate("f", attributes) {
+				enabled++
+			}
+			t.Assert(flag.Evaluate("f", attributes), flag.Evaluate("f", attributes))
+		}
+		t.AssertGT(enabled, 4500)
+		t.AssertLT(enabled, 5500)
+
+		// Rollout by tenant.
+		flag = &gfeature.Flag{Enabled: true, Percentage: 50, RolloutBy: gfeature.AttributeTenant}
+		enabled = 0
+		for i := 0; i < 1000; i++ {
+			if flag.Evaluate("f", map[string]string{gfeature.AttributeTenant: fmt.Sprint(i)}) {
+				enabled++
+			}
+		}
+		t.AssertGT(enabled, 400)
+		t.AssertLT(enabled, 600)
+	})
+}
+
+func Test_ProviderConfig(t *testing.T) {
+	adapter, err := gcfg.NewAdapterContent(testConfigContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gfeature.SetProvider(gfeature.NewProviderConfig(gcfg.NewWithAdapter(adapter)))
+	defer gfeature.SetProvider(gfeature.NewProviderConfig())
+
+	gtest.C(t, func(t *gtest.T) {
+		ctx := gctx.New()
+		t.Assert(gfeature.Enabled(ctx, "on"), true)
+		t.Assert(gfeature.Enabled(ctx, "off"), false)
+		t.Assert(gfeature.Enabled(ctx, "beta"), false)
+		t.Assert(gfeature.Enabled(ctx, "not-exist"), false)
+		t.Assert(gfeature.Enabled(gfeature.WithTenant(ctx, "beta"), "beta"), true)
+		t.Assert(gfeature.Enabled(gfeature.WithUser(ctx, "blocked"), "rollout"), false)
+
+		evaluated, err := gfeature.Evaluate(gfeature.WithTenant(ctx, "beta"))
+		t.AssertNil(err)
+		t.Assert(evaluated["on"], true)
+		t.Assert(evaluated["beta"], true)
+		t.Assert(gfeature.EnabledNames(map[string]bool{"b": true, "a": true, "c": false}), []string{"a", "b"})
+	})
+
+	// Hot reloading.
+	gtest.C(t, func(t *gtest.T) {
+		ctx := gctx.New()
+		t.AssertNil(adapter.SetContent(`{"features": {"off": {"enabled": true}}}`))
+		t.Assert(gfeature.Enabled(ctx, "off"), true)
+		t.Assert(gfeature.Enabled(ctx, "on"), false)
+		t.AssertNil(adapter.SetContent(testConfigContent))
+	})
+
+	// Evaluated in context.
+	gtest.C(t, func(t *gtest.T) {
+		ctx, err := gfeature.WithEvaluated(gctx.New())
+		t.AssertNil(err)
+		t.AssertNil(adapter.SetContent(`{"features": {}}`))
+		t.Assert(gfeature.Enabled(ctx, "on"), true)
+		t.Assert(gfeature.Enabled(gctx.New(), "on"), false)
+		t.AssertNil(adapter.SetContent(testConfigContent))
+	})
+}
+
+func Test_ProviderRemote(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx      = gctx.New()
+			loaded   = gtype.NewInt()
+			provider = gfeature.NewProviderRemote(func(ctx context.Context) (map[string]*gfeature.Flag, error) {
+				if loaded.Add(1) > 1 {
+					return nil, errors.New("remote unavailable")
+				}
+				return gfeature.ParseFlags(map[string]interface{}{
+					"remote": map[string]interface{}{"enabled": true},
+				})
+			}, 50*time.Millisecond)
+		)
+		flags, err := provider.Flags(ctx)
+		t.AssertNil(err)
+		t.Assert(flags["remote"].Percentage, 100)
+		t.Assert(flags["remote"].Evaluate("remote", nil), true)
+
+		// The last loaded flags are used if reloading fails.
+		time.Sleep(200 * time.Millisecond)
+		t.AssertGT(loaded.Val(), 1)
+		flags, err = provider.Flags(ctx)
+		t.AssertNil(err)
+		t.Assert(len(flags), 1)
+	})
+
+	gtest.C(t, func(t *gtest.T) {
+		provider := gfeature.NewProviderRemote(func(ctx context.Context) (map[string]*gfeature.Flag, error) {
+			return nil, errors.New("remote unavailable")
+		})
+		_, err := provider.Flags(gctx.New())
+		t.AssertNE(err, nil)
+	})
+}
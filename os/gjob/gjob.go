@@ -0,0 +1,91 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gjob implements a persistent background job runner.
+//
+// Different from gcron, which runs jobs on schedule in current process, and gqueue, which
+// is a memory queue, the jobs of gjob are persisted in Storage, so that they survive process
+// restarting and can be processed by multiple processes. It supports delayed execution,
+// retries with exponential backoff, dead-letter jobs and idempotency keys.
+package gjob
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// Job is the job persisted in Storage.
+type Job struct {
+	Id             string    `json:"id"`             // Unique id of the job.
+	Name           string    `json:"name"`           // Name of the handler processing the job.
+	Payload        []byte    `json:"payload"`        // JSON encoded payload.
+	Status         Status    `json:"status"`         // Current status.
+	Attempt        int       `json:"attempt"`        // Number of processing attempts, which is increased when the job is fetched.
+	MaxRetries     int       `json:"maxRetries"`     // Max retry count before it's moved to dead letter.
+	LastError      string    `json:"lastError"`      // Error of the last failed attempt.
+	IdempotencyKey string    `json:"idempotencyKey"` // Optional key that avoids enqueuing duplicated jobs.
+	RunAt          time.Time `json:"runAt"`          // Time to process it, or the lease deadline if running.
+	CreatedAt      time.Time `json:"createdAt"`      // Creating time.
+	UpdatedAt      time.Time `json:"updatedAt"`      // Last updating time.
+}
+
+// Status is the status of job.
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // Waiting for processing, including the retrying ones.
+	StatusRunning   Status = "running"   // Being processed by a worker.
+	StatusSucceeded Status = "succeeded" // Processed successfully.
+	StatusDead      Status = "dead"      // Failed after all retries, which is in dead letter.
+)
+
+// Handler processes the job.
+// The job is retried later if it returns error.
+type Handler func(ctx context.Context, job *Job) error
+
+// Scan decodes the payload of job to `pointer`, which is commonly a pointer to struct.
+func (j *Job) Scan(pointer interface{}) error {
+	if len(j.Payload) == 0 {
+		return nil
+	}
+	var data interface{}
+	if err := json.UnmarshalUseNumber(j.Payload, &data); err != nil {
+		return err
+	}
+	return gconv.Scan(data, pointer)
+}
+
+// Clone returns a copy of the job.
+func (j *Job) Clone() *Job {
+	clone := *j
+	clone.Payload = append([]byte(nil), j.Payload...)
+	return &clone
+}
+
+// IsFinished checks and returns whether the job is finished processing, succeeded or dead.
+func (j *Job) IsFinished() bool {
+	return j.Status == StatusSucceeded || j.Status == StatusDead
+}
+
+// encodePayload encodes `payload` as JSON, which keeps it if it's already bytes.
+func encodePayload(payload interface{}) ([]byte, error) {
+	switch v := payload.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return v, nil
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `encode job payload failed`)
+	}
+	return b, nil
+}
@@ -0,0 +1,353 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjob
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/os/grpool"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// Manager enqueues jobs to Storage, and processes them using registered handlers in worker pool.
+type Manager struct {
+	storage   Storage
+	option    Option
+	handlers  *gmap.StrAnyMap // Job name to Handler.
+	pool      *grpool.Pool    // Worker pool processing jobs.
+	busy      *gtype.Int      // Count of processing jobs.
+	running   *gtype.Bool     // Whether the manager is started.
+	wakeChan  chan struct{}   // Wakes up the fetching loop for jobs enqueued in current process.
+	closeChan chan struct{}   // Stops the fetching loop.
+	loopDone  chan struct{}   // Closed when the fetching loop exits.
+	jobWg     sync.WaitGroup  // Processing jobs.
+}
+
+// Option is the option for Manager.
+type Option struct {
+	Workers      int           // Max number of concurrent processing jobs, which is 10 in default.
+	PollInterval time.Duration // Interval of polling due jobs from storage, which is 1 second in default.
+	Lease        time.Duration // Max processing time, after which the job is considered abandoned and processed again. It's 5 minutes in default.
+	MaxRetries   int           // Default max retry count of jobs, which is 3 in default.
+	Backoff      time.Duration // Base delay of exponential backoff for retries, which is 1 second in default.
+	MaxBackoff   time.Duration // Max delay of retries, which is 1 hour in default.
+	Retention    time.Duration // Time keeping succeeded jobs in storage, which is 24 hours in default.
+	Logger       *glog.Logger  // Logger for processing errors, which uses the default logger if not set.
+}
+
+// EnqueueOption is the option for enqueuing a job.
+type EnqueueOption struct {
+	Delay          time.Duration // Delays the job processing.
+	RunAt          time.Time     // Processes the job at this time, which has priority over Delay.
+	MaxRetries     int           // Max retry count, which uses Option.MaxRetries if 0. Negative value means no retry.
+	IdempotencyKey string        // Unique key of the job, it does not enqueue another job with the same key.
+}
+
+const (
+	defaultWorkers      = 10
+	defaultPollInterval = time.Second
+	defaultLease        = 5 * time.Minute
+	defaultMaxRetries   = 3
+	defaultBackoff      = time.Second
+	defaultMaxBackoff   = time.Hour
+	defaultRetention    = 24 * time.Hour
+	purgeInterval       = time.Minute
+)
+
+// New creates and returns a job manager with `storage`.
+// The optional parameter `option` specifies the processing options.
+func New(storage Storage, option ...Option) *Manager {
+	var opt Option
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.Workers <= 0 {
+		opt.Workers = defaultWorkers
+	}
+	if opt.PollInterval <= 0 {
+		opt.PollInterval = defaultPollInterval
+	}
+	if opt.Lease <= 0 {
+		opt.Lease = defaultLease
+	}
+	if opt.MaxRetries == 0 {
+		opt.MaxRetries = defaultMaxRetries
+	}
+	if opt.Backoff <= 0 {
+		opt.Backoff = defaultBackoff
+	}
+	if opt.MaxBackoff <= 0 {
+		opt.MaxBackoff = defaultMaxBackoff
+	}
+	if opt.Retention <= 0 {
+		opt.Retention = defaultRetention
+	}
+	if opt.Logger == nil {
+		opt.Logger = glog.DefaultLogger()
+	}
+	return &Manager{
+		storage:  storage,
+		option:   opt,
+		handlers: gmap.NewStrAnyMap(true),
+		busy:     gtype.NewInt(),
+		running:  gtype.NewBool(),
+		wakeChan: make(chan struct{}, 1),
+	}
+}
+
+// Register registers `handler` processing jobs with `name`.
+func (m *Manager) Register(name string, handler Handler) {
+	m.handlers.Set(name, handler)
+}
+
+// Enqueue adds a job with `name` and `payload` to storage, and returns the job id.
+// The `payload` is encoded as JSON, unless it is []byte.
+//
+// If the IdempotencyKey of `option` is given and there's already a job with the key,
+// it returns the id of the existing job.
+func (m *Manager) Enqueue(ctx context.Context, name string, payload interface{}, option ...EnqueueOption) (id string, err error) {
+	var opt EnqueueOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	data, err := encodePayload(payload)
+	if err != nil {
+		return "", err
+	}
+	var (
+		now = time.Now()
+		job = &Job{
+			Id:             guid.S(),
+			Name:           name,
+			Payload:        data,
+			Status:         StatusPending,
+			MaxRetries:     m.option.MaxRetries,
+			IdempotencyKey: opt.IdempotencyKey,
+			RunAt:          now.Add(opt.Delay),
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		}
+	)
+	if !opt.RunAt.IsZero() {
+		job.RunAt = opt.RunAt
+	}
+	if opt.MaxRetries != 0 {
+		job.MaxRetries = opt.MaxRetries
+	}
+	if job.MaxRetries < 0 {
+		job.MaxRetries = 0
+	}
+	if id, err = m.storage.Add(ctx, job); err != nil {
+		return "", err
+	}
+	if !job.RunAt.After(now) {
+		m.wake()
+	}
+	return id, nil
+}
+
+// Start starts fetching and processing jobs asynchronously.
+func (m *Manager) Start(ctx context.Context) error {
+	if !m.running.Cas(false, true) {
+		return gerror.NewCode(gcode.CodeInvalidOperation, `job manager is already started`)
+	}
+	m.pool = grpool.New(m.option.Workers)
+	m.closeChan = make(chan struct{})
+	m.loopDone = make(chan struct{})
+	go m.loop(ctx)
+	return nil
+}
+
+// Stop stops fetching jobs, and waits the processing jobs done until `ctx` is done.
+// The jobs that are not done are processed again after their lease passes.
+func (m *Manager) Stop(ctx context.Context) error {
+	if !m.running.Cas(true, false) {
+		return nil
+	}
+	close(m.closeChan)
+	<-m.loopDone
+	done := make(chan struct{})
+	go func() {
+		m.jobWg.Wait()
+		close(done)
+	}()
+	defer m.pool.Close()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return gerror.WrapCode(gcode.CodeOperationFailed, ctx.Err(), `waiting processing jobs done failed`)
+	}
+}
+
+// wake wakes up the fetching loop without blocking.
+func (m *Manager) wake() {
+	select {
+	case m.wakeChan <- struct{}{}:
+	default:
+	}
+}
+
+// loop fetches and processes due jobs until the manager is stopped.
+func (m *Manager) loop(ctx context.Context) {
+	defer close(m.loopDone)
+	var (
+		ticker    = time.NewTicker(m.option.PollInterval)
+		lastPurge time.Time
+	)
+	defer ticker.Stop()
+	for {
+		for m.fetch(ctx) {
+			// Continues fetching as there might be more due jobs.
+		}
+		if time.Since(lastPurge) >= purgeInterval {
+			m.purge(ctx)
+			lastPurge = time.Now()
+		}
+		select {
+		case <-m.closeChan:
+			return
+		case <-ticker.C:
+		case <-m.wakeChan:
+		}
+	}
+}
+
+// fetch fetches due jobs as many as idle workers and processes them.
+// It returns true if the workers are fully filled.
+func (m *Manager) fetch(ctx context.Context) bool {
+	select {
+	case <-m.closeChan:
+		return false
+	default:
+	}
+	limit := m.option.Workers - m.busy.Val()
+	if limit <= 0 {
+		return false
+	}
+	now := time.Now()
+	jobs, err := m.storage.Fetch(ctx, now, limit, m.option.Lease)
+	if err != nil {
+		m.option.Logger.Errorf(ctx, `fetch jobs failed: %+v`, err)
+		return false
+	}
+	for _, job := range jobs {
+		job.Status = StatusRunning
+		job.Attempt++
+		job.UpdatedAt = now
+		if err = m.storage.Update(ctx, job); err != nil {
+			m.option.Logger.Errorf(ctx, `update job "%s" failed: %+v`, job.Id, err)
+			continue
+		}
+		m.process(ctx, job)
+	}
+	return len(jobs) == limit
+}
+
+// process processes `job` asynchronously in worker pool.
+func (m *Manager) process(ctx context.Context, job *Job) {
+	m.busy.Add(1)
+	m.jobWg.Add(1)
+	err := m.pool.Add(ctx, func(ctx context.Context) {
+		defer func() {
+			m.busy.Add(-1)
+			m.jobWg.Done()
+		}()
+		m.finish(ctx, job, m.handle(ctx, job))
+	})
+	if err != nil {
+		m.busy.Add(-1)
+		m.jobWg.Done()
+		m.option.Logger.Errorf(ctx, `process job "%s" failed: %+v`, job.Id, err)
+	}
+}
+
+// handle calls the handler of `job` with timeout of lease, which converts panic to error.
+func (m *Manager) handle(ctx context.Context, job *Job) (err error) {
+	handler, ok := m.handlers.Get(job.Name).(Handler)
+	if !ok {
+		return gerror.NewCodef(gcode.CodeNotFound, `no handler registered for job "%s"`, job.Name)
+	}
+	defer func() {
+		if exception := recover(); exception != nil {
+			if v, ok := exception.(error); ok && gerror.HasStack(v) {
+				err = v
+			} else {
+				err = gerror.NewCodef(gcode.CodeInternalPanic, "%+v", exception)
+			}
+		}
+	}()
+	timeoutCtx, cancel := context.WithTimeout(ctx, m.option.Lease)
+	defer cancel()
+	return handler(timeoutCtx, job)
+}
+
+// finish updates the status of `job` by its processing result `err`.
+func (m *Manager) finish(ctx context.Context, job *Job, err error) {
+	now := time.Now()
+	job.UpdatedAt = now
+	switch {
+	case err == nil:
+		job.Status = StatusSucceeded
+		job.LastError = ""
+		job.RunAt = now
+
+	case job.Attempt > job.MaxRetries:
+		job.Status = StatusDead
+		job.LastError = err.Error()
+		job.RunAt = now
+		m.option.Logger.Errorf(ctx, `job "%s" of "%s" is dead after %d attempts: %+v`, job.Id, job.Name, job.Attempt, err)
+
+	default:
+		job.Status = StatusPending
+		job.LastError = err.Error()
+		job.RunAt = now.Add(m.backoff(job.Attempt))
+		m.option.Logger.Warningf(ctx, `job "%s" of "%s" attempt %d failed, retry at %s: %+v`, job.Id, job.Name, job.Attempt, job.RunAt, err)
+	}
+	if err = m.storage.Update(ctx, job); err != nil {
+		m.option.Logger.Errorf(ctx, `update job "%s" failed: %+v`, job.Id, err)
+	}
+}
+
+// backoff calculates and returns the exponential delay for retrying after `attempt`.
+func (m *Manager) backoff(attempt int) time.Duration {
+	delay := m.option.Backoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= m.option.MaxBackoff {
+			return m.option.MaxBackoff
+		}
+	}
+	if delay > m.option.MaxBackoff {
+		return m.option.MaxBackoff
+	}
+	return delay
+}
+
+// purge removes the succeeded jobs that exceed retention.
+func (m *Manager) purge(ctx context.Context) {
+	jobs, err := m.storage.List(ctx, StatusSucceeded)
+	if err != nil {
+		m.option.Logger.Errorf(ctx, `list succeeded jobs failed: %+v`, err)
+		return
+	}
+	deadline := time.Now().Add(-m.option.Retention)
+	for _, job := range jobs {
+		if job.UpdatedAt.Before(deadline) {
+			if err = m.storage.Remove(ctx, job.Id); err != nil {
+				m.option.Logger.Errorf(ctx, `remove job "%s" failed: %+v`, job.Id, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjob
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Get retrieves and returns the job with `id`, which is nil if it does not exist.
+func (m *Manager) Get(ctx context.Context, id string) (*Job, error) {
+	return m.storage.Get(ctx, id)
+}
+
+// List retrieves and returns the jobs with `status`, or all jobs if `status` is empty.
+// It is commonly used for inspecting the dead jobs with StatusDead.
+func (m *Manager) List(ctx context.Context, status Status) ([]*Job, error) {
+	return m.storage.List(ctx, status)
+}
+
+// Requeue resets the job with `id` to be processed again immediately with all its retries,
+// which is commonly used for the dead jobs after the failure reason is fixed.
+func (m *Manager) Requeue(ctx context.Context, id string) error {
+	job, err := m.storage.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return gerror.NewCodef(gcode.CodeNotFound, `job "%s" not found`, id)
+	}
+	if job.Status == StatusRunning {
+		return gerror.NewCodef(gcode.CodeInvalidOperation, `job "%s" is running`, id)
+	}
+	now := time.Now()
+	job.Status = StatusPending
+	job.Attempt = 0
+	job.RunAt = now
+	job.UpdatedAt = now
+	if err = m.storage.Update(ctx, job); err != nil {
+		return err
+	}
+	m.wake()
+	return nil
+}
+
+// Remove deletes the job with `id` from storage.
+func (m *Manager) Remove(ctx context.Context, id string) error {
+	return m.storage.Remove(ctx, id)
+}
@@ -0,0 +1,41 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjob
+
+import (
+	"context"
+	"time"
+)
+
+// Storage is the interface definition for job storage.
+type Storage interface {
+	// Add adds `job` to the storage.
+	// If the job has idempotency key and there's already a job with the same key,
+	// it does not add the job and returns the id of the existing one.
+	Add(ctx context.Context, job *Job) (id string, err error)
+
+	// Fetch claims and returns at most `limit` jobs that are pending with RunAt before `now`,
+	// or running with lease deadline RunAt before `now`, which are abandoned by crashed workers.
+	//
+	// The returned jobs should not be returned again by Fetch until `lease` passes,
+	// and the caller updates them to running status with increased attempt.
+	Fetch(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*Job, error)
+
+	// Update saves the status of `job`.
+	Update(ctx context.Context, job *Job) error
+
+	// Get retrieves and returns the job with `id`.
+	// It returns nil if the job does not exist.
+	Get(ctx context.Context, id string) (*Job, error)
+
+	// List retrieves and returns the jobs with `status`, ordered by RunAt.
+	// It returns all jobs if `status` is empty.
+	List(ctx context.Context, status Status) ([]*Job, error)
+
+	// Remove deletes the job with `id`, which also releases its idempotency key.
+	Remove(ctx context.Context, id string) error
+}
@@ -0,0 +1,125 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjob
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// StorageMemory implements the Storage interface in memory,
+// which is mainly for testing or single process without persistence.
+type StorageMemory struct {
+	mu          sync.RWMutex
+	jobs        map[string]*Job   // Job id to job.
+	idempotency map[string]string // Idempotency key to job id.
+}
+
+// NewStorageMemory creates and returns a memory storage for job.
+func NewStorageMemory() *StorageMemory {
+	return &StorageMemory{
+		jobs:        make(map[string]*Job),
+		idempotency: make(map[string]string),
+	}
+}
+
+// Add implements the Storage interface.
+func (s *StorageMemory) Add(ctx context.Context, job *Job) (id string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if job.IdempotencyKey != "" {
+		if existingId, ok := s.idempotency[job.IdempotencyKey]; ok {
+			return existingId, nil
+		}
+		s.idempotency[job.IdempotencyKey] = job.Id
+	}
+	s.jobs[job.Id] = job.Clone()
+	return job.Id, nil
+}
+
+// Fetch implements the Storage interface.
+func (s *StorageMemory) Fetch(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var due []*Job
+	for _, job := range s.jobs {
+		if job.IsFinished() || job.RunAt.After(now) {
+			continue
+		}
+		due = append(due, job)
+	}
+	sortJobs(due)
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	fetched := make([]*Job, len(due))
+	for i, job := range due {
+		// Leasing the job by postponing it.
+		job.RunAt = now.Add(lease)
+		fetched[i] = job.Clone()
+	}
+	return fetched, nil
+}
+
+// Update implements the Storage interface.
+func (s *StorageMemory) Update(ctx context.Context, job *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.jobs[job.Id]; ok {
+		s.jobs[job.Id] = job.Clone()
+	}
+	return nil
+}
+
+// Get implements the Storage interface.
+func (s *StorageMemory) Get(ctx context.Context, id string) (*Job, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if job, ok := s.jobs[id]; ok {
+		return job.Clone(), nil
+	}
+	return nil, nil
+}
+
+// List implements the Storage interface.
+func (s *StorageMemory) List(ctx context.Context, status Status) ([]*Job, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	jobs := make([]*Job, 0)
+	for _, job := range s.jobs {
+		if status == "" || job.Status == status {
+			jobs = append(jobs, job.Clone())
+		}
+	}
+	sortJobs(jobs)
+	return jobs, nil
+}
+
+// Remove implements the Storage interface.
+func (s *StorageMemory) Remove(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if job, ok := s.jobs[id]; ok {
+		if job.IdempotencyKey != "" {
+			delete(s.idempotency, job.IdempotencyKey)
+		}
+		delete(s.jobs, id)
+	}
+	return nil
+}
+
+// sortJobs sorts `jobs` by RunAt and then CreatedAt.
+func sortJobs(jobs []*Job) {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if jobs[i].RunAt.Equal(jobs[j].RunAt) {
+			return jobs[i].CreatedAt.Before(jobs[j].CreatedAt)
+		}
+		return jobs[i].RunAt.Before(jobs[j].RunAt)
+	})
+}
@@ -0,0 +1,210 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjob
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogf/gf/v2/database/gredis"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// StorageRedis implements the Storage interface with redis.
+//
+// The jobs are stored in a hash table, and the unfinished ones are scheduled in a sorted set
+// scored by RunAt, which are claimed atomically using lua scripts among multiple processes.
+type StorageRedis struct {
+	redis          *gredis.Redis // Redis client for job storage.
+	keyJobs        string        // Hash key of job id to job.
+	keyScheduled   string        // Sorted set key of unfinished job ids scored by RunAt.
+	keyIdempotency string        // Hash key of idempotency key to job id.
+}
+
+const (
+	// DefaultStorageRedisPrefix is the default key prefix of redis storage.
+	DefaultStorageRedisPrefix = "gjob:"
+)
+
+const (
+	redisScriptAdd = `
+if ARGV[4] ~= '' then
+	local existing = redis.call('HGET', KEYS[3], ARGV[4])
+	if existing then
+		return existing
+	end
+	redis.call('HSET', KEYS[3], ARGV[4], ARGV[1])
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+redis.call('ZADD', KEYS[2], ARGV[3], ARGV[1])
+return ARGV[1]
+`
+	redisScriptFetch = `
+local ids = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+local result = {}
+for _, id in ipairs(ids) do
+	local data = redis.call('HGET', KEYS[1], id)
+	if data then
+		redis.call('ZADD', KEYS[2], ARGV[3], id)
+		table.insert(result, data)
+	else
+		redis.call('ZREM', KEYS[2], id)
+	end
+end
+return result
+`
+	redisScriptUpdate = `
+if redis.call('HEXISTS', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+if ARGV[4] == '1' then
+	redis.call('ZREM', KEYS[2], ARGV[1])
+else
+	redis.call('ZADD', KEYS[2], ARGV[3], ARGV[1])
+end
+return 1
+`
+	redisScriptRemove = `
+redis.call('HDEL', KEYS[1], ARGV[1])
+redis.call('ZREM', KEYS[2], ARGV[1])
+if ARGV[2] ~= '' and redis.call('HGET', KEYS[3], ARGV[2]) == ARGV[1] then
+	redis.call('HDEL', KEYS[3], ARGV[2])
+end
+return 1
+`
+)
+
+// NewStorageRedis creates and returns a redis storage object for job.
+// The optional parameter `prefix` specifies the key prefix, which is DefaultStorageRedisPrefix in default.
+// It returns error if `redis` is nil.
+func NewStorageRedis(redis *gredis.Redis, prefix ...string) (*StorageRedis, error) {
+	if redis == nil {
+		return nil, gerror.NewCode(gcode.CodeInvalidParameter, `redis instance for storage cannot be empty`)
+	}
+	keyPrefix := DefaultStorageRedisPrefix
+	if len(prefix) > 0 && prefix[0] != "" {
+		keyPrefix = prefix[0]
+	}
+	return &StorageRedis{
+		redis:          redis,
+		keyJobs:        keyPrefix + "jobs",
+		keyScheduled:   keyPrefix + "scheduled",
+		keyIdempotency: keyPrefix + "idempotency",
+	}, nil
+}
+
+// Add implements the Storage interface.
+func (s *StorageRedis) Add(ctx context.Context, job *Job) (id string, err error) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return "", gerror.Wrap(err, `json.Marshal failed`)
+	}
+	v, err := s.redis.Eval(
+		ctx, redisScriptAdd, 3,
+		[]string{s.keyJobs, s.keyScheduled, s.keyIdempotency},
+		[]interface{}{job.Id, data, job.RunAt.UnixMilli(), job.IdempotencyKey},
+	)
+	if err != nil {
+		return "", err
+	}
+	return v.String(), nil
+}
+
+// Fetch implements the Storage interface.
+func (s *StorageRedis) Fetch(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*Job, error) {
+	v, err := s.redis.Eval(
+		ctx, redisScriptFetch, 2,
+		[]string{s.keyJobs, s.keyScheduled},
+		[]interface{}{now.UnixMilli(), limit, now.Add(lease).UnixMilli()},
+	)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]*Job, 0)
+	for _, data := range v.Strings() {
+		job, err := s.decode(data)
+		if err != nil {
+			return nil, err
+		}
+		job.RunAt = now.Add(lease)
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// Update implements the Storage interface.
+func (s *StorageRedis) Update(ctx context.Context, job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return gerror.Wrap(err, `json.Marshal failed`)
+	}
+	finished := "0"
+	if job.IsFinished() {
+		finished = "1"
+	}
+	_, err = s.redis.Eval(
+		ctx, redisScriptUpdate, 2,
+		[]string{s.keyJobs, s.keyScheduled},
+		[]interface{}{job.Id, data, job.RunAt.UnixMilli(), finished},
+	)
+	return err
+}
+
+// Get implements the Storage interface.
+func (s *StorageRedis) Get(ctx context.Context, id string) (*Job, error) {
+	v, err := s.redis.HGet(ctx, s.keyJobs, id)
+	if err != nil || v.IsNil() {
+		return nil, err
+	}
+	return s.decode(v.String())
+}
+
+// List implements the Storage interface.
+func (s *StorageRedis) List(ctx context.Context, status Status) ([]*Job, error) {
+	values, err := s.redis.HVals(ctx, s.keyJobs)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]*Job, 0)
+	for _, v := range values {
+		job, err := s.decode(v.String())
+		if err != nil {
+			return nil, err
+		}
+		if status == "" || job.Status == status {
+			jobs = append(jobs, job)
+		}
+	}
+	sortJobs(jobs)
+	return jobs, nil
+}
+
+// Remove implements the Storage interface.
+func (s *StorageRedis) Remove(ctx context.Context, id string) error {
+	job, err := s.Get(ctx, id)
+	if err != nil || job == nil {
+		return err
+	}
+	_, err = s.redis.Eval(
+		ctx, redisScriptRemove, 3,
+		[]string{s.keyJobs, s.keyScheduled, s.keyIdempotency},
+		[]interface{}{job.Id, job.IdempotencyKey},
+	)
+	return err
+}
+
+// decode decodes the job from JSON `data`.
+func (s *StorageRedis) decode(data string) (*Job, error) {
+	job := &Job{}
+	if err := json.UnmarshalUseNumber([]byte(data), job); err != nil {
+		return nil, gerror.Wrap(err, `decode job failed`)
+	}
+	return job, nil
+}
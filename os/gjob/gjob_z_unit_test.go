@@ -0,0 +1,229 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gjob_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gjob"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+type testPayload struct {
+	OrderId int
+	Email   string
+}
+
+func newTestManager() *gjob.Manager {
+	return gjob.New(gjob.NewStorageMemory(), gjob.Option{
+		Workers:      2,
+		PollInterval: 10 * time.Millisecond,
+		Backoff:      10 * time.Millisecond,
+		MaxBackoff:   20 * time.Millisecond,
+	})
+}
+
+func Test_Manager_Process(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx      = gctx.New()
+			manager  = newTestManager()
+			received = garray.New(true)
+		)
+		manager.Register("email", func(ctx context.Context, job *gjob.Job) error {
+			var payload *testPayload
+			if err := job.Scan(&payload); err != nil {
+				return err
+			}
+			received.Append(payload)
+			return nil
+		})
+		t.AssertNil(manager.Start(ctx))
+		t.AssertNE(manager.Start(ctx), nil)
+		defer manager.Stop(ctx)
+
+		id, err := manager.Enqueue(ctx, "email", testPayload{OrderId: 1, Email: "john@goframe.org"})
+		t.AssertNil(err)
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(received.Len(), 1)
+		t.Assert(received.At(0).(*testPayload).Email, "john@goframe.org")
+
+		job, err := manager.Get(ctx, id)
+		t.AssertNil(err)
+		t.Assert(job.Status, gjob.StatusSucceeded)
+		t.Assert(job.Attempt, 1)
+	})
+}
+
+func Test_Manager_Delay(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = gctx.New()
+			manager = newTestManager()
+			count   = gtype.NewInt()
+		)
+		manager.Register("delay", func(ctx context.Context, job *gjob.Job) error {
+			count.Add(1)
+			return nil
+		})
+		t.AssertNil(manager.Start(ctx))
+		defer manager.Stop(ctx)
+
+		_, err := manager.Enqueue(ctx, "delay", nil, gjob.EnqueueOption{Delay: 300 * time.Millisecond})
+		t.AssertNil(err)
+		time.Sleep(100 * time.Millisecond)
+		t.Assert(count.Val(), 0)
+		time.Sleep(400 * time.Millisecond)
+		t.Assert(count.Val(), 1)
+	})
+}
+
+func Test_Manager_RetryAndDead(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = gctx.New()
+			manager = newTestManager()
+			count   = gtype.NewInt()
+			fixed   = gtype.NewBool()
+		)
+		manager.Register("fail", func(ctx context.Context, job *gjob.Job) error {
+			count.Add(1)
+			if fixed.Val() {
+				return nil
+			}
+			return errors.New("service unavailable")
+		})
+		t.AssertNil(manager.Start(ctx))
+		defer manager.Stop(ctx)
+
+		id, err := manager.Enqueue(ctx, "fail", nil, gjob.EnqueueOption{MaxRetries: 2})
+		t.AssertNil(err)
+		time.Sleep(500 * time.Millisecond)
+		t.Assert(count.Val(), 3)
+
+		job, err := manager.Get(ctx, id)
+		t.AssertNil(err)
+		t.Assert(job.Status, gjob.StatusDead)
+		t.Assert(job.Attempt, 3)
+		t.Assert(job.LastError, "service unavailable")
+
+		dead, err := manager.List(ctx, gjob.StatusDead)
+		t.AssertNil(err)
+		t.Assert(len(dead), 1)
+
+		// Requeue after the failure reason is fixed.
+		fixed.Set(true)
+		t.AssertNil(manager.Requeue(ctx, id))
+		time.Sleep(100 * time.Millisecond)
+		job, err = manager.Get(ctx, id)
+		t.AssertNil(err)
+		t.Assert(job.Status, gjob.StatusSucceeded)
+		t.Assert(count.Val(), 4)
+
+		t.AssertNE(manager.Requeue(ctx, "not-exist"), nil)
+	})
+}
+
+func Test_Manager_Panic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = gctx.New()
+			manager = newTestManager()
+		)
+		manager.Register("panic", func(ctx context.Context, job *gjob.Job) error {
+			panic("unexpected")
+		})
+		t.AssertNil(manager.Start(ctx))
+		defer manager.Stop(ctx)
+
+		id, err := manager.Enqueue(ctx, "panic", nil, gjob.EnqueueOption{MaxRetries: -1})
+		t.AssertNil(err)
+		id2, err := manager.Enqueue(ctx, "no-handler", nil, gjob.EnqueueOption{MaxRetries: -1})
+		t.AssertNil(err)
+		time.Sleep(100 * time.Millisecond)
+
+		job, err := manager.Get(ctx, id)
+		t.AssertNil(err)
+		t.Assert(job.Status, gjob.StatusDead)
+		t.Assert(job.LastError, "unexpected")
+
+		job, err = manager.Get(ctx, id2)
+		t.AssertNil(err)
+		t.Assert(job.Status, gjob.StatusDead)
+	})
+}
+
+func Test_Manager_Idempotency(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = gctx.New()
+			manager = newTestManager()
+			option  = gjob.EnqueueOption{IdempotencyKey: "order-1", Delay: time.Hour}
+		)
+		id1, err := manager.Enqueue(ctx, "email", 1, option)
+		t.AssertNil(err)
+		id2, err := manager.Enqueue(ctx, "email", 2, option)
+		t.AssertNil(err)
+		t.Assert(id1, id2)
+
+		jobs, err := manager.List(ctx, gjob.StatusPending)
+		t.AssertNil(err)
+		t.Assert(len(jobs), 1)
+		t.Assert(jobs[0].Payload, "1")
+
+		// Key released after removing.
+		t.AssertNil(manager.Remove(ctx, id1))
+		id3, err := manager.Enqueue(ctx, "email", 3, option)
+		t.AssertNil(err)
+		t.AssertNE(id3, id1)
+	})
+}
+
+func Test_Manager_Workers(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = gctx.New()
+			manager = newTestManager()
+			current = gtype.NewInt()
+			max     = gtype.NewInt()
+			done    = gtype.NewInt()
+		)
+		manager.Register("slow", func(ctx context.Context, job *gjob.Job) error {
+			n := current.Add(1)
+			if n > max.Val() {
+				max.Set(n)
+			}
+			time.Sleep(50 * time.Millisecond)
+			current.Add(-1)
+			done.Add(1)
+			return nil
+		})
+		for i := 0; i < 6; i++ {
+			_, err := manager.Enqueue(ctx, "slow", i)
+			t.AssertNil(err)
+		}
+		t.AssertNil(manager.Start(ctx))
+		time.Sleep(500 * time.Millisecond)
+		t.AssertNil(manager.Stop(ctx))
+		t.Assert(done.Val(), 6)
+		t.AssertLE(max.Val(), 2)
+	})
+}
+
+func Test_NewStorageRedis_Nil(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		storage, err := gjob.NewStorageRedis(nil)
+		t.AssertNE(err, nil)
+		t.Assert(storage, nil)
+	})
+}
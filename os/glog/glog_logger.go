@@ -190,6 +190,10 @@ func (l *Logger) print(ctx context.Context, level int, stack string, values ...a
 		if traceId := spanCtx.TraceID(); traceId.IsValid() {
 			input.TraceId = traceId.String()
 		}
+		// Span events.
+		if l.config.SpanEventsEnabled && level&spanEventLevels > 0 {
+			l.addSpanEvent(ctx, input)
+		}
 		// Context values.
 		if len(l.config.CtxKeys) > 0 {
 			for _, ctxKey := range l.config.CtxKeys {
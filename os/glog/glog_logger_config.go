@@ -47,6 +47,7 @@ type Config struct {
 	RotateCheckInterval  time.Duration  `json:"rotateCheckInterval"`  // Asynchronously checks the backups and expiration at intervals. It's 1 hour in default.
 	StdoutColorDisabled  bool           `json:"stdoutColorDisabled"`  // Logging level prefix with color to writer or not (false in default).
 	WriterColorEnable    bool           `json:"writerColorEnable"`    // Logging level prefix with color to writer or not (false in default).
+	SpanEventsEnabled    bool           `json:"spanEventsEnabled"`    // Add warning and error logging content as events to the span of context or not (false in default).
 	internalConfig
 }
 
@@ -291,3 +292,9 @@ func (l *Logger) SetWriterColorEnable(enabled bool) {
 func (l *Logger) SetStdoutColorDisabled(disabled bool) {
 	l.config.StdoutColorDisabled = disabled
 }
+
+// SetSpanEventsEnabled enables or disables adding warning, error and critical
+// logging content as events to the recording span of context.
+func (l *Logger) SetSpanEventsEnabled(enabled bool) {
+	l.config.SpanEventsEnabled = enabled
+}
@@ -0,0 +1,63 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package glog
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	spanEventName             = "log"
+	spanEventAttrLogSeverity  = "log.severity"
+	spanEventAttrLogMessage   = "log.message"
+	spanEventAttrErrorCode    = "error.code"
+	spanEventAttrErrorMessage = "error.message"
+	spanEventAttrStack        = "exception.stacktrace"
+)
+
+// spanEventLevels are the logging levels whose content is added as span events.
+const spanEventLevels = LEVEL_WARN | LEVEL_ERRO | LEVEL_CRIT | LEVEL_PANI | LEVEL_FATA
+
+// addSpanEvent adds the logging content as an event to the recording span of `ctx`,
+// along with the error code and stack if any.
+func (l *Logger) addSpanEvent(ctx context.Context, input *HandlerInput) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	var (
+		stack = input.Stack
+		attrs = []attribute.KeyValue{
+			attribute.String(spanEventAttrLogSeverity, input.LevelFormat),
+			attribute.String(spanEventAttrLogMessage, input.ValuesContent()),
+		}
+	)
+	for _, v := range input.Values {
+		err, ok := v.(error)
+		if !ok {
+			continue
+		}
+		if code := gerror.Code(err); code != gcode.CodeNil {
+			attrs = append(attrs, attribute.Int(spanEventAttrErrorCode, code.Code()))
+		}
+		attrs = append(attrs, attribute.String(spanEventAttrErrorMessage, err.Error()))
+		if stack == "" && gerror.HasStack(err) {
+			stack = gerror.Stack(err)
+		}
+		break
+	}
+	if stack != "" {
+		attrs = append(attrs, attribute.String(spanEventAttrStack, stack))
+	}
+	span.AddEvent(spanEventName, trace.WithAttributes(attrs...))
+}
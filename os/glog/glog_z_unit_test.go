@@ -15,6 +15,11 @@ import (
 	"testing"
 	"time"
 
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gfile"
 	"github.com/gogf/gf/v2/os/glog"
@@ -487,3 +492,44 @@ func Test_Concurrent(t *testing.T) {
 		t.Assert(gstr.Count(content, s), c)
 	})
 }
+
+func Test_SpanEvents(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			recorder      = tracetest.NewSpanRecorder()
+			provider      = sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorder))
+			spanCtx, span = provider.Tracer("glog").Start(context.Background(), "test")
+			buffer        = bytes.NewBuffer(nil)
+			l             = glog.NewWithWriter(buffer)
+		)
+		l.Info(spanCtx, "info")
+		l.Error(spanCtx, "error")
+		t.Assert(len(span.(sdkTrace.ReadOnlySpan).Events()), 0)
+
+		l.SetSpanEventsEnabled(true)
+		l.Info(spanCtx, "info")
+		l.Warning(spanCtx, "warning")
+		l.Error(spanCtx, gerror.NewCode(gcode.CodeInvalidParameter, "invalid"))
+		span.End()
+
+		spans := recorder.Ended()
+		t.Assert(len(spans), 1)
+		events := spans[0].Events()
+		t.Assert(len(events), 2)
+		t.Assert(events[0].Name, "log")
+		attrs := make(map[string]string)
+		for _, attr := range events[0].Attributes {
+			attrs[string(attr.Key)] = attr.Value.Emit()
+		}
+		t.Assert(attrs["log.severity"], "WARN")
+		t.Assert(attrs["log.message"], "warning")
+		attrs = make(map[string]string)
+		for _, attr := range events[1].Attributes {
+			attrs[string(attr.Key)] = attr.Value.Emit()
+		}
+		t.Assert(attrs["log.severity"], "ERRO")
+		t.Assert(attrs["error.code"], gcode.CodeInvalidParameter.Code())
+		t.Assert(attrs["error.message"], "invalid")
+		t.AssertNE(attrs["exception.stacktrace"], "")
+	})
+}
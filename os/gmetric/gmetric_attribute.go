@@ -10,9 +10,10 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
 
 	"github.com/gogf/gf/v2/internal/json"
-	"github.com/gogf/gf/v2/os/gfile"
 )
 
 // Attributes is a slice of Attribute.
@@ -46,7 +47,15 @@ var (
 
 func init() {
 	hostname, _ = os.Hostname()
-	processPath = gfile.SelfPath()
+	// It does not use gfile.SelfPath here, as package gfile depends on gcache,
+	// which is instrumented by gmetric.
+	processPath, _ = exec.LookPath(os.Args[0])
+	if processPath != "" {
+		processPath, _ = filepath.Abs(processPath)
+	}
+	if processPath == "" {
+		processPath, _ = filepath.Abs(os.Args[0])
+	}
 }
 
 // CommonAttributes returns the common used attributes for an instrument.
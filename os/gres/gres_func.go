@@ -33,10 +33,19 @@ func init() {
 `
 )
 
+var (
+	// zstdMagic is the magic number of zstd frame, which distinguishes zstd packed data from gzip.
+	zstdMagic = []byte{0x28, 0xB5, 0x2F, 0xFD}
+	// gzipMagic is the magic number of gzip, as brotli data has no magic number.
+	gzipMagic = []byte{0x1F, 0x8B}
+)
+
 // Option contains the extra options for Pack functions.
 type Option struct {
 	Prefix   string // The file path prefix for each file item in resource manager.
 	KeepPath bool   // Keep the passed path when packing, usually for relative path.
+	Zstd     bool   // Compress the packed data using zstd instead of gzip, which needs the zstd adapter of gcompress.
+	Brotli   bool   // Compress the packed data using brotli instead of gzip, which needs the brotli adapter of gcompress and is ignored if Zstd is true.
 }
 
 // Pack packs the path specified by `srcPaths` into bytes.
@@ -63,7 +72,13 @@ func PackWithOption(srcPaths string, option Option) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	// Gzip the data bytes to reduce the size.
+	// Compress the data bytes to reduce the size.
+	if option.Zstd {
+		return gcompress.Zstd(buffer.Bytes(), gcompress.ZstdLevelBest)
+	}
+	if option.Brotli {
+		return gcompress.Brotli(buffer.Bytes(), gcompress.BrotliQualityBest)
+	}
 	return gcompress.Gzip(buffer.Bytes(), 9)
 }
 
@@ -146,7 +161,7 @@ func UnpackContent(content string) ([]*File, error) {
 	if isHexStr(content) {
 		// It here keeps compatible with old version packing string using hex string.
 		// TODO remove this support in the future.
-		data, err = gcompress.UnGzip(hexStrToBytes(content))
+		data, err = decompress(hexStrToBytes(content))
 		if err != nil {
 			return nil, err
 		}
@@ -156,12 +171,12 @@ func UnpackContent(content string) ([]*File, error) {
 		if err != nil {
 			return nil, err
 		}
-		data, err = gcompress.UnGzip(b)
+		data, err = decompress(b)
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		data, err = gcompress.UnGzip([]byte(content))
+		data, err = decompress([]byte(content))
 		if err != nil {
 			return nil, err
 		}
@@ -178,6 +193,18 @@ func UnpackContent(content string) ([]*File, error) {
 	return array, nil
 }
 
+// decompress decompresses the packed `data`, which is compressed using zstd, gzip or brotli.
+func decompress(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, zstdMagic):
+		return gcompress.UnZstd(data)
+	case bytes.HasPrefix(data, gzipMagic):
+		return gcompress.UnGzip(data)
+	default:
+		return gcompress.UnBrotli(data)
+	}
+}
+
 // isBase64 checks and returns whether given content `s` is base64 string.
 // It returns true if `s` is base64 string, or false if not.
 func isBase64(s string) bool {
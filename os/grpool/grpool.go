@@ -20,6 +20,9 @@ import (
 // Func is the pool function which contains context parameter.
 type Func func(ctx context.Context)
 
+// RetryFunc is the pool function which returns error for retry purpose.
+type RetryFunc func(ctx context.Context) error
+
 // RecoverFunc is the pool runtime panic recover function which contains context parameter.
 type RecoverFunc func(ctx context.Context, exception error)
 
@@ -76,6 +79,12 @@ func Add(ctx context.Context, f Func) error {
 	return defaultPool.Add(ctx, f)
 }
 
+// AddWithRetry pushes a new job to the default pool, which is requeued if it returns retryable error.
+// The job will be executed asynchronously.
+func AddWithRetry(ctx context.Context, retryFunc RetryFunc, retryCount int) error {
+	return defaultPool.AddWithRetry(ctx, retryFunc, retryCount)
+}
+
 // AddWithRecover pushes a new job to the default pool with specified recover function.
 //
 // The optional `recoverFunc` is called when any panic during executing of `userFunc`.
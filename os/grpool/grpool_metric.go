@@ -0,0 +1,118 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package grpool
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/os/gmetric"
+)
+
+type localMetricManager struct {
+	PoolJobTotal     gmetric.Counter
+	PoolJobActive    gmetric.UpDownCounter
+	PoolWorkerActive gmetric.UpDownCounter
+	PoolJobDuration  gmetric.Histogram
+}
+
+const (
+	metricInstrumentName = "github.com/gogf/gf/v2/os/grpool"
+)
+
+var (
+	// metricManager for goroutine pool metrics.
+	metricManager = newMetricManager()
+)
+
+func newMetricManager() *localMetricManager {
+	meter := gmetric.GetGlobalProvider().Meter(gmetric.MeterOption{
+		Instrument:        metricInstrumentName,
+		InstrumentVersion: gf.VERSION,
+	})
+	mm := &localMetricManager{
+		PoolJobTotal: meter.MustCounter(
+			"grpool.job.total",
+			gmetric.MetricOption{
+				Help:       "Total job number added to goroutine pools.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		PoolJobActive: meter.MustUpDownCounter(
+			"grpool.job.active",
+			gmetric.MetricOption{
+				Help:       "Number of jobs waiting or running in goroutine pools.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		PoolWorkerActive: meter.MustUpDownCounter(
+			"grpool.worker.active",
+			gmetric.MetricOption{
+				Help:       "Number of active goroutine workers in goroutine pools.",
+				Unit:       "",
+				Attributes: gmetric.Attributes{},
+			},
+		),
+		PoolJobDuration: meter.MustHistogram(
+			"grpool.job.duration",
+			gmetric.MetricOption{
+				Help:       "Measures the execution duration of jobs in goroutine pools.",
+				Unit:       "ms",
+				Attributes: gmetric.Attributes{},
+				Buckets: []float64{
+					1,
+					5,
+					10,
+					25,
+					50,
+					100,
+					250,
+					500,
+					1000,
+					2500,
+					5000,
+					10000,
+					30000,
+					60000,
+				},
+			},
+		),
+	}
+	return mm
+}
+
+// handleMetricsAfterJobAdded records the metrics after a job is added to the pool.
+func (p *Pool) handleMetricsAfterJobAdded(ctx context.Context) {
+	if !gmetric.IsEnabled() {
+		return
+	}
+	metricManager.PoolJobTotal.Inc(ctx)
+	metricManager.PoolJobActive.Inc(ctx)
+}
+
+// handleMetricsAfterJobDone records the metrics after a job is done in the pool.
+func (p *Pool) handleMetricsAfterJobDone(ctx context.Context, durationMilli int64) {
+	if !gmetric.IsEnabled() {
+		return
+	}
+	metricManager.PoolJobActive.Dec(ctx)
+	metricManager.PoolJobDuration.Record(float64(durationMilli))
+}
+
+// handleMetricsWorkerChanged records the metrics when a worker is forked or exits.
+func (p *Pool) handleMetricsWorkerChanged(ctx context.Context, forked bool) {
+	if !gmetric.IsEnabled() {
+		return
+	}
+	if forked {
+		metricManager.PoolWorkerActive.Inc(ctx)
+	} else {
+		metricManager.PoolWorkerActive.Dec(ctx)
+	}
+}
@@ -11,6 +11,8 @@ import (
 
 	"github.com/gogf/gf/v2/errors/gcode"
 	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/intlog"
+	"github.com/gogf/gf/v2/os/gtime"
 )
 
 // Add pushes a new job to the pool.
@@ -26,11 +28,27 @@ func (p *Pool) Add(ctx context.Context, f Func) error {
 		Ctx:  ctx,
 		Func: f,
 	})
+	p.handleMetricsAfterJobAdded(ctx)
 	// Check and fork new worker.
 	p.checkAndForkNewGoroutineWorker()
 	return nil
 }
 
+// AddWithRetry pushes a new job to the pool, which is requeued to the pool at most `retryCount`
+// times if it returns retryable error that is checked by gerror.IsRetryable.
+// The job will be executed asynchronously.
+func (p *Pool) AddWithRetry(ctx context.Context, retryFunc RetryFunc, retryCount int) error {
+	return p.Add(ctx, func(ctx context.Context) {
+		err := retryFunc(ctx)
+		if err == nil || retryCount <= 0 || !gerror.IsRetryable(err) {
+			return
+		}
+		if err = p.AddWithRetry(ctx, retryFunc, retryCount-1); err != nil {
+			intlog.Errorf(ctx, `%+v`, err)
+		}
+	})
+}
+
 // AddWithRecover pushes a new job to the pool with specified recover function.
 //
 // The optional `recoverFunc` is called when any panic during executing of `userFunc`.
@@ -103,11 +121,17 @@ func (p *Pool) checkAndForkNewGoroutineWorker() {
 }
 
 func (p *Pool) asynchronousWorker() {
-	defer p.count.Add(-1)
+	var ctx = context.Background()
+	p.handleMetricsWorkerChanged(ctx, true)
+	defer func() {
+		p.count.Add(-1)
+		p.handleMetricsWorkerChanged(ctx, false)
+	}()
 
 	var (
-		listItem interface{}
-		poolItem *localPoolItem
+		listItem        interface{}
+		poolItem        *localPoolItem
+		timestampMilli1 int64
 	)
 	// Harding working, one by one, job never empty, worker never die.
 	for !p.closed.Val() {
@@ -116,6 +140,8 @@ func (p *Pool) asynchronousWorker() {
 			return
 		}
 		poolItem = listItem.(*localPoolItem)
+		timestampMilli1 = gtime.TimestampMilli()
 		poolItem.Func(poolItem.Ctx)
+		p.handleMetricsAfterJobDone(poolItem.Ctx, gtime.TimestampMilli()-timestampMilli1)
 	}
 }
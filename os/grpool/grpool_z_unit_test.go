@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/os/grpool"
 	"github.com/gogf/gf/v2/test/gtest"
 )
@@ -137,3 +138,28 @@ func Test_AddWithRecover(t *testing.T) {
 		t.Assert(array.Len(), 2)
 	})
 }
+
+func Test_AddWithRetry(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			err       error
+			retryable = garray.NewArray(true)
+			permanent = garray.NewArray(true)
+		)
+		err = grpool.AddWithRetry(ctx, func(ctx context.Context) error {
+			retryable.Append(1)
+			return gerror.MarkRetryable(gerror.New("retryable"))
+		}, 2)
+		t.AssertNil(err)
+		err = grpool.AddWithRetry(ctx, func(ctx context.Context) error {
+			permanent.Append(1)
+			return gerror.New("permanent")
+		}, 2)
+		t.AssertNil(err)
+
+		time.Sleep(500 * time.Millisecond)
+
+		t.Assert(retryable.Len(), 3)
+		t.Assert(permanent.Len(), 1)
+	})
+}
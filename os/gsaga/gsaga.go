@@ -0,0 +1,144 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+// Package gsaga implements a coordinator for distributed transactions in saga/TCC pattern.
+//
+// A saga is composed of ordered steps, each of which has an action and an optional compensation
+// undoing the action. If any action fails after retries, the compensations of the executed steps
+// are called in reverse order. The steps can also have confirm functions, which are called after
+// all actions succeed, for the Try-Confirm-Cancel pattern.
+//
+// The state of each saga is persisted in Storage after every step, so that the unfinished sagas
+// can be resumed after process restarting. As the steps may be executed more than once,
+// they should be idempotent, which can be implemented using StepContext.IdempotencyKey.
+package gsaga
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// State is the state of saga persisted in Storage.
+type State struct {
+	Id        string            `json:"id"`        // Unique id of the saga.
+	Name      string            `json:"name"`      // Name of the registered saga definition.
+	Payload   []byte            `json:"payload"`   // JSON encoded payload.
+	Status    Status            `json:"status"`    // Current status.
+	Step      int               `json:"step"`      // Index of the step to be executed in current status.
+	Reason    string            `json:"reason"`    // Error of the failed action, which aborts the saga.
+	LastError string            `json:"lastError"` // Error of the last failed step, which is cleared when the step succeeds.
+	Trace     map[string]string `json:"trace"`     // Tracing carrier of the starting context, which is used in resuming.
+	CreatedAt time.Time         `json:"createdAt"` // Creating time.
+	UpdatedAt time.Time         `json:"updatedAt"` // Last updating time.
+}
+
+// Status is the status of saga.
+type Status string
+
+const (
+	StatusRunning      Status = "running"      // Executing the actions.
+	StatusConfirming   Status = "confirming"   // Executing the confirms after all actions succeed.
+	StatusCompensating Status = "compensating" // Executing the compensations after an action fails.
+	StatusSucceeded    Status = "succeeded"    // All actions and confirms succeeded.
+	StatusAborted      Status = "aborted"      // An action failed and all compensations succeeded.
+)
+
+// Phase is the phase of step execution.
+type Phase string
+
+const (
+	PhaseAction     Phase = "action"     // Executing the action of step.
+	PhaseConfirm    Phase = "confirm"    // Executing the confirm of step.
+	PhaseCompensate Phase = "compensate" // Executing the compensation of step.
+)
+
+// StepFunc is the function of a step phase.
+// It is retried if it returns error, so it should be idempotent.
+type StepFunc func(ctx context.Context, sc *StepContext) error
+
+// Step is a step of saga.
+type Step struct {
+	Name       string   // Unique name of the step in the saga.
+	Action     StepFunc // Action of the step, or the Try phase of TCC.
+	Compensate StepFunc // (Optional) Compensation undoing the action, or the Cancel phase of TCC.
+	Confirm    StepFunc // (Optional) Confirm phase of TCC, which is called after all actions succeed.
+}
+
+// StepContext is the context of step execution.
+type StepContext struct {
+	SagaId  string // Id of the saga.
+	Step    string // Name of the step.
+	Phase   Phase  // Phase of the execution.
+	Attempt int    // Attempt number of the execution in current process, which starts from 1.
+	Payload []byte // JSON encoded payload of the saga.
+}
+
+// IdempotencyKey returns the unique key of the step execution, which is the same among retries
+// and resuming, so that the remote services can use it to avoid duplicated execution.
+func (sc *StepContext) IdempotencyKey() string {
+	return fmt.Sprintf(`%s:%s:%s`, sc.SagaId, sc.Step, sc.Phase)
+}
+
+// Scan decodes the payload of saga to `pointer`, which is commonly a pointer to struct.
+func (sc *StepContext) Scan(pointer interface{}) error {
+	return scanPayload(sc.Payload, pointer)
+}
+
+// Scan decodes the payload of saga to `pointer`, which is commonly a pointer to struct.
+func (s *State) Scan(pointer interface{}) error {
+	return scanPayload(s.Payload, pointer)
+}
+
+// Clone returns a copy of the state.
+func (s *State) Clone() *State {
+	clone := *s
+	clone.Payload = append([]byte(nil), s.Payload...)
+	if s.Trace != nil {
+		clone.Trace = make(map[string]string, len(s.Trace))
+		for k, v := range s.Trace {
+			clone.Trace[k] = v
+		}
+	}
+	return &clone
+}
+
+// IsFinished checks and returns whether the saga is finished, succeeded or aborted.
+func (s *State) IsFinished() bool {
+	return s.Status == StatusSucceeded || s.Status == StatusAborted
+}
+
+// scanPayload decodes JSON `payload` to `pointer`.
+func scanPayload(payload []byte, pointer interface{}) error {
+	if len(payload) == 0 {
+		return nil
+	}
+	var data interface{}
+	if err := json.UnmarshalUseNumber(payload, &data); err != nil {
+		return err
+	}
+	return gconv.Scan(data, pointer)
+}
+
+// encodePayload encodes `payload` as JSON, which keeps it if it's already bytes.
+func encodePayload(payload interface{}) ([]byte, error) {
+	switch v := payload.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return v, nil
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `encode saga payload failed`)
+	}
+	return b, nil
+}
@@ -0,0 +1,457 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsaga
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gogf/gf/v2"
+	"github.com/gogf/gf/v2/container/gmap"
+	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/net/gtrace"
+	"github.com/gogf/gf/v2/os/glog"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+// Coordinator executes the registered sagas, and persists their states in Storage.
+type Coordinator struct {
+	storage     Storage
+	option      Option
+	definitions *gmap.StrAnyMap // Saga name to []Step.
+	executing   *gset.StrSet    // Ids of the sagas being executed in current process.
+}
+
+// Option is the option for Coordinator.
+type Option struct {
+	MaxRetries int           // Max retry count of each step phase, which is 3 in default. Negative value means no retry.
+	Backoff    time.Duration // Base delay of exponential backoff for retries, which is 100 milliseconds in default.
+	MaxBackoff time.Duration // Max delay of retries, which is 10 seconds in default.
+	Logger     *glog.Logger  // Logger for execution errors, which uses the default logger if not set.
+}
+
+// StartOption is the option for starting a saga.
+type StartOption struct {
+	// Id is the unique id of the saga, which is generated if empty.
+	// If there's already a saga with the id, it does not start another one, which makes the
+	// starting idempotent, eg using the order id as saga id.
+	Id string
+}
+
+const (
+	instrumentName        = "github.com/gogf/gf/v2/os/gsaga"
+	tracingAttrSagaId     = "saga.id"
+	tracingAttrSagaName   = "saga.name"
+	tracingAttrSagaStep   = "saga.step"
+	tracingAttrSagaPhase  = "saga.phase"
+	tracingAttrSagaStatus = "saga.status"
+	defaultMaxRetries     = 3
+	defaultBackoff        = 100 * time.Millisecond
+	defaultMaxBackoff     = 10 * time.Second
+)
+
+// errStateChanged is the error that the saga state is changed by others in executing.
+var errStateChanged = gerror.New(`saga state is changed`)
+
+// NewCoordinator creates and returns a saga coordinator with `storage`.
+// The optional parameter `option` specifies the execution options.
+func NewCoordinator(storage Storage, option ...Option) *Coordinator {
+	var opt Option
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	if opt.MaxRetries == 0 {
+		opt.MaxRetries = defaultMaxRetries
+	}
+	if opt.Backoff <= 0 {
+		opt.Backoff = defaultBackoff
+	}
+	if opt.MaxBackoff <= 0 {
+		opt.MaxBackoff = defaultMaxBackoff
+	}
+	if opt.Logger == nil {
+		opt.Logger = glog.DefaultLogger()
+	}
+	return &Coordinator{
+		storage:     storage,
+		option:      opt,
+		definitions: gmap.NewStrAnyMap(true),
+		executing:   gset.NewStrSet(true),
+	}
+}
+
+// Register registers saga `name` composed of `steps`, which are executed in order.
+// The steps should have unique names and actions, as the names identify the steps in persisted states.
+func (c *Coordinator) Register(name string, steps ...Step) error {
+	if len(steps) == 0 {
+		return gerror.NewCodef(gcode.CodeInvalidParameter, `saga "%s" has no step`, name)
+	}
+	var names = make(map[string]struct{}, len(steps))
+	for _, step := range steps {
+		if step.Name == "" || step.Action == nil {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, `step of saga "%s" should have name and action`, name)
+		}
+		if _, ok := names[step.Name]; ok {
+			return gerror.NewCodef(gcode.CodeInvalidParameter, `duplicated step "%s" of saga "%s"`, step.Name, name)
+		}
+		names[step.Name] = struct{}{}
+	}
+	c.definitions.Set(name, steps)
+	return nil
+}
+
+// Start creates and executes saga `name` with `payload` synchronously, and returns the saga id.
+// The `payload` is encoded as JSON, unless it is []byte.
+//
+// It returns error if the saga is aborted, which means an action failed and the executed steps
+// are compensated, or if any confirm/compensation fails after retries, in which case the saga
+// keeps unfinished and can be continued by Resume or Recover.
+//
+// If the Id of `option` is given and there's already a saga with the id, it does not execute
+// the saga again, but returns the result of the existing one.
+func (c *Coordinator) Start(ctx context.Context, name string, payload interface{}, option ...StartOption) (id string, err error) {
+	var opt StartOption
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	steps, err := c.getSteps(name)
+	if err != nil {
+		return "", err
+	}
+	if opt.Id != "" {
+		existing, err := c.storage.Get(ctx, opt.Id)
+		if err != nil {
+			return opt.Id, err
+		}
+		if existing != nil {
+			return existing.Id, c.existingResult(existing)
+		}
+	}
+	data, err := encodePayload(payload)
+	if err != nil {
+		return "", err
+	}
+	var (
+		now   = time.Now()
+		state = &State{
+			Id:        opt.Id,
+			Name:      name,
+			Payload:   data,
+			Status:    StatusRunning,
+			Trace:     make(map[string]string),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+	)
+	if state.Id == "" {
+		state.Id = guid.S()
+	}
+	// The tracing context is persisted for resuming in other process.
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(state.Trace))
+	if err = c.storage.Create(ctx, state); err != nil {
+		return state.Id, err
+	}
+	return state.Id, c.execute(ctx, steps, state)
+}
+
+// Resume continues executing the unfinished saga with `id` synchronously.
+// It returns the result of the saga if it's already finished.
+func (c *Coordinator) Resume(ctx context.Context, id string) error {
+	_, err := c.resume(ctx, id)
+	return err
+}
+
+// Recover resumes all the unfinished sagas in storage, which is commonly called after process
+// starting to continue the sagas interrupted by crashing or exiting.
+// It continues resuming others if any saga fails, and returns the first error.
+//
+// It can be called by multiple processes at the same time. As the state is updated by Storage.Update
+// only if it is not changed by others, only one process continues executing a saga, and the others
+// stop once they fail updating the state. Note that the current step may be executed by multiple
+// processes in this case, which should be idempotent.
+func (c *Coordinator) Recover(ctx context.Context) error {
+	states, err := c.storage.ListUnfinished(ctx)
+	if err != nil {
+		return err
+	}
+	var firstErr error
+	for _, state := range states {
+		if c.executing.Contains(state.Id) {
+			continue
+		}
+		// The saga aborted in resuming is recovered, as all its steps are compensated.
+		resumed, err := c.resume(ctx, state.Id)
+		if gerror.Is(err, errStateChanged) {
+			// It is executed by another process.
+			continue
+		}
+		if err != nil && (resumed == nil || resumed.Status != StatusAborted) {
+			c.option.Logger.Errorf(ctx, `recover saga "%s" of "%s" failed: %+v`, state.Id, state.Name, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// Get retrieves and returns the state of saga with `id`.
+// It returns nil if the saga does not exist.
+func (c *Coordinator) Get(ctx context.Context, id string) (*State, error) {
+	return c.storage.Get(ctx, id)
+}
+
+// resume continues executing the saga with `id`, and returns its state after execution.
+func (c *Coordinator) resume(ctx context.Context, id string) (*State, error) {
+	state, err := c.storage.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, gerror.NewCodef(gcode.CodeNotFound, `saga "%s" not found`, id)
+	}
+	if state.IsFinished() {
+		return state, c.existingResult(state)
+	}
+	steps, err := c.getSteps(state.Name)
+	if err != nil {
+		return state, err
+	}
+	// It continues the trace of starting if there's no trace in current context.
+	if !trace.SpanContextFromContext(ctx).IsValid() && len(state.Trace) > 0 {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(state.Trace))
+	}
+	return state, c.execute(ctx, steps, state)
+}
+
+// getSteps returns the steps of registered saga `name`.
+func (c *Coordinator) getSteps(name string) ([]Step, error) {
+	v := c.definitions.Get(name)
+	if v == nil {
+		return nil, gerror.NewCodef(gcode.CodeInvalidParameter, `saga "%s" is not registered`, name)
+	}
+	return v.([]Step), nil
+}
+
+// existingResult returns the result of existing saga `state`.
+func (c *Coordinator) existingResult(state *State) error {
+	switch state.Status {
+	case StatusSucceeded:
+		return nil
+	case StatusAborted:
+		return c.abortedError(state)
+	default:
+		return gerror.NewCodef(gcode.CodeInvalidOperation, `saga "%s" is unfinished in status "%s"`, state.Id, state.Status)
+	}
+}
+
+// abortedError returns the error of aborted saga `state`.
+func (c *Coordinator) abortedError(state *State) error {
+	return gerror.NewCodef(gcode.CodeOperationFailed, `saga "%s" of "%s" aborted: %s`, state.Id, state.Name, state.Reason)
+}
+
+// execute executes the `steps` of saga from its current `state` until it's finished,
+// or a confirm/compensation fails after retries.
+func (c *Coordinator) execute(ctx context.Context, steps []Step, state *State) (err error) {
+	if !c.executing.AddIfNotExist(state.Id) {
+		return gerror.NewCodef(gcode.CodeInvalidOperation, `saga "%s" is being executed`, state.Id)
+	}
+	defer c.executing.Remove(state.Id)
+
+	ctx, span := tracer().Start(ctx, "saga "+state.Name, trace.WithSpanKind(trace.SpanKindInternal))
+	defer func() {
+		span.SetAttributes(attribute.String(tracingAttrSagaStatus, string(state.Status)))
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	span.SetAttributes(gtrace.CommonLabels()...)
+	span.SetAttributes(
+		attribute.String(tracingAttrSagaId, state.Id),
+		attribute.String(tracingAttrSagaName, state.Name),
+	)
+
+	for {
+		// The status and step that the state is transited from, for updating the state conditionally.
+		fromStatus, fromStep := state.Status, state.Step
+		switch state.Status {
+		case StatusRunning:
+			if state.Step >= len(steps) {
+				state.Status, state.Step = StatusSucceeded, 0
+				if hasConfirm(steps) {
+					state.Status = StatusConfirming
+				}
+				break
+			}
+			step := steps[state.Step]
+			if err = c.callStep(ctx, state, step, PhaseAction, step.Action); err != nil {
+				// The failed step is also compensated, as its action may be partially done.
+				state.Status = StatusCompensating
+				state.Reason = err.Error()
+				break
+			}
+			state.Step++
+
+		case StatusConfirming:
+			if state.Step >= len(steps) {
+				state.Status, state.Step = StatusSucceeded, 0
+				break
+			}
+			step := steps[state.Step]
+			if err = c.callStep(ctx, state, step, PhaseConfirm, step.Confirm); err != nil {
+				return c.saveFailure(ctx, state, err)
+			}
+			state.Step++
+
+		case StatusCompensating:
+			if state.Step < 0 {
+				state.Status, state.Step = StatusAborted, 0
+				break
+			}
+			step := steps[state.Step]
+			if err = c.callStep(ctx, state, step, PhaseCompensate, step.Compensate); err != nil {
+				return c.saveFailure(ctx, state, err)
+			}
+			state.Step--
+
+		case StatusSucceeded:
+			return nil
+
+		case StatusAborted:
+			return c.abortedError(state)
+
+		default:
+			return gerror.NewCodef(gcode.CodeInternalError, `invalid status "%s" of saga "%s"`, state.Status, state.Id)
+		}
+		state.LastError = ""
+		if err = c.save(ctx, state, fromStatus, fromStep); err != nil {
+			return err
+		}
+	}
+}
+
+// callStep calls function `f` of `step` in `phase` with retries.
+// It does nothing if `f` is nil.
+func (c *Coordinator) callStep(ctx context.Context, state *State, step Step, phase Phase, f StepFunc) (err error) {
+	if f == nil {
+		return nil
+	}
+	ctx, span := tracer().Start(ctx, "saga step "+step.Name, trace.WithSpanKind(trace.SpanKindInternal))
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+	span.SetAttributes(
+		attribute.String(tracingAttrSagaId, state.Id),
+		attribute.String(tracingAttrSagaStep, step.Name),
+		attribute.String(tracingAttrSagaPhase, string(phase)),
+	)
+	sc := &StepContext{
+		SagaId:  state.Id,
+		Step:    step.Name,
+		Phase:   phase,
+		Payload: state.Payload,
+	}
+	for sc.Attempt = 1; ; sc.Attempt++ {
+		if err = c.safeCall(ctx, f, sc); err == nil {
+			return nil
+		}
+		if sc.Attempt > c.option.MaxRetries {
+			return err
+		}
+		delay := c.backoff(sc.Attempt)
+		c.option.Logger.Warningf(
+			ctx, `saga "%s" step "%s" %s attempt %d failed, retry after %s: %+v`,
+			state.Id, step.Name, phase, sc.Attempt, delay, err,
+		)
+		select {
+		case <-ctx.Done():
+			return gerror.WrapCode(gcode.CodeOperationFailed, ctx.Err(), err.Error())
+		case <-time.After(delay):
+		}
+	}
+}
+
+// safeCall calls `f` and converts the panic to error.
+func (c *Coordinator) safeCall(ctx context.Context, f StepFunc, sc *StepContext) (err error) {
+	defer func() {
+		if exception := recover(); exception != nil {
+			if v, ok := exception.(error); ok && gerror.HasStack(v) {
+				err = v
+			} else {
+				err = gerror.NewCodef(gcode.CodeInternalPanic, "%+v", exception)
+			}
+		}
+	}()
+	return f(ctx, sc)
+}
+
+// saveFailure saves the `err` of confirm/compensation to `state`, and returns the error,
+// in which case the saga is left unfinished for resuming.
+func (c *Coordinator) saveFailure(ctx context.Context, state *State, err error) error {
+	state.LastError = err.Error()
+	if saveErr := c.save(ctx, state, state.Status, state.Step); saveErr != nil {
+		c.option.Logger.Errorf(ctx, `update saga "%s" failed: %+v`, state.Id, saveErr)
+	}
+	return gerror.WrapCodef(
+		gcode.CodeOperationFailed, err, `saga "%s" of "%s" failed in status "%s"`,
+		state.Id, state.Name, state.Status,
+	)
+}
+
+// save persists `state` transited from `status` at `step` to storage.
+// It returns error wrapping errStateChanged if the saga is changed by others.
+func (c *Coordinator) save(ctx context.Context, state *State, status Status, step int) error {
+	state.UpdatedAt = time.Now()
+	ok, err := c.storage.Update(ctx, state, status, step)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return gerror.WrapCodef(
+			gcode.CodeInvalidOperation, errStateChanged, `saga "%s" is executed by others`, state.Id,
+		)
+	}
+	return nil
+}
+
+// backoff calculates and returns the exponential delay for retrying after `attempt`.
+func (c *Coordinator) backoff(attempt int) time.Duration {
+	delay := c.option.Backoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= c.option.MaxBackoff {
+			return c.option.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// hasConfirm checks and returns whether any of `steps` has confirm function.
+func hasConfirm(steps []Step) bool {
+	for _, step := range steps {
+		if step.Confirm != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func tracer() trace.Tracer {
+	return otel.GetTracerProvider().Tracer(instrumentName, trace.WithInstrumentationVersion(gf.VERSION))
+}
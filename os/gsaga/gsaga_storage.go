@@ -0,0 +1,31 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsaga
+
+import (
+	"context"
+)
+
+// Storage is the interface definition for saga state storage.
+type Storage interface {
+	// Create adds the new saga `state` to the storage.
+	// It returns error if there's already a saga with the same id.
+	Create(ctx context.Context, state *State) error
+
+	// Update saves the `state` of existing saga only if it is still in `status` at `step` in the storage,
+	// which are the status and step that `state` is transited from.
+	// It returns false if the saga is changed by others, eg: it is executed by another process concurrently.
+	Update(ctx context.Context, state *State, status Status, step int) (bool, error)
+
+	// Get retrieves and returns the saga state with `id`.
+	// It returns nil if the saga does not exist.
+	Get(ctx context.Context, id string) (*State, error)
+
+	// ListUnfinished retrieves and returns the sagas that are not succeeded or aborted,
+	// ordered by creating time.
+	ListUnfinished(ctx context.Context) ([]*State, error)
+}
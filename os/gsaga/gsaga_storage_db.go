@@ -0,0 +1,163 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsaga
+
+import (
+	"context"
+
+	"github.com/gogf/gf/v2/database/gdb"
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/internal/json"
+)
+
+// StorageDb implements the Storage interface with database table.
+//
+// The table should have columns:
+// id(primary key), name, payload, status, step, reason, last_error, trace, created_at, updated_at,
+// in which the payload and trace are text in JSON, eg for MySQL:
+//
+//	CREATE TABLE `saga` (
+//	  `id`         varchar(64) NOT NULL,
+//	  `name`       varchar(128) NOT NULL,
+//	  `payload`    text,
+//	  `status`     varchar(32) NOT NULL,
+//	  `step`       int NOT NULL,
+//	  `reason`     text,
+//	  `last_error` text,
+//	  `trace`      text,
+//	  `created_at` datetime NOT NULL,
+//	  `updated_at` datetime NOT NULL,
+//	  PRIMARY KEY (`id`),
+//	  KEY `status` (`status`)
+//	);
+type StorageDb struct {
+	db    gdb.DB // Database for saga storage.
+	table string // Table name of saga states.
+}
+
+const (
+	// DefaultStorageDbTable is the default table name of database storage.
+	DefaultStorageDbTable = "saga"
+)
+
+// NewStorageDb creates and returns a database storage for saga.
+// The optional parameter `table` specifies the table name, which is DefaultStorageDbTable in default.
+func NewStorageDb(db gdb.DB, table ...string) *StorageDb {
+	s := &StorageDb{
+		db:    db,
+		table: DefaultStorageDbTable,
+	}
+	if len(table) > 0 && table[0] != "" {
+		s.table = table[0]
+	}
+	return s
+}
+
+// Create implements the Storage interface.
+func (s *StorageDb) Create(ctx context.Context, state *State) error {
+	data, err := s.stateToMap(state)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Model(s.table).Ctx(ctx).Data(data).Insert()
+	return err
+}
+
+// Update implements the Storage interface.
+// The saga is updated conditionally by its status and step, which makes only one process
+// continue executing the saga if it is executed by multiple processes concurrently.
+func (s *StorageDb) Update(ctx context.Context, state *State, status Status, step int) (bool, error) {
+	data, err := s.stateToMap(state)
+	if err != nil {
+		return false, err
+	}
+	delete(data, "id")
+	delete(data, "created_at")
+	model := s.db.Model(s.table).Ctx(ctx).Where("id", state.Id).Where("status", string(status)).Where("step", step)
+	result, err := model.Clone().Data(data).Update()
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil || affected > 0 {
+		return affected > 0, err
+	}
+	// Some databases like MySQL report no affected row if the record is not changed.
+	count, err := model.Count()
+	return count > 0, err
+}
+
+// Get implements the Storage interface.
+func (s *StorageDb) Get(ctx context.Context, id string) (*State, error) {
+	record, err := s.db.Model(s.table).Ctx(ctx).Where("id", id).One()
+	if err != nil || record.IsEmpty() {
+		return nil, err
+	}
+	return s.recordToState(record)
+}
+
+// ListUnfinished implements the Storage interface.
+func (s *StorageDb) ListUnfinished(ctx context.Context) ([]*State, error) {
+	result, err := s.db.Model(s.table).Ctx(ctx).
+		WhereNotIn("status", []string{string(StatusSucceeded), string(StatusAborted)}).
+		OrderAsc("created_at").
+		All()
+	if err != nil {
+		return nil, err
+	}
+	states := make([]*State, 0, len(result))
+	for _, record := range result {
+		state, err := s.recordToState(record)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// stateToMap converts `state` to the record data of the table.
+func (s *StorageDb) stateToMap(state *State) (gdb.Map, error) {
+	trace, err := json.Marshal(state.Trace)
+	if err != nil {
+		return nil, gerror.WrapCode(gcode.CodeInvalidParameter, err, `encode saga trace failed`)
+	}
+	return gdb.Map{
+		"id":         state.Id,
+		"name":       state.Name,
+		"payload":    string(state.Payload),
+		"status":     string(state.Status),
+		"step":       state.Step,
+		"reason":     state.Reason,
+		"last_error": state.LastError,
+		"trace":      string(trace),
+		"created_at": state.CreatedAt,
+		"updated_at": state.UpdatedAt,
+	}, nil
+}
+
+// recordToState converts the `record` of the table to state.
+func (s *StorageDb) recordToState(record gdb.Record) (*State, error) {
+	state := &State{
+		Id:        record["id"].String(),
+		Name:      record["name"].String(),
+		Payload:   record["payload"].Bytes(),
+		Status:    Status(record["status"].String()),
+		Step:      record["step"].Int(),
+		Reason:    record["reason"].String(),
+		LastError: record["last_error"].String(),
+		CreatedAt: record["created_at"].Time(),
+		UpdatedAt: record["updated_at"].Time(),
+	}
+	if trace := record["trace"].Bytes(); len(trace) > 0 {
+		if err := json.Unmarshal(trace, &state.Trace); err != nil {
+			return nil, gerror.WrapCodef(gcode.CodeInternalError, err, `decode trace of saga "%s" failed`, state.Id)
+		}
+	}
+	return state, nil
+}
@@ -0,0 +1,82 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsaga
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// StorageMemory implements the Storage interface in memory,
+// which is mainly for testing or single process without persistence.
+type StorageMemory struct {
+	mu     sync.RWMutex
+	states map[string]*State // Saga id to state.
+}
+
+// NewStorageMemory creates and returns a memory storage for saga.
+func NewStorageMemory() *StorageMemory {
+	return &StorageMemory{
+		states: make(map[string]*State),
+	}
+}
+
+// Create implements the Storage interface.
+func (s *StorageMemory) Create(ctx context.Context, state *State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.states[state.Id]; ok {
+		return gerror.NewCodef(gcode.CodeInvalidOperation, `saga "%s" already exists`, state.Id)
+	}
+	s.states[state.Id] = state.Clone()
+	return nil
+}
+
+// Update implements the Storage interface.
+func (s *StorageMemory) Update(ctx context.Context, state *State, status Status, step int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.states[state.Id]
+	if !ok {
+		return false, gerror.NewCodef(gcode.CodeNotFound, `saga "%s" not found`, state.Id)
+	}
+	if stored.Status != status || stored.Step != step {
+		return false, nil
+	}
+	s.states[state.Id] = state.Clone()
+	return true, nil
+}
+
+// Get implements the Storage interface.
+func (s *StorageMemory) Get(ctx context.Context, id string) (*State, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if state, ok := s.states[id]; ok {
+		return state.Clone(), nil
+	}
+	return nil, nil
+}
+
+// ListUnfinished implements the Storage interface.
+func (s *StorageMemory) ListUnfinished(ctx context.Context) ([]*State, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var states []*State
+	for _, state := range s.states {
+		if !state.IsFinished() {
+			states = append(states, state.Clone())
+		}
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].CreatedAt.Before(states[j].CreatedAt)
+	})
+	return states, nil
+}
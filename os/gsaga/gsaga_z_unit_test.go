@@ -0,0 +1,310 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gsaga_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/garray"
+	"github.com/gogf/gf/v2/container/gtype"
+	"github.com/gogf/gf/v2/os/gctx"
+	"github.com/gogf/gf/v2/os/gsaga"
+	"github.com/gogf/gf/v2/test/gtest"
+)
+
+type testPayload struct {
+	OrderId int
+	Amount  int
+}
+
+func newTestCoordinator(storage gsaga.Storage) *gsaga.Coordinator {
+	return gsaga.NewCoordinator(storage, gsaga.Option{
+		MaxRetries: 2,
+		Backoff:    time.Millisecond,
+		MaxBackoff: 2 * time.Millisecond,
+	})
+}
+
+// recordStep returns a step recording its executions to `calls`,
+// whose action fails if `fail` is true.
+func recordStep(name string, calls *garray.StrArray, fail bool) gsaga.Step {
+	return gsaga.Step{
+		Name: name,
+		Action: func(ctx context.Context, sc *gsaga.StepContext) error {
+			calls.Append(sc.IdempotencyKey())
+			if fail {
+				return errors.New(name + " failed")
+			}
+			return nil
+		},
+		Compensate: func(ctx context.Context, sc *gsaga.StepContext) error {
+			calls.Append(sc.IdempotencyKey())
+			return nil
+		},
+	}
+}
+
+func Test_Coordinator_Succeeded(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx         = gctx.New()
+			calls       = garray.NewStrArray(true)
+			coordinator = newTestCoordinator(gsaga.NewStorageMemory())
+			payload     testPayload
+		)
+		err := coordinator.Register("order",
+			gsaga.Step{
+				Name: "reserve",
+				Action: func(ctx context.Context, sc *gsaga.StepContext) error {
+					calls.Append(sc.IdempotencyKey())
+					return sc.Scan(&payload)
+				},
+			},
+			recordStep("pay", calls, false),
+		)
+		t.AssertNil(err)
+
+		id, err := coordinator.Start(ctx, "order", testPayload{OrderId: 1, Amount: 100})
+		t.AssertNil(err)
+		t.Assert(payload.OrderId, 1)
+		t.Assert(payload.Amount, 100)
+		t.Assert(calls.Slice(), []string{id + ":reserve:action", id + ":pay:action"})
+
+		state, err := coordinator.Get(ctx, id)
+		t.AssertNil(err)
+		t.Assert(state.Status, gsaga.StatusSucceeded)
+		t.Assert(state.Name, "order")
+		t.Assert(state.Reason, "")
+
+		// Unregistered saga.
+		_, err = coordinator.Start(ctx, "none", nil)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Coordinator_Compensate(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx         = gctx.New()
+			calls       = garray.NewStrArray(true)
+			coordinator = newTestCoordinator(gsaga.NewStorageMemory())
+		)
+		t.AssertNil(coordinator.Register("order",
+			recordStep("reserve", calls, false),
+			recordStep("pay", calls, true),
+			recordStep("ship", calls, false),
+		))
+		id, err := coordinator.Start(ctx, "order", nil)
+		t.AssertNE(err, nil)
+		t.Assert(calls.Slice(), []string{
+			id + ":reserve:action",
+			// The first attempt and 2 retries.
+			id + ":pay:action",
+			id + ":pay:action",
+			id + ":pay:action",
+			id + ":pay:compensate",
+			id + ":reserve:compensate",
+		})
+
+		state, err := coordinator.Get(ctx, id)
+		t.AssertNil(err)
+		t.Assert(state.Status, gsaga.StatusAborted)
+		t.Assert(state.Reason, "pay failed")
+	})
+}
+
+func Test_Coordinator_Confirm(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx         = gctx.New()
+			calls       = garray.NewStrArray(true)
+			coordinator = newTestCoordinator(gsaga.NewStorageMemory())
+			tccStep     = func(name string) gsaga.Step {
+				step := recordStep(name, calls, false)
+				step.Confirm = func(ctx context.Context, sc *gsaga.StepContext) error {
+					calls.Append(sc.IdempotencyKey())
+					return nil
+				}
+				return step
+			}
+		)
+		t.AssertNil(coordinator.Register("transfer", tccStep("debit"), tccStep("credit")))
+		id, err := coordinator.Start(ctx, "transfer", nil)
+		t.AssertNil(err)
+		t.Assert(calls.Slice(), []string{
+			id + ":debit:action",
+			id + ":credit:action",
+			id + ":debit:confirm",
+			id + ":credit:confirm",
+		})
+		state, err := coordinator.Get(ctx, id)
+		t.AssertNil(err)
+		t.Assert(state.Status, gsaga.StatusSucceeded)
+	})
+}
+
+func Test_Coordinator_Idempotent_Start(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx         = gctx.New()
+			calls       = garray.NewStrArray(true)
+			coordinator = newTestCoordinator(gsaga.NewStorageMemory())
+		)
+		t.AssertNil(coordinator.Register("order", recordStep("pay", calls, false)))
+		id, err := coordinator.Start(ctx, "order", nil, gsaga.StartOption{Id: "order-1"})
+		t.AssertNil(err)
+		t.Assert(id, "order-1")
+		id, err = coordinator.Start(ctx, "order", nil, gsaga.StartOption{Id: "order-1"})
+		t.AssertNil(err)
+		t.Assert(id, "order-1")
+		t.Assert(calls.Len(), 1)
+	})
+}
+
+func Test_Coordinator_Resume(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx         = gctx.New()
+			calls       = garray.NewStrArray(true)
+			storage     = gsaga.NewStorageMemory()
+			coordinator = newTestCoordinator(storage)
+			broken      = gtype.NewBool(true)
+		)
+		t.AssertNil(coordinator.Register("order",
+			recordStep("reserve", calls, false),
+			gsaga.Step{
+				Name: "pay",
+				Action: func(ctx context.Context, sc *gsaga.StepContext) error {
+					return errors.New("insufficient balance")
+				},
+				Compensate: func(ctx context.Context, sc *gsaga.StepContext) error {
+					calls.Append(sc.IdempotencyKey())
+					if broken.Val() {
+						return errors.New("payment service unavailable")
+					}
+					return nil
+				},
+			},
+		))
+		// The compensation fails after retries, which leaves the saga unfinished.
+		id, err := coordinator.Start(ctx, "order", nil)
+		t.AssertNE(err, nil)
+		state, err := coordinator.Get(ctx, id)
+		t.AssertNil(err)
+		t.Assert(state.Status, gsaga.StatusCompensating)
+		t.Assert(state.Step, 1)
+		t.Assert(state.Reason, "insufficient balance")
+		t.Assert(state.LastError, "payment service unavailable")
+
+		unfinished, err := storage.ListUnfinished(ctx)
+		t.AssertNil(err)
+		t.Assert(len(unfinished), 1)
+
+		// Recovering by another coordinator, like after process restarting.
+		broken.Set(false)
+		calls.Clear()
+		recovered := newTestCoordinator(storage)
+		t.AssertNil(recovered.Register("order",
+			recordStep("reserve", calls, false),
+			recordStep("pay", calls, false),
+		))
+		err = recovered.Recover(ctx)
+		t.AssertNil(err)
+		t.Assert(calls.Slice(), []string{id + ":pay:compensate", id + ":reserve:compensate"})
+
+		state, err = recovered.Get(ctx, id)
+		t.AssertNil(err)
+		t.Assert(state.Status, gsaga.StatusAborted)
+		t.Assert(state.LastError, "")
+
+		// Resuming finished saga returns its result.
+		err = recovered.Resume(ctx, id)
+		t.AssertNE(err, nil)
+		unfinished, err = storage.ListUnfinished(ctx)
+		t.AssertNil(err)
+		t.Assert(len(unfinished), 0)
+	})
+}
+
+func Test_Coordinator_Recover_Concurrently(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			ctx     = gctx.New()
+			calls   = garray.NewStrArray(true)
+			storage = gsaga.NewStorageMemory()
+			starter = newTestCoordinator(storage)
+		)
+		t.AssertNil(starter.Register("order",
+			recordStep("reserve", calls, false),
+			gsaga.Step{
+				Name: "pay",
+				Action: func(ctx context.Context, sc *gsaga.StepContext) error {
+					return errors.New("insufficient balance")
+				},
+				Compensate: func(ctx context.Context, sc *gsaga.StepContext) error {
+					return errors.New("payment service unavailable")
+				},
+			},
+		))
+		id, err := starter.Start(ctx, "order", nil)
+		t.AssertNE(err, nil)
+
+		// Both coordinators execute the compensation of "pay" at the same time,
+		// but only one of them continues executing the saga.
+		var (
+			entered sync.WaitGroup
+			wg      sync.WaitGroup
+			errs    = garray.NewArray(true)
+		)
+		calls.Clear()
+		entered.Add(2)
+		for i := 0; i < 2; i++ {
+			coordinator := newTestCoordinator(storage)
+			t.AssertNil(coordinator.Register("order",
+				recordStep("reserve", calls, false),
+				gsaga.Step{
+					Name:   "pay",
+					Action: func(ctx context.Context, sc *gsaga.StepContext) error { return nil },
+					Compensate: func(ctx context.Context, sc *gsaga.StepContext) error {
+						calls.Append(sc.IdempotencyKey())
+						entered.Done()
+						entered.Wait()
+						return nil
+					},
+				},
+			))
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs.Append(coordinator.Recover(ctx))
+			}()
+		}
+		wg.Wait()
+		t.Assert(errs.Slice(), []interface{}{nil, nil})
+		t.Assert(calls.Slice(), []string{id + ":pay:compensate", id + ":pay:compensate", id + ":reserve:compensate"})
+
+		state, err := storage.Get(ctx, id)
+		t.AssertNil(err)
+		t.Assert(state.Status, gsaga.StatusAborted)
+	})
+}
+
+func Test_Coordinator_Register(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		coordinator := newTestCoordinator(gsaga.NewStorageMemory())
+		t.AssertNE(coordinator.Register("empty"), nil)
+		t.AssertNE(coordinator.Register("no_action", gsaga.Step{Name: "a"}), nil)
+		t.AssertNE(coordinator.Register("duplicated",
+			recordStep("a", garray.NewStrArray(), false),
+			recordStep("a", garray.NewStrArray(), false),
+		), nil)
+	})
+}
@@ -7,10 +7,10 @@
 package gtimer
 
 import (
-	"container/heap"
 	"math"
 	"sync"
 
+	"github.com/gogf/gf/v2/container/gheap"
 	"github.com/gogf/gf/v2/container/gtype"
 )
 
@@ -20,28 +20,16 @@ import (
 // priorityQueue is based on heap structure.
 type priorityQueue struct {
 	mu           sync.Mutex
-	heap         *priorityQueueHeap // the underlying queue items manager using heap.
-	nextPriority *gtype.Int64       // nextPriority stores the next priority value of the heap, which is used to check if necessary to call the Pop of heap by Timer.
-}
-
-// priorityQueueHeap is a heap manager, of which the underlying `array` is an array implementing a heap structure.
-type priorityQueueHeap struct {
-	array []priorityQueueItem
-}
-
-// priorityQueueItem stores the queue item which has a `priority` attribute to sort itself in heap.
-type priorityQueueItem struct {
-	value    interface{}
-	priority int64
+	heap         *gheap.Heap[interface{}] // the underlying queue items manager using heap.
+	nextPriority *gtype.Int64             // nextPriority stores the next priority value of the heap, which is used to check if necessary to call the Pop of heap by Timer.
 }
 
 // newPriorityQueue creates and returns a priority queue.
 func newPriorityQueue() *priorityQueue {
 	queue := &priorityQueue{
-		heap:         &priorityQueueHeap{array: make([]priorityQueueItem, 0)},
+		heap:         gheap.New[interface{}](),
 		nextPriority: gtype.NewInt64(math.MaxInt64),
 	}
-	heap.Init(queue.heap)
 	return queue
 }
 
@@ -56,10 +44,7 @@ func (q *priorityQueue) NextPriority() int64 {
 func (q *priorityQueue) Push(value interface{}, priority int64) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
-	heap.Push(q.heap, priorityQueueItem{
-		value:    value,
-		priority: priority,
-	})
+	q.heap.PushWithPriority(value, priority)
 	// Update the minimum priority using atomic operation.
 	nextPriority := q.nextPriority.Val()
 	if priority >= nextPriority {
@@ -72,13 +57,13 @@ func (q *priorityQueue) Push(value interface{}, priority int64) {
 func (q *priorityQueue) Pop() interface{} {
 	q.mu.Lock()
 	defer q.mu.Unlock()
-	if v := heap.Pop(q.heap); v != nil {
+	if v, _, ok := q.heap.PopMin(); ok {
 		var nextPriority int64 = math.MaxInt64
-		if len(q.heap.array) > 0 {
-			nextPriority = q.heap.array[0].priority
+		if _, priority, ok := q.heap.Peek(); ok {
+			nextPriority = priority
 		}
 		q.nextPriority.Set(nextPriority)
-		return v.(priorityQueueItem).value
+		return v
 	}
 	return nil
 }
@@ -79,7 +79,7 @@ func TestTimer_PriorityQueue_FirstOneInArrayIsTheLeast(t *testing.T) {
 		})
 		for i := 0; i < size; i++ {
 			t.Assert(queue.Pop(), i)
-			t.Assert(queue.heap.array[0].priority, i+1)
+			t.Assert(queue.NextPriority(), i+1)
 		}
 	})
 }
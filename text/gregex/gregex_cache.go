@@ -13,16 +13,71 @@ import (
 	"github.com/gogf/gf/v2/errors/gerror"
 )
 
+const (
+	// DefaultCacheSize is the default max count of the compiled patterns in cache.
+	DefaultCacheSize = 4096
+)
+
 var (
 	regexMu = sync.RWMutex{}
 
 	// Cache for regex object.
 	// Note that:
 	// 1. It uses sync.RWMutex ensuring the concurrent safety.
-	// 2. There's no expiring logic for this map.
+	// 2. It is size limited by `regexCacheSize`, an arbitrary pattern is evicted if it is full.
 	regexMap = make(map[string]*regexp.Regexp)
+
+	// Max count of the patterns in `regexMap`.
+	regexCacheSize = DefaultCacheSize
+
+	// Registry for precompiled regex object, which is never evicted.
+	regexRegistry = make(map[string]*regexp.Regexp)
 )
 
+// Register precompiles and registers `patterns` to the registry, which are never evicted
+// from the cache. It is usually used in package initialization for frequently used patterns.
+func Register(patterns ...string) error {
+	compiled := make(map[string]*regexp.Regexp, len(patterns))
+	for _, pattern := range patterns {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return gerror.Wrapf(err, `regexp.Compile failed for pattern "%s"`, pattern)
+		}
+		compiled[pattern] = regex
+	}
+	regexMu.Lock()
+	defer regexMu.Unlock()
+	for pattern, regex := range compiled {
+		regexRegistry[pattern] = regex
+		delete(regexMap, pattern)
+	}
+	return nil
+}
+
+// MustRegister performs as Register, but it panics if any error occurs.
+func MustRegister(patterns ...string) {
+	if err := Register(patterns...); err != nil {
+		panic(err)
+	}
+}
+
+// SetCacheSize sets the max count of the compiled patterns in cache, which does not limit
+// the registered patterns. The cache is disabled if `size` is 0, and it is DefaultCacheSize in default.
+func SetCacheSize(size int) {
+	if size < 0 {
+		size = 0
+	}
+	regexMu.Lock()
+	defer regexMu.Unlock()
+	regexCacheSize = size
+	for pattern := range regexMap {
+		if len(regexMap) <= regexCacheSize {
+			break
+		}
+		delete(regexMap, pattern)
+	}
+}
+
 // getRegexp returns *regexp.Regexp object with given `pattern`.
 // It uses cache to enhance the performance for compiling regular expression pattern,
 // which means, it will return the same *regexp.Regexp object with the same regular
@@ -32,7 +87,9 @@ var (
 func getRegexp(pattern string) (regex *regexp.Regexp, err error) {
 	// Retrieve the regular expression object using reading lock.
 	regexMu.RLock()
-	regex = regexMap[pattern]
+	if regex = regexRegistry[pattern]; regex == nil {
+		regex = regexMap[pattern]
+	}
 	regexMu.RUnlock()
 	if regex != nil {
 		return
@@ -45,7 +102,16 @@ func getRegexp(pattern string) (regex *regexp.Regexp, err error) {
 	}
 	// Cache the result object using writing lock.
 	regexMu.Lock()
-	regexMap[pattern] = regex
+	if regexCacheSize > 0 {
+		if _, ok := regexMap[pattern]; !ok && len(regexMap) >= regexCacheSize {
+			// Evict an arbitrary one as the map iteration is in random order.
+			for p := range regexMap {
+				delete(regexMap, p)
+				break
+			}
+		}
+		regexMap[pattern] = regex
+	}
 	regexMu.Unlock()
 	return
 }
@@ -0,0 +1,202 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gregex
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// Glob is a compiled glob pattern, which is concurrent-safe for matching.
+//
+// The glob syntax:
+//
+//	"*"      matches any sequence of chars except '/'.
+//	"**"     matches any sequence of chars including '/', and "**/" matches zero or more directories.
+//	"?"      matches any single char except '/'.
+//	"[abc]"  matches any char in the class, which also supports ranges like "[a-z]".
+//	"[!abc]" matches any char not in the class and not '/', in which '^' can also be used instead of '!'.
+//	"{a,b}"  matches any of the comma-separated alternatives, which can be nested.
+//	"\x"     matches char 'x' literally.
+//
+// Note that the path separator is always '/', use filepath.ToSlash for Windows paths.
+type Glob struct {
+	pattern string         // The original glob pattern.
+	regex   *regexp.Regexp // The compiled regular expression of the glob pattern.
+}
+
+// CompileGlob compiles the glob `pattern` and returns a Glob object for matching.
+func CompileGlob(pattern string) (*Glob, error) {
+	expr, err := GlobToRegex(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regex, err := getRegexp(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &Glob{
+		pattern: pattern,
+		regex:   regex,
+	}, nil
+}
+
+// MustCompileGlob performs as CompileGlob, but it panics if any error occurs.
+func MustCompileGlob(pattern string) *Glob {
+	glob, err := CompileGlob(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return glob
+}
+
+// IsMatchGlob checks whether given string `src` matches the glob `pattern`.
+// It returns false if `pattern` is invalid.
+func IsMatchGlob(pattern string, src string) bool {
+	if glob, err := CompileGlob(pattern); err == nil {
+		return glob.Match(src)
+	}
+	return false
+}
+
+// Match checks whether given string `src` matches the glob.
+func (g *Glob) Match(src string) bool {
+	return g.regex.MatchString(src)
+}
+
+// String returns the original glob pattern.
+func (g *Glob) String() string {
+	return g.pattern
+}
+
+// Regex returns the regular expression pattern of the glob.
+func (g *Glob) Regex() string {
+	return g.regex.String()
+}
+
+// GlobToRegex converts the glob `pattern` to an equivalent regular expression pattern,
+// which matches the whole string. See Glob for the glob syntax.
+//
+// Eg: GlobToRegex(`src/**/*.{go,mod}`) returns `^src/(?:.*/)?[^/]*\.(?:go|mod)$`.
+func GlobToRegex(pattern string) (string, error) {
+	var (
+		runes      = []rune(pattern)
+		length     = len(runes)
+		braceDepth = 0
+		builder    strings.Builder
+	)
+	builder.WriteByte('^')
+	for i := 0; i < length; i++ {
+		switch c := runes[i]; c {
+		case '\\':
+			if i+1 >= length {
+				return "", gerror.NewCodef(gcode.CodeInvalidParameter, `trailing backslash in glob pattern "%s"`, pattern)
+			}
+			i++
+			builder.WriteString(regexp.QuoteMeta(string(runes[i])))
+
+		case '*':
+			if i+1 < length && runes[i+1] == '*' {
+				var (
+					atSegmentStart = i == 0 || runes[i-1] == '/'
+					beforeSlash    = i+2 < length && runes[i+2] == '/'
+				)
+				if atSegmentStart && beforeSlash {
+					builder.WriteString(`(?:.*/)?`)
+					i += 2
+				} else {
+					builder.WriteString(`.*`)
+					i++
+				}
+			} else {
+				builder.WriteString(`[^/]*`)
+			}
+
+		case '?':
+			builder.WriteString(`[^/]`)
+
+		case '[':
+			class, end, err := globClassToRegex(pattern, runes, i)
+			if err != nil {
+				return "", err
+			}
+			builder.WriteString(class)
+			i = end
+
+		case '{':
+			braceDepth++
+			builder.WriteString(`(?:`)
+
+		case ',':
+			if braceDepth > 0 {
+				builder.WriteByte('|')
+			} else {
+				builder.WriteByte(',')
+			}
+
+		case '}':
+			if braceDepth > 0 {
+				braceDepth--
+				builder.WriteByte(')')
+			} else {
+				builder.WriteString(`\}`)
+			}
+
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if braceDepth > 0 {
+		return "", gerror.NewCodef(gcode.CodeInvalidParameter, `unclosed brace in glob pattern "%s"`, pattern)
+	}
+	builder.WriteByte('$')
+	return builder.String(), nil
+}
+
+// globClassToRegex converts the char class starting at `runes[start]` which is '[' to regular expression,
+// it returns the converted class and the index of the closing ']'.
+func globClassToRegex(pattern string, runes []rune, start int) (class string, end int, err error) {
+	var (
+		i       = start + 1
+		builder strings.Builder
+	)
+	builder.WriteByte('[')
+	if i < len(runes) && (runes[i] == '!' || runes[i] == '^') {
+		// The negated class never matches the path separator.
+		builder.WriteString(`^/`)
+		i++
+	}
+	for first := true; i < len(runes); i, first = i+1, false {
+		c := runes[i]
+		switch {
+		case c == ']' && !first:
+			builder.WriteByte(']')
+			return builder.String(), i, nil
+
+		case c == '\\' && i+1 < len(runes):
+			// The escaped punctuation is escaped again for regular expression.
+			i++
+			if c = runes[i]; c < utf8.RuneSelf && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+				builder.WriteByte('\\')
+			}
+			builder.WriteRune(c)
+
+		case c == '\\' || c == '[' || c == ']' || c == '^':
+			builder.WriteByte('\\')
+			builder.WriteRune(c)
+
+		default:
+			builder.WriteRune(c)
+		}
+	}
+	return "", 0, gerror.NewCodef(gcode.CodeInvalidParameter, `unclosed char class in glob pattern "%s"`, pattern)
+}
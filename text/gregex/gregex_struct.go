@@ -0,0 +1,220 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gregex
+
+import (
+	"encoding"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+const (
+	// structTagName is the struct tag name for specifying the capture group name of attribute.
+	structTagName = "gregex"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+// MatchMap returns the named capture groups of the first match of `pattern` in `src` as map,
+// of which the keys are group names and the values are matched strings.
+// It returns nil if `src` does not match `pattern`.
+//
+// Eg: MatchMap(`(?P<year>\d+)-(?P<month>\d+)`, "2023-10") returns {"year":"2023","month":"10"}.
+func MatchMap(pattern string, src string) (map[string]string, error) {
+	r, err := getRegexp(pattern)
+	if err != nil {
+		return nil, err
+	}
+	match := r.FindStringSubmatch(src)
+	if match == nil {
+		return nil, nil
+	}
+	data := make(map[string]string)
+	for i, name := range r.SubexpNames() {
+		if i > 0 && name != "" {
+			data[name] = match[i]
+		}
+	}
+	return data, nil
+}
+
+// MatchStruct maps the named capture groups of the first match of `pattern` in `src`
+// to the attributes of struct `pointer`, which should be type of *struct/**struct.
+// It returns false if `src` does not match `pattern`, and `pointer` is not changed.
+//
+// The capture group is mapped to the attribute that has the same name in tag `gregex`,
+// or else the attribute whose name equals to the group name case-insensitively ignoring
+// chars '_' and '-'. The matched strings are converted to the attribute types, which
+// supports string, bool, integer, float, time.Duration, pointer of them and types
+// implementing encoding.TextUnmarshaler. Empty matches of optional groups are ignored
+// for non-string attributes.
+//
+// Eg: MatchStruct(`(?P<year>\d+)-(?P<m>\d+)`, "2023-10", &date) sets attribute `Year` and
+// attribute `Month` with tag `gregex:"m"` of `date`.
+func MatchStruct(pattern string, src string, pointer interface{}) (matched bool, err error) {
+	reflectValue := reflect.ValueOf(pointer)
+	if reflectValue.Kind() != reflect.Ptr || reflectValue.IsNil() {
+		return false, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`destination pointer should be type of *struct/**struct, but got type: %T`,
+			pointer,
+		)
+	}
+	data, err := MatchMap(pattern, src)
+	if err != nil || data == nil {
+		return false, err
+	}
+	reflectValue = reflectValue.Elem()
+	if reflectValue.Kind() == reflect.Ptr {
+		if reflectValue.IsNil() {
+			reflectValue.Set(reflect.New(reflectValue.Type().Elem()))
+		}
+		reflectValue = reflectValue.Elem()
+	}
+	if reflectValue.Kind() != reflect.Struct {
+		return false, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`destination pointer should be type of *struct/**struct, but got type: %T`,
+			pointer,
+		)
+	}
+	if err = doMatchStructFields(reflectValue, data); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// doMatchStructFields sets the attributes of struct `structValue` with `data`,
+// including the attributes of embedded structs.
+func doMatchStructFields(structValue reflect.Value, data map[string]string) error {
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		var (
+			field      = structType.Field(i)
+			fieldValue = structValue.Field(i)
+		)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := doMatchStructFields(fieldValue, data); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		value, ok := searchMatchValue(field, data)
+		if !ok {
+			continue
+		}
+		if err := setMatchValue(fieldValue, value); err != nil {
+			return gerror.WrapCodef(
+				gcode.CodeInvalidParameter, err,
+				`set value "%s" to attribute "%s" failed`, value, field.Name,
+			)
+		}
+	}
+	return nil
+}
+
+// searchMatchValue searches and returns the matched value for struct `field` from `data`.
+func searchMatchValue(field reflect.StructField, data map[string]string) (value string, ok bool) {
+	if name := field.Tag.Get(structTagName); name != "" {
+		value, ok = data[name]
+		return
+	}
+	if value, ok = data[field.Name]; ok {
+		return
+	}
+	fieldName := normalizeMatchName(field.Name)
+	for name, v := range data {
+		if normalizeMatchName(name) == fieldName {
+			return v, true
+		}
+	}
+	return
+}
+
+// normalizeMatchName lowers `name` and removes chars '_' and '-' for comparison.
+func normalizeMatchName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+// setMatchValue converts string `value` and sets it to `reflectValue`.
+func setMatchValue(reflectValue reflect.Value, value string) error {
+	if reflectValue.CanAddr() && reflectValue.Addr().Type().Implements(textUnmarshalerType) {
+		return reflectValue.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+	if reflectValue.Kind() == reflect.String {
+		reflectValue.SetString(value)
+		return nil
+	}
+	if value == "" {
+		return nil
+	}
+	switch reflectValue.Kind() {
+	case reflect.Ptr:
+		if reflectValue.IsNil() {
+			reflectValue.Set(reflect.New(reflectValue.Type().Elem()))
+		}
+		return setMatchValue(reflectValue.Elem(), value)
+
+	case reflect.Bool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		reflectValue.SetBool(v)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if reflectValue.Type() == durationType {
+			if d, err := time.ParseDuration(value); err == nil {
+				reflectValue.SetInt(int64(d))
+				return nil
+			}
+		}
+		v, err := strconv.ParseInt(value, 10, reflectValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+		reflectValue.SetInt(v)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(value, 10, reflectValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+		reflectValue.SetUint(v)
+
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(value, reflectValue.Type().Bits())
+		if err != nil {
+			return err
+		}
+		reflectValue.SetFloat(v)
+
+	case reflect.Slice:
+		if reflectValue.Type().Elem().Kind() != reflect.Uint8 {
+			return gerror.NewCodef(gcode.CodeNotSupported, `unsupported attribute type: %s`, reflectValue.Type())
+		}
+		reflectValue.SetBytes([]byte(value))
+
+	case reflect.Interface:
+		reflectValue.Set(reflect.ValueOf(value))
+
+	default:
+		return gerror.NewCodef(gcode.CodeNotSupported, `unsupported attribute type: %s`, reflectValue.Type())
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gregex
+
+import (
+	"strconv"
+	"testing"
+)
+
+func Test_CacheSize(t *testing.T) {
+	defer SetCacheSize(DefaultCacheSize)
+	SetCacheSize(10)
+	for i := 0; i < 100; i++ {
+		if err := Validate(`^` + strconv.Itoa(i) + `$`); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := len(regexMap); n != 10 {
+		t.Errorf("len(regexMap) = %d, want %d", n, 10)
+	}
+	SetCacheSize(5)
+	if n := len(regexMap); n != 5 {
+		t.Errorf("len(regexMap) = %d, want %d", n, 5)
+	}
+
+	// The registered patterns are never evicted.
+	MustRegister(`^registered$`)
+	for i := 0; i < 100; i++ {
+		_ = Validate(`^` + strconv.Itoa(i) + `$`)
+	}
+	if regexRegistry[`^registered$`] == nil {
+		t.Errorf("registered pattern is evicted")
+	}
+
+	SetCacheSize(0)
+	_ = Validate(`^disabled$`)
+	if n := len(regexMap); n != 0 {
+		t.Errorf("len(regexMap) = %d, want %d", n, 0)
+	}
+}
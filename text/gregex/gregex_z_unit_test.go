@@ -11,7 +11,9 @@ package gregex_test
 import (
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/gogf/gf/v2/os/gtime"
 	"github.com/gogf/gf/v2/test/gtest"
 	"github.com/gogf/gf/v2/text/gregex"
 )
@@ -341,3 +343,142 @@ func Test_Split(t *testing.T) {
 
 	})
 }
+
+func Test_MatchMap(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		data, err := gregex.MatchMap(`(?P<year>\d+)-(?P<month>\d+)-(\d+)`, "date: 2023-10-15")
+		t.AssertNil(err)
+		t.Assert(data, map[string]string{"year": "2023", "month": "10"})
+
+		data, err = gregex.MatchMap(`(?P<year>\d+)`, "none")
+		t.AssertNil(err)
+		t.Assert(data, nil)
+
+		_, err = gregex.MatchMap(`(?P<year>\d+`, "2023")
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_MatchStruct(t *testing.T) {
+	type Base struct {
+		Level string
+	}
+	type Log struct {
+		Base
+		Time     *gtime.Time
+		UserId   int64
+		Cost     time.Duration
+		Ratio    float64
+		Success  bool
+		Message  string `gregex:"msg"`
+		Optional *uint
+	}
+	var pattern = `^(?P<time>\S+ \S+) \[(?P<level>\w+)\] user=(?P<user_id>\d+) cost=(?P<cost>\S+) ratio=(?P<ratio>\S+) ok=(?P<success>\w+)(?: n=(?P<optional>\d+))? (?P<msg>.+)$`
+	gtest.C(t, func(t *gtest.T) {
+		var log *Log
+		matched, err := gregex.MatchStruct(
+			pattern, "2023-10-15 12:00:00 [INFO] user=100 cost=1.5s ratio=0.75 ok=true request done", &log,
+		)
+		t.AssertNil(err)
+		t.Assert(matched, true)
+		t.Assert(log.Time.String(), "2023-10-15 12:00:00")
+		t.Assert(log.Level, "INFO")
+		t.Assert(log.UserId, 100)
+		t.Assert(log.Cost, 1500*time.Millisecond)
+		t.Assert(log.Ratio, 0.75)
+		t.Assert(log.Success, true)
+		t.Assert(log.Message, "request done")
+		t.Assert(log.Optional, nil)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var log Log
+		matched, err := gregex.MatchStruct(
+			pattern, "2023-10-15 12:00:00 [INFO] user=100 cost=1s ratio=1 ok=false n=3 done", &log,
+		)
+		t.AssertNil(err)
+		t.Assert(matched, true)
+		t.Assert(*log.Optional, 3)
+
+		matched, err = gregex.MatchStruct(pattern, "invalid", &log)
+		t.AssertNil(err)
+		t.Assert(matched, false)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var v struct {
+			Id int
+		}
+		_, err := gregex.MatchStruct(`(?P<id>\w+)`, "abc", &v)
+		t.AssertNE(err, nil)
+		_, err = gregex.MatchStruct(`(?P<id>\w+)`, "abc", v)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Register(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNil(gregex.Register(`^\d+$`, `^[a-z]+$`))
+		t.Assert(gregex.IsMatchString(`^\d+$`, "123"), true)
+		t.AssertNE(gregex.Register(`(`), nil)
+	})
+}
+
+func Test_GlobToRegex(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		expr, err := gregex.GlobToRegex(`src/**/*.{go,mod}`)
+		t.AssertNil(err)
+		t.Assert(expr, `^src/(?:.*/)?[^/]*\.(?:go|mod)$`)
+
+		expr, err = gregex.GlobToRegex(`a?[!b]`)
+		t.AssertNil(err)
+		t.Assert(expr, `^a[^/][^/b]$`)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		_, err := gregex.GlobToRegex(`a\`)
+		t.AssertNE(err, nil)
+		_, err = gregex.GlobToRegex(`a[bc`)
+		t.AssertNE(err, nil)
+		_, err = gregex.GlobToRegex(`{a,b`)
+		t.AssertNE(err, nil)
+	})
+}
+
+func Test_Glob(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gregex.IsMatchGlob(`*.go`, "main.go"), true)
+		t.Assert(gregex.IsMatchGlob(`*.go`, "cmd/main.go"), false)
+		t.Assert(gregex.IsMatchGlob(`**.go`, "cmd/main.go"), true)
+		t.Assert(gregex.IsMatchGlob(`a/**/b`, "a/b"), true)
+		t.Assert(gregex.IsMatchGlob(`a/**/b`, "a/x/y/b"), true)
+		t.Assert(gregex.IsMatchGlob(`a/**/b`, "a/xb"), false)
+		t.Assert(gregex.IsMatchGlob(`**/*.go`, "main.go"), true)
+		t.Assert(gregex.IsMatchGlob(`file?.txt`, "file1.txt"), true)
+		t.Assert(gregex.IsMatchGlob(`file?.txt`, "file/.txt"), false)
+		t.Assert(gregex.IsMatchGlob(`[a-c]x`, "bx"), true)
+		t.Assert(gregex.IsMatchGlob(`[a-c]x`, "dx"), false)
+		t.Assert(gregex.IsMatchGlob(`[!a]x`, "bx"), true)
+		t.Assert(gregex.IsMatchGlob(`[!a]x`, "ax"), false)
+		t.Assert(gregex.IsMatchGlob(`[]]`, "]"), true)
+		t.Assert(gregex.IsMatchGlob(`[\]a]`, "]"), true)
+		t.Assert(gregex.IsMatchGlob(`\*.go`, "*.go"), true)
+		t.Assert(gregex.IsMatchGlob(`\*.go`, "a.go"), false)
+		t.Assert(gregex.IsMatchGlob(`a,b}`, "a,b}"), true)
+		t.Assert(gregex.IsMatchGlob(`[a`, "[a"), false)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		glob := gregex.MustCompileGlob(`/api/{user,order{,s}}/*`)
+		t.Assert(glob.String(), `/api/{user,order{,s}}/*`)
+		t.Assert(glob.Regex(), `^/api/(?:user|order(?:|s))/[^/]*$`)
+		t.Assert(glob.Match("/api/user/1"), true)
+		t.Assert(glob.Match("/api/order/1"), true)
+		t.Assert(glob.Match("/api/orders/1"), true)
+		t.Assert(glob.Match("/api/orderx/1"), false)
+		t.Assert(glob.Match("/api/user/1/2"), false)
+
+		_, err := gregex.CompileGlob(`{a`)
+		t.AssertNE(err, nil)
+		defer func() {
+			t.AssertNE(recover(), nil)
+		}()
+		gregex.MustCompileGlob(`[a`)
+	})
+}
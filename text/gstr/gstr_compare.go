@@ -6,7 +6,10 @@
 
 package gstr
 
-import "strings"
+import (
+	"crypto/subtle"
+	"strings"
+)
 
 // Compare returns an integer comparing two strings lexicographically.
 // The result will be 0 if a==b, -1 if a < b, and +1 if a > b.
@@ -19,3 +22,10 @@ func Compare(a, b string) int {
 func Equal(a, b string) bool {
 	return strings.EqualFold(a, b)
 }
+
+// SecureCompare reports whether `a` and `b` are equal in constant time,
+// which is used for comparing secrets like tokens and signatures to avoid timing attacks.
+// Note that the length of the strings might still be leaked.
+func SecureCompare(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
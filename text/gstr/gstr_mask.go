@@ -0,0 +1,120 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstr
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// defaultMaskChar is the default char for masking.
+	defaultMaskChar = "*"
+)
+
+// MaskMiddle keeps `keepStart` count of chars at the beginning and `keepEnd` count of chars at the end
+// of `str`, and replaces each of the chars in the middle with `mask`, which is "*" in default.
+// It considers parameter `str` as unicode string.
+//
+// If `str` is not long enough, the kept chars are reduced from the end and then from the beginning,
+// to make sure at least one char is masked. Eg:
+// MaskMiddle("15928008611", 3, 4) => "159****8611"
+// MaskMiddle("张三", 1, 1)         => "张*"
+func MaskMiddle(str string, keepStart, keepEnd int, mask ...string) string {
+	return doMask(str, keepStart, keepEnd, getMaskChar(mask), nil)
+}
+
+// MaskEmail masks the local part of email address `email` and keeps its domain, eg:
+// MaskEmail("john@goframe.org") => "j**n@goframe.org".
+// The optional parameter `mask` specifies the char for masking, which is "*" in default.
+func MaskEmail(email string, mask ...string) string {
+	pos := strings.LastIndex(email, "@")
+	if pos == -1 {
+		return doMask(email, 1, 1, getMaskChar(mask), nil)
+	}
+	return doMask(email[:pos], 1, 1, getMaskChar(mask), nil) + email[pos:]
+}
+
+// MaskPhone masks the middle digits of phone number `phone` and keeps the other chars like
+// '+', '-' and spaces, eg:
+// MaskPhone("15928008611")     => "159****8611"
+// MaskPhone("+1 415-555-2671") => "+1 41*-***-2671"
+// The optional parameter `mask` specifies the char for masking, which is "*" in default.
+func MaskPhone(phone string, mask ...string) string {
+	var (
+		digitCount         = 0
+		keepStart, keepEnd int
+	)
+	for _, r := range phone {
+		if unicode.IsDigit(r) {
+			digitCount++
+		}
+	}
+	switch {
+	case digitCount >= 11:
+		keepStart, keepEnd = 3, 4
+	case digitCount >= 7:
+		keepStart, keepEnd = 2, 3
+	default:
+		keepStart, keepEnd = 1, 1
+	}
+	return doMask(phone, keepStart, keepEnd, getMaskChar(mask), unicode.IsDigit)
+}
+
+// doMask masks the chars of `str` that `maskable` returns true, except `keepStart` count of them at
+// the beginning and `keepEnd` count of them at the end. All the chars are maskable if `maskable` is nil.
+func doMask(str string, keepStart, keepEnd int, mask string, maskable func(r rune) bool) string {
+	var (
+		runes = []rune(str)
+		total = 0
+	)
+	for _, r := range runes {
+		if maskable == nil || maskable(r) {
+			total++
+		}
+	}
+	if total == 0 {
+		return str
+	}
+	if keepStart < 0 {
+		keepStart = 0
+	}
+	if keepEnd < 0 {
+		keepEnd = 0
+	}
+	for keepStart+keepEnd >= total {
+		if keepEnd > 0 {
+			keepEnd--
+		} else {
+			keepStart--
+		}
+	}
+	var (
+		builder strings.Builder
+		index   = 0
+	)
+	for _, r := range runes {
+		if maskable != nil && !maskable(r) {
+			builder.WriteRune(r)
+			continue
+		}
+		if index >= keepStart && index < total-keepEnd {
+			builder.WriteString(mask)
+		} else {
+			builder.WriteRune(r)
+		}
+		index++
+	}
+	return builder.String()
+}
+
+func getMaskChar(mask []string) string {
+	if len(mask) > 0 {
+		return mask[0]
+	}
+	return defaultMaskChar
+}
@@ -0,0 +1,83 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstr
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeNFC returns the Unicode Normalization Form C(canonical composition) of `s`.
+// Eg: "é" -> "é".
+func NormalizeNFC(s string) string {
+	return norm.NFC.String(s)
+}
+
+// NormalizeNFD returns the Unicode Normalization Form D(canonical decomposition) of `s`.
+// Eg: "é" -> "é".
+func NormalizeNFD(s string) string {
+	return norm.NFD.String(s)
+}
+
+// NormalizeNFKC returns the Unicode Normalization Form KC(compatibility composition) of `s`,
+// which also maps compatibility characters to their canonical forms.
+// Eg: "ﬁ" -> "fi", "Ａ" -> "A".
+func NormalizeNFKC(s string) string {
+	return norm.NFKC.String(s)
+}
+
+// NormalizeNFKD returns the Unicode Normalization Form KD(compatibility decomposition) of `s`.
+func NormalizeNFKD(s string) string {
+	return norm.NFKD.String(s)
+}
+
+// FoldCase returns the case folded `s`, which is used for caseless matching of strings.
+// Different from ToLower, it handles special cases like "ß" -> "ss".
+//
+// The optional parameter `locale` specifies the BCP 47 language tag for locale-aware folding,
+// eg: "tr" maps "I" to "ı" for Turkish, which is language-independent folding in default.
+func FoldCase(s string, locale ...string) string {
+	if len(locale) > 0 && locale[0] != "" {
+		if tag, err := language.Parse(locale[0]); err == nil {
+			s = cases.Lower(tag).String(s)
+		}
+	}
+	return cases.Fold().String(s)
+}
+
+// RemoveAccents removes the diacritical marks(accents) of `s`.
+// Eg: "Crème Brûlée" -> "Creme Brulee".
+func RemoveAccents(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, err := transform.String(t, s)
+	if err != nil {
+		return s
+	}
+	return result
+}
+
+// EqualFoldNormalized checks whether `a` and `b` are equal after NFKC normalization
+// and case folding, which is the proper way comparing user inputs in non-ASCII languages.
+// The optional parameter `locale` is used for locale-aware folding, see FoldCase.
+func EqualFoldNormalized(a, b string, locale ...string) bool {
+	return FoldCase(NormalizeNFKC(a), locale...) == FoldCase(NormalizeNFKC(b), locale...)
+}
+
+// SearchKey converts `s` to a normalized key for searching or indexing,
+// which is NFKC normalized, case folded, accent removed and trimmed with collapsed spaces.
+// The optional parameter `locale` is used for locale-aware folding, see FoldCase.
+// Eg: "  Crème   BRÛLÉE " -> "creme brulee".
+func SearchKey(s string, locale ...string) string {
+	s = RemoveAccents(FoldCase(NormalizeNFKC(s), locale...))
+	return strings.Join(strings.Fields(s), " ")
+}
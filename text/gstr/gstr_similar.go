@@ -6,6 +6,10 @@
 
 package gstr
 
+import (
+	"strings"
+)
+
 // Levenshtein calculates Levenshtein distance between two strings.
 // costIns: Defines the cost of insertion.
 // costRep: Defines the cost of replacement.
@@ -156,3 +160,161 @@ func Soundex(str string) string {
 	}
 	return string(sd)
 }
+
+// LevenshteinDistance calculates the Levenshtein distance between two strings in unicode runes,
+// which is the minimum count of single-character insertions, deletions or substitutions
+// required to change one string into the other.
+// Different from Levenshtein, it has unit costs and no length limit of the strings.
+func LevenshteinDistance(str1, str2 string) int {
+	var (
+		r1 = []rune(str1)
+		r2 = []rune(str2)
+	)
+	if len(r1) == 0 {
+		return len(r2)
+	}
+	if len(r2) == 0 {
+		return len(r1)
+	}
+	var (
+		prev = make([]int, len(r2)+1)
+		curr = make([]int, len(r2)+1)
+	)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(r1); i++ {
+		curr[0] = i
+		for j := 1; j <= len(r2); j++ {
+			cost := 1
+			if r1[i-1] == r2[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(minInt(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(r2)]
+}
+
+// JaroWinkler calculates the Jaro-Winkler similarity between two strings in unicode runes,
+// which is in range [0, 1], and 1 means the two strings are the same.
+// It favors strings that have the same prefix, which is suitable for short strings like names.
+func JaroWinkler(str1, str2 string) float64 {
+	var (
+		r1 = []rune(str1)
+		r2 = []rune(str2)
+	)
+	if len(r1) == 0 && len(r2) == 0 {
+		return 1
+	}
+	if len(r1) == 0 || len(r2) == 0 {
+		return 0
+	}
+	matchDistance := maxInt(len(r1), len(r2))/2 - 1
+	if matchDistance < 0 {
+		matchDistance = 0
+	}
+	var (
+		matches1 = make([]bool, len(r1))
+		matches2 = make([]bool, len(r2))
+		matches  = 0
+	)
+	for i := range r1 {
+		start, end := maxInt(0, i-matchDistance), minInt(len(r2), i+matchDistance+1)
+		for j := start; j < end; j++ {
+			if !matches2[j] && r1[i] == r2[j] {
+				matches1[i], matches2[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+	var transpositions, k int
+	for i := range r1 {
+		if !matches1[i] {
+			continue
+		}
+		for !matches2[k] {
+			k++
+		}
+		if r1[i] != r2[k] {
+			transpositions++
+		}
+		k++
+	}
+	var (
+		m    = float64(matches)
+		jaro = (m/float64(len(r1)) + m/float64(len(r2)) + (m-float64(transpositions)/2)/m) / 3
+	)
+	// Winkler modification using common prefix up to 4 characters.
+	prefix := 0
+	for prefix < minInt(4, minInt(len(r1), len(r2))) && r1[prefix] == r2[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// TrigramSimilarity calculates the trigram similarity between two strings,
+// which is the Jaccard index of their trigram sets in range [0, 1].
+// The strings are case folded and padded with spaces before splitting into trigrams,
+// which is suitable for ranking search results of longer texts.
+func TrigramSimilarity(str1, str2 string) float64 {
+	var (
+		t1 = trigrams(str1)
+		t2 = trigrams(str2)
+	)
+	if len(t1) == 0 && len(t2) == 0 {
+		return 1
+	}
+	intersection := 0
+	for t := range t1 {
+		if _, ok := t2[t]; ok {
+			intersection++
+		}
+	}
+	return float64(intersection) / float64(len(t1)+len(t2)-intersection)
+}
+
+// trigrams returns the trigram set of `str`.
+func trigrams(str string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, word := range strings.Fields(FoldCase(str)) {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			set[string(runes[i:i+3])] = struct{}{}
+		}
+	}
+	return set
+}
+
+// BestMatch returns the candidate most similar to `input` from `candidates` and its similarity score,
+// which is calculated using JaroWinkler after case folding, in range [0, 1].
+// It returns an empty string and 0 if `candidates` is empty.
+// It is usually used for "did you mean" suggestions with a score threshold like 0.8.
+func BestMatch(input string, candidates []string) (match string, score float64) {
+	input = FoldCase(input)
+	for i, candidate := range candidates {
+		if s := JaroWinkler(input, FoldCase(candidate)); i == 0 || s > score {
+			match, score = candidate, s
+		}
+	}
+	return
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
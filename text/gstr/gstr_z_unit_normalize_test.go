@@ -0,0 +1,62 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gstr_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+)
+
+func Test_Normalize(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			composed   = "é"
+			decomposed = "é"
+		)
+		t.AssertNE(composed, decomposed)
+		t.Assert(gstr.NormalizeNFC(decomposed), composed)
+		t.Assert(gstr.NormalizeNFD(composed), decomposed)
+		t.Assert(gstr.NormalizeNFKC("ﬁＡ"), "fiA")
+		t.Assert(gstr.NormalizeNFKD("é"), decomposed)
+	})
+}
+
+func Test_FoldCase(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.FoldCase("GoFrame"), "goframe")
+		t.Assert(gstr.FoldCase("Straße"), "strasse")
+		t.Assert(gstr.FoldCase("ΣΊΣΥΦΟΣ"), gstr.FoldCase("σίσυφος"))
+		t.Assert(gstr.FoldCase("DİYARBAKIR", "tr"), "diyarbakır")
+		t.Assert(gstr.FoldCase("ABC", "invalid-locale-tag-!"), "abc")
+	})
+}
+
+func Test_RemoveAccents(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.RemoveAccents("Crème Brûlée"), "Creme Brulee")
+		t.Assert(gstr.RemoveAccents("é"), "e")
+		t.Assert(gstr.RemoveAccents("中文"), "中文")
+	})
+}
+
+func Test_EqualFoldNormalized(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.EqualFoldNormalized("Café", "CAFÉ"), true)
+		t.Assert(gstr.EqualFoldNormalized("straße", "STRASSE"), true)
+		t.Assert(gstr.EqualFoldNormalized("ｆｉｌｅ", "FILE"), true)
+		t.Assert(gstr.EqualFoldNormalized("cafe", "café"), false)
+	})
+}
+
+func Test_SearchKey(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.SearchKey("  Crème   BRÛLÉE "), "creme brulee")
+		t.Assert(gstr.SearchKey("Ｇｏ　Ｆｒａｍｅ"), "go frame")
+	})
+}
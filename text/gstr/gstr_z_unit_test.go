@@ -9,6 +9,7 @@
 package gstr_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/gogf/gf/v2/test/gtest"
@@ -453,3 +454,90 @@ func Test_SubStrFromREx(t *testing.T) {
 		t.Assert(gstr.SubStrFromREx("我爱GoFrameGood", `Frame`), `Good`)
 	})
 }
+
+func Test_LevenshteinDistance(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.LevenshteinDistance("kitten", "sitting"), 3)
+		t.Assert(gstr.LevenshteinDistance("", "abc"), 3)
+		t.Assert(gstr.LevenshteinDistance("abc", ""), 3)
+		t.Assert(gstr.LevenshteinDistance("中文字", "中字"), 1)
+		t.Assert(gstr.LevenshteinDistance("same", "same"), 0)
+	})
+}
+
+func Test_JaroWinkler(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(fmt.Sprintf("%.3f", gstr.JaroWinkler("MARTHA", "MARHTA")), "0.961")
+		t.Assert(fmt.Sprintf("%.3f", gstr.JaroWinkler("DIXON", "DICKSONX")), "0.813")
+		t.Assert(gstr.JaroWinkler("", ""), 1)
+		t.Assert(gstr.JaroWinkler("abc", ""), 0)
+		t.Assert(gstr.JaroWinkler("abc", "xyz"), 0)
+		t.Assert(gstr.JaroWinkler("abc", "abc"), 1)
+	})
+}
+
+func Test_TrigramSimilarity(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.TrigramSimilarity("word", "WORD"), 1)
+		t.Assert(gstr.TrigramSimilarity("word", "xyz"), 0)
+		t.AssertGT(gstr.TrigramSimilarity("goframe framework", "goframe"), gstr.TrigramSimilarity("gin framework", "goframe"))
+		t.Assert(gstr.TrigramSimilarity("", ""), 1)
+	})
+}
+
+func Test_BestMatch(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		match, score := gstr.BestMatch("biuld", []string{"run", "build", "init"})
+		t.Assert(match, "build")
+		t.AssertGT(score, 0.8)
+
+		match, score = gstr.BestMatch("RUN", []string{"run", "build"})
+		t.Assert(match, "run")
+		t.Assert(score, 1)
+
+		match, score = gstr.BestMatch("x", nil)
+		t.Assert(match, "")
+		t.Assert(score, 0)
+	})
+}
+
+func Test_SecureCompare(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.SecureCompare("", ""), true)
+		t.Assert(gstr.SecureCompare("token", "token"), true)
+		t.Assert(gstr.SecureCompare("token", "Token"), false)
+		t.Assert(gstr.SecureCompare("token", "token1"), false)
+	})
+}
+
+func Test_MaskMiddle(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.MaskMiddle("15928008611", 3, 4), "159****8611")
+		t.Assert(gstr.MaskMiddle("15928008611", 3, 4, "#"), "159####8611")
+		t.Assert(gstr.MaskMiddle("张三", 1, 1), "张*")
+		t.Assert(gstr.MaskMiddle("欧阳小三", 1, 1), "欧**三")
+		t.Assert(gstr.MaskMiddle("a", 1, 1), "*")
+		t.Assert(gstr.MaskMiddle("abc", 0, 0), "***")
+		t.Assert(gstr.MaskMiddle("", 1, 1), "")
+	})
+}
+
+func Test_MaskEmail(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.MaskEmail("john@goframe.org"), "j**n@goframe.org")
+		t.Assert(gstr.MaskEmail("jo@goframe.org"), "j*@goframe.org")
+		t.Assert(gstr.MaskEmail("j@goframe.org"), "*@goframe.org")
+		t.Assert(gstr.MaskEmail("张小三@goframe.org"), "张*三@goframe.org")
+		t.Assert(gstr.MaskEmail("john"), "j**n")
+	})
+}
+
+func Test_MaskPhone(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gstr.MaskPhone("15928008611"), "159****8611")
+		t.Assert(gstr.MaskPhone("+1 415-555-2671"), "+1 41*-***-2671")
+		t.Assert(gstr.MaskPhone("555-2671"), "55*-*671")
+		t.Assert(gstr.MaskPhone("110"), "1*0")
+		t.Assert(gstr.MaskPhone("-"), "-")
+	})
+}
@@ -311,3 +311,26 @@ func TestStructs(t *testing.T) {
 		}
 	})
 }
+
+func TestStructWithMsgPackTag(t *testing.T) {
+	type S struct {
+		UserName string `msgpack:"user_name"`
+		Password string `json:"pass" msgpack:"password,omitempty"`
+	}
+	gtest.C(t, func(t *gtest.T) {
+		var s *S
+		err := gconv.Struct(g.Map{
+			"user_name": "john",
+			"pass":      "123",
+		}, &s)
+		t.AssertNil(err)
+		t.Assert(s.UserName, "john")
+		t.Assert(s.Password, "123")
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gconv.Map(S{UserName: "john", Password: "123"}), g.Map{
+			"user_name": "john",
+			"pass":      "123",
+		})
+	})
+}
@@ -5,6 +5,17 @@
 // You can obtain one at https://github.com/gogf/gf.
 
 // Package grand provides high performance random bytes/number/string generation functionality.
+//
+// There are three groups of functions in this package:
+//
+//  1. The package functions like Intn/N/B/S/Str/Digits are high performance helpers, which are backed
+//     by a buffer of crypto/rand bytes. Note that they produce results with modulo bias, they are suitable
+//     for load balancing, sampling, jitter and so on, but NOT suitable for secrets.
+//  2. The Secure* functions like SecureB/SecureIntn/SecureS/SecureToken read from crypto/rand directly
+//     and produce uniformly distributed results, which should be used for secrets like tokens,
+//     passwords, verification codes and keys.
+//  3. The Source created by NewSource produces deterministic sequences with given seed, which is used for
+//     reproducible tests and simulations, and must never be used for secrets.
 package grand
 
 import (
@@ -0,0 +1,129 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package grand
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+)
+
+// SecureB reads and returns `n` count of cryptographically secure random bytes directly from crypto/rand.
+// It panics if the system random source fails, as there's no secure fallback.
+func SecureB(n int) []byte {
+	if n <= 0 {
+		return nil
+	}
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(gerror.WrapCode(gcode.CodeInternalError, err, `error reading random buffer from system`))
+	}
+	return b
+}
+
+// SecureIntn returns a cryptographically secure and uniformly distributed int number
+// which is between 0 and max: [0, max).
+// The `max` can only be greater than 0, or else it returns `max` directly.
+func SecureIntn(max int) int {
+	if max <= 0 {
+		return max
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(max)))
+	if err != nil {
+		panic(gerror.WrapCode(gcode.CodeInternalError, err, `error reading random number from system`))
+	}
+	return int(n.Int64())
+}
+
+// SecureN returns a cryptographically secure and uniformly distributed int between min and max: [min, max].
+// The `min` and `max` also support negative numbers.
+func SecureN(min, max int) int {
+	if min >= max {
+		return min
+	}
+	return SecureIntn(max-min+1) + min
+}
+
+// SecureS returns a cryptographically secure random string which contains digits and letters,
+// and its length is `n`. The optional parameter `symbols` specifies whether the result
+// could contain symbols, which is false in default.
+func SecureS(n int, symbols ...bool) string {
+	if len(symbols) > 0 && symbols[0] {
+		return securePick(characters, n)
+	}
+	return securePick(characters[:62], n)
+}
+
+// SecureStr randomly picks and returns `n` count of chars from given string `s`
+// in cryptographically secure and uniformly distributed way.
+// It also supports unicode string like Chinese/Russian/Japanese, etc.
+func SecureStr(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	var (
+		b     = make([]rune, n)
+		runes = []rune(s)
+	)
+	for i := range b {
+		b[i] = runes[SecureIntn(len(runes))]
+	}
+	return string(b)
+}
+
+// SecureDigits returns a cryptographically secure random string which contains only digits,
+// and its length is `n`. It is usually used for verification codes.
+func SecureDigits(n int) string {
+	return securePick(digits, n)
+}
+
+// SecureLetters returns a cryptographically secure random string which contains only letters,
+// and its length is `n`.
+func SecureLetters(n int) string {
+	return securePick(letters, n)
+}
+
+// SecureToken returns a URL-safe base64 encoded token, which is generated from `n` count of
+// cryptographically secure random bytes.
+func SecureToken(n int) string {
+	return base64.RawURLEncoding.EncodeToString(SecureB(n))
+}
+
+// SecureHex returns a hex encoded token, which is generated from `n` count of
+// cryptographically secure random bytes.
+func SecureHex(n int) string {
+	return hex.EncodeToString(SecureB(n))
+}
+
+// securePick randomly picks `n` count of bytes from `chars` without modulo bias.
+// The length of `chars` should not be greater than 256.
+func securePick(chars string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	var (
+		b     = make([]byte, n)
+		limit = 256 - 256%len(chars) // Random bytes not less than limit are dropped to avoid modulo bias.
+		i     = 0
+	)
+	for i < n {
+		for _, v := range SecureB(n - i + n/4 + 1) {
+			if int(v) >= limit {
+				continue
+			}
+			b[i] = chars[int(v)%len(chars)]
+			if i++; i == n {
+				break
+			}
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,158 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package grand
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Source is a deterministic pseudo-random generator, which produces the same sequence
+// for the same seed. It is designed for reproducible tests and simulations.
+//
+// Note that Source is NOT cryptographically secure, never use it for secrets like
+// tokens, passwords or keys, use the Secure* functions instead.
+//
+// It is concurrent-safe, but the sequence is only reproducible if it is consumed in the same order.
+type Source struct {
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewSource creates and returns a deterministic random Source with given `seed`.
+func NewSource(seed int64) *Source {
+	return &Source{
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Intn returns an int number which is between 0 and max: [0, max).
+// The `max` can only be greater than 0, or else it returns `max` directly.
+func (s *Source) Intn(max int) int {
+	if max <= 0 {
+		return max
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.Intn(max)
+}
+
+// B retrieves and returns random bytes of given length `n`.
+func (s *Source) B(n int) []byte {
+	if n <= 0 {
+		return nil
+	}
+	b := make([]byte, n)
+	s.mu.Lock()
+	s.rand.Read(b)
+	s.mu.Unlock()
+	return b
+}
+
+// N returns a random int between min and max: [min, max].
+// The `min` and `max` also support negative numbers.
+func (s *Source) N(min, max int) int {
+	if min >= max {
+		return min
+	}
+	return s.Intn(max-min+1) + min
+}
+
+// S returns a random string which contains digits and letters, and its length is `n`.
+// The optional parameter `symbols` specifies whether the result could contain symbols,
+// which is false in default.
+func (s *Source) S(n int, symbols ...bool) string {
+	if len(symbols) > 0 && symbols[0] {
+		return s.pick(characters, n)
+	}
+	return s.pick(characters[:62], n)
+}
+
+// D returns a random time.Duration between min and max: [min, max].
+func (s *Source) D(min, max time.Duration) time.Duration {
+	if min >= max {
+		return min
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return min + time.Duration(s.rand.Int63n(int64(max-min)+1))
+}
+
+// Str randomly picks and returns `n` count of chars from given string `str`.
+// It also supports unicode string like Chinese/Russian/Japanese, etc.
+func (s *Source) Str(str string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	var (
+		b     = make([]rune, n)
+		runes = []rune(str)
+	)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range b {
+		b[i] = runes[s.rand.Intn(len(runes))]
+	}
+	return string(b)
+}
+
+// Digits returns a random string which contains only digits, and its length is `n`.
+func (s *Source) Digits(n int) string {
+	return s.pick(digits, n)
+}
+
+// Letters returns a random string which contains only letters, and its length is `n`.
+func (s *Source) Letters(n int) string {
+	return s.pick(letters, n)
+}
+
+// Symbols returns a random string which contains only symbols, and its length is `n`.
+func (s *Source) Symbols(n int) string {
+	return s.pick(symbols, n)
+}
+
+// Perm returns, as a slice of n int numbers, a pseudo-random permutation of the integers [0,n).
+func (s *Source) Perm(n int) []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.Perm(n)
+}
+
+// Shuffle pseudo-randomizes the order of elements using the swap function `swap`.
+// The parameter `n` is the number of elements.
+func (s *Source) Shuffle(n int, swap func(i, j int)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rand.Shuffle(n, swap)
+}
+
+// Meet randomly calculate whether the given probability `num`/`total` is met.
+func (s *Source) Meet(num, total int) bool {
+	return s.Intn(total) < num
+}
+
+// MeetProb randomly calculate whether the given probability is met.
+func (s *Source) MeetProb(prob float32) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rand.Float32() < prob
+}
+
+// pick randomly picks `n` count of bytes from `chars`.
+func (s *Source) pick(chars string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	b := make([]byte, n)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range b {
+		b[i] = chars[s.rand.Intn(len(chars))]
+	}
+	return string(b)
+}
@@ -0,0 +1,116 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package grand_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+func Test_Source_Deterministic(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			s1 = grand.NewSource(100)
+			s2 = grand.NewSource(100)
+		)
+		for i := 0; i < 100; i++ {
+			t.Assert(s1.Intn(1000), s2.Intn(1000))
+			t.Assert(s1.N(-50, 50), s2.N(-50, 50))
+			t.Assert(s1.S(16, true), s2.S(16, true))
+			t.Assert(s1.B(8), s2.B(8))
+			t.Assert(s1.Str("我爱GoFrame", 8), s2.Str("我爱GoFrame", 8))
+			t.Assert(s1.D(time.Second, time.Minute), s2.D(time.Second, time.Minute))
+		}
+		t.Assert(s1.Perm(10), s2.Perm(10))
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			s1 = grand.NewSource(1)
+			s2 = grand.NewSource(2)
+		)
+		t.AssertNE(s1.S(32), s2.S(32))
+	})
+}
+
+func Test_Source_Range(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		s := grand.NewSource(time.Now().UnixNano())
+		for i := 0; i < 1000; i++ {
+			n := s.N(-2, 2)
+			t.AssertGE(n, -2)
+			t.AssertLE(n, 2)
+			d := s.D(time.Second, 3*time.Second)
+			t.AssertGE(d, time.Second)
+			t.AssertLE(d, 3*time.Second)
+		}
+		t.Assert(s.Intn(0), 0)
+		t.Assert(s.Intn(-1), -1)
+		t.Assert(s.N(5, 5), 5)
+		t.Assert(s.B(0), nil)
+		t.Assert(s.S(0), "")
+		t.Assert(len(s.Digits(10)), 10)
+		t.Assert(strings.Trim(s.Digits(10), "0123456789"), "")
+		t.Assert(len(s.Letters(10)), 10)
+		t.Assert(len(s.Symbols(10)), 10)
+		t.Assert(s.Meet(100, 100), true)
+		t.Assert(s.Meet(0, 100), false)
+		t.Assert(s.MeetProb(1), true)
+		t.Assert(s.MeetProb(0), false)
+	})
+}
+
+func Test_Source_Shuffle(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			a1 = []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+			a2 = []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+		)
+		grand.NewSource(7).Shuffle(len(a1), func(i, j int) { a1[i], a1[j] = a1[j], a1[i] })
+		grand.NewSource(7).Shuffle(len(a2), func(i, j int) { a2[i], a2[j] = a2[j], a2[i] })
+		t.Assert(a1, a2)
+	})
+}
+
+func Test_Secure(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(grand.SecureB(0), nil)
+		t.Assert(len(grand.SecureB(32)), 32)
+		t.AssertNE(grand.SecureB(32), grand.SecureB(32))
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(grand.SecureIntn(0), 0)
+		t.Assert(grand.SecureN(3, 3), 3)
+		for i := 0; i < 1000; i++ {
+			n := grand.SecureN(-1, 2)
+			t.AssertIN(n, []int{-1, 0, 1, 2})
+			t.AssertLT(grand.SecureIntn(10), 10)
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(grand.SecureS(0), "")
+		for i := 0; i < 100; i++ {
+			s := grand.SecureS(20)
+			t.Assert(len(s), 20)
+			t.Assert(strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"), "")
+			t.Assert(len(grand.SecureS(20, true)), 20)
+			t.Assert(strings.Trim(grand.SecureDigits(6), "0123456789"), "")
+			t.Assert(len(grand.SecureLetters(6)), 6)
+		}
+		t.Assert(len([]rune(grand.SecureStr("我爱GoFrame", 10))), 10)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(len(grand.SecureToken(32)), 43)
+		b, err := hex.DecodeString(grand.SecureHex(16))
+		t.AssertNil(err)
+		t.Assert(len(b), 16)
+	})
+}
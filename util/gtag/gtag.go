@@ -44,7 +44,9 @@ const (
 	GConv             = "gconv"        // GConv defines the converting target name for specified struct field.
 	GConvShort        = "c"            // GConv defines the converting target name for specified struct field.
 	Json              = "json"         // Json tag is supported by stdlib.
+	MsgPack           = "msgpack"      // MsgPack tag specifies the attribute name for MessagePack encoding.
 	Security          = "security"     // Security defines scheme for authentication. Detail to see https://swagger.io/docs/specification/authentication/
+	Status            = "status"       // Status code for HTTP response, usually for OpenAPI in response struct.
 	In                = "in"           // Swagger distinguishes between the following parameter types based on the parameter location. Detail to see https://swagger.io/docs/specification/describing-parameters/
 )
 
@@ -52,5 +54,5 @@ const (
 // Note that, the `gconv/param` tags are used by old version of package.
 // It is strongly recommended using short tag `c/p` instead in the future.
 var StructTagPriority = []string{
-	GConv, Param, GConvShort, ParamShort, Json,
+	GConv, Param, GConvShort, ParamShort, Json, MsgPack,
 }
@@ -0,0 +1,151 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package guid
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/gogf/gf/v2/util/grand"
+)
+
+const (
+	crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ" // Crockford's base32 alphabet for ULID.
+	uuidV7HiMax     = uint16(1<<10 - 1)                  // UUIDv7 has 74 bits entropy, 10 bits of which are in hi.
+	ulidHiMax       = uint16(1<<16 - 1)                  // ULID has 80 bits entropy, 16 bits of which are in hi.
+)
+
+var (
+	uuidV7Generator = &monotonicGenerator{hiMax: uuidV7HiMax}
+	ulidGenerator   = &monotonicGenerator{hiMax: ulidHiMax}
+)
+
+// monotonicGenerator produces millisecond timestamp and entropy pairs, which are strictly
+// increasing within the same process. The entropy is freshly generated from crypto/rand
+// in a new millisecond, and is incremented by one within the same millisecond.
+type monotonicGenerator struct {
+	mu     sync.Mutex
+	lastMs uint64 // Timestamp in milliseconds of the last generated id.
+	hi     uint16 // High bits of the entropy, which are no greater than hiMax.
+	lo     uint64 // Low 64 bits of the entropy.
+	hiMax  uint16 // Max value of hi, which defines the bit size of entropy.
+}
+
+// next returns the timestamp in milliseconds and the entropy of next id.
+func (g *monotonicGenerator) next() (ms uint64, hi uint16, lo uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := uint64(time.Now().UnixNano() / int64(time.Millisecond))
+	// The last timestamp is reused if the clock goes backwards, to keep the ids in order.
+	if now > g.lastMs {
+		g.lastMs = now
+		g.reseed()
+	} else {
+		g.lo++
+		if g.lo == 0 {
+			g.hi++
+		}
+		// It borrows the next millisecond if the entropy overflows, which hardly happens.
+		if g.hi > g.hiMax {
+			g.lastMs++
+			g.reseed()
+		}
+	}
+	return g.lastMs, g.hi, g.lo
+}
+
+// reseed fills the entropy with crypto random bytes.
+// The highest bit of entropy is always cleared, leaving room for incrementing in the same millisecond.
+func (g *monotonicGenerator) reseed() {
+	b := grand.SecureB(10)
+	g.hi = binary.BigEndian.Uint16(b) & (g.hiMax >> 1)
+	g.lo = binary.BigEndian.Uint64(b[2:])
+}
+
+// UUIDv7 creates and returns a time-ordered UUID in version 7 of RFC 9562,
+// in canonical format like "01890a5d-ac96-774b-bcce-b302099a8057".
+//
+// The returned UUIDs are composed of 48 bits unix timestamp in milliseconds and 74 bits
+// crypto random entropy. They are lexically sortable by creation time and strictly monotonic
+// within current process even in the same millisecond, which makes them suitable for
+// primary keys of database.
+func UUIDv7() string {
+	var (
+		b           = UUIDv7Bytes()
+		s           = make([]byte, 36)
+		hexSegments = [][2]int{{0, 4}, {4, 6}, {6, 8}, {8, 10}, {10, 16}}
+		pos         = 0
+	)
+	for i, segment := range hexSegments {
+		if i > 0 {
+			s[pos] = '-'
+			pos++
+		}
+		pos += hex.Encode(s[pos:], b[segment[0]:segment[1]])
+	}
+	return string(s)
+}
+
+// UUIDv7Bytes creates and returns a time-ordered UUID in version 7 as 16 bytes, see UUIDv7.
+func UUIDv7Bytes() [16]byte {
+	var (
+		b              [16]byte
+		ms, hi, lo     = uuidV7Generator.next()
+		randA          = uint16(hi)<<2 | uint16(lo>>62) // 12 bits.
+		randB          = lo & (1<<62 - 1)               // 62 bits.
+		timestampBytes [8]byte
+	)
+	binary.BigEndian.PutUint64(timestampBytes[:], ms)
+	copy(b[:6], timestampBytes[2:])
+	binary.BigEndian.PutUint16(b[6:], randA)
+	binary.BigEndian.PutUint64(b[8:], randB)
+	b[6] = b[6]&0x0f | 0x70 // Version 7.
+	b[8] = b[8]&0x3f | 0x80 // Variant 10.
+	return b
+}
+
+// ULID creates and returns a time-ordered ULID in 26 bytes of Crockford's base32,
+// like "01H2F5ZV3Q9M6XK4T8YB0N7CRD".
+//
+// The returned ULIDs are composed of 48 bits unix timestamp in milliseconds and 80 bits
+// crypto random entropy. They are lexically sortable by creation time and strictly monotonic
+// within current process even in the same millisecond, which makes them suitable for
+// primary keys of database.
+func ULID() string {
+	var (
+		b = ULIDBytes()
+		s = make([]byte, 26)
+	)
+	// The 128 bits are encoded as 130 bits with 2 leading zero bits, 5 bits for each char.
+	for i := range s {
+		var index byte
+		for p := 5*i - 2; p < 5*i+3; p++ {
+			index <<= 1
+			if p >= 0 {
+				index |= b[p/8] >> (7 - p%8) & 1
+			}
+		}
+		s[i] = crockfordBase32[index]
+	}
+	return string(s)
+}
+
+// ULIDBytes creates and returns a time-ordered ULID as 16 bytes, see ULID.
+func ULIDBytes() [16]byte {
+	var (
+		b              [16]byte
+		ms, hi, lo     = ulidGenerator.next()
+		timestampBytes [8]byte
+	)
+	binary.BigEndian.PutUint64(timestampBytes[:], ms)
+	copy(b[:6], timestampBytes[2:])
+	binary.BigEndian.PutUint16(b[6:], hi)
+	binary.BigEndian.PutUint64(b[8:], lo)
+	return b
+}
@@ -37,3 +37,19 @@ func Benchmark_S_Data_2(b *testing.B) {
 		}
 	})
 }
+
+func Benchmark_UUIDv7(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			guid.UUIDv7()
+		}
+	})
+}
+
+func Benchmark_ULID(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			guid.ULID()
+		}
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package guid_test
+
+import (
+	"encoding/binary"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/container/gset"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/guid"
+)
+
+func Test_UUIDv7(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			set  = gset.NewStrSet()
+			last = ""
+		)
+		for i := 0; i < 100000; i++ {
+			s := guid.UUIDv7()
+			t.Assert(len(s), 36)
+			t.Assert(s[8], '-')
+			t.Assert(s[13], '-')
+			t.Assert(s[14], '7')
+			t.Assert(s[18], '-')
+			t.AssertIN(string(s[19]), []string{"8", "9", "a", "b"})
+			t.Assert(s[23], '-')
+			t.Assert(set.AddIfNotExist(s), true)
+			t.Assert(s > last, true)
+			last = s
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			now = time.Now().UnixNano() / int64(time.Millisecond)
+			b   = guid.UUIDv7Bytes()
+			ms  = int64(binary.BigEndian.Uint64(append([]byte{0, 0}, b[:6]...)))
+		)
+		t.AssertGE(ms, now)
+		t.AssertLT(ms, now+1000)
+		t.Assert(b[6]>>4, 7)
+		t.Assert(b[8]>>6, 2)
+	})
+}
+
+func Test_ULID(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			set  = gset.NewStrSet()
+			last = ""
+		)
+		for i := 0; i < 100000; i++ {
+			s := guid.ULID()
+			t.Assert(len(s), 26)
+			t.Assert(strings.Trim(s, "0123456789ABCDEFGHJKMNPQRSTVWXYZ"), "")
+			t.Assert(set.AddIfNotExist(s), true)
+			t.Assert(s > last, true)
+			last = s
+		}
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			now = time.Now().UnixNano() / int64(time.Millisecond)
+			s   = guid.ULID()
+			ms  int64
+		)
+		// The first 10 chars are the timestamp in milliseconds.
+		for _, c := range s[:10] {
+			ms = ms<<5 | int64(strings.IndexRune("0123456789ABCDEFGHJKMNPQRSTVWXYZ", c))
+		}
+		t.AssertGE(ms, now)
+		t.AssertLT(ms, now+1000)
+	})
+}
+
+func Test_Sortable_Concurrent(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			wg      sync.WaitGroup
+			uuidSet = gset.NewStrSet(true)
+			ulidSet = gset.NewStrSet(true)
+		)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 1000; j++ {
+					uuidSet.Add(guid.UUIDv7())
+					ulidSet.Add(guid.ULID())
+				}
+			}()
+		}
+		wg.Wait()
+		t.Assert(uuidSet.Size(), 10000)
+		t.Assert(ulidSet.Size(), 10000)
+	})
+}
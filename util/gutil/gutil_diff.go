@@ -0,0 +1,217 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gutil
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/os/gstructs"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// DiffType is the type of DiffItem.
+type DiffType string
+
+const (
+	DiffTypeAdded   DiffType = "added"   // The path exists only in the new value.
+	DiffTypeRemoved DiffType = "removed" // The path exists only in the old value.
+	DiffTypeChanged DiffType = "changed" // The path exists in both values but with different values.
+)
+
+// DiffItem is a changed path of the structural difference between two values.
+type DiffItem struct {
+	Path string      `json:"path"` // Path of the changed value, eg: "user.roles[1].name", which is empty for the root.
+	Type DiffType    `json:"type"` // Type of the change.
+	Old  interface{} `json:"old"`  // Old value, which is nil if the path is added.
+	New  interface{} `json:"new"`  // New value, which is nil if the path is removed.
+}
+
+// diffKind is the kind of value for structural comparison.
+type diffKind int
+
+const (
+	diffKindLeaf   diffKind = iota // Values compared as a whole.
+	diffKindObject                 // Map and struct, which are compared by keys.
+	diffKindList                   // Slice and array, which are compared by indexes.
+)
+
+// Diff compares `a` and `b` deeply and returns the structural difference as a list of changed paths
+// with their old and new values, which is usually used for audit logs or configuration changes.
+//
+// The maps and structs are compared by keys, in which the struct attribute name is replaced by its
+// tag name in priority of gconv.StructTagPriority, eg: `json:"name"`, and the attribute with tag
+// value "-" is ignored. A map and a struct can also be compared with each other by keys.
+// The slices and arrays are compared by indexes, and the other values are compared as a whole.
+//
+// The returned list is ordered by struct attributes in definition order and map keys in ascending order.
+// It returns an empty list if `a` and `b` are structurally equal.
+func Diff(a, b interface{}) []DiffItem {
+	items := make([]DiffItem, 0)
+	doDiff("", reflect.ValueOf(a), reflect.ValueOf(b), true, true, &items)
+	return items
+}
+
+func doDiff(path string, a, b reflect.Value, aExist, bExist bool, items *[]DiffItem) {
+	switch {
+	case !aExist:
+		*items = append(*items, DiffItem{Path: path, Type: DiffTypeAdded, New: diffInterface(diffIndirect(b))})
+		return
+	case !bExist:
+		*items = append(*items, DiffItem{Path: path, Type: DiffTypeRemoved, Old: diffInterface(diffIndirect(a))})
+		return
+	}
+	a, b = diffIndirect(a), diffIndirect(b)
+	var (
+		aKind = getDiffKind(a)
+		bKind = getDiffKind(b)
+	)
+	switch {
+	case aKind == diffKindObject && bKind == diffKindObject:
+		var (
+			aKeys, aValues = diffObjectEntries(a)
+			bKeys, bValues = diffObjectEntries(b)
+		)
+		for _, key := range aKeys {
+			bValue, ok := bValues[key]
+			doDiff(diffJoinKey(path, key), aValues[key], bValue, true, ok, items)
+		}
+		for _, key := range bKeys {
+			if _, ok := aValues[key]; !ok {
+				doDiff(diffJoinKey(path, key), reflect.Value{}, bValues[key], false, true, items)
+			}
+		}
+
+	case aKind == diffKindList && bKind == diffKindList:
+		var (
+			aLen = a.Len()
+			bLen = b.Len()
+		)
+		for i := 0; i < aLen || i < bLen; i++ {
+			var aValue, bValue reflect.Value
+			if i < aLen {
+				aValue = a.Index(i)
+			}
+			if i < bLen {
+				bValue = b.Index(i)
+			}
+			doDiff(path+"["+strconv.Itoa(i)+"]", aValue, bValue, i < aLen, i < bLen, items)
+		}
+
+	default:
+		if !diffLeafEqual(a, b) {
+			*items = append(*items, DiffItem{
+				Path: path,
+				Type: DiffTypeChanged,
+				Old:  diffInterface(a),
+				New:  diffInterface(b),
+			})
+		}
+	}
+}
+
+// diffIndirect retrieves the underlying value of pointer and interface,
+// which returns an invalid reflect.Value if it is nil.
+func diffIndirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func getDiffKind(v reflect.Value) diffKind {
+	if !v.IsValid() {
+		return diffKindLeaf
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		return diffKindObject
+	case reflect.Struct:
+		// The struct having no exported attributes like time.Time is compared as a whole.
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				return diffKindObject
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		// The bytes are compared as a whole.
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return diffKindList
+		}
+	}
+	return diffKindLeaf
+}
+
+// diffObjectEntries returns the ordered keys and values of map or struct `v`.
+func diffObjectEntries(v reflect.Value) (keys []string, values map[string]reflect.Value) {
+	values = make(map[string]reflect.Value)
+	if v.Kind() == reflect.Map {
+		for _, mapKey := range v.MapKeys() {
+			key := gconv.String(mapKey.Interface())
+			keys = append(keys, key)
+			values[key] = v.MapIndex(mapKey)
+		}
+		sort.Strings(keys)
+		return
+	}
+	fields, _ := gstructs.Fields(gstructs.FieldsInput{
+		Pointer:         v,
+		RecursiveOption: gstructs.RecursiveOptionEmbeddedNoTag,
+	})
+	for _, field := range fields {
+		if !field.IsExported() {
+			continue
+		}
+		key := field.Name()
+		for _, tag := range gconv.StructTagPriority {
+			if tagValue := strings.Split(field.Tag(tag), ",")[0]; tagValue != "" {
+				key = tagValue
+				break
+			}
+		}
+		if key == "-" {
+			continue
+		}
+		if _, ok := values[key]; !ok {
+			keys = append(keys, key)
+		}
+		values[key] = field.Value
+	}
+	return
+}
+
+func diffLeafEqual(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if aTime, ok := diffInterface(a).(time.Time); ok {
+		if bTime, ok := diffInterface(b).(time.Time); ok {
+			return aTime.Equal(bTime)
+		}
+	}
+	return reflect.DeepEqual(diffInterface(a), diffInterface(b))
+}
+
+func diffInterface(v reflect.Value) interface{} {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+func diffJoinKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
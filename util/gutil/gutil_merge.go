@@ -0,0 +1,249 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gutil
+
+import (
+	"reflect"
+
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// MergeStrategy specifies how to merge the values of the same path.
+type MergeStrategy int
+
+const (
+	MergeStrategyOverwrite MergeStrategy = iota // The source value overwrites the destination value, which is the default strategy.
+	MergeStrategyAppend                         // The source slice is appended to the destination slice.
+	MergeStrategyUnion                          // The source slice is appended to the destination slice without duplicated items.
+	MergeStrategyError                          // It returns an error if the source and destination values are both set but different.
+)
+
+// MergeOption is the option for Merge.
+type MergeOption struct {
+	// Strategy is the default strategy for all paths.
+	Strategy MergeStrategy
+	// PathStrategy specifies the strategies for specified paths, which overwrites the default strategy.
+	// The path is composed of keys joined with char '.', eg: "server.hosts".
+	PathStrategy map[string]MergeStrategy
+	// OmitEmpty specifies whether ignoring the empty source values like 0 and "",
+	// note that the nil source values are always ignored.
+	OmitEmpty bool
+}
+
+// Merge deeply merges `src` into `dst` with optional merging strategies.
+//
+// The parameter `dst` should be a map or a pointer to a map/struct, and `src` can be any map/struct or
+// pointer to them. The maps and structs are merged by keys recursively, in which the struct attribute name
+// is replaced by its tag name in priority of gconv.StructTagPriority, and the struct attributes of `dst`
+// that do not exist in `src` are kept. The other values including slices are merged according to
+// the strategy of their paths, and they are converted to the types of `dst` automatically.
+//
+// As the nil source values are ignored, it can be used for PATCH handlers merging partial structs
+// with pointer attributes, or for layered configurations.
+func Merge(dst, src interface{}, option ...MergeOption) error {
+	var (
+		m        = &merger{}
+		dstValue = reflect.ValueOf(dst)
+	)
+	if len(option) > 0 {
+		m.option = option[0]
+	}
+	switch {
+	case dstValue.Kind() == reflect.Map && !dstValue.IsNil():
+		_, _, err := m.merge("", dstValue.Type(), dstValue, reflect.ValueOf(src))
+		return err
+
+	case dstValue.Kind() == reflect.Ptr && !dstValue.IsNil():
+		result, ok, err := m.merge("", dstValue.Elem().Type(), dstValue.Elem(), reflect.ValueOf(src))
+		if err != nil {
+			return err
+		}
+		if ok {
+			dstValue.Elem().Set(result)
+		}
+		return nil
+
+	default:
+		return gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`destination should be type of non-nil map or pointer, but given "%T"`,
+			dst,
+		)
+	}
+}
+
+// merger holds the option for merging.
+type merger struct {
+	option MergeOption
+}
+
+// strategy returns the merging strategy of `path`.
+func (m *merger) strategy(path string) MergeStrategy {
+	if strategy, ok := m.option.PathStrategy[path]; ok {
+		return strategy
+	}
+	return m.option.Strategy
+}
+
+// merge merges `src` into `dst` of type `dstType` and returns the merged value,
+// in which `dst` might be invalid if it does not exist.
+// The returned `ok` is false if `dst` should be kept unchanged.
+func (m *merger) merge(path string, dstType reflect.Type, dst, src reflect.Value) (result reflect.Value, ok bool, err error) {
+	src = diffIndirect(src)
+	if !src.IsValid() || (m.option.OmitEmpty && src.IsZero()) {
+		return dst, false, nil
+	}
+	switch dstType.Kind() {
+	case reflect.Ptr:
+		var elem reflect.Value
+		if dst.IsValid() && !dst.IsNil() {
+			elem = dst.Elem()
+		}
+		if result, ok, err = m.merge(path, dstType.Elem(), elem, src); err != nil || !ok {
+			return
+		}
+		ptr := reflect.New(dstType.Elem())
+		ptr.Elem().Set(result)
+		return ptr, true, nil
+
+	case reflect.Interface:
+		// It merges into the underlying value if they are both maps/structs or slices,
+		// or else the source value is used as it is.
+		elem := diffIndirect(dst)
+		if elem.IsValid() && getDiffKind(elem) != diffKindLeaf && getDiffKind(elem) == getDiffKind(src) {
+			if result, ok, err = m.merge(path, elem.Type(), elem, src); err != nil || !ok {
+				return
+			}
+			if result.Type().AssignableTo(dstType) {
+				return result, true, nil
+			}
+		}
+	}
+
+	var (
+		srcKind = getDiffKind(src)
+		dstKind = getDiffKind(reflect.New(dstType).Elem())
+	)
+	switch {
+	case srcKind == diffKindObject && dstKind == diffKindObject:
+		return m.mergeObject(path, dstType, dst, src)
+
+	case srcKind == diffKindList && dstKind == diffKindList && dstType.Kind() == reflect.Slice:
+		switch m.strategy(path) {
+		case MergeStrategyAppend, MergeStrategyUnion:
+			return m.mergeSlice(path, dstType, dst, src)
+		}
+	}
+	if result, err = mergeConvert(path, src, dstType); err != nil {
+		return
+	}
+	if m.strategy(path) == MergeStrategyError && dst.IsValid() && !dst.IsZero() && !diffLeafEqual(dst, result) {
+		return dst, false, gerror.NewCodef(
+			gcode.CodeInvalidParameter,
+			`merge conflict at path "%s": %v != %v`,
+			path, diffInterface(dst), diffInterface(result),
+		)
+	}
+	return result, true, nil
+}
+
+// mergeObject merges the map/struct `src` into map/struct `dst` by keys.
+func (m *merger) mergeObject(path string, dstType reflect.Type, dst, src reflect.Value) (reflect.Value, bool, error) {
+	srcKeys, srcValues := diffObjectEntries(src)
+	if dstType.Kind() == reflect.Map {
+		result := dst
+		if !result.IsValid() || result.IsNil() {
+			result = reflect.MakeMap(dstType)
+		}
+		for _, key := range srcKeys {
+			mapKey, err := mergeConvert(diffJoinKey(path, key), reflect.ValueOf(key), dstType.Key())
+			if err != nil {
+				return dst, false, err
+			}
+			value, ok, err := m.merge(diffJoinKey(path, key), dstType.Elem(), result.MapIndex(mapKey), srcValues[key])
+			if err != nil {
+				return dst, false, err
+			}
+			if ok {
+				result.SetMapIndex(mapKey, value)
+			}
+		}
+		return result, true, nil
+	}
+	result := reflect.New(dstType).Elem()
+	if dst.IsValid() {
+		result.Set(dst)
+	}
+	_, dstFields := diffObjectEntries(result)
+	for _, key := range srcKeys {
+		field, ok := dstFields[key]
+		if !ok || !field.CanSet() {
+			continue
+		}
+		value, ok, err := m.merge(diffJoinKey(path, key), field.Type(), field, srcValues[key])
+		if err != nil {
+			return dst, false, err
+		}
+		if ok {
+			field.Set(value)
+		}
+	}
+	return result, true, nil
+}
+
+// mergeSlice appends the items of slice/array `src` to slice `dst`.
+func (m *merger) mergeSlice(path string, dstType reflect.Type, dst, src reflect.Value) (reflect.Value, bool, error) {
+	var (
+		dstLen = 0
+		union  = m.strategy(path) == MergeStrategyUnion
+	)
+	if dst.IsValid() {
+		dstLen = dst.Len()
+	}
+	result := reflect.MakeSlice(dstType, 0, dstLen+src.Len())
+	if dstLen > 0 {
+		result = reflect.AppendSlice(result, dst)
+	}
+	for i := 0; i < src.Len(); i++ {
+		item, err := mergeConvert(path, src.Index(i), dstType.Elem())
+		if err != nil {
+			return dst, false, err
+		}
+		if union && mergeSliceContains(result, item) {
+			continue
+		}
+		result = reflect.Append(result, item)
+	}
+	return result, true, nil
+}
+
+func mergeSliceContains(slice, item reflect.Value) bool {
+	for i := 0; i < slice.Len(); i++ {
+		if diffLeafEqual(slice.Index(i), item) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeConvert converts `value` to type `toType`.
+func mergeConvert(path string, value reflect.Value, toType reflect.Type) (reflect.Value, error) {
+	if value.Type().AssignableTo(toType) {
+		return value, nil
+	}
+	converted := reflect.ValueOf(gconv.ConvertWithRefer(value.Interface(), reflect.New(toType).Elem()))
+	if converted.IsValid() && converted.Type().AssignableTo(toType) {
+		return converted, nil
+	}
+	return value, gerror.NewCodef(
+		gcode.CodeInvalidParameter,
+		`cannot merge value of type "%s" into type "%s" at path "%s"`,
+		value.Type(), toType, path,
+	)
+}
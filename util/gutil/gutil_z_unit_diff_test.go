@@ -0,0 +1,117 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+func Test_Diff_Map(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(len(gutil.Diff(nil, nil)), 0)
+		t.Assert(len(gutil.Diff(g.Map{"a": 1}, g.Map{"a": 1})), 0)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			a = g.Map{
+				"name":  "john",
+				"age":   18,
+				"roles": g.Slice{"admin", "user"},
+				"extra": g.Map{"city": "beijing", "zip": nil},
+			}
+			b = g.Map{
+				"name":  "john",
+				"age":   19,
+				"roles": g.Slice{"admin"},
+				"extra": g.Map{"city": "shanghai", "phone": "123"},
+			}
+			items = gutil.Diff(a, b)
+		)
+		t.Assert(items, []gutil.DiffItem{
+			{Path: "age", Type: gutil.DiffTypeChanged, Old: 18, New: 19},
+			{Path: "extra.city", Type: gutil.DiffTypeChanged, Old: "beijing", New: "shanghai"},
+			{Path: "extra.zip", Type: gutil.DiffTypeRemoved, Old: nil, New: nil},
+			{Path: "extra.phone", Type: gutil.DiffTypeAdded, Old: nil, New: "123"},
+			{Path: "roles[1]", Type: gutil.DiffTypeRemoved, Old: "user", New: nil},
+		})
+	})
+}
+
+func Test_Diff_Struct(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+	}
+	type Base struct {
+		Id int `json:"id"`
+	}
+	type User struct {
+		Base
+		Name      string     `json:"name"`
+		Password  string     `json:"-"`
+		Address   *Address   `json:"address"`
+		Tags      []string   `json:"tags"`
+		CreatedAt time.Time  `json:"created_at"`
+		Friends   []*Address `c:"friends"`
+	}
+	gtest.C(t, func(t *gtest.T) {
+		now := time.Now()
+		var (
+			a = &User{
+				Base:      Base{Id: 1},
+				Name:      "john",
+				Password:  "123",
+				Address:   &Address{City: "beijing"},
+				Tags:      []string{"a"},
+				CreatedAt: now,
+				Friends:   []*Address{{City: "a"}},
+			}
+			b = User{
+				Base:      Base{Id: 2},
+				Name:      "john",
+				Password:  "456",
+				Address:   nil,
+				Tags:      []string{"a", "b"},
+				CreatedAt: now.UTC(),
+				Friends:   []*Address{{City: "b"}},
+			}
+			items = gutil.Diff(a, b)
+		)
+		t.Assert(items, []gutil.DiffItem{
+			{Path: "id", Type: gutil.DiffTypeChanged, Old: 1, New: 2},
+			{Path: "address", Type: gutil.DiffTypeChanged, Old: Address{City: "beijing"}, New: nil},
+			{Path: "tags[1]", Type: gutil.DiffTypeAdded, Old: nil, New: "b"},
+			{Path: "friends[0].city", Type: gutil.DiffTypeChanged, Old: "a", New: "b"},
+		})
+	})
+	// Struct compared with map.
+	gtest.C(t, func(t *gtest.T) {
+		items := gutil.Diff(Address{City: "beijing"}, g.Map{"city": "beijing", "zip": 100000})
+		t.Assert(items, []gutil.DiffItem{
+			{Path: "zip", Type: gutil.DiffTypeAdded, Old: nil, New: 100000},
+		})
+	})
+}
+
+func Test_Diff_Leaf(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		t.Assert(gutil.Diff(1, 2), []gutil.DiffItem{
+			{Path: "", Type: gutil.DiffTypeChanged, Old: 1, New: 2},
+		})
+		t.Assert(gutil.Diff([]byte("a"), []byte("b")), []gutil.DiffItem{
+			{Path: "", Type: gutil.DiffTypeChanged, Old: []byte("a"), New: []byte("b")},
+		})
+		t.Assert(len(gutil.Diff([]int{1, 2}, [2]int{1, 2})), 0)
+		t.Assert(gutil.Diff(g.Map{"a": 1}, []int{1}), []gutil.DiffItem{
+			{Path: "", Type: gutil.DiffTypeChanged, Old: g.Map{"a": 1}, New: []int{1}},
+		})
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright GoFrame Author(https://goframe.org). All Rights Reserved.
+//
+// This Source Code Form is subject to the terms of the MIT License.
+// If a copy of the MIT was not distributed with this file,
+// You can obtain one at https://github.com/gogf/gf.
+
+package gutil_test
+
+import (
+	"testing"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/test/gtest"
+	"github.com/gogf/gf/v2/text/gstr"
+	"github.com/gogf/gf/v2/util/gutil"
+)
+
+func Test_Merge_Map(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dst = g.Map{
+				"server": g.Map{
+					"address": ":8000",
+					"hosts":   g.Slice{"a", "b"},
+				},
+				"debug": false,
+			}
+			src = g.Map{
+				"server": g.Map{
+					"hosts":   g.Slice{"b", "c"},
+					"timeout": 10,
+				},
+				"debug": true,
+			}
+		)
+		err := gutil.Merge(dst, src)
+		t.AssertNil(err)
+		t.Assert(dst, g.Map{
+			"server": g.Map{
+				"address": ":8000",
+				"hosts":   g.Slice{"b", "c"},
+				"timeout": 10,
+			},
+			"debug": true,
+		})
+	})
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dst = g.Map{"hosts": g.Slice{"a", "b"}, "tags": []string{"x"}}
+			src = g.Map{"hosts": g.Slice{"b", "c"}, "tags": []string{"x", "y"}}
+		)
+		err := gutil.Merge(dst, src, gutil.MergeOption{
+			Strategy: gutil.MergeStrategyAppend,
+			PathStrategy: map[string]gutil.MergeStrategy{
+				"tags": gutil.MergeStrategyUnion,
+			},
+		})
+		t.AssertNil(err)
+		t.Assert(dst["hosts"], g.Slice{"a", "b", "b", "c"})
+		t.Assert(dst["tags"], []string{"x", "y"})
+	})
+}
+
+func Test_Merge_Error(t *testing.T) {
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			dst = g.Map{"a": 1, "b": g.Map{"c": 2}}
+			opt = gutil.MergeOption{Strategy: gutil.MergeStrategyError}
+		)
+		t.AssertNil(gutil.Merge(dst, g.Map{"a": 1, "d": 4}, opt))
+		t.Assert(dst["d"], 4)
+		err := gutil.Merge(dst, g.Map{"b": g.Map{"c": 3}}, opt)
+		t.AssertNE(err, nil)
+		t.Assert(gstr.Contains(err.Error(), `"b.c"`), true)
+	})
+	gtest.C(t, func(t *gtest.T) {
+		t.AssertNE(gutil.Merge(nil, g.Map{}), nil)
+		t.AssertNE(gutil.Merge(g.Map(nil), g.Map{}), nil)
+	})
+}
+
+func Test_Merge_Struct(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+		Zip  string `json:"zip"`
+	}
+	type User struct {
+		Id      int      `json:"id"`
+		Name    string   `json:"name"`
+		Age     int      `json:"age"`
+		Address *Address `json:"address"`
+		Roles   []string `json:"roles"`
+	}
+	// PATCH with partial struct.
+	type UserPatch struct {
+		Name    *string  `json:"name"`
+		Age     *int     `json:"age"`
+		Address *Address `json:"address"`
+		Roles   []string `json:"roles"`
+	}
+	gtest.C(t, func(t *gtest.T) {
+		var (
+			name = "john"
+			user = &User{
+				Id:      1,
+				Name:    "jack",
+				Age:     18,
+				Address: &Address{City: "beijing", Zip: "100000"},
+				Roles:   []string{"user"},
+			}
+		)
+		err := gutil.Merge(user, UserPatch{
+			Name:    &name,
+			Address: &Address{City: "shanghai"},
+			Roles:   []string{"admin"},
+		}, gutil.MergeOption{
+			OmitEmpty: true,
+			PathStrategy: map[string]gutil.MergeStrategy{
+				"roles": gutil.MergeStrategyAppend,
+			},
+		})
+		t.AssertNil(err)
+		t.Assert(user.Id, 1)
+		t.Assert(user.Name, "john")
+		t.Assert(user.Age, 18)
+		t.Assert(user.Address, &Address{City: "shanghai", Zip: "100000"})
+		t.Assert(user.Roles, []string{"user", "admin"})
+	})
+	// Struct merged with map and converted.
+	gtest.C(t, func(t *gtest.T) {
+		user := &User{Id: 1}
+		err := gutil.Merge(user, g.Map{
+			"name":    "john",
+			"age":     "20",
+			"address": g.Map{"city": "beijing"},
+			"roles":   g.Slice{"a", "b"},
+		})
+		t.AssertNil(err)
+		t.Assert(user, &User{
+			Id:      1,
+			Name:    "john",
+			Age:     20,
+			Address: &Address{City: "beijing"},
+			Roles:   []string{"a", "b"},
+		})
+	})
+}